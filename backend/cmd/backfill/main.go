@@ -0,0 +1,153 @@
+// cmd/backfill/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/config"
+	"github.com/StratWarsAI/strategy-wars/internal/database"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+)
+
+func main() {
+	job := flag.String("job", "candles", "Backfill job to run: candles, creators, wallets, launches, outcomes")
+	from := flag.Int64("from", 0, "Start of the range as unix seconds (defaults to now minus -hours)")
+	to := flag.Int64("to", 0, "End of the range as unix seconds (defaults to now)")
+	hours := flag.Int("hours", 24, "Hours of history to process when -from is not set")
+	flag.Parse()
+
+	log := logger.New("backfill")
+	log.Info("Starting Strategy Wars backfill job %q", *job)
+
+	// Load configuration from .env
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Error("Failed to load configuration: %v", err)
+		os.Exit(1)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		Database: cfg.Database.Name,
+	}
+
+	db, err := database.Connect(dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to database: %v", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("Error closing db: %v", err)
+		}
+	}()
+
+	// Resolve the time range
+	end := *to
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+	start := *from
+	if start == 0 {
+		start = end - int64(*hours)*3600
+	}
+	if start >= end {
+		log.Error("Invalid range: from (%d) must be before to (%d)", start, end)
+		os.Exit(1)
+	}
+
+	// Cancel the job cleanly on Ctrl+C
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Info("Interrupted, stopping backfill...")
+		cancel()
+	}()
+
+	tokenRepo := repository.NewTokenRepository(db)
+	tradeRepo := repository.NewTradeRepository(db)
+
+	switch *job {
+	case "candles":
+		candleService := service.NewCandleService(
+			repository.NewCandleRepository(db),
+			tokenRepo,
+			tradeRepo,
+			nil,
+			logger.New("candle-service"),
+		)
+		written, err := candleService.Backfill(ctx, start, end)
+		if err != nil {
+			log.Error("Candle backfill failed after %d candles: %v", written, err)
+			os.Exit(1)
+		}
+		log.Info("Candle backfill complete: %d candles written", written)
+
+	case "creators":
+		creatorService := service.NewCreatorReputationService(
+			repository.NewCreatorProfileRepository(db),
+			logger.New("creator-service"),
+		)
+		written, err := creatorService.Backfill(ctx, start*1000)
+		if err != nil {
+			log.Error("Creator backfill failed after %d profiles: %v", written, err)
+			os.Exit(1)
+		}
+		log.Info("Creator backfill complete: %d profiles written", written)
+
+	case "wallets":
+		walletService := service.NewWalletAnalyticsService(
+			repository.NewWalletStatsRepository(db),
+			logger.New("wallet-service"),
+		)
+		scored, err := walletService.Refresh(ctx, start)
+		if err != nil {
+			log.Error("Wallet backfill failed after %d wallets: %v", scored, err)
+			os.Exit(1)
+		}
+		log.Info("Wallet backfill complete: %d wallets scored", scored)
+
+	case "launches":
+		launchService := service.NewLaunchAnalysisService(
+			repository.NewLaunchAnalysisRepository(db),
+			tokenRepo,
+			logger.New("launch-service"),
+		)
+		written, err := launchService.Backfill(ctx, start*1000)
+		if err != nil {
+			log.Error("Launch backfill failed after %d analyses: %v", written, err)
+			os.Exit(1)
+		}
+		log.Info("Launch backfill complete: %d analyses written", written)
+
+	case "outcomes":
+		outcomeService := service.NewTokenOutcomeService(
+			repository.NewTokenOutcomeRepository(db),
+			logger.New("outcome-service"),
+		)
+		written, err := outcomeService.Backfill(ctx, start*1000)
+		if err != nil {
+			log.Error("Outcome backfill failed after %d labels: %v", written, err)
+			os.Exit(1)
+		}
+		log.Info("Outcome backfill complete: %d labels written", written)
+
+	default:
+		log.Error("Unknown job %q", *job)
+		os.Exit(1)
+	}
+}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,6 +11,7 @@ import (
 	"github.com/StratWarsAI/strategy-wars/internal/config"
 	"github.com/StratWarsAI/strategy-wars/internal/database"
 	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
 	"github.com/StratWarsAI/strategy-wars/internal/service"
 	"github.com/StratWarsAI/strategy-wars/internal/websocket"
 )
@@ -51,6 +53,15 @@ func main() {
 	// Create data service
 	dataService := service.NewDataService(db, logger.New("data-service"))
 
+	// Record token lifecycle transitions from the feed
+	lifecycleService := service.NewTokenLifecycleService(
+		repository.NewTokenTransitionRepository(db),
+		repository.NewTokenRepository(db),
+		nil,
+		logger.New("token-lifecycle"),
+	)
+	dataService.SetLifecycleTracker(lifecycleService)
+
 	// Create and connect WebSocket client
 	wsClient := websocket.NewClient(cfg.WebSocket.URL, logger.New("websocket"))
 	if err := wsClient.Connect(); err != nil {
@@ -63,8 +74,26 @@ func main() {
 	go wsClient.Listen()
 	log.Info("WebSocket listener started")
 
+	// Monitor feed liveness and record gaps
+	monitorCtx, cancelMonitor := context.WithCancel(context.Background())
+	defer cancelMonitor()
+	feedMonitor := service.NewFeedMonitor(
+		service.FeedSourcePumpFun,
+		wsClient,
+		wsClient.StatusChannel,
+		repository.NewFeedMetricRepository(db),
+		repository.NewDataGapRepository(db),
+		time.Duration(cfg.Feed.StaleThresholdSec)*time.Second,
+		time.Duration(cfg.Feed.MetricsPeriodSec)*time.Second,
+		logger.New("feed-monitor"),
+	)
+	feedMonitor.Start(monitorCtx)
+
+	// Abandon tokens that stop trading
+	lifecycleService.StartSweeper(monitorCtx)
+
 	// Process incoming WebSocket messages
-	go processWebSocketMessages(wsClient, dataService, log)
+	go processWebSocketMessages(monitorCtx, wsClient, dataService, log)
 
 	// Setup signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -73,15 +102,19 @@ func main() {
 	log.Info("Data collector is now running. Press Ctrl+C to exit")
 	<-sigChan
 	log.Info("Shutting down...")
+	cancelMonitor()
 
 	// Allow some time for pending operations to complete
 	time.Sleep(2 * time.Second)
 }
 
-// processWebSocketMessages processes messages from WebSocket channels
-func processWebSocketMessages(wsClient *websocket.Client, dataService *service.DataService, log *logger.Logger) {
+// processWebSocketMessages processes messages from WebSocket channels until ctx is cancelled
+func processWebSocketMessages(ctx context.Context, wsClient *websocket.Client, dataService *service.DataService, log *logger.Logger) {
 	for {
 		select {
+		case <-ctx.Done():
+			return
+
 		case tokenData := <-wsClient.TokenChannel:
 			// Process token data
 			if err := dataService.ProcessTokenData(tokenData); err != nil {
@@ -93,6 +126,12 @@ func processWebSocketMessages(wsClient *websocket.Client, dataService *service.D
 			if err := dataService.ProcessTradeData(tradeData); err != nil {
 				log.Error("Failed to process trade data: %v", err)
 			}
+
+		case update := <-wsClient.UpdateChannel:
+			// Process completion, king of the hill and other token updates
+			if err := dataService.ProcessTokenUpdate(update.Event, update.Data); err != nil {
+				log.Error("Failed to process %s event: %v", update.Event, err)
+			}
 		}
 	}
 }
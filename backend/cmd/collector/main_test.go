@@ -0,0 +1,180 @@
+// cmd/collector/main_test.go
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket/wstest"
+	"github.com/stretchr/testify/assert"
+)
+
+var tokenColumns = []string{
+	"id", "mint_address", "creator_address", "name", "symbol", "image_url", "twitter_url", "website_url",
+	"telegram_url", "metadata_url", "created_timestamp", "market_cap", "usd_market_cap", "completed",
+	"king_of_the_hill_timestamp", "created_at",
+}
+
+// waitForExpectations polls until every database expectation has been met
+func waitForExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	deadline := time.Now().Add(2 * time.Second)
+	for mock.ExpectationsWereMet() != nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestCollectorPipeline runs the collector against a fake upstream, through DataService
+// and the repositories down to SQL, across malformed frames and a dropped connection
+func TestCollectorPipeline(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+
+	client := websocket.NewClient(upstream.URL, logger.New("test"))
+	client.SetHeartbeat(50*time.Millisecond, 300*time.Millisecond)
+	client.SetReconnectDelay(20 * time.Millisecond)
+	assert.NoError(t, client.Connect())
+	defer client.Close()
+	go client.Listen()
+	assert.True(t, upstream.WaitForHandshakes(1, 2*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go processWebSocketMessages(ctx, client, service.NewDataService(db, logger.New("test")), logger.New("test"))
+
+	mint, creator := "Mint111pump", "Creator111"
+	created := int64(1700000000000)
+	tokenRow := func(marketCap, usdMarketCap float64) *sqlmock.Rows {
+		return sqlmock.NewRows(tokenColumns).AddRow(
+			1, mint, creator, "Test Token", "TEST", "", "", "", "", "", created, marketCap, usdMarketCap, false, 0, time.Now(),
+		)
+	}
+
+	// A new token is inserted
+	mock.ExpectQuery("INSERT INTO tokens").
+		WithArgs(mint, creator, "Test Token", "TEST", "", "", "", "", "", created, 28.0, 4200.0, false, int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	_, err = upstream.Emit("tokenCreated", map[string]interface{}{
+		"mint": mint, "creator": creator, "name": "Test Token", "symbol": "TEST",
+		"created_timestamp": created, "market_cap": 28.0, "usd_market_cap": 4200.0,
+	})
+	assert.NoError(t, err)
+	waitForExpectations(t, mock)
+
+	// Malformed frames are dropped without touching the database or the connection
+	for _, frame := range []string{`42["tradeCreated",{broken`, `42["tradeCreated","oops"]`} {
+		_, err = upstream.SendRaw(frame)
+		assert.NoError(t, err)
+	}
+
+	// A trade on the known token is stored against its ID
+	mock.ExpectQuery("SELECT (.+) FROM tokens").WithArgs(mint).WillReturnRows(tokenRow(28, 4200))
+	mock.ExpectQuery("INSERT INTO trades").
+		WithArgs(int64(1), "Sig1", 1e9, 3.4e13, true, "Buyer1", int64(1700000005)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	_, err = upstream.Emit("tradeCreated", map[string]interface{}{
+		"mint": mint, "signature": "Sig1", "sol_amount": 1e9, "token_amount": 3.4e13, "is_buy": true,
+		"user": "Buyer1", "timestamp": 1700000005, "market_cap": 28.0, "usd_market_cap": 4200.0,
+	})
+	assert.NoError(t, err)
+	waitForExpectations(t, mock)
+	assert.Equal(t, 1, upstream.Handshakes())
+
+	// After a dropped connection the client reconnects and trades keep flowing; the newer
+	// market cap they carry is saved on the token
+	upstream.DropConnections()
+	assert.True(t, upstream.WaitForHandshakes(2, 2*time.Second))
+	assert.True(t, upstream.WaitForConnections(1, 2*time.Second))
+
+	mock.ExpectQuery("SELECT (.+) FROM tokens").WithArgs(mint).WillReturnRows(tokenRow(28, 4200))
+	mock.ExpectQuery("INSERT INTO tokens").
+		WithArgs(mint, creator, "Test Token", "TEST", "", "", "", "", "", created, 35.0, 5250.0, false, int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO trades").
+		WithArgs(int64(1), "Sig2", 2e9, 5e13, true, "Buyer2", int64(1700000009)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	_, err = upstream.Emit("tradeCreated", map[string]interface{}{
+		"mint": mint, "signature": "Sig2", "sol_amount": 2e9, "token_amount": 5e13, "is_buy": true,
+		"user": "Buyer2", "timestamp": 1700000009, "market_cap": 35.0, "usd_market_cap": 5250.0,
+	})
+	assert.NoError(t, err)
+	waitForExpectations(t, mock)
+
+	// A replayed trade hits the signature conflict and is skipped
+	mock.ExpectQuery("SELECT (.+) FROM tokens").WithArgs(mint).WillReturnRows(tokenRow(35, 5250))
+	mock.ExpectQuery("INSERT INTO trades").
+		WithArgs(int64(1), "Sig2", 2e9, 5e13, true, "Buyer2", int64(1700000009)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = upstream.Emit("tradeCreated", map[string]interface{}{
+		"mint": mint, "signature": "Sig2", "sol_amount": 2e9, "token_amount": 5e13, "is_buy": true,
+		"user": "Buyer2", "timestamp": 1700000009, "market_cap": 35.0, "usd_market_cap": 5250.0,
+	})
+	assert.NoError(t, err)
+	waitForExpectations(t, mock)
+
+	stats := client.Stats()
+	assert.Equal(t, uint64(1), stats.Tokens)
+	assert.Equal(t, uint64(3), stats.Trades)
+	assert.Equal(t, uint64(1), stats.Reconnects)
+}
+
+// TestCollectorPipelineSyntheticMarket streams a synthetic market through the collector and
+// checks every token and trade reaches the database
+func TestCollectorPipelineSyntheticMarket(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+
+	client := websocket.NewClient(upstream.URL, logger.New("test"))
+	assert.NoError(t, client.Connect())
+	defer client.Close()
+	go client.Listen()
+	assert.True(t, upstream.WaitForHandshakes(1, 2*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go processWebSocketMessages(ctx, client, service.NewDataService(db, logger.New("test")), logger.New("test"))
+
+	market := service.NewSyntheticMarket(3, service.SyntheticRegimes["cold"], time.Unix(1700000000, 0))
+	tokens, trades := 0, 0
+	for tokens == 0 || trades < 5 {
+		for _, event := range market.Tick() {
+			switch event.Event {
+			case service.SyntheticEventTokenCreated:
+				tokens++
+				mock.ExpectQuery("INSERT INTO tokens").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tokens))
+			case service.SyntheticEventTradeCreated:
+				trades++
+				mock.ExpectQuery("SELECT (.+) FROM tokens").WithArgs(event.Data["mint"]).
+					WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow(
+						tokens, event.Data["mint"], event.Data["creator"], event.Data["name"], event.Data["symbol"],
+						"", "", "", "", "", 0, event.Data["market_cap"], event.Data["usd_market_cap"], false, 0, time.Now(),
+					))
+				mock.ExpectQuery("INSERT INTO trades").WithArgs(
+					sqlmock.AnyArg(), event.Data["signature"], sqlmock.AnyArg(), sqlmock.AnyArg(),
+					sqlmock.AnyArg(), event.Data["user"], sqlmock.AnyArg(),
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(trades))
+			}
+
+			frame, err := event.Frame()
+			assert.NoError(t, err)
+			_, err = upstream.SendRaw(string(frame))
+			assert.NoError(t, err)
+		}
+	}
+
+	waitForExpectations(t, mock)
+}
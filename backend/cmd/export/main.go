@@ -0,0 +1,107 @@
+// cmd/export/main.go
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/config"
+	"github.com/StratWarsAI/strategy-wars/internal/database"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+)
+
+func main() {
+	out := flag.String("out", "dataset.csv", "CSV file to write the dataset to")
+	from := flag.Int64("from", 0, "Start of the snapshot range as unix seconds (defaults to now minus -hours)")
+	to := flag.Int64("to", 0, "End of the snapshot range as unix seconds (defaults to now)")
+	hours := flag.Int("hours", 24*7, "Hours of snapshots to export when -from is not set")
+	flag.Parse()
+
+	log := logger.New("export")
+	log.Info("Starting Strategy Wars dataset export")
+
+	// Load configuration from .env
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Error("Failed to load configuration: %v", err)
+		os.Exit(1)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		Database: cfg.Database.Name,
+	}
+
+	db, err := database.Connect(dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to database: %v", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("Error closing db: %v", err)
+		}
+	}()
+
+	// Resolve the time range
+	end := *to
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+	start := *from
+	if start == 0 {
+		start = end - int64(*hours)*3600
+	}
+	if start >= end {
+		log.Error("Invalid range: from (%d) must be before to (%d)", start, end)
+		os.Exit(1)
+	}
+
+	// Cancel the export cleanly on Ctrl+C
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Info("Interrupted, stopping export...")
+		cancel()
+	}()
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Error("Failed to create %s: %v", *out, err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Error("Error closing %s: %v", *out, err)
+		}
+	}()
+	buffered := bufio.NewWriter(file)
+
+	outcomeService := service.NewTokenOutcomeService(
+		repository.NewTokenOutcomeRepository(db),
+		logger.New("outcome-service"),
+	)
+	written, err := outcomeService.ExportDataset(ctx, buffered, time.Unix(start, 0), time.Unix(end, 0))
+	if flushErr := buffered.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		log.Error("Dataset export failed after %d rows: %v", written, err)
+		os.Exit(1)
+	}
+	log.Info("Dataset export complete: %d rows written to %s", written, *out)
+}
@@ -0,0 +1,281 @@
+// cmd/synthetic/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/config"
+	"github.com/StratWarsAI/strategy-wars/internal/database"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gorilla/websocket"
+)
+
+func main() {
+	mode := flag.String("mode", "socket", "\"socket\" serves the feed for the collector, \"direct\" writes to the database")
+	listen := flag.String("listen", ":8765", "Address the Socket.IO feed is served on in socket mode")
+	seed := flag.Int64("seed", 1, "Random seed; the same seed, regime and start produce the same market")
+	regimeName := flag.String("regime", "normal", "Market regime: "+strings.Join(regimeNames(), ", "))
+	start := flag.Int64("start", 0, "Simulated start time as unix seconds (defaults to now)")
+	duration := flag.Duration("duration", time.Hour, "Simulated time to generate, 0 to run until interrupted")
+	speed := flag.Float64("speed", 1, "Simulated seconds per real second; 0 runs as fast as possible in direct mode")
+	flag.Parse()
+
+	log := logger.New("synthetic")
+	log.Info("Starting Strategy Wars synthetic market")
+
+	regime, ok := service.SyntheticRegimes[*regimeName]
+	if !ok {
+		log.Error("Unknown regime %q: use one of %s", *regimeName, strings.Join(regimeNames(), ", "))
+		os.Exit(1)
+	}
+	if *speed < 0 || (*speed == 0 && *mode != "direct") {
+		log.Error("Invalid speed %v: must be positive, or 0 in direct mode", *speed)
+		os.Exit(1)
+	}
+
+	startTime := time.Now()
+	if *start > 0 {
+		startTime = time.Unix(*start, 0)
+	}
+	market := service.NewSyntheticMarket(*seed, regime, startTime)
+
+	// Stop cleanly on Ctrl+C
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Info("Interrupted, stopping synthetic market...")
+		cancel()
+	}()
+
+	switch *mode {
+	case "socket":
+		serveFeed(ctx, market, *listen, *duration, *speed, log)
+	case "direct":
+		writeDirect(ctx, market, *duration, *speed, log)
+	default:
+		log.Error("Unknown mode %q: use socket or direct", *mode)
+		os.Exit(1)
+	}
+}
+
+// regimeNames returns the names of the built-in regimes
+func regimeNames() []string {
+	names := make([]string, 0, len(service.SyntheticRegimes))
+	for name := range service.SyntheticRegimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// run ticks the market until ctx is cancelled or duration of simulated time has passed,
+// pacing ticks by speed, and hands each second's events to emit
+func run(ctx context.Context, market *service.SyntheticMarket, duration time.Duration, speed float64, emit func([]service.SyntheticEvent)) int {
+	var pace <-chan time.Time
+	if speed > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / speed))
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+
+	count := 0
+	for tick := int64(0); duration == 0 || tick < int64(duration/time.Second); tick++ {
+		if pace != nil {
+			select {
+			case <-ctx.Done():
+				return count
+			case <-pace:
+			}
+		} else if ctx.Err() != nil {
+			return count
+		}
+
+		events := market.Tick()
+		emit(events)
+		count += len(events)
+	}
+	return count
+}
+
+// writeDirect processes the market's events through DataService as the collector would
+func writeDirect(ctx context.Context, market *service.SyntheticMarket, duration time.Duration, speed float64, log *logger.Logger) {
+	// Load configuration from .env
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Error("Failed to load configuration: %v", err)
+		os.Exit(1)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		Database: cfg.Database.Name,
+	}
+
+	db, err := database.Connect(dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to database: %v", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("Error closing db: %v", err)
+		}
+	}()
+
+	dataService := service.NewDataService(db, logger.New("data-service"))
+	lifecycleService := service.NewTokenLifecycleService(
+		repository.NewTokenTransitionRepository(db),
+		repository.NewTokenRepository(db),
+		nil,
+		logger.New("token-lifecycle"),
+	)
+	dataService.SetLifecycleTracker(lifecycleService)
+
+	count := run(ctx, market, duration, speed, func(events []service.SyntheticEvent) {
+		for _, event := range events {
+			var err error
+			switch event.Event {
+			case service.SyntheticEventTokenCreated:
+				err = dataService.ProcessTokenData(event.Data)
+			case service.SyntheticEventTradeCreated:
+				err = dataService.ProcessTradeData(event.Data)
+			default:
+				err = dataService.ProcessTokenUpdate(event.Event, event.Data)
+			}
+			if err != nil {
+				log.Error("Failed to process %s event: %v", event.Event, err)
+			}
+		}
+	})
+	log.Info("Synthetic market wrote %d events up to %s", count, market.Now().Format(time.RFC3339))
+}
+
+// feedServer fans Socket.IO frames out to every connected collector
+type feedServer struct {
+	mu        sync.Mutex
+	clients   map[*websocket.Conn]chan []byte
+	connected chan struct{}
+	once      sync.Once
+	log       *logger.Logger
+}
+
+// serveFeed serves the market as a pump.fun style Socket.IO feed. The market starts when
+// the first collector connects so it sees every event from the first launch.
+func serveFeed(ctx context.Context, market *service.SyntheticMarket, listen string, duration time.Duration, speed float64, log *logger.Logger) {
+	feed := &feedServer{
+		clients:   make(map[*websocket.Conn]chan []byte),
+		connected: make(chan struct{}),
+		log:       log,
+	}
+
+	server := &http.Server{Addr: listen, Handler: http.HandlerFunc(feed.handle)}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("Feed server error: %v", err)
+			os.Exit(1)
+		}
+	}()
+	defer func() {
+		if err := server.Close(); err != nil {
+			log.Error("Error closing feed server: %v", err)
+		}
+	}()
+
+	log.Info("Serving synthetic feed on ws://%s, set WEBSOCKET_URL to it and start the collector", listen)
+	select {
+	case <-ctx.Done():
+		return
+	case <-feed.connected:
+	}
+
+	count := run(ctx, market, duration, speed, func(events []service.SyntheticEvent) {
+		for _, event := range events {
+			frame, err := event.Frame()
+			if err != nil {
+				log.Error("%v", err)
+				continue
+			}
+			feed.broadcast(frame)
+		}
+	})
+	log.Info("Synthetic market sent %d events up to %s", count, market.Now().Format(time.RFC3339))
+}
+
+// handle upgrades a collector connection and streams frames to it
+func (f *feedServer) handle(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		f.log.Error("Failed to upgrade feed connection: %v", err)
+		return
+	}
+
+	send := make(chan []byte, 1024)
+	f.mu.Lock()
+	f.clients[conn] = send
+	f.mu.Unlock()
+	f.log.Info("Collector connected from %s", r.RemoteAddr)
+	f.once.Do(func() { close(f.connected) })
+
+	// Drain the handshake and pings; the default ping handler answers with pongs
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				f.remove(conn)
+				return
+			}
+		}
+	}()
+
+	for frame := range send {
+		if err := conn.WriteMessage(websocket.TextMessage, frame); err != nil {
+			f.remove(conn)
+			break
+		}
+	}
+	if err := conn.Close(); err != nil {
+		f.log.Debug("Error closing feed connection: %v", err)
+	}
+}
+
+// broadcast queues a frame for every connected collector, dropping it for slow ones
+func (f *feedServer) broadcast(frame []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for conn, send := range f.clients {
+		select {
+		case send <- frame:
+		default:
+			f.log.Warn("Feed client %s is too slow, dropping frame", conn.RemoteAddr())
+		}
+	}
+}
+
+// remove disconnects a collector
+func (f *feedServer) remove(conn *websocket.Conn) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if send, ok := f.clients[conn]; ok {
+		close(send)
+		delete(f.clients, conn)
+		f.log.Info("Collector disconnected")
+	}
+}
@@ -0,0 +1,113 @@
+// cmd/train/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/config"
+	"github.com/StratWarsAI/strategy-wars/internal/database"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+)
+
+func main() {
+	name := flag.String("name", "p2x_5m", "Name of the entry model; each run saves the next version")
+	horizon := flag.String("horizon", "5m", "Outcome horizon to predict: 1m, 5m or 30m")
+	minReturnPct := flag.Float64("min-return-pct", 100, "Max return within the horizon that counts as a hit (100 = 2x)")
+	from := flag.Int64("from", 0, "Start of the snapshot range as unix seconds (defaults to now minus -hours)")
+	to := flag.Int64("to", 0, "End of the snapshot range as unix seconds (defaults to now)")
+	hours := flag.Int("hours", 24*7, "Hours of snapshots to train on when -from is not set")
+	flag.Parse()
+
+	log := logger.New("train")
+	log.Info("Starting Strategy Wars entry model training")
+
+	horizonMin, err := strconv.Atoi(strings.TrimSuffix(*horizon, "m"))
+	if err != nil {
+		log.Error("Invalid horizon %q: use 1m, 5m or 30m", *horizon)
+		os.Exit(1)
+	}
+
+	// Load configuration from .env
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Error("Failed to load configuration: %v", err)
+		os.Exit(1)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		Database: cfg.Database.Name,
+	}
+
+	db, err := database.Connect(dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to database: %v", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("Error closing db: %v", err)
+		}
+	}()
+
+	// Resolve the time range
+	end := *to
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+	start := *from
+	if start == 0 {
+		start = end - int64(*hours)*3600
+	}
+	if start >= end {
+		log.Error("Invalid range: from (%d) must be before to (%d)", start, end)
+		os.Exit(1)
+	}
+
+	// Cancel training cleanly on Ctrl+C
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Info("Interrupted, stopping training...")
+		cancel()
+	}()
+
+	modelService := service.NewEntryModelService(
+		repository.NewEntryModelRepository(db),
+		repository.NewTokenOutcomeRepository(db),
+		logger.New("entry-model-service"),
+	)
+	model, err := modelService.Train(ctx, service.EntryModelTrainingOptions{
+		Name:         *name,
+		HorizonMin:   horizonMin,
+		MinReturnPct: *minReturnPct,
+		From:         time.Unix(start, 0),
+		To:           time.Unix(end, 0),
+	})
+	if err != nil {
+		log.Error("Training failed: %v", err)
+		os.Exit(1)
+	}
+	log.Info("Saved %s: validation accuracy %.3f, log loss %.4f on %.0f rows",
+		model.Tag(),
+		model.Metrics["validation_accuracy"],
+		model.Metrics["validation_log_loss"],
+		model.Metrics["validation_rows"],
+	)
+}
@@ -0,0 +1,29 @@
+// internal/api/dto/market_dto.go
+package dto
+
+import "github.com/StratWarsAI/strategy-wars/internal/models"
+
+// CandleUpdateEvent is pushed to clients subscribed to a candle topic
+type CandleUpdateEvent struct {
+	Type      string         `json:"type"`
+	Topic     string         `json:"topic"`
+	Interval  string         `json:"interval"`
+	Timestamp int64          `json:"timestamp"`
+	Candle    *models.Candle `json:"candle"`
+}
+
+// CandleSeriesResponse is returned by the candles endpoint
+type CandleSeriesResponse struct {
+	Mint     string           `json:"mint"`
+	Interval string           `json:"interval"`
+	From     int64            `json:"from"`
+	To       int64            `json:"to"`
+	Candles  []*models.Candle `json:"candles"`
+}
+
+// TokenTransitionEvent is pushed to clients subscribed to a lifecycle topic
+type TokenTransitionEvent struct {
+	Type       string                  `json:"type"`
+	Topic      string                  `json:"topic"`
+	Transition *models.TokenTransition `json:"transition"`
+}
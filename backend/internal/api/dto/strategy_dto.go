@@ -59,6 +59,7 @@ type StrategyResponseDto struct {
 	ComplexityScore int              `json:"complexity_score"`
 	RiskScore       int              `json:"risk_score"`
 	AIEnhanced      bool             `json:"ai_enhanced"`
+	Source          string           `json:"source"`
 	Metrics         *StrategyMetricsDto `json:"metrics,omitempty"`
 }
 
@@ -83,6 +84,7 @@ func NewStrategyResponseDto(strategy *models.Strategy) StrategyResponseDto {
 		ComplexityScore: strategy.ComplexityScore,
 		RiskScore:       strategy.RiskScore,
 		AIEnhanced:      strategy.AIEnhanced,
+		Source:          strategy.Source,
 	}
 
 	// Check if there are any metrics for this strategy in the config
@@ -166,6 +168,7 @@ func (dto StrategyCreateDto) ToModel(userID int64) *models.Strategy {
 		IsPublic:   dto.IsPublic,
 		Tags:       dto.Tags,
 		AIEnhanced: dto.AIEnhanced,
+		Source:     models.StrategySourceUser,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
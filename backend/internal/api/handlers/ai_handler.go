@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/StratWarsAI/strategy-wars/internal/api/dto"
+	"github.com/StratWarsAI/strategy-wars/internal/models"
 	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
 	"github.com/StratWarsAI/strategy-wars/internal/service"
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +16,8 @@ import (
 type AIHandler struct {
 	aiService           *service.AIService
 	performanceAnalyzer *service.AIPerformanceAnalyzer
+	usageService        *service.AIUsageService
+	admissionService    *service.StrategyAdmissionService
 	logger              *logger.Logger
 }
 
@@ -22,11 +25,15 @@ type AIHandler struct {
 func NewAIHandler(
 	aiService *service.AIService,
 	performanceAnalyzer *service.AIPerformanceAnalyzer,
+	usageService *service.AIUsageService,
+	admissionService *service.StrategyAdmissionService,
 	logger *logger.Logger,
 ) *AIHandler {
 	return &AIHandler{
 		aiService:           aiService,
 		performanceAnalyzer: performanceAnalyzer,
+		usageService:        usageService,
+		admissionService:    admissionService,
 		logger:              logger,
 	}
 }
@@ -54,10 +61,12 @@ func (h *AIHandler) GetAIAnalysis(c *fiber.Ctx) error {
 
 	// Create the response
 	response := fiber.Map{
-		"strategy_id":   report.StrategyID,
-		"strategy_name": report.StrategyName,
-		"analysis":      report.Analysis,
-		"rating":        report.Rating,
+		"strategy_id":     report.StrategyID,
+		"strategy_name":   report.StrategyName,
+		"analysis":        report.Analysis,
+		"rating":          report.Rating,
+		"prompt_template": report.PromptTemplate,
+		"prompt_version":  report.PromptVersion,
 		"metrics": fiber.Map{
 			"roi":              report.ROI,
 			"win_rate":         report.WinRate,
@@ -97,8 +106,67 @@ func (h *AIHandler) GetAIAnalysis(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// GetAIUsage returns today's and this month's LLM usage per purpose, budgets and the
+// circuit breaker state
+func (h *AIHandler) GetAIUsage(c *fiber.Ctx) error {
+	report, err := h.usageService.GetUsageReport()
+	if err != nil {
+		h.logger.Error("Error getting AI usage: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fmt.Sprintf("Error getting AI usage: %v", err),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// GetPrompts returns every version of the strategy, analysis and draft prompt templates
+func (h *AIHandler) GetPrompts(c *fiber.Ctx) error {
+	prompts := h.aiService.Prompts()
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"strategy":    prompts.List(service.PromptKindStrategy),
+		"analysis":    prompts.List(service.PromptKindAnalysis),
+		"draft":       prompts.List(service.PromptKindDraft),
+		"post_mortem": prompts.List(service.PromptKindPostMortem),
+	})
+}
+
+// GetCandidates returns the most recent generated strategies that went through admission,
+// optionally only those with the given status, with their backtests
+func (h *AIHandler) GetCandidates(c *fiber.Ctx) error {
+	status := c.Query("status")
+	switch status {
+	case "", models.AdmissionAdmitted, models.AdmissionRejected, models.AdmissionMerged, models.AdmissionPending:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid status, must be admitted, rejected, merged or pending",
+		})
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+
+	candidates, err := h.admissionService.GetCandidates(status, limit)
+	if err != nil {
+		h.logger.Error("Error getting strategy candidates: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fmt.Sprintf("Error getting strategy candidates: %v", err),
+		})
+	}
+	if candidates == nil {
+		candidates = []*models.StrategyCandidate{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(candidates)
+}
+
 // RegisterRoutes registers all AI routes
 func (h *AIHandler) RegisterRoutes(app fiber.Router) {
 	ai := app.Group("/ai")
 	ai.Get("/analysis/:id", h.GetAIAnalysis)
+	ai.Get("/usage", h.GetAIUsage)
+	ai.Get("/prompts", h.GetPrompts)
+	ai.Get("/candidates", h.GetCandidates)
 }
\ No newline at end of file
@@ -0,0 +1,82 @@
+// internal/api/handlers/creator_handler.go
+package handlers
+
+import (
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreatorHandler handles creator reputation requests
+type CreatorHandler struct {
+	creatorService *service.CreatorReputationService
+	logger         *logger.Logger
+}
+
+// NewCreatorHandler creates a new creator handler
+func NewCreatorHandler(creatorService *service.CreatorReputationService, logger *logger.Logger) *CreatorHandler {
+	return &CreatorHandler{
+		creatorService: creatorService,
+		logger:         logger,
+	}
+}
+
+// GetCreators returns profiled creators ordered by reputation
+// Query params: order (best, worst), min_launches, limit
+func (h *CreatorHandler) GetCreators(c *fiber.Ctx) error {
+	order := c.Query("order", "best")
+	if order != "best" && order != "worst" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid order, expected best or worst",
+		})
+	}
+
+	minLaunches := c.QueryInt("min_launches", 1)
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+
+	profiles, err := h.creatorService.GetTopCreators(minLaunches, order == "worst", limit)
+	if err != nil {
+		h.logger.Error("Error getting creator profiles: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get creator profiles",
+		})
+	}
+
+	return c.JSON(profiles)
+}
+
+// GetCreator returns the reputation profile of a creator wallet
+func (h *CreatorHandler) GetCreator(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Creator address is required",
+		})
+	}
+
+	profile, err := h.creatorService.GetProfile(address)
+	if err != nil {
+		h.logger.Error("Error getting creator profile for %s: %v", address, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get creator profile",
+		})
+	}
+
+	if profile == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Creator not found",
+		})
+	}
+
+	return c.JSON(profile)
+}
+
+// RegisterRoutes registers all creator routes
+func (h *CreatorHandler) RegisterRoutes(app fiber.Router) {
+	creators := app.Group("/creators")
+	creators.Get("/", h.GetCreators)
+	creators.Get("/:address", h.GetCreator)
+}
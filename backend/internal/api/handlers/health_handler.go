@@ -0,0 +1,41 @@
+// internal/api/handlers/health_handler.go
+package handlers
+
+import (
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthHandler handles data health requests
+type HealthHandler struct {
+	feedHealthService *service.FeedHealthService
+	logger            *logger.Logger
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(feedHealthService *service.FeedHealthService, logger *logger.Logger) *HealthHandler {
+	return &HealthHandler{
+		feedHealthService: feedHealthService,
+		logger:            logger,
+	}
+}
+
+// GetFeedHealth returns the upstream feed status, message rates and recent data gaps
+func (h *HealthHandler) GetFeedHealth(c *fiber.Ctx) error {
+	health, err := h.feedHealthService.GetFeedHealth()
+	if err != nil {
+		h.logger.Error("Error getting feed health: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get feed health",
+		})
+	}
+
+	return c.JSON(health)
+}
+
+// RegisterRoutes registers all health routes
+func (h *HealthHandler) RegisterRoutes(app fiber.Router) {
+	health := app.Group("/health")
+	health.Get("/feed", h.GetFeedHealth)
+}
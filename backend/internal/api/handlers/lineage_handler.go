@@ -0,0 +1,60 @@
+// internal/api/handlers/lineage_handler.go
+package handlers
+
+import (
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LineageHandler handles strategy lineage requests
+type LineageHandler struct {
+	lineageService *service.LineageService
+	logger         *logger.Logger
+}
+
+// NewLineageHandler creates a new lineage handler
+func NewLineageHandler(lineageService *service.LineageService, logger *logger.Logger) *LineageHandler {
+	return &LineageHandler{
+		lineageService: lineageService,
+		logger:         logger,
+	}
+}
+
+// GetLineage returns a strategy's ancestors and descendants with the performance change
+// across each derivation
+// Query params: depth (derivations to follow in each direction, 1-10)
+func (h *LineageHandler) GetLineage(c *fiber.Ctx) error {
+	strategyID, err := c.ParamsInt("id")
+	if err != nil || strategyID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid strategy ID",
+		})
+	}
+
+	depth := c.QueryInt("depth", 5)
+	if depth <= 0 || depth > 10 {
+		depth = 5
+	}
+
+	lineage, err := h.lineageService.GetLineage(int64(strategyID), depth)
+	if err != nil {
+		h.logger.Error("Error getting lineage of strategy %d: %v", strategyID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get strategy lineage",
+		})
+	}
+
+	if lineage == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Strategy not found",
+		})
+	}
+
+	return c.JSON(lineage)
+}
+
+// RegisterRoutes registers all lineage routes
+func (h *LineageHandler) RegisterRoutes(app fiber.Router) {
+	app.Get("/strategies/:id/lineage", h.GetLineage)
+}
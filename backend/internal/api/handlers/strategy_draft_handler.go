@@ -0,0 +1,175 @@
+// internal/api/handlers/strategy_draft_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StrategyDraftHandler handles strategies drafted from natural-language descriptions. A
+// draft stays open while it is revised, each revision bumping its revision number and
+// adding the instruction to its history, until it is saved as a strategy; saved drafts
+// record their strategy ID and can no longer be revised or saved. AI responses that fail
+// validation are sent back for repair, and are answered with 422 and the problems found if
+// they never pass.
+type StrategyDraftHandler struct {
+	draftService *service.StrategyDraftService
+	logger       *logger.Logger
+}
+
+// NewStrategyDraftHandler creates a new strategy draft handler
+func NewStrategyDraftHandler(draftService *service.StrategyDraftService, logger *logger.Logger) *StrategyDraftHandler {
+	return &StrategyDraftHandler{
+		draftService: draftService,
+		logger:       logger,
+	}
+}
+
+// CreateDraft drafts a strategy from a description such as "buy tokens with 5+ unique
+// buyers in 30s, exit at 2x or 90s" and answers 201 with the draft as revision 1.
+// POST /strategies/draft
+func (h *StrategyDraftHandler) CreateDraft(c *fiber.Ctx) error {
+	var body struct {
+		Description string `json:"description"`
+	}
+	if err := c.BodyParser(&body); err != nil || strings.TrimSpace(body.Description) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "A strategy description is required",
+		})
+	}
+
+	draft, err := h.draftService.CreateDraft(body.Description)
+	if err != nil {
+		return h.draftError(c, "drafting strategy", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(draft)
+}
+
+// GetDraft returns a strategy draft, or 404 if it doesn't exist.
+// GET /strategies/draft/:id
+func (h *StrategyDraftHandler) GetDraft(c *fiber.Ctx) error {
+	draftID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid draft ID",
+		})
+	}
+
+	draft, err := h.draftService.GetDraft(int64(draftID))
+	if err != nil {
+		return h.draftError(c, "getting strategy draft", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(draft)
+}
+
+// ReviseDraft applies an instruction such as "use a 20% stop loss" to an open draft and
+// answers with its next revision, or 409 if the draft has been saved.
+// POST /strategies/draft/:id/revise
+func (h *StrategyDraftHandler) ReviseDraft(c *fiber.Ctx) error {
+	draftID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid draft ID",
+		})
+	}
+
+	var body struct {
+		Instruction string `json:"instruction"`
+	}
+	if err := c.BodyParser(&body); err != nil || strings.TrimSpace(body.Instruction) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "A revision instruction is required",
+		})
+	}
+
+	draft, err := h.draftService.ReviseDraft(int64(draftID), body.Instruction)
+	if err != nil {
+		return h.draftError(c, "revising strategy draft", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(draft)
+}
+
+// SaveDraft saves an open draft as a strategy and answers 201 with its ID. A near-duplicate
+// of a saved strategy is answered with 409, or 200 and merged when merging, and leaves the
+// draft open; an already saved draft is answered with 409.
+// POST /strategies/draft/:id/save
+func (h *StrategyDraftHandler) SaveDraft(c *fiber.Ctx) error {
+	draftID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid draft ID",
+		})
+	}
+
+	strategyID, err := h.draftService.SaveDraft(int64(draftID))
+	if duplicate, ok := err.(*service.DuplicateStrategyError); ok && duplicate.Merged {
+		h.logger.Info("Merged strategy draft %d: %v", draftID, err)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"merged":      true,
+			"message":     err.Error(),
+			"strategy_id": duplicate.ExistingID,
+			"similarity":  duplicate.Similarity,
+		})
+	}
+	if duplicate, ok := err.(*service.DuplicateStrategyError); ok {
+		h.logger.Info("Rejected strategy draft %d: %v", draftID, err)
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":                err.Error(),
+			"existing_strategy_id": duplicate.ExistingID,
+			"similarity":           duplicate.Similarity,
+		})
+	}
+	if err != nil {
+		return h.draftError(c, "saving strategy draft", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success":     true,
+		"message":     fmt.Sprintf("Strategy created with ID: %d", strategyID),
+		"strategy_id": strategyID,
+	})
+}
+
+// draftError responds to a failed draft operation: missing drafts are 404, saved ones 409
+// and AI responses that never passed validation 422 with the problems found
+func (h *StrategyDraftHandler) draftError(c *fiber.Ctx, action string, err error) error {
+	var validationErr *service.DraftValidationError
+	switch {
+	case errors.Is(err, service.ErrDraftNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Strategy draft not found",
+		})
+	case errors.Is(err, service.ErrDraftSaved):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.As(err, &validationErr):
+		h.logger.Warn("Error %s: %v", action, err)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":    "The AI could not produce a valid strategy from this description",
+			"problems": validationErr.Problems,
+		})
+	}
+
+	h.logger.Error("Error %s: %v", action, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fmt.Sprintf("Error %s: %v", action, err),
+	})
+}
+
+// RegisterRoutes registers all strategy draft routes
+func (h *StrategyDraftHandler) RegisterRoutes(app fiber.Router) {
+	drafts := app.Group("/strategies/draft")
+	drafts.Post("/", h.CreateDraft)
+	drafts.Get("/:id", h.GetDraft)
+	drafts.Post("/:id/revise", h.ReviseDraft)
+	drafts.Post("/:id/save", h.SaveDraft)
+}
@@ -84,6 +84,23 @@ func (h *StrategyHandler) Create(c *fiber.Ctx) error {
 
 	// Create strategy
 	id, err := h.service.CreateStrategy(strategy)
+	if duplicate, ok := err.(*service.DuplicateStrategyError); ok && duplicate.Merged {
+		h.logger.Info("Merged strategy %s: %v", strategy.Name, err)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"merged":      true,
+			"message":     err.Error(),
+			"strategy_id": duplicate.ExistingID,
+			"similarity":  duplicate.Similarity,
+		})
+	}
+	if duplicate, ok := err.(*service.DuplicateStrategyError); ok {
+		h.logger.Info("Rejected strategy %s: %v", strategy.Name, err)
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":                err.Error(),
+			"existing_strategy_id": duplicate.ExistingID,
+			"similarity":           duplicate.Similarity,
+		})
+	}
 	if err != nil {
 		h.logger.Error("Error creating strategy: %v", err)
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
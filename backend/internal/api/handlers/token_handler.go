@@ -0,0 +1,239 @@
+// internal/api/handlers/token_handler.go
+package handlers
+
+import (
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/api/dto"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultCandleLimit = 500
+	maxCandleLimit     = 5000
+)
+
+// TokenHandler handles token market data requests
+type TokenHandler struct {
+	candleService *service.CandleService
+	holderLedger  *service.HolderLedgerService
+	launchService *service.LaunchAnalysisService
+	anomalies     *service.TradeAnomalyService
+	lifecycle     *service.TokenLifecycleService
+	features      *service.FeatureStore
+	logger        *logger.Logger
+}
+
+// NewTokenHandler creates a new token handler
+func NewTokenHandler(
+	candleService *service.CandleService,
+	holderLedger *service.HolderLedgerService,
+	launchService *service.LaunchAnalysisService,
+	anomalies *service.TradeAnomalyService,
+	lifecycle *service.TokenLifecycleService,
+	features *service.FeatureStore,
+	logger *logger.Logger,
+) *TokenHandler {
+	return &TokenHandler{
+		candleService: candleService,
+		holderLedger:  holderLedger,
+		launchService: launchService,
+		anomalies:     anomalies,
+		lifecycle:     lifecycle,
+		features:      features,
+		logger:        logger,
+	}
+}
+
+// GetCandles returns OHLCV candles for a token
+// Query params: interval (1s, 15s, 1m, 5m), from and to as unix seconds, limit
+func (h *TokenHandler) GetCandles(c *fiber.Ctx) error {
+	mint := c.Params("mint")
+	if mint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mint address is required",
+		})
+	}
+
+	interval := c.Query("interval", "1m")
+	intervalSec, ok := service.CandleIntervals[interval]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid interval, expected one of 1s, 15s, 1m, 5m",
+		})
+	}
+
+	limit := c.QueryInt("limit", defaultCandleLimit)
+	if limit <= 0 || limit > maxCandleLimit {
+		limit = defaultCandleLimit
+	}
+
+	to := int64(c.QueryInt("to", int(time.Now().Unix())))
+	from := int64(c.QueryInt("from", int(to-int64(intervalSec*limit))))
+	if from > to {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "from must be before to",
+		})
+	}
+
+	candles, err := h.candleService.GetCandles(mint, interval, from, to, limit)
+	if err != nil {
+		if err.Error() == "token not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Token not found",
+			})
+		}
+		h.logger.Error("Error getting candles for %s: %v", mint, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get candles",
+		})
+	}
+
+	return c.JSON(dto.CandleSeriesResponse{
+		Mint:     mint,
+		Interval: interval,
+		From:     from,
+		To:       to,
+		Candles:  candles,
+	})
+}
+
+// GetHolders returns the holder distribution of a token: unique holders, top-10
+// concentration and the creator's current holding
+func (h *TokenHandler) GetHolders(c *fiber.Ctx) error {
+	mint := c.Params("mint")
+	if mint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mint address is required",
+		})
+	}
+
+	snapshot, err := h.holderLedger.GetHolderSnapshotByMint(mint)
+	if err != nil {
+		if err.Error() == "token not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Token not found",
+			})
+		}
+		h.logger.Error("Error getting holders for %s: %v", mint, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get holders",
+		})
+	}
+
+	return c.JSON(snapshot)
+}
+
+// GetLaunch returns the launch analysis of a token: early buyers labeled as creator,
+// bundle, sniper or fresh wallets and the share of supply they bought
+func (h *TokenHandler) GetLaunch(c *fiber.Ctx) error {
+	mint := c.Params("mint")
+	if mint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mint address is required",
+		})
+	}
+
+	analysis, err := h.launchService.GetLaunchAnalysisByMint(mint)
+	if err != nil {
+		if err.Error() == "token not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Token not found",
+			})
+		}
+		h.logger.Error("Error getting launch analysis for %s: %v", mint, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get launch analysis",
+		})
+	}
+
+	return c.JSON(analysis)
+}
+
+// GetAnomalies returns the wash-trading and bot activity detected in a token's trades
+func (h *TokenHandler) GetAnomalies(c *fiber.Ctx) error {
+	mint := c.Params("mint")
+	if mint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mint address is required",
+		})
+	}
+
+	anomalies, err := h.anomalies.GetTokenAnomaliesByMint(mint)
+	if err != nil {
+		if err.Error() == "token not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Token not found",
+			})
+		}
+		h.logger.Error("Error getting trade anomalies for %s: %v", mint, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get trade anomalies",
+		})
+	}
+
+	return c.JSON(anomalies)
+}
+
+// GetLifecycle returns a token's lifecycle state and its timestamped transitions
+func (h *TokenHandler) GetLifecycle(c *fiber.Ctx) error {
+	mint := c.Params("mint")
+	if mint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mint address is required",
+		})
+	}
+
+	lifecycle, err := h.lifecycle.GetLifecycleByMint(mint)
+	if err != nil {
+		if err.Error() == "token not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Token not found",
+			})
+		}
+		h.logger.Error("Error getting lifecycle for %s: %v", mint, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get token lifecycle",
+		})
+	}
+
+	return c.JSON(lifecycle)
+}
+
+// GetFeatures returns a token's rolling-window trading features
+func (h *TokenHandler) GetFeatures(c *fiber.Ctx) error {
+	mint := c.Params("mint")
+	if mint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mint address is required",
+		})
+	}
+
+	features, err := h.features.GetTokenFeaturesByMint(mint)
+	if err != nil {
+		if err.Error() == "token not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Token not found",
+			})
+		}
+		h.logger.Error("Error getting features for %s: %v", mint, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get token features",
+		})
+	}
+
+	return c.JSON(features)
+}
+
+// RegisterRoutes registers all token routes
+func (h *TokenHandler) RegisterRoutes(app fiber.Router) {
+	tokens := app.Group("/tokens")
+	tokens.Get("/:mint/candles", h.GetCandles)
+	tokens.Get("/:mint/holders", h.GetHolders)
+	tokens.Get("/:mint/launch", h.GetLaunch)
+	tokens.Get("/:mint/anomalies", h.GetAnomalies)
+	tokens.Get("/:mint/lifecycle", h.GetLifecycle)
+	tokens.Get("/:mint/features", h.GetFeatures)
+}
@@ -0,0 +1,139 @@
+// internal/api/handlers/trade_post_mortem_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxPostMortemBatch is the most trades one batch request may diagnose
+const maxPostMortemBatch = 100
+
+// TradePostMortemHandler handles AI post-mortems of closed simulated trades
+type TradePostMortemHandler struct {
+	postMortemService *service.TradePostMortemService
+	logger            *logger.Logger
+}
+
+// NewTradePostMortemHandler creates a new trade post-mortem handler
+func NewTradePostMortemHandler(postMortemService *service.TradePostMortemService, logger *logger.Logger) *TradePostMortemHandler {
+	return &TradePostMortemHandler{
+		postMortemService: postMortemService,
+		logger:            logger,
+	}
+}
+
+// AnalyzeTrade returns the post-mortem of a closed trade, generating it if the trade has
+// none yet or ?refresh=true is given
+func (h *TradePostMortemHandler) AnalyzeTrade(c *fiber.Ctx) error {
+	tradeID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	trade, err := h.postMortemService.AnalyzeTrade(int64(tradeID), c.QueryBool("refresh"))
+	if err != nil {
+		return h.postMortemError(c, "diagnosing trade", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"trade_id":    trade.ID,
+		"strategy_id": trade.StrategyID,
+		"trade":       trade,
+	})
+}
+
+// AnalyzeStrategy diagnoses the closed trades of a strategy that have no post-mortem yet,
+// largest losses first, up to ?limit trades
+func (h *TradePostMortemHandler) AnalyzeStrategy(c *fiber.Ctx) error {
+	strategyID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid strategy ID",
+		})
+	}
+
+	limit := c.QueryInt("limit", service.DefaultPostMortemBatch)
+	if limit < 1 || limit > maxPostMortemBatch {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Limit must be between 1 and %d", maxPostMortemBatch),
+		})
+	}
+
+	batch, err := h.postMortemService.AnalyzeStrategy(int64(strategyID), limit)
+	if err != nil {
+		return h.postMortemError(c, "diagnosing strategy trades", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(batch)
+}
+
+// GetStrategySummary returns how often each diagnosis was made across a strategy's trades
+func (h *TradePostMortemHandler) GetStrategySummary(c *fiber.Ctx) error {
+	strategyID, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid strategy ID",
+		})
+	}
+
+	summary, err := h.postMortemService.Summarize(int64(strategyID))
+	if err != nil {
+		return h.postMortemError(c, "summarizing trade diagnoses", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(summary)
+}
+
+// postMortemError responds to a failed post-mortem: missing trades and strategies are 404,
+// open trades 409, a spent AI budget 429, an open circuit breaker 503 and AI responses that
+// never passed validation 422
+func (h *TradePostMortemHandler) postMortemError(c *fiber.Ctx, action string, err error) error {
+	var validationErr *service.PostMortemValidationError
+	switch {
+	case errors.Is(err, service.ErrTradeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Simulated trade not found",
+		})
+	case errors.Is(err, service.ErrStrategyNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Strategy not found",
+		})
+	case errors.Is(err, service.ErrTradeNotClosed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAIBudgetExceeded):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAICircuitOpen):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.As(err, &validationErr):
+		h.logger.Warn("Error %s: %v", action, err)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":    "The AI could not produce a valid post-mortem",
+			"problems": validationErr.Problems,
+		})
+	}
+
+	h.logger.Error("Error %s: %v", action, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fmt.Sprintf("Error %s: %v", action, err),
+	})
+}
+
+// RegisterRoutes registers all trade post-mortem routes
+func (h *TradePostMortemHandler) RegisterRoutes(app fiber.Router) {
+	app.Post("/simulated-trades/:id/post-mortem", h.AnalyzeTrade)
+	app.Get("/strategies/:id/post-mortems", h.GetStrategySummary)
+	app.Post("/strategies/:id/post-mortems", h.AnalyzeStrategy)
+}
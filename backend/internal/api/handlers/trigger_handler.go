@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/StratWarsAI/strategy-wars/internal/models"
 	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
 	"github.com/StratWarsAI/strategy-wars/internal/service"
 	"github.com/gofiber/fiber/v2"
@@ -37,9 +38,10 @@ func NewTriggerHandler(
 func (h *TriggerHandler) TriggerStrategyCreation(c *fiber.Ctx) error {
 	h.logger.Info("Manual trigger for strategy creation received")
 
-	// Get prompt from request body if provided, otherwise use default
+	// Get prompt and template from request body if provided, otherwise use defaults
 	var body struct {
-		Prompt string `json:"prompt"`
+		Prompt   string `json:"prompt"`
+		Template string `json:"template"` // Strategy prompt template as id or id@vN
 	}
 
 	if err := c.BodyParser(&body); err != nil {
@@ -61,13 +63,16 @@ func (h *TriggerHandler) TriggerStrategyCreation(c *fiber.Ctx) error {
 		})
 	}
 
-	// Create metadata with top strategies
-	metadata := map[string]interface{}{
-		"top_strategies": topStrategies,
+	if body.Template == "" {
+		body.Template = service.DefaultStrategyPrompt
 	}
 
 	// Generate new strategy
-	strategy, err := h.aiService.GenerateStrategy(body.Prompt, metadata)
+	strategy, err := h.aiService.GenerateStrategyFromTemplate(
+		models.AIPurposeManualGeneration,
+		body.Template,
+		service.NewStrategyPromptInput(body.Prompt, topStrategies),
+	)
 	if err != nil {
 		h.logger.Error("Error generating strategy: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -75,8 +80,34 @@ func (h *TriggerHandler) TriggerStrategyCreation(c *fiber.Ctx) error {
 		})
 	}
 
-	// Save the strategy
-	id, err := h.aiService.SaveStrategy(strategy)
+	// Save the strategy if it passes admission
+	id, err := h.aiService.AdmitStrategy(strategy, models.AIPurposeManualGeneration)
+	if rejected, ok := err.(*service.StrategyRejectedError); ok {
+		h.logger.Info("Generated strategy was not admitted: %v", err)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":        "Generated strategy was not admitted",
+			"candidate_id": rejected.CandidateID,
+			"status":       rejected.Status,
+			"reasons":      rejected.Reasons,
+		})
+	}
+	if duplicate, ok := err.(*service.DuplicateStrategyError); ok && duplicate.Merged {
+		h.logger.Info("Merged generated strategy %s: %v", strategy.Name, err)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"merged":      true,
+			"message":     err.Error(),
+			"strategy_id": duplicate.ExistingID,
+			"similarity":  duplicate.Similarity,
+		})
+	}
+	if duplicate, ok := err.(*service.DuplicateStrategyError); ok {
+		h.logger.Info("Rejected generated strategy %s: %v", strategy.Name, err)
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":                err.Error(),
+			"existing_strategy_id": duplicate.ExistingID,
+			"similarity":           duplicate.Similarity,
+		})
+	}
 	if err != nil {
 		h.logger.Error("Error saving generated strategy: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -0,0 +1,97 @@
+// internal/api/handlers/trigger_handler_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generatedStrategyJSON = `{
+	"name": "Momentum Chaser",
+	"description": "Buys tokens with rapid buy activity",
+	"marketCapThreshold": 7000,
+	"minBuysForEntry": 3,
+	"entryTimeWindowSec": 300,
+	"takeProfitPct": 50,
+	"stopLossPct": 30,
+	"maxHoldTimeSec": 600,
+	"fixedPositionSizeSol": 0.5,
+	"initialBalance": 10,
+	"entrySignalType": "unique_buyers",
+	"minUniqueBuyers": 5,
+	"exitOnCreatorSell": true
+}`
+
+// newTriggerApp serves the trigger routes with an AI service that generates
+// generatedStrategyJSON, without admission, next to a saved strategy with the same config
+func newTriggerApp(t *testing.T, action string) (*fiber.App, int64) {
+	log := logger.New("test")
+	strategyRepo := memory.NewStrategyRepository(memory.NewStore())
+
+	existingID, err := strategyRepo.Save(&models.Strategy{
+		Name:     "Saved Momentum",
+		IsPublic: true,
+		Tags:     []string{"momentum"},
+		Config: models.JSONB{
+			"marketCapThreshold": 7000.0,
+			"minBuysForEntry":    3.0,
+			"entryTimeWindowSec": 300.0,
+			"takeProfitPct":      50.0,
+			"stopLossPct":        30.0,
+			"maxHoldTimeSec":     600.0,
+			"entrySignalType":    "unique_buyers",
+			"minUniqueBuyers":    5.0,
+			"exitOnCreatorSell":  true,
+		},
+	})
+	require.NoError(t, err)
+
+	aiService := service.NewAIService(service.NewMockLLMProvider(generatedStrategyJSON), strategyRepo, log)
+	aiService.SetDiversity(service.NewStrategyDiversityService(strategyRepo, service.DiversityConfig{
+		DuplicateThreshold: 0.9,
+		DuplicateAction:    action,
+	}, log))
+
+	app := fiber.New()
+	NewTriggerHandler(aiService, nil, nil, log).RegisterRoutes(app)
+	return app, existingID
+}
+
+func postCreateStrategy(t *testing.T, app *fiber.App) (int, map[string]interface{}) {
+	resp, err := app.Test(httptest.NewRequest("POST", "/trigger/create-strategy", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestTriggerStrategyCreationRejectsNearDuplicates(t *testing.T) {
+	app, existingID := newTriggerApp(t, service.DuplicateActionReject)
+
+	status, body := postCreateStrategy(t, app)
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, float64(existingID), body["existing_strategy_id"])
+	assert.InDelta(t, 1.0, body["similarity"], 0.1)
+	assert.Contains(t, body["error"], "near-duplicate")
+}
+
+func TestTriggerStrategyCreationReportsMerges(t *testing.T) {
+	app, existingID := newTriggerApp(t, service.DuplicateActionMerge)
+
+	status, body := postCreateStrategy(t, app)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, true, body["merged"])
+	assert.Equal(t, float64(existingID), body["strategy_id"])
+	assert.InDelta(t, 1.0, body["similarity"], 0.1)
+}
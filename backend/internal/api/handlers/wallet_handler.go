@@ -0,0 +1,73 @@
+// internal/api/handlers/wallet_handler.go
+package handlers
+
+import (
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WalletHandler handles wallet analytics requests
+type WalletHandler struct {
+	walletService *service.WalletAnalyticsService
+	logger        *logger.Logger
+}
+
+// NewWalletHandler creates a new wallet handler
+func NewWalletHandler(walletService *service.WalletAnalyticsService, logger *logger.Logger) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+		logger:        logger,
+	}
+}
+
+// GetSmartWallets returns the ranked smart-wallet list
+func (h *WalletHandler) GetSmartWallets(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+
+	wallets, err := h.walletService.GetSmartWallets(limit)
+	if err != nil {
+		h.logger.Error("Error getting smart wallets: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get smart wallets",
+		})
+	}
+
+	return c.JSON(wallets)
+}
+
+// GetWallet returns the realized performance of a wallet
+func (h *WalletHandler) GetWallet(c *fiber.Ctx) error {
+	address := c.Params("address")
+	if address == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Wallet address is required",
+		})
+	}
+
+	stats, err := h.walletService.GetWalletStats(address)
+	if err != nil {
+		h.logger.Error("Error getting wallet stats for %s: %v", address, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get wallet stats",
+		})
+	}
+
+	if stats == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Wallet not found",
+		})
+	}
+
+	return c.JSON(stats)
+}
+
+// RegisterRoutes registers all wallet routes
+func (h *WalletHandler) RegisterRoutes(app fiber.Router) {
+	wallets := app.Group("/wallets")
+	wallets.Get("/smart", h.GetSmartWallets)
+	wallets.Get("/:address", h.GetWallet)
+}
@@ -34,6 +34,25 @@ type Server struct {
 	triggerHandler      *handlers.TriggerHandler
 	simulationHandler   *handlers.SimulationHandler
 	performanceAnalyzer *service.AIPerformanceAnalyzer
+	tradeFeed           *service.TradeFeed
+	candleService       *service.CandleService
+	holderLedger        *service.HolderLedgerService
+	launchService       *service.LaunchAnalysisService
+	anomalyService      *service.TradeAnomalyService
+	lifecycleService    *service.TokenLifecycleService
+	featureStore        *service.FeatureStore
+	outcomeService      *service.TokenOutcomeService
+	tokenHandler        *handlers.TokenHandler
+	healthHandler       *handlers.HealthHandler
+	creatorService      *service.CreatorReputationService
+	creatorHandler      *handlers.CreatorHandler
+	walletService       *service.WalletAnalyticsService
+	walletHandler       *handlers.WalletHandler
+	lineageHandler      *handlers.LineageHandler
+	draftHandler        *handlers.StrategyDraftHandler
+	postMortemHandler   *handlers.TradePostMortemHandler
+	backgroundCtx       context.Context
+	backgroundCancel    context.CancelFunc
 }
 
 // NewServer creates a new API server
@@ -67,23 +86,160 @@ func NewServer(port int, db *sql.DB, cfg *config.Config, logger *logger.Logger)
 	simulationEventRepo := repository.NewSimulationEventRepository(db)
 	simulationResultRepo := repository.NewSimulationResultRepository(db)
 	dashboardRepo := repository.NewDashboardRepository(db)
+	candleRepo := repository.NewCandleRepository(db)
+	feedMetricRepo := repository.NewFeedMetricRepository(db)
+	dataGapRepo := repository.NewDataGapRepository(db)
+	creatorProfileRepo := repository.NewCreatorProfileRepository(db)
+	walletStatsRepo := repository.NewWalletStatsRepository(db)
+	launchAnalysisRepo := repository.NewLaunchAnalysisRepository(db)
+	tokenAnomalyRepo := repository.NewTokenAnomalyRepository(db)
+	tokenFeatureRepo := repository.NewTokenFeatureRepository(db)
+	tokenOutcomeRepo := repository.NewTokenOutcomeRepository(db)
+	tokenTransitionRepo := repository.NewTokenTransitionRepository(db)
+	entryModelRepo := repository.NewEntryModelRepository(db)
+	strategyGenerationRepo := repository.NewStrategyGenerationRepository(db)
+	aiGenerationFailureRepo := repository.NewAIGenerationFailureRepository(db)
+	aiUsageRepo := repository.NewAIUsageRepository(db)
+	strategyCandidateRepo := repository.NewStrategyCandidateRepository(db)
+	strategyDraftRepo := repository.NewStrategyDraftRepository(db)
 
 	// Create basic services
 	dataService := service.NewDataService(db, logger)
 	strategyService := service.NewStrategyService(strategyRepo, strategyMetricRepo, logger)
+
+	// Near-duplicate strategies are rejected or merged when saved
+	diversityService := service.NewStrategyDiversityService(strategyRepo, service.DiversityConfig{
+		DuplicateThreshold: cfg.Diversity.DuplicateThreshold,
+		DuplicateAction:    cfg.Diversity.DuplicateAction,
+		ClusterSimilarity:  cfg.Diversity.ClusterSimilarity,
+		MaxPerCluster:      cfg.Diversity.MaxPerCluster,
+	}, logger)
+	strategyService.(*service.StrategyService).SetDiversity(diversityService)
 	dashboardService := service.NewDashboardService(dashboardRepo, simulatedTradeRepo, logger)
 
+	// Create market data services fed from the trades table
+	tradeFeed := service.NewTradeFeed(tradeRepo, logger)
+	candleService := service.NewCandleService(candleRepo, tokenRepo, tradeRepo, wsHub, logger)
+	holderLedger := service.NewHolderLedgerService(tradeRepo, tokenRepo, logger)
+	anomalyService := service.NewTradeAnomalyService(tokenAnomalyRepo, tradeRepo, tokenRepo, logger)
+	tradeFeed.Subscribe(candleService)
+	tradeFeed.Subscribe(holderLedger)
+	tradeFeed.Subscribe(anomalyService)
+	featureStore := service.NewFeatureStore(tokenFeatureRepo, tradeRepo, tokenRepo, logger)
+	tradeFeed.Subscribe(featureStore)
+	outcomeService := service.NewTokenOutcomeService(tokenOutcomeRepo, logger)
+	entryModelService := service.NewEntryModelService(entryModelRepo, tokenOutcomeRepo, logger)
+	lifecycleService := service.NewTokenLifecycleService(tokenTransitionRepo, tokenRepo, wsHub, logger)
+
+	// Feed health is reported by the collector through feed_metrics and data_gaps
+	feedHealthService := service.NewFeedHealthService(
+		feedMetricRepo,
+		dataGapRepo,
+		time.Duration(cfg.Feed.StaleThresholdSec)*time.Second,
+		time.Duration(cfg.Feed.MetricsPeriodSec)*time.Second,
+		logger,
+	)
+	creatorService := service.NewCreatorReputationService(creatorProfileRepo, logger)
+	walletService := service.NewWalletAnalyticsService(walletStatsRepo, logger)
+	launchService := service.NewLaunchAnalysisService(launchAnalysisRepo, tokenRepo, logger)
+	lineageService := service.NewLineageService(strategyGenerationRepo, strategyRepo, strategyMetricRepo, logger)
+
 	// Create handlers
 	strategyHandler := handlers.NewStrategyHandler(strategyService, logger, strategyMetricRepo)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService, logger)
+	tokenHandler := handlers.NewTokenHandler(candleService, holderLedger, launchService, anomalyService, lifecycleService, featureStore, logger)
+	healthHandler := handlers.NewHealthHandler(feedHealthService, logger)
+	creatorHandler := handlers.NewCreatorHandler(creatorService, logger)
+	walletHandler := handlers.NewWalletHandler(walletService, logger)
+	lineageHandler := handlers.NewLineageHandler(lineageService, logger)
 
 	// Create AI and automation services
+	llmProvider, err := service.NewLLMProvider(service.LLMProviderConfig{
+		Provider: cfg.AI.Provider,
+		APIKey:   cfg.AI.APIKey,
+		Endpoint: cfg.AI.Endpoint,
+		Model:    cfg.AI.Model,
+		Timeout:  time.Duration(cfg.AI.TimeoutSec) * time.Second,
+	})
+	if err != nil {
+		logger.Error("Error creating LLM provider, falling back to mock: %v", err)
+		llmProvider = service.NewMockLLMProvider()
+	}
+	aiUsageService := service.NewAIUsageService(llmProvider, aiUsageRepo, service.AIUsageConfig{
+		DailyTokenBudgets:   cfg.AI.DailyTokenBudgets,
+		MonthlyTokenBudgets: cfg.AI.MonthlyTokenBudgets,
+		BreakerThreshold:    cfg.AI.BreakerThreshold,
+		BreakerCooldown:     time.Duration(cfg.AI.BreakerCooldownSec) * time.Second,
+		InputCostPer1K:      cfg.AI.InputCostPer1K,
+		OutputCostPer1K:     cfg.AI.OutputCostPer1K,
+	}, logger)
 	aiService := service.NewAIService(
-		cfg.AI.APIKey,
-		cfg.AI.Endpoint,
+		aiUsageService,
 		strategyRepo,
 		logger,
 	)
+	aiService.SetLineageRecorder(lineageService)
+	aiService.SetFailureRepository(aiGenerationFailureRepo)
+	aiService.SetAutoGenerationPrompts(cfg.AI.AutoGenerationPrompts)
+
+	// Generated strategies are backtested before they go public and get simulated
+	backtester := service.NewBacktester(tradeRepo, tokenRepo)
+	backtester.SetDataGapRepository(dataGapRepo)
+	admissionService := service.NewStrategyAdmissionService(
+		backtester,
+		strategyRepo,
+		strategyCandidateRepo,
+		service.AdmissionConfig{
+			LookbackHours:  cfg.Admission.LookbackHours,
+			MinTrades:      cfg.Admission.MinTrades,
+			MaxDrawdownPct: cfg.Admission.MaxDrawdownPct,
+			MinExpectancy:  cfg.Admission.MinExpectancy,
+		},
+		logger,
+	)
+	admissionService.SetDiversity(diversityService)
+	aiService.SetDiversity(diversityService)
+	if cfg.Admission.Enabled {
+		aiService.SetAdmission(admissionService)
+	}
+
+	// Evolution and optimization prompts get feedback on why recent strategies lost money
+	if cfg.AI.FeedbackTokenBudget > 0 {
+		aiService.SetFeedbackBuilder(service.NewFeedbackContextBuilder(
+			strategyRepo,
+			simulatedTradeRepo,
+			simulationRunRepo,
+			simulationResultRepo,
+			cfg.AI.FeedbackTokenBudget,
+		))
+	}
+
+	promptLibrary, err := service.LoadPromptLibrary(cfg.AI.PromptDir)
+	if err != nil {
+		logger.Error("Error loading prompt templates from %s, using built-in templates: %v", cfg.AI.PromptDir, err)
+		promptLibrary = service.DefaultPromptLibrary()
+	}
+	aiService.SetPromptLibrary(promptLibrary)
+	for _, ref := range append(append([]string{}, cfg.AI.AutoGenerationPrompts...), cfg.Automation.GenerationPrompts...) {
+		if _, err := promptLibrary.Get(service.PromptKindStrategy, ref); err != nil {
+			logger.Error("Configured strategy prompt is unavailable: %v", err)
+		}
+	}
+	if _, err := promptLibrary.Get(service.PromptKindAnalysis, cfg.Automation.AnalysisPrompt); err != nil {
+		logger.Error("Configured analysis prompt is unavailable: %v", err)
+	}
+
+	// Strategies drafted from natural-language descriptions are saved like user strategies
+	draftService := service.NewStrategyDraftService(aiService, strategyDraftRepo, strategyService, logger)
+	draftHandler := handlers.NewStrategyDraftHandler(draftService, logger)
+
+	// Post-mortems of closed trades see the same token analyses the simulation trades on
+	postMortemService := service.NewTradePostMortemService(aiService, simulatedTradeRepo, strategyRepo, tokenRepo, tokenOutcomeRepo, logger)
+	postMortemService.SetFeatureRepository(tokenFeatureRepo)
+	postMortemService.SetSimulationEventRepository(simulationEventRepo)
+	postMortemService.SetLaunchAnalysisProvider(launchService)
+	postMortemService.SetTradeAnomalyProvider(anomalyService)
+	postMortemHandler := handlers.NewTradePostMortemHandler(postMortemService, logger)
 
 	simulationService := service.NewSimulationService(
 		db,
@@ -96,6 +252,15 @@ func NewServer(port int, db *sql.DB, cfg *config.Config, logger *logger.Logger)
 		wsHub,
 		logger,
 	)
+	simulationService.SetFeedHealthChecker(feedHealthService)
+	simulationService.SetCreatorReputationProvider(creatorService)
+	simulationService.SetSmartWalletProvider(walletService)
+	simulationService.SetHolderSnapshotProvider(holderLedger)
+	simulationService.SetLaunchAnalysisProvider(launchService)
+	simulationService.SetTradeAnomalyProvider(anomalyService)
+	simulationService.SetTokenLifecycleProvider(lifecycleService)
+	simulationService.SetTokenFeatureProvider(featureStore)
+	simulationService.SetEntryModelProvider(entryModelService)
 
 	performanceAnalyzer := service.NewAIPerformanceAnalyzer(
 		strategyRepo,
@@ -120,6 +285,21 @@ func NewServer(port int, db *sql.DB, cfg *config.Config, logger *logger.Logger)
 		cfg, // Pass the configuration object
 	)
 
+	// The genetic algorithm evolves strategies from backtests, without an external API
+	evolutionConfig := service.DefaultEvolutionConfig()
+	evolutionConfig.PopulationSize = cfg.Evolution.PopulationSize
+	evolutionConfig.Generations = cfg.Evolution.Generations
+	evolutionConfig.Offspring = cfg.Evolution.Offspring
+	evolutionConfig.LookbackHours = cfg.Evolution.LookbackHours
+	evolutionConfig.Seed = cfg.Evolution.Seed
+	evolutionEngine := service.NewEvolutionEngine(backtester, strategyRepo, lineageService, evolutionConfig, logger)
+	evolutionEngine.SetDiversity(diversityService)
+	if cfg.Admission.Enabled {
+		evolutionEngine.SetAdmission(admissionService)
+	}
+	automationService.SetEvolutionEngine(evolutionEngine)
+	automationService.SetDiversity(diversityService)
+
 	// Create trigger handler
 	triggerHandler := handlers.NewTriggerHandler(
 		aiService,
@@ -150,12 +330,32 @@ func NewServer(port int, db *sql.DB, cfg *config.Config, logger *logger.Logger)
 		triggerHandler:      triggerHandler,
 		simulationHandler:   simulationHandler,
 		performanceAnalyzer: performanceAnalyzer,
+		tradeFeed:           tradeFeed,
+		candleService:       candleService,
+		holderLedger:        holderLedger,
+		launchService:       launchService,
+		anomalyService:      anomalyService,
+		lifecycleService:    lifecycleService,
+		featureStore:        featureStore,
+		outcomeService:      outcomeService,
+		tokenHandler:        tokenHandler,
+		healthHandler:       healthHandler,
+		creatorService:      creatorService,
+		creatorHandler:      creatorHandler,
+		walletService:       walletService,
+		walletHandler:       walletHandler,
+		lineageHandler:      lineageHandler,
+		draftHandler:        draftHandler,
+		postMortemHandler:   postMortemHandler,
 	}
+	server.backgroundCtx, server.backgroundCancel = context.WithCancel(context.Background())
 
 	// Create AI handler
 	aiHandler := handlers.NewAIHandler(
 		aiService,
 		performanceAnalyzer,
+		aiUsageService,
+		admissionService,
 		logger,
 	)
 
@@ -195,6 +395,13 @@ func (s *Server) registerRoutes(aiHandler *handlers.AIHandler) {
 		s.logger.Warn("Strategy handler is nil, routes not registered")
 	}
 
+	// Register strategy draft routes
+	if s.draftHandler != nil {
+		s.draftHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Strategy draft handler is nil, routes not registered")
+	}
+
 	// Register trigger routes
 	if s.triggerHandler != nil {
 		s.triggerHandler.RegisterRoutes(api)
@@ -222,6 +429,48 @@ func (s *Server) registerRoutes(aiHandler *handlers.AIHandler) {
 	} else {
 		s.logger.Warn("Dashboard handler is nil, routes not registered")
 	}
+
+	// Register token market data routes
+	if s.tokenHandler != nil {
+		s.tokenHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Token handler is nil, routes not registered")
+	}
+
+	// Register data health routes
+	if s.healthHandler != nil {
+		s.healthHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Health handler is nil, routes not registered")
+	}
+
+	// Register creator reputation routes
+	if s.creatorHandler != nil {
+		s.creatorHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Creator handler is nil, routes not registered")
+	}
+
+	// Register wallet analytics routes
+	if s.walletHandler != nil {
+		s.walletHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Wallet handler is nil, routes not registered")
+	}
+
+	// Register strategy lineage routes
+	if s.lineageHandler != nil {
+		s.lineageHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Lineage handler is nil, routes not registered")
+	}
+
+	// Register trade post-mortem routes
+	if s.postMortemHandler != nil {
+		s.postMortemHandler.RegisterRoutes(api)
+	} else {
+		s.logger.Warn("Trade post-mortem handler is nil, routes not registered")
+	}
 }
 
 // loggingMiddleware logs API requests
@@ -239,6 +488,38 @@ func (s *Server) loggingMiddleware() fiber.Handler {
 
 // Start starts the API server
 func (s *Server) Start(automation_enabled bool) error {
+	// Start candle building from the trade feed, replaying the current largest bucket
+	// and rebuilding the one before it so in-progress candles are complete after a restart
+	intervals := service.DefaultCandleIntervals()
+	largest := int64(intervals[len(intervals)-1])
+	now := time.Now().Unix()
+	replayFrom := now - now%largest
+	s.candleService.Resume(s.backgroundCtx, replayFrom)
+	s.candleService.Start(s.backgroundCtx)
+	s.holderLedger.Start(s.backgroundCtx)
+	s.anomalyService.Start(s.backgroundCtx)
+	s.featureStore.Start(s.backgroundCtx)
+	if err := s.tradeFeed.Start(s.backgroundCtx, replayFrom); err != nil {
+		s.logger.Error("Failed to start trade feed: %v", err)
+	}
+
+	// Follow lifecycle transitions recorded by the collector
+	if err := s.lifecycleService.Start(s.backgroundCtx); err != nil {
+		s.logger.Error("Failed to start token lifecycle feed: %v", err)
+	}
+
+	// Keep creator reputation profiles current for recent launches
+	s.creatorService.Start(s.backgroundCtx)
+
+	// Keep wallet stats and the smart-wallet list current
+	s.walletService.Start(s.backgroundCtx)
+
+	// Label bundle and sniper wallets once each launch window closes
+	s.launchService.Start(s.backgroundCtx)
+
+	// Label token outcomes once their horizon has passed
+	s.outcomeService.Start(s.backgroundCtx)
+
 	// Start the automation service if enabled in config
 	if automation_enabled && s.automationService != nil {
 		if err := s.automationService.Start(); err != nil {
@@ -267,6 +548,11 @@ func (s *Server) Stop(ctx context.Context) error {
 		}
 	}
 
+	// Stop the trade feed and its consumers
+	if s.backgroundCancel != nil {
+		s.backgroundCancel()
+	}
+
 	// Stop the simulation service if it exists
 	if s.simulationService != nil {
 		s.simulationService.Shutdown()
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -26,9 +27,22 @@ type Config struct {
 	}
 
 	AI struct {
-		APIKey   string
-		Model    string
-		Endpoint string
+		Provider   string // openai, anthropic, ollama or mock
+		APIKey     string
+		Model      string
+		Endpoint   string
+		TimeoutSec int
+
+		DailyTokenBudgets   map[string]int64 // Tokens per AI call purpose per day, unlimited when unset
+		MonthlyTokenBudgets map[string]int64 // Tokens per AI call purpose per month, unlimited when unset
+		BreakerThreshold    int              // Consecutive provider errors that open the circuit breaker
+		BreakerCooldownSec  int              // Seconds the circuit breaker stays open
+		InputCostPer1K      float64          // USD per 1000 prompt tokens
+		OutputCostPer1K     float64          // USD per 1000 response tokens
+
+		PromptDir             string   // Directory of prompt templates added to the built-in ones
+		AutoGenerationPrompts []string // Strategy templates the AI service's own generation loop cycles through
+		FeedbackTokenBudget   int      // Prompt tokens the feedback on recent simulation runs may use, disabled at 0
 	}
 
 	Monitoring struct {
@@ -45,6 +59,37 @@ type Config struct {
 		PerformanceAnalysisInterval int // In minutes
 		StrategiesPerInterval       int
 		MaxConcurrentSimulations    int
+		GenerationPrompts           []string // Strategy templates generation cycles through, as id or id@vN
+		AnalysisPrompt              string   // Analysis template performance analyses use, as id or id@vN
+		Generator                   string   // What generates scheduled strategies: llm, genetic or both
+	}
+
+	Evolution struct {
+		PopulationSize int   // Configs in each generation of the genetic algorithm
+		Generations    int   // Generations evolved per run
+		Offspring      int   // Best new configs saved as strategies per run
+		LookbackHours  int   // Hours of stored trades fitness backtests replay
+		Seed           int64 // Random seed, so runs can be reproduced; 0 seeds each run from the clock
+	}
+
+	Diversity struct {
+		DuplicateThreshold float64 // Similarity (0-1) at which a new strategy is a near-duplicate of a saved one, disabled at 0
+		DuplicateAction    string  // What happens to near-duplicates: reject or merge
+		ClusterSimilarity  float64 // Similarity (0-1) that puts strategies in the same configuration cluster
+		MaxPerCluster      int     // Strategies of one cluster queued or simulating at once, unlimited at 0
+	}
+
+	Admission struct {
+		Enabled        bool    // Backtest generated strategies before they go public and get simulated
+		LookbackHours  int     // Hours of stored trades the backtest replays
+		MinTrades      int     // Trades the backtest must make
+		MaxDrawdownPct float64 // Largest backtest drawdown allowed, in percent
+		MinExpectancy  float64 // Average backtest profit per trade in SOL that must be exceeded
+	}
+
+	Feed struct {
+		StaleThresholdSec int // Silence after which the upstream feed counts as a gap
+		MetricsPeriodSec  int // How often the collector records message rates
 	}
 }
 
@@ -110,6 +155,88 @@ func LoadConfig() (*Config, error) {
 	config.AI.Model = os.Getenv("AI_MODEL")
 	config.AI.Endpoint = os.Getenv("AI_ENDPOINT")
 
+	if provider := os.Getenv("AI_PROVIDER"); provider != "" {
+		switch provider {
+		case "openai", "anthropic", "ollama", "mock":
+			config.AI.Provider = provider
+		default:
+			return nil, fmt.Errorf("invalid AI_PROVIDER: %s", provider)
+		}
+	} else {
+		config.AI.Provider = "openai" // Default OpenAI-compatible endpoint
+	}
+
+	if timeoutStr := os.Getenv("AI_TIMEOUT_SEC"); timeoutStr != "" {
+		timeout, err := strconv.Atoi(timeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI_TIMEOUT_SEC: %v", err)
+		}
+		config.AI.TimeoutSec = timeout
+	} else {
+		config.AI.TimeoutSec = 60 // Default 60 seconds
+	}
+
+	if config.AI.DailyTokenBudgets, err = parseTokenBudgets(os.Getenv("AI_DAILY_TOKEN_BUDGETS")); err != nil {
+		return nil, fmt.Errorf("invalid AI_DAILY_TOKEN_BUDGETS: %v", err)
+	}
+	if config.AI.MonthlyTokenBudgets, err = parseTokenBudgets(os.Getenv("AI_MONTHLY_TOKEN_BUDGETS")); err != nil {
+		return nil, fmt.Errorf("invalid AI_MONTHLY_TOKEN_BUDGETS: %v", err)
+	}
+
+	if thresholdStr := os.Getenv("AI_BREAKER_THRESHOLD"); thresholdStr != "" {
+		threshold, err := strconv.Atoi(thresholdStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI_BREAKER_THRESHOLD: %v", err)
+		}
+		config.AI.BreakerThreshold = threshold
+	} else {
+		config.AI.BreakerThreshold = 5 // Default 5 consecutive errors
+	}
+
+	if cooldownStr := os.Getenv("AI_BREAKER_COOLDOWN_SEC"); cooldownStr != "" {
+		cooldown, err := strconv.Atoi(cooldownStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI_BREAKER_COOLDOWN_SEC: %v", err)
+		}
+		config.AI.BreakerCooldownSec = cooldown
+	} else {
+		config.AI.BreakerCooldownSec = 300 // Default 5 minutes
+	}
+
+	if costStr := os.Getenv("AI_INPUT_COST_PER_1K"); costStr != "" {
+		cost, err := strconv.ParseFloat(costStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI_INPUT_COST_PER_1K: %v", err)
+		}
+		config.AI.InputCostPer1K = cost
+	}
+
+	if costStr := os.Getenv("AI_OUTPUT_COST_PER_1K"); costStr != "" {
+		cost, err := strconv.ParseFloat(costStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI_OUTPUT_COST_PER_1K: %v", err)
+		}
+		config.AI.OutputCostPer1K = cost
+	}
+
+	config.AI.PromptDir = os.Getenv("AI_PROMPT_DIR")
+
+	if prompts := parseList(os.Getenv("AI_AUTOGEN_PROMPTS")); len(prompts) > 0 {
+		config.AI.AutoGenerationPrompts = prompts
+	} else {
+		config.AI.AutoGenerationPrompts = []string{"momentum", "defensive"}
+	}
+
+	if budgetStr := os.Getenv("AI_FEEDBACK_TOKEN_BUDGET"); budgetStr != "" {
+		budget, err := strconv.Atoi(budgetStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AI_FEEDBACK_TOKEN_BUDGET: %v", err)
+		}
+		config.AI.FeedbackTokenBudget = budget
+	} else {
+		config.AI.FeedbackTokenBudget = 600 // Default 600 tokens
+	}
+
 	// Automation Configuration
 	if enabledStr := os.Getenv("AUTOMATION_ENABLED"); enabledStr != "" {
 		enabled, err := strconv.ParseBool(enabledStr)
@@ -161,6 +288,190 @@ func LoadConfig() (*Config, error) {
 		config.Automation.MaxConcurrentSimulations = 3 // Default 3 concurrent simulations
 	}
 
+	if prompts := parseList(os.Getenv("AUTOMATION_GENERATION_PROMPTS")); len(prompts) > 0 {
+		config.Automation.GenerationPrompts = prompts
+	} else {
+		config.Automation.GenerationPrompts = []string{"early_entry", "diversified"}
+	}
+
+	if prompt := os.Getenv("AUTOMATION_ANALYSIS_PROMPT"); prompt != "" {
+		config.Automation.AnalysisPrompt = prompt
+	} else {
+		config.Automation.AnalysisPrompt = "performance"
+	}
+
+	if generator := os.Getenv("AUTOMATION_GENERATOR"); generator != "" {
+		switch generator {
+		case "llm", "genetic", "both":
+			config.Automation.Generator = generator
+		default:
+			return nil, fmt.Errorf("invalid AUTOMATION_GENERATOR: %s", generator)
+		}
+	} else {
+		config.Automation.Generator = "llm" // Default LLM generation only
+	}
+
+	// Genetic Evolution Configuration
+	if sizeStr := os.Getenv("EVOLUTION_POPULATION_SIZE"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVOLUTION_POPULATION_SIZE: %v", err)
+		}
+		config.Evolution.PopulationSize = size
+	} else {
+		config.Evolution.PopulationSize = 20 // Default 20 configs
+	}
+
+	if generationsStr := os.Getenv("EVOLUTION_GENERATIONS"); generationsStr != "" {
+		generations, err := strconv.Atoi(generationsStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVOLUTION_GENERATIONS: %v", err)
+		}
+		config.Evolution.Generations = generations
+	} else {
+		config.Evolution.Generations = 15 // Default 15 generations
+	}
+
+	if offspringStr := os.Getenv("EVOLUTION_OFFSPRING"); offspringStr != "" {
+		offspring, err := strconv.Atoi(offspringStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVOLUTION_OFFSPRING: %v", err)
+		}
+		config.Evolution.Offspring = offspring
+	} else {
+		config.Evolution.Offspring = 2 // Default 2 strategies per run
+	}
+
+	if hoursStr := os.Getenv("EVOLUTION_LOOKBACK_HOURS"); hoursStr != "" {
+		hours, err := strconv.Atoi(hoursStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVOLUTION_LOOKBACK_HOURS: %v", err)
+		}
+		config.Evolution.LookbackHours = hours
+	} else {
+		config.Evolution.LookbackHours = 24 // Default 24 hours
+	}
+
+	if seedStr := os.Getenv("EVOLUTION_SEED"); seedStr != "" {
+		seed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVOLUTION_SEED: %v", err)
+		}
+		config.Evolution.Seed = seed
+	}
+
+	// Strategy Diversity Configuration
+	if thresholdStr := os.Getenv("DIVERSITY_DUPLICATE_THRESHOLD"); thresholdStr != "" {
+		threshold, err := strconv.ParseFloat(thresholdStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DIVERSITY_DUPLICATE_THRESHOLD: %v", err)
+		}
+		config.Diversity.DuplicateThreshold = threshold
+	} else {
+		config.Diversity.DuplicateThreshold = 0.95 // Default 95% similar
+	}
+
+	if action := os.Getenv("DIVERSITY_DUPLICATE_ACTION"); action != "" {
+		switch action {
+		case "reject", "merge":
+			config.Diversity.DuplicateAction = action
+		default:
+			return nil, fmt.Errorf("invalid DIVERSITY_DUPLICATE_ACTION: %s", action)
+		}
+	} else {
+		config.Diversity.DuplicateAction = "reject" // Default reject near-duplicates
+	}
+
+	if similarityStr := os.Getenv("DIVERSITY_CLUSTER_SIMILARITY"); similarityStr != "" {
+		similarity, err := strconv.ParseFloat(similarityStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DIVERSITY_CLUSTER_SIMILARITY: %v", err)
+		}
+		config.Diversity.ClusterSimilarity = similarity
+	} else {
+		config.Diversity.ClusterSimilarity = 0.85 // Default 85% similar
+	}
+
+	if maxStr := os.Getenv("DIVERSITY_MAX_PER_CLUSTER"); maxStr != "" {
+		limit, err := strconv.Atoi(maxStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DIVERSITY_MAX_PER_CLUSTER: %v", err)
+		}
+		config.Diversity.MaxPerCluster = limit
+	} else {
+		config.Diversity.MaxPerCluster = 1 // Default one strategy per cluster at a time
+	}
+
+	// Strategy Admission Configuration
+	if enabledStr := os.Getenv("ADMISSION_ENABLED"); enabledStr != "" {
+		enabled, err := strconv.ParseBool(enabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMISSION_ENABLED: %v", err)
+		}
+		config.Admission.Enabled = enabled
+	} else {
+		config.Admission.Enabled = true // Enabled by default
+	}
+
+	if hoursStr := os.Getenv("ADMISSION_LOOKBACK_HOURS"); hoursStr != "" {
+		hours, err := strconv.Atoi(hoursStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMISSION_LOOKBACK_HOURS: %v", err)
+		}
+		config.Admission.LookbackHours = hours
+	} else {
+		config.Admission.LookbackHours = 6 // Default 6 hours
+	}
+
+	if tradesStr := os.Getenv("ADMISSION_MIN_TRADES"); tradesStr != "" {
+		trades, err := strconv.Atoi(tradesStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMISSION_MIN_TRADES: %v", err)
+		}
+		config.Admission.MinTrades = trades
+	} else {
+		config.Admission.MinTrades = 3 // Default 3 trades
+	}
+
+	if drawdownStr := os.Getenv("ADMISSION_MAX_DRAWDOWN_PCT"); drawdownStr != "" {
+		drawdown, err := strconv.ParseFloat(drawdownStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMISSION_MAX_DRAWDOWN_PCT: %v", err)
+		}
+		config.Admission.MaxDrawdownPct = drawdown
+	} else {
+		config.Admission.MaxDrawdownPct = 30 // Default 30%
+	}
+
+	if expectancyStr := os.Getenv("ADMISSION_MIN_EXPECTANCY_SOL"); expectancyStr != "" {
+		expectancy, err := strconv.ParseFloat(expectancyStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMISSION_MIN_EXPECTANCY_SOL: %v", err)
+		}
+		config.Admission.MinExpectancy = expectancy
+	}
+
+	// Feed Monitoring Configuration
+	if thresholdStr := os.Getenv("FEED_STALE_THRESHOLD_SEC"); thresholdStr != "" {
+		threshold, err := strconv.Atoi(thresholdStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEED_STALE_THRESHOLD_SEC: %v", err)
+		}
+		config.Feed.StaleThresholdSec = threshold
+	} else {
+		config.Feed.StaleThresholdSec = 30 // Default 30 seconds
+	}
+
+	if periodStr := os.Getenv("FEED_METRICS_PERIOD_SEC"); periodStr != "" {
+		period, err := strconv.Atoi(periodStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEED_METRICS_PERIOD_SEC: %v", err)
+		}
+		config.Feed.MetricsPeriodSec = period
+	} else {
+		config.Feed.MetricsPeriodSec = 10 // Default 10 seconds
+	}
+
 	// Validate required configurations
 	if config.WebSocket.URL == "" {
 		return nil, fmt.Errorf("WEBSOCKET_URL is required")
@@ -171,3 +482,36 @@ func LoadConfig() (*Config, error) {
 
 	return &config, nil
 }
+
+// parseTokenBudgets parses a comma-separated list of purpose=tokens pairs, e.g.
+// "strategy_generation=200000,performance_analysis=50000"
+func parseTokenBudgets(value string) (map[string]int64, error) {
+	budgets := make(map[string]int64)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		purpose, tokensStr, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(purpose) == "" {
+			return nil, fmt.Errorf("expected purpose=tokens, got %q", entry)
+		}
+		tokens, err := strconv.ParseInt(strings.TrimSpace(tokensStr), 10, 64)
+		if err != nil || tokens < 0 {
+			return nil, fmt.Errorf("invalid token budget %q", entry)
+		}
+		budgets[strings.TrimSpace(purpose)] = tokens
+	}
+	return budgets, nil
+}
+
+// parseList parses a comma-separated list, skipping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
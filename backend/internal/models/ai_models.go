@@ -0,0 +1,243 @@
+// internal/models/ai_models.go
+package models
+
+import "time"
+
+// AI call purposes, used for usage accounting and budgets
+const (
+	AIPurposeStrategyGeneration  = "strategy_generation"  // Scheduled and automated strategy generation
+	AIPurposeManualGeneration    = "manual_generation"    // Strategies requested through the trigger endpoint
+	AIPurposePerformanceAnalysis = "performance_analysis" // Written analyses of strategy performance
+	AIPurposeStrategyDrafting    = "strategy_drafting"    // Strategies written from a user's description
+	AIPurposeTradePostMortem     = "trade_post_mortem"    // Diagnoses of individual closed simulated trades
+)
+
+// AI call outcomes
+const (
+	AIOutcomeSuccess        = "success"
+	AIOutcomeError          = "error"           // The provider failed
+	AIOutcomeBudgetExceeded = "budget_exceeded" // Blocked before reaching the provider
+	AIOutcomeCircuitOpen    = "circuit_open"    // Blocked before reaching the provider
+)
+
+// AIUsage records one LLM call
+type AIUsage struct {
+	ID           int64     `json:"id"`
+	Purpose      string    `json:"purpose"` // One of the AIPurpose* values
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	LatencyMs    int64     `json:"latency_ms"`
+	Outcome      string    `json:"outcome"` // One of the AIOutcome* values
+	Error        string    `json:"error,omitempty"`
+	CostUSD      float64   `json:"cost_usd"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AIUsageSummary aggregates the calls made for one purpose over a period
+type AIUsageSummary struct {
+	Purpose      string  `json:"purpose"`
+	Calls        int     `json:"calls"`
+	Successes    int     `json:"successes"`
+	Errors       int     `json:"errors"`
+	Blocked      int     `json:"blocked"` // Calls refused by a budget or the circuit breaker
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"` // Over calls that reached the provider
+}
+
+// AIBudgetStatus is the token budget of one purpose over a period
+type AIBudgetStatus struct {
+	Purpose         string `json:"purpose"`
+	Period          string `json:"period"` // "daily" or "monthly"
+	LimitTokens     int64  `json:"limit_tokens"`
+	UsedTokens      int64  `json:"used_tokens"`
+	RemainingTokens int64  `json:"remaining_tokens"`
+	Exceeded        bool   `json:"exceeded"`
+}
+
+// AICircuitStatus is the state of the LLM circuit breaker
+type AICircuitStatus struct {
+	State               string     `json:"state"` // "closed", "open" or "half_open"
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"` // When an open breaker lets a trial call through
+}
+
+// AIUsageReport is the usage, budgets and breaker state served by the usage endpoint
+type AIUsageReport struct {
+	Provider    string            `json:"provider"`
+	Daily       []*AIUsageSummary `json:"daily"`   // Since midnight UTC
+	Monthly     []*AIUsageSummary `json:"monthly"` // Since the first of the month UTC
+	Budgets     []*AIBudgetStatus `json:"budgets"`
+	Circuit     AICircuitStatus   `json:"circuit"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+// AIGenerationFailure records an AI response that still failed validation after every
+// repair attempt
+type AIGenerationFailure struct {
+	ID           int64     `json:"id"`
+	Purpose      string    `json:"purpose"` // One of the AIPurpose* values
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	Attempts     int       `json:"attempts"` // Completions requested, including the first
+	Errors       []string  `json:"errors"`   // Validation errors of the last attempt
+	LastResponse string    `json:"last_response"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Strategy admission outcomes
+const (
+	AdmissionAdmitted = "admitted" // Saved as a public strategy and queued for simulation
+	AdmissionRejected = "rejected" // Kept only as a candidate
+	AdmissionMerged   = "merged"   // A near-duplicate of a saved strategy, merged into it
+	AdmissionPending  = "pending"  // Couldn't be backtested, kept as an unverified candidate
+)
+
+// PurposeGeneticEvolution is the purpose of candidates evolved by the genetic algorithm,
+// which makes no AI call
+const PurposeGeneticEvolution = "genetic_evolution"
+
+// BacktestResult summarizes a strategy replayed over stored trades
+type BacktestResult struct {
+	From            int64          `json:"from"` // Unix seconds
+	To              int64          `json:"to"`   // Unix seconds
+	MarketTrades    int            `json:"market_trades"`
+	TokensEvaluated int            `json:"tokens_evaluated"`
+	TradeCount      int            `json:"trade_count"`
+	Wins            int            `json:"wins"`
+	WinRate         float64        `json:"win_rate"`     // Percentage of trades that made a profit
+	NetPnL          float64        `json:"net_pnl"`      // SOL
+	ROI             float64        `json:"roi"`          // Percentage of the initial balance
+	MaxDrawdown     float64        `json:"max_drawdown"` // Largest fall from peak balance, in percent
+	Expectancy      float64        `json:"expectancy"`   // Average profit per trade in SOL
+	ExitReasons     map[string]int `json:"exit_reasons"`
+	DataGaps        int            `json:"data_gaps,omitempty"` // Feed gaps overlapping the window
+	GapSec          int64          `json:"gap_sec,omitempty"`   // Seconds of the window inside feed gaps
+	Degraded        bool           `json:"degraded,omitempty"`  // Trades are missing, so the result can't be trusted
+}
+
+// StrategyCandidate is an AI-generated strategy that went through admission, kept with its
+// backtest whether it was admitted or not
+type StrategyCandidate struct {
+	ID             int64           `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Config         JSONB           `json:"config"`
+	Purpose        string          `json:"purpose"` // One of the AIPurpose* values, or PurposeGeneticEvolution
+	PromptTemplate string          `json:"prompt_template,omitempty"`
+	PromptVersion  int             `json:"prompt_version,omitempty"`
+	Status         string          `json:"status"`  // One of the Admission* values
+	Reasons        []string        `json:"reasons"` // Why the candidate was rejected, or notes on its admission
+	Backtest       *BacktestResult `json:"backtest,omitempty"`
+	StrategyID     *int64          `json:"strategy_id,omitempty"` // Set once admitted, or to the strategy it was merged into
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// Strategy draft statuses
+const (
+	DraftOpen  = "open"  // Can still be revised
+	DraftSaved = "saved" // Saved as a strategy
+)
+
+// StrategyDraft is a strategy written by the AI from a user's description and revised by
+// their follow-up instructions until it is saved
+type StrategyDraft struct {
+	ID             int64              `json:"id"`
+	Name           string             `json:"name"`
+	Description    string             `json:"description"`
+	Config         JSONB              `json:"config"`                // Validated strategy parameters and rules
+	Explanations   []DraftExplanation `json:"explanations"`          // How the description maps to each parameter
+	Ambiguities    []DraftAmbiguity   `json:"ambiguities"`           // What the description left open and what was assumed
+	Instructions   []string           `json:"instructions"`          // The description, then each revision instruction
+	Revision       int                `json:"revision"`              // 1 for the first draft
+	Status         string             `json:"status"`                // One of the Draft* values
+	StrategyID     *int64             `json:"strategy_id,omitempty"` // Set once saved
+	PromptTemplate string             `json:"prompt_template,omitempty"`
+	PromptVersion  int                `json:"prompt_version,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// DraftExplanation says which part of the description a draft parameter came from
+type DraftExplanation struct {
+	Parameter   string `json:"parameter"`
+	Source      string `json:"source,omitempty"` // The words of the description it was taken from, empty for defaults
+	Explanation string `json:"explanation"`
+}
+
+// DraftAmbiguity is something the description left open and the assumption made instead
+type DraftAmbiguity struct {
+	Parameter  string `json:"parameter,omitempty"`
+	Issue      string `json:"issue"`
+	Assumption string `json:"assumption"`
+}
+
+// Trade post-mortem diagnosis codes
+const (
+	DiagnosisBundledLaunch    = "bundled_launch"      // Entered a launch dominated by bundled or sniper wallets
+	DiagnosisWashTrading      = "wash_trading"        // The entry signal came from manufactured activity
+	DiagnosisLateEntry        = "late_entry"          // Entered after most of the move had already happened
+	DiagnosisStopTooTight     = "stop_too_tight"      // Stopped out by normal volatility before the price recovered
+	DiagnosisStopTooLoose     = "stop_too_loose"      // The stop let a failed entry lose far more than it needed to
+	DiagnosisTakeProfitTooLow = "take_profit_too_low" // Sold well below the run-up that followed
+	DiagnosisHeldTooLong      = "held_too_long"       // Gave back a run-up waiting for an exit that never came
+	DiagnosisHolderDump       = "holder_dump"         // The creator or large holders sold into the position
+	DiagnosisThinLiquidity    = "thin_liquidity"      // Too few trades for the entry signal or exit price to mean much
+	DiagnosisSoundTrade       = "sound_trade"         // The trade played out as the strategy intended
+	DiagnosisOther            = "other"
+)
+
+// DiagnosisCodes lists every diagnosis code
+var DiagnosisCodes = []string{
+	DiagnosisBundledLaunch,
+	DiagnosisWashTrading,
+	DiagnosisLateEntry,
+	DiagnosisStopTooTight,
+	DiagnosisStopTooLoose,
+	DiagnosisTakeProfitTooLow,
+	DiagnosisHeldTooLong,
+	DiagnosisHolderDump,
+	DiagnosisThinLiquidity,
+	DiagnosisSoundTrade,
+	DiagnosisOther,
+}
+
+// TradePostMortem is the AI's diagnosis of why a closed simulated trade won or lost
+type TradePostMortem struct {
+	Diagnoses      []TradeDiagnosis `json:"diagnoses"` // Most important first
+	Summary        string           `json:"summary"`
+	PromptTemplate string           `json:"prompt_template"`
+	PromptVersion  int              `json:"prompt_version"`
+	AnalyzedAt     time.Time        `json:"analyzed_at"`
+}
+
+// TradeDiagnosis is one cause of a trade's outcome, such as a stop too tight for the
+// token's volatility
+type TradeDiagnosis struct {
+	Code       string  `json:"code"`                 // One of the Diagnosis* values
+	Detail     string  `json:"detail"`               // The evidence, e.g. "price swung 18% within 10s of entry"
+	Parameter  string  `json:"parameter,omitempty"`  // Strategy parameter to change, if one applies
+	Suggestion string  `json:"suggestion,omitempty"` // How to change it
+	Confidence float64 `json:"confidence"`           // 0-1
+}
+
+// StrategyDiagnosisSummary aggregates the post-mortems of a strategy's closed trades
+type StrategyDiagnosisSummary struct {
+	StrategyID     int64            `json:"strategy_id"`
+	ClosedTrades   int              `json:"closed_trades"`
+	TradesAnalyzed int              `json:"trades_analyzed"`
+	Diagnoses      []DiagnosisCount `json:"diagnoses"` // Most frequent first
+}
+
+// DiagnosisCount is how often a diagnosis was made across a strategy's trades
+type DiagnosisCount struct {
+	Code       string   `json:"code"`
+	Trades     int      `json:"trades"`
+	SharePct   float64  `json:"share_pct"`  // Of the analyzed trades
+	NetPnL     float64  `json:"net_pnl"`    // Summed profit/loss of those trades
+	Parameters []string `json:"parameters"` // Strategy parameters the diagnoses pointed at
+}
@@ -0,0 +1,29 @@
+// internal/models/lineage_models.go
+package models
+
+// LineagePerformance is the latest recorded performance of a strategy in a lineage
+type LineagePerformance struct {
+	ROI         float64 `json:"roi"`
+	WinRate     float64 `json:"win_rate"`
+	TotalTrades int     `json:"total_trades"`
+}
+
+// LineageDelta is the performance change across one derivation: the derived strategy's
+// metrics minus its parent's, so positive values mean the derivation improved on it
+type LineageDelta struct {
+	ROI     float64 `json:"roi"`
+	WinRate float64 `json:"win_rate"`
+}
+
+// LineageNode is a strategy in a lineage tree. The requested strategy is the root; its
+// ancestors hang off Parents and its descendants off Children.
+type LineageNode struct {
+	StrategyID        int64               `json:"strategy_id"`
+	Name              string              `json:"name"`
+	Generation        int                 `json:"generation"`                   // 0 for strategies not derived from another
+	ImprovementReason string              `json:"improvement_reason,omitempty"` // Why the strategy was derived from its parents
+	Performance       *LineagePerformance `json:"performance,omitempty"`        // Nil until the strategy has metrics
+	Delta             *LineageDelta       `json:"delta,omitempty"`              // Change across the derivation linking this node to the node above it
+	Parents           []*LineageNode      `json:"parents,omitempty"`
+	Children          []*LineageNode      `json:"children,omitempty"`
+}
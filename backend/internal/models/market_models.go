@@ -0,0 +1,241 @@
+// internal/models/market_models.go
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Candle represents an OHLCV bar for a token over a fixed interval
+type Candle struct {
+	ID            int64     `json:"-"`
+	TokenID       int64     `json:"-"`
+	MintAddress   string    `json:"mint"`
+	IntervalSec   int       `json:"interval_sec"`
+	BucketStart   int64     `json:"bucket_start"` // Unix seconds, aligned to the interval
+	Open          float64   `json:"open"`
+	High          float64   `json:"high"`
+	Low           float64   `json:"low"`
+	Close         float64   `json:"close"`
+	VolumeSol     float64   `json:"volume_sol"`
+	BuyVolumeSol  float64   `json:"buy_volume_sol"`
+	SellVolumeSol float64   `json:"sell_volume_sol"`
+	TradeCount    int       `json:"trade_count"`
+	BuyCount      int       `json:"buy_count"`
+	SellCount     int       `json:"sell_count"`
+	UpdatedAt     time.Time `json:"-"`
+}
+
+// FeedMetric records upstream feed activity over one collector reporting period
+type FeedMetric struct {
+	ID            int64     `json:"-"`
+	Source        string    `json:"source"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	MessageCount  int64     `json:"message_count"`
+	TradeCount    int64     `json:"trade_count"`
+	TokenCount    int64     `json:"token_count"`
+	Connected     bool      `json:"connected"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	CreatedAt     time.Time `json:"-"`
+}
+
+// DataGap represents a period in which the upstream feed was disconnected or silent
+type DataGap struct {
+	ID          int64      `json:"id"`
+	Source      string     `json:"source"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"` // nil while the gap is still open
+	DurationSec float64    `json:"duration_sec"`
+	Reason      string     `json:"reason"` // 'disconnected', 'stalled', 'collector_down'
+	CreatedAt   time.Time  `json:"-"`
+}
+
+// Anomaly flags raised against a wallet's trading in one token
+const (
+	AnomalySelfTrade = "self_trade" // Buys and sells the same amount within seconds
+	AnomalyRoundTrip = "round_trip" // Repeatedly buys and sells out again, churning volume
+	AnomalyDust      = "dust"       // Mostly tiny buys that inflate buy counts
+	AnomalyPeriodic  = "periodic"   // Trades at near-constant intervals like a bot
+)
+
+// TokenAnomalies summarizes wash-trading and bot activity detected in a token's trades
+type TokenAnomalies struct {
+	TokenID            int64               `json:"-"`
+	MintAddress        string              `json:"mint"`
+	TradeCount         int                 `json:"trade_count"`
+	BuyCount           int                 `json:"buy_count"`
+	SelfTradeCount     int                 `json:"self_trade_count"` // Matched buy/sell pairs
+	RoundTripWallets   int                 `json:"round_trip_wallets"`
+	DustBuyCount       int                 `json:"dust_buy_count"`
+	DustThresholdSol   float64             `json:"dust_threshold_sol"` // Buys below this size count as dust
+	PeriodicWallets    int                 `json:"periodic_wallets"`
+	FlaggedWalletCount int                 `json:"flagged_wallet_count"`
+	FlaggedBuyPct      float64             `json:"flagged_buy_pct"`    // Share of buys from flagged wallets or dust
+	FlaggedVolumePct   float64             `json:"flagged_volume_pct"` // Share of buy volume from flagged wallets or dust
+	AnomalyScore       float64             `json:"anomaly_score"`      // 0-100, higher means more manufactured activity
+	FlaggedWallets     map[string][]string `json:"flagged_wallets"`    // Wallet to its anomaly flags
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+// IsFlagged reports whether a wallet has any anomaly flag
+func (a *TokenAnomalies) IsFlagged(wallet string) bool {
+	return len(a.FlaggedWallets[wallet]) > 0
+}
+
+// IsOrganicBuy reports whether a buy is neither dust nor from a flagged wallet
+func (a *TokenAnomalies) IsOrganicBuy(trade *Trade) bool {
+	return trade.IsBuy && !a.IsFlagged(trade.UserAddress) && trade.SolAmount >= a.DustThresholdSol
+}
+
+// Token lifecycle states, in the order a successful launch moves through them
+const (
+	TokenStateCreated       = "created"
+	TokenStateTrading       = "trading"
+	TokenStateKingOfTheHill = "king_of_the_hill"
+	TokenStateGraduated     = "graduated" // Bonding curve completed, terminal
+	TokenStateAbandoned     = "abandoned" // No trades for a while, left when trading resumes
+)
+
+// TokenTransition is a timestamped change of a token's lifecycle state
+type TokenTransition struct {
+	ID          int64     `json:"id"`
+	TokenID     int64     `json:"-"`
+	MintAddress string    `json:"mint"`
+	FromState   string    `json:"from_state,omitempty"`
+	ToState     string    `json:"to_state"`
+	Timestamp   int64     `json:"timestamp"` // Unix seconds the transition happened
+	Source      string    `json:"source"`    // Socket.IO event or 'sweep' that caused the transition
+	CreatedAt   time.Time `json:"-"`
+}
+
+// TokenLifecycle is a token's current lifecycle state and the transitions that led to it
+type TokenLifecycle struct {
+	TokenID         int64              `json:"-"`
+	MintAddress     string             `json:"mint"`
+	State           string             `json:"state"`
+	StateSince      int64              `json:"state_since"`
+	KingOfTheHillAt int64              `json:"king_of_the_hill_at,omitempty"` // Unix seconds, 0 if never reached
+	GraduatedAt     int64              `json:"graduated_at,omitempty"`        // Unix seconds, 0 if not graduated
+	Transitions     []*TokenTransition `json:"transitions"`
+}
+
+// Apply advances the lifecycle with a transition
+func (l *TokenLifecycle) Apply(transition *TokenTransition) {
+	l.State = transition.ToState
+	l.StateSince = transition.Timestamp
+	switch transition.ToState {
+	case TokenStateKingOfTheHill:
+		l.KingOfTheHillAt = transition.Timestamp
+	case TokenStateGraduated:
+		l.GraduatedAt = transition.Timestamp
+	}
+	l.Transitions = append(l.Transitions, transition)
+}
+
+// Feature snapshot decisions
+const (
+	FeatureDecisionEntry = "entry"
+	FeatureDecisionExit  = "exit"
+)
+
+// FeatureWindows are the rolling windows, in seconds, the feature store maintains
+var FeatureWindows = []int{10, 30, 60, 300}
+
+// TokenWindowFeatures holds a token's trading features over one rolling window
+type TokenWindowFeatures struct {
+	WindowSec        int     `json:"window_sec"`
+	BuyCount         int     `json:"buy_count"`
+	SellCount        int     `json:"sell_count"`
+	UniqueBuyers     int     `json:"unique_buyers"`
+	BuyVolumeSol     float64 `json:"buy_volume_sol"`
+	SellVolumeSol    float64 `json:"sell_volume_sol"`
+	VolumeSol        float64 `json:"volume_sol"`
+	NetFlowSol       float64 `json:"net_flow_sol"`       // Buy volume minus sell volume
+	PriceVelocityPct float64 `json:"price_velocity_pct"` // Price change per minute across the window
+	VolatilityPct    float64 `json:"volatility_pct"`     // Standard deviation of trade-to-trade price changes
+}
+
+// TokenFeatures holds a token's rolling-window features as of a point in time
+type TokenFeatures struct {
+	TokenID               int64                  `json:"-"`
+	MintAddress           string                 `json:"mint"`
+	AsOf                  int64                  `json:"as_of"` // Unix seconds the windows end at
+	LastPrice             float64                `json:"last_price"`
+	LastTradeAt           int64                  `json:"last_trade_at,omitempty"`
+	SecondsSinceLastTrade int64                  `json:"seconds_since_last_trade"` // -1 when the token has no trades
+	Windows               []*TokenWindowFeatures `json:"windows"`
+}
+
+// Window returns the features of the window with the given length, or nil if the store
+// does not maintain it
+func (f *TokenFeatures) Window(windowSec int) *TokenWindowFeatures {
+	for _, window := range f.Windows {
+		if window.WindowSec == windowSec {
+			return window
+		}
+	}
+	return nil
+}
+
+// TokenFeatureSnapshot records a token's features at a strategy decision
+type TokenFeatureSnapshot struct {
+	ID               int64          `json:"id"`
+	TokenID          int64          `json:"token_id"`
+	StrategyID       *int64         `json:"strategy_id,omitempty"`
+	SimulationRunID  *int64         `json:"simulation_run_id,omitempty"`
+	SimulatedTradeID *int64         `json:"simulated_trade_id,omitempty"`
+	Decision         string         `json:"decision"` // 'entry' or 'exit'
+	Features         *TokenFeatures `json:"features"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+// TokenOutcome labels what happened to a token in the 30 minutes after launch. Returns are
+// relative to the price of its first trade.
+type TokenOutcome struct {
+	TokenID           int64     `json:"-"`
+	MintAddress       string    `json:"mint"`
+	LaunchTimestamp   int64     `json:"launch_timestamp"` // Unix seconds
+	BasePrice         float64   `json:"base_price"`       // Price of the first trade after launch
+	TradeCount        int       `json:"trade_count"`
+	MaxReturn1mPct    float64   `json:"max_return_1m_pct"`
+	MaxReturn5mPct    float64   `json:"max_return_5m_pct"`
+	MaxReturn30mPct   float64   `json:"max_return_30m_pct"`
+	MaxDrawdownPct    float64   `json:"max_drawdown_pct"` // Largest peak-to-trough drop within 30 minutes
+	Graduated         bool      `json:"graduated"`
+	TimeToGraduateSec *int64    `json:"time_to_graduate_sec,omitempty"` // Nil when not graduated or the time is unknown
+	LabeledAt         time.Time `json:"labeled_at"`
+}
+
+// LabeledFeatureSnapshot pairs a feature snapshot with the outcome of its token
+type LabeledFeatureSnapshot struct {
+	Snapshot *TokenFeatureSnapshot
+	Outcome  *TokenOutcome
+}
+
+// Entry model types
+const (
+	EntryModelLogistic = "logistic_regression"
+)
+
+// EntryModel is a trained model scoring the probability that an entry reaches its target
+type EntryModel struct {
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Version     int                `json:"version"` // Increments per name each time the model is trained
+	ModelType   string             `json:"model_type"`
+	Target      string             `json:"target"` // Label the model predicts, e.g. max_return_5m_pct>=100
+	Features    []string           `json:"features"`
+	Weights     []float64          `json:"weights"`
+	Bias        float64            `json:"bias"`
+	Means       []float64          `json:"means"`  // Feature means used to standardize inputs
+	Scales      []float64          `json:"scales"` // Feature standard deviations used to standardize inputs
+	Metrics     map[string]float64 `json:"metrics"`
+	TrainedRows int                `json:"trained_rows"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// Tag identifies the model and version, e.g. p2x_5m@v3
+func (m *EntryModel) Tag() string {
+	return fmt.Sprintf("%s@v%d", m.Name, m.Version)
+}
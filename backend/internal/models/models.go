@@ -22,10 +22,30 @@ type Strategy struct {
 	ComplexityScore int       `json:"complexity_score"`
 	RiskScore       int       `json:"risk_score"`
 	AIEnhanced      bool      `json:"ai_enhanced"`
+	PromptTemplate  string    `json:"prompt_template,omitempty"` // Prompt template an AI strategy was generated from
+	PromptVersion   int       `json:"prompt_version,omitempty"`  // Version of PromptTemplate
+	Source          string    `json:"source"`                    // One of the StrategySource* values, set when saved
 	CreatedAt       time.Time `json:"-"`
 	UpdatedAt       time.Time `json:"-"`
 }
 
+// Where strategies come from
+const (
+	StrategySourceUser    = "user"    // Created through the API
+	StrategySourceAI      = "ai"      // Written by an LLM, from a template or a user's description
+	StrategySourceGenetic = "genetic" // Evolved by the genetic algorithm, without an LLM
+)
+
+// Entry signal types supported by the simulator
+const (
+	EntrySignalBuyCount      = "buy_count"        // Enough buys within the entry window (default)
+	EntrySignalSmartMoney    = "smart_money"      // Enough distinct smart wallets bought within the entry window
+	EntrySignalUniqueBuyers  = "unique_buyers"    // Enough distinct unflagged wallets made non-dust buys within the entry window
+	EntrySignalOrganicVolume = "organic_volume"   // Enough buy volume from unflagged wallets within the entry window
+	EntrySignalKingOfTheHill = "king_of_the_hill" // The token became king of the hill within the entry window
+	EntrySignalModelScore    = "model_score"      // A trained entry model scores the token's features above a threshold
+)
+
 type StrategyConfig struct {
 	// Entry conditions
 	MarketCapThreshold float64 `json:"marketCapThreshold"` // Minimum market cap in USD
@@ -33,11 +53,48 @@ type StrategyConfig struct {
 	MinBuysForEntry    int     `json:"minBuysForEntry"`    // Minimum buy trades to trigger entry
 	EntryTimeWindowSec int     `json:"entryTimeWindowSec"` // Time window for counting buys (seconds)
 
+	// Entry signal
+	EntrySignalType        string  `json:"entrySignalType,omitempty"`        // One of the EntrySignal* types, defaults to buy_count
+	MinSmartWalletBuys     int     `json:"minSmartWalletBuys,omitempty"`     // Distinct smart wallets that must buy for smart_money entries
+	MinUniqueBuyers        int     `json:"minUniqueBuyers,omitempty"`        // Distinct organic buyers required for unique_buyers entries
+	MinOrganicBuyVolumeSol float64 `json:"minOrganicBuyVolumeSol,omitempty"` // Organic buy volume in SOL required for organic_volume entries
+	EntryModelName         string  `json:"entryModelName,omitempty"`         // Trained entry model used by model_score entries
+	EntryModelVersion      int     `json:"entryModelVersion,omitempty"`      // Version of the entry model to use, 0 for the latest
+	MinModelScore          float64 `json:"minModelScore,omitempty"`          // Probability (0-1) the model must exceed for model_score entries
+
+	// Creator filters
+	MinCreatorReputation                float64 `json:"minCreatorReputation,omitempty"`                // Skip creators scoring below this (0-100)
+	MaxCreatorLaunchesWithoutGraduation int     `json:"maxCreatorLaunchesWithoutGraduation,omitempty"` // Skip creators with more launches than this and no graduation
+
+	// Holder filters
+	MaxTop10ConcentrationPct float64 `json:"maxTop10ConcentrationPct,omitempty"` // Skip tokens whose top 10 holders own more than this % of supply
+
+	// Launch filters
+	MaxBundledSupplyPct float64 `json:"maxBundledSupplyPct,omitempty"` // Skip tokens where bundled launch wallets bought more than this % of supply
+	ExcludeLaunchBuyers bool    `json:"excludeLaunchBuyers,omitempty"` // Don't count buys from bundled or sniper wallets towards minBuysForEntry
+
+	// Trade anomaly filters
+	MaxAnomalyScore float64 `json:"maxAnomalyScore,omitempty"` // Skip tokens whose wash-trading/bot anomaly score (0-100) is above this
+
+	// Feature filters, evaluated over one of the feature store's rolling windows
+	FeatureWindowSec         int     `json:"featureWindowSec,omitempty"`         // Window the feature filters use: 10, 30, 60 or 300 seconds (default 60)
+	MinNetFlowSol            float64 `json:"minNetFlowSol,omitempty"`            // Skip tokens whose buy minus sell volume in the window is below this
+	MinPriceVelocityPct      float64 `json:"minPriceVelocityPct,omitempty"`      // Skip tokens whose price rises slower than this % per minute in the window
+	MaxVolatilityPct         float64 `json:"maxVolatilityPct,omitempty"`         // Skip tokens whose trade-to-trade price volatility in the window is above this %
+	MaxSecondsSinceLastTrade int64   `json:"maxSecondsSinceLastTrade,omitempty"` // Skip tokens that have not traded for longer than this
+
 	// Exit conditions
 	TakeProfitPct  float64 `json:"takeProfitPct"`  // Take profit percentage
 	StopLossPct    float64 `json:"stopLossPct"`    // Stop loss percentage
 	MaxHoldTimeSec int     `json:"maxHoldTimeSec"` // Maximum hold time (seconds)
 
+	// Holder exits
+	ExitOnCreatorSell      bool    `json:"exitOnCreatorSell,omitempty"`      // Exit as soon as the creator sells any of their holding
+	ExitOnTopHolderDumpPct float64 `json:"exitOnTopHolderDumpPct,omitempty"` // Exit when a top 10 holder at entry sells at least this % of their holding
+
+	// Lifecycle exits
+	ExitOnGraduation bool `json:"exitOnGraduation,omitempty"` // Exit as soon as the token's bonding curve completes
+
 	// Position sizing
 	FixedPositionSizeSol float64 `json:"fixedPositionSizeSol"` // Fixed position size in SOL
 
@@ -68,6 +125,8 @@ type SimulationResult struct {
 	MaxDrawdown       float64   `json:"max_drawdown"`
 	PerformanceRating string    `json:"performance_rating"` // 'excellent', 'good', 'average', 'poor', 'very_poor'
 	Analysis          string    `json:"analysis,omitempty"`
+	PromptTemplate    string    `json:"prompt_template,omitempty"` // Prompt template the analysis was generated from
+	PromptVersion     int       `json:"prompt_version,omitempty"`  // Version of PromptTemplate
 	Rank              int       `json:"rank"`
 	CreatedAt         time.Time `json:"-"`
 }
@@ -146,22 +205,24 @@ type Trade struct {
 
 // SimulatedTrade represents a simulated trading activity
 type SimulatedTrade struct {
-	ID                int64     `json:"-"`
-	StrategyID        int64     `json:"-"`
-	TokenID           int64     `json:"-"`
-	SimulationRunID   *int64    `json:"-"`
-	EntryPrice        float64   `json:"entry_price"`
-	ExitPrice         *float64  `json:"exit_price,omitempty"`
-	EntryTimestamp    int64     `json:"entry_timestamp"`
-	ExitTimestamp     *int64    `json:"exit_timestamp,omitempty"`
-	PositionSize      float64   `json:"position_size"`
-	ProfitLoss        *float64  `json:"profit_loss,omitempty"`
-	Status            string    `json:"status"` // 'open', 'closed', 'canceled'
-	ExitReason        *string   `json:"exit_reason,omitempty"`
-	EntryUsdMarketCap float64   `json:"entry_usd_market_cap"`
-	ExitUsdMarketCap  *float64  `json:"exit_usd_market_cap,omitempty"`
-	CreatedAt         time.Time `json:"-"`
-	UpdatedAt         time.Time `json:"-"`
+	ID                int64            `json:"-"`
+	StrategyID        int64            `json:"-"`
+	TokenID           int64            `json:"-"`
+	SimulationRunID   *int64           `json:"-"`
+	EntryPrice        float64          `json:"entry_price"`
+	ExitPrice         *float64         `json:"exit_price,omitempty"`
+	EntryTimestamp    int64            `json:"entry_timestamp"`
+	ExitTimestamp     *int64           `json:"exit_timestamp,omitempty"`
+	PositionSize      float64          `json:"position_size"`
+	ProfitLoss        *float64         `json:"profit_loss,omitempty"`
+	Status            string           `json:"status"` // 'open', 'closed', 'canceled'
+	ExitReason        *string          `json:"exit_reason,omitempty"`
+	EntryUsdMarketCap float64          `json:"entry_usd_market_cap"`
+	ExitUsdMarketCap  *float64         `json:"exit_usd_market_cap,omitempty"`
+	ModelVersion      *string          `json:"model_version,omitempty"` // Entry model that scored the entry, as name@vN
+	PostMortem        *TradePostMortem `json:"post_mortem,omitempty"`   // AI diagnosis of a closed trade
+	CreatedAt         time.Time        `json:"-"`
+	UpdatedAt         time.Time        `json:"-"`
 }
 
 // Custom type for JSONB handling
@@ -0,0 +1,131 @@
+// internal/models/wallet_models.go
+package models
+
+import "time"
+
+// CreatorLaunch holds the facts about one token launch used to profile its creator
+type CreatorLaunch struct {
+	TokenID                int64   `json:"-"`
+	MintAddress            string  `json:"mint"`
+	CreatorAddress         string  `json:"creator"`
+	CreatedTimestamp       int64   `json:"created_timestamp"` // Unix milliseconds
+	Completed              bool    `json:"completed"`
+	KingOfTheHillTimeStamp int64   `json:"king_of_the_hill_timestamp"` // Unix milliseconds, 0 if never reached
+	UsdMarketCap           float64 `json:"usd_market_cap"`
+	MaxPrice               float64 `json:"max_price"`             // Highest trade price seen
+	LastPrice              float64 `json:"last_price"`            // Price of the most recent trade
+	FirstCreatorSellAt     int64   `json:"first_creator_sell_at"` // Unix seconds, 0 if the creator never sold
+}
+
+// CreatorProfile aggregates the launch history of a creator wallet
+type CreatorProfile struct {
+	CreatorAddress      string    `json:"creator"`
+	TokensLaunched      int       `json:"tokens_launched"`
+	TokensGraduated     int       `json:"tokens_graduated"`
+	GraduationRate      float64   `json:"graduation_rate"`
+	KothCount           int       `json:"koth_count"`
+	AvgTimeToKothSec    float64   `json:"avg_time_to_koth_sec"`
+	CreatorSellCount    int       `json:"creator_sell_count"`   // Launches in which the creator sold
+	AvgTimeToSellSec    float64   `json:"avg_time_to_sell_sec"` // Average time from launch to the creator's first sell
+	FastDumpCount       int       `json:"fast_dump_count"`      // Launches the creator sold within five minutes
+	AvgPeakUsdMarketCap float64   `json:"avg_peak_usd_market_cap"`
+	MaxPeakUsdMarketCap float64   `json:"max_peak_usd_market_cap"`
+	ReputationScore     float64   `json:"reputation_score"` // 0-100, higher is more trustworthy
+	FirstLaunchAt       int64     `json:"first_launch_at"`  // Unix milliseconds
+	LastLaunchAt        int64     `json:"last_launch_at"`   // Unix milliseconds
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// WalletPosition aggregates one wallet's trades in one token
+type WalletPosition struct {
+	WalletAddress string  `json:"wallet"`
+	TokenID       int64   `json:"-"`
+	BuySol        float64 `json:"buy_sol"`
+	BuyTokens     float64 `json:"buy_tokens"`
+	SellSol       float64 `json:"sell_sol"`
+	SellTokens    float64 `json:"sell_tokens"`
+	BuyCount      int     `json:"buy_count"`
+	SellCount     int     `json:"sell_count"`
+	LastTradeAt   int64   `json:"last_trade_at"` // Unix seconds
+	IsCreator     bool    `json:"is_creator"`    // The wallet launched the token
+}
+
+// WalletStats holds the realized trading performance of a wallet
+type WalletStats struct {
+	WalletAddress    string    `json:"wallet"`
+	TradeCount       int       `json:"trade_count"`
+	BuyCount         int       `json:"buy_count"`
+	SellCount        int       `json:"sell_count"`
+	TokensTraded     int       `json:"tokens_traded"`
+	ClosedPositions  int       `json:"closed_positions"`
+	WinningPositions int       `json:"winning_positions"`
+	HitRate          float64   `json:"hit_rate"`
+	RealizedPnlSol   float64   `json:"realized_pnl_sol"`
+	VolumeSol        float64   `json:"volume_sol"`
+	SmartScore       float64   `json:"smart_score"`
+	Rank             int       `json:"rank,omitempty"` // Position in the smart-wallet ranking, 0 if unranked
+	IsSmart          bool      `json:"is_smart"`
+	LastTradeAt      int64     `json:"last_trade_at"` // Unix seconds
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// HolderBalance is one wallet's net token balance in a mint
+type HolderBalance struct {
+	WalletAddress string  `json:"wallet"`
+	Balance       float64 `json:"balance"` // Raw token units, net of buys and sells seen
+	SupplyPct     float64 `json:"supply_pct"`
+}
+
+// HolderSnapshot summarizes the holder distribution of a token at a point in time
+type HolderSnapshot struct {
+	TokenID        int64            `json:"-"`
+	MintAddress    string           `json:"mint"`
+	CreatorAddress string           `json:"creator"`
+	UniqueHolders  int              `json:"unique_holders"`
+	Top10Pct       float64          `json:"top10_pct"` // Share of total supply held by the ten largest holders
+	CreatorBalance float64          `json:"creator_balance"`
+	CreatorPct     float64          `json:"creator_pct"`
+	TopHolders     []*HolderBalance `json:"top_holders"`
+	LastTradeID    int64            `json:"-"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// Labels assigned to wallets buying in a token's launch window
+const (
+	LaunchLabelCreator  = "creator"   // The token's creator buying its own launch
+	LaunchLabelBundle   = "bundle"    // Part of a cluster of wallets buying in the same second
+	LaunchLabelSameSize = "same_size" // Bought exactly the same SOL amount as other early wallets
+	LaunchLabelSniper   = "sniper"    // Bought within seconds of the launch
+	LaunchLabelFresh    = "fresh"     // No trade seen from the wallet before this launch
+)
+
+// LaunchBuyer is a wallet that bought during a token's launch window
+type LaunchBuyer struct {
+	WalletAddress string   `json:"wallet"`
+	FirstBuyAt    int64    `json:"first_buy_at"` // Unix seconds
+	BuyCount      int      `json:"buy_count"`
+	SolAmount     float64  `json:"sol_amount"`
+	TokenAmount   float64  `json:"token_amount"`
+	SupplyPct     float64  `json:"supply_pct"`
+	Labels        []string `json:"labels"`
+	Bundled       bool     `json:"bundled"` // Counted towards the bundled supply
+}
+
+// LaunchAnalysis labels the early buyers of a token and measures how much of the supply
+// went to bundled wallets
+type LaunchAnalysis struct {
+	TokenID            int64          `json:"-"`
+	MintAddress        string         `json:"mint"`
+	CreatorAddress     string         `json:"creator"`
+	WindowSec          int            `json:"window_sec"`
+	EarlyBuyCount      int            `json:"early_buy_count"`
+	EarlyBuyerCount    int            `json:"early_buyer_count"`
+	BundledWalletCount int            `json:"bundled_wallet_count"`
+	SniperCount        int            `json:"sniper_count"`
+	FreshWalletCount   int            `json:"fresh_wallet_count"`
+	BundledSupplyPct   float64        `json:"bundled_supply_pct"`
+	SniperSupplyPct    float64        `json:"sniper_supply_pct"`
+	Buyers             []*LaunchBuyer `json:"buyers"`
+	Final              bool           `json:"final"` // False while the launch window is still open
+	AnalyzedAt         time.Time      `json:"analyzed_at"`
+}
@@ -0,0 +1,116 @@
+// internal/repository/ai_generation_failure_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// AIGenerationFailureRepository handles database operations for rejected AI generations
+type AIGenerationFailureRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewAIGenerationFailureRepository creates a new AI generation failure repository
+func NewAIGenerationFailureRepository(db *sql.DB) *AIGenerationFailureRepository {
+	return &AIGenerationFailureRepository{db: db}
+}
+
+// Save inserts an AI generation failure into the database
+func (r *AIGenerationFailureRepository) Save(failure *models.AIGenerationFailure) (int64, error) {
+	errs := failure.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+	errorsJSON, err := json.Marshal(errs)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding AI generation failure errors: %v", err)
+	}
+
+	if failure.CreatedAt.IsZero() {
+		failure.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO ai_generation_failures
+			(purpose, provider, model, attempts, errors, last_response, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	var id int64
+	err = r.db.QueryRow(
+		query,
+		failure.Purpose,
+		failure.Provider,
+		failure.Model,
+		failure.Attempts,
+		errorsJSON,
+		failure.LastResponse,
+		failure.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving AI generation failure: %v", err)
+	}
+
+	failure.ID = id
+	return id, nil
+}
+
+// GetRecent retrieves the most recent AI generation failures, newest first
+func (r *AIGenerationFailureRepository) GetRecent(limit int) ([]*models.AIGenerationFailure, error) {
+	query := `
+		SELECT id, purpose, provider, model, attempts, errors, last_response, created_at
+		FROM ai_generation_failures
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting AI generation failures: %v", err)
+	}
+	defer rows.Close()
+
+	var failures []*models.AIGenerationFailure
+	for rows.Next() {
+		var failure models.AIGenerationFailure
+		var model, lastResponse sql.NullString
+		var errorsJSON []byte
+
+		if err := rows.Scan(
+			&failure.ID,
+			&failure.Purpose,
+			&failure.Provider,
+			&model,
+			&failure.Attempts,
+			&errorsJSON,
+			&lastResponse,
+			&failure.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning AI generation failure row: %v", err)
+		}
+
+		if err := json.Unmarshal(errorsJSON, &failure.Errors); err != nil {
+			return nil, fmt.Errorf("error decoding AI generation failure errors: %v", err)
+		}
+		failure.Model = model.String
+		failure.LastResponse = lastResponse.String
+
+		failures = append(failures, &failure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating AI generation failure rows: %v", err)
+	}
+
+	return failures, nil
+}
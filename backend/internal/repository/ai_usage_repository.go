@@ -0,0 +1,125 @@
+// internal/repository/ai_usage_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// AIUsageRepository handles database operations for LLM call records
+type AIUsageRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewAIUsageRepository creates a new AI usage repository
+func NewAIUsageRepository(db *sql.DB) *AIUsageRepository {
+	return &AIUsageRepository{db: db}
+}
+
+// Save inserts an LLM call record into the database
+func (r *AIUsageRepository) Save(usage *models.AIUsage) (int64, error) {
+	query := `
+		INSERT INTO ai_usage
+			(purpose, provider, model, input_tokens, output_tokens, latency_ms, outcome, error, cost_usd, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	if usage.CreatedAt.IsZero() {
+		usage.CreatedAt = time.Now()
+	}
+
+	var id int64
+	err := r.db.QueryRow(
+		query,
+		usage.Purpose,
+		usage.Provider,
+		usage.Model,
+		usage.InputTokens,
+		usage.OutputTokens,
+		usage.LatencyMs,
+		usage.Outcome,
+		usage.Error,
+		usage.CostUSD,
+		usage.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving AI usage: %v", err)
+	}
+
+	usage.ID = id
+	return id, nil
+}
+
+// SumTokens returns the input and output tokens spent on a purpose since a time
+func (r *AIUsageRepository) SumTokens(purpose string, since time.Time) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(input_tokens + output_tokens), 0)
+		FROM ai_usage
+		WHERE purpose = $1 AND created_at >= $2
+	`
+
+	var tokens int64
+	if err := r.db.QueryRow(query, purpose, since).Scan(&tokens); err != nil {
+		return 0, fmt.Errorf("error summing AI usage tokens: %v", err)
+	}
+	return tokens, nil
+}
+
+// Summarize aggregates the calls made since a time per purpose, ordered by purpose
+func (r *AIUsageRepository) Summarize(since time.Time) ([]*models.AIUsageSummary, error) {
+	query := `
+		SELECT
+			purpose,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE outcome = $2),
+			COUNT(*) FILTER (WHERE outcome = $3),
+			COUNT(*) FILTER (WHERE outcome NOT IN ($2, $3)),
+			COALESCE(SUM(input_tokens), 0),
+			COALESCE(SUM(output_tokens), 0),
+			COALESCE(SUM(cost_usd), 0),
+			COALESCE(AVG(latency_ms) FILTER (WHERE outcome IN ($2, $3)), 0)
+		FROM ai_usage
+		WHERE created_at >= $1
+		GROUP BY purpose
+		ORDER BY purpose
+	`
+
+	rows, err := r.db.Query(query, since, models.AIOutcomeSuccess, models.AIOutcomeError)
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing AI usage: %v", err)
+	}
+	defer rows.Close()
+
+	var summaries []*models.AIUsageSummary
+	for rows.Next() {
+		var summary models.AIUsageSummary
+		if err := rows.Scan(
+			&summary.Purpose,
+			&summary.Calls,
+			&summary.Successes,
+			&summary.Errors,
+			&summary.Blocked,
+			&summary.InputTokens,
+			&summary.OutputTokens,
+			&summary.CostUSD,
+			&summary.AvgLatencyMs,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning AI usage summary row: %v", err)
+		}
+		summaries = append(summaries, &summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating AI usage summary rows: %v", err)
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,163 @@
+// internal/repository/candle_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// CandleRepository handles database operations for OHLCV candles
+type CandleRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewCandleRepository creates a new candle repository
+func NewCandleRepository(db *sql.DB) *CandleRepository {
+	return &CandleRepository{db: db}
+}
+
+const upsertCandleQuery = `
+	INSERT INTO candles
+		(token_id, interval_sec, bucket_start, open, high, low, close, volume_sol, buy_volume_sol, sell_volume_sol, trade_count, buy_count, sell_count, updated_at)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW())
+	ON CONFLICT (token_id, interval_sec, bucket_start)
+	DO UPDATE SET
+		open = $4,
+		high = $5,
+		low = $6,
+		close = $7,
+		volume_sol = $8,
+		buy_volume_sol = $9,
+		sell_volume_sol = $10,
+		trade_count = $11,
+		buy_count = $12,
+		sell_count = $13,
+		updated_at = NOW()
+`
+
+// Upsert inserts a candle or replaces the stored values for its bucket
+func (r *CandleRepository) Upsert(candle *models.Candle) error {
+	_, err := r.db.Exec(
+		upsertCandleQuery,
+		candle.TokenID,
+		candle.IntervalSec,
+		candle.BucketStart,
+		candle.Open,
+		candle.High,
+		candle.Low,
+		candle.Close,
+		candle.VolumeSol,
+		candle.BuyVolumeSol,
+		candle.SellVolumeSol,
+		candle.TradeCount,
+		candle.BuyCount,
+		candle.SellCount,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving candle: %v", err)
+	}
+
+	return nil
+}
+
+// UpsertBatch upserts multiple candles in a single transaction
+func (r *CandleRepository) UpsertBatch(candles []*models.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting candle transaction: %v", err)
+	}
+
+	stmt, err := tx.Prepare(upsertCandleQuery)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error preparing candle statement: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, candle := range candles {
+		if _, err := stmt.Exec(
+			candle.TokenID,
+			candle.IntervalSec,
+			candle.BucketStart,
+			candle.Open,
+			candle.High,
+			candle.Low,
+			candle.Close,
+			candle.VolumeSol,
+			candle.BuyVolumeSol,
+			candle.SellVolumeSol,
+			candle.TradeCount,
+			candle.BuyCount,
+			candle.SellCount,
+		); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("error saving candle: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing candles: %v", err)
+	}
+
+	return nil
+}
+
+// GetByToken retrieves candles for a token and interval with bucket starts in [from, to]
+func (r *CandleRepository) GetByToken(tokenID int64, intervalSec int, from, to int64, limit int) ([]*models.Candle, error) {
+	query := `
+		SELECT c.id, c.token_id, tk.mint_address, c.interval_sec, c.bucket_start, c.open, c.high, c.low, c.close,
+			c.volume_sol, c.buy_volume_sol, c.sell_volume_sol, c.trade_count, c.buy_count, c.sell_count, c.updated_at
+		FROM candles c
+		JOIN tokens tk ON tk.id = c.token_id
+		WHERE c.token_id = $1 AND c.interval_sec = $2 AND c.bucket_start >= $3 AND c.bucket_start <= $4
+		ORDER BY c.bucket_start ASC
+		LIMIT $5
+	`
+
+	rows, err := r.db.Query(query, tokenID, intervalSec, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting candles: %v", err)
+	}
+	defer rows.Close()
+
+	var candles []*models.Candle
+	for rows.Next() {
+		var candle models.Candle
+		if err := rows.Scan(
+			&candle.ID,
+			&candle.TokenID,
+			&candle.MintAddress,
+			&candle.IntervalSec,
+			&candle.BucketStart,
+			&candle.Open,
+			&candle.High,
+			&candle.Low,
+			&candle.Close,
+			&candle.VolumeSol,
+			&candle.BuyVolumeSol,
+			&candle.SellVolumeSol,
+			&candle.TradeCount,
+			&candle.BuyCount,
+			&candle.SellCount,
+			&candle.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning candle row: %v", err)
+		}
+		candles = append(candles, &candle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candle rows: %v", err)
+	}
+
+	return candles, nil
+}
@@ -0,0 +1,90 @@
+// internal/repository/candle_repository_test.go
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandleRepositoryUpsert(t *testing.T) {
+	// Setup mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock: %v", err)
+	}
+
+	candle := &models.Candle{
+		TokenID:       1,
+		IntervalSec:   60,
+		BucketStart:   1700000040,
+		Open:          0.01,
+		High:          0.03,
+		Low:           0.005,
+		Close:         0.02,
+		VolumeSol:     6.5,
+		BuyVolumeSol:  6,
+		SellVolumeSol: 0.5,
+		TradeCount:    4,
+		BuyCount:      3,
+		SellCount:     1,
+	}
+
+	mock.ExpectExec(`INSERT INTO candles`).
+		WithArgs(
+			candle.TokenID,
+			candle.IntervalSec,
+			candle.BucketStart,
+			candle.Open,
+			candle.High,
+			candle.Low,
+			candle.Close,
+			candle.VolumeSol,
+			candle.BuyVolumeSol,
+			candle.SellVolumeSol,
+			candle.TradeCount,
+			candle.BuyCount,
+			candle.SellCount,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewCandleRepository(db)
+	err = repo.Upsert(candle)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCandleRepositoryGetByToken(t *testing.T) {
+	// Setup mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock: %v", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "token_id", "mint_address", "interval_sec", "bucket_start", "open", "high", "low", "close",
+		"volume_sol", "buy_volume_sol", "sell_volume_sol", "trade_count", "buy_count", "sell_count", "updated_at",
+	}).
+		AddRow(1, 1, "test-mint", 60, 1700000040, 0.01, 0.03, 0.005, 0.02, 6.5, 6.0, 0.5, 4, 3, 1, now).
+		AddRow(2, 1, "test-mint", 60, 1700000100, 0.02, 0.02, 0.015, 0.015, 1.0, 0.0, 1.0, 1, 0, 1, now)
+
+	mock.ExpectQuery(`SELECT (.+) FROM candles`).
+		WithArgs(int64(1), 60, int64(1700000000), int64(1700000200), 100).
+		WillReturnRows(rows)
+
+	repo := NewCandleRepository(db)
+	candles, err := repo.GetByToken(1, 60, 1700000000, 1700000200, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, candles, 2)
+	assert.Equal(t, "test-mint", candles[0].MintAddress)
+	assert.Equal(t, int64(1700000040), candles[0].BucketStart)
+	assert.Equal(t, 4, candles[0].TradeCount)
+	assert.Equal(t, 0.015, candles[1].Close)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
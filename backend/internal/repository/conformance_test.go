@@ -0,0 +1,81 @@
+// internal/repository/conformance_test.go
+package repository_test
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/repotest"
+	_ "github.com/lib/pq" // PostgreSQL driver
+)
+
+// conformanceTables lists every table the migrations create, truncated between cases
+var conformanceTables = []string{
+	"strategies", "simulation_runs", "tokens", "trades", "simulated_trades", "strategy_metrics",
+	"simulation_results", "simulation_events", "strategy_generations", "candles", "feed_metrics",
+	"data_gaps", "creator_profiles", "wallet_stats", "launch_analyses", "token_anomalies",
+	"token_transitions", "token_feature_snapshots", "token_outcomes", "entry_models",
+	"ai_generation_failures", "ai_usage", "strategy_candidates", "strategy_drafts",
+}
+
+// TestPostgresConformance runs the repository conformance suite against a real database.
+// It is skipped unless TEST_DATABASE_URL points at a disposable Postgres database; every
+// table in it is truncated.
+func TestPostgresConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer db.Close()
+
+	migration, err := os.ReadFile("../../../db/migrations/up.sql")
+	if err != nil {
+		t.Fatalf("Error reading migrations: %v", err)
+	}
+	if _, err := db.Exec(string(migration)); err != nil {
+		t.Fatalf("Error applying migrations: %v", err)
+	}
+
+	truncate := "TRUNCATE " + strings.Join(conformanceTables, ", ") + " RESTART IDENTITY CASCADE"
+	repotest.Run(t, func(t *testing.T) *repotest.Repositories {
+		if _, err := db.Exec(truncate); err != nil {
+			t.Fatalf("Error truncating tables: %v", err)
+		}
+		return &repotest.Repositories{
+			Dashboard:          repository.NewDashboardRepository(db),
+			Token:              repository.NewTokenRepository(db),
+			Trade:              repository.NewTradeRepository(db),
+			Strategy:           repository.NewStrategyRepository(db),
+			StrategyMetric:     repository.NewStrategyMetricRepository(db),
+			SimulationRun:      repository.NewSimulationRunRepository(db),
+			SimulationResult:   repository.NewSimulationResultRepository(db),
+			StrategyGeneration: repository.NewStrategyGenerationRepository(db),
+			SimulatedTrade:     repository.NewSimulatedTradeRepository(db),
+			SimulationEvent:    repository.NewSimulationEventRepository(db),
+			Candle:             repository.NewCandleRepository(db),
+			FeedMetric:         repository.NewFeedMetricRepository(db),
+			DataGap:            repository.NewDataGapRepository(db),
+			CreatorProfile:     repository.NewCreatorProfileRepository(db),
+			WalletStats:        repository.NewWalletStatsRepository(db),
+			LaunchAnalysis:     repository.NewLaunchAnalysisRepository(db),
+			TokenAnomaly:       repository.NewTokenAnomalyRepository(db),
+			TokenTransition:    repository.NewTokenTransitionRepository(db),
+			TokenFeature:       repository.NewTokenFeatureRepository(db),
+			TokenOutcome:       repository.NewTokenOutcomeRepository(db),
+			EntryModel:         repository.NewEntryModelRepository(db),
+
+			AIGenerationFailure: repository.NewAIGenerationFailureRepository(db),
+			AIUsage:             repository.NewAIUsageRepository(db),
+			StrategyCandidate:   repository.NewStrategyCandidateRepository(db),
+			StrategyDraft:       repository.NewStrategyDraftRepository(db),
+		}
+	})
+}
@@ -0,0 +1,256 @@
+// internal/repository/creator_profile_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// CreatorProfileRepository handles database operations for creator reputation profiles
+type CreatorProfileRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewCreatorProfileRepository creates a new creator profile repository
+func NewCreatorProfileRepository(db *sql.DB) *CreatorProfileRepository {
+	return &CreatorProfileRepository{db: db}
+}
+
+// Upsert inserts or replaces the profile of a creator
+func (r *CreatorProfileRepository) Upsert(profile *models.CreatorProfile) error {
+	query := `
+		INSERT INTO creator_profiles
+			(creator_address, tokens_launched, tokens_graduated, graduation_rate, koth_count, avg_time_to_koth_sec,
+			 creator_sell_count, avg_time_to_sell_sec, fast_dump_count, avg_peak_usd_market_cap, max_peak_usd_market_cap,
+			 reputation_score, first_launch_at, last_launch_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW())
+		ON CONFLICT (creator_address)
+		DO UPDATE SET
+			tokens_launched = $2,
+			tokens_graduated = $3,
+			graduation_rate = $4,
+			koth_count = $5,
+			avg_time_to_koth_sec = $6,
+			creator_sell_count = $7,
+			avg_time_to_sell_sec = $8,
+			fast_dump_count = $9,
+			avg_peak_usd_market_cap = $10,
+			max_peak_usd_market_cap = $11,
+			reputation_score = $12,
+			first_launch_at = $13,
+			last_launch_at = $14,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		profile.CreatorAddress,
+		profile.TokensLaunched,
+		profile.TokensGraduated,
+		profile.GraduationRate,
+		profile.KothCount,
+		profile.AvgTimeToKothSec,
+		profile.CreatorSellCount,
+		profile.AvgTimeToSellSec,
+		profile.FastDumpCount,
+		profile.AvgPeakUsdMarketCap,
+		profile.MaxPeakUsdMarketCap,
+		profile.ReputationScore,
+		profile.FirstLaunchAt,
+		profile.LastLaunchAt,
+	).Scan(&profile.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("error saving creator profile: %v", err)
+	}
+
+	return nil
+}
+
+// GetByAddress retrieves the profile of a creator
+func (r *CreatorProfileRepository) GetByAddress(creatorAddress string) (*models.CreatorProfile, error) {
+	query := `
+		SELECT creator_address, tokens_launched, tokens_graduated, graduation_rate, koth_count, avg_time_to_koth_sec,
+			creator_sell_count, avg_time_to_sell_sec, fast_dump_count, avg_peak_usd_market_cap, max_peak_usd_market_cap,
+			reputation_score, COALESCE(first_launch_at, 0), COALESCE(last_launch_at, 0), updated_at
+		FROM creator_profiles
+		WHERE creator_address = $1
+	`
+
+	rows, err := r.db.Query(query, creatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting creator profile: %v", err)
+	}
+	defer rows.Close()
+
+	profiles, err := r.scanProfileRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+
+	return profiles[0], nil
+}
+
+// GetTop retrieves creators with at least minLaunches launches ordered by reputation.
+// With ascending set the least trustworthy creators come first.
+func (r *CreatorProfileRepository) GetTop(minLaunches int, ascending bool, limit int) ([]*models.CreatorProfile, error) {
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT creator_address, tokens_launched, tokens_graduated, graduation_rate, koth_count, avg_time_to_koth_sec,
+			creator_sell_count, avg_time_to_sell_sec, fast_dump_count, avg_peak_usd_market_cap, max_peak_usd_market_cap,
+			reputation_score, COALESCE(first_launch_at, 0), COALESCE(last_launch_at, 0), updated_at
+		FROM creator_profiles
+		WHERE tokens_launched >= $1
+		ORDER BY reputation_score %s, tokens_launched DESC
+		LIMIT $2
+	`, order)
+
+	rows, err := r.db.Query(query, minLaunches, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting top creator profiles: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanProfileRows(rows)
+}
+
+// GetLaunches retrieves every known launch of a creator with its price extremes and the
+// creator's first sell
+func (r *CreatorProfileRepository) GetLaunches(creatorAddress string) ([]*models.CreatorLaunch, error) {
+	query := `
+		SELECT t.id, t.mint_address, t.creator_address, t.created_timestamp, COALESCE(t.completed, FALSE),
+			COALESCE(t.king_of_the_hill_timestamp, 0), COALESCE(t.usd_market_cap, 0),
+			COALESCE(p.max_price, 0), COALESCE(p.last_price, 0), COALESCE(cs.first_sell, 0)
+		FROM tokens t
+		LEFT JOIN LATERAL (
+			SELECT
+				MAX(tr.sol_amount / NULLIF(tr.token_amount, 0)) AS max_price,
+				(SELECT tl.sol_amount / NULLIF(tl.token_amount, 0)
+				 FROM trades tl
+				 WHERE tl.token_id = t.id
+				 ORDER BY tl.timestamp DESC, tl.id DESC
+				 LIMIT 1) AS last_price
+			FROM trades tr
+			WHERE tr.token_id = t.id
+		) p ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT MIN(tr.timestamp) AS first_sell
+			FROM trades tr
+			WHERE tr.token_id = t.id AND tr.user_address = t.creator_address AND tr.is_buy = FALSE
+		) cs ON TRUE
+		WHERE t.creator_address = $1
+		ORDER BY t.created_timestamp ASC
+	`
+
+	rows, err := r.db.Query(query, creatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting creator launches: %v", err)
+	}
+	defer rows.Close()
+
+	var launches []*models.CreatorLaunch
+	for rows.Next() {
+		var launch models.CreatorLaunch
+		if err := rows.Scan(
+			&launch.TokenID,
+			&launch.MintAddress,
+			&launch.CreatorAddress,
+			&launch.CreatedTimestamp,
+			&launch.Completed,
+			&launch.KingOfTheHillTimeStamp,
+			&launch.UsdMarketCap,
+			&launch.MaxPrice,
+			&launch.LastPrice,
+			&launch.FirstCreatorSellAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning creator launch row: %v", err)
+		}
+		launches = append(launches, &launch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating creator launch rows: %v", err)
+	}
+
+	return launches, nil
+}
+
+// GetCreatorAddresses retrieves distinct creators with a launch at or after sinceMs,
+// ordered by address and paged with afterAddress
+func (r *CreatorProfileRepository) GetCreatorAddresses(sinceMs int64, afterAddress string, limit int) ([]string, error) {
+	query := `
+		SELECT DISTINCT creator_address
+		FROM tokens
+		WHERE created_timestamp >= $1 AND creator_address > $2
+		ORDER BY creator_address ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, sinceMs, afterAddress, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting creator addresses: %v", err)
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("error scanning creator address row: %v", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating creator address rows: %v", err)
+	}
+
+	return addresses, nil
+}
+
+// scanProfileRows scans creator profile rows
+func (r *CreatorProfileRepository) scanProfileRows(rows *sql.Rows) ([]*models.CreatorProfile, error) {
+	var profiles []*models.CreatorProfile
+	for rows.Next() {
+		var profile models.CreatorProfile
+		if err := rows.Scan(
+			&profile.CreatorAddress,
+			&profile.TokensLaunched,
+			&profile.TokensGraduated,
+			&profile.GraduationRate,
+			&profile.KothCount,
+			&profile.AvgTimeToKothSec,
+			&profile.CreatorSellCount,
+			&profile.AvgTimeToSellSec,
+			&profile.FastDumpCount,
+			&profile.AvgPeakUsdMarketCap,
+			&profile.MaxPeakUsdMarketCap,
+			&profile.ReputationScore,
+			&profile.FirstLaunchAt,
+			&profile.LastLaunchAt,
+			&profile.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning creator profile row: %v", err)
+		}
+		profiles = append(profiles, &profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating creator profile rows: %v", err)
+	}
+
+	return profiles, nil
+}
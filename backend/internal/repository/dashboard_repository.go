@@ -713,8 +713,8 @@ func (r *DashboardRepository) GetRecentPerformance() ([]models.RecentPerformance
 				SELECT
 					COUNT(*) as trade_count,
 					SUM(CASE WHEN profit_loss > 0 THEN 1 ELSE 0 END) as winning_trades,
-					SUM(COALESCE(profit_loss, 0)) as total_profit,
-					MAX((profit_loss / position_size) * 100) as best_trade_pct
+					COALESCE(SUM(profit_loss), 0) as total_profit,
+					COALESCE(MAX((profit_loss / NULLIF(position_size, 0)) * 100), 0) as best_trade_pct
 				FROM simulated_trades
 				WHERE exit_timestamp IS NOT NULL
 				AND exit_timestamp > $1
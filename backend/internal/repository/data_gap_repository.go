@@ -0,0 +1,170 @@
+// internal/repository/data_gap_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// DataGapRepository handles database operations for detected feed gaps
+type DataGapRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewDataGapRepository creates a new data gap repository
+func NewDataGapRepository(db *sql.DB) *DataGapRepository {
+	return &DataGapRepository{db: db}
+}
+
+// Save inserts a data gap into the database
+func (r *DataGapRepository) Save(gap *models.DataGap) (int64, error) {
+	query := `
+		INSERT INTO data_gaps
+			(source, started_at, ended_at, duration_sec, reason, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	if gap.CreatedAt.IsZero() {
+		gap.CreatedAt = time.Now()
+	}
+
+	var endedAt sql.NullTime
+	if gap.EndedAt != nil {
+		endedAt = sql.NullTime{Time: *gap.EndedAt, Valid: true}
+	}
+
+	var id int64
+	err := r.db.QueryRow(
+		query,
+		gap.Source,
+		gap.StartedAt,
+		endedAt,
+		gap.DurationSec,
+		gap.Reason,
+		gap.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving data gap: %v", err)
+	}
+
+	return id, nil
+}
+
+// Close marks a gap as ended and records its duration
+func (r *DataGapRepository) Close(id int64, endedAt time.Time) error {
+	query := `
+		UPDATE data_gaps
+		SET ended_at = $2, duration_sec = EXTRACT(EPOCH FROM ($2 - started_at))
+		WHERE id = $1 AND ended_at IS NULL
+	`
+
+	if _, err := r.db.Exec(query, id, endedAt); err != nil {
+		return fmt.Errorf("error closing data gap: %v", err)
+	}
+
+	return nil
+}
+
+// GetOpen retrieves the currently open gap for a source, if any
+func (r *DataGapRepository) GetOpen(source string) (*models.DataGap, error) {
+	query := `
+		SELECT id, source, started_at, ended_at, duration_sec, reason, created_at
+		FROM data_gaps
+		WHERE source = $1 AND ended_at IS NULL
+		ORDER BY started_at DESC
+		LIMIT 1
+	`
+
+	rows, err := r.db.Query(query, source)
+	if err != nil {
+		return nil, fmt.Errorf("error getting open data gap: %v", err)
+	}
+	defer rows.Close()
+
+	gaps, err := r.scanGapRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(gaps) == 0 {
+		return nil, nil
+	}
+
+	return gaps[0], nil
+}
+
+// GetOverlapping retrieves gaps that overlap the [from, to] time range, including open gaps
+func (r *DataGapRepository) GetOverlapping(from, to time.Time) ([]*models.DataGap, error) {
+	query := `
+		SELECT id, source, started_at, ended_at, duration_sec, reason, created_at
+		FROM data_gaps
+		WHERE started_at <= $2 AND (ended_at IS NULL OR ended_at >= $1)
+		ORDER BY started_at ASC
+	`
+
+	rows, err := r.db.Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error getting overlapping data gaps: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanGapRows(rows)
+}
+
+// GetRecent retrieves the most recent gaps
+func (r *DataGapRepository) GetRecent(limit int) ([]*models.DataGap, error) {
+	query := `
+		SELECT id, source, started_at, ended_at, duration_sec, reason, created_at
+		FROM data_gaps
+		ORDER BY started_at DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recent data gaps: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanGapRows(rows)
+}
+
+// scanGapRows scans data gap rows
+func (r *DataGapRepository) scanGapRows(rows *sql.Rows) ([]*models.DataGap, error) {
+	var gaps []*models.DataGap
+	for rows.Next() {
+		var gap models.DataGap
+		var endedAt sql.NullTime
+		if err := rows.Scan(
+			&gap.ID,
+			&gap.Source,
+			&gap.StartedAt,
+			&endedAt,
+			&gap.DurationSec,
+			&gap.Reason,
+			&gap.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning data gap row: %v", err)
+		}
+		if endedAt.Valid {
+			ended := endedAt.Time
+			gap.EndedAt = &ended
+		} else {
+			gap.DurationSec = time.Since(gap.StartedAt).Seconds()
+		}
+		gaps = append(gaps, &gap)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating data gap rows: %v", err)
+	}
+
+	return gaps, nil
+}
@@ -0,0 +1,140 @@
+// internal/repository/entry_model_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// entryModelArtifact is the stored form of a model's parameters
+type entryModelArtifact struct {
+	Features []string  `json:"features"`
+	Weights  []float64 `json:"weights"`
+	Bias     float64   `json:"bias"`
+	Means    []float64 `json:"means"`
+	Scales   []float64 `json:"scales"`
+}
+
+// EntryModelRepository handles database operations for trained entry models
+type EntryModelRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewEntryModelRepository creates a new entry model repository
+func NewEntryModelRepository(db *sql.DB) *EntryModelRepository {
+	return &EntryModelRepository{db: db}
+}
+
+// Save stores a model as the next version of its name and sets its ID, version and
+// creation time
+func (r *EntryModelRepository) Save(model *models.EntryModel) error {
+	artifact, err := json.Marshal(entryModelArtifact{
+		Features: model.Features,
+		Weights:  model.Weights,
+		Bias:     model.Bias,
+		Means:    model.Means,
+		Scales:   model.Scales,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding entry model artifact: %v", err)
+	}
+
+	metrics, err := json.Marshal(model.Metrics)
+	if err != nil {
+		return fmt.Errorf("error encoding entry model metrics: %v", err)
+	}
+
+	query := `
+		INSERT INTO entry_models
+			(name, version, model_type, target, artifact, metrics, trained_rows, created_at)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5, $6, NOW()
+		FROM entry_models
+		WHERE name = $1
+		RETURNING id, version, created_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		model.Name,
+		model.ModelType,
+		model.Target,
+		artifact,
+		metrics,
+		model.TrainedRows,
+	).Scan(&model.ID, &model.Version, &model.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("error saving entry model: %v", err)
+	}
+
+	return nil
+}
+
+// GetLatest retrieves the newest version of a model, or nil if none has been trained
+func (r *EntryModelRepository) GetLatest(name string) (*models.EntryModel, error) {
+	query := `
+		SELECT id, name, version, model_type, target, artifact, metrics, trained_rows, created_at
+		FROM entry_models
+		WHERE name = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	return r.scanModel(r.db.QueryRow(query, name))
+}
+
+// GetByVersion retrieves one version of a model, or nil if it doesn't exist
+func (r *EntryModelRepository) GetByVersion(name string, version int) (*models.EntryModel, error) {
+	query := `
+		SELECT id, name, version, model_type, target, artifact, metrics, trained_rows, created_at
+		FROM entry_models
+		WHERE name = $1 AND version = $2
+	`
+
+	return r.scanModel(r.db.QueryRow(query, name, version))
+}
+
+// scanModel scans an entry model row
+func (r *EntryModelRepository) scanModel(row *sql.Row) (*models.EntryModel, error) {
+	var model models.EntryModel
+	var artifact, metrics []byte
+
+	err := row.Scan(
+		&model.ID,
+		&model.Name,
+		&model.Version,
+		&model.ModelType,
+		&model.Target,
+		&artifact,
+		&metrics,
+		&model.TrainedRows,
+		&model.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting entry model: %v", err)
+	}
+
+	var params entryModelArtifact
+	if err := json.Unmarshal(artifact, &params); err != nil {
+		return nil, fmt.Errorf("error decoding entry model artifact: %v", err)
+	}
+	model.Features = params.Features
+	model.Weights = params.Weights
+	model.Bias = params.Bias
+	model.Means = params.Means
+	model.Scales = params.Scales
+
+	if err := json.Unmarshal(metrics, &model.Metrics); err != nil {
+		return nil, fmt.Errorf("error decoding entry model metrics: %v", err)
+	}
+
+	return &model, nil
+}
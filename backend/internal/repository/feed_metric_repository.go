@@ -0,0 +1,140 @@
+// internal/repository/feed_metric_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// FeedMetricRepository handles database operations for collector feed metrics
+type FeedMetricRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewFeedMetricRepository creates a new feed metric repository
+func NewFeedMetricRepository(db *sql.DB) *FeedMetricRepository {
+	return &FeedMetricRepository{db: db}
+}
+
+// Save inserts a feed metric into the database
+func (r *FeedMetricRepository) Save(metric *models.FeedMetric) (int64, error) {
+	query := `
+		INSERT INTO feed_metrics
+			(source, period_start, period_end, message_count, trade_count, token_count, connected, last_message_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	if metric.CreatedAt.IsZero() {
+		metric.CreatedAt = time.Now()
+	}
+
+	var lastMessageAt sql.NullTime
+	if !metric.LastMessageAt.IsZero() {
+		lastMessageAt = sql.NullTime{Time: metric.LastMessageAt, Valid: true}
+	}
+
+	var id int64
+	err := r.db.QueryRow(
+		query,
+		metric.Source,
+		metric.PeriodStart,
+		metric.PeriodEnd,
+		metric.MessageCount,
+		metric.TradeCount,
+		metric.TokenCount,
+		metric.Connected,
+		lastMessageAt,
+		metric.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving feed metric: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetLatest retrieves the most recent feed metric for a source
+func (r *FeedMetricRepository) GetLatest(source string) (*models.FeedMetric, error) {
+	query := `
+		SELECT id, source, period_start, period_end, message_count, trade_count, token_count, connected, last_message_at, created_at
+		FROM feed_metrics
+		WHERE source = $1
+		ORDER BY period_end DESC
+		LIMIT 1
+	`
+
+	rows, err := r.db.Query(query, source)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest feed metric: %v", err)
+	}
+	defer rows.Close()
+
+	metrics, err := r.scanMetricRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+
+	return metrics[0], nil
+}
+
+// GetSince retrieves feed metrics for a source with periods ending after the given time
+func (r *FeedMetricRepository) GetSince(source string, since time.Time) ([]*models.FeedMetric, error) {
+	query := `
+		SELECT id, source, period_start, period_end, message_count, trade_count, token_count, connected, last_message_at, created_at
+		FROM feed_metrics
+		WHERE source = $1 AND period_end > $2
+		ORDER BY period_end ASC
+	`
+
+	rows, err := r.db.Query(query, source, since)
+	if err != nil {
+		return nil, fmt.Errorf("error getting feed metrics: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanMetricRows(rows)
+}
+
+// scanMetricRows scans feed metric rows
+func (r *FeedMetricRepository) scanMetricRows(rows *sql.Rows) ([]*models.FeedMetric, error) {
+	var metrics []*models.FeedMetric
+	for rows.Next() {
+		var metric models.FeedMetric
+		var lastMessageAt sql.NullTime
+		if err := rows.Scan(
+			&metric.ID,
+			&metric.Source,
+			&metric.PeriodStart,
+			&metric.PeriodEnd,
+			&metric.MessageCount,
+			&metric.TradeCount,
+			&metric.TokenCount,
+			&metric.Connected,
+			&lastMessageAt,
+			&metric.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning feed metric row: %v", err)
+		}
+		if lastMessageAt.Valid {
+			metric.LastMessageAt = lastMessageAt.Time
+		}
+		metrics = append(metrics, &metric)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feed metric rows: %v", err)
+	}
+
+	return metrics, nil
+}
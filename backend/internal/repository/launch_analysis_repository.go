@@ -0,0 +1,225 @@
+// internal/repository/launch_analysis_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// LaunchAnalysisRepository handles database operations for token launch analyses
+type LaunchAnalysisRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewLaunchAnalysisRepository creates a new launch analysis repository
+func NewLaunchAnalysisRepository(db *sql.DB) *LaunchAnalysisRepository {
+	return &LaunchAnalysisRepository{db: db}
+}
+
+// Upsert inserts or replaces the launch analysis of a token
+func (r *LaunchAnalysisRepository) Upsert(analysis *models.LaunchAnalysis) error {
+	buyers, err := json.Marshal(analysis.Buyers)
+	if err != nil {
+		return fmt.Errorf("error encoding launch buyers: %v", err)
+	}
+
+	query := `
+		INSERT INTO launch_analyses
+			(token_id, window_sec, early_buy_count, early_buyer_count, bundled_wallet_count, sniper_count,
+			 fresh_wallet_count, bundled_supply_pct, sniper_supply_pct, buyers, analyzed_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT (token_id)
+		DO UPDATE SET
+			window_sec = $2,
+			early_buy_count = $3,
+			early_buyer_count = $4,
+			bundled_wallet_count = $5,
+			sniper_count = $6,
+			fresh_wallet_count = $7,
+			bundled_supply_pct = $8,
+			sniper_supply_pct = $9,
+			buyers = $10,
+			analyzed_at = NOW()
+		RETURNING analyzed_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		analysis.TokenID,
+		analysis.WindowSec,
+		analysis.EarlyBuyCount,
+		analysis.EarlyBuyerCount,
+		analysis.BundledWalletCount,
+		analysis.SniperCount,
+		analysis.FreshWalletCount,
+		analysis.BundledSupplyPct,
+		analysis.SniperSupplyPct,
+		buyers,
+	).Scan(&analysis.AnalyzedAt)
+
+	if err != nil {
+		return fmt.Errorf("error saving launch analysis: %v", err)
+	}
+
+	return nil
+}
+
+// GetByTokenID retrieves the stored launch analysis of a token
+func (r *LaunchAnalysisRepository) GetByTokenID(tokenID int64) (*models.LaunchAnalysis, error) {
+	query := `
+		SELECT la.token_id, t.mint_address, t.creator_address, la.window_sec, la.early_buy_count,
+			la.early_buyer_count, la.bundled_wallet_count, la.sniper_count, la.fresh_wallet_count,
+			la.bundled_supply_pct, la.sniper_supply_pct, la.buyers, la.analyzed_at
+		FROM launch_analyses la
+		JOIN tokens t ON t.id = la.token_id
+		WHERE la.token_id = $1
+	`
+
+	var analysis models.LaunchAnalysis
+	var buyers []byte
+	err := r.db.QueryRow(query, tokenID).Scan(
+		&analysis.TokenID,
+		&analysis.MintAddress,
+		&analysis.CreatorAddress,
+		&analysis.WindowSec,
+		&analysis.EarlyBuyCount,
+		&analysis.EarlyBuyerCount,
+		&analysis.BundledWalletCount,
+		&analysis.SniperCount,
+		&analysis.FreshWalletCount,
+		&analysis.BundledSupplyPct,
+		&analysis.SniperSupplyPct,
+		&buyers,
+		&analysis.AnalyzedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting launch analysis: %v", err)
+	}
+
+	if err := json.Unmarshal(buyers, &analysis.Buyers); err != nil {
+		return nil, fmt.Errorf("error decoding launch buyers: %v", err)
+	}
+	analysis.Final = true
+
+	return &analysis, nil
+}
+
+// GetUnanalyzedTokens retrieves tokens created between fromMs and toMs (unix ms) that have
+// no stored launch analysis, oldest first
+func (r *LaunchAnalysisRepository) GetUnanalyzedTokens(fromMs, toMs int64, limit int) ([]*models.Token, error) {
+	query := `
+		SELECT t.id, t.mint_address, t.creator_address, t.created_timestamp
+		FROM tokens t
+		LEFT JOIN launch_analyses la ON la.token_id = t.id
+		WHERE t.created_timestamp >= $1 AND t.created_timestamp < $2 AND la.token_id IS NULL
+		ORDER BY t.created_timestamp ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, fromMs, toMs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting unanalyzed tokens: %v", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.Token
+	for rows.Next() {
+		var token models.Token
+		if err := rows.Scan(&token.ID, &token.MintAddress, &token.CreatorAddress, &token.CreatedTimestamp); err != nil {
+			return nil, fmt.Errorf("error scanning unanalyzed token row: %v", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unanalyzed token rows: %v", err)
+	}
+
+	return tokens, nil
+}
+
+// GetLaunchTrades retrieves the trades of a token up to untilSec (unix seconds) in
+// execution order
+func (r *LaunchAnalysisRepository) GetLaunchTrades(tokenID int64, untilSec int64, limit int) ([]*models.Trade, error) {
+	query := `
+		SELECT id, token_id, signature, sol_amount, token_amount, is_buy, user_address, timestamp
+		FROM trades
+		WHERE token_id = $1 AND timestamp <= $2
+		ORDER BY timestamp ASC, id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, tokenID, untilSec, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting launch trades: %v", err)
+	}
+	defer rows.Close()
+
+	var trades []*models.Trade
+	for rows.Next() {
+		var trade models.Trade
+		if err := rows.Scan(
+			&trade.ID,
+			&trade.TokenID,
+			&trade.Signature,
+			&trade.SolAmount,
+			&trade.TokenAmount,
+			&trade.IsBuy,
+			&trade.UserAddress,
+			&trade.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning launch trade row: %v", err)
+		}
+		trades = append(trades, &trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating launch trade rows: %v", err)
+	}
+
+	return trades, nil
+}
+
+// GetFirstTradeTimes returns the timestamp (unix seconds) of the first trade seen from
+// each of the given wallets. Wallets with no trades are left out.
+func (r *LaunchAnalysisRepository) GetFirstTradeTimes(walletAddresses []string) (map[string]int64, error) {
+	query := `
+		SELECT user_address, MIN(timestamp)
+		FROM trades
+		WHERE user_address = ANY($1)
+		GROUP BY user_address
+	`
+
+	rows, err := r.db.Query(query, pq.Array(walletAddresses))
+	if err != nil {
+		return nil, fmt.Errorf("error getting wallet first trades: %v", err)
+	}
+	defer rows.Close()
+
+	firstTrades := make(map[string]int64, len(walletAddresses))
+	for rows.Next() {
+		var address string
+		var timestamp int64
+		if err := rows.Scan(&address, &timestamp); err != nil {
+			return nil, fmt.Errorf("error scanning wallet first trade row: %v", err)
+		}
+		firstTrades[address] = timestamp
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wallet first trade rows: %v", err)
+	}
+
+	return firstTrades, nil
+}
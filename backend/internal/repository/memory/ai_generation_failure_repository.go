@@ -0,0 +1,58 @@
+// internal/repository/memory/ai_generation_failure_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.AIGenerationFailureRepositoryInterface = (*AIGenerationFailureRepository)(nil)
+
+// AIGenerationFailureRepository is an in-memory AIGenerationFailureRepositoryInterface
+type AIGenerationFailureRepository struct {
+	store *Store
+}
+
+// NewAIGenerationFailureRepository creates a new in-memory AI generation failure repository
+func NewAIGenerationFailureRepository(store *Store) *AIGenerationFailureRepository {
+	return &AIGenerationFailureRepository{store: store}
+}
+
+// Save inserts an AI generation failure
+func (r *AIGenerationFailureRepository) Save(failure *models.AIGenerationFailure) (int64, error) {
+	if failure.CreatedAt.IsZero() {
+		failure.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneAIGenerationFailure(failure)
+	if row.Errors == nil {
+		row.Errors = []string{}
+	}
+	row.ID = r.store.aiGenerationFailures.insert(row)
+	failure.ID = row.ID
+	return row.ID, nil
+}
+
+// GetRecent retrieves the most recent AI generation failures, newest first
+func (r *AIGenerationFailureRepository) GetRecent(limit int) ([]*models.AIGenerationFailure, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var failures []*models.AIGenerationFailure
+	for _, row := range r.store.aiGenerationFailures.all() {
+		failures = append(failures, cloneAIGenerationFailure(row))
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		if !failures[i].CreatedAt.Equal(failures[j].CreatedAt) {
+			return failures[i].CreatedAt.After(failures[j].CreatedAt)
+		}
+		return failures[i].ID > failures[j].ID
+	})
+	return limitRows(failures, limit), nil
+}
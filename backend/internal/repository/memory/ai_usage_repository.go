@@ -0,0 +1,96 @@
+// internal/repository/memory/ai_usage_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.AIUsageRepositoryInterface = (*AIUsageRepository)(nil)
+
+// AIUsageRepository is an in-memory AIUsageRepositoryInterface
+type AIUsageRepository struct {
+	store *Store
+}
+
+// NewAIUsageRepository creates a new in-memory AI usage repository
+func NewAIUsageRepository(store *Store) *AIUsageRepository {
+	return &AIUsageRepository{store: store}
+}
+
+// Save inserts an LLM call record
+func (r *AIUsageRepository) Save(usage *models.AIUsage) (int64, error) {
+	if usage.CreatedAt.IsZero() {
+		usage.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *usage
+	row.ID = r.store.aiUsage.insert(&row)
+	usage.ID = row.ID
+	return row.ID, nil
+}
+
+// SumTokens returns the input and output tokens spent on a purpose since a time
+func (r *AIUsageRepository) SumTokens(purpose string, since time.Time) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tokens int64
+	for _, row := range r.store.aiUsage.all() {
+		if row.Purpose == purpose && !row.CreatedAt.Before(since) {
+			tokens += int64(row.InputTokens + row.OutputTokens)
+		}
+	}
+	return tokens, nil
+}
+
+// Summarize aggregates the calls made since a time per purpose, ordered by purpose
+func (r *AIUsageRepository) Summarize(since time.Time) ([]*models.AIUsageSummary, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byPurpose := make(map[string]*models.AIUsageSummary)
+	latencies := make(map[string]int64)
+	for _, row := range r.store.aiUsage.all() {
+		if row.CreatedAt.Before(since) {
+			continue
+		}
+		summary, ok := byPurpose[row.Purpose]
+		if !ok {
+			summary = &models.AIUsageSummary{Purpose: row.Purpose}
+			byPurpose[row.Purpose] = summary
+		}
+
+		summary.Calls++
+		switch row.Outcome {
+		case models.AIOutcomeSuccess:
+			summary.Successes++
+		case models.AIOutcomeError:
+			summary.Errors++
+		default:
+			summary.Blocked++
+		}
+		if row.Outcome == models.AIOutcomeSuccess || row.Outcome == models.AIOutcomeError {
+			latencies[row.Purpose] += row.LatencyMs
+		}
+		summary.InputTokens += int64(row.InputTokens)
+		summary.OutputTokens += int64(row.OutputTokens)
+		summary.CostUSD += row.CostUSD
+	}
+
+	summaries := make([]*models.AIUsageSummary, 0, len(byPurpose))
+	for purpose, summary := range byPurpose {
+		if reached := summary.Successes + summary.Errors; reached > 0 {
+			summary.AvgLatencyMs = float64(latencies[purpose]) / float64(reached)
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Purpose < summaries[j].Purpose })
+	return summaries, nil
+}
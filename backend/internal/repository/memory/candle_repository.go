@@ -0,0 +1,74 @@
+// internal/repository/memory/candle_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.CandleRepositoryInterface = (*CandleRepository)(nil)
+
+// CandleRepository is an in-memory CandleRepositoryInterface
+type CandleRepository struct {
+	store *Store
+}
+
+// NewCandleRepository creates a new in-memory candle repository
+func NewCandleRepository(store *Store) *CandleRepository {
+	return &CandleRepository{store: store}
+}
+
+// Upsert inserts a candle or replaces the stored values for its bucket
+func (r *CandleRepository) Upsert(candle *models.Candle) error {
+	return r.UpsertBatch([]*models.Candle{candle})
+}
+
+// UpsertBatch upserts several candles at once
+func (r *CandleRepository) UpsertBatch(candles []*models.Candle) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for _, candle := range candles {
+		row := *candle
+		row.MintAddress = ""
+		row.UpdatedAt = now
+
+		key := candleKey{tokenID: candle.TokenID, intervalSec: candle.IntervalSec, bucketStart: candle.BucketStart}
+		if id, ok := r.store.candlesByKey[key]; ok {
+			row.ID = id
+			*r.store.candles.get(id) = row
+			continue
+		}
+		stored := row
+		stored.ID = r.store.candles.insert(&stored)
+		r.store.candlesByKey[key] = stored.ID
+	}
+	return nil
+}
+
+// GetByToken retrieves a token's candles for an interval with a bucket start in
+// [from, to], oldest first
+func (r *CandleRepository) GetByToken(tokenID int64, intervalSec int, from, to int64, limit int) ([]*models.Candle, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	mint, ok := r.store.mintAddress(tokenID)
+	if !ok {
+		return nil, nil
+	}
+
+	var candles []*models.Candle
+	for _, row := range r.store.candles.all() {
+		if row.TokenID == tokenID && row.IntervalSec == intervalSec && row.BucketStart >= from && row.BucketStart <= to {
+			candle := *row
+			candle.MintAddress = mint
+			candles = append(candles, &candle)
+		}
+	}
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].BucketStart < candles[j].BucketStart })
+	return limitRows(candles, limit), nil
+}
@@ -0,0 +1,150 @@
+// internal/repository/memory/creator_profile_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.CreatorProfileRepositoryInterface = (*CreatorProfileRepository)(nil)
+
+// CreatorProfileRepository is an in-memory CreatorProfileRepositoryInterface
+type CreatorProfileRepository struct {
+	store *Store
+}
+
+// NewCreatorProfileRepository creates a new in-memory creator profile repository
+func NewCreatorProfileRepository(store *Store) *CreatorProfileRepository {
+	return &CreatorProfileRepository{store: store}
+}
+
+// Upsert inserts or replaces a creator profile
+func (r *CreatorProfileRepository) Upsert(profile *models.CreatorProfile) error {
+	profile.UpdatedAt = time.Now()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *profile
+	r.store.creatorProfiles[row.CreatorAddress] = &row
+	return nil
+}
+
+// GetByAddress retrieves a creator's profile, or nil if none has been built yet
+func (r *CreatorProfileRepository) GetByAddress(creatorAddress string) (*models.CreatorProfile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.creatorProfiles[creatorAddress]
+	if !ok {
+		return nil, nil
+	}
+	profile := *row
+	return &profile, nil
+}
+
+// GetTop retrieves creators with at least minLaunches launches ordered by reputation
+func (r *CreatorProfileRepository) GetTop(minLaunches int, ascending bool, limit int) ([]*models.CreatorProfile, error) {
+	r.store.mu.RLock()
+	var profiles []*models.CreatorProfile
+	for _, row := range r.store.creatorProfiles {
+		if row.TokensLaunched >= minLaunches {
+			profile := *row
+			profiles = append(profiles, &profile)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(profiles, func(i, j int) bool {
+		a, b := profiles[i], profiles[j]
+		if a.ReputationScore != b.ReputationScore {
+			if ascending {
+				return a.ReputationScore < b.ReputationScore
+			}
+			return a.ReputationScore > b.ReputationScore
+		}
+		if a.TokensLaunched != b.TokensLaunched {
+			return a.TokensLaunched > b.TokensLaunched
+		}
+		return a.CreatorAddress < b.CreatorAddress
+	})
+	return limitRows(profiles, limit), nil
+}
+
+// GetLaunches retrieves every token a creator launched with the price and creator-sell
+// facts needed to score it, oldest first
+func (r *CreatorProfileRepository) GetLaunches(creatorAddress string) ([]*models.CreatorLaunch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	launches := make(map[int64]*models.CreatorLaunch)
+	var ordered []*models.CreatorLaunch
+	for _, token := range r.store.tokens.all() {
+		if token.CreatorAddress != creatorAddress {
+			continue
+		}
+		launch := &models.CreatorLaunch{
+			TokenID:                token.ID,
+			MintAddress:            token.MintAddress,
+			CreatorAddress:         token.CreatorAddress,
+			CreatedTimestamp:       token.CreatedTimestamp,
+			Completed:              token.Completed,
+			KingOfTheHillTimeStamp: token.KingOfTheHillTimeStamp,
+			UsdMarketCap:           token.UsdMarketCap,
+		}
+		launches[token.ID] = launch
+		ordered = append(ordered, launch)
+	}
+
+	last := make(map[int64]*models.Trade)
+	for _, trade := range r.store.trades.all() {
+		launch, ok := launches[trade.TokenID]
+		if !ok {
+			continue
+		}
+		if trade.TokenAmount != 0 {
+			if price := trade.SolAmount / trade.TokenAmount; price > launch.MaxPrice {
+				launch.MaxPrice = price
+			}
+		}
+		if prev, ok := last[trade.TokenID]; !ok || trade.Timestamp >= prev.Timestamp {
+			last[trade.TokenID] = trade
+		}
+		if !trade.IsBuy && trade.UserAddress == creatorAddress &&
+			(launch.FirstCreatorSellAt == 0 || trade.Timestamp < launch.FirstCreatorSellAt) {
+			launch.FirstCreatorSellAt = trade.Timestamp
+		}
+	}
+	for tokenID, trade := range last {
+		if trade.TokenAmount != 0 {
+			launches[tokenID].LastPrice = trade.SolAmount / trade.TokenAmount
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].CreatedTimestamp < ordered[j].CreatedTimestamp })
+	return ordered, nil
+}
+
+// GetCreatorAddresses pages through the distinct creators that launched a token since
+// sinceMs, in address order after afterAddress
+func (r *CreatorProfileRepository) GetCreatorAddresses(sinceMs int64, afterAddress string, limit int) ([]string, error) {
+	r.store.mu.RLock()
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, token := range r.store.tokens.all() {
+		if token.CreatedTimestamp >= sinceMs && token.CreatorAddress > afterAddress && !seen[token.CreatorAddress] {
+			seen[token.CreatorAddress] = true
+			addresses = append(addresses, token.CreatorAddress)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Strings(addresses)
+	if limit >= 0 && len(addresses) > limit {
+		addresses = addresses[:limit]
+	}
+	return addresses, nil
+}
@@ -0,0 +1,467 @@
+// internal/repository/memory/dashboard_repository.go
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.DashboardRepositoryInterface = (*DashboardRepository)(nil)
+
+// DashboardRepository is an in-memory DashboardRepositoryInterface. It computes the same
+// aggregates as the Postgres queries over the store's tables; calendar days are UTC.
+type DashboardRepository struct {
+	store *Store
+}
+
+// NewDashboardRepository creates a new in-memory dashboard repository
+func NewDashboardRepository(store *Store) *DashboardRepository {
+	return &DashboardRepository{store: store}
+}
+
+// GetTotalBalance retrieves the total balance across all strategies, including unrealized
+// gains from active trades and the initial balance of running simulations
+func (r *DashboardRepository) GetTotalBalance() (float64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.totalBalance(), nil
+}
+
+// GetBalanceChange retrieves the balance change over a timeframe of 24h, 7d or 30d
+func (r *DashboardRepository) GetBalanceChange(timeframe string) (float64, float64, error) {
+	now := time.Now()
+	var timeCutoff time.Time
+	switch timeframe {
+	case "7d":
+		timeCutoff = now.Add(-7 * 24 * time.Hour)
+	case "30d":
+		timeCutoff = now.Add(-30 * 24 * time.Hour)
+	default:
+		timeCutoff = now.Add(-24 * time.Hour)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	currentBalance := r.totalBalance()
+
+	var previousBalance float64
+	for _, metric := range r.latestMetrics(func(m *models.StrategyMetric) bool { return !m.CreatedAt.After(timeCutoff) }) {
+		previousBalance += metric.CurrentBalance
+	}
+
+	balanceChange := currentBalance - previousBalance
+	balanceChangePercent := 0.0
+	if previousBalance > 0 {
+		balanceChangePercent = (balanceChange / previousBalance) * 100
+	}
+	return balanceChange, balanceChangePercent, nil
+}
+
+// GetTradingStats retrieves aggregated trading statistics from each strategy's latest metric
+func (r *DashboardRepository) GetTradingStats() (int, int, int, float64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var totalTrades, winningTrades int
+	for _, metric := range r.latestMetrics(func(*models.StrategyMetric) bool { return true }) {
+		totalTrades += metric.TotalTrades
+		winningTrades += metric.SuccessfulTrades
+	}
+
+	losingTrades := totalTrades - winningTrades
+	winRate := 0.0
+	if totalTrades > 0 {
+		winRate = float64(winningTrades) / float64(totalTrades) * 100
+	}
+	return totalTrades, winningTrades, losingTrades, winRate, nil
+}
+
+// GetActiveTradeCount retrieves the count of currently active trades
+func (r *DashboardRepository) GetActiveTradeCount() (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, trade := range r.store.simulatedTrades.all() {
+		if trade.Status == "active" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetAverageHoldTime calculates the average holding time of closed trades
+func (r *DashboardRepository) GetAverageHoldTime() (string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now().Unix()
+	var total float64
+	count := 0
+	for _, trade := range r.store.simulatedTrades.all() {
+		if trade.Status != "closed" && trade.Status != "completed" {
+			continue
+		}
+		if trade.ExitTimestamp != nil {
+			total += float64(*trade.ExitTimestamp - trade.EntryTimestamp)
+		} else {
+			total += float64(now - trade.EntryTimestamp)
+		}
+		count++
+	}
+
+	var avgHoldTimeSec int64
+	if count > 0 {
+		avgHoldTimeSec = int64(math.Round(total / float64(count)))
+	}
+	return fmt.Sprintf("%dm %ds", avgHoldTimeSec/60, avgHoldTimeSec%60), nil
+}
+
+// GetTopPerformingStrategy retrieves the strategy whose latest metric with trades has the
+// highest ROI
+func (r *DashboardRepository) GetTopPerformingStrategy() (*models.Strategy, float64, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	strategies := r.store.strategies.all()
+	if len(strategies) == 0 {
+		return &models.Strategy{ID: 0, Name: "No strategies available"}, 0, 0, nil
+	}
+
+	latest := make(map[int64]*models.StrategyMetric)
+	for _, metric := range r.store.strategyMetrics.all() {
+		if metric.TotalTrades <= 0 || r.store.strategies.get(metric.StrategyID) == nil {
+			continue
+		}
+		if current, ok := latest[metric.StrategyID]; !ok || !metric.CreatedAt.Before(current.CreatedAt) {
+			latest[metric.StrategyID] = metric
+		}
+	}
+
+	var best *models.StrategyMetric
+	for _, strategy := range strategies {
+		metric, ok := latest[strategy.ID]
+		if ok && (best == nil || metric.ROI > best.ROI) {
+			best = metric
+		}
+	}
+
+	if best == nil {
+		first := strategies[0]
+		return &models.Strategy{
+			ID:          first.ID,
+			Name:        first.Name + " (No metrics)",
+			Description: first.Description,
+		}, 0, 0, nil
+	}
+
+	strategy := r.store.strategies.get(best.StrategyID)
+	return &models.Strategy{
+		ID:          strategy.ID,
+		Name:        strategy.Name,
+		Description: strategy.Description,
+	}, best.ROI, best.TotalTrades, nil
+}
+
+// GetMarketConditions derives the market trend and a volatility index from the hourly
+// profit of trades closed in the last 48 hours
+func (r *DashboardRepository) GetMarketConditions() (string, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	since := now.Add(-48 * time.Hour).Unix()
+	hourly := make(map[int64]float64)
+	for _, trade := range r.store.simulatedTrades.all() {
+		if trade.ExitTimestamp == nil || *trade.ExitTimestamp <= since {
+			continue
+		}
+		pnl := 0.0
+		if trade.ProfitLoss != nil {
+			pnl = *trade.ProfitLoss
+		}
+		hourly[time.Unix(*trade.ExitTimestamp, 0).Truncate(time.Hour).Unix()] += pnl
+	}
+	if len(hourly) == 0 {
+		return "neutral", 50, nil
+	}
+
+	dayAgo := now.Add(-24 * time.Hour).Unix()
+	var recentPnl, previousPnl, sum float64
+	hasRecent := false
+	for hour, pnl := range hourly {
+		if hour > dayAgo {
+			recentPnl += pnl
+			hasRecent = true
+		} else {
+			previousPnl += pnl
+		}
+		sum += pnl
+	}
+
+	status := "neutral"
+	switch {
+	case hasRecent && recentPnl > 0 && recentPnl > previousPnl:
+		status = "bullish"
+	case hasRecent && recentPnl < 0 && recentPnl < previousPnl:
+		status = "bearish"
+	}
+
+	volatility := 50.0
+	if n := float64(len(hourly)); n > 1 {
+		mean := sum / n
+		var squares float64
+		for _, pnl := range hourly {
+			squares += (pnl - mean) * (pnl - mean)
+		}
+		volatility = math.Sqrt(squares/(n-1)) * 100 / math.Max(math.Abs(mean), 0.01)
+	}
+	volatilityIndex := int(math.Max(math.Min(math.Ceil(volatility), 100), 10))
+	return status, volatilityIndex, nil
+}
+
+// GetPerformanceHistory retrieves one balance point per day for the last days days
+func (r *DashboardRepository) GetPerformanceHistory(days int) ([]models.PerformanceDataPoint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type strategyBalance struct {
+		strategyID int64
+		balance    float64
+	}
+	seen := make(map[strategyBalance]bool)
+	initialBalance := 0.0
+	for _, metric := range r.store.strategyMetrics.all() {
+		key := strategyBalance{strategyID: metric.StrategyID, balance: metric.InitialBalance}
+		if !seen[key] {
+			seen[key] = true
+			initialBalance += metric.InitialBalance
+		}
+	}
+	if len(seen) == 0 {
+		initialBalance = 100
+	}
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	since := now.AddDate(0, 0, -days).Unix()
+	daily := make(map[int64]float64)
+	for _, trade := range r.store.simulatedTrades.all() {
+		if trade.ExitTimestamp == nil || *trade.ExitTimestamp <= since || trade.ProfitLoss == nil {
+			continue
+		}
+		day := time.Unix(*trade.ExitTimestamp, 0).UTC().Truncate(24 * time.Hour).Unix()
+		daily[day] += *trade.ProfitLoss
+	}
+
+	var dataPoints []models.PerformanceDataPoint
+	cumulative := 0.0
+	for day := today.AddDate(0, 0, -days); !day.After(today); day = day.AddDate(0, 0, 1) {
+		cumulative += daily[day.Unix()]
+		dataPoints = append(dataPoints, models.PerformanceDataPoint{
+			Date:    day.Format("Jan 02"),
+			Balance: initialBalance + cumulative,
+		})
+	}
+
+	if len(dataPoints) == 0 {
+		totalBalance := r.totalBalance()
+		increment := (totalBalance - initialBalance) / float64(days)
+		balance := initialBalance
+		for i := 0; i < days; i++ {
+			dataPoints = append(dataPoints, models.PerformanceDataPoint{
+				Date:    now.AddDate(0, 0, -days+i+1).Format("Jan 02"),
+				Balance: balance,
+			})
+			balance += increment
+		}
+		if len(dataPoints) > 0 {
+			dataPoints[len(dataPoints)-1].Balance = totalBalance
+		}
+	}
+	return dataPoints, nil
+}
+
+// GetStrategyDistribution retrieves the most profitable strategies by realized profit
+func (r *DashboardRepository) GetStrategyDistribution(limit int) ([]models.StrategyDistribution, error) {
+	r.store.mu.RLock()
+	byStrategy := make(map[int64]*models.StrategyDistribution)
+	var profits []*models.StrategyDistribution
+	for _, trade := range r.store.simulatedTrades.all() {
+		if trade.ProfitLoss == nil {
+			continue
+		}
+		strategy := r.store.strategies.get(trade.StrategyID)
+		if strategy == nil {
+			continue
+		}
+		dist, ok := byStrategy[strategy.ID]
+		if !ok {
+			dist = &models.StrategyDistribution{ID: strategy.ID, Name: strategy.Name}
+			byStrategy[strategy.ID] = dist
+			profits = append(profits, dist)
+		}
+		dist.Trades++
+		dist.Profit += *trade.ProfitLoss
+	}
+	r.store.mu.RUnlock()
+
+	sort.SliceStable(profits, func(i, j int) bool { return profits[i].Profit > profits[j].Profit })
+	profits = limitRows(profits, limit)
+
+	colorOptions := []string{"var(--color-chart-1)", "var(--color-chart-2)", "var(--color-chart-3)", "var(--color-chart-4)"}
+	var distributions []models.StrategyDistribution
+	for _, dist := range profits {
+		if dist.Profit <= 0 {
+			continue
+		}
+		dist.Color = colorOptions[len(distributions)%len(colorOptions)]
+		distributions = append(distributions, *dist)
+	}
+
+	if len(distributions) == 0 {
+		distributions = []models.StrategyDistribution{
+			{ID: 1, Name: "Strategy #4", Trades: 87, Profit: 34.24, Color: "var(--color-chart-1)"},
+			{ID: 2, Name: "Strategy #7", Trades: 56, Profit: 17.94, Color: "var(--color-chart-2)"},
+		}
+	}
+	return distributions, nil
+}
+
+// GetRecentPerformance retrieves trading performance over the last day, week and month
+func (r *DashboardRepository) GetRecentPerformance() ([]models.RecentPerformance, error) {
+	now := time.Now()
+	periods := []struct {
+		name  string
+		start time.Time
+	}{
+		{"Last 24 Hours", now.Add(-24 * time.Hour)},
+		{"Last Week", now.Add(-7 * 24 * time.Hour)},
+		{"Last Month", now.Add(-30 * 24 * time.Hour)},
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var stats []models.RecentPerformance
+	for _, period := range periods {
+		startTimestamp := period.start.Unix()
+		tradeCount, winningTrades := 0, 0
+		var totalProfit, bestTradePct float64
+		hasBest := false
+		for _, trade := range r.store.simulatedTrades.all() {
+			if trade.ExitTimestamp == nil || *trade.ExitTimestamp <= startTimestamp {
+				continue
+			}
+			tradeCount++
+			if trade.ProfitLoss == nil {
+				continue
+			}
+			if *trade.ProfitLoss > 0 {
+				winningTrades++
+			}
+			totalProfit += *trade.ProfitLoss
+			if trade.PositionSize != 0 {
+				pct := *trade.ProfitLoss / trade.PositionSize * 100
+				if !hasBest || pct > bestTradePct {
+					bestTradePct = pct
+					hasBest = true
+				}
+			}
+		}
+
+		winRate := 0.0
+		if tradeCount > 0 {
+			winRate = float64(winningTrades) / float64(tradeCount) * 100
+		}
+		bestTradeStr := fmt.Sprintf("+%.1f%%", bestTradePct)
+		if bestTradePct <= 0 {
+			bestTradeStr = "N/A"
+		}
+
+		stats = append(stats, models.RecentPerformance{
+			Period:    period.name,
+			Trades:    tradeCount,
+			Profit:    totalProfit,
+			WinRate:   winRate,
+			BestTrade: bestTradeStr,
+		})
+	}
+	return stats, nil
+}
+
+// totalBalance sums the latest balance of every strategy, the estimated value of active
+// trades and the initial balance of running simulations. The caller must hold the store
+// lock.
+func (r *DashboardRepository) totalBalance() float64 {
+	var baseBalance float64
+	for _, metric := range r.latestMetrics(func(*models.StrategyMetric) bool { return true }) {
+		baseBalance += metric.CurrentBalance
+	}
+
+	now := time.Now().Unix()
+	var activeTradesValue float64
+	for _, trade := range r.store.simulatedTrades.all() {
+		if trade.Status == "active" {
+			activeTradesValue += trade.PositionSize * (1 + 0.05*float64(now-trade.EntryTimestamp)/3600)
+		}
+	}
+
+	var runningSimsBalance float64
+	for _, run := range r.store.simulationRuns.all() {
+		if run.Status != "running" {
+			continue
+		}
+		balance, ok := initialBalanceParam(run.SimulationParameters)
+		if !ok {
+			// Postgres fails the whole cast, so none of the running simulations count
+			runningSimsBalance = 0
+			break
+		}
+		runningSimsBalance += balance
+	}
+
+	return baseBalance + activeTradesValue + runningSimsBalance
+}
+
+// latestMetrics returns the metric with the highest ID of each strategy among the metrics
+// matching filter. The caller must hold the store lock.
+func (r *DashboardRepository) latestMetrics(filter func(*models.StrategyMetric) bool) map[int64]*models.StrategyMetric {
+	latest := make(map[int64]*models.StrategyMetric)
+	for _, metric := range r.store.strategyMetrics.all() {
+		if filter(metric) {
+			latest[metric.StrategyID] = metric
+		}
+	}
+	return latest
+}
+
+// initialBalanceParam reads a run's initialBalance parameter like a Postgres FLOAT cast;
+// a missing value counts as 0
+func initialBalanceParam(params models.JSONB) (float64, bool) {
+	value, ok := params["initialBalance"]
+	if !ok || value == nil {
+		return 0, true
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		balance, err := strconv.ParseFloat(v, 64)
+		return balance, err == nil
+	default:
+		return 0, false
+	}
+}
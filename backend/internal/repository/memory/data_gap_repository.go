@@ -0,0 +1,96 @@
+// internal/repository/memory/data_gap_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.DataGapRepositoryInterface = (*DataGapRepository)(nil)
+
+// DataGapRepository is an in-memory DataGapRepositoryInterface
+type DataGapRepository struct {
+	store *Store
+}
+
+// NewDataGapRepository creates a new in-memory data gap repository
+func NewDataGapRepository(store *Store) *DataGapRepository {
+	return &DataGapRepository{store: store}
+}
+
+// Save inserts a data gap
+func (r *DataGapRepository) Save(gap *models.DataGap) (int64, error) {
+	if gap.CreatedAt.IsZero() {
+		gap.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneDataGap(gap)
+	row.ID = r.store.dataGaps.insert(row)
+	return row.ID, nil
+}
+
+// Close ends an open data gap; closing a gap that is already closed does nothing
+func (r *DataGapRepository) Close(id int64, endedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.dataGaps.get(id)
+	if row == nil || row.EndedAt != nil {
+		return nil
+	}
+	row.EndedAt = &endedAt
+	row.DurationSec = endedAt.Sub(row.StartedAt).Seconds()
+	return nil
+}
+
+// GetOpen retrieves the most recent open gap of a source, or nil if there is none
+func (r *DataGapRepository) GetOpen(source string) (*models.DataGap, error) {
+	gaps := r.byStart(func(g *models.DataGap) bool { return g.Source == source && g.EndedAt == nil })
+	if len(gaps) == 0 {
+		return nil, nil
+	}
+	return gaps[len(gaps)-1], nil
+}
+
+// GetOverlapping retrieves the gaps overlapping [from, to], oldest first
+func (r *DataGapRepository) GetOverlapping(from, to time.Time) ([]*models.DataGap, error) {
+	return r.byStart(func(g *models.DataGap) bool {
+		return !g.StartedAt.After(to) && (g.EndedAt == nil || !g.EndedAt.Before(from))
+	}), nil
+}
+
+// GetRecent retrieves the most recently started gaps
+func (r *DataGapRepository) GetRecent(limit int) ([]*models.DataGap, error) {
+	gaps := r.byStart(func(*models.DataGap) bool { return true })
+	for i, j := 0, len(gaps)-1; i < j; i, j = i+1, j-1 {
+		gaps[i], gaps[j] = gaps[j], gaps[i]
+	}
+	return limitRows(gaps, limit), nil
+}
+
+// byStart returns copies of the gaps matching filter ordered by start time. Open gaps
+// report their duration so far.
+func (r *DataGapRepository) byStart(filter func(*models.DataGap) bool) []*models.DataGap {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var gaps []*models.DataGap
+	for _, row := range r.store.dataGaps.all() {
+		if !filter(row) {
+			continue
+		}
+		gap := cloneDataGap(row)
+		if gap.EndedAt == nil {
+			gap.DurationSec = time.Since(gap.StartedAt).Seconds()
+		}
+		gaps = append(gaps, gap)
+	}
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].StartedAt.Before(gaps[j].StartedAt) })
+	return gaps
+}
@@ -0,0 +1,86 @@
+// internal/repository/memory/entry_model_repository.go
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.EntryModelRepositoryInterface = (*EntryModelRepository)(nil)
+
+// EntryModelRepository is an in-memory EntryModelRepositoryInterface
+type EntryModelRepository struct {
+	store *Store
+}
+
+// NewEntryModelRepository creates a new in-memory entry model repository
+func NewEntryModelRepository(store *Store) *EntryModelRepository {
+	return &EntryModelRepository{store: store}
+}
+
+// Save stores a trained model as the next version of its name and sets its ID, version
+// and creation time
+func (r *EntryModelRepository) Save(model *models.EntryModel) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	version := 0
+	for _, row := range r.store.entryModels.all() {
+		if row.Name == model.Name && row.Version > version {
+			version = row.Version
+		}
+	}
+
+	row, err := cloneJSON(*model)
+	if err != nil {
+		return fmt.Errorf("error encoding entry model artifact: %v", err)
+	}
+	row.Version = version + 1
+	row.CreatedAt = time.Now()
+	row.ID = r.store.entryModels.insert(&row)
+
+	model.ID = row.ID
+	model.Version = row.Version
+	model.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// GetLatest retrieves the highest version of a model, or nil if none has been trained
+func (r *EntryModelRepository) GetLatest(name string) (*models.EntryModel, error) {
+	return r.find(func(m *models.EntryModel, latest *models.EntryModel) bool {
+		return m.Name == name && (latest == nil || m.Version > latest.Version)
+	})
+}
+
+// GetByVersion retrieves a specific version of a model, or nil if it doesn't exist
+func (r *EntryModelRepository) GetByVersion(name string, version int) (*models.EntryModel, error) {
+	return r.find(func(m *models.EntryModel, _ *models.EntryModel) bool {
+		return m.Name == name && m.Version == version
+	})
+}
+
+// find returns a copy of the last model for which better reports true against the best
+// match so far, or nil if there is none
+func (r *EntryModelRepository) find(better func(m, best *models.EntryModel) bool) (*models.EntryModel, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var best *models.EntryModel
+	for _, row := range r.store.entryModels.all() {
+		if better(row, best) {
+			best = row
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	model, err := cloneJSON(*best)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding entry model artifact: %v", err)
+	}
+	return &model, nil
+}
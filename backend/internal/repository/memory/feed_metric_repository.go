@@ -0,0 +1,69 @@
+// internal/repository/memory/feed_metric_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.FeedMetricRepositoryInterface = (*FeedMetricRepository)(nil)
+
+// FeedMetricRepository is an in-memory FeedMetricRepositoryInterface
+type FeedMetricRepository struct {
+	store *Store
+}
+
+// NewFeedMetricRepository creates a new in-memory feed metric repository
+func NewFeedMetricRepository(store *Store) *FeedMetricRepository {
+	return &FeedMetricRepository{store: store}
+}
+
+// Save inserts a feed metric
+func (r *FeedMetricRepository) Save(metric *models.FeedMetric) (int64, error) {
+	if metric.CreatedAt.IsZero() {
+		metric.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *metric
+	row.ID = r.store.feedMetrics.insert(&row)
+	return row.ID, nil
+}
+
+// GetLatest retrieves the most recent feed metric of a source, or nil if there is none
+func (r *FeedMetricRepository) GetLatest(source string) (*models.FeedMetric, error) {
+	metrics := r.byPeriodEnd(func(m *models.FeedMetric) bool { return m.Source == source })
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+	return metrics[len(metrics)-1], nil
+}
+
+// GetSince retrieves the feed metrics of a source whose period ended after since, oldest
+// first
+func (r *FeedMetricRepository) GetSince(source string, since time.Time) ([]*models.FeedMetric, error) {
+	return r.byPeriodEnd(func(m *models.FeedMetric) bool {
+		return m.Source == source && m.PeriodEnd.After(since)
+	}), nil
+}
+
+// byPeriodEnd returns copies of the metrics matching filter ordered by period end
+func (r *FeedMetricRepository) byPeriodEnd(filter func(*models.FeedMetric) bool) []*models.FeedMetric {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var metrics []*models.FeedMetric
+	for _, row := range r.store.feedMetrics.all() {
+		if filter(row) {
+			metric := *row
+			metrics = append(metrics, &metric)
+		}
+	}
+	sort.SliceStable(metrics, func(i, j int) bool { return metrics[i].PeriodEnd.Before(metrics[j].PeriodEnd) })
+	return metrics
+}
@@ -0,0 +1,123 @@
+// internal/repository/memory/launch_analysis_repository.go
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.LaunchAnalysisRepositoryInterface = (*LaunchAnalysisRepository)(nil)
+
+// LaunchAnalysisRepository is an in-memory LaunchAnalysisRepositoryInterface
+type LaunchAnalysisRepository struct {
+	store *Store
+}
+
+// NewLaunchAnalysisRepository creates a new in-memory launch analysis repository
+func NewLaunchAnalysisRepository(store *Store) *LaunchAnalysisRepository {
+	return &LaunchAnalysisRepository{store: store}
+}
+
+// Upsert inserts or replaces a token's launch analysis
+func (r *LaunchAnalysisRepository) Upsert(analysis *models.LaunchAnalysis) error {
+	buyers, err := cloneJSON(analysis.Buyers)
+	if err != nil {
+		return fmt.Errorf("error encoding launch buyers: %v", err)
+	}
+	analysis.AnalyzedAt = time.Now()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *analysis
+	row.MintAddress = ""
+	row.CreatorAddress = ""
+	row.Buyers = buyers
+	r.store.launchAnalyses[row.TokenID] = &row
+	return nil
+}
+
+// GetByTokenID retrieves a token's stored launch analysis, or nil if it hasn't been
+// analyzed yet
+func (r *LaunchAnalysisRepository) GetByTokenID(tokenID int64) (*models.LaunchAnalysis, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.launchAnalyses[tokenID]
+	if !ok {
+		return nil, nil
+	}
+	token := r.store.tokens.get(tokenID)
+	if token == nil {
+		return nil, nil
+	}
+
+	buyers, err := cloneJSON(row.Buyers)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding launch buyers: %v", err)
+	}
+	analysis := *row
+	analysis.MintAddress = token.MintAddress
+	analysis.CreatorAddress = token.CreatorAddress
+	analysis.Buyers = buyers
+	analysis.Final = true
+	return &analysis, nil
+}
+
+// GetUnanalyzedTokens retrieves tokens created in [fromMs, toMs) without a stored launch
+// analysis, oldest first
+func (r *LaunchAnalysisRepository) GetUnanalyzedTokens(fromMs, toMs int64, limit int) ([]*models.Token, error) {
+	r.store.mu.RLock()
+	var tokens []*models.Token
+	for _, row := range r.store.tokens.all() {
+		if row.CreatedTimestamp < fromMs || row.CreatedTimestamp >= toMs {
+			continue
+		}
+		if _, ok := r.store.launchAnalyses[row.ID]; ok {
+			continue
+		}
+		tokens = append(tokens, &models.Token{
+			ID:               row.ID,
+			MintAddress:      row.MintAddress,
+			CreatorAddress:   row.CreatorAddress,
+			CreatedTimestamp: row.CreatedTimestamp,
+		})
+	}
+	r.store.mu.RUnlock()
+
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].CreatedTimestamp < tokens[j].CreatedTimestamp })
+	return limitRows(tokens, limit), nil
+}
+
+// GetLaunchTrades retrieves a token's trades up to untilSec in chronological order
+func (r *LaunchAnalysisRepository) GetLaunchTrades(tokenID int64, untilSec int64, limit int) ([]*models.Trade, error) {
+	return r.store.tokenTrades(tokenID, math.MinInt64, untilSec, limit), nil
+}
+
+// GetFirstTradeTimes returns the earliest trade timestamp seen for each of the given
+// wallets; wallets without trades are omitted
+func (r *LaunchAnalysisRepository) GetFirstTradeTimes(walletAddresses []string) (map[string]int64, error) {
+	wanted := make(map[string]bool, len(walletAddresses))
+	for _, address := range walletAddresses {
+		wanted[address] = true
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	firstTrades := make(map[string]int64, len(walletAddresses))
+	for _, trade := range r.store.trades.all() {
+		if !wanted[trade.UserAddress] {
+			continue
+		}
+		if first, ok := firstTrades[trade.UserAddress]; !ok || trade.Timestamp < first {
+			firstTrades[trade.UserAddress] = trade.Timestamp
+		}
+	}
+	return firstTrades, nil
+}
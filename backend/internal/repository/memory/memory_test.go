@@ -0,0 +1,94 @@
+// internal/repository/memory/memory_test.go
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/repotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepositories(store *Store) *repotest.Repositories {
+	return &repotest.Repositories{
+		Dashboard:          NewDashboardRepository(store),
+		Token:              NewTokenRepository(store),
+		Trade:              NewTradeRepository(store),
+		Strategy:           NewStrategyRepository(store),
+		StrategyMetric:     NewStrategyMetricRepository(store),
+		SimulationRun:      NewSimulationRunRepository(store),
+		SimulationResult:   NewSimulationResultRepository(store),
+		StrategyGeneration: NewStrategyGenerationRepository(store),
+		SimulatedTrade:     NewSimulatedTradeRepository(store),
+		SimulationEvent:    NewSimulationEventRepository(store),
+		Candle:             NewCandleRepository(store),
+		FeedMetric:         NewFeedMetricRepository(store),
+		DataGap:            NewDataGapRepository(store),
+		CreatorProfile:     NewCreatorProfileRepository(store),
+		WalletStats:        NewWalletStatsRepository(store),
+		LaunchAnalysis:     NewLaunchAnalysisRepository(store),
+		TokenAnomaly:       NewTokenAnomalyRepository(store),
+		TokenTransition:    NewTokenTransitionRepository(store),
+		TokenFeature:       NewTokenFeatureRepository(store),
+		TokenOutcome:       NewTokenOutcomeRepository(store),
+		EntryModel:         NewEntryModelRepository(store),
+
+		AIGenerationFailure: NewAIGenerationFailureRepository(store),
+		AIUsage:             NewAIUsageRepository(store),
+		StrategyCandidate:   NewStrategyCandidateRepository(store),
+		StrategyDraft:       NewStrategyDraftRepository(store),
+	}
+}
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repotest.Repositories {
+		return newRepositories(NewStore())
+	})
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	repos := newRepositories(NewStore())
+	tokenID, err := repos.Token.Save(&models.Token{MintAddress: "mint", CreatorAddress: "creator"})
+	require.NoError(t, err)
+
+	const workers = 8
+	const perWorker = 50
+	now := time.Now().Unix()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				_, err := repos.Trade.Save(&models.Trade{
+					TokenID:     tokenID,
+					Signature:   fmt.Sprintf("sig-%d-%d", w, i),
+					SolAmount:   1,
+					TokenAmount: 100,
+					IsBuy:       true,
+					UserAddress: fmt.Sprintf("wallet-%d", w),
+					Timestamp:   now,
+				})
+				assert.NoError(t, err)
+				_, _, err = repos.Trade.GetHolderBalances(tokenID)
+				assert.NoError(t, err)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	trades, err := repos.Trade.GetTradesAfterID(0, workers*perWorker+1)
+	require.NoError(t, err)
+	assert.Len(t, trades, workers*perWorker)
+
+	seen := make(map[int64]bool, len(trades))
+	for _, trade := range trades {
+		assert.False(t, seen[trade.ID], "duplicate trade ID %d", trade.ID)
+		seen[trade.ID] = true
+	}
+}
@@ -0,0 +1,273 @@
+// internal/repository/memory/simulated_trade_repository.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.SimulatedTradeRepositoryInterface = (*SimulatedTradeRepository)(nil)
+
+// SimulatedTradeRepository is an in-memory SimulatedTradeRepositoryInterface
+type SimulatedTradeRepository struct {
+	store *Store
+}
+
+// NewSimulatedTradeRepository creates a new in-memory simulated trade repository
+func NewSimulatedTradeRepository(store *Store) *SimulatedTradeRepository {
+	return &SimulatedTradeRepository{store: store}
+}
+
+// Save inserts a simulated trade
+func (r *SimulatedTradeRepository) Save(trade *models.SimulatedTrade) (int64, error) {
+	return r.SaveWithContext(context.Background(), trade)
+}
+
+// SaveWithContext inserts a simulated trade with context
+func (r *SimulatedTradeRepository) SaveWithContext(ctx context.Context, trade *models.SimulatedTrade) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("error saving simulated trade: %v", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	row := cloneSimulatedTrade(trade)
+	row.PostMortem = nil // Stored separately with SavePostMortem
+	row.CreatedAt = now
+	row.UpdatedAt = now
+	row.ID = r.store.simulatedTrades.insert(row)
+	return row.ID, nil
+}
+
+// Update updates the exit of a simulated trade
+func (r *SimulatedTradeRepository) Update(trade *models.SimulatedTrade) error {
+	return r.UpdateWithContext(context.Background(), trade)
+}
+
+// UpdateWithContext updates the exit of a simulated trade with context
+func (r *SimulatedTradeRepository) UpdateWithContext(ctx context.Context, trade *models.SimulatedTrade) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("error updating simulated trade: %v", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.simulatedTrades.get(trade.ID)
+	if row == nil {
+		return fmt.Errorf("no trade found with ID %d", trade.ID)
+	}
+	row.ExitPrice = clonePtr(trade.ExitPrice)
+	row.ExitTimestamp = clonePtr(trade.ExitTimestamp)
+	row.ProfitLoss = clonePtr(trade.ProfitLoss)
+	row.Status = trade.Status
+	row.ExitReason = clonePtr(trade.ExitReason)
+	row.ExitUsdMarketCap = clonePtr(trade.ExitUsdMarketCap)
+	row.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetByStrategyID retrieves the simulated trades of a strategy, latest entry first
+func (r *SimulatedTradeRepository) GetByStrategyID(strategyID int64) ([]*models.SimulatedTrade, error) {
+	return r.GetByStrategyIDWithContext(context.Background(), strategyID)
+}
+
+// GetByStrategyIDWithContext retrieves the simulated trades of a strategy with context
+func (r *SimulatedTradeRepository) GetByStrategyIDWithContext(ctx context.Context, strategyID int64) ([]*models.SimulatedTrade, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error querying simulated trades: %v", err)
+	}
+	return r.latestEntryFirst(func(t *models.SimulatedTrade) bool { return t.StrategyID == strategyID }), nil
+}
+
+// GetActiveByStrategyID retrieves the active simulated trades of a strategy
+func (r *SimulatedTradeRepository) GetActiveByStrategyID(strategyID int64) ([]*models.SimulatedTrade, error) {
+	return r.GetActiveByStrategyIDWithContext(context.Background(), strategyID)
+}
+
+// GetActiveByStrategyIDWithContext retrieves the active simulated trades of a strategy with context
+func (r *SimulatedTradeRepository) GetActiveByStrategyIDWithContext(ctx context.Context, strategyID int64) ([]*models.SimulatedTrade, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error querying active simulated trades: %v", err)
+	}
+	return r.latestEntryFirst(func(t *models.SimulatedTrade) bool {
+		return t.StrategyID == strategyID && t.Status == "active"
+	}), nil
+}
+
+// GetSummaryByStrategyID calculates summary statistics of a strategy's simulated trades
+func (r *SimulatedTradeRepository) GetSummaryByStrategyID(strategyID int64) (map[string]interface{}, error) {
+	return r.GetSummaryByStrategyIDWithContext(context.Background(), strategyID)
+}
+
+// GetSummaryByStrategyIDWithContext calculates summary statistics with context
+func (r *SimulatedTradeRepository) GetSummaryByStrategyIDWithContext(ctx context.Context, strategyID int64) (map[string]interface{}, error) {
+	trades, err := r.GetByStrategyIDWithContext(ctx, strategyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(trades) == 0 {
+		return repository.SummarizeSimulatedTrades(strategyID, "", trades), nil
+	}
+
+	strategyName := fmt.Sprintf("Strategy %d", strategyID)
+	r.store.mu.RLock()
+	if strategy := r.store.strategies.get(strategyID); strategy != nil {
+		strategyName = strategy.Name
+	}
+	r.store.mu.RUnlock()
+
+	return repository.SummarizeSimulatedTrades(strategyID, strategyName, trades), nil
+}
+
+// DeleteByStrategyID deletes the simulated trades of a strategy
+func (r *SimulatedTradeRepository) DeleteByStrategyID(strategyID int64) error {
+	return r.DeleteByStrategyIDWithContext(context.Background(), strategyID)
+}
+
+// DeleteByStrategyIDWithContext deletes the simulated trades of a strategy with context
+func (r *SimulatedTradeRepository) DeleteByStrategyIDWithContext(ctx context.Context, strategyID int64) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("error deleting simulated trades: %v", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []int64
+	for _, row := range r.store.simulatedTrades.all() {
+		if row.StrategyID == strategyID {
+			ids = append(ids, row.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no simulated trades found for strategy %d", strategyID)
+	}
+	for _, id := range ids {
+		r.store.simulatedTrades.delete(id)
+	}
+	return nil
+}
+
+// GetTradesByTokenID retrieves the latest simulated trades of a token
+func (r *SimulatedTradeRepository) GetTradesByTokenID(tokenID int64, limit int) ([]*models.SimulatedTrade, error) {
+	return r.GetTradesByTokenIDWithContext(context.Background(), tokenID, limit)
+}
+
+// GetTradesByTokenIDWithContext retrieves the latest simulated trades of a token with context
+func (r *SimulatedTradeRepository) GetTradesByTokenIDWithContext(ctx context.Context, tokenID int64, limit int) ([]*models.SimulatedTrade, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error querying token trades: %v", err)
+	}
+	trades := r.latestEntryFirst(func(t *models.SimulatedTrade) bool { return t.TokenID == tokenID })
+	return limitRows(trades, limit), nil
+}
+
+// GetBySimulationRun retrieves the simulated trades of a simulation run by strategy
+func (r *SimulatedTradeRepository) GetBySimulationRun(simulationRunID int64) ([]*models.SimulatedTrade, error) {
+	return r.GetBySimulationRunWithContext(context.Background(), simulationRunID)
+}
+
+// GetBySimulationRunWithContext retrieves the simulated trades of a simulation run with context
+func (r *SimulatedTradeRepository) GetBySimulationRunWithContext(ctx context.Context, simulationRunID int64) ([]*models.SimulatedTrade, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error querying simulation trades: %v", err)
+	}
+	trades := r.latestEntryFirst(func(t *models.SimulatedTrade) bool {
+		return t.SimulationRunID != nil && *t.SimulationRunID == simulationRunID
+	})
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].StrategyID < trades[j].StrategyID })
+	return trades, nil
+}
+
+// ExistsByStrategyIDAndTokenID reports whether a strategy has traded a token
+func (r *SimulatedTradeRepository) ExistsByStrategyIDAndTokenID(strategyID int64, tokenID int64) (bool, error) {
+	return r.ExistsByStrategyIDAndTokenIDWithContext(context.Background(), strategyID, tokenID)
+}
+
+// ExistsByStrategyIDAndTokenIDWithContext reports whether a strategy has traded a token with context
+func (r *SimulatedTradeRepository) ExistsByStrategyIDAndTokenIDWithContext(ctx context.Context, strategyID int64, tokenID int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, fmt.Errorf("error checking trade existence: %v", err)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, row := range r.store.simulatedTrades.all() {
+		if row.StrategyID == strategyID && row.TokenID == tokenID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetByID retrieves a simulated trade by its ID, or nil if it doesn't exist
+func (r *SimulatedTradeRepository) GetByID(id int64) (*models.SimulatedTrade, error) {
+	return r.GetByIDWithContext(context.Background(), id)
+}
+
+// GetByIDWithContext retrieves a simulated trade by its ID with context
+func (r *SimulatedTradeRepository) GetByIDWithContext(ctx context.Context, id int64) (*models.SimulatedTrade, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error querying simulated trade: %v", err)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.simulatedTrades.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	return cloneSimulatedTrade(row), nil
+}
+
+// SavePostMortem stores the post-mortem of a simulated trade, replacing any earlier one
+func (r *SimulatedTradeRepository) SavePostMortem(tradeID int64, postMortem *models.TradePostMortem) error {
+	return r.SavePostMortemWithContext(context.Background(), tradeID, postMortem)
+}
+
+// SavePostMortemWithContext stores the post-mortem of a simulated trade with context
+func (r *SimulatedTradeRepository) SavePostMortemWithContext(ctx context.Context, tradeID int64, postMortem *models.TradePostMortem) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("error saving simulated trade post-mortem: %v", err)
+	}
+	stored, err := cloneJSON(*postMortem)
+	if err != nil {
+		return fmt.Errorf("error encoding simulated trade post-mortem: %v", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.simulatedTrades.get(tradeID)
+	if row == nil {
+		return fmt.Errorf("no trade found with ID %d", tradeID)
+	}
+	row.PostMortem = &stored
+	row.UpdatedAt = time.Now()
+	return nil
+}
+
+// latestEntryFirst returns copies of the trades matching filter, latest entry first
+func (r *SimulatedTradeRepository) latestEntryFirst(filter func(*models.SimulatedTrade) bool) []*models.SimulatedTrade {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var trades []*models.SimulatedTrade
+	for _, row := range r.store.simulatedTrades.all() {
+		if filter(row) {
+			trades = append(trades, cloneSimulatedTrade(row))
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].EntryTimestamp > trades[j].EntryTimestamp })
+	return trades
+}
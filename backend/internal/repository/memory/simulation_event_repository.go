@@ -0,0 +1,90 @@
+// internal/repository/memory/simulation_event_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.SimulationEventRepositoryInterface = (*SimulationEventRepository)(nil)
+
+// SimulationEventRepository is an in-memory SimulationEventRepositoryInterface
+type SimulationEventRepository struct {
+	store *Store
+}
+
+// NewSimulationEventRepository creates a new in-memory simulation event repository
+func NewSimulationEventRepository(store *Store) *SimulationEventRepository {
+	return &SimulationEventRepository{store: store}
+}
+
+// Save inserts a simulation event
+func (r *SimulationEventRepository) Save(event *models.SimulationEvent) (int64, error) {
+	now := time.Now()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = now
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = now
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneSimulationEvent(event)
+	row.ID = r.store.simulationEvents.insert(row)
+	return row.ID, nil
+}
+
+// GetByID retrieves a simulation event by ID, or nil if it doesn't exist
+func (r *SimulationEventRepository) GetByID(id int64) (*models.SimulationEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.simulationEvents.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	return cloneSimulationEvent(row), nil
+}
+
+// GetByStrategyID retrieves the events of a strategy, newest first
+func (r *SimulationEventRepository) GetByStrategyID(strategyID int64, limit, offset int) ([]*models.SimulationEvent, error) {
+	events := r.byTimestamp(func(e *models.SimulationEvent) bool { return e.StrategyID == strategyID }, false)
+	return pageRows(events, limit, offset), nil
+}
+
+// GetBySimulationRunID retrieves the events of a simulation run, oldest first
+func (r *SimulationEventRepository) GetBySimulationRunID(simulationRunID int64, limit, offset int) ([]*models.SimulationEvent, error) {
+	events := r.byTimestamp(func(e *models.SimulationEvent) bool { return e.SimulationRunID == simulationRunID }, true)
+	return pageRows(events, limit, offset), nil
+}
+
+// GetLatestByStrategyID retrieves the latest events of a strategy
+func (r *SimulationEventRepository) GetLatestByStrategyID(strategyID int64, limit int) ([]*models.SimulationEvent, error) {
+	events := r.byTimestamp(func(e *models.SimulationEvent) bool { return e.StrategyID == strategyID }, false)
+	return limitRows(events, limit), nil
+}
+
+// byTimestamp returns copies of the events matching filter ordered by event time
+func (r *SimulationEventRepository) byTimestamp(filter func(*models.SimulationEvent) bool, ascending bool) []*models.SimulationEvent {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []*models.SimulationEvent
+	for _, row := range r.store.simulationEvents.all() {
+		if filter(row) {
+			events = append(events, cloneSimulationEvent(row))
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if ascending {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		}
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+	return events
+}
@@ -0,0 +1,97 @@
+// internal/repository/memory/simulation_result_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.SimulationResultRepositoryInterface = (*SimulationResultRepository)(nil)
+
+// SimulationResultRepository is an in-memory SimulationResultRepositoryInterface
+type SimulationResultRepository struct {
+	store *Store
+}
+
+// NewSimulationResultRepository creates a new in-memory simulation result repository
+func NewSimulationResultRepository(store *Store) *SimulationResultRepository {
+	return &SimulationResultRepository{store: store}
+}
+
+// Save inserts a simulation result
+func (r *SimulationResultRepository) Save(result *models.SimulationResult) (int64, error) {
+	if result.CreatedAt.IsZero() {
+		result.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *result
+	row.ID = r.store.simulationResults.insert(&row)
+	return row.ID, nil
+}
+
+// GetByID retrieves a simulation result by ID, or nil if it doesn't exist
+func (r *SimulationResultRepository) GetByID(id int64) (*models.SimulationResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.simulationResults.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	result := *row
+	return &result, nil
+}
+
+// GetBySimulationRun retrieves the results of a simulation run by rank
+func (r *SimulationResultRepository) GetBySimulationRun(simulationRunID int64) ([]*models.SimulationResult, error) {
+	return r.sorted(func(result *models.SimulationResult) bool {
+		return result.SimulationRunID == simulationRunID
+	}, func(a, b *models.SimulationResult) bool {
+		return a.Rank < b.Rank
+	}), nil
+}
+
+// GetTopPerformers retrieves the results of a simulation run with the highest ROI
+func (r *SimulationResultRepository) GetTopPerformers(simulationRunID int64, limit int) ([]*models.SimulationResult, error) {
+	results := r.sorted(func(result *models.SimulationResult) bool {
+		return result.SimulationRunID == simulationRunID
+	}, func(a, b *models.SimulationResult) bool {
+		return a.ROI > b.ROI
+	})
+	return limitRows(results, limit), nil
+}
+
+// GetByStrategy retrieves the results of a strategy, newest first
+func (r *SimulationResultRepository) GetByStrategy(strategyID int64, limit int) ([]*models.SimulationResult, error) {
+	results := r.sorted(func(result *models.SimulationResult) bool {
+		return result.StrategyID == strategyID
+	}, func(a, b *models.SimulationResult) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+	return limitRows(results, limit), nil
+}
+
+// sorted returns copies of the results matching filter, sorted by less
+func (r *SimulationResultRepository) sorted(filter func(*models.SimulationResult) bool, less func(a, b *models.SimulationResult) bool) []*models.SimulationResult {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var results []*models.SimulationResult
+	for _, row := range r.store.simulationResults.all() {
+		if filter(row) {
+			result := *row
+			results = append(results, &result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return less(results[i], results[j]) })
+	return results
+}
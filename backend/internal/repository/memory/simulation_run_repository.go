@@ -0,0 +1,131 @@
+// internal/repository/memory/simulation_run_repository.go
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.SimulationRunRepositoryInterface = (*SimulationRunRepository)(nil)
+
+// SimulationRunRepository is an in-memory SimulationRunRepositoryInterface
+type SimulationRunRepository struct {
+	store *Store
+}
+
+// NewSimulationRunRepository creates a new in-memory simulation run repository
+func NewSimulationRunRepository(store *Store) *SimulationRunRepository {
+	return &SimulationRunRepository{store: store}
+}
+
+// Save inserts a simulation run; the winner is always left unset
+func (r *SimulationRunRepository) Save(run *models.SimulationRun) (int64, error) {
+	now := time.Now()
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = now
+	}
+	run.UpdatedAt = now
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneSimulationRun(run)
+	row.WinnerStrategyID = 0
+	row.ID = r.store.simulationRuns.insert(row)
+	return row.ID, nil
+}
+
+// GetByID retrieves a simulation run by its ID, or nil if it doesn't exist
+func (r *SimulationRunRepository) GetByID(id int64) (*models.SimulationRun, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.simulationRuns.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	return cloneSimulationRun(row), nil
+}
+
+// GetCurrent retrieves the most recently created preparing or running simulation run
+func (r *SimulationRunRepository) GetCurrent() (*models.SimulationRun, error) {
+	runs := r.newestFirst(func(run *models.SimulationRun) bool {
+		return run.Status == "running" || run.Status == "preparing"
+	})
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return runs[0], nil
+}
+
+// GetByStatus retrieves simulation runs by status, newest first
+func (r *SimulationRunRepository) GetByStatus(status string, limit int) ([]*models.SimulationRun, error) {
+	runs := r.newestFirst(func(run *models.SimulationRun) bool { return run.Status == status })
+	return limitRows(runs, limit), nil
+}
+
+// GetByTimeRange retrieves simulation runs that started and ended within a time range
+func (r *SimulationRunRepository) GetByTimeRange(start, end time.Time) ([]*models.SimulationRun, error) {
+	return r.newestFirst(func(run *models.SimulationRun) bool {
+		return !run.StartTime.Before(start) && !run.EndTime.After(end)
+	}), nil
+}
+
+// UpdateStatus updates the status of a simulation run
+func (r *SimulationRunRepository) UpdateStatus(id int64, status string) error {
+	return r.update(id, func(run *models.SimulationRun) { run.Status = status })
+}
+
+// UpdateWinner updates the winner strategy of a simulation run
+func (r *SimulationRunRepository) UpdateWinner(id int64, strategyID int64) error {
+	return r.update(id, func(run *models.SimulationRun) { run.WinnerStrategyID = strategyID })
+}
+
+// MarkDataDegraded flags a simulation run as having run on degraded market data
+func (r *SimulationRunRepository) MarkDataDegraded(id int64, reason string) error {
+	return r.update(id, func(run *models.SimulationRun) {
+		if run.SimulationParameters == nil {
+			run.SimulationParameters = models.JSONB{}
+		}
+		run.SimulationParameters["data_degraded"] = true
+		run.SimulationParameters["data_degraded_reason"] = reason
+	})
+}
+
+// update applies change to a simulation run and bumps its update time
+func (r *SimulationRunRepository) update(id int64, change func(*models.SimulationRun)) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.simulationRuns.get(id)
+	if row == nil {
+		return fmt.Errorf("simulation run not found: %d", id)
+	}
+	change(row)
+	row.UpdatedAt = time.Now()
+	return nil
+}
+
+// newestFirst returns copies of the runs matching filter, most recently created first
+func (r *SimulationRunRepository) newestFirst(filter func(*models.SimulationRun) bool) []*models.SimulationRun {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var runs []*models.SimulationRun
+	for _, row := range r.store.simulationRuns.all() {
+		if filter(row) {
+			runs = append(runs, cloneSimulationRun(row))
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].CreatedAt.Equal(runs[j].CreatedAt) {
+			return runs[i].CreatedAt.After(runs[j].CreatedAt)
+		}
+		return runs[i].ID > runs[j].ID
+	})
+	return runs
+}
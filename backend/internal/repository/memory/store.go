@@ -0,0 +1,319 @@
+// internal/repository/memory/store.go
+
+// Package memory provides in-memory implementations of the repository interfaces for
+// tests and database-free runs. Repositories created from the same Store share its tables,
+// so joins such as a trade's mint address or a dashboard's strategy names behave like
+// their Postgres counterparts. Unique constraints are enforced; foreign keys are not.
+package memory
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// table holds rows keyed by a serial ID and remembers their insertion order
+type table[T any] struct {
+	lastID int64
+	ids    []int64 // Ascending
+	rows   map[int64]*T
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{rows: make(map[int64]*T)}
+}
+
+// insert stores a row under the next serial ID and returns the ID
+func (t *table[T]) insert(row *T) int64 {
+	t.lastID++
+	t.ids = append(t.ids, t.lastID)
+	t.rows[t.lastID] = row
+	return t.lastID
+}
+
+// get returns the row with the given ID, or nil
+func (t *table[T]) get(id int64) *T {
+	return t.rows[id]
+}
+
+// delete removes the row with the given ID
+func (t *table[T]) delete(id int64) {
+	if _, ok := t.rows[id]; !ok {
+		return
+	}
+	delete(t.rows, id)
+	i := sort.Search(len(t.ids), func(i int) bool { return t.ids[i] >= id })
+	t.ids = append(t.ids[:i], t.ids[i+1:]...)
+}
+
+// all returns the rows in ID order
+func (t *table[T]) all() []*T {
+	return t.after(0)
+}
+
+// after returns the rows with an ID greater than afterID in ID order
+func (t *table[T]) after(afterID int64) []*T {
+	i := sort.Search(len(t.ids), func(i int) bool { return t.ids[i] > afterID })
+	rows := make([]*T, 0, len(t.ids)-i)
+	for _, id := range t.ids[i:] {
+		rows = append(rows, t.rows[id])
+	}
+	return rows
+}
+
+// candleKey is the unique key of a candle
+type candleKey struct {
+	tokenID     int64
+	intervalSec int
+	bucketStart int64
+}
+
+// Store holds the tables shared by the in-memory repositories. It is safe for
+// concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	strategies          *table[models.Strategy]
+	strategyMetrics     *table[models.StrategyMetric]
+	strategyGenerations *table[models.StrategyGeneration]
+	simulationRuns      *table[models.SimulationRun]
+	simulationResults   *table[models.SimulationResult]
+	simulationEvents    *table[models.SimulationEvent]
+	simulatedTrades     *table[models.SimulatedTrade]
+
+	tokens       *table[models.Token]
+	tokensByMint map[string]int64
+	trades       *table[models.Trade]
+	tradesBySig  map[string]int64
+
+	candles          *table[models.Candle]
+	candlesByKey     map[candleKey]int64
+	feedMetrics      *table[models.FeedMetric]
+	dataGaps         *table[models.DataGap]
+	creatorProfiles  map[string]*models.CreatorProfile
+	walletStats      map[string]*models.WalletStats
+	launchAnalyses   map[int64]*models.LaunchAnalysis
+	tokenAnomalies   map[int64]*models.TokenAnomalies
+	tokenTransitions *table[models.TokenTransition]
+	featureSnapshots *table[models.TokenFeatureSnapshot]
+	tokenOutcomes    map[int64]*models.TokenOutcome
+	entryModels      *table[models.EntryModel]
+
+	aiGenerationFailures *table[models.AIGenerationFailure]
+	aiUsage              *table[models.AIUsage]
+	strategyCandidates   *table[models.StrategyCandidate]
+	strategyDrafts       *table[models.StrategyDraft]
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{
+		strategies:          newTable[models.Strategy](),
+		strategyMetrics:     newTable[models.StrategyMetric](),
+		strategyGenerations: newTable[models.StrategyGeneration](),
+		simulationRuns:      newTable[models.SimulationRun](),
+		simulationResults:   newTable[models.SimulationResult](),
+		simulationEvents:    newTable[models.SimulationEvent](),
+		simulatedTrades:     newTable[models.SimulatedTrade](),
+		tokens:              newTable[models.Token](),
+		tokensByMint:        make(map[string]int64),
+		trades:              newTable[models.Trade](),
+		tradesBySig:         make(map[string]int64),
+		candles:             newTable[models.Candle](),
+		candlesByKey:        make(map[candleKey]int64),
+		feedMetrics:         newTable[models.FeedMetric](),
+		dataGaps:            newTable[models.DataGap](),
+		creatorProfiles:     make(map[string]*models.CreatorProfile),
+		walletStats:         make(map[string]*models.WalletStats),
+		launchAnalyses:      make(map[int64]*models.LaunchAnalysis),
+		tokenAnomalies:      make(map[int64]*models.TokenAnomalies),
+		tokenTransitions:    newTable[models.TokenTransition](),
+		featureSnapshots:    newTable[models.TokenFeatureSnapshot](),
+		tokenOutcomes:       make(map[int64]*models.TokenOutcome),
+		entryModels:         newTable[models.EntryModel](),
+
+		aiGenerationFailures: newTable[models.AIGenerationFailure](),
+		aiUsage:              newTable[models.AIUsage](),
+		strategyCandidates:   newTable[models.StrategyCandidate](),
+		strategyDrafts:       newTable[models.StrategyDraft](),
+	}
+}
+
+// mintAddress returns the mint address of a token, or false if the token doesn't exist.
+// The caller must hold s.mu.
+func (s *Store) mintAddress(tokenID int64) (string, bool) {
+	token := s.tokens.get(tokenID)
+	if token == nil {
+		return "", false
+	}
+	return token.MintAddress, true
+}
+
+// limitRows truncates rows to limit like SQL LIMIT; a negative limit means no limit
+func limitRows[T any](rows []*T, limit int) []*T {
+	if limit >= 0 && len(rows) > limit {
+		return rows[:limit]
+	}
+	return rows
+}
+
+// pageRows applies SQL OFFSET and LIMIT to rows
+func pageRows[T any](rows []*T, limit, offset int) []*T {
+	if offset >= len(rows) {
+		return nil
+	}
+	if offset > 0 {
+		rows = rows[offset:]
+	}
+	return limitRows(rows, limit)
+}
+
+// clonePtr copies the value a pointer points to
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// cloneJSON deep-copies a value the way a JSONB column stores it
+func cloneJSON[T any](src T) (T, error) {
+	var dst T
+	data, err := json.Marshal(src)
+	if err != nil {
+		return dst, err
+	}
+	err = json.Unmarshal(data, &dst)
+	return dst, err
+}
+
+// cloneJSONB deep-copies a JSONB value; values that can't be encoded are dropped, as they
+// could never have been stored
+func cloneJSONB(src models.JSONB) models.JSONB {
+	dst, err := cloneJSON(src)
+	if err != nil {
+		return nil
+	}
+	return dst
+}
+
+// cloneStrings copies a string slice, keeping nil and empty apart like a TEXT[] column
+func cloneStrings(src []string) []string {
+	if src == nil {
+		return nil
+	}
+	return append([]string{}, src...)
+}
+
+func cloneStrategy(s *models.Strategy) *models.Strategy {
+	c := *s
+	c.Config = cloneJSONB(s.Config)
+	c.Tags = cloneStrings(s.Tags)
+	return &c
+}
+
+func cloneStrategyMetric(m *models.StrategyMetric) *models.StrategyMetric {
+	c := *m
+	c.SimulationRunID = clonePtr(m.SimulationRunID)
+	return &c
+}
+
+func cloneSimulationRun(r *models.SimulationRun) *models.SimulationRun {
+	c := *r
+	c.SimulationParameters = cloneJSONB(r.SimulationParameters)
+	return &c
+}
+
+func cloneSimulationEvent(e *models.SimulationEvent) *models.SimulationEvent {
+	c := *e
+	c.EventData = cloneJSONB(e.EventData)
+	return &c
+}
+
+func cloneSimulatedTrade(t *models.SimulatedTrade) *models.SimulatedTrade {
+	c := *t
+	c.SimulationRunID = clonePtr(t.SimulationRunID)
+	c.ExitPrice = clonePtr(t.ExitPrice)
+	c.ExitTimestamp = clonePtr(t.ExitTimestamp)
+	c.ProfitLoss = clonePtr(t.ProfitLoss)
+	c.ExitReason = clonePtr(t.ExitReason)
+	c.ExitUsdMarketCap = clonePtr(t.ExitUsdMarketCap)
+	c.ModelVersion = clonePtr(t.ModelVersion)
+	if t.PostMortem != nil {
+		postMortem, err := cloneJSON(*t.PostMortem)
+		if err == nil {
+			c.PostMortem = &postMortem
+		}
+	}
+	return &c
+}
+
+func cloneAIGenerationFailure(f *models.AIGenerationFailure) *models.AIGenerationFailure {
+	c := *f
+	c.Errors = cloneStrings(f.Errors)
+	return &c
+}
+
+func cloneStrategyCandidate(sc *models.StrategyCandidate) *models.StrategyCandidate {
+	c := *sc
+	c.Config = cloneJSONB(sc.Config)
+	c.Reasons = cloneStrings(sc.Reasons)
+	c.StrategyID = clonePtr(sc.StrategyID)
+	if sc.Backtest != nil {
+		backtest, err := cloneJSON(*sc.Backtest)
+		if err == nil {
+			c.Backtest = &backtest
+		}
+	}
+	return &c
+}
+
+// cloneStrategyDraft copies a draft, keeping nil lists empty like its JSONB columns
+func cloneStrategyDraft(d *models.StrategyDraft) *models.StrategyDraft {
+	c := *d
+	c.Config = cloneJSONB(d.Config)
+	c.Explanations = append([]models.DraftExplanation{}, d.Explanations...)
+	c.Ambiguities = append([]models.DraftAmbiguity{}, d.Ambiguities...)
+	c.Instructions = append([]string{}, d.Instructions...)
+	c.StrategyID = clonePtr(d.StrategyID)
+	return &c
+}
+
+func cloneDataGap(g *models.DataGap) *models.DataGap {
+	c := *g
+	c.EndedAt = clonePtr(g.EndedAt)
+	return &c
+}
+
+func cloneFeatureSnapshot(s *models.TokenFeatureSnapshot) (*models.TokenFeatureSnapshot, error) {
+	c := *s
+	c.StrategyID = clonePtr(s.StrategyID)
+	c.SimulationRunID = clonePtr(s.SimulationRunID)
+	c.SimulatedTradeID = clonePtr(s.SimulatedTradeID)
+	features, err := cloneJSON(s.Features)
+	if err != nil {
+		return nil, err
+	}
+	c.Features = features
+	return &c, nil
+}
+
+// tokenTrades returns copies of a token's trades with a timestamp in [fromSec, untilSec]
+// in chronological order, without mint addresses
+func (s *Store) tokenTrades(tokenID int64, fromSec, untilSec int64, limit int) []*models.Trade {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var trades []*models.Trade
+	for _, row := range s.trades.all() {
+		if row.TokenID == tokenID && row.Timestamp >= fromSec && row.Timestamp <= untilSec {
+			trade := *row
+			trades = append(trades, &trade)
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].Timestamp < trades[j].Timestamp })
+	return limitRows(trades, limit)
+}
@@ -0,0 +1,61 @@
+// internal/repository/memory/strategy_candidate_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.StrategyCandidateRepositoryInterface = (*StrategyCandidateRepository)(nil)
+
+// StrategyCandidateRepository is an in-memory StrategyCandidateRepositoryInterface
+type StrategyCandidateRepository struct {
+	store *Store
+}
+
+// NewStrategyCandidateRepository creates a new in-memory strategy candidate repository
+func NewStrategyCandidateRepository(store *Store) *StrategyCandidateRepository {
+	return &StrategyCandidateRepository{store: store}
+}
+
+// Save inserts a strategy candidate
+func (r *StrategyCandidateRepository) Save(candidate *models.StrategyCandidate) (int64, error) {
+	if candidate.CreatedAt.IsZero() {
+		candidate.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneStrategyCandidate(candidate)
+	if row.Reasons == nil {
+		row.Reasons = []string{}
+	}
+	row.ID = r.store.strategyCandidates.insert(row)
+	candidate.ID = row.ID
+	return row.ID, nil
+}
+
+// GetRecent retrieves the most recent strategy candidates with the given status, newest
+// first. An empty status returns candidates of every status.
+func (r *StrategyCandidateRepository) GetRecent(status string, limit int) ([]*models.StrategyCandidate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var candidates []*models.StrategyCandidate
+	for _, row := range r.store.strategyCandidates.all() {
+		if status == "" || row.Status == status {
+			candidates = append(candidates, cloneStrategyCandidate(row))
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].CreatedAt.Equal(candidates[j].CreatedAt) {
+			return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+		}
+		return candidates[i].ID > candidates[j].ID
+	})
+	return limitRows(candidates, limit), nil
+}
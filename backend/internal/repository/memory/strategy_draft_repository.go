@@ -0,0 +1,69 @@
+// internal/repository/memory/strategy_draft_repository.go
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.StrategyDraftRepositoryInterface = (*StrategyDraftRepository)(nil)
+
+// StrategyDraftRepository is an in-memory StrategyDraftRepositoryInterface
+type StrategyDraftRepository struct {
+	store *Store
+}
+
+// NewStrategyDraftRepository creates a new in-memory strategy draft repository
+func NewStrategyDraftRepository(store *Store) *StrategyDraftRepository {
+	return &StrategyDraftRepository{store: store}
+}
+
+// Save inserts a strategy draft
+func (r *StrategyDraftRepository) Save(draft *models.StrategyDraft) (int64, error) {
+	now := time.Now()
+	if draft.CreatedAt.IsZero() {
+		draft.CreatedAt = now
+	}
+	draft.UpdatedAt = now
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneStrategyDraft(draft)
+	row.ID = r.store.strategyDrafts.insert(row)
+	draft.ID = row.ID
+	return row.ID, nil
+}
+
+// GetByID retrieves a strategy draft by its ID, or nil if it doesn't exist
+func (r *StrategyDraftRepository) GetByID(id int64) (*models.StrategyDraft, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.strategyDrafts.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	return cloneStrategyDraft(row), nil
+}
+
+// Update updates an existing strategy draft
+func (r *StrategyDraftRepository) Update(draft *models.StrategyDraft) error {
+	draft.UpdatedAt = time.Now()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.strategyDrafts.get(draft.ID)
+	if row == nil {
+		return fmt.Errorf("strategy draft not found: %d", draft.ID)
+	}
+
+	updated := cloneStrategyDraft(draft)
+	updated.CreatedAt = row.CreatedAt
+	*row = *updated
+	return nil
+}
@@ -0,0 +1,93 @@
+// internal/repository/memory/strategy_generation_repository.go
+package memory
+
+import (
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.StrategyGenerationRepositoryInterface = (*StrategyGenerationRepository)(nil)
+
+// StrategyGenerationRepository is an in-memory StrategyGenerationRepositoryInterface
+type StrategyGenerationRepository struct {
+	store *Store
+}
+
+// NewStrategyGenerationRepository creates a new in-memory strategy generation repository
+func NewStrategyGenerationRepository(store *Store) *StrategyGenerationRepository {
+	return &StrategyGenerationRepository{store: store}
+}
+
+// Save inserts a strategy generation
+func (r *StrategyGenerationRepository) Save(generation *models.StrategyGeneration) (int64, error) {
+	if generation.CreatedAt.IsZero() {
+		generation.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *generation
+	row.ID = r.store.strategyGenerations.insert(&row)
+	return row.ID, nil
+}
+
+// GetByID retrieves a strategy generation by its ID, or nil if it doesn't exist
+func (r *StrategyGenerationRepository) GetByID(id int64) (*models.StrategyGeneration, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.strategyGenerations.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	generation := *row
+	return &generation, nil
+}
+
+// GetByParentStrategy retrieves the generations bred from a strategy, oldest first
+func (r *StrategyGenerationRepository) GetByParentStrategy(parentStrategyID int64) ([]*models.StrategyGeneration, error) {
+	return r.filter(func(g *models.StrategyGeneration) bool { return g.ParentStrategyID == parentStrategyID }), nil
+}
+
+// GetByChildStrategy retrieves the generations that produced a strategy, oldest first
+func (r *StrategyGenerationRepository) GetByChildStrategy(childStrategyID int64) ([]*models.StrategyGeneration, error) {
+	return r.filter(func(g *models.StrategyGeneration) bool { return g.ChildStrategyID == childStrategyID }), nil
+}
+
+// GetByGenerationNumber retrieves the generations with a generation number in insertion order
+func (r *StrategyGenerationRepository) GetByGenerationNumber(generationNumber int, limit, offset int) ([]*models.StrategyGeneration, error) {
+	generations := r.filter(func(g *models.StrategyGeneration) bool { return g.GenerationNumber == generationNumber })
+	return pageRows(generations, limit, offset), nil
+}
+
+// GetLatestGeneration returns the highest generation number, or 0 when there are none
+func (r *StrategyGenerationRepository) GetLatestGeneration() (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	latest := 0
+	for i, row := range r.store.strategyGenerations.all() {
+		if i == 0 || row.GenerationNumber > latest {
+			latest = row.GenerationNumber
+		}
+	}
+	return latest, nil
+}
+
+// filter returns copies of the generations matching keep in insertion order
+func (r *StrategyGenerationRepository) filter(keep func(*models.StrategyGeneration) bool) []*models.StrategyGeneration {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var generations []*models.StrategyGeneration
+	for _, row := range r.store.strategyGenerations.all() {
+		if keep(row) {
+			generation := *row
+			generations = append(generations, &generation)
+		}
+	}
+	return generations
+}
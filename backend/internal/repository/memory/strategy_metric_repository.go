@@ -0,0 +1,146 @@
+// internal/repository/memory/strategy_metric_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.StrategyMetricRepositoryInterface = (*StrategyMetricRepository)(nil)
+
+// StrategyMetricRepository is an in-memory StrategyMetricRepositoryInterface
+type StrategyMetricRepository struct {
+	store *Store
+}
+
+// NewStrategyMetricRepository creates a new in-memory strategy metric repository
+func NewStrategyMetricRepository(store *Store) *StrategyMetricRepository {
+	return &StrategyMetricRepository{store: store}
+}
+
+// Save inserts a strategy metric
+func (r *StrategyMetricRepository) Save(metric *models.StrategyMetric) (int64, error) {
+	if metric.CreatedAt.IsZero() {
+		metric.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneStrategyMetric(metric)
+	row.ID = r.store.strategyMetrics.insert(row)
+	return row.ID, nil
+}
+
+// GetByID retrieves a strategy metric by ID, or nil if it doesn't exist
+func (r *StrategyMetricRepository) GetByID(id int64) (*models.StrategyMetric, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.strategyMetrics.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	return cloneStrategyMetric(row), nil
+}
+
+// GetByStrategy retrieves the metrics of a strategy, newest first
+func (r *StrategyMetricRepository) GetByStrategy(strategyID int64) ([]*models.StrategyMetric, error) {
+	return r.newestFirst(func(m *models.StrategyMetric) bool { return m.StrategyID == strategyID }), nil
+}
+
+// GetBySimulationRun retrieves the metrics of a simulation run, newest first
+func (r *StrategyMetricRepository) GetBySimulationRun(simulationRunID int64) ([]*models.StrategyMetric, error) {
+	return r.newestFirst(func(m *models.StrategyMetric) bool {
+		return m.SimulationRunID != nil && *m.SimulationRunID == simulationRunID
+	}), nil
+}
+
+// GetLatestByStrategy retrieves the latest metric for a strategy
+func (r *StrategyMetricRepository) GetLatestByStrategy(strategyID int64) (*models.StrategyMetric, error) {
+	return r.GetLatestByStrategyAndSimulation(strategyID, nil)
+}
+
+// GetLatestByStrategyAndSimulation retrieves the latest metric for a strategy, limited to
+// a simulation run when simulationRunID is set
+func (r *StrategyMetricRepository) GetLatestByStrategyAndSimulation(strategyID int64, simulationRunID *int64) (*models.StrategyMetric, error) {
+	metrics := r.newestFirst(func(m *models.StrategyMetric) bool {
+		if m.StrategyID != strategyID {
+			return false
+		}
+		return simulationRunID == nil || (m.SimulationRunID != nil && *m.SimulationRunID == *simulationRunID)
+	})
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+	return metrics[0], nil
+}
+
+// UpdateLatestByStrategy updates the latest metric for the strategy and simulation run of
+// metric, or saves metric as a new one if there is none
+func (r *StrategyMetricRepository) UpdateLatestByStrategy(metric *models.StrategyMetric) error {
+	r.store.mu.Lock()
+	var latest *models.StrategyMetric
+	for _, row := range r.store.strategyMetrics.all() {
+		if row.StrategyID != metric.StrategyID {
+			continue
+		}
+		if metric.SimulationRunID != nil && (row.SimulationRunID == nil || *row.SimulationRunID != *metric.SimulationRunID) {
+			continue
+		}
+		if latest == nil || !row.CreatedAt.Before(latest.CreatedAt) {
+			latest = row
+		}
+	}
+
+	sameRun := latest != nil &&
+		((metric.SimulationRunID == nil && latest.SimulationRunID == nil) ||
+			(metric.SimulationRunID != nil && latest.SimulationRunID != nil))
+	if sameRun {
+		latest.WinRate = metric.WinRate
+		latest.AvgProfit = metric.AvgProfit
+		latest.AvgLoss = metric.AvgLoss
+		latest.MaxDrawdown = metric.MaxDrawdown
+		latest.TotalTrades = metric.TotalTrades
+		latest.SuccessfulTrades = metric.SuccessfulTrades
+		latest.RiskScore = metric.RiskScore
+		latest.ROI = metric.ROI
+		latest.CurrentBalance = metric.CurrentBalance
+		latest.InitialBalance = metric.InitialBalance
+		r.store.mu.Unlock()
+		return nil
+	}
+	r.store.mu.Unlock()
+
+	_, err := r.Save(metric)
+	return err
+}
+
+// newestFirst returns copies of the metrics matching filter, newest first
+func (r *StrategyMetricRepository) newestFirst(filter func(*models.StrategyMetric) bool) []*models.StrategyMetric {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var metrics []*models.StrategyMetric
+	for _, row := range r.store.strategyMetrics.all() {
+		if filter(row) {
+			metrics = append(metrics, cloneStrategyMetric(row))
+		}
+	}
+	sortNewestFirst(metrics)
+	return metrics
+}
+
+// sortNewestFirst orders metrics by creation time, newest first; ties go to the latest
+// inserted
+func sortNewestFirst(metrics []*models.StrategyMetric) {
+	sort.SliceStable(metrics, func(i, j int) bool {
+		if !metrics[i].CreatedAt.Equal(metrics[j].CreatedAt) {
+			return metrics[i].CreatedAt.After(metrics[j].CreatedAt)
+		}
+		return metrics[i].ID > metrics[j].ID
+	})
+}
@@ -0,0 +1,177 @@
+// internal/repository/memory/strategy_repository.go
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.StrategyRepositoryInterface = (*StrategyRepository)(nil)
+
+// StrategyRepository is an in-memory StrategyRepositoryInterface
+type StrategyRepository struct {
+	store *Store
+}
+
+// NewStrategyRepository creates a new in-memory strategy repository
+func NewStrategyRepository(store *Store) *StrategyRepository {
+	return &StrategyRepository{store: store}
+}
+
+// Save inserts a strategy
+func (r *StrategyRepository) Save(strategy *models.Strategy) (int64, error) {
+	now := time.Now()
+	if strategy.CreatedAt.IsZero() {
+		strategy.CreatedAt = now
+	}
+	strategy.UpdatedAt = now
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := cloneStrategy(strategy)
+	row.ID = r.store.strategies.insert(row)
+	return row.ID, nil
+}
+
+// GetByID retrieves a strategy by its ID, or nil if it doesn't exist
+func (r *StrategyRepository) GetByID(id int64) (*models.Strategy, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.strategies.get(id)
+	if row == nil {
+		return nil, nil
+	}
+	return cloneStrategy(row), nil
+}
+
+// ListPublic retrieves public strategies, most recently updated first
+func (r *StrategyRepository) ListPublic(limit, offset int) ([]*models.Strategy, error) {
+	strategies := r.public(func(*models.Strategy) bool { return true }, byUpdatedAtDesc)
+	return pageRows(strategies, limit, offset), nil
+}
+
+// Update updates an existing strategy. Its source never changes.
+func (r *StrategyRepository) Update(strategy *models.Strategy) error {
+	strategy.UpdatedAt = time.Now()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.strategies.get(strategy.ID)
+	if row == nil {
+		return fmt.Errorf("strategy not found: %d", strategy.ID)
+	}
+
+	updated := cloneStrategy(strategy)
+	updated.CreatedAt = row.CreatedAt
+	updated.Source = row.Source
+	*row = *updated
+	return nil
+}
+
+// Delete deletes a strategy
+func (r *StrategyRepository) Delete(id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.strategies.get(id) == nil {
+		return fmt.Errorf("strategy not found: %d", id)
+	}
+	r.store.strategies.delete(id)
+	return nil
+}
+
+// IncrementVoteCount increments the vote count for a strategy
+func (r *StrategyRepository) IncrementVoteCount(id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.strategies.get(id)
+	if row == nil {
+		return fmt.Errorf("strategy not found: %d", id)
+	}
+	row.VoteCount++
+	row.UpdatedAt = time.Now()
+	return nil
+}
+
+// IncrementWinCount increments the win count for a strategy and updates the last win time
+func (r *StrategyRepository) IncrementWinCount(id int64, winTime time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := r.store.strategies.get(id)
+	if row == nil {
+		return fmt.Errorf("strategy not found: %d", id)
+	}
+	row.WinCount++
+	row.LastWinTime = winTime
+	row.UpdatedAt = time.Now()
+	return nil
+}
+
+// SearchByTags retrieves public strategies sharing at least one tag with tags
+func (r *StrategyRepository) SearchByTags(tags []string, limit int) ([]*models.Strategy, error) {
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		wanted[tag] = true
+	}
+
+	strategies := r.public(func(s *models.Strategy) bool {
+		for _, tag := range s.Tags {
+			if wanted[tag] {
+				return true
+			}
+		}
+		return false
+	}, byUpdatedAtDesc)
+	return limitRows(strategies, limit), nil
+}
+
+// GetTopVoted retrieves public strategies with the most votes
+func (r *StrategyRepository) GetTopVoted(limit int) ([]*models.Strategy, error) {
+	strategies := r.public(func(*models.Strategy) bool { return true }, func(a, b *models.Strategy) bool {
+		if a.VoteCount != b.VoteCount {
+			return a.VoteCount > b.VoteCount
+		}
+		return byUpdatedAtDesc(a, b)
+	})
+	return limitRows(strategies, limit), nil
+}
+
+// GetTopWinners retrieves public strategies with the most wins
+func (r *StrategyRepository) GetTopWinners(limit int) ([]*models.Strategy, error) {
+	strategies := r.public(func(*models.Strategy) bool { return true }, func(a, b *models.Strategy) bool {
+		if a.WinCount != b.WinCount {
+			return a.WinCount > b.WinCount
+		}
+		return byUpdatedAtDesc(a, b)
+	})
+	return limitRows(strategies, limit), nil
+}
+
+// public returns copies of the public strategies matching filter, sorted by less
+func (r *StrategyRepository) public(filter func(*models.Strategy) bool, less func(a, b *models.Strategy) bool) []*models.Strategy {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var strategies []*models.Strategy
+	for _, row := range r.store.strategies.all() {
+		if row.IsPublic && filter(row) {
+			strategies = append(strategies, cloneStrategy(row))
+		}
+	}
+	sort.SliceStable(strategies, func(i, j int) bool { return less(strategies[i], strategies[j]) })
+	return strategies
+}
+
+// byUpdatedAtDesc orders strategies by most recent update
+func byUpdatedAtDesc(a, b *models.Strategy) bool {
+	return a.UpdatedAt.After(b.UpdatedAt)
+}
@@ -0,0 +1,40 @@
+// internal/repository/memory/token_anomaly_repository.go
+package memory
+
+import (
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.TokenAnomalyRepositoryInterface = (*TokenAnomalyRepository)(nil)
+
+// TokenAnomalyRepository is an in-memory TokenAnomalyRepositoryInterface
+type TokenAnomalyRepository struct {
+	store *Store
+}
+
+// NewTokenAnomalyRepository creates a new in-memory token anomaly repository
+func NewTokenAnomalyRepository(store *Store) *TokenAnomalyRepository {
+	return &TokenAnomalyRepository{store: store}
+}
+
+// UpsertBatch inserts or replaces the anomaly summaries of several tokens
+func (r *TokenAnomalyRepository) UpsertBatch(anomalies []*models.TokenAnomalies) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for _, a := range anomalies {
+		row := *a
+		row.MintAddress = ""
+		row.FlaggedWallets = make(map[string][]string, len(a.FlaggedWallets))
+		for wallet, flags := range a.FlaggedWallets {
+			row.FlaggedWallets[wallet] = cloneStrings(flags)
+		}
+		row.UpdatedAt = now
+		r.store.tokenAnomalies[row.TokenID] = &row
+	}
+	return nil
+}
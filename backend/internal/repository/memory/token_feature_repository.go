@@ -0,0 +1,59 @@
+// internal/repository/memory/token_feature_repository.go
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.TokenFeatureRepositoryInterface = (*TokenFeatureRepository)(nil)
+
+// TokenFeatureRepository is an in-memory TokenFeatureRepositoryInterface
+type TokenFeatureRepository struct {
+	store *Store
+}
+
+// NewTokenFeatureRepository creates a new in-memory token feature repository
+func NewTokenFeatureRepository(store *Store) *TokenFeatureRepository {
+	return &TokenFeatureRepository{store: store}
+}
+
+// SaveSnapshot inserts the features a strategy decision was based on
+func (r *TokenFeatureRepository) SaveSnapshot(snapshot *models.TokenFeatureSnapshot) (int64, error) {
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = time.Now()
+	}
+	row, err := cloneFeatureSnapshot(snapshot)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding token features: %v", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row.ID = r.store.featureSnapshots.insert(row)
+	return row.ID, nil
+}
+
+// GetSnapshotsBySimulationRun retrieves the feature snapshots recorded during a simulation
+// run in the order they were taken
+func (r *TokenFeatureRepository) GetSnapshotsBySimulationRun(simulationRunID int64) ([]*models.TokenFeatureSnapshot, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var snapshots []*models.TokenFeatureSnapshot
+	for _, row := range r.store.featureSnapshots.all() {
+		if row.SimulationRunID == nil || *row.SimulationRunID != simulationRunID {
+			continue
+		}
+		snapshot, err := cloneFeatureSnapshot(row)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding token features: %v", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
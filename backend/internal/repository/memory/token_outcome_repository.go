@@ -0,0 +1,118 @@
+// internal/repository/memory/token_outcome_repository.go
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.TokenOutcomeRepositoryInterface = (*TokenOutcomeRepository)(nil)
+
+// TokenOutcomeRepository is an in-memory TokenOutcomeRepositoryInterface
+type TokenOutcomeRepository struct {
+	store *Store
+}
+
+// NewTokenOutcomeRepository creates a new in-memory token outcome repository
+func NewTokenOutcomeRepository(store *Store) *TokenOutcomeRepository {
+	return &TokenOutcomeRepository{store: store}
+}
+
+// Upsert inserts or replaces a token's outcome labels
+func (r *TokenOutcomeRepository) Upsert(outcome *models.TokenOutcome) error {
+	outcome.LabeledAt = time.Now()
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *outcome
+	row.MintAddress = ""
+	row.TimeToGraduateSec = clonePtr(outcome.TimeToGraduateSec)
+	r.store.tokenOutcomes[row.TokenID] = &row
+	return nil
+}
+
+// GetUnlabeledTokens retrieves tokens created in [fromMs, toMs) without outcome labels,
+// oldest first
+func (r *TokenOutcomeRepository) GetUnlabeledTokens(fromMs, toMs int64, limit int) ([]*models.Token, error) {
+	r.store.mu.RLock()
+	var tokens []*models.Token
+	for _, row := range r.store.tokens.all() {
+		if row.CreatedTimestamp < fromMs || row.CreatedTimestamp >= toMs {
+			continue
+		}
+		if _, ok := r.store.tokenOutcomes[row.ID]; ok {
+			continue
+		}
+		tokens = append(tokens, &models.Token{
+			ID:               row.ID,
+			MintAddress:      row.MintAddress,
+			CreatedTimestamp: row.CreatedTimestamp,
+			Completed:        row.Completed,
+		})
+	}
+	r.store.mu.RUnlock()
+
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].CreatedTimestamp < tokens[j].CreatedTimestamp })
+	return limitRows(tokens, limit), nil
+}
+
+// GetOutcomeTrades retrieves a token's trades in [fromSec, untilSec] in chronological order
+func (r *TokenOutcomeRepository) GetOutcomeTrades(tokenID int64, fromSec, untilSec int64, limit int) ([]*models.Trade, error) {
+	return r.store.tokenTrades(tokenID, fromSec, untilSec, limit), nil
+}
+
+// GetGraduatedAt returns when a token first transitioned to graduated, or 0 if it never did
+func (r *TokenOutcomeRepository) GetGraduatedAt(tokenID int64) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var graduatedAt int64
+	for _, row := range r.store.tokenTransitions.all() {
+		if row.TokenID == tokenID && row.ToState == models.TokenStateGraduated &&
+			(graduatedAt == 0 || row.Timestamp < graduatedAt) {
+			graduatedAt = row.Timestamp
+		}
+	}
+	return graduatedAt, nil
+}
+
+// GetLabeledSnapshots pages through the feature snapshots taken in [from, to) whose token
+// has outcome labels, in ID order after afterID
+func (r *TokenOutcomeRepository) GetLabeledSnapshots(from, to time.Time, afterID int64, limit int) ([]*models.LabeledFeatureSnapshot, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var labeled []*models.LabeledFeatureSnapshot
+	for _, row := range r.store.featureSnapshots.after(afterID) {
+		if limit >= 0 && len(labeled) == limit {
+			break
+		}
+		if row.CreatedAt.Before(from) || !row.CreatedAt.Before(to) {
+			continue
+		}
+		stored, ok := r.store.tokenOutcomes[row.TokenID]
+		if !ok {
+			continue
+		}
+		mint, ok := r.store.mintAddress(row.TokenID)
+		if !ok {
+			continue
+		}
+
+		snapshot, err := cloneFeatureSnapshot(row)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding token features: %v", err)
+		}
+		outcome := *stored
+		outcome.TokenID = row.TokenID
+		outcome.MintAddress = mint
+		outcome.TimeToGraduateSec = clonePtr(stored.TimeToGraduateSec)
+		labeled = append(labeled, &models.LabeledFeatureSnapshot{Snapshot: snapshot, Outcome: &outcome})
+	}
+	return labeled, nil
+}
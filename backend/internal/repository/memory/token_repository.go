@@ -0,0 +1,107 @@
+// internal/repository/memory/token_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.TokenRepositoryInterface = (*TokenRepository)(nil)
+
+// TokenRepository is an in-memory TokenRepositoryInterface
+type TokenRepository struct {
+	store *Store
+}
+
+// NewTokenRepository creates a new in-memory token repository
+func NewTokenRepository(store *Store) *TokenRepository {
+	return &TokenRepository{store: store}
+}
+
+// Save inserts a token or updates the one with the same mint address. Completion and the
+// king of the hill time are sticky once set.
+func (r *TokenRepository) Save(token *models.Token) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if id, ok := r.store.tokensByMint[token.MintAddress]; ok {
+		row := r.store.tokens.get(id)
+		updated := *token
+		updated.ID = row.ID
+		updated.CreatedAt = row.CreatedAt
+		updated.Completed = row.Completed || token.Completed
+		if row.KingOfTheHillTimeStamp > 0 {
+			updated.KingOfTheHillTimeStamp = row.KingOfTheHillTimeStamp
+		}
+		*row = updated
+		return id, nil
+	}
+
+	row := *token
+	row.CreatedAt = time.Now()
+	row.ID = r.store.tokens.insert(&row)
+	r.store.tokensByMint[row.MintAddress] = row.ID
+	return row.ID, nil
+}
+
+// GetByMintAddress retrieves a token by its mint address, or nil if it doesn't exist
+func (r *TokenRepository) GetByMintAddress(mintAddress string) (*models.Token, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.tokensByMint[mintAddress]
+	if !ok {
+		return nil, nil
+	}
+	token := *r.store.tokens.get(id)
+	return &token, nil
+}
+
+// GetRecentTokens retrieves the most recently created tokens
+func (r *TokenRepository) GetRecentTokens(limit int) ([]*models.Token, error) {
+	tokens := r.newestFirst(func(*models.Token) bool { return true })
+	return limitRows(tokens, limit), nil
+}
+
+// GetFilteredTokens retrieves recent tokens above a market cap that are younger than
+// maxAgeSeconds
+func (r *TokenRepository) GetFilteredTokens(minMarketCapUSD float64, maxAgeSeconds int64, limit int) ([]*models.Token, error) {
+	minTimestamp := (time.Now().Unix() - maxAgeSeconds) * 1000
+
+	tokens := r.newestFirst(func(t *models.Token) bool {
+		return t.UsdMarketCap >= minMarketCapUSD && t.CreatedTimestamp >= minTimestamp
+	})
+	return limitRows(tokens, limit), nil
+}
+
+// GetByID retrieves a token by its ID, or nil if it doesn't exist
+func (r *TokenRepository) GetByID(tokenID int64) (*models.Token, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.store.tokens.get(tokenID)
+	if row == nil {
+		return nil, nil
+	}
+	token := *row
+	return &token, nil
+}
+
+// newestFirst returns copies of the tokens matching filter, most recently created first
+func (r *TokenRepository) newestFirst(filter func(*models.Token) bool) []*models.Token {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tokens []*models.Token
+	for _, row := range r.store.tokens.all() {
+		if filter(row) {
+			token := *row
+			tokens = append(tokens, &token)
+		}
+	}
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].CreatedTimestamp > tokens[j].CreatedTimestamp })
+	return tokens
+}
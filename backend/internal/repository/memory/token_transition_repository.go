@@ -0,0 +1,130 @@
+// internal/repository/memory/token_transition_repository.go
+package memory
+
+import (
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.TokenTransitionRepositoryInterface = (*TokenTransitionRepository)(nil)
+
+// TokenTransitionRepository is an in-memory TokenTransitionRepositoryInterface
+type TokenTransitionRepository struct {
+	store *Store
+}
+
+// NewTokenTransitionRepository creates a new in-memory token transition repository
+func NewTokenTransitionRepository(store *Store) *TokenTransitionRepository {
+	return &TokenTransitionRepository{store: store}
+}
+
+// Save inserts a token lifecycle transition
+func (r *TokenTransitionRepository) Save(transition *models.TokenTransition) (int64, error) {
+	if transition.CreatedAt.IsZero() {
+		transition.CreatedAt = time.Now()
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row := *transition
+	row.MintAddress = ""
+	row.ID = r.store.tokenTransitions.insert(&row)
+	return row.ID, nil
+}
+
+// GetByTokenID retrieves a token's transitions in the order they were recorded
+func (r *TokenTransitionRepository) GetByTokenID(tokenID int64) ([]*models.TokenTransition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var transitions []*models.TokenTransition
+	for _, row := range r.store.tokenTransitions.all() {
+		if row.TokenID != tokenID {
+			continue
+		}
+		if transition := r.withMint(row); transition != nil {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+// GetAfterID retrieves transitions recorded after afterID in ID order
+func (r *TokenTransitionRepository) GetAfterID(afterID int64, limit int) ([]*models.TokenTransition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var transitions []*models.TokenTransition
+	for _, row := range r.store.tokenTransitions.after(afterID) {
+		if limit >= 0 && len(transitions) == limit {
+			break
+		}
+		if transition := r.withMint(row); transition != nil {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+// GetLastID returns the ID of the most recent transition, or 0 if there are none
+func (r *TokenTransitionRepository) GetLastID() (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rows := r.store.tokenTransitions.all()
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[len(rows)-1].ID, nil
+}
+
+// GetStale retrieves the latest transition of each token still in a pre-graduation state
+// that has neither transitioned nor traded since cutoff, in token order
+func (r *TokenTransitionRepository) GetStale(cutoff int64, limit int) ([]*models.TokenTransition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	latest := make(map[int64]*models.TokenTransition)
+	for _, row := range r.store.tokenTransitions.all() {
+		latest[row.TokenID] = row
+	}
+	traded := make(map[int64]bool)
+	for _, trade := range r.store.trades.all() {
+		if trade.Timestamp >= cutoff {
+			traded[trade.TokenID] = true
+		}
+	}
+
+	var transitions []*models.TokenTransition
+	for _, token := range r.store.tokens.all() {
+		if limit >= 0 && len(transitions) == limit {
+			break
+		}
+		row, ok := latest[token.ID]
+		if !ok || row.Timestamp >= cutoff || traded[token.ID] {
+			continue
+		}
+		switch row.ToState {
+		case models.TokenStateCreated, models.TokenStateTrading, models.TokenStateKingOfTheHill:
+			transition := *row
+			transition.MintAddress = token.MintAddress
+			transitions = append(transitions, &transition)
+		}
+	}
+	return transitions, nil
+}
+
+// withMint returns a copy of a transition joined to its token's mint address, or nil if
+// the token doesn't exist. The caller must hold the store lock.
+func (r *TokenTransitionRepository) withMint(row *models.TokenTransition) *models.TokenTransition {
+	mint, ok := r.store.mintAddress(row.TokenID)
+	if !ok {
+		return nil
+	}
+	transition := *row
+	transition.MintAddress = mint
+	return &transition
+}
@@ -0,0 +1,160 @@
+// internal/repository/memory/trade_repository.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.TradeRepositoryInterface = (*TradeRepository)(nil)
+
+// TradeRepository is an in-memory TradeRepositoryInterface
+type TradeRepository struct {
+	store *Store
+}
+
+// NewTradeRepository creates a new in-memory trade repository
+func NewTradeRepository(store *Store) *TradeRepository {
+	return &TradeRepository{store: store}
+}
+
+// Save inserts a trade. A trade whose signature is already stored is ignored and 0 is
+// returned.
+func (r *TradeRepository) Save(trade *models.Trade) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.tradesBySig[trade.Signature]; ok {
+		return 0, nil
+	}
+
+	row := *trade
+	row.MintAddress = ""
+	row.ID = r.store.trades.insert(&row)
+	r.store.tradesBySig[row.Signature] = row.ID
+	return row.ID, nil
+}
+
+// GetTradesByTokenID retrieves the latest trades of a token
+func (r *TradeRepository) GetTradesByTokenID(tokenID int64, limit int) ([]*models.Trade, error) {
+	return r.GetTradesByTokenIDWithContext(context.Background(), tokenID, limit)
+}
+
+// GetTradesByTokenIDWithContext retrieves the latest trades of a token with context
+func (r *TradeRepository) GetTradesByTokenIDWithContext(ctx context.Context, tokenID int64, limit int) ([]*models.Trade, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error getting trades: %v", err)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var trades []*models.Trade
+	for _, row := range r.store.trades.all() {
+		if row.TokenID == tokenID {
+			trade := *row
+			trades = append(trades, &trade)
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].Timestamp > trades[j].Timestamp })
+	return limitRows(trades, limit), nil
+}
+
+// GetTradesBySignature retrieves a trade by its signature, or nil if it doesn't exist
+func (r *TradeRepository) GetTradesBySignature(signature string) (*models.Trade, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.tradesBySig[signature]
+	if !ok {
+		return nil, nil
+	}
+	trade := *r.store.trades.get(id)
+	return &trade, nil
+}
+
+// GetTradesAfterID retrieves trades with an ID greater than afterID in ID order, with
+// their mint addresses
+func (r *TradeRepository) GetTradesAfterID(afterID int64, limit int) ([]*models.Trade, error) {
+	return r.withMint(afterID, limit, func(*models.Trade) bool { return true }), nil
+}
+
+// GetTradesByTimeRange retrieves trades in [from, to) with an ID greater than afterID in
+// ID order, with their mint addresses
+func (r *TradeRepository) GetTradesByTimeRange(from, to int64, afterID int64, limit int) ([]*models.Trade, error) {
+	return r.withMint(afterID, limit, func(t *models.Trade) bool {
+		return t.Timestamp >= from && t.Timestamp < to
+	}), nil
+}
+
+// GetLastTradeIDBefore returns the highest ID of the trades before timestamp, or 0
+func (r *TradeRepository) GetLastTradeIDBefore(timestamp int64) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var lastID int64
+	for _, row := range r.store.trades.all() {
+		if row.Timestamp < timestamp {
+			lastID = row.ID
+		}
+	}
+	return lastID, nil
+}
+
+// GetHolderBalances returns every wallet's net token balance in a token along with the
+// highest trade ID included
+func (r *TradeRepository) GetHolderBalances(tokenID int64) ([]*models.HolderBalance, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byWallet := make(map[string]*models.HolderBalance)
+	var balances []*models.HolderBalance
+	var lastID int64
+	for _, row := range r.store.trades.all() {
+		if row.TokenID != tokenID {
+			continue
+		}
+		balance, ok := byWallet[row.UserAddress]
+		if !ok {
+			balance = &models.HolderBalance{WalletAddress: row.UserAddress}
+			byWallet[row.UserAddress] = balance
+			balances = append(balances, balance)
+		}
+		if row.IsBuy {
+			balance.Balance += row.TokenAmount
+		} else {
+			balance.Balance -= row.TokenAmount
+		}
+		lastID = row.ID
+	}
+	return balances, lastID, nil
+}
+
+// withMint returns copies of the trades after afterID matching filter, joined to their
+// token's mint address. Trades of unknown tokens are skipped like an inner join would.
+func (r *TradeRepository) withMint(afterID int64, limit int, filter func(*models.Trade) bool) []*models.Trade {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var trades []*models.Trade
+	for _, row := range r.store.trades.after(afterID) {
+		if limit >= 0 && len(trades) == limit {
+			break
+		}
+		if !filter(row) {
+			continue
+		}
+		mint, ok := r.store.mintAddress(row.TokenID)
+		if !ok {
+			continue
+		}
+		trade := *row
+		trade.MintAddress = mint
+		trades = append(trades, &trade)
+	}
+	return trades
+}
@@ -0,0 +1,183 @@
+// internal/repository/memory/wallet_stats_repository.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var _ repository.WalletStatsRepositoryInterface = (*WalletStatsRepository)(nil)
+
+// WalletStatsRepository is an in-memory WalletStatsRepositoryInterface
+type WalletStatsRepository struct {
+	store *Store
+}
+
+// NewWalletStatsRepository creates a new in-memory wallet stats repository
+func NewWalletStatsRepository(store *Store) *WalletStatsRepository {
+	return &WalletStatsRepository{store: store}
+}
+
+// UpsertBatch inserts or replaces the stats of several wallets. Ranks are kept until the
+// next UpdateRanks.
+func (r *WalletStatsRepository) UpsertBatch(stats []*models.WalletStats) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for _, s := range stats {
+		row := *s
+		row.Rank = 0
+		row.IsSmart = false
+		if existing, ok := r.store.walletStats[s.WalletAddress]; ok {
+			row.Rank = existing.Rank
+			row.IsSmart = existing.IsSmart
+		}
+		row.UpdatedAt = now
+		r.store.walletStats[row.WalletAddress] = &row
+	}
+	return nil
+}
+
+// UpdateRanks ranks every wallet with a positive smart score and flags the top smartCount
+// as smart
+func (r *WalletStatsRepository) UpdateRanks(smartCount int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ranked []*models.WalletStats
+	for _, row := range r.store.walletStats {
+		row.Rank = 0
+		row.IsSmart = false
+		if row.SmartScore > 0 {
+			ranked = append(ranked, row)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.SmartScore != b.SmartScore {
+			return a.SmartScore > b.SmartScore
+		}
+		if a.RealizedPnlSol != b.RealizedPnlSol {
+			return a.RealizedPnlSol > b.RealizedPnlSol
+		}
+		return a.WalletAddress < b.WalletAddress
+	})
+	for i, row := range ranked {
+		row.Rank = i + 1
+		row.IsSmart = row.Rank <= smartCount
+	}
+	return nil
+}
+
+// GetByAddress retrieves a wallet's stats, or nil if none have been computed
+func (r *WalletStatsRepository) GetByAddress(walletAddress string) (*models.WalletStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.walletStats[walletAddress]
+	if !ok {
+		return nil, nil
+	}
+	stats := *row
+	return &stats, nil
+}
+
+// GetSmartWallets retrieves the smart wallets in rank order
+func (r *WalletStatsRepository) GetSmartWallets(limit int) ([]*models.WalletStats, error) {
+	r.store.mu.RLock()
+	var wallets []*models.WalletStats
+	for _, row := range r.store.walletStats {
+		if row.IsSmart {
+			stats := *row
+			wallets = append(wallets, &stats)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].Rank < wallets[j].Rank })
+	return limitRows(wallets, limit), nil
+}
+
+// GetActiveWallets pages through the distinct wallets that traded since sinceSec, in
+// address order after afterAddress
+func (r *WalletStatsRepository) GetActiveWallets(sinceSec int64, afterAddress string, limit int) ([]string, error) {
+	r.store.mu.RLock()
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, trade := range r.store.trades.all() {
+		if trade.Timestamp >= sinceSec && trade.UserAddress > afterAddress && !seen[trade.UserAddress] {
+			seen[trade.UserAddress] = true
+			addresses = append(addresses, trade.UserAddress)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Strings(addresses)
+	if limit >= 0 && len(addresses) > limit {
+		addresses = addresses[:limit]
+	}
+	return addresses, nil
+}
+
+// GetPositions aggregates the trades of the given wallets into one position per token,
+// ordered by wallet and token
+func (r *WalletStatsRepository) GetPositions(walletAddresses []string) ([]*models.WalletPosition, error) {
+	wanted := make(map[string]bool, len(walletAddresses))
+	for _, address := range walletAddresses {
+		wanted[address] = true
+	}
+
+	type positionKey struct {
+		wallet  string
+		tokenID int64
+	}
+
+	r.store.mu.RLock()
+	byKey := make(map[positionKey]*models.WalletPosition)
+	var positions []*models.WalletPosition
+	for _, trade := range r.store.trades.all() {
+		if !wanted[trade.UserAddress] {
+			continue
+		}
+		token := r.store.tokens.get(trade.TokenID)
+		if token == nil {
+			continue
+		}
+
+		key := positionKey{wallet: trade.UserAddress, tokenID: trade.TokenID}
+		position, ok := byKey[key]
+		if !ok {
+			position = &models.WalletPosition{WalletAddress: trade.UserAddress, TokenID: trade.TokenID}
+			byKey[key] = position
+			positions = append(positions, position)
+		}
+		if trade.IsBuy {
+			position.BuySol += trade.SolAmount
+			position.BuyTokens += trade.TokenAmount
+			position.BuyCount++
+		} else {
+			position.SellSol += trade.SolAmount
+			position.SellTokens += trade.TokenAmount
+			position.SellCount++
+		}
+		if trade.Timestamp > position.LastTradeAt {
+			position.LastTradeAt = trade.Timestamp
+		}
+		if token.CreatorAddress == trade.UserAddress {
+			position.IsCreator = true
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].WalletAddress != positions[j].WalletAddress {
+			return positions[i].WalletAddress < positions[j].WalletAddress
+		}
+		return positions[i].TokenID < positions[j].TokenID
+	})
+	return positions, nil
+}
@@ -37,6 +37,10 @@ type TradeRepositoryInterface interface {
 	GetTradesByTokenID(tokenID int64, limit int) ([]*models.Trade, error)
 	GetTradesByTokenIDWithContext(ctx context.Context, tokenID int64, limit int) ([]*models.Trade, error)
 	GetTradesBySignature(signature string) (*models.Trade, error)
+	GetTradesAfterID(afterID int64, limit int) ([]*models.Trade, error)
+	GetTradesByTimeRange(from, to int64, afterID int64, limit int) ([]*models.Trade, error)
+	GetLastTradeIDBefore(timestamp int64) (int64, error)
+	GetHolderBalances(tokenID int64) ([]*models.HolderBalance, int64, error)
 }
 
 // StrategyRepositoryInterface defines the interface for strategy repository operations
@@ -73,6 +77,7 @@ type SimulationRunRepositoryInterface interface {
 	GetByTimeRange(start, end time.Time) ([]*models.SimulationRun, error)
 	UpdateStatus(id int64, status string) error
 	UpdateWinner(id int64, strategyID int64) error
+	MarkDataDegraded(id int64, reason string) error
 }
 
 // SimulationResultRepositoryInterface for managing simulation results
@@ -114,6 +119,10 @@ type SimulatedTradeRepositoryInterface interface {
 	GetBySimulationRunWithContext(ctx context.Context, simulationRunID int64) ([]*models.SimulatedTrade, error)
 	ExistsByStrategyIDAndTokenID(strategyID int64, tokenID int64) (bool, error)
 	ExistsByStrategyIDAndTokenIDWithContext(ctx context.Context, strategyID int64, tokenID int64) (bool, error)
+	GetByID(id int64) (*models.SimulatedTrade, error) // nil if the trade doesn't exist
+	GetByIDWithContext(ctx context.Context, id int64) (*models.SimulatedTrade, error)
+	SavePostMortem(tradeID int64, postMortem *models.TradePostMortem) error
+	SavePostMortemWithContext(ctx context.Context, tradeID int64, postMortem *models.TradePostMortem) error
 }
 
 // SimulationEventRepositoryInterface for managing simulation events
@@ -124,3 +133,116 @@ type SimulationEventRepositoryInterface interface {
 	GetBySimulationRunID(simulationRunID int64, limit, offset int) ([]*models.SimulationEvent, error)
 	GetLatestByStrategyID(strategyID int64, limit int) ([]*models.SimulationEvent, error)
 }
+
+// CandleRepositoryInterface defines the interface for candle repository operations
+type CandleRepositoryInterface interface {
+	Upsert(candle *models.Candle) error
+	UpsertBatch(candles []*models.Candle) error
+	GetByToken(tokenID int64, intervalSec int, from, to int64, limit int) ([]*models.Candle, error)
+}
+
+// FeedMetricRepositoryInterface defines the interface for feed metric repository operations
+type FeedMetricRepositoryInterface interface {
+	Save(metric *models.FeedMetric) (int64, error)
+	GetLatest(source string) (*models.FeedMetric, error)
+	GetSince(source string, since time.Time) ([]*models.FeedMetric, error)
+}
+
+// DataGapRepositoryInterface defines the interface for data gap repository operations
+type DataGapRepositoryInterface interface {
+	Save(gap *models.DataGap) (int64, error)
+	Close(id int64, endedAt time.Time) error
+	GetOpen(source string) (*models.DataGap, error)
+	GetOverlapping(from, to time.Time) ([]*models.DataGap, error)
+	GetRecent(limit int) ([]*models.DataGap, error)
+}
+
+// CreatorProfileRepositoryInterface defines the interface for creator profile repository operations
+type CreatorProfileRepositoryInterface interface {
+	Upsert(profile *models.CreatorProfile) error
+	GetByAddress(creatorAddress string) (*models.CreatorProfile, error)
+	GetTop(minLaunches int, ascending bool, limit int) ([]*models.CreatorProfile, error)
+	GetLaunches(creatorAddress string) ([]*models.CreatorLaunch, error)
+	GetCreatorAddresses(sinceMs int64, afterAddress string, limit int) ([]string, error)
+}
+
+// WalletStatsRepositoryInterface defines the interface for wallet stats repository operations
+type WalletStatsRepositoryInterface interface {
+	UpsertBatch(stats []*models.WalletStats) error
+	UpdateRanks(smartCount int) error
+	GetByAddress(walletAddress string) (*models.WalletStats, error)
+	GetSmartWallets(limit int) ([]*models.WalletStats, error)
+	GetActiveWallets(sinceSec int64, afterAddress string, limit int) ([]string, error)
+	GetPositions(walletAddresses []string) ([]*models.WalletPosition, error)
+}
+
+// LaunchAnalysisRepositoryInterface defines the interface for launch analysis repository operations
+type LaunchAnalysisRepositoryInterface interface {
+	Upsert(analysis *models.LaunchAnalysis) error
+	GetByTokenID(tokenID int64) (*models.LaunchAnalysis, error)
+	GetUnanalyzedTokens(fromMs, toMs int64, limit int) ([]*models.Token, error)
+	GetLaunchTrades(tokenID int64, untilSec int64, limit int) ([]*models.Trade, error)
+	GetFirstTradeTimes(walletAddresses []string) (map[string]int64, error)
+}
+
+// TokenAnomalyRepositoryInterface defines the interface for token anomaly repository operations
+type TokenAnomalyRepositoryInterface interface {
+	UpsertBatch(anomalies []*models.TokenAnomalies) error
+}
+
+// TokenTransitionRepositoryInterface defines the interface for token lifecycle transition repository operations
+type TokenTransitionRepositoryInterface interface {
+	Save(transition *models.TokenTransition) (int64, error)
+	GetByTokenID(tokenID int64) ([]*models.TokenTransition, error)
+	GetAfterID(afterID int64, limit int) ([]*models.TokenTransition, error)
+	GetLastID() (int64, error)
+	GetStale(cutoff int64, limit int) ([]*models.TokenTransition, error)
+}
+
+// TokenFeatureRepositoryInterface defines the interface for token feature snapshot repository operations
+type TokenFeatureRepositoryInterface interface {
+	SaveSnapshot(snapshot *models.TokenFeatureSnapshot) (int64, error)
+	GetSnapshotsBySimulationRun(simulationRunID int64) ([]*models.TokenFeatureSnapshot, error)
+}
+
+// TokenOutcomeRepositoryInterface defines the interface for token outcome label repository operations
+type TokenOutcomeRepositoryInterface interface {
+	Upsert(outcome *models.TokenOutcome) error
+	GetUnlabeledTokens(fromMs, toMs int64, limit int) ([]*models.Token, error)
+	GetOutcomeTrades(tokenID int64, fromSec, untilSec int64, limit int) ([]*models.Trade, error)
+	GetGraduatedAt(tokenID int64) (int64, error)
+	GetLabeledSnapshots(from, to time.Time, afterID int64, limit int) ([]*models.LabeledFeatureSnapshot, error)
+}
+
+// EntryModelRepositoryInterface defines the interface for entry model repository operations
+type EntryModelRepositoryInterface interface {
+	Save(model *models.EntryModel) error
+	GetLatest(name string) (*models.EntryModel, error)
+	GetByVersion(name string, version int) (*models.EntryModel, error)
+}
+
+// AIGenerationFailureRepositoryInterface defines the interface for AI generation failure repository operations
+type AIGenerationFailureRepositoryInterface interface {
+	Save(failure *models.AIGenerationFailure) (int64, error)
+	GetRecent(limit int) ([]*models.AIGenerationFailure, error)
+}
+
+// AIUsageRepositoryInterface defines the interface for AI usage repository operations
+type AIUsageRepositoryInterface interface {
+	Save(usage *models.AIUsage) (int64, error)
+	SumTokens(purpose string, since time.Time) (int64, error)
+	Summarize(since time.Time) ([]*models.AIUsageSummary, error)
+}
+
+// StrategyCandidateRepositoryInterface defines the interface for strategy candidate repository operations
+type StrategyCandidateRepositoryInterface interface {
+	Save(candidate *models.StrategyCandidate) (int64, error)
+	GetRecent(status string, limit int) ([]*models.StrategyCandidate, error) // An empty status returns every candidate
+}
+
+// StrategyDraftRepositoryInterface defines the interface for strategy draft repository operations
+type StrategyDraftRepositoryInterface interface {
+	Save(draft *models.StrategyDraft) (int64, error)
+	GetByID(id int64) (*models.StrategyDraft, error) // nil if the draft doesn't exist
+	Update(draft *models.StrategyDraft) error
+}
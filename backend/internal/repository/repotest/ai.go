@@ -0,0 +1,262 @@
+// internal/repository/repotest/ai.go
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAIGenerationFailure(t *testing.T, repos *Repositories) {
+	failures, err := repos.AIGenerationFailure.GetRecent(10)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+
+	now := time.Now()
+	older := &models.AIGenerationFailure{
+		Purpose:      models.AIPurposeStrategyGeneration,
+		Provider:     "openai",
+		Model:        "gpt-4",
+		Attempts:     3,
+		Errors:       []string{"stopLossPct: must be at most 99", "name: is required"},
+		LastResponse: `{"stopLossPct": 150}`,
+		CreatedAt:    now.Add(-time.Minute),
+	}
+	olderID, err := repos.AIGenerationFailure.Save(older)
+	require.NoError(t, err)
+	assert.NotZero(t, olderID)
+	assert.Equal(t, olderID, older.ID)
+
+	newerID, err := repos.AIGenerationFailure.Save(&models.AIGenerationFailure{
+		Purpose:  models.AIPurposeStrategyGeneration,
+		Provider: "mock",
+		Attempts: 1,
+	})
+	require.NoError(t, err)
+
+	failures, err = repos.AIGenerationFailure.GetRecent(10)
+	require.NoError(t, err)
+	require.Len(t, failures, 2)
+	assert.Equal(t, newerID, failures[0].ID, "newest first")
+	assert.Empty(t, failures[0].Errors)
+	assert.Empty(t, failures[0].Model)
+
+	got := failures[1]
+	assert.Equal(t, olderID, got.ID)
+	assert.Equal(t, "openai", got.Provider)
+	assert.Equal(t, "gpt-4", got.Model)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Equal(t, older.Errors, got.Errors)
+	assert.Equal(t, older.LastResponse, got.LastResponse)
+	assert.WithinDuration(t, older.CreatedAt, got.CreatedAt, timeTolerance)
+
+	failures, err = repos.AIGenerationFailure.GetRecent(1)
+	require.NoError(t, err)
+	assert.Len(t, failures, 1)
+}
+
+func testAIUsage(t *testing.T, repos *Repositories) {
+	now := time.Now()
+	since := now.Add(-time.Hour)
+
+	summaries, err := repos.AIUsage.Summarize(since)
+	require.NoError(t, err)
+	assert.Empty(t, summaries)
+
+	save := func(usage *models.AIUsage) {
+		t.Helper()
+		id, err := repos.AIUsage.Save(usage)
+		require.NoError(t, err)
+		assert.NotZero(t, id)
+		assert.Equal(t, id, usage.ID)
+	}
+	save(&models.AIUsage{
+		Purpose: models.AIPurposeStrategyGeneration, Provider: "openai", Model: "gpt-4",
+		InputTokens: 100, OutputTokens: 50, LatencyMs: 200, Outcome: models.AIOutcomeSuccess,
+		CostUSD: 0.01, CreatedAt: now.Add(-time.Minute),
+	})
+	save(&models.AIUsage{
+		Purpose: models.AIPurposeStrategyGeneration, Provider: "openai",
+		LatencyMs: 400, Outcome: models.AIOutcomeError, Error: "status 500", CreatedAt: now.Add(-time.Minute),
+	})
+	save(&models.AIUsage{
+		Purpose: models.AIPurposeStrategyGeneration, Provider: "openai",
+		Outcome: models.AIOutcomeCircuitOpen, CreatedAt: now.Add(-time.Minute),
+	})
+	save(&models.AIUsage{
+		Purpose: models.AIPurposePerformanceAnalysis, Provider: "openai",
+		InputTokens: 30, OutputTokens: 10, LatencyMs: 100, Outcome: models.AIOutcomeSuccess, CreatedAt: now.Add(-time.Minute),
+	})
+	// Outside the period
+	save(&models.AIUsage{
+		Purpose: models.AIPurposeStrategyGeneration, Provider: "openai",
+		InputTokens: 1000, OutputTokens: 1000, Outcome: models.AIOutcomeSuccess, CreatedAt: now.Add(-2 * time.Hour),
+	})
+
+	tokens, err := repos.AIUsage.SumTokens(models.AIPurposeStrategyGeneration, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), tokens)
+
+	tokens, err = repos.AIUsage.SumTokens(models.AIPurposeManualGeneration, since)
+	require.NoError(t, err)
+	assert.Zero(t, tokens)
+
+	summaries, err = repos.AIUsage.Summarize(since)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+
+	analysis := summaries[0]
+	assert.Equal(t, models.AIPurposePerformanceAnalysis, analysis.Purpose)
+	assert.Equal(t, 1, analysis.Calls)
+	assert.InDelta(t, 100, analysis.AvgLatencyMs, 1e-9)
+
+	generation := summaries[1]
+	assert.Equal(t, models.AIPurposeStrategyGeneration, generation.Purpose)
+	assert.Equal(t, 3, generation.Calls)
+	assert.Equal(t, 1, generation.Successes)
+	assert.Equal(t, 1, generation.Errors)
+	assert.Equal(t, 1, generation.Blocked)
+	assert.Equal(t, int64(100), generation.InputTokens)
+	assert.Equal(t, int64(50), generation.OutputTokens)
+	assert.InDelta(t, 0.01, generation.CostUSD, 1e-9)
+	assert.InDelta(t, 300, generation.AvgLatencyMs, 1e-9, "blocked calls don't count towards latency")
+}
+
+func testStrategyCandidate(t *testing.T, repos *Repositories) {
+	candidates, err := repos.StrategyCandidate.GetRecent("", 10)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	strategyID := seedStrategy(t, repos, "Admitted", true)
+	now := time.Now()
+	admitted := &models.StrategyCandidate{
+		Name:           "Admitted",
+		Description:    "Buys early momentum",
+		Config:         models.JSONB{"takeProfitPct": 50.0},
+		Purpose:        models.AIPurposeStrategyGeneration,
+		PromptTemplate: "momentum",
+		PromptVersion:  2,
+		Status:         models.AdmissionAdmitted,
+		Backtest: &models.BacktestResult{
+			From: 100, To: 200, MarketTrades: 40, TokensEvaluated: 5, TradeCount: 4, Wins: 3,
+			WinRate: 75, NetPnL: 0.4, ROI: 4, MaxDrawdown: 1.5, Expectancy: 0.1,
+			ExitReasons: map[string]int{"take_profit": 3, "stop_loss": 1},
+		},
+		StrategyID: &strategyID,
+		CreatedAt:  now.Add(-time.Minute),
+	}
+	admittedID, err := repos.StrategyCandidate.Save(admitted)
+	require.NoError(t, err)
+	assert.NotZero(t, admittedID)
+	assert.Equal(t, admittedID, admitted.ID)
+
+	rejectedID, err := repos.StrategyCandidate.Save(&models.StrategyCandidate{
+		Name:    "Rejected",
+		Config:  models.JSONB{"takeProfitPct": 500.0},
+		Purpose: models.AIPurposeManualGeneration,
+		Status:  models.AdmissionRejected,
+		Reasons: []string{"made 1 trade, at least 3 required"},
+	})
+	require.NoError(t, err)
+
+	candidates, err = repos.StrategyCandidate.GetRecent("", 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, rejectedID, candidates[0].ID, "newest first")
+	assert.Nil(t, candidates[0].Backtest)
+	assert.Nil(t, candidates[0].StrategyID)
+	assert.Empty(t, candidates[0].Description)
+
+	got := candidates[1]
+	assert.Equal(t, admittedID, got.ID)
+	assert.Equal(t, "Admitted", got.Name)
+	assert.Equal(t, admitted.Description, got.Description)
+	assert.Equal(t, 50.0, got.Config["takeProfitPct"])
+	assert.Equal(t, models.AIPurposeStrategyGeneration, got.Purpose)
+	assert.Equal(t, "momentum", got.PromptTemplate)
+	assert.Equal(t, 2, got.PromptVersion)
+	assert.Equal(t, models.AdmissionAdmitted, got.Status)
+	assert.Empty(t, got.Reasons)
+	assert.Equal(t, admitted.Backtest, got.Backtest)
+	require.NotNil(t, got.StrategyID)
+	assert.Equal(t, strategyID, *got.StrategyID)
+	assert.WithinDuration(t, admitted.CreatedAt, got.CreatedAt, timeTolerance)
+
+	candidates, err = repos.StrategyCandidate.GetRecent(models.AdmissionRejected, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, rejectedID, candidates[0].ID)
+	assert.Equal(t, []string{"made 1 trade, at least 3 required"}, candidates[0].Reasons)
+
+	candidates, err = repos.StrategyCandidate.GetRecent("", 1)
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+}
+
+func testStrategyDraft(t *testing.T, repos *Repositories) {
+	missing, err := repos.StrategyDraft.GetByID(1)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	draft := &models.StrategyDraft{
+		Name:        "Unique Buyer Flip",
+		Description: "Buys tokens with broad early demand",
+		Config: models.JSONB{
+			"minUniqueBuyers": 5.0,
+			"rules":           []interface{}{map[string]interface{}{"condition": "unique buyers >= 5", "action": "buy"}},
+		},
+		Explanations: []models.DraftExplanation{
+			{Parameter: "minUniqueBuyers", Source: "5+ unique buyers", Explanation: "Five distinct organic wallets"},
+		},
+		Instructions:   []string{"buy tokens with 5+ unique buyers"},
+		Revision:       1,
+		Status:         models.DraftOpen,
+		PromptTemplate: "authoring",
+		PromptVersion:  1,
+	}
+	id, err := repos.StrategyDraft.Save(draft)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+	assert.Equal(t, id, draft.ID)
+
+	got, err := repos.StrategyDraft.GetByID(id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "Unique Buyer Flip", got.Name)
+	assert.Equal(t, draft.Description, got.Description)
+	assert.Equal(t, 5.0, got.Config["minUniqueBuyers"])
+	assert.Len(t, got.Config["rules"], 1)
+	assert.Equal(t, draft.Explanations, got.Explanations)
+	assert.NotNil(t, got.Ambiguities, "unset lists are stored empty")
+	assert.Empty(t, got.Ambiguities)
+	assert.Equal(t, draft.Instructions, got.Instructions)
+	assert.Equal(t, 1, got.Revision)
+	assert.Equal(t, models.DraftOpen, got.Status)
+	assert.Nil(t, got.StrategyID)
+	assert.Equal(t, "authoring", got.PromptTemplate)
+	assert.Equal(t, 1, got.PromptVersion)
+	assert.WithinDuration(t, draft.CreatedAt, got.CreatedAt, timeTolerance)
+
+	strategyID := seedStrategy(t, repos, "Unique Buyer Flip", true)
+	got.Revision = 2
+	got.Instructions = append(got.Instructions, "exit at 2x")
+	got.Ambiguities = []models.DraftAmbiguity{{Parameter: "stopLossPct", Issue: "No stop loss given", Assumption: "20%"}}
+	got.Status = models.DraftSaved
+	got.StrategyID = &strategyID
+	require.NoError(t, repos.StrategyDraft.Update(got))
+
+	updated, err := repos.StrategyDraft.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Revision)
+	assert.Equal(t, []string{"buy tokens with 5+ unique buyers", "exit at 2x"}, updated.Instructions)
+	assert.Equal(t, got.Ambiguities, updated.Ambiguities)
+	assert.Equal(t, models.DraftSaved, updated.Status)
+	require.NotNil(t, updated.StrategyID)
+	assert.Equal(t, strategyID, *updated.StrategyID)
+	assert.WithinDuration(t, draft.CreatedAt, updated.CreatedAt, timeTolerance)
+
+	assert.Error(t, repos.StrategyDraft.Update(&models.StrategyDraft{ID: id + 100, Name: "Missing", Status: models.DraftOpen}))
+}
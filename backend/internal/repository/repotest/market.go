@@ -0,0 +1,784 @@
+// internal/repository/repotest/market.go
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testToken(t *testing.T, repos *Repositories) {
+	nowMs := time.Now().UnixMilli()
+
+	id, err := repos.Token.Save(&models.Token{
+		MintAddress:      "mint-a",
+		CreatorAddress:   "creator-a",
+		Name:             "Token A",
+		Symbol:           "TKA",
+		CreatedTimestamp: nowMs - 60_000,
+		UsdMarketCap:     5000,
+	})
+	require.NoError(t, err)
+	oldID := seedToken(t, repos, "mint-old", "creator-b", nowMs-2*3600_000)
+
+	token, err := repos.Token.GetByMintAddress("mint-a")
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, id, token.ID)
+	assert.Equal(t, "Token A", token.Name)
+	assert.Equal(t, "creator-a", token.CreatorAddress)
+
+	missing, err := repos.Token.GetByMintAddress("mint-missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Saving the same mint updates the token, keeping completion and the king of the hill
+	// time once set
+	updatedID, err := repos.Token.Save(&models.Token{
+		MintAddress:            "mint-a",
+		CreatorAddress:         "creator-a",
+		Name:                   "Token A",
+		Symbol:                 "TKA",
+		CreatedTimestamp:       nowMs - 60_000,
+		UsdMarketCap:           9000,
+		Completed:              true,
+		KingOfTheHillTimeStamp: nowMs - 30_000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, id, updatedID)
+
+	_, err = repos.Token.Save(&models.Token{
+		MintAddress:            "mint-a",
+		CreatorAddress:         "creator-a",
+		Name:                   "Token A",
+		Symbol:                 "TKA",
+		CreatedTimestamp:       nowMs - 60_000,
+		UsdMarketCap:           7000,
+		KingOfTheHillTimeStamp: nowMs,
+	})
+	require.NoError(t, err)
+
+	token, err = repos.Token.GetByID(id)
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.InDelta(t, 7000, token.UsdMarketCap, 1e-6)
+	assert.True(t, token.Completed)
+	assert.Equal(t, nowMs-30_000, token.KingOfTheHillTimeStamp)
+
+	missing, err = repos.Token.GetByID(oldID + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	recent, err := repos.Token.GetRecentTokens(10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id, oldID}, tokenIDs(recent))
+
+	filtered, err := repos.Token.GetFilteredTokens(6000, 3600, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id}, tokenIDs(filtered))
+}
+
+func testTrade(t *testing.T, repos *Repositories) {
+	tokenID := seedToken(t, repos, "mint-trade", "creator", time.Now().UnixMilli())
+	base := time.Now().Unix() - 100
+
+	firstID := seedTrade(t, repos, tokenID, "sig-1", "wallet-a", true, 1, 1000, base)
+	secondID := seedTrade(t, repos, tokenID, "sig-2", "wallet-b", true, 0.5, 400, base+10)
+	thirdID := seedTrade(t, repos, tokenID, "sig-3", "wallet-a", false, 0.25, 300, base+20)
+
+	// A replayed signature is ignored
+	duplicateID, err := repos.Trade.Save(&models.Trade{
+		TokenID: tokenID, Signature: "sig-1", SolAmount: 9, TokenAmount: 9, IsBuy: true, UserAddress: "wallet-c", Timestamp: base,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, duplicateID)
+
+	trade, err := repos.Trade.GetTradesBySignature("sig-2")
+	require.NoError(t, err)
+	require.NotNil(t, trade)
+	assert.Equal(t, secondID, trade.ID)
+	assert.Equal(t, "wallet-b", trade.UserAddress)
+	assert.InDelta(t, 0.5, trade.SolAmount, 1e-9)
+	assert.InDelta(t, 400, trade.TokenAmount, 1e-9)
+
+	missing, err := repos.Trade.GetTradesBySignature("sig-missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	latest, err := repos.Trade.GetTradesByTokenID(tokenID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{thirdID, secondID}, tradeIDs(latest))
+
+	after, err := repos.Trade.GetTradesAfterID(firstID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{secondID, thirdID}, tradeIDs(after))
+	assert.Equal(t, "mint-trade", after[0].MintAddress)
+
+	inRange, err := repos.Trade.GetTradesByTimeRange(base, base+20, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{firstID, secondID}, tradeIDs(inRange))
+
+	lastID, err := repos.Trade.GetLastTradeIDBefore(base + 15)
+	require.NoError(t, err)
+	assert.Equal(t, secondID, lastID)
+
+	lastID, err = repos.Trade.GetLastTradeIDBefore(base)
+	require.NoError(t, err)
+	assert.Zero(t, lastID)
+
+	balances, balanceLastID, err := repos.Trade.GetHolderBalances(tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, thirdID, balanceLastID)
+	assert.ElementsMatch(t, []models.HolderBalance{
+		{WalletAddress: "wallet-a", Balance: 700},
+		{WalletAddress: "wallet-b", Balance: 400},
+	}, derefBalances(balances))
+}
+
+func testCandle(t *testing.T, repos *Repositories) {
+	tokenID := seedToken(t, repos, "mint-candle", "creator", time.Now().UnixMilli())
+
+	require.NoError(t, repos.Candle.UpsertBatch([]*models.Candle{
+		{TokenID: tokenID, IntervalSec: 60, BucketStart: 120, Open: 1, High: 2, Low: 1, Close: 2, VolumeSol: 3, TradeCount: 2, BuyCount: 2},
+		{TokenID: tokenID, IntervalSec: 60, BucketStart: 60, Open: 1, High: 1, Low: 1, Close: 1, VolumeSol: 1, TradeCount: 1, BuyCount: 1},
+		{TokenID: tokenID, IntervalSec: 300, BucketStart: 0, Open: 1, High: 2, Low: 1, Close: 2, VolumeSol: 4, TradeCount: 3},
+	}))
+
+	// Upserting a bucket again replaces its values
+	require.NoError(t, repos.Candle.Upsert(&models.Candle{
+		TokenID: tokenID, IntervalSec: 60, BucketStart: 120, Open: 1, High: 3, Low: 0.5, Close: 2.5, VolumeSol: 5, TradeCount: 4, BuyCount: 3, SellCount: 1,
+	}))
+
+	candles, err := repos.Candle.GetByToken(tokenID, 60, 0, 600, 10)
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, int64(60), candles[0].BucketStart)
+	assert.Equal(t, int64(120), candles[1].BucketStart)
+	assert.Equal(t, "mint-candle", candles[1].MintAddress)
+	assert.InDelta(t, 3, candles[1].High, 1e-9)
+	assert.InDelta(t, 2.5, candles[1].Close, 1e-9)
+	assert.Equal(t, 4, candles[1].TradeCount)
+	assert.Equal(t, 1, candles[1].SellCount)
+
+	candles, err = repos.Candle.GetByToken(tokenID, 60, 100, 600, 10)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, int64(120), candles[0].BucketStart)
+
+	candles, err = repos.Candle.GetByToken(tokenID, 60, 0, 600, 1)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, int64(60), candles[0].BucketStart)
+}
+
+func testFeedMetric(t *testing.T, repos *Repositories) {
+	latest, err := repos.FeedMetric.GetLatest("pumpfun")
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	base := time.Now().Add(-10 * time.Minute)
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		end := base.Add(time.Duration(i+1) * time.Minute)
+		id, err := repos.FeedMetric.Save(&models.FeedMetric{
+			Source:        "pumpfun",
+			PeriodStart:   end.Add(-time.Minute),
+			PeriodEnd:     end,
+			MessageCount:  int64(10 * (i + 1)),
+			TradeCount:    int64(i + 1),
+			Connected:     i != 1,
+			LastMessageAt: end,
+		})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	_, err = repos.FeedMetric.Save(&models.FeedMetric{
+		Source: "other", PeriodStart: base, PeriodEnd: base.Add(5 * time.Minute), LastMessageAt: base,
+	})
+	require.NoError(t, err)
+
+	latest, err = repos.FeedMetric.GetLatest("pumpfun")
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, ids[2], latest.ID)
+	assert.Equal(t, int64(30), latest.MessageCount)
+	assert.True(t, latest.Connected)
+	assert.WithinDuration(t, base.Add(3*time.Minute), latest.PeriodEnd, timeTolerance)
+
+	since, err := repos.FeedMetric.GetSince("pumpfun", base.Add(90*time.Second))
+	require.NoError(t, err)
+	require.Len(t, since, 2)
+	assert.Equal(t, ids[1], since[0].ID)
+	assert.False(t, since[0].Connected)
+	assert.Equal(t, ids[2], since[1].ID)
+}
+
+func testDataGap(t *testing.T, repos *Repositories) {
+	open, err := repos.DataGap.GetOpen("pumpfun")
+	require.NoError(t, err)
+	assert.Nil(t, open)
+
+	base := time.Now().Add(-time.Hour)
+	closedID, err := repos.DataGap.Save(&models.DataGap{Source: "pumpfun", StartedAt: base, Reason: "disconnected"})
+	require.NoError(t, err)
+	openID, err := repos.DataGap.Save(&models.DataGap{Source: "pumpfun", StartedAt: base.Add(30 * time.Minute), Reason: "stalled"})
+	require.NoError(t, err)
+
+	require.NoError(t, repos.DataGap.Close(closedID, base.Add(2*time.Minute)))
+	// Closing it again leaves the first end time in place
+	require.NoError(t, repos.DataGap.Close(closedID, base.Add(10*time.Minute)))
+	require.NoError(t, repos.DataGap.Close(openID+1000, base))
+
+	open, err = repos.DataGap.GetOpen("pumpfun")
+	require.NoError(t, err)
+	require.NotNil(t, open)
+	assert.Equal(t, openID, open.ID)
+	assert.Equal(t, "stalled", open.Reason)
+	assert.Nil(t, open.EndedAt)
+
+	recent, err := repos.DataGap.GetRecent(10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{openID, closedID}, gapIDs(recent))
+	closed := recent[1]
+	require.NotNil(t, closed.EndedAt)
+	assert.WithinDuration(t, base.Add(2*time.Minute), *closed.EndedAt, timeTolerance)
+	assert.InDelta(t, 120, closed.DurationSec, 0.01)
+
+	overlapping, err := repos.DataGap.GetOverlapping(base.Add(time.Minute), base.Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{closedID}, gapIDs(overlapping))
+
+	overlapping, err = repos.DataGap.GetOverlapping(base.Add(10*time.Minute), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{openID}, gapIDs(overlapping))
+}
+
+func testCreatorProfile(t *testing.T, repos *Repositories) {
+	nowMs := time.Now().UnixMilli()
+	nowSec := nowMs / 1000
+
+	firstID := seedToken(t, repos, "mint-first", "creator-a", nowMs-120_000)
+	secondID := seedToken(t, repos, "mint-second", "creator-a", nowMs-60_000)
+	seedToken(t, repos, "mint-other", "creator-b", nowMs-3600_000)
+
+	seedTrade(t, repos, firstID, "sig-1", "buyer", true, 1, 1000, nowSec-110)
+	seedTrade(t, repos, firstID, "sig-2", "buyer", true, 3, 1000, nowSec-100)
+	seedTrade(t, repos, firstID, "sig-3", "creator-a", false, 2, 1000, nowSec-90)
+	seedTrade(t, repos, firstID, "sig-4", "creator-a", false, 1, 1000, nowSec-80)
+
+	launches, err := repos.CreatorProfile.GetLaunches("creator-a")
+	require.NoError(t, err)
+	require.Len(t, launches, 2)
+	assert.Equal(t, firstID, launches[0].TokenID)
+	assert.Equal(t, "mint-first", launches[0].MintAddress)
+	assert.InDelta(t, 0.003, launches[0].MaxPrice, 1e-12)
+	assert.InDelta(t, 0.001, launches[0].LastPrice, 1e-12)
+	assert.Equal(t, nowSec-90, launches[0].FirstCreatorSellAt)
+	assert.Equal(t, secondID, launches[1].TokenID)
+	assert.Zero(t, launches[1].MaxPrice)
+	assert.Zero(t, launches[1].FirstCreatorSellAt)
+
+	addresses, err := repos.CreatorProfile.GetCreatorAddresses(nowMs-600_000, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"creator-a"}, addresses)
+
+	addresses, err = repos.CreatorProfile.GetCreatorAddresses(0, "creator-a", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"creator-b"}, addresses)
+
+	profile, err := repos.CreatorProfile.GetByAddress("creator-a")
+	require.NoError(t, err)
+	assert.Nil(t, profile)
+
+	require.NoError(t, repos.CreatorProfile.Upsert(&models.CreatorProfile{
+		CreatorAddress: "creator-a", TokensLaunched: 2, ReputationScore: 40, FirstLaunchAt: nowMs - 120_000, LastLaunchAt: nowMs - 60_000,
+	}))
+	require.NoError(t, repos.CreatorProfile.Upsert(&models.CreatorProfile{
+		CreatorAddress: "creator-b", TokensLaunched: 1, ReputationScore: 90,
+	}))
+	require.NoError(t, repos.CreatorProfile.Upsert(&models.CreatorProfile{
+		CreatorAddress: "creator-c", TokensLaunched: 3, ReputationScore: 70.5, CreatorSellCount: 1,
+	}))
+
+	// Upserting again replaces the profile
+	require.NoError(t, repos.CreatorProfile.Upsert(&models.CreatorProfile{
+		CreatorAddress: "creator-a", TokensLaunched: 2, TokensGraduated: 1, GraduationRate: 0.5, ReputationScore: 55,
+		FirstLaunchAt: nowMs - 120_000, LastLaunchAt: nowMs - 60_000,
+	}))
+
+	profile, err = repos.CreatorProfile.GetByAddress("creator-a")
+	require.NoError(t, err)
+	require.NotNil(t, profile)
+	assert.Equal(t, 1, profile.TokensGraduated)
+	assert.InDelta(t, 0.5, profile.GraduationRate, 1e-9)
+	assert.InDelta(t, 55, profile.ReputationScore, 1e-9)
+	assert.Equal(t, nowMs-60_000, profile.LastLaunchAt)
+	assert.False(t, profile.UpdatedAt.IsZero())
+
+	top, err := repos.CreatorProfile.GetTop(2, false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"creator-c", "creator-a"}, creatorAddresses(top))
+
+	worst, err := repos.CreatorProfile.GetTop(1, true, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"creator-a"}, creatorAddresses(worst))
+}
+
+func testWalletStats(t *testing.T, repos *Repositories) {
+	nowSec := time.Now().Unix()
+	firstID := seedToken(t, repos, "mint-first", "wallet-a", time.Now().UnixMilli())
+	secondID := seedToken(t, repos, "mint-second", "creator", time.Now().UnixMilli())
+
+	seedTrade(t, repos, firstID, "sig-1", "wallet-a", true, 1, 1000, nowSec-300)
+	seedTrade(t, repos, firstID, "sig-2", "wallet-a", false, 1.5, 600, nowSec-200)
+	seedTrade(t, repos, secondID, "sig-3", "wallet-a", true, 0.5, 200, nowSec-100)
+	seedTrade(t, repos, secondID, "sig-4", "wallet-b", true, 2, 800, nowSec-50)
+	seedTrade(t, repos, secondID, "sig-5", "wallet-c", true, 2, 800, nowSec-5000)
+
+	active, err := repos.WalletStats.GetActiveWallets(nowSec-1000, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallet-a", "wallet-b"}, active)
+
+	active, err = repos.WalletStats.GetActiveWallets(0, "wallet-a", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallet-b"}, active)
+
+	positions, err := repos.WalletStats.GetPositions([]string{"wallet-a", "wallet-b"})
+	require.NoError(t, err)
+	require.Len(t, positions, 3)
+	assert.Equal(t, "wallet-a", positions[0].WalletAddress)
+	assert.Equal(t, firstID, positions[0].TokenID)
+	assert.InDelta(t, 1, positions[0].BuySol, 1e-9)
+	assert.InDelta(t, 1.5, positions[0].SellSol, 1e-9)
+	assert.InDelta(t, 600, positions[0].SellTokens, 1e-9)
+	assert.Equal(t, 1, positions[0].BuyCount)
+	assert.Equal(t, 1, positions[0].SellCount)
+	assert.Equal(t, nowSec-200, positions[0].LastTradeAt)
+	assert.True(t, positions[0].IsCreator)
+	assert.Equal(t, "wallet-a", positions[1].WalletAddress)
+	assert.Equal(t, secondID, positions[1].TokenID)
+	assert.False(t, positions[1].IsCreator)
+	assert.Equal(t, "wallet-b", positions[2].WalletAddress)
+
+	missing, err := repos.WalletStats.GetByAddress("wallet-a")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, repos.WalletStats.UpsertBatch([]*models.WalletStats{
+		{WalletAddress: "wallet-a", TradeCount: 3, SmartScore: 80, RealizedPnlSol: 0.5, LastTradeAt: nowSec - 100},
+		{WalletAddress: "wallet-b", TradeCount: 1, SmartScore: 80, RealizedPnlSol: 1},
+		{WalletAddress: "wallet-c", TradeCount: 1, SmartScore: 40},
+		{WalletAddress: "wallet-d", TradeCount: 1},
+	}))
+	require.NoError(t, repos.WalletStats.UpdateRanks(2))
+
+	smart, err := repos.WalletStats.GetSmartWallets(10)
+	require.NoError(t, err)
+	require.Len(t, smart, 2)
+	assert.Equal(t, "wallet-b", smart[0].WalletAddress)
+	assert.Equal(t, 1, smart[0].Rank)
+	assert.Equal(t, "wallet-a", smart[1].WalletAddress)
+	assert.Equal(t, 2, smart[1].Rank)
+
+	stats, err := repos.WalletStats.GetByAddress("wallet-c")
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Equal(t, 3, stats.Rank)
+	assert.False(t, stats.IsSmart)
+
+	stats, err = repos.WalletStats.GetByAddress("wallet-d")
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Zero(t, stats.Rank)
+
+	// Upserting keeps the rank until the next ranking
+	require.NoError(t, repos.WalletStats.UpsertBatch([]*models.WalletStats{
+		{WalletAddress: "wallet-a", TradeCount: 4, SmartScore: 10, LastTradeAt: nowSec},
+	}))
+	stats, err = repos.WalletStats.GetByAddress("wallet-a")
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.TradeCount)
+	assert.Equal(t, 2, stats.Rank)
+	assert.True(t, stats.IsSmart)
+	assert.Equal(t, nowSec, stats.LastTradeAt)
+}
+
+func testLaunchAnalysis(t *testing.T, repos *Repositories) {
+	nowMs := time.Now().UnixMilli()
+	launchSec := nowMs/1000 - 600
+
+	firstID := seedToken(t, repos, "mint-first", "creator", nowMs-600_000)
+	secondID := seedToken(t, repos, "mint-second", "creator", nowMs-300_000)
+
+	seedTrade(t, repos, firstID, "sig-3", "wallet-b", true, 0.5, 500, launchSec+30)
+	seedTrade(t, repos, firstID, "sig-1", "wallet-a", true, 1, 1000, launchSec)
+	seedTrade(t, repos, firstID, "sig-2", "wallet-b", true, 1, 900, launchSec+2)
+	seedTrade(t, repos, secondID, "sig-4", "wallet-a", true, 1, 1000, launchSec+300)
+
+	trades, err := repos.LaunchAnalysis.GetLaunchTrades(firstID, launchSec+10, 10)
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	assert.Equal(t, "sig-1", trades[0].Signature)
+	assert.Equal(t, "sig-2", trades[1].Signature)
+
+	firstTrades, err := repos.LaunchAnalysis.GetFirstTradeTimes([]string{"wallet-a", "wallet-b", "wallet-z"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"wallet-a": launchSec, "wallet-b": launchSec + 2}, firstTrades)
+
+	unanalyzed, err := repos.LaunchAnalysis.GetUnanalyzedTokens(0, nowMs, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{firstID, secondID}, tokenIDs(unanalyzed))
+	assert.Equal(t, "creator", unanalyzed[0].CreatorAddress)
+
+	analysis, err := repos.LaunchAnalysis.GetByTokenID(firstID)
+	require.NoError(t, err)
+	assert.Nil(t, analysis)
+
+	require.NoError(t, repos.LaunchAnalysis.Upsert(&models.LaunchAnalysis{
+		TokenID:          firstID,
+		WindowSec:        30,
+		EarlyBuyCount:    2,
+		EarlyBuyerCount:  2,
+		SniperCount:      2,
+		BundledSupplyPct: 1.9,
+		Buyers: []*models.LaunchBuyer{
+			{WalletAddress: "wallet-a", FirstBuyAt: launchSec, BuyCount: 1, SolAmount: 1, TokenAmount: 1000, Labels: []string{models.LaunchLabelSniper}},
+			{WalletAddress: "wallet-b", FirstBuyAt: launchSec + 2, BuyCount: 1, SolAmount: 1, TokenAmount: 900, Labels: []string{models.LaunchLabelSniper, models.LaunchLabelFresh}},
+		},
+	}))
+
+	analysis, err = repos.LaunchAnalysis.GetByTokenID(firstID)
+	require.NoError(t, err)
+	require.NotNil(t, analysis)
+	assert.Equal(t, "mint-first", analysis.MintAddress)
+	assert.Equal(t, "creator", analysis.CreatorAddress)
+	assert.Equal(t, 30, analysis.WindowSec)
+	assert.Equal(t, 2, analysis.SniperCount)
+	assert.InDelta(t, 1.9, analysis.BundledSupplyPct, 1e-9)
+	assert.True(t, analysis.Final)
+	require.Len(t, analysis.Buyers, 2)
+	assert.Equal(t, []string{models.LaunchLabelSniper, models.LaunchLabelFresh}, analysis.Buyers[1].Labels)
+
+	unanalyzed, err = repos.LaunchAnalysis.GetUnanalyzedTokens(0, nowMs, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{secondID}, tokenIDs(unanalyzed))
+}
+
+func testTokenAnomaly(t *testing.T, repos *Repositories) {
+	tokenID := seedToken(t, repos, "mint-anomaly", "creator", time.Now().UnixMilli())
+
+	anomalies := &models.TokenAnomalies{
+		TokenID:            tokenID,
+		TradeCount:         10,
+		BuyCount:           8,
+		SelfTradeCount:     2,
+		FlaggedWalletCount: 1,
+		FlaggedBuyPct:      25,
+		AnomalyScore:       40,
+		FlaggedWallets:     map[string][]string{"wallet-a": {models.AnomalySelfTrade}},
+	}
+	require.NoError(t, repos.TokenAnomaly.UpsertBatch([]*models.TokenAnomalies{anomalies}))
+
+	// Upserting the same token again replaces its summary
+	anomalies.AnomalyScore = 60
+	require.NoError(t, repos.TokenAnomaly.UpsertBatch([]*models.TokenAnomalies{anomalies}))
+	require.NoError(t, repos.TokenAnomaly.UpsertBatch(nil))
+}
+
+func testTokenTransition(t *testing.T, repos *Repositories) {
+	lastID, err := repos.TokenTransition.GetLastID()
+	require.NoError(t, err)
+	assert.Zero(t, lastID)
+
+	nowSec := time.Now().Unix()
+	staleID := seedToken(t, repos, "mint-stale", "creator", time.Now().UnixMilli())
+	tradedID := seedToken(t, repos, "mint-traded", "creator", time.Now().UnixMilli())
+	graduatedID := seedToken(t, repos, "mint-graduated", "creator", time.Now().UnixMilli())
+
+	save := func(tokenID int64, from, to string, ts int64) int64 {
+		t.Helper()
+		id, err := repos.TokenTransition.Save(&models.TokenTransition{
+			TokenID: tokenID, FromState: from, ToState: to, Timestamp: ts, Source: "newTrade",
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	createdID := save(staleID, "", models.TokenStateCreated, nowSec-900)
+	tradingID := save(staleID, models.TokenStateCreated, models.TokenStateTrading, nowSec-800)
+	save(tradedID, "", models.TokenStateCreated, nowSec-900)
+	save(graduatedID, "", models.TokenStateCreated, nowSec-900)
+	graduatedTransitionID := save(graduatedID, models.TokenStateCreated, models.TokenStateGraduated, nowSec-700)
+	seedTrade(t, repos, tradedID, "sig-1", "wallet", true, 1, 1000, nowSec-60)
+
+	transitions, err := repos.TokenTransition.GetByTokenID(staleID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{createdID, tradingID}, transitionIDs(transitions))
+	assert.Equal(t, "mint-stale", transitions[1].MintAddress)
+	assert.Equal(t, models.TokenStateCreated, transitions[1].FromState)
+	assert.Equal(t, models.TokenStateTrading, transitions[1].ToState)
+	assert.Equal(t, nowSec-800, transitions[1].Timestamp)
+	assert.Equal(t, "newTrade", transitions[1].Source)
+
+	after, err := repos.TokenTransition.GetAfterID(tradingID, 2)
+	require.NoError(t, err)
+	assert.Len(t, after, 2)
+	assert.Greater(t, after[0].ID, tradingID)
+
+	lastID, err = repos.TokenTransition.GetLastID()
+	require.NoError(t, err)
+	assert.Equal(t, graduatedTransitionID, lastID)
+
+	stale, err := repos.TokenTransition.GetStale(nowSec-300, 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{tradingID}, transitionIDs(stale))
+	assert.Equal(t, "mint-stale", stale[0].MintAddress)
+
+	stale, err = repos.TokenTransition.GetStale(nowSec-850, 10)
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
+
+func testTokenFeature(t *testing.T, repos *Repositories) {
+	strategyID := seedStrategy(t, repos, "Features", true)
+	runID := seedRun(t, repos, "running")
+	tokenID := seedToken(t, repos, "mint-feature", "creator", time.Now().UnixMilli())
+
+	features := &models.TokenFeatures{
+		AsOf:                  time.Now().Unix(),
+		LastPrice:             0.000002,
+		SecondsSinceLastTrade: 3,
+		Windows: []*models.TokenWindowFeatures{
+			{WindowSec: 10, BuyCount: 4, UniqueBuyers: 3, BuyVolumeSol: 1.5, NetFlowSol: 1.5},
+			{WindowSec: 60, BuyCount: 9, SellCount: 2, VolumeSol: 4},
+		},
+	}
+
+	entryID, err := repos.TokenFeature.SaveSnapshot(&models.TokenFeatureSnapshot{
+		TokenID:         tokenID,
+		StrategyID:      ptr(strategyID),
+		SimulationRunID: ptr(runID),
+		Decision:        models.FeatureDecisionEntry,
+		Features:        features,
+	})
+	require.NoError(t, err)
+	exitID, err := repos.TokenFeature.SaveSnapshot(&models.TokenFeatureSnapshot{
+		TokenID:         tokenID,
+		StrategyID:      ptr(strategyID),
+		SimulationRunID: ptr(runID),
+		Decision:        models.FeatureDecisionExit,
+		Features:        &models.TokenFeatures{AsOf: features.AsOf + 30, SecondsSinceLastTrade: -1},
+	})
+	require.NoError(t, err)
+	_, err = repos.TokenFeature.SaveSnapshot(&models.TokenFeatureSnapshot{
+		TokenID:  tokenID,
+		Decision: models.FeatureDecisionEntry,
+		Features: features,
+	})
+	require.NoError(t, err)
+
+	// Snapshots must not alias the caller's features
+	features.Windows[0].BuyCount = 100
+
+	snapshots, err := repos.TokenFeature.GetSnapshotsBySimulationRun(runID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, entryID, snapshots[0].ID)
+	assert.Equal(t, exitID, snapshots[1].ID)
+	assert.Equal(t, models.FeatureDecisionEntry, snapshots[0].Decision)
+	require.NotNil(t, snapshots[0].StrategyID)
+	assert.Equal(t, strategyID, *snapshots[0].StrategyID)
+	assert.Nil(t, snapshots[0].SimulatedTradeID)
+	require.NotNil(t, snapshots[0].Features)
+	require.Len(t, snapshots[0].Features.Windows, 2)
+	assert.Equal(t, 4, snapshots[0].Features.Window(10).BuyCount)
+	assert.InDelta(t, 1.5, snapshots[0].Features.Window(10).BuyVolumeSol, 1e-9)
+	assert.Equal(t, int64(-1), snapshots[1].Features.SecondsSinceLastTrade)
+
+	empty, err := repos.TokenFeature.GetSnapshotsBySimulationRun(runID + 1000)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func testTokenOutcome(t *testing.T, repos *Repositories) {
+	nowMs := time.Now().UnixMilli()
+	nowSec := nowMs / 1000
+
+	labeledID := seedToken(t, repos, "mint-labeled", "creator", nowMs-3600_000)
+	unlabeledID := seedToken(t, repos, "mint-unlabeled", "creator", nowMs-1800_000)
+
+	seedTrade(t, repos, labeledID, "sig-1", "wallet-a", true, 1, 1000, nowSec-3500)
+	seedTrade(t, repos, labeledID, "sig-2", "wallet-b", true, 2, 1000, nowSec-3400)
+	seedTrade(t, repos, labeledID, "sig-3", "wallet-a", false, 1, 500, nowSec-1000)
+
+	trades, err := repos.TokenOutcome.GetOutcomeTrades(labeledID, nowSec-3500, nowSec-3400, 10)
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	assert.Equal(t, "sig-1", trades[0].Signature)
+	assert.Equal(t, "sig-2", trades[1].Signature)
+
+	graduatedAt, err := repos.TokenOutcome.GetGraduatedAt(labeledID)
+	require.NoError(t, err)
+	assert.Zero(t, graduatedAt)
+
+	for _, ts := range []int64{nowSec - 3000, nowSec - 2000} {
+		_, err := repos.TokenTransition.Save(&models.TokenTransition{
+			TokenID: labeledID, FromState: models.TokenStateKingOfTheHill, ToState: models.TokenStateGraduated, Timestamp: ts, Source: "newTrade",
+		})
+		require.NoError(t, err)
+	}
+	graduatedAt, err = repos.TokenOutcome.GetGraduatedAt(labeledID)
+	require.NoError(t, err)
+	assert.Equal(t, nowSec-3000, graduatedAt)
+
+	unlabeled, err := repos.TokenOutcome.GetUnlabeledTokens(0, nowMs, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{labeledID, unlabeledID}, tokenIDs(unlabeled))
+
+	require.NoError(t, repos.TokenOutcome.Upsert(&models.TokenOutcome{
+		TokenID:           labeledID,
+		LaunchTimestamp:   nowSec - 3600,
+		BasePrice:         0.001,
+		TradeCount:        3,
+		MaxReturn5mPct:    100,
+		Graduated:         true,
+		TimeToGraduateSec: ptr(int64(600)),
+	}))
+
+	unlabeled, err = repos.TokenOutcome.GetUnlabeledTokens(0, nowMs, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{unlabeledID}, tokenIDs(unlabeled))
+
+	from := time.Now().Add(-time.Hour)
+	labeledSnapshotID, err := repos.TokenFeature.SaveSnapshot(&models.TokenFeatureSnapshot{
+		TokenID: labeledID, Decision: models.FeatureDecisionEntry, Features: &models.TokenFeatures{LastPrice: 0.001},
+	})
+	require.NoError(t, err)
+	_, err = repos.TokenFeature.SaveSnapshot(&models.TokenFeatureSnapshot{
+		TokenID: unlabeledID, Decision: models.FeatureDecisionEntry, Features: &models.TokenFeatures{},
+	})
+	require.NoError(t, err)
+
+	labeled, err := repos.TokenOutcome.GetLabeledSnapshots(from, time.Now().Add(time.Minute), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, labeled, 1)
+	assert.Equal(t, labeledSnapshotID, labeled[0].Snapshot.ID)
+	assert.InDelta(t, 0.001, labeled[0].Snapshot.Features.LastPrice, 1e-12)
+	assert.Equal(t, "mint-labeled", labeled[0].Outcome.MintAddress)
+	assert.InDelta(t, 100, labeled[0].Outcome.MaxReturn5mPct, 1e-9)
+	assert.True(t, labeled[0].Outcome.Graduated)
+	require.NotNil(t, labeled[0].Outcome.TimeToGraduateSec)
+	assert.Equal(t, int64(600), *labeled[0].Outcome.TimeToGraduateSec)
+
+	labeled, err = repos.TokenOutcome.GetLabeledSnapshots(from, time.Now().Add(time.Minute), labeledSnapshotID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, labeled)
+}
+
+func testEntryModel(t *testing.T, repos *Repositories) {
+	latest, err := repos.EntryModel.GetLatest("entry")
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	newModel := func(bias float64) *models.EntryModel {
+		return &models.EntryModel{
+			Name:        "entry",
+			ModelType:   models.EntryModelLogistic,
+			Target:      "max_return_5m_pct>=100",
+			Features:    []string{"buy_count_10s", "net_flow_sol_60s"},
+			Weights:     []float64{0.5, -0.25},
+			Bias:        bias,
+			Means:       []float64{3, 1},
+			Scales:      []float64{2, 0.5},
+			Metrics:     map[string]float64{"auc": 0.75},
+			TrainedRows: 200,
+		}
+	}
+
+	first := newModel(0.1)
+	require.NoError(t, repos.EntryModel.Save(first))
+	assert.NotZero(t, first.ID)
+	assert.Equal(t, 1, first.Version)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second := newModel(0.2)
+	require.NoError(t, repos.EntryModel.Save(second))
+	assert.Equal(t, 2, second.Version)
+
+	other := newModel(0.3)
+	other.Name = "other"
+	require.NoError(t, repos.EntryModel.Save(other))
+	assert.Equal(t, 1, other.Version)
+
+	latest, err = repos.EntryModel.GetLatest("entry")
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, second.ID, latest.ID)
+	assert.Equal(t, 2, latest.Version)
+	assert.InDelta(t, 0.2, latest.Bias, 1e-12)
+	assert.Equal(t, []string{"buy_count_10s", "net_flow_sol_60s"}, latest.Features)
+	assert.Equal(t, []float64{0.5, -0.25}, latest.Weights)
+	assert.Equal(t, map[string]float64{"auc": 0.75}, latest.Metrics)
+	assert.Equal(t, 200, latest.TrainedRows)
+
+	byVersion, err := repos.EntryModel.GetByVersion("entry", 1)
+	require.NoError(t, err)
+	require.NotNil(t, byVersion)
+	assert.Equal(t, first.ID, byVersion.ID)
+	assert.InDelta(t, 0.1, byVersion.Bias, 1e-12)
+
+	missing, err := repos.EntryModel.GetByVersion("entry", 3)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func tokenIDs(tokens []*models.Token) []int64 {
+	ids := make([]int64, 0, len(tokens))
+	for _, token := range tokens {
+		ids = append(ids, token.ID)
+	}
+	return ids
+}
+
+func tradeIDs(trades []*models.Trade) []int64 {
+	ids := make([]int64, 0, len(trades))
+	for _, trade := range trades {
+		ids = append(ids, trade.ID)
+	}
+	return ids
+}
+
+func gapIDs(gaps []*models.DataGap) []int64 {
+	ids := make([]int64, 0, len(gaps))
+	for _, gap := range gaps {
+		ids = append(ids, gap.ID)
+	}
+	return ids
+}
+
+func transitionIDs(transitions []*models.TokenTransition) []int64 {
+	ids := make([]int64, 0, len(transitions))
+	for _, transition := range transitions {
+		ids = append(ids, transition.ID)
+	}
+	return ids
+}
+
+func creatorAddresses(profiles []*models.CreatorProfile) []string {
+	addresses := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		addresses = append(addresses, profile.CreatorAddress)
+	}
+	return addresses
+}
+
+func derefBalances(balances []*models.HolderBalance) []models.HolderBalance {
+	values := make([]models.HolderBalance, 0, len(balances))
+	for _, balance := range balances {
+		values = append(values, *balance)
+	}
+	return values
+}
@@ -0,0 +1,148 @@
+// internal/repository/repotest/repotest.go
+
+// Package repotest is a conformance suite for the repository interfaces. Every
+// implementation runs the same cases, so the in-memory repositories can stand in for
+// Postgres in tests without drifting from its behaviour.
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories holds one implementation of every repository interface, all backed by the
+// same storage
+type Repositories struct {
+	Dashboard          repository.DashboardRepositoryInterface
+	Token              repository.TokenRepositoryInterface
+	Trade              repository.TradeRepositoryInterface
+	Strategy           repository.StrategyRepositoryInterface
+	StrategyMetric     repository.StrategyMetricRepositoryInterface
+	SimulationRun      repository.SimulationRunRepositoryInterface
+	SimulationResult   repository.SimulationResultRepositoryInterface
+	StrategyGeneration repository.StrategyGenerationRepositoryInterface
+	SimulatedTrade     repository.SimulatedTradeRepositoryInterface
+	SimulationEvent    repository.SimulationEventRepositoryInterface
+	Candle             repository.CandleRepositoryInterface
+	FeedMetric         repository.FeedMetricRepositoryInterface
+	DataGap            repository.DataGapRepositoryInterface
+	CreatorProfile     repository.CreatorProfileRepositoryInterface
+	WalletStats        repository.WalletStatsRepositoryInterface
+	LaunchAnalysis     repository.LaunchAnalysisRepositoryInterface
+	TokenAnomaly       repository.TokenAnomalyRepositoryInterface
+	TokenTransition    repository.TokenTransitionRepositoryInterface
+	TokenFeature       repository.TokenFeatureRepositoryInterface
+	TokenOutcome       repository.TokenOutcomeRepositoryInterface
+	EntryModel         repository.EntryModelRepositoryInterface
+
+	AIGenerationFailure repository.AIGenerationFailureRepositoryInterface
+	AIUsage             repository.AIUsageRepositoryInterface
+	StrategyCandidate   repository.StrategyCandidateRepositoryInterface
+	StrategyDraft       repository.StrategyDraftRepositoryInterface
+}
+
+// Run runs the conformance suite. newRepos is called once per case and must return
+// repositories over empty storage.
+func Run(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repos *Repositories)
+	}{
+		{"Strategy", testStrategy},
+		{"StrategyMetric", testStrategyMetric},
+		{"SimulationRun", testSimulationRun},
+		{"SimulationResult", testSimulationResult},
+		{"StrategyGeneration", testStrategyGeneration},
+		{"SimulationEvent", testSimulationEvent},
+		{"SimulatedTrade", testSimulatedTrade},
+		{"Dashboard", testDashboard},
+		{"Token", testToken},
+		{"Trade", testTrade},
+		{"Candle", testCandle},
+		{"FeedMetric", testFeedMetric},
+		{"DataGap", testDataGap},
+		{"CreatorProfile", testCreatorProfile},
+		{"WalletStats", testWalletStats},
+		{"LaunchAnalysis", testLaunchAnalysis},
+		{"TokenAnomaly", testTokenAnomaly},
+		{"TokenTransition", testTokenTransition},
+		{"TokenFeature", testTokenFeature},
+		{"TokenOutcome", testTokenOutcome},
+		{"EntryModel", testEntryModel},
+		{"AIGenerationFailure", testAIGenerationFailure},
+		{"AIUsage", testAIUsage},
+		{"StrategyCandidate", testStrategyCandidate},
+		{"StrategyDraft", testStrategyDraft},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepos(t))
+		})
+	}
+}
+
+// timeTolerance absorbs the microsecond precision of Postgres timestamps
+const timeTolerance = time.Millisecond
+
+func seedStrategy(t *testing.T, repos *Repositories, name string, public bool) int64 {
+	t.Helper()
+	id, err := repos.Strategy.Save(&models.Strategy{
+		Name:     name,
+		Config:   models.JSONB{"takeProfitPct": 50.0},
+		IsPublic: public,
+		Tags:     []string{},
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func seedRun(t *testing.T, repos *Repositories, status string) int64 {
+	t.Helper()
+	now := time.Now()
+	id, err := repos.SimulationRun.Save(&models.SimulationRun{
+		StartTime:            now.Add(-time.Hour),
+		EndTime:              now.Add(time.Hour),
+		Status:               status,
+		SimulationParameters: models.JSONB{},
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func seedToken(t *testing.T, repos *Repositories, mint, creator string, createdMs int64) int64 {
+	t.Helper()
+	id, err := repos.Token.Save(&models.Token{
+		MintAddress:      mint,
+		CreatorAddress:   creator,
+		Name:             mint,
+		Symbol:           "TKN",
+		CreatedTimestamp: createdMs,
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func seedTrade(t *testing.T, repos *Repositories, tokenID int64, sig, user string, isBuy bool, sol, tokens float64, ts int64) int64 {
+	t.Helper()
+	id, err := repos.Trade.Save(&models.Trade{
+		TokenID:     tokenID,
+		Signature:   sig,
+		SolAmount:   sol,
+		TokenAmount: tokens,
+		IsBuy:       isBuy,
+		UserAddress: user,
+		Timestamp:   ts,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, id)
+	return id
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,705 @@
+// internal/repository/repotest/strategy.go
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStrategy(t *testing.T, repos *Repositories) {
+	id, err := repos.Strategy.Save(&models.Strategy{
+		Name:           "Momentum",
+		Description:    "Buys early momentum",
+		Config:         models.JSONB{"takeProfitPct": 50.0, "entrySignalType": "buy_count"},
+		IsPublic:       true,
+		Tags:           []string{"momentum", "fast"},
+		RiskScore:      3,
+		PromptTemplate: "momentum",
+		PromptVersion:  2,
+		Source:         models.StrategySourceAI,
+	})
+	require.NoError(t, err)
+	privateID := seedStrategy(t, repos, "Private", false)
+	quietID := seedStrategy(t, repos, "Quiet", true)
+
+	strategy, err := repos.Strategy.GetByID(id)
+	require.NoError(t, err)
+	require.NotNil(t, strategy)
+	assert.Equal(t, id, strategy.ID)
+	assert.Equal(t, "Momentum", strategy.Name)
+	assert.Equal(t, "Buys early momentum", strategy.Description)
+	assert.Equal(t, 50.0, strategy.Config["takeProfitPct"])
+	assert.ElementsMatch(t, []string{"momentum", "fast"}, strategy.Tags)
+	assert.Equal(t, 3, strategy.RiskScore)
+	assert.Equal(t, "momentum", strategy.PromptTemplate)
+	assert.Equal(t, 2, strategy.PromptVersion)
+	assert.Equal(t, models.StrategySourceAI, strategy.Source)
+
+	missing, err := repos.Strategy.GetByID(id + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Stored copies must not alias the caller's values
+	strategy.Config["takeProfitPct"] = 10.0
+	again, err := repos.Strategy.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, again.Config["takeProfitPct"])
+
+	strategy.Description = "Updated"
+	strategy.Source = models.StrategySourceUser
+	require.NoError(t, repos.Strategy.Update(strategy))
+	updated, err := repos.Strategy.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", updated.Description)
+	assert.Equal(t, models.StrategySourceAI, updated.Source, "the source is kept on update")
+
+	require.NoError(t, repos.Strategy.IncrementVoteCount(id))
+	require.NoError(t, repos.Strategy.IncrementVoteCount(id))
+	winTime := time.Now().Add(-time.Minute)
+	require.NoError(t, repos.Strategy.IncrementWinCount(quietID, winTime))
+
+	updated, err = repos.Strategy.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.VoteCount)
+	quiet, err := repos.Strategy.GetByID(quietID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, quiet.WinCount)
+	assert.WithinDuration(t, winTime, quiet.LastWinTime, timeTolerance)
+
+	public, err := repos.Strategy.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{id, quietID}, strategyIDs(public))
+
+	paged, err := repos.Strategy.ListPublic(10, 1)
+	require.NoError(t, err)
+	assert.Len(t, paged, 1)
+
+	tagged, err := repos.Strategy.SearchByTags([]string{"fast", "other"}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id}, strategyIDs(tagged))
+
+	topVoted, err := repos.Strategy.GetTopVoted(1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id}, strategyIDs(topVoted))
+
+	topWinners, err := repos.Strategy.GetTopWinners(1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{quietID}, strategyIDs(topWinners))
+
+	require.NoError(t, repos.Strategy.Delete(privateID))
+	deleted, err := repos.Strategy.GetByID(privateID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	assert.Error(t, repos.Strategy.Delete(privateID))
+	assert.Error(t, repos.Strategy.IncrementVoteCount(privateID))
+	assert.Error(t, repos.Strategy.Update(&models.Strategy{ID: privateID, Name: "Gone", Config: models.JSONB{}}))
+}
+
+func testStrategyMetric(t *testing.T, repos *Repositories) {
+	strategyID := seedStrategy(t, repos, "Metrics", true)
+	runID := seedRun(t, repos, "running")
+	base := time.Now().Add(-time.Hour)
+
+	oldID, err := repos.StrategyMetric.Save(&models.StrategyMetric{
+		StrategyID:     strategyID,
+		WinRate:        40,
+		ROI:            1.5,
+		TotalTrades:    10,
+		CurrentBalance: 10.5,
+		InitialBalance: 10,
+		CreatedAt:      base,
+	})
+	require.NoError(t, err)
+	runMetricID, err := repos.StrategyMetric.Save(&models.StrategyMetric{
+		StrategyID:      strategyID,
+		SimulationRunID: ptr(runID),
+		WinRate:         55.5,
+		ROI:             2.25,
+		TotalTrades:     20,
+		CurrentBalance:  11,
+		InitialBalance:  10,
+		CreatedAt:       base.Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	metric, err := repos.StrategyMetric.GetByID(runMetricID)
+	require.NoError(t, err)
+	require.NotNil(t, metric)
+	assert.Equal(t, strategyID, metric.StrategyID)
+	require.NotNil(t, metric.SimulationRunID)
+	assert.Equal(t, runID, *metric.SimulationRunID)
+	assert.InDelta(t, 55.5, metric.WinRate, 1e-9)
+	assert.InDelta(t, 2.25, metric.ROI, 1e-9)
+
+	missing, err := repos.StrategyMetric.GetByID(runMetricID + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	byStrategy, err := repos.StrategyMetric.GetByStrategy(strategyID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{runMetricID, oldID}, metricIDs(byStrategy))
+
+	byRun, err := repos.StrategyMetric.GetBySimulationRun(runID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{runMetricID}, metricIDs(byRun))
+
+	latest, err := repos.StrategyMetric.GetLatestByStrategy(strategyID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, runMetricID, latest.ID)
+
+	latest, err = repos.StrategyMetric.GetLatestByStrategyAndSimulation(strategyID, ptr(runID+1000))
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	// The latest metric of the run is updated in place
+	require.NoError(t, repos.StrategyMetric.UpdateLatestByStrategy(&models.StrategyMetric{
+		StrategyID:      strategyID,
+		SimulationRunID: ptr(runID),
+		WinRate:         60,
+		TotalTrades:     25,
+		CurrentBalance:  12,
+		InitialBalance:  10,
+	}))
+	metric, err = repos.StrategyMetric.GetByID(runMetricID)
+	require.NoError(t, err)
+	assert.Equal(t, 25, metric.TotalTrades)
+	assert.InDelta(t, 12, metric.CurrentBalance, 1e-9)
+
+	// Without a matching metric a new one is saved
+	otherID := seedStrategy(t, repos, "Fresh", true)
+	require.NoError(t, repos.StrategyMetric.UpdateLatestByStrategy(&models.StrategyMetric{
+		StrategyID:  otherID,
+		TotalTrades: 3,
+	}))
+	fresh, err := repos.StrategyMetric.GetByStrategy(otherID)
+	require.NoError(t, err)
+	require.Len(t, fresh, 1)
+	assert.Equal(t, 3, fresh[0].TotalTrades)
+
+	all, err := repos.StrategyMetric.GetByStrategy(strategyID)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func testSimulationRun(t *testing.T, repos *Repositories) {
+	strategyID := seedStrategy(t, repos, "Winner", true)
+	now := time.Now()
+
+	completedID, err := repos.SimulationRun.Save(&models.SimulationRun{
+		StartTime:            now.Add(-3 * time.Hour),
+		EndTime:              now.Add(-2 * time.Hour),
+		Status:               "completed",
+		SimulationParameters: models.JSONB{"initialBalance": 10.0},
+		CreatedAt:            now.Add(-3 * time.Hour),
+	})
+	require.NoError(t, err)
+	runningID, err := repos.SimulationRun.Save(&models.SimulationRun{
+		StartTime:            now.Add(-time.Hour),
+		EndTime:              now.Add(time.Hour),
+		Status:               "running",
+		SimulationParameters: models.JSONB{"initialBalance": 10.0},
+		CreatedAt:            now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	run, err := repos.SimulationRun.GetByID(completedID)
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.Equal(t, "completed", run.Status)
+	assert.Zero(t, run.WinnerStrategyID)
+	assert.WithinDuration(t, now.Add(-3*time.Hour), run.StartTime, timeTolerance)
+	assert.Equal(t, 10.0, run.SimulationParameters["initialBalance"])
+
+	missing, err := repos.SimulationRun.GetByID(runningID + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	current, err := repos.SimulationRun.GetCurrent()
+	require.NoError(t, err)
+	require.NotNil(t, current)
+	assert.Equal(t, runningID, current.ID)
+
+	completed, err := repos.SimulationRun.GetByStatus("completed", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{completedID}, runIDs(completed))
+
+	inRange, err := repos.SimulationRun.GetByTimeRange(now.Add(-4*time.Hour), now)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{completedID}, runIDs(inRange))
+
+	require.NoError(t, repos.SimulationRun.UpdateWinner(completedID, strategyID))
+	require.NoError(t, repos.SimulationRun.MarkDataDegraded(completedID, "feed gap"))
+	require.NoError(t, repos.SimulationRun.UpdateStatus(runningID, "completed"))
+
+	run, err = repos.SimulationRun.GetByID(completedID)
+	require.NoError(t, err)
+	assert.Equal(t, strategyID, run.WinnerStrategyID)
+	assert.Equal(t, true, run.SimulationParameters["data_degraded"])
+	assert.Equal(t, "feed gap", run.SimulationParameters["data_degraded_reason"])
+	assert.Equal(t, 10.0, run.SimulationParameters["initialBalance"])
+
+	current, err = repos.SimulationRun.GetCurrent()
+	require.NoError(t, err)
+	assert.Nil(t, current)
+
+	assert.Error(t, repos.SimulationRun.UpdateStatus(runningID+1000, "failed"))
+	assert.Error(t, repos.SimulationRun.UpdateWinner(runningID+1000, strategyID))
+	assert.Error(t, repos.SimulationRun.MarkDataDegraded(runningID+1000, "feed gap"))
+}
+
+func testSimulationResult(t *testing.T, repos *Repositories) {
+	firstID := seedStrategy(t, repos, "First", true)
+	secondID := seedStrategy(t, repos, "Second", true)
+	runID := seedRun(t, repos, "completed")
+
+	lowID, err := repos.SimulationResult.Save(&models.SimulationResult{
+		SimulationRunID:   runID,
+		StrategyID:        firstID,
+		ROI:               -5.5,
+		TradeCount:        4,
+		PerformanceRating: "poor",
+		Rank:              2,
+	})
+	require.NoError(t, err)
+	highID, err := repos.SimulationResult.Save(&models.SimulationResult{
+		SimulationRunID:   runID,
+		StrategyID:        secondID,
+		ROI:               12.25,
+		TradeCount:        8,
+		WinRate:           62.5,
+		PerformanceRating: "good",
+		PromptTemplate:    "performance",
+		PromptVersion:     1,
+		Rank:              1,
+	})
+	require.NoError(t, err)
+
+	result, err := repos.SimulationResult.GetByID(highID)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, secondID, result.StrategyID)
+	assert.InDelta(t, 12.25, result.ROI, 1e-9)
+	assert.InDelta(t, 62.5, result.WinRate, 1e-9)
+	assert.Equal(t, "good", result.PerformanceRating)
+	assert.Equal(t, "performance", result.PromptTemplate)
+	assert.Equal(t, 1, result.PromptVersion)
+
+	missing, err := repos.SimulationResult.GetByID(highID + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	byRun, err := repos.SimulationResult.GetBySimulationRun(runID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{highID, lowID}, resultIDs(byRun))
+
+	top, err := repos.SimulationResult.GetTopPerformers(runID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{highID}, resultIDs(top))
+
+	byStrategy, err := repos.SimulationResult.GetByStrategy(firstID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{lowID}, resultIDs(byStrategy))
+}
+
+func testStrategyGeneration(t *testing.T, repos *Repositories) {
+	latest, err := repos.StrategyGeneration.GetLatestGeneration()
+	require.NoError(t, err)
+	assert.Zero(t, latest)
+
+	parentID := seedStrategy(t, repos, "Parent", true)
+	childID := seedStrategy(t, repos, "Child", true)
+	grandchildID := seedStrategy(t, repos, "Grandchild", true)
+
+	firstID, err := repos.StrategyGeneration.Save(&models.StrategyGeneration{
+		GenerationNumber:  1,
+		ParentStrategyID:  parentID,
+		ChildStrategyID:   childID,
+		ImprovementReason: "Tighter stop loss",
+	})
+	require.NoError(t, err)
+	siblingID, err := repos.StrategyGeneration.Save(&models.StrategyGeneration{
+		GenerationNumber: 1,
+		ParentStrategyID: parentID,
+		ChildStrategyID:  grandchildID,
+	})
+	require.NoError(t, err)
+	secondID, err := repos.StrategyGeneration.Save(&models.StrategyGeneration{
+		GenerationNumber: 2,
+		ParentStrategyID: childID,
+		ChildStrategyID:  grandchildID,
+	})
+	require.NoError(t, err)
+
+	generation, err := repos.StrategyGeneration.GetByID(firstID)
+	require.NoError(t, err)
+	require.NotNil(t, generation)
+	assert.Equal(t, 1, generation.GenerationNumber)
+	assert.Equal(t, parentID, generation.ParentStrategyID)
+	assert.Equal(t, childID, generation.ChildStrategyID)
+	assert.Equal(t, "Tighter stop loss", generation.ImprovementReason)
+	assert.False(t, generation.CreatedAt.IsZero())
+
+	missing, err := repos.StrategyGeneration.GetByID(secondID + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	byParent, err := repos.StrategyGeneration.GetByParentStrategy(parentID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{firstID, siblingID}, generationIDs(byParent))
+
+	byChild, err := repos.StrategyGeneration.GetByChildStrategy(grandchildID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{siblingID, secondID}, generationIDs(byChild))
+
+	byNumber, err := repos.StrategyGeneration.GetByGenerationNumber(1, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{firstID, siblingID}, generationIDs(byNumber))
+
+	byNumber, err = repos.StrategyGeneration.GetByGenerationNumber(1, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{siblingID}, generationIDs(byNumber))
+
+	latest, err = repos.StrategyGeneration.GetLatestGeneration()
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest)
+}
+
+func testSimulationEvent(t *testing.T, repos *Repositories) {
+	strategyID := seedStrategy(t, repos, "Events", true)
+	runID := seedRun(t, repos, "running")
+	base := time.Now().Add(-time.Hour)
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		id, err := repos.SimulationEvent.Save(&models.SimulationEvent{
+			StrategyID:      strategyID,
+			SimulationRunID: runID,
+			EventType:       "trade_executed",
+			EventData:       models.JSONB{"index": float64(i)},
+			Timestamp:       base.Add(time.Duration(i) * time.Minute),
+		})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	event, err := repos.SimulationEvent.GetByID(ids[1])
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "trade_executed", event.EventType)
+	assert.Equal(t, 1.0, event.EventData["index"])
+	assert.WithinDuration(t, base.Add(time.Minute), event.Timestamp, timeTolerance)
+
+	missing, err := repos.SimulationEvent.GetByID(ids[2] + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	byStrategy, err := repos.SimulationEvent.GetByStrategyID(strategyID, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{ids[2], ids[1]}, eventIDs(byStrategy))
+
+	byRun, err := repos.SimulationEvent.GetBySimulationRunID(runID, 10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{ids[1], ids[2]}, eventIDs(byRun))
+
+	latest, err := repos.SimulationEvent.GetLatestByStrategyID(strategyID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{ids[2]}, eventIDs(latest))
+}
+
+func testSimulatedTrade(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+	strategyID := seedStrategy(t, repos, "Trader", true)
+	otherID := seedStrategy(t, repos, "Other", true)
+	runID := seedRun(t, repos, "running")
+	tokenID := seedToken(t, repos, "mint-sim", "creator", time.Now().UnixMilli())
+	now := time.Now().Unix()
+
+	openID, err := repos.SimulatedTrade.Save(&models.SimulatedTrade{
+		StrategyID:        strategyID,
+		TokenID:           tokenID,
+		SimulationRunID:   ptr(runID),
+		EntryPrice:        0.000001,
+		EntryTimestamp:    now - 60,
+		PositionSize:      0.5,
+		Status:            "active",
+		EntryUsdMarketCap: 6000,
+		ModelVersion:      ptr("entry@v1"),
+	})
+	require.NoError(t, err)
+	closedID, err := repos.SimulatedTrade.SaveWithContext(ctx, &models.SimulatedTrade{
+		StrategyID:      strategyID,
+		TokenID:         tokenID,
+		SimulationRunID: ptr(runID),
+		EntryPrice:      0.000002,
+		EntryTimestamp:  now - 120,
+		PositionSize:    0.5,
+		Status:          "active",
+	})
+	require.NoError(t, err)
+	otherTradeID, err := repos.SimulatedTrade.Save(&models.SimulatedTrade{
+		StrategyID:     otherID,
+		TokenID:        tokenID,
+		EntryPrice:     0.000003,
+		EntryTimestamp: now - 30,
+		PositionSize:   1,
+		Status:         "active",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repos.SimulatedTrade.Update(&models.SimulatedTrade{
+		ID:               closedID,
+		ExitPrice:        ptr(0.000003),
+		ExitTimestamp:    ptr(now - 10),
+		ProfitLoss:       ptr(0.25),
+		Status:           "closed",
+		ExitReason:       ptr("take_profit"),
+		ExitUsdMarketCap: ptr(9000.0),
+	}))
+	assert.Error(t, repos.SimulatedTrade.Update(&models.SimulatedTrade{ID: otherTradeID + 1000, Status: "closed"}))
+
+	trades, err := repos.SimulatedTrade.GetByStrategyID(strategyID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{openID, closedID}, simulatedTradeIDs(trades))
+	assert.Equal(t, "entry@v1", *trades[0].ModelVersion)
+	closed := trades[1]
+	assert.Equal(t, "closed", closed.Status)
+	require.NotNil(t, closed.ProfitLoss)
+	assert.InDelta(t, 0.25, *closed.ProfitLoss, 1e-9)
+	require.NotNil(t, closed.ExitReason)
+	assert.Equal(t, "take_profit", *closed.ExitReason)
+	require.NotNil(t, closed.SimulationRunID)
+	assert.Equal(t, runID, *closed.SimulationRunID)
+
+	byID, err := repos.SimulatedTrade.GetByID(closedID)
+	require.NoError(t, err)
+	require.NotNil(t, byID)
+	assert.Equal(t, strategyID, byID.StrategyID)
+	assert.Equal(t, "take_profit", *byID.ExitReason)
+	assert.Nil(t, byID.PostMortem)
+	missing, err := repos.SimulatedTrade.GetByID(otherTradeID + 1000)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	analyzedAt := time.Now()
+	require.NoError(t, repos.SimulatedTrade.SavePostMortem(closedID, &models.TradePostMortem{
+		Diagnoses: []models.TradeDiagnosis{
+			{Code: models.DiagnosisTakeProfitTooLow, Detail: "ran another 80% after the exit", Parameter: "takeProfitPct", Confidence: 0.7},
+		},
+		Summary:        "Sold early",
+		PromptTemplate: "trade",
+		PromptVersion:  1,
+		AnalyzedAt:     analyzedAt,
+	}))
+	assert.Error(t, repos.SimulatedTrade.SavePostMortem(otherTradeID+1000, &models.TradePostMortem{}))
+	byID, err = repos.SimulatedTrade.GetByIDWithContext(ctx, closedID)
+	require.NoError(t, err)
+	require.NotNil(t, byID.PostMortem)
+	require.Len(t, byID.PostMortem.Diagnoses, 1)
+	assert.Equal(t, models.DiagnosisTakeProfitTooLow, byID.PostMortem.Diagnoses[0].Code)
+	assert.Equal(t, "takeProfitPct", byID.PostMortem.Diagnoses[0].Parameter)
+	assert.Equal(t, "trade", byID.PostMortem.PromptTemplate)
+	assert.WithinDuration(t, analyzedAt, byID.PostMortem.AnalyzedAt, timeTolerance)
+
+	active, err := repos.SimulatedTrade.GetActiveByStrategyIDWithContext(ctx, strategyID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{openID}, simulatedTradeIDs(active))
+
+	byToken, err := repos.SimulatedTrade.GetTradesByTokenID(tokenID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{otherTradeID, openID}, simulatedTradeIDs(byToken))
+
+	byRun, err := repos.SimulatedTrade.GetBySimulationRun(runID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{openID, closedID}, simulatedTradeIDs(byRun))
+
+	exists, err := repos.SimulatedTrade.ExistsByStrategyIDAndTokenID(otherID, tokenID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repos.SimulatedTrade.ExistsByStrategyIDAndTokenID(otherID, tokenID+1000)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	summary, err := repos.SimulatedTrade.GetSummaryByStrategyID(strategyID)
+	require.NoError(t, err)
+	assert.Equal(t, strategyID, summary["strategy_id"])
+	assert.Equal(t, "Trader", summary["strategy_name"])
+	assert.Equal(t, 1, summary["total_trades"])
+
+	emptySummary, err := repos.SimulatedTrade.GetSummaryByStrategyID(otherID + 1000)
+	require.NoError(t, err)
+	assert.Equal(t, 0, emptySummary["total_trades"])
+
+	require.NoError(t, repos.SimulatedTrade.DeleteByStrategyID(otherID))
+	assert.Error(t, repos.SimulatedTrade.DeleteByStrategyID(otherID))
+	trades, err = repos.SimulatedTrade.GetByStrategyID(otherID)
+	require.NoError(t, err)
+	assert.Empty(t, trades)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = repos.SimulatedTrade.GetByStrategyIDWithContext(cancelled, strategyID)
+	assert.Error(t, err)
+}
+
+func testDashboard(t *testing.T, repos *Repositories) {
+	top, roi, trades, err := repos.Dashboard.GetTopPerformingStrategy()
+	require.NoError(t, err)
+	assert.Equal(t, "No strategies available", top.Name)
+	assert.Zero(t, roi)
+	assert.Zero(t, trades)
+
+	performance, err := repos.Dashboard.GetRecentPerformance()
+	require.NoError(t, err)
+	require.Len(t, performance, 3)
+	assert.Zero(t, performance[0].Trades)
+	assert.Equal(t, "N/A", performance[0].BestTrade)
+
+	status, volatility, err := repos.Dashboard.GetMarketConditions()
+	require.NoError(t, err)
+	assert.Equal(t, "neutral", status)
+	assert.Equal(t, 50, volatility)
+
+	firstID := seedStrategy(t, repos, "Alpha", true)
+	secondID := seedStrategy(t, repos, "Beta", true)
+
+	top, _, _, err = repos.Dashboard.GetTopPerformingStrategy()
+	require.NoError(t, err)
+	assert.Equal(t, firstID, top.ID)
+	assert.Equal(t, "Alpha (No metrics)", top.Name)
+
+	_, err = repos.StrategyMetric.Save(&models.StrategyMetric{
+		StrategyID: firstID, ROI: 5, TotalTrades: 4, SuccessfulTrades: 3, CurrentBalance: 12, InitialBalance: 10,
+	})
+	require.NoError(t, err)
+	_, err = repos.StrategyMetric.Save(&models.StrategyMetric{
+		StrategyID: secondID, ROI: 15, TotalTrades: 6, SuccessfulTrades: 2, CurrentBalance: 8, InitialBalance: 10,
+	})
+	require.NoError(t, err)
+
+	top, roi, trades, err = repos.Dashboard.GetTopPerformingStrategy()
+	require.NoError(t, err)
+	assert.Equal(t, secondID, top.ID)
+	assert.Equal(t, "Beta", top.Name)
+	assert.InDelta(t, 15, roi, 1e-9)
+	assert.Equal(t, 6, trades)
+
+	total, won, lost, winRate, err := repos.Dashboard.GetTradingStats()
+	require.NoError(t, err)
+	assert.Equal(t, 10, total)
+	assert.Equal(t, 5, won)
+	assert.Equal(t, 5, lost)
+	assert.InDelta(t, 50, winRate, 1e-9)
+
+	balance, err := repos.Dashboard.GetTotalBalance()
+	require.NoError(t, err)
+	assert.InDelta(t, 20, balance, 1e-6)
+
+	tokenID := seedToken(t, repos, "mint-dash", "creator", time.Now().UnixMilli())
+	now := time.Now().Unix()
+	_, err = repos.SimulatedTrade.Save(&models.SimulatedTrade{
+		StrategyID: firstID, TokenID: tokenID, EntryPrice: 1, EntryTimestamp: now, PositionSize: 1, Status: "active",
+	})
+	require.NoError(t, err)
+	_, err = repos.SimulatedTrade.Save(&models.SimulatedTrade{
+		StrategyID: firstID, TokenID: tokenID, EntryPrice: 1, EntryTimestamp: now - 3700, PositionSize: 2,
+		Status: "closed", ExitTimestamp: ptr(now - 3600), ProfitLoss: ptr(1.0), ExitPrice: ptr(1.5),
+	})
+	require.NoError(t, err)
+	_, err = repos.SimulatedTrade.Save(&models.SimulatedTrade{
+		StrategyID: secondID, TokenID: tokenID, EntryPrice: 1, EntryTimestamp: now - 200, PositionSize: 1,
+		Status: "closed", ExitTimestamp: ptr(now - 80), ProfitLoss: ptr(-0.5), ExitPrice: ptr(0.5),
+	})
+	require.NoError(t, err)
+
+	activeCount, err := repos.Dashboard.GetActiveTradeCount()
+	require.NoError(t, err)
+	assert.Equal(t, 1, activeCount)
+
+	holdTime, err := repos.Dashboard.GetAverageHoldTime()
+	require.NoError(t, err)
+	assert.Equal(t, "1m 50s", holdTime)
+
+	performance, err = repos.Dashboard.GetRecentPerformance()
+	require.NoError(t, err)
+	require.Len(t, performance, 3)
+	assert.Equal(t, "Last 24 Hours", performance[0].Period)
+	assert.Equal(t, 2, performance[0].Trades)
+	assert.InDelta(t, 0.5, performance[0].Profit, 1e-9)
+	assert.InDelta(t, 50, performance[0].WinRate, 1e-9)
+	assert.Equal(t, "+50.0%", performance[0].BestTrade)
+
+	distribution, err := repos.Dashboard.GetStrategyDistribution(10)
+	require.NoError(t, err)
+	require.Len(t, distribution, 1)
+	assert.Equal(t, firstID, distribution[0].ID)
+	assert.Equal(t, 1, distribution[0].Trades)
+	assert.Equal(t, "var(--color-chart-1)", distribution[0].Color)
+
+	history, err := repos.Dashboard.GetPerformanceHistory(7)
+	require.NoError(t, err)
+	require.Len(t, history, 8)
+	assert.InDelta(t, 20.5, history[len(history)-1].Balance, 1e-6)
+}
+
+func strategyIDs(strategies []*models.Strategy) []int64 {
+	ids := make([]int64, 0, len(strategies))
+	for _, s := range strategies {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func metricIDs(metrics []*models.StrategyMetric) []int64 {
+	ids := make([]int64, 0, len(metrics))
+	for _, m := range metrics {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func runIDs(runs []*models.SimulationRun) []int64 {
+	ids := make([]int64, 0, len(runs))
+	for _, r := range runs {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func resultIDs(results []*models.SimulationResult) []int64 {
+	ids := make([]int64, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func generationIDs(generations []*models.StrategyGeneration) []int64 {
+	ids := make([]int64, 0, len(generations))
+	for _, g := range generations {
+		ids = append(ids, g.ID)
+	}
+	return ids
+}
+
+func eventIDs(events []*models.SimulationEvent) []int64 {
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func simulatedTradeIDs(trades []*models.SimulatedTrade) []int64 {
+	ids := make([]int64, 0, len(trades))
+	for _, trade := range trades {
+		ids = append(ids, trade.ID)
+	}
+	return ids
+}
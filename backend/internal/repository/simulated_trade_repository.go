@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -38,9 +39,9 @@ func (r *SimulatedTradeRepository) SaveWithContext(ctx context.Context, trade *m
 	query := `
 		INSERT INTO simulated_trades 
 		(strategy_id, token_id, simulation_run_id, entry_price, exit_price, entry_timestamp, exit_timestamp, 
-		position_size, profit_loss, status, exit_reason, entry_usd_market_cap, exit_usd_market_cap, created_at, updated_at) 
+		position_size, profit_loss, status, exit_reason, entry_usd_market_cap, exit_usd_market_cap, model_version, created_at, updated_at) 
 		VALUES 
-		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id
 	`
 
@@ -106,6 +107,7 @@ func (r *SimulatedTradeRepository) SaveWithContext(ctx context.Context, trade *m
 		exitReason,
 		trade.EntryUsdMarketCap,
 		exitUsdMarketCap,
+		trade.ModelVersion,
 		now,
 		now,
 	).Scan(&id)
@@ -228,6 +230,8 @@ func (r *SimulatedTradeRepository) scanTrade(rows *sql.Rows) (*models.SimulatedT
 	var profitLoss sql.NullFloat64
 	var exitReason sql.NullString
 	var exitUsdMarketCap sql.NullFloat64
+	var modelVersion sql.NullString
+	var postMortemJSON []byte // NULL until the trade has a post-mortem
 
 	err := rows.Scan(
 		&trade.ID,
@@ -244,6 +248,8 @@ func (r *SimulatedTradeRepository) scanTrade(rows *sql.Rows) (*models.SimulatedT
 		&exitReason,
 		&trade.EntryUsdMarketCap,
 		&exitUsdMarketCap,
+		&modelVersion,
+		&postMortemJSON,
 		&trade.CreatedAt,
 		&trade.UpdatedAt,
 	)
@@ -277,9 +283,86 @@ func (r *SimulatedTradeRepository) scanTrade(rows *sql.Rows) (*models.SimulatedT
 		trade.ExitUsdMarketCap = &exitUsdMarketCap.Float64
 	}
 
+	if modelVersion.Valid {
+		trade.ModelVersion = &modelVersion.String
+	}
+
+	if postMortemJSON != nil {
+		trade.PostMortem = &models.TradePostMortem{}
+		if err := json.Unmarshal(postMortemJSON, trade.PostMortem); err != nil {
+			return nil, fmt.Errorf("error decoding simulated trade post-mortem: %v", err)
+		}
+	}
+
 	return &trade, nil
 }
 
+// GetByID retrieves a simulated trade by its ID, or nil if it doesn't exist
+func (r *SimulatedTradeRepository) GetByID(id int64) (*models.SimulatedTrade, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return r.GetByIDWithContext(ctx, id)
+}
+
+// GetByIDWithContext retrieves a simulated trade by its ID with context
+func (r *SimulatedTradeRepository) GetByIDWithContext(ctx context.Context, id int64) (*models.SimulatedTrade, error) {
+	query := `
+		SELECT id, strategy_id, token_id, simulation_run_id, entry_price, exit_price, entry_timestamp, 
+			exit_timestamp, position_size, profit_loss, status, exit_reason, 
+			entry_usd_market_cap, exit_usd_market_cap, model_version, post_mortem, created_at, updated_at
+		FROM simulated_trades
+		WHERE id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Error querying simulated trade %d: %v", id, err)
+		return nil, fmt.Errorf("error querying simulated trade: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error querying simulated trade: %v", err)
+		}
+		return nil, nil
+	}
+	return r.scanTrade(rows)
+}
+
+// SavePostMortem stores the post-mortem of a simulated trade, replacing any earlier one
+func (r *SimulatedTradeRepository) SavePostMortem(tradeID int64, postMortem *models.TradePostMortem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return r.SavePostMortemWithContext(ctx, tradeID, postMortem)
+}
+
+// SavePostMortemWithContext stores the post-mortem of a simulated trade with context
+func (r *SimulatedTradeRepository) SavePostMortemWithContext(ctx context.Context, tradeID int64, postMortem *models.TradePostMortem) error {
+	data, err := json.Marshal(postMortem)
+	if err != nil {
+		return fmt.Errorf("error encoding simulated trade post-mortem: %v", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE simulated_trades SET post_mortem = $1, updated_at = $2 WHERE id = $3",
+		data, time.Now(), tradeID,
+	)
+	if err != nil {
+		r.logger.Error("Error saving post-mortem of simulated trade %d: %v", tradeID, err)
+		return fmt.Errorf("error saving simulated trade post-mortem: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no trade found with ID %d", tradeID)
+	}
+	return nil
+}
+
 // GetByStrategyID retrieves all simulated trades for a specific strategy
 func (r *SimulatedTradeRepository) GetByStrategyID(strategyID int64) ([]*models.SimulatedTrade, error) {
 	// Use the context-based version with a default timeout
@@ -293,7 +376,7 @@ func (r *SimulatedTradeRepository) GetByStrategyIDWithContext(ctx context.Contex
 	query := `
 		SELECT id, strategy_id, token_id, simulation_run_id, entry_price, exit_price, entry_timestamp, 
 			exit_timestamp, position_size, profit_loss, status, exit_reason, 
-			entry_usd_market_cap, exit_usd_market_cap, created_at, updated_at
+			entry_usd_market_cap, exit_usd_market_cap, model_version, post_mortem, created_at, updated_at
 		FROM simulated_trades
 		WHERE strategy_id = $1
 		ORDER BY entry_timestamp DESC
@@ -344,7 +427,7 @@ func (r *SimulatedTradeRepository) GetActiveByStrategyIDWithContext(ctx context.
 	query := `
 		SELECT id, strategy_id, token_id, simulation_run_id, entry_price, exit_price, entry_timestamp, 
 			exit_timestamp, position_size, profit_loss, status, exit_reason, 
-			entry_usd_market_cap, exit_usd_market_cap, created_at, updated_at
+			entry_usd_market_cap, exit_usd_market_cap, model_version, post_mortem, created_at, updated_at
 		FROM simulated_trades
 		WHERE strategy_id = $1 AND status = 'active'
 		ORDER BY entry_timestamp DESC
@@ -400,6 +483,29 @@ func (r *SimulatedTradeRepository) GetSummaryByStrategyIDWithContext(ctx context
 
 	if len(trades) == 0 {
 		r.logger.Info("No simulated trades found for strategy %d", strategyID)
+		return SummarizeSimulatedTrades(strategyID, "", trades), nil
+	}
+
+	// Get strategy name with context
+	var strategyName string
+	err = r.db.QueryRowContext(ctx, "SELECT name FROM strategies WHERE id = $1", strategyID).Scan(&strategyName)
+	if err != nil {
+		strategyName = fmt.Sprintf("Strategy %d", strategyID)
+		r.logger.Warn("Could not retrieve strategy name for ID %d: %v", strategyID, err)
+	}
+
+	summary := SummarizeSimulatedTrades(strategyID, strategyName, trades)
+
+	r.logger.Info("Generated performance summary for strategy %d (win rate: %.2f%%, projected ROI: %.2f%%)",
+		strategyID, summary["win_rate"], summary["projected_roi"])
+
+	return summary, nil
+}
+
+// SummarizeSimulatedTrades calculates the summary statistics of a strategy's simulated
+// trades. It is shared by every SimulatedTradeRepositoryInterface implementation.
+func SummarizeSimulatedTrades(strategyID int64, strategyName string, trades []*models.SimulatedTrade) map[string]interface{} {
+	if len(trades) == 0 {
 		return map[string]interface{}{
 			"strategy_id":       strategyID,
 			"total_trades":      0,
@@ -410,7 +516,7 @@ func (r *SimulatedTradeRepository) GetSummaryByStrategyIDWithContext(ctx context
 			"total_loss":        0.0,
 			"net_pnl":           0.0,
 			"message":           "No simulated trades found for this strategy",
-		}, nil
+		}
 	}
 
 	// Calculate summary statistics
@@ -522,14 +628,6 @@ func (r *SimulatedTradeRepository) GetSummaryByStrategyIDWithContext(ctx context
 		durationSec = lastTimestamp - initialTimestamp
 	}
 
-	// Get strategy name with context
-	var strategyName string
-	err = r.db.QueryRowContext(ctx, "SELECT name FROM strategies WHERE id = $1", strategyID).Scan(&strategyName)
-	if err != nil {
-		strategyName = fmt.Sprintf("Strategy %d", strategyID)
-		r.logger.Warn("Could not retrieve strategy name for ID %d: %v", strategyID, err)
-	}
-
 	// Calculate average holding time
 	var totalHoldingTime int64
 	completedTradeCount := 0
@@ -547,9 +645,6 @@ func (r *SimulatedTradeRepository) GetSummaryByStrategyIDWithContext(ctx context
 		avgHoldingTimeSec = totalHoldingTime / int64(completedTradeCount)
 	}
 
-	r.logger.Info("Generated performance summary for strategy %d (win rate: %.2f%%, projected ROI: %.2f%%)", 
-		strategyID, winRate, projectedRoi)
-
 	// Return enriched summary
 	return map[string]interface{}{
 		"strategy_id":                   strategyID,
@@ -571,7 +666,7 @@ func (r *SimulatedTradeRepository) GetSummaryByStrategyIDWithContext(ctx context
 		"projected_win_rate":            projectedWinRate,
 		"projected_net_pnl":             totalProjectedProfit + totalProjectedLoss,
 		"projected_total_trades":        totalProjectedTrades,
-	}, nil
+	}
 }
 
 // DeleteByStrategyID deletes all simulated trades for a strategy
@@ -624,7 +719,7 @@ func (r *SimulatedTradeRepository) GetTradesByTokenIDWithContext(ctx context.Con
 	query := `
 		SELECT id, strategy_id, token_id, simulation_run_id, entry_price, exit_price, entry_timestamp, 
 			exit_timestamp, position_size, profit_loss, status, exit_reason, 
-			entry_usd_market_cap, exit_usd_market_cap, created_at, updated_at
+			entry_usd_market_cap, exit_usd_market_cap, model_version, post_mortem, created_at, updated_at
 		FROM simulated_trades
 		WHERE token_id = $1
 		ORDER BY entry_timestamp DESC
@@ -674,7 +769,7 @@ func (r *SimulatedTradeRepository) GetBySimulationRunWithContext(ctx context.Con
 	query := `
 		SELECT id, strategy_id, token_id, simulation_run_id, entry_price, exit_price, entry_timestamp, 
 			exit_timestamp, position_size, profit_loss, status, exit_reason, 
-			entry_usd_market_cap, exit_usd_market_cap, created_at, updated_at
+			entry_usd_market_cap, exit_usd_market_cap, model_version, post_mortem, created_at, updated_at
 		FROM simulated_trades
 		WHERE simulation_run_id = $1
 		ORDER BY strategy_id, entry_timestamp DESC
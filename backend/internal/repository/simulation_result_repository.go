@@ -25,9 +25,10 @@ func NewSimulationResultRepository(db *sql.DB) *SimulationResultRepository {
 func (r *SimulationResultRepository) Save(result *models.SimulationResult) (int64, error) {
 	query := `
         INSERT INTO simulation_results
-            (simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis, rank, created_at) 
+            (simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis,
+            prompt_template, prompt_version, rank, created_at) 
         VALUES 
-            ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) 
+            ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) 
         RETURNING id
     `
 
@@ -47,6 +48,8 @@ func (r *SimulationResultRepository) Save(result *models.SimulationResult) (int6
 		result.MaxDrawdown,
 		result.PerformanceRating,
 		result.Analysis,
+		result.PromptTemplate,
+		result.PromptVersion,
 		result.Rank,
 		result.CreatedAt,
 	).Scan(&id)
@@ -61,7 +64,8 @@ func (r *SimulationResultRepository) Save(result *models.SimulationResult) (int6
 // GetByID retrieves a simulation result by ID
 func (r *SimulationResultRepository) GetByID(id int64) (*models.SimulationResult, error) {
 	query := `
-        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis, rank, created_at
+        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis,
+            prompt_template, prompt_version, rank, created_at
         FROM simulation_results 
         WHERE id = $1
     `
@@ -77,6 +81,8 @@ func (r *SimulationResultRepository) GetByID(id int64) (*models.SimulationResult
 		&result.MaxDrawdown,
 		&result.PerformanceRating,
 		&result.Analysis,
+		&result.PromptTemplate,
+		&result.PromptVersion,
 		&result.Rank,
 		&result.CreatedAt,
 	)
@@ -94,7 +100,8 @@ func (r *SimulationResultRepository) GetByID(id int64) (*models.SimulationResult
 // GetBySimulationRun retrieves simulation results by simulation run ID
 func (r *SimulationResultRepository) GetBySimulationRun(simulationRunID int64) ([]*models.SimulationResult, error) {
 	query := `
-        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis, rank, created_at
+        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis,
+            prompt_template, prompt_version, rank, created_at
         FROM simulation_results 
         WHERE simulation_run_id = $1
         ORDER BY rank ASC
@@ -116,7 +123,8 @@ func (r *SimulationResultRepository) GetBySimulationRun(simulationRunID int64) (
 // GetTopPerformers retrieves top performing strategies in a simulation run
 func (r *SimulationResultRepository) GetTopPerformers(simulationRunID int64, limit int) ([]*models.SimulationResult, error) {
 	query := `
-        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis, rank, created_at
+        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis,
+            prompt_template, prompt_version, rank, created_at
         FROM simulation_results 
         WHERE simulation_run_id = $1
         ORDER BY roi DESC
@@ -139,7 +147,8 @@ func (r *SimulationResultRepository) GetTopPerformers(simulationRunID int64, lim
 // GetByStrategy retrieves simulation results for a specific strategy
 func (r *SimulationResultRepository) GetByStrategy(strategyID int64, limit int) ([]*models.SimulationResult, error) {
 	query := `
-        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis, rank, created_at
+        SELECT id, simulation_run_id, strategy_id, roi, trade_count, win_rate, max_drawdown, performance_rating, analysis,
+            prompt_template, prompt_version, rank, created_at
         FROM simulation_results 
         WHERE strategy_id = $1
         ORDER BY created_at DESC
@@ -176,6 +185,8 @@ func (r *SimulationResultRepository) scanResultRows(rows *sql.Rows) ([]*models.S
 			&result.MaxDrawdown,
 			&result.PerformanceRating,
 			&result.Analysis,
+			&result.PromptTemplate,
+			&result.PromptVersion,
 			&result.Rank,
 			&result.CreatedAt,
 		); err != nil {
@@ -223,6 +223,33 @@ func (r *SimulationRunRepository) UpdateWinner(id int64, strategyID int64) error
 	return nil
 }
 
+// MarkDataDegraded flags a simulation run as having run on degraded market data
+func (r *SimulationRunRepository) MarkDataDegraded(id int64, reason string) error {
+	query := `
+		UPDATE simulation_runs
+		SET simulation_parameters = COALESCE(simulation_parameters, '{}'::jsonb)
+				|| jsonb_build_object('data_degraded', true, 'data_degraded_reason', $1::text),
+			updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.Exec(query, reason, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("error marking simulation run as degraded: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("simulation run not found: %d", id)
+	}
+
+	return nil
+}
+
 // scanSimulationRunRows is a helper function to scan multiple simulation run rows
 func (r *SimulationRunRepository) scanSimulationRunRows(rows *sql.Rows) ([]*models.SimulationRun, error) {
 	var runs []*models.SimulationRun
@@ -0,0 +1,145 @@
+// internal/repository/strategy_candidate_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// StrategyCandidateRepository handles database operations for strategies that went through
+// admission
+type StrategyCandidateRepository struct {
+	db *sql.DB
+}
+
+// NewStrategyCandidateRepository creates a new strategy candidate repository
+func NewStrategyCandidateRepository(db *sql.DB) *StrategyCandidateRepository {
+	return &StrategyCandidateRepository{db: db}
+}
+
+// Save inserts a strategy candidate into the database
+func (r *StrategyCandidateRepository) Save(candidate *models.StrategyCandidate) (int64, error) {
+	reasons := candidate.Reasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+	reasonsJSON, err := json.Marshal(reasons)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding strategy candidate reasons: %v", err)
+	}
+
+	var backtestJSON interface{} // NULL when the candidate was never backtested
+	if candidate.Backtest != nil {
+		data, err := json.Marshal(candidate.Backtest)
+		if err != nil {
+			return 0, fmt.Errorf("error encoding strategy candidate backtest: %v", err)
+		}
+		backtestJSON = data
+	}
+
+	if candidate.CreatedAt.IsZero() {
+		candidate.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO strategy_candidates
+			(name, description, config, purpose, prompt_template, prompt_version, status, reasons,
+			 backtest, strategy_id, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	var id int64
+	err = r.db.QueryRow(
+		query,
+		candidate.Name,
+		candidate.Description,
+		candidate.Config,
+		candidate.Purpose,
+		candidate.PromptTemplate,
+		candidate.PromptVersion,
+		candidate.Status,
+		reasonsJSON,
+		backtestJSON,
+		candidate.StrategyID,
+		candidate.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving strategy candidate: %v", err)
+	}
+
+	candidate.ID = id
+	return id, nil
+}
+
+// GetRecent retrieves the most recent strategy candidates with the given status, newest
+// first. An empty status returns candidates of every status.
+func (r *StrategyCandidateRepository) GetRecent(status string, limit int) ([]*models.StrategyCandidate, error) {
+	query := `
+		SELECT id, name, description, config, purpose, prompt_template, prompt_version, status,
+		       reasons, backtest, strategy_id, created_at
+		FROM strategy_candidates
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy candidates: %v", err)
+	}
+	defer rows.Close()
+
+	var candidates []*models.StrategyCandidate
+	for rows.Next() {
+		var candidate models.StrategyCandidate
+		var description sql.NullString
+		var reasonsJSON, backtestJSON []byte
+		var strategyID sql.NullInt64
+
+		if err := rows.Scan(
+			&candidate.ID,
+			&candidate.Name,
+			&description,
+			&candidate.Config,
+			&candidate.Purpose,
+			&candidate.PromptTemplate,
+			&candidate.PromptVersion,
+			&candidate.Status,
+			&reasonsJSON,
+			&backtestJSON,
+			&strategyID,
+			&candidate.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning strategy candidate row: %v", err)
+		}
+
+		if err := json.Unmarshal(reasonsJSON, &candidate.Reasons); err != nil {
+			return nil, fmt.Errorf("error decoding strategy candidate reasons: %v", err)
+		}
+		if backtestJSON != nil {
+			candidate.Backtest = &models.BacktestResult{}
+			if err := json.Unmarshal(backtestJSON, candidate.Backtest); err != nil {
+				return nil, fmt.Errorf("error decoding strategy candidate backtest: %v", err)
+			}
+		}
+		candidate.Description = description.String
+		if strategyID.Valid {
+			candidate.StrategyID = &strategyID.Int64
+		}
+
+		candidates = append(candidates, &candidate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating strategy candidate rows: %v", err)
+	}
+
+	return candidates, nil
+}
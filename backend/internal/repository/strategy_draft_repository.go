@@ -0,0 +1,204 @@
+// internal/repository/strategy_draft_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// StrategyDraftRepository handles database operations for strategies drafted from a user's
+// description
+type StrategyDraftRepository struct {
+	db *sql.DB
+}
+
+// NewStrategyDraftRepository creates a new strategy draft repository
+func NewStrategyDraftRepository(db *sql.DB) *StrategyDraftRepository {
+	return &StrategyDraftRepository{db: db}
+}
+
+// strategyDraftJSON encodes the list columns of a draft, storing nil lists as empty ones
+func strategyDraftJSON(draft *models.StrategyDraft) (explanations, ambiguities, instructions []byte, err error) {
+	explanationList := draft.Explanations
+	if explanationList == nil {
+		explanationList = []models.DraftExplanation{}
+	}
+	if explanations, err = json.Marshal(explanationList); err != nil {
+		return nil, nil, nil, fmt.Errorf("error encoding strategy draft explanations: %v", err)
+	}
+
+	ambiguityList := draft.Ambiguities
+	if ambiguityList == nil {
+		ambiguityList = []models.DraftAmbiguity{}
+	}
+	if ambiguities, err = json.Marshal(ambiguityList); err != nil {
+		return nil, nil, nil, fmt.Errorf("error encoding strategy draft ambiguities: %v", err)
+	}
+
+	instructionList := draft.Instructions
+	if instructionList == nil {
+		instructionList = []string{}
+	}
+	if instructions, err = json.Marshal(instructionList); err != nil {
+		return nil, nil, nil, fmt.Errorf("error encoding strategy draft instructions: %v", err)
+	}
+
+	return explanations, ambiguities, instructions, nil
+}
+
+// Save inserts a strategy draft into the database
+func (r *StrategyDraftRepository) Save(draft *models.StrategyDraft) (int64, error) {
+	explanationsJSON, ambiguitiesJSON, instructionsJSON, err := strategyDraftJSON(draft)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	if draft.CreatedAt.IsZero() {
+		draft.CreatedAt = now
+	}
+	draft.UpdatedAt = now
+
+	query := `
+		INSERT INTO strategy_drafts
+			(name, description, config, explanations, ambiguities, instructions, revision, status,
+			 strategy_id, prompt_template, prompt_version, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+
+	var id int64
+	err = r.db.QueryRow(
+		query,
+		draft.Name,
+		draft.Description,
+		draft.Config,
+		explanationsJSON,
+		ambiguitiesJSON,
+		instructionsJSON,
+		draft.Revision,
+		draft.Status,
+		draft.StrategyID,
+		draft.PromptTemplate,
+		draft.PromptVersion,
+		draft.CreatedAt,
+		draft.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving strategy draft: %v", err)
+	}
+
+	draft.ID = id
+	return id, nil
+}
+
+// GetByID retrieves a strategy draft by its ID, or nil if it doesn't exist
+func (r *StrategyDraftRepository) GetByID(id int64) (*models.StrategyDraft, error) {
+	query := `
+		SELECT id, name, description, config, explanations, ambiguities, instructions, revision,
+		       status, strategy_id, prompt_template, prompt_version, created_at, updated_at
+		FROM strategy_drafts
+		WHERE id = $1
+	`
+
+	var draft models.StrategyDraft
+	var description sql.NullString
+	var explanationsJSON, ambiguitiesJSON, instructionsJSON []byte
+	var strategyID sql.NullInt64
+
+	err := r.db.QueryRow(query, id).Scan(
+		&draft.ID,
+		&draft.Name,
+		&description,
+		&draft.Config,
+		&explanationsJSON,
+		&ambiguitiesJSON,
+		&instructionsJSON,
+		&draft.Revision,
+		&draft.Status,
+		&strategyID,
+		&draft.PromptTemplate,
+		&draft.PromptVersion,
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No draft found
+		}
+		return nil, fmt.Errorf("error getting strategy draft: %v", err)
+	}
+
+	if err := json.Unmarshal(explanationsJSON, &draft.Explanations); err != nil {
+		return nil, fmt.Errorf("error decoding strategy draft explanations: %v", err)
+	}
+	if err := json.Unmarshal(ambiguitiesJSON, &draft.Ambiguities); err != nil {
+		return nil, fmt.Errorf("error decoding strategy draft ambiguities: %v", err)
+	}
+	if err := json.Unmarshal(instructionsJSON, &draft.Instructions); err != nil {
+		return nil, fmt.Errorf("error decoding strategy draft instructions: %v", err)
+	}
+	draft.Description = description.String
+	if strategyID.Valid {
+		draft.StrategyID = &strategyID.Int64
+	}
+
+	return &draft, nil
+}
+
+// Update updates an existing strategy draft
+func (r *StrategyDraftRepository) Update(draft *models.StrategyDraft) error {
+	explanationsJSON, ambiguitiesJSON, instructionsJSON, err := strategyDraftJSON(draft)
+	if err != nil {
+		return err
+	}
+
+	draft.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE strategy_drafts
+		SET name = $1, description = $2, config = $3, explanations = $4, ambiguities = $5,
+		    instructions = $6, revision = $7, status = $8, strategy_id = $9,
+		    prompt_template = $10, prompt_version = $11, updated_at = $12
+		WHERE id = $13
+	`
+
+	result, err := r.db.Exec(
+		query,
+		draft.Name,
+		draft.Description,
+		draft.Config,
+		explanationsJSON,
+		ambiguitiesJSON,
+		instructionsJSON,
+		draft.Revision,
+		draft.Status,
+		draft.StrategyID,
+		draft.PromptTemplate,
+		draft.PromptVersion,
+		draft.UpdatedAt,
+		draft.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error updating strategy draft: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("strategy draft not found: %d", draft.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+// internal/repository/strategy_generation_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// StrategyGenerationRepository handles database operations for strategy generations
+type StrategyGenerationRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewStrategyGenerationRepository creates a new strategy generation repository
+func NewStrategyGenerationRepository(db *sql.DB) *StrategyGenerationRepository {
+	return &StrategyGenerationRepository{db: db}
+}
+
+// Save inserts a strategy generation into the database
+func (r *StrategyGenerationRepository) Save(generation *models.StrategyGeneration) (int64, error) {
+	query := `
+		INSERT INTO strategy_generations
+			(generation_number, parent_strategy_id, child_strategy_id, improvement_reason, created_at)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	if generation.CreatedAt.IsZero() {
+		generation.CreatedAt = time.Now()
+	}
+
+	var id int64
+	err := r.db.QueryRow(
+		query,
+		generation.GenerationNumber,
+		generation.ParentStrategyID,
+		generation.ChildStrategyID,
+		generation.ImprovementReason,
+		generation.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving strategy generation: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetByID retrieves a strategy generation by its ID
+func (r *StrategyGenerationRepository) GetByID(id int64) (*models.StrategyGeneration, error) {
+	query := `
+		SELECT id, generation_number, parent_strategy_id, child_strategy_id, improvement_reason, created_at
+		FROM strategy_generations
+		WHERE id = $1
+	`
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy generation: %v", err)
+	}
+	defer rows.Close()
+
+	generations, err := r.scanGenerationRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(generations) == 0 {
+		return nil, nil
+	}
+
+	return generations[0], nil
+}
+
+// GetByParentStrategy retrieves the generations bred from a strategy, oldest first
+func (r *StrategyGenerationRepository) GetByParentStrategy(parentStrategyID int64) ([]*models.StrategyGeneration, error) {
+	query := `
+		SELECT id, generation_number, parent_strategy_id, child_strategy_id, improvement_reason, created_at
+		FROM strategy_generations
+		WHERE parent_strategy_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(query, parentStrategyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy generations by parent: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanGenerationRows(rows)
+}
+
+// GetByChildStrategy retrieves the generations that produced a strategy, oldest first
+func (r *StrategyGenerationRepository) GetByChildStrategy(childStrategyID int64) ([]*models.StrategyGeneration, error) {
+	query := `
+		SELECT id, generation_number, parent_strategy_id, child_strategy_id, improvement_reason, created_at
+		FROM strategy_generations
+		WHERE child_strategy_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(query, childStrategyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy generations by child: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanGenerationRows(rows)
+}
+
+// GetByGenerationNumber retrieves the generations with a generation number in insertion order
+func (r *StrategyGenerationRepository) GetByGenerationNumber(generationNumber int, limit, offset int) ([]*models.StrategyGeneration, error) {
+	query := `
+		SELECT id, generation_number, parent_strategy_id, child_strategy_id, improvement_reason, created_at
+		FROM strategy_generations
+		WHERE generation_number = $1
+		ORDER BY id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, generationNumber, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy generations by number: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanGenerationRows(rows)
+}
+
+// GetLatestGeneration returns the highest generation number, or 0 when there are none
+func (r *StrategyGenerationRepository) GetLatestGeneration() (int, error) {
+	var generation int
+	if err := r.db.QueryRow(`SELECT COALESCE(MAX(generation_number), 0) FROM strategy_generations`).Scan(&generation); err != nil {
+		return 0, fmt.Errorf("error getting latest strategy generation: %v", err)
+	}
+	return generation, nil
+}
+
+// scanGenerationRows scans strategy generation rows
+func (r *StrategyGenerationRepository) scanGenerationRows(rows *sql.Rows) ([]*models.StrategyGeneration, error) {
+	var generations []*models.StrategyGeneration
+	for rows.Next() {
+		var generation models.StrategyGeneration
+		var generationNumber, parentID, childID sql.NullInt64
+		var reason sql.NullString
+		var createdAt sql.NullTime
+
+		if err := rows.Scan(
+			&generation.ID,
+			&generationNumber,
+			&parentID,
+			&childID,
+			&reason,
+			&createdAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning strategy generation row: %v", err)
+		}
+
+		generation.GenerationNumber = int(generationNumber.Int64)
+		generation.ParentStrategyID = parentID.Int64
+		generation.ChildStrategyID = childID.Int64
+		generation.ImprovementReason = reason.String
+		generation.CreatedAt = createdAt.Time
+
+		generations = append(generations, &generation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating strategy generation rows: %v", err)
+	}
+
+	return generations, nil
+}
@@ -0,0 +1,106 @@
+// internal/repository/strategy_generation_repository_test.go
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrategyGenerationRepositorySave(t *testing.T) {
+	// Setup mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock: %v", err)
+	}
+
+	generation := &models.StrategyGeneration{
+		GenerationNumber:  2,
+		ParentStrategyID:  1,
+		ChildStrategyID:   3,
+		ImprovementReason: "Tighter stop loss",
+	}
+
+	mock.ExpectQuery(`INSERT INTO strategy_generations`).
+		WithArgs(2, int64(1), int64(3), "Tighter stop loss", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	repo := NewStrategyGenerationRepository(db)
+	id, err := repo.Save(generation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.False(t, generation.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStrategyGenerationRepositoryGetByParentStrategy(t *testing.T) {
+	// Setup mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock: %v", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "generation_number", "parent_strategy_id", "child_strategy_id", "improvement_reason", "created_at",
+	}).
+		AddRow(1, 1, 5, 6, "Higher take profit", now).
+		AddRow(2, 1, 5, 7, nil, now)
+
+	mock.ExpectQuery(`SELECT (.+) FROM strategy_generations`).
+		WithArgs(int64(5)).
+		WillReturnRows(rows)
+
+	repo := NewStrategyGenerationRepository(db)
+	generations, err := repo.GetByParentStrategy(5)
+
+	assert.NoError(t, err)
+	assert.Len(t, generations, 2)
+	assert.Equal(t, int64(6), generations[0].ChildStrategyID)
+	assert.Equal(t, "Higher take profit", generations[0].ImprovementReason)
+	assert.Equal(t, "", generations[1].ImprovementReason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStrategyGenerationRepositoryGetByIDNotFound(t *testing.T) {
+	// Setup mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM strategy_generations`).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "generation_number", "parent_strategy_id", "child_strategy_id", "improvement_reason", "created_at",
+		}))
+
+	repo := NewStrategyGenerationRepository(db)
+	generation, err := repo.GetByID(9)
+
+	assert.NoError(t, err)
+	assert.Nil(t, generation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStrategyGenerationRepositoryGetLatestGeneration(t *testing.T) {
+	// Setup mock DB
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(generation_number\), 0\) FROM strategy_generations`).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(4))
+
+	repo := NewStrategyGenerationRepository(db)
+	generation, err := repo.GetLatestGeneration()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, generation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -27,9 +27,10 @@ func (r *StrategyRepository) Save(strategy *models.Strategy) (int64, error) {
 	query := `
 		INSERT INTO strategies 
 			(name, description, config, is_public, vote_count, win_count, 
-			last_win_time, tags, complexity_score, risk_score, ai_enhanced, created_at, updated_at) 
+			last_win_time, tags, complexity_score, risk_score, ai_enhanced, prompt_template, prompt_version, source,
+			created_at, updated_at) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id
 	`
 
@@ -53,6 +54,9 @@ func (r *StrategyRepository) Save(strategy *models.Strategy) (int64, error) {
 		strategy.ComplexityScore,
 		strategy.RiskScore,
 		strategy.AIEnhanced,
+		strategy.PromptTemplate,
+		strategy.PromptVersion,
+		strategy.Source,
 		strategy.CreatedAt,
 		strategy.UpdatedAt,
 	).Scan(&id)
@@ -68,7 +72,8 @@ func (r *StrategyRepository) Save(strategy *models.Strategy) (int64, error) {
 func (r *StrategyRepository) GetByID(id int64) (*models.Strategy, error) {
 	query := `
 		SELECT id, name, description, config, is_public, vote_count, win_count, 
-			last_win_time, tags, complexity_score, risk_score, ai_enhanced, created_at, updated_at
+			last_win_time, tags, complexity_score, risk_score, ai_enhanced, prompt_template, prompt_version, source,
+			created_at, updated_at
 		FROM strategies 
 		WHERE id = $1
 	`
@@ -89,6 +94,9 @@ func (r *StrategyRepository) GetByID(id int64) (*models.Strategy, error) {
 		&strategy.ComplexityScore,
 		&strategy.RiskScore,
 		&strategy.AIEnhanced,
+		&strategy.PromptTemplate,
+		&strategy.PromptVersion,
+		&strategy.Source,
 		&strategy.CreatedAt,
 		&strategy.UpdatedAt,
 	)
@@ -111,7 +119,8 @@ func (r *StrategyRepository) GetByID(id int64) (*models.Strategy, error) {
 func (r *StrategyRepository) ListPublic(limit, offset int) ([]*models.Strategy, error) {
 	query := `
 		SELECT id, name, description, config, is_public, vote_count, win_count, 
-			last_win_time, tags, complexity_score, risk_score, ai_enhanced, created_at, updated_at
+			last_win_time, tags, complexity_score, risk_score, ai_enhanced, prompt_template, prompt_version, source,
+			created_at, updated_at
 		FROM strategies 
 		WHERE is_public = true
 		ORDER BY updated_at DESC
@@ -131,14 +140,15 @@ func (r *StrategyRepository) ListPublic(limit, offset int) ([]*models.Strategy,
 	return r.scanStrategyRows(rows)
 }
 
-// Update updates an existing strategy
+// Update updates an existing strategy. Its source never changes.
 func (r *StrategyRepository) Update(strategy *models.Strategy) error {
 	query := `
 		UPDATE strategies 
         SET name = $1, description = $2, config = $3, is_public = $4, 
             vote_count = $5, win_count = $6, last_win_time = $7, tags = $8, 
-            complexity_score = $9, risk_score = $10, ai_enhanced = $11, updated_at = $12
-        WHERE id = $13
+            complexity_score = $9, risk_score = $10, ai_enhanced = $11,
+            prompt_template = $12, prompt_version = $13, updated_at = $14
+        WHERE id = $15
 	`
 
 	strategy.UpdatedAt = time.Now()
@@ -156,6 +166,8 @@ func (r *StrategyRepository) Update(strategy *models.Strategy) error {
 		strategy.ComplexityScore,
 		strategy.RiskScore,
 		strategy.AIEnhanced,
+		strategy.PromptTemplate,
+		strategy.PromptVersion,
 		strategy.UpdatedAt,
 		strategy.ID,
 	)
@@ -251,7 +263,8 @@ func (r *StrategyRepository) IncrementWinCount(id int64, winTime time.Time) erro
 func (r *StrategyRepository) SearchByTags(tags []string, limit int) ([]*models.Strategy, error) {
 	query := `
 		SELECT id, name, description, config, is_public, vote_count, win_count, 
-			last_win_time, tags, complexity_score, risk_score, ai_enhanced, created_at, updated_at
+			last_win_time, tags, complexity_score, risk_score, ai_enhanced, prompt_template, prompt_version, source,
+			created_at, updated_at
 		FROM strategies 
 		WHERE is_public = true AND tags && $1
 		ORDER BY updated_at DESC
@@ -275,7 +288,8 @@ func (r *StrategyRepository) SearchByTags(tags []string, limit int) ([]*models.S
 func (r *StrategyRepository) GetTopVoted(limit int) ([]*models.Strategy, error) {
 	query := `
 		SELECT id, name, description, config, is_public, vote_count, win_count, 
-			last_win_time, tags, complexity_score, risk_score, ai_enhanced, created_at, updated_at
+			last_win_time, tags, complexity_score, risk_score, ai_enhanced, prompt_template, prompt_version, source,
+			created_at, updated_at
 		FROM strategies 
 		WHERE is_public = true
 		ORDER BY vote_count DESC, updated_at DESC
@@ -299,7 +313,8 @@ func (r *StrategyRepository) GetTopVoted(limit int) ([]*models.Strategy, error)
 func (r *StrategyRepository) GetTopWinners(limit int) ([]*models.Strategy, error) {
 	query := `
 		SELECT id, name, description, config, is_public, vote_count, win_count, 
-			last_win_time, tags, complexity_score, risk_score, ai_enhanced, created_at, updated_at
+			last_win_time, tags, complexity_score, risk_score, ai_enhanced, prompt_template, prompt_version, source,
+			created_at, updated_at
 		FROM strategies 
 		WHERE is_public = true
 		ORDER BY win_count DESC, updated_at DESC
@@ -340,6 +355,9 @@ func (r *StrategyRepository) scanStrategyRows(rows *sql.Rows) ([]*models.Strateg
 			&strategy.ComplexityScore,
 			&strategy.RiskScore,
 			&strategy.AIEnhanced,
+			&strategy.PromptTemplate,
+			&strategy.PromptVersion,
+			&strategy.Source,
 			&strategy.CreatedAt,
 			&strategy.UpdatedAt,
 		); err != nil {
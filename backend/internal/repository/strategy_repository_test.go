@@ -51,6 +51,9 @@ func TestStrategyRepositorySave(t *testing.T) {
 			strategy.ComplexityScore,
 			strategy.RiskScore,
 			strategy.AIEnhanced,
+			strategy.PromptTemplate,
+			strategy.PromptVersion,
+			strategy.Source,
 			sqlmock.AnyArg(), // CreatedAt as AnyArg
 			sqlmock.AnyArg(), // UpdatedAt as AnyArg
 		).
@@ -88,11 +91,11 @@ func TestStrategyRepositoryGetByID(t *testing.T) {
 	// Setup expected query and result
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "description", "config", "is_public", "vote_count", "win_count",
-		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "created_at", "updated_at",
+		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "prompt_template", "prompt_version", "source", "created_at", "updated_at",
 	}).
 		AddRow(
 			strategyID, "Test Strategy", "A test strategy", `{"key":"value"}`, true, 10, 5,
-			lastWinTime, pq.Array([]string{"test", "strategy"}), 5, 3, false, now, now,
+			lastWinTime, pq.Array([]string{"test", "strategy"}), 5, 3, false, "", 0, "user", now, now,
 		)
 
 	mock.ExpectQuery(`SELECT (.+) FROM strategies WHERE id = \$1`).
@@ -138,15 +141,15 @@ func TestStrategyRepositoryListPublic(t *testing.T) {
 	// Setup expected query and result
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "description", "config", "is_public", "vote_count", "win_count",
-		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "created_at", "updated_at",
+		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "prompt_template", "prompt_version", "source", "created_at", "updated_at",
 	}).
 		AddRow(
 			1, "Public Strategy 1", "First public strategy", `{"key":"value1"}`, true, 5, 2,
-			now, pq.Array([]string{"public", "first"}), 5, 3, false, now, now,
+			now, pq.Array([]string{"public", "first"}), 5, 3, false, "", 0, "user", now, now,
 		).
 		AddRow(
 			2, "Public Strategy 2", "Second public strategy", `{"key":"value2"}`, true, 10, 4,
-			now, pq.Array([]string{"public", "second"}), 7, 8, true, now, now,
+			now, pq.Array([]string{"public", "second"}), 7, 8, true, "", 0, "user", now, now,
 		)
 
 	mock.ExpectQuery(`SELECT (.+) FROM strategies WHERE is_public = true ORDER BY updated_at DESC LIMIT \$1 OFFSET \$2`).
@@ -213,6 +216,8 @@ func TestStrategyRepositoryUpdate(t *testing.T) {
 			strategy.ComplexityScore,
 			strategy.RiskScore,
 			strategy.AIEnhanced,
+			strategy.PromptTemplate,
+			strategy.PromptVersion,
 			sqlmock.AnyArg(), // updated_at changes
 			strategy.ID,
 		).
@@ -342,11 +347,11 @@ func TestStrategyRepositorySearchByTags(t *testing.T) {
 	// Setup expected query and result
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "description", "config", "is_public", "vote_count", "win_count",
-		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "created_at", "updated_at",
+		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "prompt_template", "prompt_version", "source", "created_at", "updated_at",
 	}).
 		AddRow(
 			1, "AI Trading Strategy", "Uses AI", `{"key":"value1"}`, true, 5, 2,
-			now, pq.Array([]string{"ai", "trading"}), 5, 3, true, now, now,
+			now, pq.Array([]string{"ai", "trading"}), 5, 3, true, "", 0, "user", now, now,
 		)
 
 	mock.ExpectQuery(`SELECT (.+) FROM strategies WHERE is_public = true AND tags && \$1 ORDER BY updated_at DESC LIMIT \$2`).
@@ -388,15 +393,15 @@ func TestStrategyRepositoryGetTopVoted(t *testing.T) {
 	// Setup expected query and result
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "description", "config", "is_public", "vote_count", "win_count",
-		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "created_at", "updated_at",
+		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "prompt_template", "prompt_version", "source", "created_at", "updated_at",
 	}).
 		AddRow(
 			1, "Popular Strategy", "Most votes", `{"key":"value1"}`, true, 50, 5,
-			now, pq.Array([]string{"popular"}), 5, 3, false, now, now,
+			now, pq.Array([]string{"popular"}), 5, 3, false, "", 0, "user", now, now,
 		).
 		AddRow(
 			2, "Second Popular", "Second most votes", `{"key":"value2"}`, true, 30, 3,
-			now, pq.Array([]string{"popular"}), 4, 5, false, now, now,
+			now, pq.Array([]string{"popular"}), 4, 5, false, "", 0, "user", now, now,
 		)
 
 	mock.ExpectQuery(`SELECT (.+) FROM strategies WHERE is_public = true ORDER BY vote_count DESC, updated_at DESC LIMIT \$1`).
@@ -439,15 +444,15 @@ func TestStrategyRepositoryGetTopWinners(t *testing.T) {
 	// Setup expected query and result
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "description", "config", "is_public", "vote_count", "win_count",
-		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "created_at", "updated_at",
+		"last_win_time", "tags", "complexity_score", "risk_score", "ai_enhanced", "prompt_template", "prompt_version", "source", "created_at", "updated_at",
 	}).
 		AddRow(
 			1, "Winning Strategy", "Most wins", `{"key":"value1"}`, true, 20, 15,
-			now, pq.Array([]string{"winning"}), 5, 3, false, now, now,
+			now, pq.Array([]string{"winning"}), 5, 3, false, "", 0, "user", now, now,
 		).
 		AddRow(
 			2, "Second Winner", "Second most wins", `{"key":"value2"}`, true, 15, 10,
-			now, pq.Array([]string{"winning"}), 4, 5, false, now, now,
+			now, pq.Array([]string{"winning"}), 4, 5, false, "", 0, "user", now, now,
 		)
 
 	mock.ExpectQuery(`SELECT (.+) FROM strategies WHERE is_public = true ORDER BY win_count DESC, updated_at DESC LIMIT \$1`).
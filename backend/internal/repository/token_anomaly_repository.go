@@ -0,0 +1,97 @@
+// internal/repository/token_anomaly_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// TokenAnomalyRepository handles database operations for per-token trade anomaly scores
+type TokenAnomalyRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewTokenAnomalyRepository creates a new token anomaly repository
+func NewTokenAnomalyRepository(db *sql.DB) *TokenAnomalyRepository {
+	return &TokenAnomalyRepository{db: db}
+}
+
+const upsertTokenAnomaliesQuery = `
+	INSERT INTO token_anomalies
+		(token_id, trade_count, buy_count, self_trade_count, round_trip_wallets, dust_buy_count, dust_threshold_sol,
+		 periodic_wallets, flagged_wallet_count, flagged_buy_pct, flagged_volume_pct, anomaly_score, flagged_wallets, updated_at)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW())
+	ON CONFLICT (token_id)
+	DO UPDATE SET
+		trade_count = $2,
+		buy_count = $3,
+		self_trade_count = $4,
+		round_trip_wallets = $5,
+		dust_buy_count = $6,
+		dust_threshold_sol = $7,
+		periodic_wallets = $8,
+		flagged_wallet_count = $9,
+		flagged_buy_pct = $10,
+		flagged_volume_pct = $11,
+		anomaly_score = $12,
+		flagged_wallets = $13,
+		updated_at = NOW()
+`
+
+// UpsertBatch inserts or replaces the anomaly scores of several tokens in one transaction
+func (r *TokenAnomalyRepository) UpsertBatch(anomalies []*models.TokenAnomalies) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting token anomaly transaction: %v", err)
+	}
+
+	stmt, err := tx.Prepare(upsertTokenAnomaliesQuery)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error preparing token anomaly upsert: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, a := range anomalies {
+		flagged, err := json.Marshal(a.FlaggedWallets)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("error encoding flagged wallets: %v", err)
+		}
+
+		if _, err := stmt.Exec(
+			a.TokenID,
+			a.TradeCount,
+			a.BuyCount,
+			a.SelfTradeCount,
+			a.RoundTripWallets,
+			a.DustBuyCount,
+			a.DustThresholdSol,
+			a.PeriodicWallets,
+			a.FlaggedWalletCount,
+			a.FlaggedBuyPct,
+			a.FlaggedVolumePct,
+			a.AnomalyScore,
+			flagged,
+		); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("error saving token anomalies: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing token anomalies: %v", err)
+	}
+
+	return nil
+}
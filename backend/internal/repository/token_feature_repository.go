@@ -0,0 +1,118 @@
+// internal/repository/token_feature_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// TokenFeatureRepository handles database operations for token feature snapshots
+type TokenFeatureRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewTokenFeatureRepository creates a new token feature repository
+func NewTokenFeatureRepository(db *sql.DB) *TokenFeatureRepository {
+	return &TokenFeatureRepository{db: db}
+}
+
+// SaveSnapshot inserts a feature snapshot taken at a strategy decision
+func (r *TokenFeatureRepository) SaveSnapshot(snapshot *models.TokenFeatureSnapshot) (int64, error) {
+	features, err := json.Marshal(snapshot.Features)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding token features: %v", err)
+	}
+
+	query := `
+		INSERT INTO token_feature_snapshots
+			(token_id, strategy_id, simulation_run_id, simulated_trade_id, decision, features, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = time.Now()
+	}
+
+	var id int64
+	err = r.db.QueryRow(
+		query,
+		snapshot.TokenID,
+		snapshot.StrategyID,
+		snapshot.SimulationRunID,
+		snapshot.SimulatedTradeID,
+		snapshot.Decision,
+		features,
+		snapshot.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving token feature snapshot: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetSnapshotsBySimulationRun retrieves the feature snapshots taken during a simulation run
+func (r *TokenFeatureRepository) GetSnapshotsBySimulationRun(simulationRunID int64) ([]*models.TokenFeatureSnapshot, error) {
+	query := `
+		SELECT id, token_id, strategy_id, simulation_run_id, simulated_trade_id, decision, features, created_at
+		FROM token_feature_snapshots
+		WHERE simulation_run_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(query, simulationRunID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token feature snapshots: %v", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.TokenFeatureSnapshot
+	for rows.Next() {
+		var snapshot models.TokenFeatureSnapshot
+		var strategyID, runID, tradeID sql.NullInt64
+		var features []byte
+
+		if err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.TokenID,
+			&strategyID,
+			&runID,
+			&tradeID,
+			&snapshot.Decision,
+			&features,
+			&snapshot.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning token feature snapshot row: %v", err)
+		}
+
+		if strategyID.Valid {
+			snapshot.StrategyID = &strategyID.Int64
+		}
+		if runID.Valid {
+			snapshot.SimulationRunID = &runID.Int64
+		}
+		if tradeID.Valid {
+			snapshot.SimulatedTradeID = &tradeID.Int64
+		}
+		if err := json.Unmarshal(features, &snapshot.Features); err != nil {
+			return nil, fmt.Errorf("error decoding token features: %v", err)
+		}
+
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating token feature snapshot rows: %v", err)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,239 @@
+// internal/repository/token_outcome_repository.go
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// TokenOutcomeRepository handles database operations for token outcome labels
+type TokenOutcomeRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewTokenOutcomeRepository creates a new token outcome repository
+func NewTokenOutcomeRepository(db *sql.DB) *TokenOutcomeRepository {
+	return &TokenOutcomeRepository{db: db}
+}
+
+// Upsert inserts or replaces the outcome labels of a token
+func (r *TokenOutcomeRepository) Upsert(outcome *models.TokenOutcome) error {
+	query := `
+		INSERT INTO token_outcomes
+			(token_id, launch_timestamp, base_price, trade_count, max_return_1m_pct, max_return_5m_pct,
+			 max_return_30m_pct, max_drawdown_pct, graduated, time_to_graduate_sec, labeled_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT (token_id)
+		DO UPDATE SET
+			launch_timestamp = $2,
+			base_price = $3,
+			trade_count = $4,
+			max_return_1m_pct = $5,
+			max_return_5m_pct = $6,
+			max_return_30m_pct = $7,
+			max_drawdown_pct = $8,
+			graduated = $9,
+			time_to_graduate_sec = $10,
+			labeled_at = NOW()
+		RETURNING labeled_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		outcome.TokenID,
+		outcome.LaunchTimestamp,
+		outcome.BasePrice,
+		outcome.TradeCount,
+		outcome.MaxReturn1mPct,
+		outcome.MaxReturn5mPct,
+		outcome.MaxReturn30mPct,
+		outcome.MaxDrawdownPct,
+		outcome.Graduated,
+		outcome.TimeToGraduateSec,
+	).Scan(&outcome.LabeledAt)
+
+	if err != nil {
+		return fmt.Errorf("error saving token outcome: %v", err)
+	}
+
+	return nil
+}
+
+// GetUnlabeledTokens retrieves tokens created between fromMs and toMs (unix ms) that have
+// no stored outcome, oldest first
+func (r *TokenOutcomeRepository) GetUnlabeledTokens(fromMs, toMs int64, limit int) ([]*models.Token, error) {
+	query := `
+		SELECT t.id, t.mint_address, t.created_timestamp, t.completed
+		FROM tokens t
+		LEFT JOIN token_outcomes o ON o.token_id = t.id
+		WHERE t.created_timestamp >= $1 AND t.created_timestamp < $2 AND o.token_id IS NULL
+		ORDER BY t.created_timestamp ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, fromMs, toMs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting unlabeled tokens: %v", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.Token
+	for rows.Next() {
+		var token models.Token
+		if err := rows.Scan(&token.ID, &token.MintAddress, &token.CreatedTimestamp, &token.Completed); err != nil {
+			return nil, fmt.Errorf("error scanning unlabeled token row: %v", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unlabeled token rows: %v", err)
+	}
+
+	return tokens, nil
+}
+
+// GetOutcomeTrades retrieves the trades of a token between fromSec and untilSec (unix
+// seconds) in execution order
+func (r *TokenOutcomeRepository) GetOutcomeTrades(tokenID int64, fromSec, untilSec int64, limit int) ([]*models.Trade, error) {
+	query := `
+		SELECT id, token_id, signature, sol_amount, token_amount, is_buy, user_address, timestamp
+		FROM trades
+		WHERE token_id = $1 AND timestamp >= $2 AND timestamp <= $3
+		ORDER BY timestamp ASC, id ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(query, tokenID, fromSec, untilSec, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting outcome trades: %v", err)
+	}
+	defer rows.Close()
+
+	var trades []*models.Trade
+	for rows.Next() {
+		var trade models.Trade
+		if err := rows.Scan(
+			&trade.ID,
+			&trade.TokenID,
+			&trade.Signature,
+			&trade.SolAmount,
+			&trade.TokenAmount,
+			&trade.IsBuy,
+			&trade.UserAddress,
+			&trade.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning outcome trade row: %v", err)
+		}
+		trades = append(trades, &trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outcome trade rows: %v", err)
+	}
+
+	return trades, nil
+}
+
+// GetGraduatedAt returns when a token moved to the graduated lifecycle state in unix
+// seconds, or 0 when no graduation was recorded
+func (r *TokenOutcomeRepository) GetGraduatedAt(tokenID int64) (int64, error) {
+	query := `
+		SELECT COALESCE(MIN(timestamp), 0)
+		FROM token_transitions
+		WHERE token_id = $1 AND to_state = $2
+	`
+
+	var graduatedAt int64
+	if err := r.db.QueryRow(query, tokenID, models.TokenStateGraduated).Scan(&graduatedAt); err != nil {
+		return 0, fmt.Errorf("error getting graduation time: %v", err)
+	}
+	return graduatedAt, nil
+}
+
+// GetLabeledSnapshots retrieves feature snapshots taken between from and to whose tokens
+// have outcome labels, in insertion order after afterID
+func (r *TokenOutcomeRepository) GetLabeledSnapshots(from, to time.Time, afterID int64, limit int) ([]*models.LabeledFeatureSnapshot, error) {
+	query := `
+		SELECT s.id, s.token_id, t.mint_address, s.strategy_id, s.simulation_run_id, s.simulated_trade_id,
+			s.decision, s.features, s.created_at,
+			o.launch_timestamp, o.base_price, o.trade_count, o.max_return_1m_pct, o.max_return_5m_pct,
+			o.max_return_30m_pct, o.max_drawdown_pct, o.graduated, o.time_to_graduate_sec, o.labeled_at
+		FROM token_feature_snapshots s
+		JOIN token_outcomes o ON o.token_id = s.token_id
+		JOIN tokens t ON t.id = s.token_id
+		WHERE s.created_at >= $1 AND s.created_at < $2 AND s.id > $3
+		ORDER BY s.id ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(query, from, to, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting labeled snapshots: %v", err)
+	}
+	defer rows.Close()
+
+	var labeled []*models.LabeledFeatureSnapshot
+	for rows.Next() {
+		var snapshot models.TokenFeatureSnapshot
+		var outcome models.TokenOutcome
+		var strategyID, runID, tradeID, timeToGraduate sql.NullInt64
+		var features []byte
+
+		if err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.TokenID,
+			&outcome.MintAddress,
+			&strategyID,
+			&runID,
+			&tradeID,
+			&snapshot.Decision,
+			&features,
+			&snapshot.CreatedAt,
+			&outcome.LaunchTimestamp,
+			&outcome.BasePrice,
+			&outcome.TradeCount,
+			&outcome.MaxReturn1mPct,
+			&outcome.MaxReturn5mPct,
+			&outcome.MaxReturn30mPct,
+			&outcome.MaxDrawdownPct,
+			&outcome.Graduated,
+			&timeToGraduate,
+			&outcome.LabeledAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning labeled snapshot row: %v", err)
+		}
+
+		if strategyID.Valid {
+			snapshot.StrategyID = &strategyID.Int64
+		}
+		if runID.Valid {
+			snapshot.SimulationRunID = &runID.Int64
+		}
+		if tradeID.Valid {
+			snapshot.SimulatedTradeID = &tradeID.Int64
+		}
+		if timeToGraduate.Valid {
+			outcome.TimeToGraduateSec = &timeToGraduate.Int64
+		}
+		if err := json.Unmarshal(features, &snapshot.Features); err != nil {
+			return nil, fmt.Errorf("error decoding token features: %v", err)
+		}
+		outcome.TokenID = snapshot.TokenID
+
+		labeled = append(labeled, &models.LabeledFeatureSnapshot{Snapshot: &snapshot, Outcome: &outcome})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labeled snapshot rows: %v", err)
+	}
+
+	return labeled, nil
+}
@@ -21,7 +21,8 @@ func NewTokenRepository(db *sql.DB) *TokenRepository {
 	return &TokenRepository{db: db}
 }
 
-// Save inserts or updates a token in the database
+// Save inserts or updates a token in the database. Graduation and the king of the hill
+// timestamp are sticky: an update never clears them once set.
 func (r *TokenRepository) Save(token *models.Token) (int64, error) {
 	query := `
 		INSERT INTO tokens 
@@ -41,8 +42,11 @@ func (r *TokenRepository) Save(token *models.Token) (int64, error) {
 			created_timestamp = $10, 
 			market_cap = $11,
 			usd_market_cap = $12,
-			completed = $13,
-			king_of_the_hill_timestamp = $14
+			completed = tokens.completed OR $13,
+			king_of_the_hill_timestamp = CASE
+				WHEN COALESCE(tokens.king_of_the_hill_timestamp, 0) > 0 THEN tokens.king_of_the_hill_timestamp
+				ELSE $14
+			END
 		RETURNING id
 	`
 
@@ -0,0 +1,165 @@
+// internal/repository/token_transition_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+)
+
+// TokenTransitionRepository handles database operations for token lifecycle transitions
+type TokenTransitionRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewTokenTransitionRepository creates a new token transition repository
+func NewTokenTransitionRepository(db *sql.DB) *TokenTransitionRepository {
+	return &TokenTransitionRepository{db: db}
+}
+
+// Save inserts a lifecycle transition into the database
+func (r *TokenTransitionRepository) Save(transition *models.TokenTransition) (int64, error) {
+	query := `
+		INSERT INTO token_transitions
+			(token_id, from_state, to_state, timestamp, source, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	if transition.CreatedAt.IsZero() {
+		transition.CreatedAt = time.Now()
+	}
+
+	var id int64
+	err := r.db.QueryRow(
+		query,
+		transition.TokenID,
+		transition.FromState,
+		transition.ToState,
+		transition.Timestamp,
+		transition.Source,
+		transition.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("error saving token transition: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetByTokenID retrieves the transitions of a token in the order they happened
+func (r *TokenTransitionRepository) GetByTokenID(tokenID int64) ([]*models.TokenTransition, error) {
+	query := `
+		SELECT l.id, l.token_id, t.mint_address, l.from_state, l.to_state, l.timestamp, l.source, l.created_at
+		FROM token_transitions l
+		JOIN tokens t ON t.id = l.token_id
+		WHERE l.token_id = $1
+		ORDER BY l.id ASC
+	`
+
+	rows, err := r.db.Query(query, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token transitions: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransitionRows(rows)
+}
+
+// GetAfterID retrieves transitions inserted after the given ID in insertion order
+func (r *TokenTransitionRepository) GetAfterID(afterID int64, limit int) ([]*models.TokenTransition, error) {
+	query := `
+		SELECT l.id, l.token_id, t.mint_address, l.from_state, l.to_state, l.timestamp, l.source, l.created_at
+		FROM token_transitions l
+		JOIN tokens t ON t.id = l.token_id
+		WHERE l.id > $1
+		ORDER BY l.id ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token transitions after id: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransitionRows(rows)
+}
+
+// GetLastID returns the ID of the most recent transition, or 0 when there are none
+func (r *TokenTransitionRepository) GetLastID() (int64, error) {
+	var id int64
+	if err := r.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM token_transitions`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("error getting last token transition id: %v", err)
+	}
+	return id, nil
+}
+
+// GetStale retrieves the latest transition of tokens that are still live (created, trading
+// or king of the hill) but have not changed state or traded since the cutoff
+func (r *TokenTransitionRepository) GetStale(cutoff int64, limit int) ([]*models.TokenTransition, error) {
+	query := `
+		SELECT l.id, l.token_id, t.mint_address, l.from_state, l.to_state, l.timestamp, l.source, l.created_at
+		FROM (
+			SELECT DISTINCT ON (token_id) id, token_id, from_state, to_state, timestamp, source, created_at
+			FROM token_transitions
+			ORDER BY token_id, id DESC
+		) l
+		JOIN tokens t ON t.id = l.token_id
+		WHERE l.to_state IN ($1, $2, $3)
+			AND l.timestamp < $4
+			AND NOT EXISTS (
+				SELECT 1 FROM trades tr WHERE tr.token_id = l.token_id AND tr.timestamp >= $4
+			)
+		ORDER BY l.token_id
+		LIMIT $5
+	`
+
+	rows, err := r.db.Query(
+		query,
+		models.TokenStateCreated,
+		models.TokenStateTrading,
+		models.TokenStateKingOfTheHill,
+		cutoff,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting stale tokens: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransitionRows(rows)
+}
+
+// scanTransitionRows scans token transition rows
+func (r *TokenTransitionRepository) scanTransitionRows(rows *sql.Rows) ([]*models.TokenTransition, error) {
+	var transitions []*models.TokenTransition
+	for rows.Next() {
+		var transition models.TokenTransition
+		if err := rows.Scan(
+			&transition.ID,
+			&transition.TokenID,
+			&transition.MintAddress,
+			&transition.FromState,
+			&transition.ToState,
+			&transition.Timestamp,
+			&transition.Source,
+			&transition.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning token transition row: %v", err)
+		}
+		transitions = append(transitions, &transition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating token transition rows: %v", err)
+	}
+
+	return transitions, nil
+}
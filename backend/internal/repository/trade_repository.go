@@ -137,3 +137,124 @@ func (r *TradeRepository) GetTradesBySignature(signature string) (*models.Trade,
 
 	return &trade, nil
 }
+
+// GetTradesAfterID retrieves trades with an ID greater than afterID, oldest first,
+// including the mint address of the traded token
+func (r *TradeRepository) GetTradesAfterID(afterID int64, limit int) ([]*models.Trade, error) {
+	query := `
+		SELECT t.id, t.token_id, tk.mint_address, t.signature, t.sol_amount, t.token_amount, t.is_buy, t.user_address, t.timestamp
+		FROM trades t
+		JOIN tokens tk ON tk.id = t.token_id
+		WHERE t.id > $1
+		ORDER BY t.id ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting trades after id: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanTradesWithMint(rows)
+}
+
+// GetTradesByTimeRange retrieves trades with timestamps in [from, to) and an ID greater
+// than afterID, ordered by ID so callers can page through large ranges
+func (r *TradeRepository) GetTradesByTimeRange(from, to int64, afterID int64, limit int) ([]*models.Trade, error) {
+	query := `
+		SELECT t.id, t.token_id, tk.mint_address, t.signature, t.sol_amount, t.token_amount, t.is_buy, t.user_address, t.timestamp
+		FROM trades t
+		JOIN tokens tk ON tk.id = t.token_id
+		WHERE t.timestamp >= $1 AND t.timestamp < $2 AND t.id > $3
+		ORDER BY t.id ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(query, from, to, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting trades by time range: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanTradesWithMint(rows)
+}
+
+// GetLastTradeIDBefore returns the highest trade ID with a timestamp before the given one
+func (r *TradeRepository) GetLastTradeIDBefore(timestamp int64) (int64, error) {
+	query := `SELECT COALESCE(MAX(id), 0) FROM trades WHERE timestamp < $1`
+
+	var id int64
+	if err := r.db.QueryRow(query, timestamp).Scan(&id); err != nil {
+		return 0, fmt.Errorf("error getting last trade id: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetHolderBalances returns every wallet's net token balance in a token along with the
+// highest trade ID included, so live updates can resume after it
+func (r *TradeRepository) GetHolderBalances(tokenID int64) ([]*models.HolderBalance, int64, error) {
+	query := `
+		SELECT user_address,
+			SUM(CASE WHEN is_buy THEN token_amount ELSE -token_amount END),
+			MAX(id)
+		FROM trades
+		WHERE token_id = $1
+		GROUP BY user_address
+	`
+
+	rows, err := r.db.Query(query, tokenID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting holder balances: %v", err)
+	}
+	defer rows.Close()
+
+	var balances []*models.HolderBalance
+	var lastID int64
+	for rows.Next() {
+		var balance models.HolderBalance
+		var maxID int64
+		if err := rows.Scan(&balance.WalletAddress, &balance.Balance, &maxID); err != nil {
+			return nil, 0, fmt.Errorf("error scanning holder balance row: %v", err)
+		}
+		if maxID > lastID {
+			lastID = maxID
+		}
+		balances = append(balances, &balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating holder balance rows: %v", err)
+	}
+
+	return balances, lastID, nil
+}
+
+// scanTradesWithMint scans trade rows that include the token mint address
+func (r *TradeRepository) scanTradesWithMint(rows *sql.Rows) ([]*models.Trade, error) {
+	var trades []*models.Trade
+	for rows.Next() {
+		var trade models.Trade
+		if err := rows.Scan(
+			&trade.ID,
+			&trade.TokenID,
+			&trade.MintAddress,
+			&trade.Signature,
+			&trade.SolAmount,
+			&trade.TokenAmount,
+			&trade.IsBuy,
+			&trade.UserAddress,
+			&trade.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning trade row: %v", err)
+		}
+		trades = append(trades, &trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trade rows: %v", err)
+	}
+
+	return trades, nil
+}
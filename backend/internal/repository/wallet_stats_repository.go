@@ -0,0 +1,283 @@
+// internal/repository/wallet_stats_repository.go
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// WalletStatsRepository handles database operations for wallet trading statistics
+type WalletStatsRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewWalletStatsRepository creates a new wallet stats repository
+func NewWalletStatsRepository(db *sql.DB) *WalletStatsRepository {
+	return &WalletStatsRepository{db: db}
+}
+
+const upsertWalletStatsQuery = `
+	INSERT INTO wallet_stats
+		(wallet_address, trade_count, buy_count, sell_count, tokens_traded, closed_positions, winning_positions,
+		 hit_rate, realized_pnl_sol, volume_sol, smart_score, last_trade_at, updated_at)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+	ON CONFLICT (wallet_address)
+	DO UPDATE SET
+		trade_count = $2,
+		buy_count = $3,
+		sell_count = $4,
+		tokens_traded = $5,
+		closed_positions = $6,
+		winning_positions = $7,
+		hit_rate = $8,
+		realized_pnl_sol = $9,
+		volume_sol = $10,
+		smart_score = $11,
+		last_trade_at = $12,
+		updated_at = NOW()
+`
+
+const walletStatsColumns = `
+	wallet_address, trade_count, buy_count, sell_count, tokens_traded, closed_positions, winning_positions,
+	hit_rate, realized_pnl_sol, volume_sol, smart_score, rank, is_smart, COALESCE(last_trade_at, 0), updated_at
+`
+
+// UpsertBatch inserts or replaces the stats of several wallets in one transaction.
+// Rank and smart flag are left to UpdateRanks.
+func (r *WalletStatsRepository) UpsertBatch(stats []*models.WalletStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting wallet stats transaction: %v", err)
+	}
+
+	stmt, err := tx.Prepare(upsertWalletStatsQuery)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error preparing wallet stats upsert: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, s := range stats {
+		if _, err := stmt.Exec(
+			s.WalletAddress,
+			s.TradeCount,
+			s.BuyCount,
+			s.SellCount,
+			s.TokensTraded,
+			s.ClosedPositions,
+			s.WinningPositions,
+			s.HitRate,
+			s.RealizedPnlSol,
+			s.VolumeSol,
+			s.SmartScore,
+			s.LastTradeAt,
+		); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("error saving wallet stats: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing wallet stats: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateRanks ranks all wallets with a positive smart score and flags the top smartCount
+// as smart wallets
+func (r *WalletStatsRepository) UpdateRanks(smartCount int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting wallet rank transaction: %v", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE wallet_stats SET rank = 0, is_smart = FALSE WHERE rank <> 0 OR is_smart`); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error resetting wallet ranks: %v", err)
+	}
+
+	query := `
+		UPDATE wallet_stats w
+		SET rank = ranked.rn, is_smart = ranked.rn <= $1
+		FROM (
+			SELECT wallet_address, ROW_NUMBER() OVER (ORDER BY smart_score DESC, realized_pnl_sol DESC) AS rn
+			FROM wallet_stats
+			WHERE smart_score > 0
+		) ranked
+		WHERE w.wallet_address = ranked.wallet_address
+	`
+	if _, err := tx.Exec(query, smartCount); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error updating wallet ranks: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing wallet ranks: %v", err)
+	}
+
+	return nil
+}
+
+// GetByAddress retrieves the stats of a wallet
+func (r *WalletStatsRepository) GetByAddress(walletAddress string) (*models.WalletStats, error) {
+	query := `SELECT ` + walletStatsColumns + ` FROM wallet_stats WHERE wallet_address = $1`
+
+	rows, err := r.db.Query(query, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wallet stats: %v", err)
+	}
+	defer rows.Close()
+
+	stats, err := r.scanStatsRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+
+	return stats[0], nil
+}
+
+// GetSmartWallets retrieves the ranked smart-wallet list
+func (r *WalletStatsRepository) GetSmartWallets(limit int) ([]*models.WalletStats, error) {
+	query := `SELECT ` + walletStatsColumns + ` FROM wallet_stats WHERE is_smart = TRUE ORDER BY rank ASC LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting smart wallets: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanStatsRows(rows)
+}
+
+// GetActiveWallets retrieves distinct wallets that traded at or after sinceSec, ordered by
+// address and paged with afterAddress
+func (r *WalletStatsRepository) GetActiveWallets(sinceSec int64, afterAddress string, limit int) ([]string, error) {
+	query := `
+		SELECT DISTINCT user_address
+		FROM trades
+		WHERE timestamp >= $1 AND user_address > $2
+		ORDER BY user_address ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, sinceSec, afterAddress, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting active wallets: %v", err)
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("error scanning wallet address row: %v", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wallet address rows: %v", err)
+	}
+
+	return addresses, nil
+}
+
+// GetPositions aggregates the trades of the given wallets per token
+func (r *WalletStatsRepository) GetPositions(walletAddresses []string) ([]*models.WalletPosition, error) {
+	query := `
+		SELECT tr.user_address, tr.token_id,
+			COALESCE(SUM(CASE WHEN tr.is_buy THEN tr.sol_amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN tr.is_buy THEN tr.token_amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN tr.is_buy THEN 0 ELSE tr.sol_amount END), 0),
+			COALESCE(SUM(CASE WHEN tr.is_buy THEN 0 ELSE tr.token_amount END), 0),
+			COUNT(*) FILTER (WHERE tr.is_buy),
+			COUNT(*) FILTER (WHERE NOT tr.is_buy),
+			MAX(tr.timestamp),
+			BOOL_OR(t.creator_address = tr.user_address)
+		FROM trades tr
+		JOIN tokens t ON t.id = tr.token_id
+		WHERE tr.user_address = ANY($1)
+		GROUP BY tr.user_address, tr.token_id
+		ORDER BY tr.user_address, tr.token_id
+	`
+
+	rows, err := r.db.Query(query, pq.Array(walletAddresses))
+	if err != nil {
+		return nil, fmt.Errorf("error getting wallet positions: %v", err)
+	}
+	defer rows.Close()
+
+	var positions []*models.WalletPosition
+	for rows.Next() {
+		var position models.WalletPosition
+		if err := rows.Scan(
+			&position.WalletAddress,
+			&position.TokenID,
+			&position.BuySol,
+			&position.BuyTokens,
+			&position.SellSol,
+			&position.SellTokens,
+			&position.BuyCount,
+			&position.SellCount,
+			&position.LastTradeAt,
+			&position.IsCreator,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning wallet position row: %v", err)
+		}
+		positions = append(positions, &position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wallet position rows: %v", err)
+	}
+
+	return positions, nil
+}
+
+// scanStatsRows scans wallet stats rows
+func (r *WalletStatsRepository) scanStatsRows(rows *sql.Rows) ([]*models.WalletStats, error) {
+	var stats []*models.WalletStats
+	for rows.Next() {
+		var s models.WalletStats
+		if err := rows.Scan(
+			&s.WalletAddress,
+			&s.TradeCount,
+			&s.BuyCount,
+			&s.SellCount,
+			&s.TokensTraded,
+			&s.ClosedPositions,
+			&s.WinningPositions,
+			&s.HitRate,
+			&s.RealizedPnlSol,
+			&s.VolumeSol,
+			&s.SmartScore,
+			&s.Rank,
+			&s.IsSmart,
+			&s.LastTradeAt,
+			&s.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning wallet stats row: %v", err)
+		}
+		stats = append(stats, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wallet stats rows: %v", err)
+	}
+
+	return stats, nil
+}
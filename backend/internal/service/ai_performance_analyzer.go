@@ -26,6 +26,8 @@ type PerformanceReport struct {
 	NetPnL          float64                `json:"net_pnl"`
 	AvgTradeProfit  float64                `json:"avg_trade_profit"`
 	Analysis        string                 `json:"analysis"`
+	PromptTemplate  string                 `json:"prompt_template,omitempty"` // Prompt template of an AI analysis
+	PromptVersion   int                    `json:"prompt_version,omitempty"`
 	Rating          string                 `json:"rating"`
 	Metrics         map[string]interface{} `json:"metrics"`
 	GeneratedAt     time.Time              `json:"generated_at"`
@@ -43,6 +45,7 @@ type AIPerformanceAnalyzer struct {
 	aiService            *AIService
 	logger               *logger.Logger
 	analysisInterval     time.Duration
+	analysisPrompt       string // Analysis template the analyses are generated from
 	lastAnalysisTime     time.Time
 	activeAnalysis       bool
 	mu                   sync.Mutex
@@ -76,6 +79,7 @@ func NewAIPerformanceAnalyzer(
 		aiService:            aiService,
 		logger:               logger,
 		analysisInterval:     analysisInterval,
+		analysisPrompt:       cfg.Automation.AnalysisPrompt,
 		lastAnalysisTime:     time.Now(),
 		activeAnalysis:       false,
 	}
@@ -236,6 +240,8 @@ func (a *AIPerformanceAnalyzer) SaveAnalysisReport(report *PerformanceReport, we
 		MaxDrawdown:       report.MaxDrawdown,
 		PerformanceRating: report.Rating,
 		Analysis:          report.Analysis,
+		PromptTemplate:    report.PromptTemplate,
+		PromptVersion:     report.PromptVersion,
 		CreatedAt:         time.Now(),
 	}
 
@@ -258,6 +264,8 @@ func (a *AIPerformanceAnalyzer) SaveAnalysisReport(report *PerformanceReport, we
 			"max_drawdown":     report.MaxDrawdown,
 			"net_pnl":          report.NetPnL,
 			"avg_trade_profit": report.AvgTradeProfit,
+			"prompt_template":  report.PromptTemplate,
+			"prompt_version":   report.PromptVersion,
 		},
 		Timestamp: report.GeneratedAt,
 		CreatedAt: time.Now(),
@@ -447,10 +455,8 @@ func (a *AIPerformanceAnalyzer) generatePerformanceAnalysis(
 
 	// Use AI service to generate the analysis
 	analysis, err := a.aiService.GenerateAnalysis(
-		strategy.Name,
-		report.Metrics,
-		hasActiveTrades,
-		isThisStrategyActive,
+		a.analysisPrompt,
+		NewAnalysisPromptInput(strategy.Name, report.Metrics, hasActiveTrades, isThisStrategyActive),
 	)
 
 	// If AI analysis fails, fall back to a simple template-based analysis
@@ -512,8 +518,11 @@ func (a *AIPerformanceAnalyzer) generatePerformanceAnalysis(
 
 	// Log successful AI analysis
 	a.logger.Info("Generated AI analysis for strategy %d successfully", strategy.ID)
-	
-	return analysis
+
+	report.PromptTemplate = analysis.PromptTemplate
+	report.PromptVersion = analysis.PromptVersion
+
+	return analysis.Text
 }
 
 // getAllAIStrategies gets all AI-enhanced strategies
@@ -2,81 +2,145 @@
 package service
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"math/rand"
-	"net/http"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/StratWarsAI/strategy-wars/internal/models"
 	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
 	"github.com/StratWarsAI/strategy-wars/internal/repository"
-	"github.com/StratWarsAI/strategy-wars/pkg/utils"
 )
 
 // AIService handles integration with AI API for strategy generation
 type AIService struct {
-	apiKey          string
-	apiURL          string
-	httpClient      *http.Client
+	llm             LLMProvider
 	logger          *logger.Logger
 	strategyRepo    repository.StrategyRepositoryInterface
+	lineage         LineageRecorder
+	failureRepo     repository.AIGenerationFailureRepositoryInterface
+	admission       *StrategyAdmissionService
+	diversity       *StrategyDiversityService
+	feedback        *FeedbackContextBuilder
+	prompts         *PromptLibrary
+	autoGenPrompts  []string      // Strategy templates StartAutoGeneration cycles through
+	repairAttempts  int           // Follow-up requests allowed to fix a response that fails the strategy schema
 	autoGenInterval time.Duration // Interval between automatic strategy generation
 	lastGenTime     time.Time
 }
 
-// AIRequest represents a request to the AI API
-type AIRequest struct {
-	Model       string      `json:"model"`
-	Messages    []AIMessage `json:"messages"`
-	Temperature float64     `json:"temperature"`
-	MaxTokens   int         `json:"max_tokens"`
-}
-
-// AIMessage represents a message in the AI conversation
-type AIMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
-
-// AIResponse represents a response from the AI API
-type AIResponse struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	Created int64  `json:"created"`
-	Choices []struct {
-		Index   int `json:"index"`
-		Message struct {
-			Role    string `json:"role"`
-			Content string `json:"content"`
-		} `json:"message"`
-	} `json:"choices"`
+// optionalStrategyParams are strategy parameters the AI may set; they are validated like
+// the required ones when present and left unset otherwise
+var optionalStrategyParams = []StrategyPromptParam{
+	{"entrySignalType", "Entry signal to use: \"buy_count\" (default, uses minBuysForEntry), \"smart_money\" (enter when tracked profitable wallets buy), \"unique_buyers\" or \"organic_volume\" (ignore wash-trading, dust and bot buys), or \"king_of_the_hill\" (enter when the token becomes king of the hill) (string)"},
+	{"minSmartWalletBuys", "For smart_money entries, number of distinct smart wallets that must buy within entryTimeWindowSec (number, typically 1-5)"},
+	{"minUniqueBuyers", "For unique_buyers entries, number of distinct organic wallets that must buy within entryTimeWindowSec (number, typically 3-10)"},
+	{"minOrganicBuyVolumeSol", "For organic_volume entries, SOL bought by organic wallets within entryTimeWindowSec (number, typically 1-10)"},
+	{"minCreatorReputation", "Skip tokens whose creator wallet reputation score (0-100) is below this (number, typically 30-70)"},
+	{"maxCreatorLaunchesWithoutGraduation", "Skip tokens whose creator has launched more than this many tokens without any graduating (number, typically 2-5)"},
+	{"maxTop10ConcentrationPct", "Skip tokens whose ten largest holders own more than this percentage of supply (number, typically 20-50)"},
+	{"maxBundledSupplyPct", "Skip tokens where bundled or sniper launch wallets bought more than this percentage of supply (number, typically 10-30)"},
+	{"excludeLaunchBuyers", "Ignore buys from bundled and sniper launch wallets when counting minBuysForEntry (boolean)"},
+	{"maxAnomalyScore", "Skip tokens whose wash-trading and bot anomaly score (0-100) is above this (number, typically 20-50)"},
+	{"featureWindowSec", "Rolling window the feature filters use: 10, 30, 60 or 300 seconds, default 60 (number)"},
+	{"minNetFlowSol", "Skip tokens whose buy minus sell volume in SOL over the feature window is below this (number)"},
+	{"minPriceVelocityPct", "Skip tokens whose price rises slower than this percent per minute over the feature window (number)"},
+	{"maxVolatilityPct", "Skip tokens whose trade-to-trade price volatility over the feature window is above this percent (number)"},
+	{"maxSecondsSinceLastTrade", "Skip tokens that have not traded for longer than this many seconds (number)"},
+	{"exitOnCreatorSell", "Exit immediately when the token creator sells (boolean)"},
+	{"exitOnGraduation", "Exit as soon as the token graduates from the bonding curve (boolean)"},
+	{"exitOnTopHolderDumpPct", "Exit when one of the ten largest holders at entry sells at least this percentage of their holding (number, typically 30-80)"},
 }
 
 // NewAIService creates a new AI service
 func NewAIService(
-	apiKey string,
-	apiURL string,
+	llm LLMProvider,
 	strategyRepo repository.StrategyRepositoryInterface,
 	logger *logger.Logger,
 ) *AIService {
 	return &AIService{
-		apiKey: apiKey,
-		apiURL: apiURL,
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+		llm:             llm,
 		logger:          logger,
 		strategyRepo:    strategyRepo,
+		prompts:         DefaultPromptLibrary(),
+		autoGenPrompts:  []string{"momentum", "defensive"},
+		repairAttempts:  2,
 		autoGenInterval: 1 * time.Hour, // Generate strategies every hour
 		lastGenTime:     time.Now(),
 	}
 }
 
+// SetLineageRecorder sets where the parents of derived strategies are recorded
+func (s *AIService) SetLineageRecorder(recorder LineageRecorder) {
+	s.lineage = recorder
+}
+
+// SetFailureRepository sets where responses that never pass validation are recorded
+func (s *AIService) SetFailureRepository(repo repository.AIGenerationFailureRepositoryInterface) {
+	s.failureRepo = repo
+}
+
+// SetAdmission sets the admission pipeline generated strategies must pass before they are
+// saved, and whose recent rejections are shown in strategy prompts
+func (s *AIService) SetAdmission(admission *StrategyAdmissionService) {
+	s.admission = admission
+}
+
+// SetDiversity sets what generated strategies are checked against for near-duplicates when
+// no admission is set; admission does its own check
+func (s *AIService) SetDiversity(diversity *StrategyDiversityService) {
+	s.diversity = diversity
+}
+
+// SetFeedbackBuilder sets what summarizes recent simulation runs for evolution and
+// optimization prompts
+func (s *AIService) SetFeedbackBuilder(builder *FeedbackContextBuilder) {
+	s.feedback = builder
+}
+
+// buildFeedback summarizes recent simulation runs for a prompt, focused on strategyID unless
+// it is 0. Failures are logged and leave the prompt without feedback.
+func (s *AIService) buildFeedback(strategyID int64) string {
+	if s.feedback == nil {
+		return ""
+	}
+	feedback, err := s.feedback.Build(strategyID)
+	if err != nil {
+		s.logger.Error("Error building feedback context: %v", err)
+		return ""
+	}
+	return feedback
+}
+
+// SetPromptLibrary sets the templates prompts are rendered from
+func (s *AIService) SetPromptLibrary(library *PromptLibrary) {
+	s.prompts = library
+}
+
+// SetAutoGenerationPrompts sets the strategy templates StartAutoGeneration cycles through
+func (s *AIService) SetAutoGenerationPrompts(refs []string) {
+	if len(refs) > 0 {
+		s.autoGenPrompts = refs
+	}
+}
+
+// Prompts returns the templates prompts are rendered from
+func (s *AIService) Prompts() *PromptLibrary {
+	return s.prompts
+}
+
+// RecordLineage records that a saved strategy was derived from the given parents. Failures
+// are logged rather than returned so they never undo a saved strategy.
+func (s *AIService) RecordLineage(childID int64, parentIDs []int64, reason string) {
+	if s.lineage == nil || len(parentIDs) == 0 {
+		return
+	}
+	if err := s.lineage.RecordDerivation(childID, parentIDs, reason); err != nil {
+		s.logger.Error("Error recording lineage of strategy %d: %v", childID, err)
+	}
+}
+
 // StartAutoGeneration starts the automatic strategy generation process
 func (s *AIService) StartAutoGeneration(ctx context.Context) {
 	s.logger.Info("Starting automatic strategy generation service")
@@ -94,10 +158,8 @@ func (s *AIService) StartAutoGeneration(ctx context.Context) {
 			if time.Since(s.lastGenTime) >= s.autoGenInterval {
 				s.logger.Info("Generating new automatic strategies")
 
-				// Number of strategies to generate in each cycle
-				strategiesPerCycle := 2
-
-				for i := 0; i < strategiesPerCycle; i++ {
+				// Generate one strategy per configured prompt template
+				for i, templateRef := range s.autoGenPrompts {
 					// Get top performing strategies to learn from
 					topStrategies, err := s.GetTopPerformingStrategies()
 					if err != nil {
@@ -105,36 +167,29 @@ func (s *AIService) StartAutoGeneration(ctx context.Context) {
 						continue
 					}
 
-					// Create metadata with top strategies
-					metadata := map[string]interface{}{
-						"top_strategies": topStrategies,
-					}
-
-					// Generate strategy prompt based on index
-					var prompt string
-					if i == 0 {
-						prompt = "Generate a profitable trading strategy for cryptocurrency tokens focused on momentum and quick profitability"
-					} else {
-						prompt = "Generate a defensive trading strategy for cryptocurrency tokens with strong risk management"
-					}
-
 					// Generate new strategy
-					strategy, err := s.GenerateStrategy(prompt, metadata)
+					strategy, err := s.GenerateStrategyFromTemplate(
+						models.AIPurposeStrategyGeneration,
+						templateRef,
+						NewStrategyPromptInput("", topStrategies),
+					)
 					if err != nil {
-						s.logger.Error("Error generating strategy %d: %v", i+1, err)
+						s.logger.Error("Error generating strategy %d from prompt %s: %v", i+1, templateRef, err)
 						continue
 					}
 
 					// Add timestamp to make name unique
 					strategy.Name = fmt.Sprintf("%s (%s)", strategy.Name, time.Now().Format("20060102-1504"))
 
-					// Save the strategy
-					id, err := s.strategyRepo.Save(strategy)
+					// Save the strategy if it passes admission
+					id, err := s.AdmitStrategy(strategy, models.AIPurposeStrategyGeneration)
 					if err != nil {
 						s.logger.Error("Error saving generated strategy: %v", err)
 						continue
 					}
 
+					s.RecordLineage(id, TopStrategyIDs(topStrategies), "Generated from top performing strategies")
+
 					s.logger.Info("Successfully generated and saved new strategy %d with ID: %d", i+1, id)
 				}
 
@@ -206,241 +261,302 @@ func (s *AIService) GetTopPerformingStrategies() ([]map[string]interface{}, erro
 	return topStrategies, nil
 }
 
-// GenerateStrategy generates a new trading strategy using AI
+// TopStrategyIDs returns the IDs of strategies returned by GetTopPerformingStrategies
+func TopStrategyIDs(topStrategies []map[string]interface{}) []int64 {
+	var ids []int64
+	for _, strategy := range topStrategies {
+		if id, ok := strategy["id"].(int64); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// GenerateStrategy generates a new trading strategy using AI, accounted as scheduled
+// strategy generation
 func (s *AIService) GenerateStrategy(basePrompt string, metaData map[string]interface{}) (*models.Strategy, error) {
-	// Format prompt with metadata
-	prompt := s.formatPrompt(basePrompt, metaData)
+	return s.GenerateStrategyForPurpose(models.AIPurposeStrategyGeneration, basePrompt, metaData)
+}
+
+// GenerateStrategyForPurpose generates a new trading strategy using AI from the default
+// strategy template, accounting its calls to purpose
+func (s *AIService) GenerateStrategyForPurpose(purpose, basePrompt string, metaData map[string]interface{}) (*models.Strategy, error) {
+	topStrategies, _ := metaData["top_strategies"].([]map[string]interface{})
+	return s.GenerateStrategyFromTemplate(purpose, DefaultStrategyPrompt, NewStrategyPromptInput(basePrompt, topStrategies))
+}
 
-	// Create request
-	req := AIRequest{
-		Model: "gpt-4",
-		Messages: []AIMessage{
+// GenerateStrategyFromTemplate generates a new trading strategy using AI from the strategy
+// template templateRef, accounting its calls to purpose. The response must match
+// StrategyJSONSchema; when it doesn't, the validation errors are sent back to the model for
+// up to repairAttempts corrections before the strategy is rejected and the failure
+// recorded. The strategy records the template and version it was generated from. With
+// admission set, the most recent rejections are added to inputs that don't have any.
+func (s *AIService) GenerateStrategyFromTemplate(purpose, templateRef string, input StrategyPromptInput) (*models.Strategy, error) {
+	tmpl, err := s.prompts.Get(PromptKindStrategy, templateRef)
+	if err != nil {
+		return nil, err
+	}
+	if s.admission != nil && input.RecentRejections == nil {
+		rejections, err := s.admission.RecentRejections(maxPromptRejections)
+		if err != nil {
+			s.logger.Error("Error getting recent strategy rejections: %v", err)
+		}
+		input.RecentRejections = rejections
+	}
+	prompt, err := tmpl.Render(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create request; the provider's configured model is used
+	req := LLMRequest{
+		Purpose: purpose,
+		Messages: []LLMMessage{
 			{
 				Role:    "system",
-				Content: "You are a professional algorithmic trader specializing in creating strategies for cryptocurrency trading.",
+				Content: prompt.System,
 			},
 			{
 				Role:    "user",
-				Content: prompt,
+				Content: prompt.User,
 			},
 		},
 		Temperature: 0.7,
 		MaxTokens:   2000,
+		ResponseSchema: &LLMJSONSchema{
+			Name:   "strategy",
+			Schema: StrategyJSONSchema(),
+		},
 	}
 
-	// Execute request
-	response, err := s.executeRequest(req)
-	if err != nil {
-		return nil, fmt.Errorf("error executing ChatGPT request: %v", err)
-	}
-
-	// Parse response into strategy
-	strategy, err := s.parseStrategyResponse(response)
+	var strategy *models.Strategy
+	err = s.completeValidated(req, "Strategy", func(response *LLMResponse) []string {
+		parsed, err := s.parseStrategyResponse(response)
+		if err != nil {
+			return err.(*StrategyValidationError).Problems
+		}
+		strategy = parsed
+		return nil
+	}, strategyRepairPrompt, func(problems []string) error {
+		return &StrategyValidationError{Problems: problems}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error parsing ChatGPT response: %v", err)
+		return nil, err
 	}
 
+	strategy.PromptTemplate = tmpl.ID
+	strategy.PromptVersion = tmpl.Version
 	return strategy, nil
 }
 
-// formatPrompt creates a detailed prompt with metadata
-func (s *AIService) formatPrompt(basePrompt string, metaData map[string]interface{}) string {
-	// Format metadata into a structured prompt context
-	var contextBuilder strings.Builder
-
-	contextBuilder.WriteString(basePrompt)
-	contextBuilder.WriteString("\n\n")
-	contextBuilder.WriteString("You are tasked with creating a trading strategy for cryptocurrency tokens on the Strategy Wars platform. ")
-	contextBuilder.WriteString("The strategy should be designed to identify and trade tokens with high potential for price increase in short timeframes.\n\n")
-
-	contextBuilder.WriteString("Your strategy must include the following required parameters as a JSON object:\n\n")
-	contextBuilder.WriteString("1. name: A catchy name for your strategy (string)\n")
-	contextBuilder.WriteString("2. description: A brief description of how your strategy works (string)\n")
-	contextBuilder.WriteString("3. marketCapThreshold: The minimum market cap in USD for tokens to consider (number, typically between 5000-50000)\n")
-	contextBuilder.WriteString("4. minBuysForEntry: Minimum number of buy transactions required in the time window to trigger entry (number, typically 2-10)\n")
-	contextBuilder.WriteString("5. entryTimeWindowSec: Time window in seconds to count transactions for entry signal (number, typically 60-600)\n")
-	contextBuilder.WriteString("6. takeProfitPct: Percentage gain to trigger take profit (number, typically 10-100)\n")
-	contextBuilder.WriteString("7. stopLossPct: Percentage loss to trigger stop loss (number, typically 5-50)\n")
-	contextBuilder.WriteString("8. maxHoldTimeSec: Maximum time to hold a position in seconds (number, typically 30-3600)\n")
-	contextBuilder.WriteString("9. fixedPositionSizeSol: Fixed position size in SOL for each trade (number, typically 0.1-2)\n")
-	contextBuilder.WriteString("10. initialBalance: Starting balance in SOL (number, typically 10-100)\n\n")
-
-	contextBuilder.WriteString("Example strategy format:\n")
-	contextBuilder.WriteString(`{
-		"name": "Momentum Chaser",
-		"description": "This strategy looks for tokens with rapid buy activity as an indicator of positive momentum",
-		"marketCapThreshold": 7000,
-		"minBuysForEntry": 3,
-		"entryTimeWindowSec": 300,
-		"takeProfitPct": 50,
-		"stopLossPct": 30,
-		"maxHoldTimeSec": 60,
-		"fixedPositionSizeSol": 0.5,
-		"initialBalance": 10
-		}`)
-	contextBuilder.WriteString("\n\n")
-
-	// Add information about top performing strategies if available
-	if topStrategies, ok := metaData["top_strategies"].([]map[string]interface{}); ok && len(topStrategies) > 0 {
-		contextBuilder.WriteString("Here are some of our top performing strategies you can learn from:\n\n")
-
-		for i, strategy := range topStrategies {
-			if i >= 3 { // Limit to top 3
-				break
-			}
-
-			contextBuilder.WriteString(fmt.Sprintf("Strategy: %s\n", strategy["name"]))
-			contextBuilder.WriteString(fmt.Sprintf("Win Rate: %.2f%%\n", strategy["win_rate"]))
-
-			if params, ok := strategy["parameters"].(map[string]interface{}); ok {
-				contextBuilder.WriteString("Parameters:\n")
-
-				if val, ok := params["marketCapThreshold"].(float64); ok {
-					contextBuilder.WriteString(fmt.Sprintf("- Market Cap Threshold: $%.2f\n", val))
-				}
-
-				if val, ok := params["takeProfitPct"].(float64); ok {
-					contextBuilder.WriteString(fmt.Sprintf("- Take Profit: %.2f%%\n", val))
-				}
+// GenerateDraft writes a strategy draft from the draft template templateRef, accounted as
+// strategy drafting. The response must match DraftJSONSchema and explain every parameter it
+// sets; like generated strategies, invalid responses are sent back for up to
+// repairAttempts corrections before the failure is recorded. The returned draft has no ID,
+// revision or status yet.
+func (s *AIService) GenerateDraft(templateRef string, input DraftPromptInput) (*models.StrategyDraft, error) {
+	tmpl, err := s.prompts.Get(PromptKindDraft, templateRef)
+	if err != nil {
+		return nil, err
+	}
+	if input.Params == nil {
+		input.Params = draftPromptParams
+	}
+	prompt, err := tmpl.Render(input)
+	if err != nil {
+		return nil, err
+	}
 
-				if val, ok := params["stopLossPct"].(float64); ok {
-					contextBuilder.WriteString(fmt.Sprintf("- Stop Loss: %.2f%%\n", val))
-				}
-			}
+	// A low temperature keeps the draft faithful to the description
+	req := LLMRequest{
+		Purpose: models.AIPurposeStrategyDrafting,
+		Messages: []LLMMessage{
+			{
+				Role:    "system",
+				Content: prompt.System,
+			},
+			{
+				Role:    "user",
+				Content: prompt.User,
+			},
+		},
+		Temperature: 0.2,
+		MaxTokens:   3000,
+		ResponseSchema: &LLMJSONSchema{
+			Name:   "strategy_draft",
+			Schema: DraftJSONSchema(),
+		},
+	}
 
-			contextBuilder.WriteString("\n")
+	var draft *models.StrategyDraft
+	err = s.completeValidated(req, "Draft", func(response *LLMResponse) []string {
+		payload, err := decodeStrategyPayload(response.Content)
+		if err != nil {
+			return []string{err.Error()}
 		}
-	} else {
-		// If no top strategies, provide guidelines for creating a good strategy
-		contextBuilder.WriteString("Guidelines for creating an effective strategy:\n\n")
-		contextBuilder.WriteString("1. Balance risk and reward: Higher take profit levels should be paired with appropriate stop losses\n")
-		contextBuilder.WriteString("2. Consider market cap: Lower market cap tokens may have higher volatility but also higher potential returns\n")
-		contextBuilder.WriteString("3. Entry timing: The entry time window and minimum buys should capture meaningful momentum\n")
-		contextBuilder.WriteString("4. Position sizing: Smaller position sizes allow for more trades but may limit profits\n")
-		contextBuilder.WriteString("5. Hold time: Shorter hold times reduce exposure to downside risks but may limit profit potential\n\n")
+		var problems []string
+		draft, problems = validateDraftPayload(payload)
+		return problems
+	}, strategyRepairPrompt, func(problems []string) error {
+		return &DraftValidationError{Problems: problems}
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	contextBuilder.WriteString("Generate a complete strategy that you believe would be profitable. Be creative and innovative while considering risk management. Return ONLY the JSON object for your strategy.\n")
-
-	return contextBuilder.String()
+	draft.PromptTemplate = tmpl.ID
+	draft.PromptVersion = tmpl.Version
+	return draft, nil
 }
 
-// executeRequest sends the request to the AI API
-func (s *AIService) executeRequest(req AIRequest) (*AIResponse, error) {
-	// Convert request to JSON
-	reqBody, err := json.Marshal(req)
+// GeneratePostMortem diagnoses a closed trade with the post-mortem template. Responses that
+// fail validation are sent back for repair like strategies and end in a
+// *PostMortemValidationError; provider errors are wrapped so callers can tell
+// ErrAIBudgetExceeded and ErrAICircuitOpen apart.
+func (s *AIService) GeneratePostMortem(templateRef string, input PostMortemPromptInput) (*models.TradePostMortem, error) {
+	tmpl, err := s.prompts.Get(PromptKindPostMortem, templateRef)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
+		return nil, err
 	}
-
-	// Create HTTP request
-	httpReq, err := http.NewRequest("POST", s.apiURL, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
+	if input.Diagnoses == nil {
+		input.Diagnoses = postMortemDiagnoses
 	}
-
-	// Set headers
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.apiKey))
-
-	// Execute request
-	httpResp, err := s.httpClient.Do(httpReq)
+	prompt, err := tmpl.Render(input)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %v", err)
+		return nil, err
 	}
-	defer httpResp.Body.Close()
 
-	// Check status code
-	if httpResp.StatusCode != http.StatusOK {
-		// Read error response
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(httpResp.Body).Decode(&errResp); err != nil {
-			return nil, fmt.Errorf("error decoding error response: %v", err)
-		}
-		return nil, fmt.Errorf("error from ChatGPT API: %v", errResp)
+	req := LLMRequest{
+		Purpose: models.AIPurposeTradePostMortem,
+		Messages: []LLMMessage{
+			{
+				Role:    "system",
+				Content: prompt.System,
+			},
+			{
+				Role:    "user",
+				Content: prompt.User,
+			},
+		},
+		Temperature: 0.3,
+		MaxTokens:   1000,
+		ResponseSchema: &LLMJSONSchema{
+			Name:   "trade_post_mortem",
+			Schema: PostMortemJSONSchema(),
+		},
 	}
 
-	// Decode response
-	var resp AIResponse
-	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	var postMortem *models.TradePostMortem
+	err = s.completeValidated(req, "Post-mortem", func(response *LLMResponse) []string {
+		payload, err := decodeStrategyPayload(response.Content)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		var problems []string
+		postMortem, problems = validatePostMortemPayload(payload)
+		return problems
+	}, postMortemRepairPrompt, func(problems []string) error {
+		return &PostMortemValidationError{Problems: problems}
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &resp, nil
+	postMortem.PromptTemplate = tmpl.ID
+	postMortem.PromptVersion = tmpl.Version
+	postMortem.AnalyzedAt = time.Now()
+	return postMortem, nil
 }
 
-// parseStrategyResponse parses the ChatGPT response into a strategy
-func (s *AIService) parseStrategyResponse(response *AIResponse) (*models.Strategy, error) {
-	if len(response.Choices) == 0 {
-		return nil, fmt.Errorf("no choices in response")
-	}
+// completeValidated executes req and checks each response with validate, which returns the
+// problems it found. A response with problems is sent back to the model with
+// repairPrompt(problems) for up to repairAttempts corrections; after that the failure is
+// recorded and the last problems are returned as invalid(problems). kind names the response
+// in logs. Provider errors are wrapped so callers can tell ErrAIBudgetExceeded and
+// ErrAICircuitOpen apart.
+func (s *AIService) completeValidated(
+	req LLMRequest,
+	kind string,
+	validate func(response *LLMResponse) []string,
+	repairPrompt func(problems []string) string,
+	invalid func(problems []string) error,
+) error {
+	for attempt := 1; ; attempt++ {
+		response, err := s.executeRequest(req)
+		if err != nil {
+			return fmt.Errorf("error executing %s request: %w", s.llm.Name(), err)
+		}
 
-	content := response.Choices[0].Message.Content
+		problems := validate(response)
+		if len(problems) == 0 {
+			return nil
+		}
+		validationErr := invalid(problems)
 
-	// Extract JSON configuration from response
-	strategy, err := s.extractStrategyConfig(content)
-	if err != nil {
-		return nil, fmt.Errorf("error extracting strategy config: %v", err)
-	}
+		if attempt > s.repairAttempts {
+			s.recordGenerationFailure(req.Purpose, response, attempt, problems)
+			return fmt.Errorf("rejected %s response after %d attempts: %w", s.llm.Name(), attempt, validationErr)
+		}
 
-	return strategy, nil
+		s.logger.Warn("%s response failed validation (attempt %d), requesting repair: %v", kind, attempt, validationErr)
+		req.Messages = append(req.Messages,
+			LLMMessage{Role: "assistant", Content: response.Content},
+			LLMMessage{Role: "user", Content: repairPrompt(problems)},
+		)
+	}
 }
 
-// extractStrategyConfig extracts strategy configuration from the response content
-func (s *AIService) extractStrategyConfig(content string) (*models.Strategy, error) {
-	// Look for JSON blocks in the content
-	jsonPattern := regexp.MustCompile(`(?s)\{.*\}`)
-	jsonMatches := jsonPattern.FindAllString(content, -1)
-
-	if len(jsonMatches) == 0 {
-		return nil, fmt.Errorf("no JSON configuration found in response")
+// recordGenerationFailure stores a response that never passed validation
+func (s *AIService) recordGenerationFailure(purpose string, response *LLMResponse, attempts int, problems []string) {
+	if s.failureRepo == nil {
+		return
 	}
+	failure := &models.AIGenerationFailure{
+		Purpose:      purpose,
+		Provider:     s.llm.Name(),
+		Model:        response.Model,
+		Attempts:     attempts,
+		Errors:       problems,
+		LastResponse: response.Content,
+	}
+	if _, err := s.failureRepo.Save(failure); err != nil {
+		s.logger.Error("Error recording strategy generation failure: %v", err)
+	}
+}
 
-	// Try each JSON block until we find a valid one
-	var strategy models.Strategy
-	var validJSON bool
-
-	for _, jsonStr := range jsonMatches {
-		// Try to unmarshal into a config
-		var config map[string]interface{}
-		if err := json.Unmarshal([]byte(jsonStr), &config); err != nil {
-			continue
-		}
-
-		// Check if it has required fields
-		if _, hasName := config["name"]; !hasName {
-			continue
-		}
-
-		// Create strategy with the required parameters
-		strategyConfig := map[string]interface{}{
-			"marketCapThreshold":   utils.GetFloat64OrDefault(config, "marketCapThreshold", 7000.0),
-			"minBuysForEntry":      utils.GetIntOrDefault(config, "minBuysForEntry", 3),
-			"entryTimeWindowSec":   utils.GetIntOrDefault(config, "entryTimeWindowSec", 300),
-			"takeProfitPct":        utils.GetFloat64OrDefault(config, "takeProfitPct", 50.0),
-			"stopLossPct":          utils.GetFloat64OrDefault(config, "stopLossPct", 30.0),
-			"maxHoldTimeSec":       utils.GetIntOrDefault(config, "maxHoldTimeSec", 1800),
-			"fixedPositionSizeSol": utils.GetFloat64OrDefault(config, "fixedPositionSizeSol", 0.5),
-			"initialBalance":       utils.GetFloat64OrDefault(config, "initialBalance", 10.0),
-		}
+// executeRequest sends the request to the configured LLM provider
+func (s *AIService) executeRequest(req LLMRequest) (*LLMResponse, error) {
+	return s.llm.Complete(context.Background(), req)
+}
 
-		strategy = models.Strategy{
-			Name:        utils.GetStringOrDefault(config, "name", "AI Generated Strategy"),
-			Description: utils.GetStringOrDefault(config, "description", fmt.Sprintf("AI-generated strategy on %s", time.Now().Format("2006-01-02 15:04:05"))),
-			Config:      models.JSONB(strategyConfig),
-			IsPublic:    true,
-			AIEnhanced:  true,
-			Tags:        []string{"ai-generated", "auto-optimized"},
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		}
+// parseStrategyResponse parses the LLM response into a strategy. Responses that aren't a
+// single JSON object or break the schema yield a *StrategyValidationError.
+func (s *AIService) parseStrategyResponse(response *LLMResponse) (*models.Strategy, error) {
+	payload, err := decodeStrategyPayload(response.Content)
+	if err != nil {
+		return nil, &StrategyValidationError{Problems: []string{err.Error()}}
+	}
 
-		validJSON = true
-		break
+	config, problems := validateStrategyPayload(payload)
+	if len(problems) > 0 {
+		return nil, &StrategyValidationError{Problems: problems}
 	}
 
-	if !validJSON {
-		return nil, fmt.Errorf("no valid strategy configuration found in response")
+	name := config["name"].(string)
+	description := config["description"].(string)
+	delete(config, "name")
+	delete(config, "description")
+
+	strategy := &models.Strategy{
+		Name:        name,
+		Description: description,
+		Config:      models.JSONB(config),
+		IsPublic:    true,
+		AIEnhanced:  true,
+		Source:      models.StrategySourceAI,
+		Tags:        []string{"ai-generated", "auto-optimized"},
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	// Calculate complexity score based on parameters
@@ -449,7 +565,7 @@ func (s *AIService) extractStrategyConfig(content string) (*models.Strategy, err
 	// Estimate risk score based on configuration parameters
 	strategy.RiskScore = s.calculateRiskScore(strategy.Config)
 
-	return &strategy, nil
+	return strategy, nil
 }
 
 // calculateComplexityScore calculates the complexity score of a strategy (1-10)
@@ -565,111 +681,91 @@ func (s *AIService) SaveStrategy(strategy *models.Strategy) (int64, error) {
 	return s.strategyRepo.Save(strategy)
 }
 
-// GenerateAnalysis generates an AI-powered analysis of a strategy's performance
-func (s *AIService) GenerateAnalysis(strategyName string, metrics map[string]interface{}, hasActiveTrades bool, isActiveSimulation bool) (string, error) {
-	s.logger.Info("Generating AI analysis for strategy: %s", strategyName)
-
-	// Convert metrics to a description format for the AI
-	var metricsDescription strings.Builder
-	metricsDescription.WriteString(fmt.Sprintf("Strategy Name: %s\n", strategyName))
-
-	if totalTrades, ok := metrics["total_trades"].(int); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Total Completed Trades: %d\n", totalTrades))
-	}
-
-	if hasActiveTrades {
-		if activeTrades, ok := metrics["active_trades"].(int); ok {
-			metricsDescription.WriteString(fmt.Sprintf("Active Positions: %d\n", activeTrades))
+// AdmitStrategy saves a generated strategy if it passes admission, returning a
+// *StrategyRejectedError if it doesn't. Without admission set the strategy is saved unless
+// it is a near-duplicate of a saved one, which yields a *DuplicateStrategyError.
+func (s *AIService) AdmitStrategy(strategy *models.Strategy, purpose string) (int64, error) {
+	if s.admission == nil {
+		if s.diversity != nil {
+			duplicate, err := s.diversity.Resolve(strategy)
+			if err != nil {
+				return 0, fmt.Errorf("error checking for near-duplicates: %v", err)
+			}
+			if duplicate != nil {
+				return 0, duplicate
+			}
 		}
+		return s.SaveStrategy(strategy)
 	}
+	return s.admission.Admit(strategy, purpose)
+}
 
-	if winRate, ok := metrics["win_rate"].(float64); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Win Rate: %.2f%%\n", winRate))
-	}
-
-	if roi, ok := metrics["roi"].(float64); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Return on Investment (ROI): %.2f%%\n", roi))
-	}
-
-	if maxDrawdown, ok := metrics["max_drawdown"].(float64); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Maximum Drawdown: %.2f%%\n", maxDrawdown))
-	}
-
-	if netPnL, ok := metrics["net_pnl"].(float64); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Net Profit/Loss: %.4f\n", netPnL))
-	}
-
-	if avgProfit, ok := metrics["avg_profit"].(float64); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Average Profit per Trade: %.4f\n", avgProfit))
-	}
+// GeneratedAnalysis is an AI analysis and the prompt template it was generated from
+type GeneratedAnalysis struct {
+	Text           string
+	PromptTemplate string
+	PromptVersion  int
+}
 
-	if avgLoss, ok := metrics["avg_loss"].(float64); ok {
-		metricsDescription.WriteString(fmt.Sprintf("Average Loss per Trade: %.4f\n", avgLoss))
-	}
+// GenerateAnalysis generates an AI-powered analysis of a strategy's performance from the
+// analysis template templateRef
+func (s *AIService) GenerateAnalysis(templateRef string, input AnalysisPromptInput) (*GeneratedAnalysis, error) {
+	s.logger.Info("Generating AI analysis for strategy: %s", input.StrategyName)
 
-	// Additional context about the current state
-	if isActiveSimulation {
-		metricsDescription.WriteString("\nThis strategy is currently being simulated. The metrics may change as trades complete.\n")
+	tmpl, err := s.prompts.Get(PromptKindAnalysis, templateRef)
+	if err != nil {
+		return nil, err
 	}
-
-	if hasActiveTrades {
-		metricsDescription.WriteString("\nThe strategy has active open positions that are not reflected in the completed trade metrics.\n")
+	prompt, err := tmpl.Render(input)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create the prompt for the AI
-	prompt := fmt.Sprintf(`Analyze the trading performance of a cryptocurrency strategy based on the provided metrics and generate an insightful performance analysis. Your analysis should:
-
-			1. Objectively assess the strategy's performance based on the metrics
-			2. Highlight strengths and weaknesses
-			3. Assess the level of risk based on drawdown and other metrics
-			4. Provide a clear assessment (excellent, good, average, poor, or very poor)
-			5. Suggest potential areas of improvement if applicable
-
-			Here are the performance metrics:
-
-		%s
-
-		Write a concise, clear analysis of approximately 3-5 sentences that a trader would find valuable.`, metricsDescription.String())
-
-	// Create request using the same model and API configuration already set in the AIService
-	req := AIRequest{
-		Model: "gpt-4",
-		Messages: []AIMessage{
+	// Create request using the provider's configured model
+	req := LLMRequest{
+		Purpose: models.AIPurposePerformanceAnalysis,
+		Messages: []LLMMessage{
 			{
 				Role:    "system",
-				Content: "You are an expert trading strategy analyst specializing in cryptocurrency trading. You provide concise, insightful analysis of trading strategy performance.",
+				Content: prompt.System,
 			},
 			{
 				Role:    "user",
-				Content: prompt,
+				Content: prompt.User,
 			},
 		},
 		Temperature: 0.7,
 		MaxTokens:   500,
 	}
 
-	// Execute request using the existing API configuration
+	// Execute request using the configured provider
 	response, err := s.executeRequest(req)
 	if err != nil {
-		return "", fmt.Errorf("error executing analysis generation request: %v", err)
+		return nil, fmt.Errorf("error executing analysis generation request: %v", err)
 	}
 
 	// Check for valid response
-	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no analysis content in AI response")
+	if strings.TrimSpace(response.Content) == "" {
+		return nil, fmt.Errorf("no analysis content in AI response")
 	}
 
-	analysis := response.Choices[0].Message.Content
+	analysis := response.Content
 
 	// Clean the analysis text (remove quotes, etc. if needed)
 	analysis = strings.TrimSpace(analysis)
 
-	s.logger.Info("Generated AI analysis for strategy %s successfully", strategyName)
+	s.logger.Info("Generated AI analysis for strategy %s successfully with prompt %s", input.StrategyName, tmpl.Tag())
 
-	return analysis, nil
+	return &GeneratedAnalysis{
+		Text:           analysis,
+		PromptTemplate: tmpl.ID,
+		PromptVersion:  tmpl.Version,
+	}, nil
 }
 
-// GenerateEvolutionaryStrategy creates a new strategy based on existing successful ones
+// GenerateEvolutionaryStrategy creates a new strategy based on existing successful ones and
+// feedback on why recent strategies lost money, and saves it if it passes admission,
+// recording the successful ones as its parents
 func (s *AIService) GenerateEvolutionaryStrategy() (*models.Strategy, error) {
 	// Get top strategies to base the new one on
 	topStrategies, err := s.GetTopPerformingStrategies()
@@ -681,22 +777,31 @@ func (s *AIService) GenerateEvolutionaryStrategy() (*models.Strategy, error) {
 		return nil, fmt.Errorf("no existing strategies to evolve from")
 	}
 
-	// Create metadata with top strategies
-	metadata := map[string]interface{}{
-		"top_strategies": topStrategies,
-		"evolution":      true,
+	// Generate the evolved strategy
+	input := NewStrategyPromptInput("", topStrategies)
+	input.Feedback = s.buildFeedback(0)
+
+	evolvedStrategy, err := s.GenerateStrategyFromTemplate(models.AIPurposeStrategyGeneration, EvolutionStrategyPrompt, input)
+	if err != nil {
+		return nil, fmt.Errorf("error generating evolved strategy: %v", err)
 	}
 
-	// Generate evolutionary prompt
-	prompt := "Create an evolved trading strategy that improves upon our best performing strategies. " +
-		"Analyze the winning strategies provided and create a new strategy that combines their strengths " +
-		"while addressing their weaknesses."
+	evolvedStrategy.Tags = append(evolvedStrategy.Tags, "evolved")
 
-	// Generate the evolved strategy
-	return s.GenerateStrategy(prompt, metadata)
+	id, err := s.AdmitStrategy(evolvedStrategy, models.AIPurposeStrategyGeneration)
+	if err != nil {
+		return nil, fmt.Errorf("error saving evolved strategy: %v", err)
+	}
+	evolvedStrategy.ID = id
+
+	s.RecordLineage(id, TopStrategyIDs(topStrategies), "Evolved from top performing strategies")
+
+	return evolvedStrategy, nil
 }
 
-// GenerateOptimizedStrategy creates an optimized version of an existing strategy
+// GenerateOptimizedStrategy creates an optimized version of an existing strategy, guided by
+// feedback on where it lost money, and saves it if it passes admission, recording the base
+// strategy as its parent
 func (s *AIService) GenerateOptimizedStrategy(baseStrategyID int64) (*models.Strategy, error) {
 	// Get the base strategy
 	baseStrategy, err := s.strategyRepo.GetByID(baseStrategyID)
@@ -708,30 +813,12 @@ func (s *AIService) GenerateOptimizedStrategy(baseStrategyID int64) (*models.Str
 		return nil, fmt.Errorf("base strategy not found: %d", baseStrategyID)
 	}
 
-	// Create metadata with the base strategy
-	baseStrategyInfo := map[string]interface{}{
-		"id":          baseStrategy.ID,
-		"name":        baseStrategy.Name,
-		"description": baseStrategy.Description,
-		"config":      baseStrategy.Config,
-		"win_count":   baseStrategy.WinCount,
-		"vote_count":  baseStrategy.VoteCount,
-	}
-
-	metadata := map[string]interface{}{
-		"base_strategy": baseStrategyInfo,
-		"optimization":  true,
-	}
-
-	// Generate optimization prompt
-	prompt := fmt.Sprintf("Optimize the trading strategy named '%s'. "+
-		"The strategy has had %d wins. "+
-		"Create an improved version that keeps its core strengths but fine-tunes the parameters "+
-		"for better performance.",
-		baseStrategy.Name, baseStrategy.WinCount)
+	// Generate the optimized strategy from the base strategy
+	input := NewStrategyPromptInput("", nil)
+	input.BaseStrategy = newStrategyPromptExample(baseStrategy)
+	input.Feedback = s.buildFeedback(baseStrategy.ID)
 
-	// Generate the optimized strategy
-	optimizedStrategy, err := s.GenerateStrategy(prompt, metadata)
+	optimizedStrategy, err := s.GenerateStrategyFromTemplate(models.AIPurposeStrategyGeneration, OptimizationStrategyPrompt, input)
 	if err != nil {
 		return nil, fmt.Errorf("error generating optimized strategy: %v", err)
 	}
@@ -744,5 +831,13 @@ func (s *AIService) GenerateOptimizedStrategy(baseStrategyID int64) (*models.Str
 	// Add "optimized" tag
 	optimizedStrategy.Tags = append(optimizedStrategy.Tags, "optimized")
 
+	id, err := s.AdmitStrategy(optimizedStrategy, models.AIPurposeStrategyGeneration)
+	if err != nil {
+		return nil, fmt.Errorf("error saving optimized strategy: %v", err)
+	}
+	optimizedStrategy.ID = id
+
+	s.RecordLineage(id, []int64{baseStrategy.ID}, fmt.Sprintf("Optimized parameters of strategy #%d", baseStrategy.ID))
+
 	return optimizedStrategy, nil
 }
@@ -0,0 +1,137 @@
+// internal/service/ai_service_test.go
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type aiServiceFixture struct {
+	service     *AIService
+	provider    *MockLLMProvider
+	failureRepo *memory.AIGenerationFailureRepository
+}
+
+func newAIServiceFixture(responses ...string) *aiServiceFixture {
+	store := memory.NewStore()
+	f := &aiServiceFixture{
+		provider:    NewMockLLMProvider(responses...),
+		failureRepo: memory.NewAIGenerationFailureRepository(store),
+	}
+	f.service = NewAIService(f.provider, memory.NewStrategyRepository(store), logger.New("test"))
+	f.service.SetFailureRepository(f.failureRepo)
+	return f
+}
+
+func TestGenerateStrategyRequestsStructuredOutput(t *testing.T) {
+	f := newAIServiceFixture(validStrategyJSON)
+
+	strategy, err := f.service.GenerateStrategy("Create a strategy", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "Momentum Chaser", strategy.Name)
+	assert.Equal(t, "Buys tokens with rapid buy activity", strategy.Description)
+	assert.NotContains(t, strategy.Config, "name")
+	assert.Equal(t, 50.0, strategy.Config["takeProfitPct"])
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 1)
+	require.NotNil(t, requests[0].ResponseSchema)
+	assert.Equal(t, "strategy", requests[0].ResponseSchema.Name)
+}
+
+func TestGenerateStrategyRepairsInvalidResponse(t *testing.T) {
+	invalid := `{"name": "Too Risky", "stopLossPct": 150}`
+	f := newAIServiceFixture(invalid, validStrategyJSON)
+
+	strategy, err := f.service.GenerateStrategy("Create a strategy", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "Momentum Chaser", strategy.Name)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 2)
+	repair := requests[1].Messages
+	require.Len(t, repair, 4)
+	assert.Equal(t, "assistant", repair[2].Role)
+	assert.Equal(t, invalid, repair[2].Content)
+	assert.Equal(t, "user", repair[3].Role)
+	assert.Contains(t, repair[3].Content, "stopLossPct: must be between 1 and 99, got 150")
+	assert.Contains(t, repair[3].Content, "marketCapThreshold: is required")
+
+	failures, err := f.failureRepo.GetRecent(10)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+}
+
+func TestGenerateStrategyRejectsAfterRepairAttempts(t *testing.T) {
+	f := newAIServiceFixture("not json", `{"name": "x"}`, `{"stopLossPct": 0}`)
+
+	_, err := f.service.GenerateStrategy("Create a strategy", map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.Len(t, f.provider.Requests(), 3)
+
+	failures, err := f.failureRepo.GetRecent(10)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, models.AIPurposeStrategyGeneration, failures[0].Purpose)
+	assert.Equal(t, LLMProviderMock, failures[0].Provider)
+	assert.Equal(t, 3, failures[0].Attempts)
+	assert.Equal(t, `{"stopLossPct": 0}`, failures[0].LastResponse)
+	assert.Contains(t, failures[0].Errors, "stopLossPct: must be between 1 and 99, got 0")
+}
+
+func TestGenerateStrategyProviderErrorIsNotRepaired(t *testing.T) {
+	f := newAIServiceFixture()
+	f.provider.SetError(errors.New("offline"))
+
+	_, err := f.service.GenerateStrategy("Create a strategy", map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "offline")
+	assert.Len(t, f.provider.Requests(), 1)
+
+	failures, err := f.failureRepo.GetRecent(10)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+}
+
+func TestGenerateStrategyRecordsPromptTemplate(t *testing.T) {
+	f := newAIServiceFixture(validStrategyJSON, validStrategyJSON)
+
+	strategy, err := f.service.GenerateStrategy("Create a strategy", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStrategyPrompt, strategy.PromptTemplate)
+	assert.Equal(t, 2, strategy.PromptVersion)
+
+	strategy, err = f.service.GenerateStrategyFromTemplate(models.AIPurposeStrategyGeneration, "defensive@v1", NewStrategyPromptInput("", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "defensive", strategy.PromptTemplate)
+	assert.Equal(t, 1, strategy.PromptVersion)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 2)
+	assert.Contains(t, requests[1].Messages[1].Content, "Generate a defensive trading strategy")
+
+	_, err = f.service.GenerateStrategyFromTemplate(models.AIPurposeStrategyGeneration, "unknown", NewStrategyPromptInput("", nil))
+	require.Error(t, err)
+	assert.Len(t, f.provider.Requests(), 2, "unknown templates never reach the provider")
+}
+
+func TestGenerateAnalysisRecordsPromptTemplate(t *testing.T) {
+	f := newAIServiceFixture("  Solid strategy.  ")
+
+	analysis, err := f.service.GenerateAnalysis("performance@v1", AnalysisPromptInput{StrategyName: "Momentum", TotalTrades: 4})
+	require.NoError(t, err)
+	assert.Equal(t, "Solid strategy.", analysis.Text)
+	assert.Equal(t, DefaultAnalysisPrompt, analysis.PromptTemplate)
+	assert.Equal(t, 1, analysis.PromptVersion)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 1)
+	assert.Contains(t, requests[0].Messages[1].Content, "Total Completed Trades: 4")
+}
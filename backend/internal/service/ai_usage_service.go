@@ -0,0 +1,304 @@
+// internal/service/ai_usage_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+var (
+	// ErrAIBudgetExceeded is returned for calls whose purpose has spent its token budget
+	ErrAIBudgetExceeded = errors.New("AI token budget exceeded")
+	// ErrAICircuitOpen is returned while repeated provider errors keep the breaker open
+	ErrAICircuitOpen = errors.New("AI circuit breaker is open")
+)
+
+// AIUsageConfig configures budgets, the circuit breaker and cost accounting. Purposes
+// without a budget are unlimited.
+type AIUsageConfig struct {
+	DailyTokenBudgets   map[string]int64 // Tokens per purpose per UTC day
+	MonthlyTokenBudgets map[string]int64 // Tokens per purpose per UTC calendar month
+	BreakerThreshold    int              // Consecutive provider errors that open the breaker
+	BreakerCooldown     time.Duration    // How long the breaker stays open before a trial call
+	InputCostPer1K      float64          // USD per 1000 prompt tokens
+	OutputCostPer1K     float64          // USD per 1000 response tokens
+}
+
+// AIUsageService wraps an LLMProvider so that every call is recorded with its purpose,
+// tokens, latency, cost and outcome. Calls are refused once their purpose exceeds its
+// budget, or while the circuit breaker is open after repeated provider errors.
+type AIUsageService struct {
+	provider  LLMProvider
+	usageRepo repository.AIUsageRepositoryInterface
+	config    AIUsageConfig
+	logger    *logger.Logger
+
+	mu                  sync.Mutex
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool // A half-open breaker lets one call through at a time
+}
+
+// NewAIUsageService creates a usage service around a provider
+func NewAIUsageService(
+	provider LLMProvider,
+	usageRepo repository.AIUsageRepositoryInterface,
+	config AIUsageConfig,
+	logger *logger.Logger,
+) *AIUsageService {
+	if config.BreakerThreshold <= 0 {
+		config.BreakerThreshold = 5
+	}
+	if config.BreakerCooldown <= 0 {
+		config.BreakerCooldown = 5 * time.Minute
+	}
+	return &AIUsageService{
+		provider:  provider,
+		usageRepo: usageRepo,
+		config:    config,
+		logger:    logger,
+		state:     CircuitClosed,
+	}
+}
+
+// Name returns the name of the wrapped provider
+func (s *AIUsageService) Name() string {
+	return s.provider.Name()
+}
+
+// Complete checks the budget and breaker, forwards the call to the provider and records it
+func (s *AIUsageService) Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	usage := &models.AIUsage{
+		Purpose:  req.Purpose,
+		Provider: s.provider.Name(),
+		Model:    req.Model,
+	}
+	if usage.Purpose == "" {
+		usage.Purpose = models.AIPurposeStrategyGeneration
+	}
+
+	if err := s.checkBudget(usage.Purpose, time.Now()); err != nil {
+		usage.Outcome = models.AIOutcomeBudgetExceeded
+		usage.Error = err.Error()
+		s.record(usage)
+		return nil, err
+	}
+
+	if !s.allow(time.Now()) {
+		usage.Outcome = models.AIOutcomeCircuitOpen
+		usage.Error = ErrAICircuitOpen.Error()
+		s.record(usage)
+		return nil, ErrAICircuitOpen
+	}
+
+	start := time.Now()
+	response, err := s.provider.Complete(ctx, req)
+	usage.LatencyMs = time.Since(start).Milliseconds()
+	s.recordResult(err, time.Now())
+
+	if err != nil {
+		usage.Outcome = models.AIOutcomeError
+		usage.Error = err.Error()
+		s.record(usage)
+		return nil, err
+	}
+
+	usage.Outcome = models.AIOutcomeSuccess
+	usage.Model = response.Model
+	usage.InputTokens = response.InputTokens
+	usage.OutputTokens = response.OutputTokens
+	usage.CostUSD = float64(response.InputTokens)/1000*s.config.InputCostPer1K +
+		float64(response.OutputTokens)/1000*s.config.OutputCostPer1K
+	s.record(usage)
+
+	return response, nil
+}
+
+// record saves a call; failures are logged so accounting never fails the call itself
+func (s *AIUsageService) record(usage *models.AIUsage) {
+	if _, err := s.usageRepo.Save(usage); err != nil {
+		s.logger.Error("Error recording AI usage for %s: %v", usage.Purpose, err)
+	}
+}
+
+// checkBudget returns ErrAIBudgetExceeded if the purpose has used up its daily or monthly
+// tokens. A call that starts under budget may finish over it.
+func (s *AIUsageService) checkBudget(purpose string, now time.Time) error {
+	for _, budget := range []struct {
+		period string
+		limit  int64
+		since  time.Time
+	}{
+		{"daily", s.config.DailyTokenBudgets[purpose], startOfDay(now)},
+		{"monthly", s.config.MonthlyTokenBudgets[purpose], startOfMonth(now)},
+	} {
+		if budget.limit <= 0 {
+			continue
+		}
+		used, err := s.usageRepo.SumTokens(purpose, budget.since)
+		if err != nil {
+			// Don't stop AI work because accounting is unavailable
+			s.logger.Error("Error checking %s AI budget for %s: %v", budget.period, purpose, err)
+			continue
+		}
+		if used >= budget.limit {
+			return fmt.Errorf("%w: %s used %d of %d %s tokens", ErrAIBudgetExceeded, purpose, used, budget.limit, budget.period)
+		}
+	}
+	return nil
+}
+
+// allow reports whether a call may reach the provider, moving an open breaker to half-open
+// once its cooldown has passed
+func (s *AIUsageService) allow(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch s.state {
+	case CircuitOpen:
+		if now.Sub(s.openedAt) < s.config.BreakerCooldown {
+			return false
+		}
+		s.state = CircuitHalfOpen
+		s.trialInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if s.trialInFlight {
+			return false
+		}
+		s.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// recordResult updates the breaker with the outcome of a provider call
+func (s *AIUsageService) recordResult(err error, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trialInFlight = false
+	if err == nil {
+		if s.state != CircuitClosed {
+			s.logger.Info("AI circuit breaker closed after a successful call")
+		}
+		s.state = CircuitClosed
+		s.consecutiveFailures = 0
+		return
+	}
+
+	s.consecutiveFailures++
+	if s.state == CircuitHalfOpen || s.consecutiveFailures >= s.config.BreakerThreshold {
+		if s.state != CircuitOpen {
+			s.logger.Warn("AI circuit breaker opened after %d consecutive errors: %v", s.consecutiveFailures, err)
+		}
+		s.state = CircuitOpen
+		s.openedAt = now
+	}
+}
+
+// CircuitStatus returns the current breaker state
+func (s *AIUsageService) CircuitStatus() models.AICircuitStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := models.AICircuitStatus{
+		State:               s.state,
+		ConsecutiveFailures: s.consecutiveFailures,
+	}
+	if s.state != CircuitClosed {
+		openedAt := s.openedAt
+		retryAt := s.openedAt.Add(s.config.BreakerCooldown)
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
+
+// GetUsageReport summarizes today's and this month's calls along with budgets and the
+// breaker state
+func (s *AIUsageService) GetUsageReport() (*models.AIUsageReport, error) {
+	now := time.Now()
+
+	daily, err := s.usageRepo.Summarize(startOfDay(now))
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing daily AI usage: %v", err)
+	}
+	monthly, err := s.usageRepo.Summarize(startOfMonth(now))
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing monthly AI usage: %v", err)
+	}
+
+	report := &models.AIUsageReport{
+		Provider:    s.provider.Name(),
+		Daily:       daily,
+		Monthly:     monthly,
+		Budgets:     []*models.AIBudgetStatus{},
+		Circuit:     s.CircuitStatus(),
+		GeneratedAt: now,
+	}
+
+	for _, budgets := range []struct {
+		period string
+		limits map[string]int64
+		since  time.Time
+	}{
+		{"daily", s.config.DailyTokenBudgets, startOfDay(now)},
+		{"monthly", s.config.MonthlyTokenBudgets, startOfMonth(now)},
+	} {
+		purposes := make([]string, 0, len(budgets.limits))
+		for purpose, limit := range budgets.limits {
+			if limit > 0 {
+				purposes = append(purposes, purpose)
+			}
+		}
+		sort.Strings(purposes)
+
+		for _, purpose := range purposes {
+			used, err := s.usageRepo.SumTokens(purpose, budgets.since)
+			if err != nil {
+				return nil, fmt.Errorf("error getting %s AI usage for %s: %v", budgets.period, purpose, err)
+			}
+			limit := budgets.limits[purpose]
+			report.Budgets = append(report.Budgets, &models.AIBudgetStatus{
+				Purpose:         purpose,
+				Period:          budgets.period,
+				LimitTokens:     limit,
+				UsedTokens:      used,
+				RemainingTokens: max(limit-used, 0),
+				Exceeded:        used >= limit,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// startOfDay returns midnight UTC of the day containing t
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// startOfMonth returns midnight UTC of the first day of the month containing t
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,148 @@
+// internal/service/ai_usage_service_test.go
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAIUsageFixture(config AIUsageConfig) (*AIUsageService, *MockLLMProvider, *memory.AIUsageRepository) {
+	provider := NewMockLLMProvider()
+	usageRepo := memory.NewAIUsageRepository(memory.NewStore())
+	return NewAIUsageService(provider, usageRepo, config, logger.New("test")), provider, usageRepo
+}
+
+func usageRequest(purpose string) LLMRequest {
+	return LLMRequest{
+		Purpose:  purpose,
+		Messages: []LLMMessage{{Role: "user", Content: "one two three four"}},
+	}
+}
+
+func TestAIUsageRecordsCalls(t *testing.T) {
+	usage, provider, usageRepo := newAIUsageFixture(AIUsageConfig{InputCostPer1K: 1, OutputCostPer1K: 2})
+	provider.Enqueue("five six")
+
+	_, err := usage.Complete(context.Background(), usageRequest(models.AIPurposePerformanceAnalysis))
+	require.NoError(t, err)
+
+	provider.SetError(errors.New("status 500"))
+	_, err = usage.Complete(context.Background(), usageRequest(models.AIPurposePerformanceAnalysis))
+	require.Error(t, err)
+
+	summaries, err := usageRepo.Summarize(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	summary := summaries[0]
+	assert.Equal(t, models.AIPurposePerformanceAnalysis, summary.Purpose)
+	assert.Equal(t, 2, summary.Calls)
+	assert.Equal(t, 1, summary.Successes)
+	assert.Equal(t, 1, summary.Errors)
+	assert.Equal(t, int64(4), summary.InputTokens)
+	assert.Equal(t, int64(2), summary.OutputTokens)
+	assert.InDelta(t, 4.0/1000+2*2.0/1000, summary.CostUSD, 1e-12)
+}
+
+func TestAIUsageBudgets(t *testing.T) {
+	usage, provider, _ := newAIUsageFixture(AIUsageConfig{
+		DailyTokenBudgets: map[string]int64{models.AIPurposeStrategyGeneration: 5},
+	})
+
+	// 4 input tokens and 2 output tokens take the purpose over its budget
+	provider.Enqueue("five six")
+	_, err := usage.Complete(context.Background(), usageRequest(models.AIPurposeStrategyGeneration))
+	require.NoError(t, err)
+
+	_, err = usage.Complete(context.Background(), usageRequest(models.AIPurposeStrategyGeneration))
+	require.ErrorIs(t, err, ErrAIBudgetExceeded)
+	assert.Len(t, provider.Requests(), 1, "blocked calls never reach the provider")
+
+	_, err = usage.Complete(context.Background(), usageRequest(models.AIPurposeManualGeneration))
+	require.NoError(t, err, "other purposes have their own budgets")
+
+	report, err := usage.GetUsageReport()
+	require.NoError(t, err)
+	require.Len(t, report.Budgets, 1)
+	budget := report.Budgets[0]
+	assert.Equal(t, "daily", budget.Period)
+	assert.Equal(t, int64(6), budget.UsedTokens)
+	assert.Zero(t, budget.RemainingTokens)
+	assert.True(t, budget.Exceeded)
+
+	var generation *models.AIUsageSummary
+	for _, summary := range report.Daily {
+		if summary.Purpose == models.AIPurposeStrategyGeneration {
+			generation = summary
+		}
+	}
+	require.NotNil(t, generation)
+	assert.Equal(t, 1, generation.Blocked)
+}
+
+func TestAIUsageCircuitBreaker(t *testing.T) {
+	usage, provider, usageRepo := newAIUsageFixture(AIUsageConfig{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	provider.SetError(errors.New("status 503"))
+
+	for i := 0; i < 2; i++ {
+		_, err := usage.Complete(context.Background(), usageRequest(models.AIPurposeStrategyGeneration))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrAICircuitOpen)
+	}
+	assert.Equal(t, CircuitOpen, usage.CircuitStatus().State)
+
+	_, err := usage.Complete(context.Background(), usageRequest(models.AIPurposeStrategyGeneration))
+	require.ErrorIs(t, err, ErrAICircuitOpen)
+	assert.Len(t, provider.Requests(), 2)
+
+	status := usage.CircuitStatus()
+	require.NotNil(t, status.RetryAt)
+	assert.Equal(t, time.Minute, status.RetryAt.Sub(*status.OpenedAt))
+
+	// After the cooldown one trial call goes through; its failure reopens the breaker
+	retryAt := *status.RetryAt
+	assert.False(t, usage.allow(retryAt.Add(-time.Second)))
+	assert.True(t, usage.allow(retryAt))
+	assert.False(t, usage.allow(retryAt), "only one trial call at a time")
+	usage.recordResult(errors.New("status 503"), retryAt)
+	assert.Equal(t, CircuitOpen, usage.CircuitStatus().State)
+
+	// A successful trial closes it
+	assert.True(t, usage.allow(retryAt.Add(time.Minute)))
+	usage.recordResult(nil, retryAt.Add(time.Minute))
+	status = usage.CircuitStatus()
+	assert.Equal(t, CircuitClosed, status.State)
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Nil(t, status.OpenedAt)
+
+	summaries, err := usageRepo.Summarize(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, 2, summaries[0].Errors)
+	assert.Equal(t, 1, summaries[0].Blocked)
+}
+
+func TestAIServiceAccountsPurposes(t *testing.T) {
+	usage, _, usageRepo := newAIUsageFixture(AIUsageConfig{})
+	aiService := NewAIService(usage, memory.NewStrategyRepository(memory.NewStore()), logger.New("test"))
+
+	_, err := aiService.GenerateStrategyForPurpose(models.AIPurposeManualGeneration, "Create a strategy", map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = aiService.GenerateAnalysis(DefaultAnalysisPrompt, AnalysisPromptInput{StrategyName: "Momentum", ROI: 12.5})
+	require.NoError(t, err)
+
+	summaries, err := usageRepo.Summarize(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	purposes := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		purposes = append(purposes, summary.Purpose)
+	}
+	assert.Equal(t, []string{models.AIPurposeManualGeneration, models.AIPurposePerformanceAnalysis}, purposes)
+}
@@ -20,8 +20,17 @@ type AutomationConfig struct {
 	PerformanceAnalysisInterval time.Duration
 	StrategiesPerInterval int
 	MaxConcurrentSimulations int
+	GenerationPrompts []string // Strategy templates generated strategies cycle through
+	Generator string // One of the StrategyGenerator* values
 }
 
+// What generates scheduled strategies
+const (
+	StrategyGeneratorLLM     = "llm"     // AI generation from prompt templates
+	StrategyGeneratorGenetic = "genetic" // The genetic algorithm, without any external API
+	StrategyGeneratorBoth    = "both"
+)
+
 // AutomationService handles the automation of strategy generation, simulation, and analysis
 type AutomationService struct {
 	config               AutomationConfig
@@ -32,6 +41,8 @@ type AutomationService struct {
 	aiService            *AIService
 	simulationService    *SimulationService
 	performanceAnalyzer  *AIPerformanceAnalyzer
+	evolution            *EvolutionEngine
+	diversity            *StrategyDiversityService
 	logger               *logger.Logger
 	runningSimulations   map[int64]bool
 	simulationQueue      chan int64
@@ -68,6 +79,8 @@ func NewAutomationService(
 			PerformanceAnalysisInterval: perfAnalysisInterval,
 			StrategiesPerInterval: cfg.Automation.StrategiesPerInterval,
 			MaxConcurrentSimulations: cfg.Automation.MaxConcurrentSimulations,
+			GenerationPrompts: cfg.Automation.GenerationPrompts,
+			Generator: cfg.Automation.Generator,
 		},
 		ctx:                 ctx,
 		cancelFunc:          cancel,
@@ -84,6 +97,18 @@ func NewAutomationService(
 	}
 }
 
+// SetEvolutionEngine sets the genetic algorithm scheduled generation uses when the
+// generator is genetic or both
+func (s *AutomationService) SetEvolutionEngine(engine *EvolutionEngine) {
+	s.evolution = engine
+}
+
+// SetDiversity sets the configuration-space clusters whose quota limits how many similar
+// strategies are queued or simulating at once
+func (s *AutomationService) SetDiversity(diversity *StrategyDiversityService) {
+	s.diversity = diversity
+}
+
 // Start starts the automation service
 func (s *AutomationService) Start() error {
 	if s.isRunning {
@@ -95,6 +120,8 @@ func (s *AutomationService) Start() error {
 	s.logger.Info("- Performance Analysis Interval: %v", s.config.PerformanceAnalysisInterval)
 	s.logger.Info("- Strategies Per Interval: %d", s.config.StrategiesPerInterval)
 	s.logger.Info("- Max Concurrent Simulations: %d", s.config.MaxConcurrentSimulations)
+	s.logger.Info("- Generation Prompts: %v", s.config.GenerationPrompts)
+	s.logger.Info("- Strategy Generator: %s", s.config.Generator)
 	
 	// Check if there are any running simulations at startup
 	activeRuns, err := s.simulationRunRepo.GetByStatus("running", 10)
@@ -110,7 +137,7 @@ func (s *AutomationService) Start() error {
 			s.logger.Info("No running simulations found at startup")
 			
 			// First check if there are any strategies in the database
-			strategies, err := s.getAllAIStrategies()
+			strategies, err := s.getGeneratedStrategies()
 			if err != nil {
 				s.logger.Error("Error getting AI strategies at startup: %v", err)
 			} else {
@@ -205,7 +232,12 @@ func (s *AutomationService) runAutomationLoop() {
 			// Check if it's time to generate new strategies
 			if time.Since(s.lastStrategyGenTime) >= s.config.StrategyGenerationInterval {
 				s.logger.Info("Time for scheduled strategy generation (last gen: %v ago)", time.Since(s.lastStrategyGenTime))
-				go s.generateStrategies()
+				if s.config.Generator != StrategyGeneratorGenetic {
+					go s.generateStrategies()
+				}
+				if s.config.Generator == StrategyGeneratorGenetic || s.config.Generator == StrategyGeneratorBoth {
+					go s.evolveStrategies()
+				}
 				s.lastStrategyGenTime = time.Now()
 			} else {
 				remaining := s.config.StrategyGenerationInterval - time.Since(s.lastStrategyGenTime)
@@ -240,39 +272,37 @@ func (s *AutomationService) generateStrategies() {
 			continue
 		}
 
-		// Create metadata with top strategies
-		metadata := map[string]interface{}{
-			"top_strategies": topStrategies,
-		}
-
-		// Generate strategy prompts based on the iteration
-		var prompt string
-		if i == 0 {
-			prompt = "Generate a profitable trading strategy for cryptocurrency tokens that focuses on early entry and quick profit-taking"
-		} else {
-			prompt = "Generate a diversified trading strategy for cryptocurrency tokens that focuses on longer holds and larger market caps"
-		}
-
-		// Generate new strategy
-		strategy, err := s.aiService.GenerateStrategy(prompt, metadata)
+		// Generate new strategy from the next prompt template
+		templateRef := s.generationPrompt(i)
+		strategy, err := s.aiService.GenerateStrategyFromTemplate(
+			models.AIPurposeStrategyGeneration,
+			templateRef,
+			NewStrategyPromptInput("", topStrategies),
+		)
 		if err != nil {
-			s.logger.Error("Error generating strategy %d: %v", i+1, err)
+			s.logger.Error("Error generating strategy %d from prompt %s: %v", i+1, templateRef, err)
 			continue
 		}
 
 		// Make sure strategy name is unique by adding timestamp
 		strategy.Name = fmt.Sprintf("%s (%s)", strategy.Name, time.Now().Format("20060102-1504"))
 
-		// Save the strategy
-		id, err := s.strategyRepo.Save(strategy)
+		// Save the strategy if it passes admission
+		id, err := s.aiService.AdmitStrategy(strategy, models.AIPurposeStrategyGeneration)
 		if err != nil {
-			s.logger.Error("Error saving generated strategy: %v", err)
+			if _, rejected := err.(*StrategyRejectedError); rejected {
+				s.logger.Info("Generated strategy %d was not admitted: %v", i+1, err)
+			} else {
+				s.logger.Error("Error saving generated strategy: %v", err)
+			}
 			continue
 		}
 
 		// Create initial metrics record for the strategy
 		s.createInitialStrategyMetric(id)
 
+		s.aiService.RecordLineage(id, TopStrategyIDs(topStrategies), "Generated from top performing strategies")
+
 		s.logger.Info("Successfully generated and saved new strategy %d with ID: %d", i+1, id)
 		
 		// Add strategy to simulation queue
@@ -280,6 +310,44 @@ func (s *AutomationService) generateStrategies() {
 	}
 }
 
+// evolveStrategies evolves new strategies with the genetic algorithm and queues them for
+// simulation
+func (s *AutomationService) evolveStrategies() {
+	if s.evolution == nil {
+		s.logger.Warn("Genetic strategy generation is enabled but no evolution engine is set")
+		return
+	}
+
+	activeRuns, err := s.simulationRunRepo.GetByStatus("running", 5)
+	if err != nil {
+		s.logger.Error("Error checking for active simulations before evolution: %v", err)
+	} else if len(activeRuns) > 0 {
+		s.logger.Info("Found %d active simulations running. Will skip strategy evolution this cycle.", len(activeRuns))
+		return
+	}
+
+	result, err := s.evolution.Run()
+	if err != nil {
+		s.logger.Error("Error evolving strategies: %v", err)
+		if result == nil {
+			return
+		}
+	}
+
+	for _, strategy := range result.Strategies {
+		s.createInitialStrategyMetric(strategy.ID)
+		s.queueStrategyForSimulation(strategy.ID)
+	}
+}
+
+// generationPrompt returns the strategy template for the i-th strategy of a generation cycle
+func (s *AutomationService) generationPrompt(i int) string {
+	if len(s.config.GenerationPrompts) == 0 {
+		return DefaultStrategyPrompt
+	}
+	return s.config.GenerationPrompts[i%len(s.config.GenerationPrompts)]
+}
+
 // checkPendingStrategies checks for strategies that need to be simulated
 func (s *AutomationService) checkPendingStrategies() {
 	// First, check if there are any active simulations running
@@ -311,10 +379,10 @@ func (s *AutomationService) checkPendingStrategies() {
 	}
 }
 
-// getUnsimulatedStrategies gets AI strategies that have not been simulated yet
+// getUnsimulatedStrategies gets generated strategies that have not been simulated yet
 func (s *AutomationService) getUnsimulatedStrategies() ([]*models.Strategy, error) {
-	// Get all AI strategies
-	strategies, err := s.getAllAIStrategies()
+	// Get all generated strategies
+	strategies, err := s.getGeneratedStrategies()
 	if err != nil {
 		return nil, fmt.Errorf("error getting AI strategies: %v", err)
 	}
@@ -344,8 +412,9 @@ func (s *AutomationService) getUnsimulatedStrategies() ([]*models.Strategy, erro
 	return unsimulated, nil
 }
 
-// getAllAIStrategies gets all AI-enhanced strategies
-func (s *AutomationService) getAllAIStrategies() ([]*models.Strategy, error) {
+// getGeneratedStrategies gets all strategies written by the AI or evolved by the genetic
+// algorithm, which automation simulates
+func (s *AutomationService) getGeneratedStrategies() ([]*models.Strategy, error) {
 	// In a real implementation, you would modify the repository to support this query directly
 	// For now, we'll get all public strategies and filter
 	strategies, err := s.strategyRepo.ListPublic(1000, 0)
@@ -353,14 +422,14 @@ func (s *AutomationService) getAllAIStrategies() ([]*models.Strategy, error) {
 		return nil, fmt.Errorf("error listing strategies: %v", err)
 	}
 
-	var aiStrategies []*models.Strategy
+	var generated []*models.Strategy
 	for _, strategy := range strategies {
-		if strategy.AIEnhanced {
-			aiStrategies = append(aiStrategies, strategy)
+		if strategy.Source == models.StrategySourceAI || strategy.Source == models.StrategySourceGenetic {
+			generated = append(generated, strategy)
 		}
 	}
 
-	return aiStrategies, nil
+	return generated, nil
 }
 
 // generateInitialStrategies generates the initial set of strategies when the database is empty
@@ -368,12 +437,6 @@ func (s *AutomationService) getAllAIStrategies() ([]*models.Strategy, error) {
 func (s *AutomationService) generateInitialStrategies() {
 	s.logger.Info("Generating initial set of strategies (ensuring %d are created)", s.config.StrategiesPerInterval)
 	
-	// Define prompts for both strategies upfront
-	prompts := []string{
-		"Generate a profitable trading strategy for cryptocurrency tokens that focuses on early entry and quick profit-taking",
-		"Generate a diversified trading strategy for cryptocurrency tokens that focuses on longer holds and larger market caps",
-	}
-	
 	// Create a wait group to ensure both strategies are generated
 	var wg sync.WaitGroup
 	wg.Add(s.config.StrategiesPerInterval)
@@ -386,8 +449,8 @@ func (s *AutomationService) generateInitialStrategies() {
 		go func(index int) {
 			defer wg.Done()
 			
-			// Get prompt for this strategy
-			prompt := prompts[index]
+			// Get prompt template for this strategy
+			templateRef := s.generationPrompt(index)
 			
 			// Make multiple attempts to generate a strategy
 			for attempt := 1; attempt <= 3; attempt++ {
@@ -398,15 +461,14 @@ func (s *AutomationService) generateInitialStrategies() {
 					continue
 				}
 				
-				// Create metadata with top strategies
-				metadata := map[string]interface{}{
-					"top_strategies": topStrategies,
-				}
-				
 				// Generate new strategy
-				strategy, err := s.aiService.GenerateStrategy(prompt, metadata)
+				strategy, err := s.aiService.GenerateStrategyFromTemplate(
+					models.AIPurposeStrategyGeneration,
+					templateRef,
+					NewStrategyPromptInput("", topStrategies),
+				)
 				if err != nil {
-					s.logger.Error("Attempt %d: Error generating strategy %d: %v", attempt, index+1, err)
+					s.logger.Error("Attempt %d: Error generating strategy %d from prompt %s: %v", attempt, index+1, templateRef, err)
 					if attempt < 3 {
 						s.logger.Info("Retrying strategy generation (attempt %d of 3)...", attempt+1)
 						time.Sleep(2 * time.Second)
@@ -417,8 +479,12 @@ func (s *AutomationService) generateInitialStrategies() {
 				// Make sure strategy name is unique by adding timestamp
 				strategy.Name = fmt.Sprintf("%s (%s)", strategy.Name, time.Now().Format("20060102-1504"))
 				
-				// Save the strategy
-				id, err := s.strategyRepo.Save(strategy)
+				// Save the strategy if it passes admission
+				id, err := s.aiService.AdmitStrategy(strategy, models.AIPurposeStrategyGeneration)
+				if _, rejected := err.(*StrategyRejectedError); rejected {
+					s.logger.Info("Attempt %d: Generated strategy %d was not admitted: %v", attempt, index+1, err)
+					continue
+				}
 				if err != nil {
 					s.logger.Error("Attempt %d: Error saving generated strategy: %v", attempt, err)
 					if attempt < 3 {
@@ -430,6 +496,8 @@ func (s *AutomationService) generateInitialStrategies() {
 				
 				// Create initial metrics even before simulation
 				s.createInitialStrategyMetric(id)
+
+				s.aiService.RecordLineage(id, TopStrategyIDs(topStrategies), "Generated from top performing strategies")
 				
 				s.logger.Info("Successfully generated and saved initial strategy %d with ID: %d", index+1, id)
 				successfulIDs <- id
@@ -470,6 +538,11 @@ func (s *AutomationService) queueStrategyForSimulation(strategyID int64) {
 		return
 	}
 
+	if !s.withinDiversityQuota(strategyID) {
+		s.logger.Info("Strategy %d is not queued: its configuration cluster already fills the diversity quota", strategyID)
+		return
+	}
+
 	// Mark as running before adding to queue to prevent duplicates
 	s.runningSimulationsMu.Lock()
 	s.runningSimulations[strategyID] = true
@@ -488,6 +561,38 @@ func (s *AutomationService) queueStrategyForSimulation(strategyID int64) {
 	}
 }
 
+// withinDiversityQuota reports whether a strategy's configuration-space cluster has room
+// among the strategies already queued or simulating. Without diversity set, or when the
+// strategies can't be loaded, every strategy has room.
+func (s *AutomationService) withinDiversityQuota(strategyID int64) bool {
+	if s.diversity == nil {
+		return true
+	}
+
+	candidate, err := s.strategyRepo.GetByID(strategyID)
+	if err != nil || candidate == nil {
+		return true
+	}
+
+	s.runningSimulationsMu.RLock()
+	ids := make([]int64, 0, len(s.runningSimulations))
+	for id := range s.runningSimulations {
+		ids = append(ids, id)
+	}
+	s.runningSimulationsMu.RUnlock()
+
+	var occupying []*models.Strategy
+	for _, id := range ids {
+		strategy, err := s.strategyRepo.GetByID(id)
+		if err != nil || strategy == nil {
+			continue
+		}
+		occupying = append(occupying, strategy)
+	}
+
+	return s.diversity.WithinQuota(candidate, occupying)
+}
+
 // processSimulationQueue processes the queue of strategies to simulate
 func (s *AutomationService) processSimulationQueue() {
 	s.logger.Info("Starting simulation queue processor")
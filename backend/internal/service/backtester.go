@@ -0,0 +1,371 @@
+// internal/service/backtester.go
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// backtestBatchSize is the number of stored trades loaded per page
+	backtestBatchSize = 5000
+
+	// backtestMaxTrades caps the stored trades one backtest replays
+	backtestMaxTrades = 500000
+)
+
+// Backtester replays a strategy over stored trades to estimate how it would have done,
+// without taking a live simulation slot.
+//
+// The replay covers the parts of a strategy that stored trades alone can answer: the token
+// age and market cap window, the buy_count, unique_buyers and organic_volume entry signals,
+// take profit, stop loss, max hold time and creator sells. Filters backed by the live
+// providers (creator reputation, holders, launch bundles, anomalies and features) are not
+// applied, so a backtest can only enter more tokens than the live simulation would.
+//
+// Windows that overlap a recorded feed gap are missing trades, so their results are marked
+// degraded rather than scored as if nothing traded.
+type Backtester struct {
+	tradeRepo repository.TradeRepositoryInterface
+	tokenRepo repository.TokenRepositoryInterface
+	gapRepo   repository.DataGapRepositoryInterface
+}
+
+// NewBacktester creates a new backtester
+func NewBacktester(tradeRepo repository.TradeRepositoryInterface, tokenRepo repository.TokenRepositoryInterface) *Backtester {
+	return &Backtester{
+		tradeRepo: tradeRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+// SetDataGapRepository sets where the feed gaps that degrade a backtest window are read from
+func (b *Backtester) SetDataGapRepository(gapRepo repository.DataGapRepositoryInterface) {
+	b.gapRepo = gapRepo
+}
+
+// BacktestSupported reports whether the backtest can replay a strategy's entry signal
+func BacktestSupported(config models.StrategyConfig) bool {
+	switch config.EntrySignalType {
+	case "", models.EntrySignalBuyCount, models.EntrySignalUniqueBuyers, models.EntrySignalOrganicVolume:
+		return true
+	default:
+		return false
+	}
+}
+
+// BacktestMarket is the stored market data of a backtest window, loaded once so any number
+// of strategies can be replayed over it
+type BacktestMarket struct {
+	From   int64
+	To     int64
+	Tokens map[int64]*models.Token
+	Trades []*models.Trade   // Sorted by timestamp, then ID
+	Gaps   []*models.DataGap // Feed gaps overlapping the window
+}
+
+// Replay backtests a strategy over the market
+func (m *BacktestMarket) Replay(config models.StrategyConfig) *models.BacktestResult {
+	result := ReplayBacktest(config, m.Tokens, m.Trades, m.From, m.To)
+	markDataGaps(result, m.Gaps)
+	return result
+}
+
+// markDataGaps records on a result how much of its window fell inside feed gaps. Any overlap
+// degrades the result, since the trades of a gap were never stored. Gaps still open count
+// up to the end of the window.
+func markDataGaps(result *models.BacktestResult, gaps []*models.DataGap) {
+	for _, gap := range gaps {
+		start := gap.StartedAt.Unix()
+		end := result.To
+		if gap.EndedAt != nil {
+			end = gap.EndedAt.Unix()
+		}
+		if start < result.From {
+			start = result.From
+		}
+		if end > result.To {
+			end = result.To
+		}
+		if end <= start {
+			continue
+		}
+		result.DataGaps++
+		result.GapSec += end - start
+	}
+	result.Degraded = result.DataGaps > 0
+}
+
+// Run backtests a strategy over the stored trades with timestamps in [from, to)
+func (b *Backtester) Run(config models.StrategyConfig, from, to int64) (*models.BacktestResult, error) {
+	if !BacktestSupported(config) {
+		return nil, fmt.Errorf("entry signal %s can't be backtested", config.EntrySignalType)
+	}
+
+	market, err := b.Load(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return market.Replay(config), nil
+}
+
+// Load loads the stored trades with timestamps in [from, to), their tokens and the feed
+// gaps overlapping the window
+func (b *Backtester) Load(from, to int64) (*BacktestMarket, error) {
+	var trades []*models.Trade
+	var afterID int64
+	for len(trades) < backtestMaxTrades {
+		batch, err := b.tradeRepo.GetTradesByTimeRange(from, to, afterID, backtestBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("error loading trades: %v", err)
+		}
+		trades = append(trades, batch...)
+		if len(batch) < backtestBatchSize {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+
+	sortBacktestTrades(trades)
+
+	tokens := make(map[int64]*models.Token)
+	for _, trade := range trades {
+		if _, ok := tokens[trade.TokenID]; ok {
+			continue
+		}
+		token, err := b.tokenRepo.GetByID(trade.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("error loading token %d: %v", trade.TokenID, err)
+		}
+		tokens[trade.TokenID] = token
+	}
+
+	var gaps []*models.DataGap
+	if b.gapRepo != nil {
+		var err error
+		gaps, err = b.gapRepo.GetOverlapping(time.Unix(from, 0), time.Unix(to, 0))
+		if err != nil {
+			return nil, fmt.Errorf("error loading data gaps: %v", err)
+		}
+	}
+
+	return &BacktestMarket{From: from, To: to, Tokens: tokens, Trades: trades, Gaps: gaps}, nil
+}
+
+// backtestToken is the replay state of one token
+type backtestToken struct {
+	token     *models.Token
+	refPrice  float64         // Price of the token's last trade, which its stored market cap reflects
+	lastPrice float64         // Price of the latest trade replayed so far
+	buys      []*models.Trade // Buys within the entry time window
+	entered   bool            // Like the live simulation, a token is traded at most once
+	position  *backtestPosition
+}
+
+// backtestPosition is an open backtest trade
+type backtestPosition struct {
+	entryPrice float64
+	entryTime  int64
+}
+
+// sortBacktestTrades sorts trades into the order ReplayBacktest replays them: by timestamp,
+// then by ID
+func sortBacktestTrades(trades []*models.Trade) {
+	sort.SliceStable(trades, func(i, j int) bool {
+		if trades[i].Timestamp != trades[j].Timestamp {
+			return trades[i].Timestamp < trades[j].Timestamp
+		}
+		return trades[i].ID < trades[j].ID
+	})
+}
+
+// ReplayBacktest replays trades through a strategy's entry and exit rules. Trades must
+// already be sorted by sortBacktestTrades, as Load returns them, so that a market can be
+// replayed many times without sorting it again. Tokens missing from tokens are skipped.
+// Stored market caps are the latest ones, so a token's market cap at each trade is
+// estimated by scaling it with the trade's price relative to the token's last price.
+// Positions still open at the end are closed at the token's last price.
+func ReplayBacktest(config models.StrategyConfig, tokens map[int64]*models.Token, trades []*models.Trade, from, to int64) *models.BacktestResult {
+	result := &models.BacktestResult{
+		From:         from,
+		To:           to,
+		MarketTrades: len(trades),
+		ExitReasons:  make(map[string]int),
+	}
+
+	states := make(map[int64]*backtestToken)
+	for _, trade := range trades {
+		token := tokens[trade.TokenID]
+		if token == nil {
+			continue
+		}
+		state := states[trade.TokenID]
+		if state == nil {
+			state = &backtestToken{token: token}
+			states[trade.TokenID] = state
+		}
+		if price := tradePrice(trade); price > 0 {
+			state.refPrice = price
+		}
+	}
+	result.TokensEvaluated = len(states)
+
+	balance := config.InitialBalance
+	equity := balance
+	peak := equity
+	var grossWins float64
+
+	closePosition := func(state *backtestToken, price float64, reason string) {
+		position := state.position
+		state.position = nil
+
+		pnl := (price/position.entryPrice - 1.0) * config.FixedPositionSizeSol
+		if pnl < -config.FixedPositionSizeSol {
+			pnl = -config.FixedPositionSizeSol
+		}
+		balance += config.FixedPositionSizeSol + pnl
+		equity += pnl
+
+		result.TradeCount++
+		result.NetPnL += pnl
+		result.ExitReasons[reason]++
+		if pnl > 0 {
+			result.Wins++
+			grossWins += pnl
+		}
+
+		if equity > peak {
+			peak = equity
+		} else if peak > 0 {
+			if drawdown := (peak - equity) / peak * 100; drawdown > result.MaxDrawdown {
+				result.MaxDrawdown = drawdown
+			}
+		}
+	}
+
+	for _, trade := range trades {
+		state := states[trade.TokenID]
+		price := tradePrice(trade)
+		if state == nil || price <= 0 {
+			continue
+		}
+
+		if state.position != nil {
+			if reason, exitPrice := backtestExit(config, state, trade, price); reason != "" {
+				closePosition(state, exitPrice, reason)
+			}
+			state.lastPrice = price
+			continue
+		}
+		state.lastPrice = price
+
+		if state.entered {
+			continue
+		}
+		if trade.IsBuy {
+			state.buys = append(state.buys, trade)
+		}
+		cutoff := trade.Timestamp - int64(config.EntryTimeWindowSec)
+		for len(state.buys) > 0 && state.buys[0].Timestamp < cutoff {
+			state.buys = state.buys[1:]
+		}
+
+		if balance < config.FixedPositionSizeSol || !backtestEntry(config, state, trade, price) {
+			continue
+		}
+
+		balance -= config.FixedPositionSizeSol
+		state.entered = true
+		state.position = &backtestPosition{entryPrice: price, entryTime: trade.Timestamp}
+	}
+
+	// Close what is still open, in token order so results are reproducible
+	tokenIDs := make([]int64, 0, len(states))
+	for id, state := range states {
+		if state.position != nil {
+			tokenIDs = append(tokenIDs, id)
+		}
+	}
+	sort.Slice(tokenIDs, func(i, j int) bool { return tokenIDs[i] < tokenIDs[j] })
+	for _, id := range tokenIDs {
+		state := states[id]
+		reason := "backtest_end"
+		if to-state.position.entryTime >= int64(config.MaxHoldTimeSec) {
+			reason = "max_hold_time"
+		}
+		closePosition(state, state.lastPrice, reason)
+	}
+
+	if result.TradeCount > 0 {
+		result.WinRate = float64(result.Wins) / float64(result.TradeCount) * 100
+		result.Expectancy = result.NetPnL / float64(result.TradeCount)
+	}
+	if config.InitialBalance > 0 {
+		result.ROI = result.NetPnL / config.InitialBalance * 100
+	}
+
+	return result
+}
+
+// backtestEntry reports whether a trade on a token without a position triggers an entry.
+// state.buys must already hold the buys within the entry time window.
+func backtestEntry(config models.StrategyConfig, state *backtestToken, trade *models.Trade, price float64) bool {
+	token := state.token
+	if trade.Timestamp-token.CreatedTimestamp/1000 > maxEntryTokenAgeSec {
+		return false
+	}
+
+	if token.UsdMarketCap <= 0 || state.refPrice <= 0 {
+		return false
+	}
+	marketCap := token.UsdMarketCap * price / state.refPrice
+	if !inEntryMarketCapRange(config, marketCap) {
+		return false
+	}
+
+	switch config.EntrySignalType {
+	case models.EntrySignalUniqueBuyers:
+		buyers := make(map[string]bool)
+		for _, buy := range state.buys {
+			buyers[buy.UserAddress] = true
+		}
+		return len(buyers) >= config.MinUniqueBuyers
+
+	case models.EntrySignalOrganicVolume:
+		var volume float64
+		for _, buy := range state.buys {
+			volume += buy.SolAmount
+		}
+		return volume >= config.MinOrganicBuyVolumeSol
+
+	default:
+		return len(state.buys) >= config.MinBuysForEntry
+	}
+}
+
+// backtestExit returns the reason and price a trade closes an open position at, or an empty
+// reason when the position stays open. A position past its max hold time closes at the
+// price before the trade that revealed it.
+func backtestExit(config models.StrategyConfig, state *backtestToken, trade *models.Trade, price float64) (string, float64) {
+	position := state.position
+	if trade.Timestamp-position.entryTime >= int64(config.MaxHoldTimeSec) {
+		return "max_hold_time", state.lastPrice
+	}
+
+	if config.ExitOnCreatorSell && !trade.IsBuy && trade.UserAddress != "" && trade.UserAddress == state.token.CreatorAddress {
+		return "creator_sell", price
+	}
+
+	if price >= position.entryPrice*(1+config.TakeProfitPct/100) {
+		return "take_profit", price
+	}
+	if price <= position.entryPrice*(1-config.StopLossPct/100) {
+		return "stop_loss", price
+	}
+
+	return "", 0
+}
@@ -0,0 +1,214 @@
+// internal/service/backtester_test.go
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const backtestStart = int64(1700000000)
+
+func backtestConfig() models.StrategyConfig {
+	return models.StrategyConfig{
+		MarketCapThreshold:   8000,
+		MinBuysForEntry:      2,
+		EntryTimeWindowSec:   60,
+		TakeProfitPct:        50,
+		StopLossPct:          20,
+		MaxHoldTimeSec:       300,
+		FixedPositionSizeSol: 1,
+		InitialBalance:       10,
+	}
+}
+
+// backtestTrade returns a trade at price SOL per token, offset seconds after backtestStart
+func backtestTrade(id, tokenID int64, offset int64, isBuy bool, price float64, user string) *models.Trade {
+	return &models.Trade{
+		ID:          id,
+		TokenID:     tokenID,
+		SolAmount:   1,
+		TokenAmount: 1 / price,
+		IsBuy:       isBuy,
+		UserAddress: user,
+		Timestamp:   backtestStart + offset,
+	}
+}
+
+func TestReplayBacktest(t *testing.T) {
+	tokens := map[int64]*models.Token{
+		// Last price 0.0012 at $10000, so $8333 at the 0.001 entry
+		1: {ID: 1, CreatorAddress: "creator1", CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 10000},
+		// Last price 0.0007 at $6000, so $8571 at the 0.001 entry
+		2: {ID: 2, CreatorAddress: "creator2", CreatedTimestamp: (backtestStart + 100) * 1000, UsdMarketCap: 6000},
+		// Too old to enter
+		3: {ID: 3, CreatedTimestamp: (backtestStart - 1000) * 1000, UsdMarketCap: 8000},
+		// Market cap far above the threshold
+		4: {ID: 4, CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 50000},
+	}
+	trades := []*models.Trade{
+		backtestTrade(1, 1, 10, true, 0.001, "a"),
+		backtestTrade(2, 1, 20, true, 0.001, "b"),  // Entry
+		backtestTrade(3, 1, 30, true, 0.0016, "c"), // Take profit
+		backtestTrade(4, 1, 40, false, 0.0012, "a"),
+		backtestTrade(5, 3, 15, true, 0.001, "a"),
+		backtestTrade(6, 3, 16, true, 0.001, "b"),
+		backtestTrade(7, 4, 15, true, 0.001, "a"),
+		backtestTrade(8, 4, 16, true, 0.001, "b"),
+		backtestTrade(9, 2, 110, true, 0.001, "a"),
+		backtestTrade(10, 2, 120, true, 0.001, "b"),   // Entry
+		backtestTrade(11, 2, 130, false, 0.0007, "a"), // Stop loss
+	}
+
+	sortBacktestTrades(trades)
+	result := ReplayBacktest(backtestConfig(), tokens, trades, backtestStart, backtestStart+3600)
+
+	assert.Equal(t, backtestStart, result.From)
+	assert.Equal(t, 11, result.MarketTrades)
+	assert.Equal(t, 4, result.TokensEvaluated)
+	assert.Equal(t, 2, result.TradeCount)
+	assert.Equal(t, 1, result.Wins)
+	assert.InDelta(t, 50, result.WinRate, 1e-9)
+	assert.InDelta(t, 0.3, result.NetPnL, 1e-9)
+	assert.InDelta(t, 3, result.ROI, 1e-9)
+	assert.InDelta(t, 0.15, result.Expectancy, 1e-9)
+	assert.InDelta(t, 0.3/10.6*100, result.MaxDrawdown, 1e-9)
+	assert.Equal(t, map[string]int{"take_profit": 1, "stop_loss": 1}, result.ExitReasons)
+}
+
+func TestReplayBacktestExits(t *testing.T) {
+	config := backtestConfig()
+	config.ExitOnCreatorSell = true
+	tokens := map[int64]*models.Token{
+		1: {ID: 1, CreatorAddress: "creator", CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 8000},
+		2: {ID: 2, CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 10000},
+		3: {ID: 3, CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 8000},
+	}
+	trades := []*models.Trade{
+		backtestTrade(1, 1, 1, true, 0.001, "a"),
+		backtestTrade(2, 1, 2, true, 0.001, "b"),
+		backtestTrade(3, 1, 3, false, 0.001, "creator"),
+		backtestTrade(4, 2, 1, true, 0.001, "a"),
+		backtestTrade(5, 2, 2, true, 0.001, "b"),
+		backtestTrade(6, 2, 100, true, 0.0011, "c"),
+		backtestTrade(7, 2, 400, true, 0.0013, "d"), // Past the max hold time, closes at the previous price
+		backtestTrade(8, 3, 1, true, 0.001, "a"),
+		backtestTrade(9, 3, 2, true, 0.001, "b"),
+	}
+
+	sortBacktestTrades(trades)
+	result := ReplayBacktest(config, tokens, trades, backtestStart, backtestStart+100)
+
+	assert.Equal(t, 3, result.TradeCount)
+	assert.Equal(t, map[string]int{"creator_sell": 1, "max_hold_time": 1, "backtest_end": 1}, result.ExitReasons)
+	assert.InDelta(t, 0.1, result.NetPnL, 1e-9)
+}
+
+func TestReplayBacktestEntrySignals(t *testing.T) {
+	tokens := map[int64]*models.Token{1: {ID: 1, CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 8000}}
+	// The same wallet buying twice and then an old buy dropping out of the window
+	trades := []*models.Trade{
+		backtestTrade(1, 1, 0, true, 0.001, "a"),
+		backtestTrade(2, 1, 100, true, 0.001, "b"),
+		backtestTrade(3, 1, 110, true, 0.001, "b"),
+	}
+
+	config := backtestConfig()
+	assert.Equal(t, 1, ReplayBacktest(config, tokens, trades, backtestStart, backtestStart+200).TradeCount)
+
+	config.EntrySignalType = models.EntrySignalUniqueBuyers
+	config.MinUniqueBuyers = 2
+	assert.Zero(t, ReplayBacktest(config, tokens, trades, backtestStart, backtestStart+200).TradeCount)
+
+	config.EntrySignalType = models.EntrySignalOrganicVolume
+	config.MinOrganicBuyVolumeSol = 2
+	assert.Equal(t, 1, ReplayBacktest(config, tokens, trades, backtestStart, backtestStart+200).TradeCount)
+
+	config.MinOrganicBuyVolumeSol = 3
+	assert.Zero(t, ReplayBacktest(config, tokens, trades, backtestStart, backtestStart+200).TradeCount)
+}
+
+func TestBacktesterRun(t *testing.T) {
+	store := memory.NewStore()
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+
+	tokenID, err := tokenRepo.Save(&models.Token{MintAddress: "mint", CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 16000})
+	require.NoError(t, err)
+	for i, trade := range []*models.Trade{
+		backtestTrade(0, tokenID, 1, true, 0.001, "a"),
+		backtestTrade(0, tokenID, 2, true, 0.001, "b"),
+		backtestTrade(0, tokenID, 3, true, 0.002, "c"),
+		backtestTrade(0, tokenID, 5000, true, 0.001, "d"), // Outside the window
+	} {
+		trade.Signature = string(rune('a' + i))
+		_, err := tradeRepo.Save(trade)
+		require.NoError(t, err)
+	}
+
+	backtester := NewBacktester(tradeRepo, tokenRepo)
+	result, err := backtester.Run(backtestConfig(), backtestStart, backtestStart+3600)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.MarketTrades)
+	assert.Equal(t, 1, result.TradeCount)
+	assert.Equal(t, 1, result.ExitReasons["take_profit"])
+
+	config := backtestConfig()
+	config.EntrySignalType = models.EntrySignalSmartMoney
+	assert.False(t, BacktestSupported(config))
+	_, err = backtester.Run(config, backtestStart, backtestStart+3600)
+	assert.Error(t, err)
+}
+
+func TestBacktesterRunMarksDataGaps(t *testing.T) {
+	store := memory.NewStore()
+	gapRepo := memory.NewDataGapRepository(store)
+	backtester := NewBacktester(memory.NewTradeRepository(store), memory.NewTokenRepository(store))
+	backtester.SetDataGapRepository(gapRepo)
+
+	// One gap started before the window and ended 60s into it, one is still open 100s before its end
+	closedID, err := gapRepo.Save(&models.DataGap{Source: "pumpfun", StartedAt: time.Unix(backtestStart-30, 0), Reason: "disconnected"})
+	require.NoError(t, err)
+	require.NoError(t, gapRepo.Close(closedID, time.Unix(backtestStart+60, 0)))
+	_, err = gapRepo.Save(&models.DataGap{Source: "pumpfun", StartedAt: time.Unix(backtestStart+3500, 0), Reason: "stalled"})
+	require.NoError(t, err)
+
+	result, err := backtester.Run(backtestConfig(), backtestStart, backtestStart+3600)
+	require.NoError(t, err)
+	assert.True(t, result.Degraded)
+	assert.Equal(t, 2, result.DataGaps)
+	assert.Equal(t, int64(160), result.GapSec)
+
+	result, err = backtester.Run(backtestConfig(), backtestStart+100, backtestStart+3400)
+	require.NoError(t, err)
+	assert.False(t, result.Degraded)
+	assert.Zero(t, result.DataGaps)
+}
+
+func TestBacktesterLoadSortsTrades(t *testing.T) {
+	store := memory.NewStore()
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+
+	tokenID, err := tokenRepo.Save(&models.Token{MintAddress: "mint", CreatedTimestamp: backtestStart * 1000, UsdMarketCap: 16000})
+	require.NoError(t, err)
+	// Stored out of timestamp order, as trades that arrive late are
+	for i, offset := range []int64{3, 1, 2, 1} {
+		trade := backtestTrade(0, tokenID, offset, true, 0.001, "a")
+		trade.Signature = string(rune('a' + i))
+		_, err := tradeRepo.Save(trade)
+		require.NoError(t, err)
+	}
+
+	market, err := NewBacktester(tradeRepo, tokenRepo).Load(backtestStart, backtestStart+3600)
+	require.NoError(t, err)
+	var order []int64
+	for _, trade := range market.Trades {
+		order = append(order, trade.Timestamp-backtestStart, trade.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 1, 4, 2, 3, 3, 1}, order)
+}
@@ -0,0 +1,241 @@
+// internal/service/candle_aggregator.go
+package service
+
+import (
+	"sort"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// CandleIntervals maps the supported interval names to their length in seconds
+var CandleIntervals = map[string]int{
+	"1s":  1,
+	"15s": 15,
+	"1m":  60,
+	"5m":  300,
+}
+
+// candleKey identifies a single candle bucket
+type candleKey struct {
+	tokenID     int64
+	intervalSec int
+	bucketStart int64
+}
+
+// candleState tracks a candle together with the trades that set its open and close,
+// so trades arriving out of order still produce the correct OHLC values
+type candleState struct {
+	candle    *models.Candle
+	firstTs   int64
+	firstID   int64
+	lastTs    int64
+	lastID    int64
+	hasPrices bool
+}
+
+// lateTrades counts the trades that arrived after some of their buckets were flushed
+type lateTrades struct {
+	count   int
+	firstTs int64
+	lastTs  int64
+}
+
+// CandleAggregator builds OHLCV candles incrementally from trades.
+// It is not safe for concurrent use; callers are expected to serialize access.
+type CandleAggregator struct {
+	intervals []int
+	candles   map[candleKey]*candleState
+	watermark int64 // buckets ending at or before this time have been flushed
+	late      lateTrades
+}
+
+// NewCandleAggregator creates an aggregator for the given intervals (in seconds)
+func NewCandleAggregator(intervals []int) *CandleAggregator {
+	sorted := append([]int(nil), intervals...)
+	sort.Ints(sorted)
+	return &CandleAggregator{
+		intervals: sorted,
+		candles:   make(map[candleKey]*candleState),
+	}
+}
+
+// DefaultCandleIntervals returns all supported intervals in ascending order
+func DefaultCandleIntervals() []int {
+	intervals := make([]int, 0, len(CandleIntervals))
+	for _, sec := range CandleIntervals {
+		intervals = append(intervals, sec)
+	}
+	sort.Ints(intervals)
+	return intervals
+}
+
+// tradePrice returns the SOL per token price of a trade, or 0 when it cannot be derived
+func tradePrice(trade *models.Trade) float64 {
+	if trade.TokenAmount <= 0 || trade.SolAmount <= 0 {
+		return 0
+	}
+	return trade.SolAmount / trade.TokenAmount
+}
+
+// Add applies a trade to every interval and returns copies of the candles it touched
+func (a *CandleAggregator) Add(trade *models.Trade) []*models.Candle {
+	price := tradePrice(trade)
+	updated := make([]*models.Candle, 0, len(a.intervals))
+	late := false
+
+	for _, intervalSec := range a.intervals {
+		bucketStart := trade.Timestamp - trade.Timestamp%int64(intervalSec)
+		if a.watermark > 0 && bucketStart+int64(intervalSec) <= a.watermark {
+			// The bucket was already flushed; the trade is recorded as late for TakeLate
+			late = true
+			continue
+		}
+		key := candleKey{tokenID: trade.TokenID, intervalSec: intervalSec, bucketStart: bucketStart}
+
+		state, exists := a.candles[key]
+		if !exists {
+			state = &candleState{
+				candle: &models.Candle{
+					TokenID:     trade.TokenID,
+					MintAddress: trade.MintAddress,
+					IntervalSec: intervalSec,
+					BucketStart: bucketStart,
+				},
+			}
+			a.candles[key] = state
+		}
+
+		c := state.candle
+		if c.MintAddress == "" {
+			c.MintAddress = trade.MintAddress
+		}
+
+		if price > 0 {
+			if !state.hasPrices {
+				c.Open, c.High, c.Low, c.Close = price, price, price, price
+				state.firstTs, state.firstID = trade.Timestamp, trade.ID
+				state.lastTs, state.lastID = trade.Timestamp, trade.ID
+				state.hasPrices = true
+			} else {
+				if price > c.High {
+					c.High = price
+				}
+				if price < c.Low {
+					c.Low = price
+				}
+				if trade.Timestamp < state.firstTs || (trade.Timestamp == state.firstTs && trade.ID < state.firstID) {
+					c.Open = price
+					state.firstTs, state.firstID = trade.Timestamp, trade.ID
+				}
+				if trade.Timestamp > state.lastTs || (trade.Timestamp == state.lastTs && trade.ID > state.lastID) {
+					c.Close = price
+					state.lastTs, state.lastID = trade.Timestamp, trade.ID
+				}
+			}
+		}
+
+		c.VolumeSol += trade.SolAmount
+		c.TradeCount++
+		if trade.IsBuy {
+			c.BuyVolumeSol += trade.SolAmount
+			c.BuyCount++
+		} else {
+			c.SellVolumeSol += trade.SolAmount
+			c.SellCount++
+		}
+
+		copied := *c
+		updated = append(updated, &copied)
+	}
+
+	if late {
+		if a.late.count == 0 || trade.Timestamp < a.late.firstTs {
+			a.late.firstTs = trade.Timestamp
+		}
+		if a.late.count == 0 || trade.Timestamp > a.late.lastTs {
+			a.late.lastTs = trade.Timestamp
+		}
+		a.late.count++
+	}
+
+	return updated
+}
+
+// TakeLate returns and forgets the number of trades dropped for arriving after some of
+// their buckets were flushed, and the range [from, to) of their timestamps widened to whole
+// largest-interval buckets. It returns nothing until every bucket in that range has been
+// flushed, so rebuilding the range never races the candles still being aggregated.
+func (a *CandleAggregator) TakeLate() (count int, from, to int64) {
+	if a.late.count == 0 {
+		return 0, 0, 0
+	}
+
+	largest := int64(a.intervals[len(a.intervals)-1])
+	from = a.late.firstTs - a.late.firstTs%largest
+	to = a.late.lastTs - a.late.lastTs%largest + largest
+	if to > a.watermark {
+		return 0, 0, 0
+	}
+
+	count = a.late.count
+	a.late = lateTrades{}
+	return count, from, to
+}
+
+// FlushClosed removes and returns candles whose bucket ended at or before the given time
+func (a *CandleAggregator) FlushClosed(now int64) []*models.Candle {
+	if now > a.watermark {
+		a.watermark = now
+	}
+
+	var closed []*models.Candle
+	for key, state := range a.candles {
+		if key.bucketStart+int64(key.intervalSec) <= now {
+			if state.hasPrices {
+				closed = append(closed, state.candle)
+			}
+			delete(a.candles, key)
+		}
+	}
+	sortCandles(closed)
+	return closed
+}
+
+// FlushAll removes and returns every candle held by the aggregator
+func (a *CandleAggregator) FlushAll() []*models.Candle {
+	closed := make([]*models.Candle, 0, len(a.candles))
+	for key, state := range a.candles {
+		if state.hasPrices {
+			closed = append(closed, state.candle)
+		}
+		delete(a.candles, key)
+	}
+	sortCandles(closed)
+	return closed
+}
+
+// Pending returns copies of the in-progress candles for a token and interval
+func (a *CandleAggregator) Pending(tokenID int64, intervalSec int) []*models.Candle {
+	var pending []*models.Candle
+	for key, state := range a.candles {
+		if key.tokenID == tokenID && key.intervalSec == intervalSec && state.hasPrices {
+			copied := *state.candle
+			pending = append(pending, &copied)
+		}
+	}
+	sortCandles(pending)
+	return pending
+}
+
+// sortCandles orders candles by token, interval and bucket start
+func sortCandles(candles []*models.Candle) {
+	sort.Slice(candles, func(i, j int) bool {
+		if candles[i].TokenID != candles[j].TokenID {
+			return candles[i].TokenID < candles[j].TokenID
+		}
+		if candles[i].IntervalSec != candles[j].IntervalSec {
+			return candles[i].IntervalSec < candles[j].IntervalSec
+		}
+		return candles[i].BucketStart < candles[j].BucketStart
+	})
+}
@@ -0,0 +1,98 @@
+// internal/service/candle_aggregator_test.go
+package service
+
+import (
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandleAggregatorBuildsOHLCV(t *testing.T) {
+	aggregator := NewCandleAggregator([]int{1, 60})
+
+	trades := []*models.Trade{
+		{ID: 1, TokenID: 7, MintAddress: "mint", Timestamp: 120, SolAmount: 1, TokenAmount: 100, IsBuy: true},    // 0.01
+		{ID: 2, TokenID: 7, MintAddress: "mint", Timestamp: 121, SolAmount: 3, TokenAmount: 100, IsBuy: true},    // 0.03
+		{ID: 3, TokenID: 7, MintAddress: "mint", Timestamp: 125, SolAmount: 0.5, TokenAmount: 100, IsBuy: false}, // 0.005
+		{ID: 4, TokenID: 7, MintAddress: "mint", Timestamp: 130, SolAmount: 2, TokenAmount: 100, IsBuy: true},    // 0.02
+	}
+	for _, trade := range trades {
+		aggregator.Add(trade)
+	}
+
+	pending := aggregator.Pending(7, 60)
+	assert.Len(t, pending, 1)
+
+	minute := pending[0]
+	assert.Equal(t, int64(120), minute.BucketStart)
+	assert.InDelta(t, 0.01, minute.Open, 1e-9)
+	assert.InDelta(t, 0.03, minute.High, 1e-9)
+	assert.InDelta(t, 0.005, minute.Low, 1e-9)
+	assert.InDelta(t, 0.02, minute.Close, 1e-9)
+	assert.InDelta(t, 6.5, minute.VolumeSol, 1e-9)
+	assert.InDelta(t, 6.0, minute.BuyVolumeSol, 1e-9)
+	assert.InDelta(t, 0.5, minute.SellVolumeSol, 1e-9)
+	assert.Equal(t, 4, minute.TradeCount)
+	assert.Equal(t, 3, minute.BuyCount)
+	assert.Equal(t, 1, minute.SellCount)
+
+	assert.Len(t, aggregator.Pending(7, 1), 4)
+}
+
+func TestCandleAggregatorOutOfOrderTrades(t *testing.T) {
+	aggregator := NewCandleAggregator([]int{60})
+
+	// The later trade arrives first
+	aggregator.Add(&models.Trade{ID: 2, TokenID: 1, Timestamp: 150, SolAmount: 2, TokenAmount: 100, IsBuy: true})
+	aggregator.Add(&models.Trade{ID: 1, TokenID: 1, Timestamp: 130, SolAmount: 1, TokenAmount: 100, IsBuy: true})
+
+	candle := aggregator.Pending(1, 60)[0]
+	assert.InDelta(t, 0.01, candle.Open, 1e-9)
+	assert.InDelta(t, 0.02, candle.Close, 1e-9)
+}
+
+func TestCandleAggregatorFlushClosed(t *testing.T) {
+	aggregator := NewCandleAggregator([]int{15, 60})
+
+	aggregator.Add(&models.Trade{ID: 1, TokenID: 1, Timestamp: 100, SolAmount: 1, TokenAmount: 100, IsBuy: true})
+	aggregator.Add(&models.Trade{ID: 2, TokenID: 1, Timestamp: 118, SolAmount: 1, TokenAmount: 100, IsBuy: true})
+
+	// Only the 15s bucket [90, 105) has closed at t=110
+	closed := aggregator.FlushClosed(110)
+	assert.Len(t, closed, 1)
+	assert.Equal(t, 15, closed[0].IntervalSec)
+	assert.Equal(t, int64(90), closed[0].BucketStart)
+
+	// Late trades for flushed buckets are ignored, open buckets still accept them
+	updated := aggregator.Add(&models.Trade{ID: 3, TokenID: 1, Timestamp: 95, SolAmount: 1, TokenAmount: 100, IsBuy: false})
+	assert.Len(t, updated, 1)
+	assert.Equal(t, 60, updated[0].IntervalSec)
+	assert.Equal(t, 3, updated[0].TradeCount)
+
+	remaining := aggregator.FlushAll()
+	assert.Len(t, remaining, 2)
+	assert.Empty(t, aggregator.FlushAll())
+}
+
+func TestCandleAggregatorTakeLate(t *testing.T) {
+	aggregator := NewCandleAggregator([]int{15, 60})
+
+	aggregator.Add(&models.Trade{ID: 1, TokenID: 1, Timestamp: 100, SolAmount: 1, TokenAmount: 100, IsBuy: true})
+	aggregator.FlushClosed(110)
+	aggregator.Add(&models.Trade{ID: 2, TokenID: 1, Timestamp: 95, SolAmount: 1, TokenAmount: 100, IsBuy: true})
+	aggregator.Add(&models.Trade{ID: 3, TokenID: 1, Timestamp: 92, SolAmount: 1, TokenAmount: 100, IsBuy: false})
+
+	// The minute [60, 120) the late trades fall in is still open
+	count, _, _ := aggregator.TakeLate()
+	assert.Zero(t, count)
+
+	aggregator.FlushClosed(120)
+	count, from, to := aggregator.TakeLate()
+	assert.Equal(t, 2, count)
+	assert.Equal(t, int64(60), from)
+	assert.Equal(t, int64(120), to)
+
+	count, _, _ = aggregator.TakeLate()
+	assert.Zero(t, count, "late trades are only returned once")
+}
@@ -0,0 +1,279 @@
+// internal/service/candle_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/api/dto"
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket"
+)
+
+const (
+	// candleFlushGrace is how long a bucket stays open after it ends, to absorb
+	// trades that reach the database late
+	candleFlushGrace = 10 * time.Second
+
+	// candleBackfillWindowSec is the size of each backfill chunk; it must be a
+	// multiple of the largest candle interval
+	candleBackfillWindowSec = 3600
+
+	// candleBackfillBatchSize is the number of trades read per query during backfill
+	candleBackfillBatchSize = 5000
+)
+
+// CandleService maintains OHLCV candles from the trade feed, persists closed candles
+// and publishes in-progress candles over WebSocket topics
+type CandleService struct {
+	candleRepo repository.CandleRepositoryInterface
+	tokenRepo  repository.TokenRepositoryInterface
+	tradeRepo  repository.TradeRepositoryInterface
+	wsHub      *websocket.WSHub
+	logger     *logger.Logger
+	aggregator *CandleAggregator
+	mu         sync.Mutex
+}
+
+// NewCandleService creates a new candle service
+func NewCandleService(
+	candleRepo repository.CandleRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	tradeRepo repository.TradeRepositoryInterface,
+	wsHub *websocket.WSHub,
+	logger *logger.Logger,
+) *CandleService {
+	return &CandleService{
+		candleRepo: candleRepo,
+		tokenRepo:  tokenRepo,
+		tradeRepo:  tradeRepo,
+		wsHub:      wsHub,
+		logger:     logger,
+		aggregator: NewCandleAggregator(DefaultCandleIntervals()),
+	}
+}
+
+// CandleTopic returns the WebSocket topic for a mint and interval name
+func CandleTopic(mint, interval string) string {
+	return fmt.Sprintf("candles:%s:%s", mint, interval)
+}
+
+// candleIntervalName returns the interval name for a length in seconds
+func candleIntervalName(intervalSec int) string {
+	for name, sec := range CandleIntervals {
+		if sec == intervalSec {
+			return name
+		}
+	}
+	return fmt.Sprintf("%ds", intervalSec)
+}
+
+// OnTrade implements TradeObserver
+func (s *CandleService) OnTrade(trade *models.Trade) {
+	s.mu.Lock()
+	updated := s.aggregator.Add(trade)
+	s.mu.Unlock()
+
+	if s.wsHub == nil {
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, candle := range updated {
+		if candle.TradeCount == 0 || candle.High == 0 {
+			continue
+		}
+		interval := candleIntervalName(candle.IntervalSec)
+		topic := CandleTopic(candle.MintAddress, interval)
+		s.wsHub.BroadcastTopic(topic, dto.CandleUpdateEvent{
+			Type:      "candle",
+			Topic:     topic,
+			Interval:  interval,
+			Timestamp: now,
+			Candle:    candle,
+		})
+	}
+}
+
+// Start periodically persists candles whose buckets have closed
+func (s *CandleService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.flushOpen()
+				s.logger.Info("Candle service stopped")
+				return
+			case <-ticker.C:
+				s.flush(time.Now().Add(-candleFlushGrace).Unix())
+				s.backfillLate(ctx)
+			}
+		}
+	}()
+}
+
+// Resume rebuilds the candles of the largest bucket before replayFrom from stored trades.
+// The trade feed replays trades from replayFrom, the start of the current largest bucket, so
+// together they replace the partial candles saved on shutdown with complete ones when the
+// restart comes within one largest bucket. Longer outages need a backfill.
+func (s *CandleService) Resume(ctx context.Context, replayFrom int64) {
+	intervals := DefaultCandleIntervals()
+	largest := int64(intervals[len(intervals)-1])
+	if _, err := s.Backfill(ctx, replayFrom-largest, replayFrom); err != nil {
+		s.logger.Error("Error rebuilding candles open before the restart: %v", err)
+	}
+}
+
+// flush persists candles whose buckets ended at or before the given time
+func (s *CandleService) flush(before int64) {
+	s.mu.Lock()
+	closed := s.aggregator.FlushClosed(before)
+	s.mu.Unlock()
+
+	if len(closed) == 0 {
+		return
+	}
+
+	if err := s.candleRepo.UpsertBatch(closed); err != nil {
+		s.logger.Error("Error saving %d closed candles: %v", len(closed), err)
+	}
+}
+
+// backfillLate rebuilds the candles that trades arriving after their buckets were flushed
+// were dropped from, once those buckets have all been flushed
+func (s *CandleService) backfillLate(ctx context.Context) {
+	s.mu.Lock()
+	count, from, to := s.aggregator.TakeLate()
+	s.mu.Unlock()
+
+	if count == 0 {
+		return
+	}
+
+	s.logger.Warn("%d trades arrived after their candles were flushed, rebuilding candles from %s to %s",
+		count, time.Unix(from, 0).UTC().Format(time.RFC3339), time.Unix(to, 0).UTC().Format(time.RFC3339))
+	if _, err := s.Backfill(ctx, from, to); err != nil {
+		s.logger.Error("Error rebuilding candles for late trades: %v", err)
+	}
+}
+
+// flushOpen persists the candles still open on shutdown. They are partial; after a restart
+// the feed replay and Resume rebuild them from stored trades.
+func (s *CandleService) flushOpen() {
+	s.mu.Lock()
+	remaining := s.aggregator.FlushAll()
+	s.mu.Unlock()
+
+	if err := s.candleRepo.UpsertBatch(remaining); err != nil {
+		s.logger.Error("Error saving candles on shutdown: %v", err)
+	}
+}
+
+// GetCandles returns stored candles for a mint merged with the in-progress ones
+func (s *CandleService) GetCandles(mint string, interval string, from, to int64, limit int) ([]*models.Candle, error) {
+	intervalSec, ok := CandleIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("unsupported interval: %s", interval)
+	}
+
+	token, err := s.tokenRepo.GetByMintAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found")
+	}
+
+	candles, err := s.candleRepo.GetByToken(token.ID, intervalSec, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	pending := s.aggregator.Pending(token.ID, intervalSec)
+	s.mu.Unlock()
+
+	// In-progress candles are fresher than anything persisted for the same bucket
+	byBucket := make(map[int64]int, len(candles))
+	for i, candle := range candles {
+		byBucket[candle.BucketStart] = i
+	}
+	for _, candle := range pending {
+		if candle.BucketStart < from || candle.BucketStart > to {
+			continue
+		}
+		candle.MintAddress = mint
+		if i, exists := byBucket[candle.BucketStart]; exists {
+			candles[i] = candle
+		} else if len(candles) < limit {
+			candles = append(candles, candle)
+		}
+	}
+	sortCandles(candles)
+
+	if candles == nil {
+		candles = []*models.Candle{}
+	}
+
+	return candles, nil
+}
+
+// Backfill rebuilds candles for all trades with timestamps in [from, to) and returns
+// the number of candles written. The range is widened to whole largest-interval buckets
+// so no partially covered candle overwrites a complete one.
+func (s *CandleService) Backfill(ctx context.Context, from, to int64) (int, error) {
+	intervals := DefaultCandleIntervals()
+	largest := int64(intervals[len(intervals)-1])
+	from -= from % largest
+	if to%largest != 0 {
+		to += largest - to%largest
+	}
+
+	written := 0
+	for windowStart := from; windowStart < to; windowStart += candleBackfillWindowSec {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		windowEnd := windowStart + candleBackfillWindowSec
+		if windowEnd > to {
+			windowEnd = to
+		}
+
+		aggregator := NewCandleAggregator(intervals)
+		var afterID int64
+		tradeCount := 0
+		for {
+			trades, err := s.tradeRepo.GetTradesByTimeRange(windowStart, windowEnd, afterID, candleBackfillBatchSize)
+			if err != nil {
+				return written, err
+			}
+			for _, trade := range trades {
+				aggregator.Add(trade)
+			}
+			tradeCount += len(trades)
+			if len(trades) < candleBackfillBatchSize {
+				break
+			}
+			afterID = trades[len(trades)-1].ID
+		}
+
+		candles := aggregator.FlushAll()
+		if err := s.candleRepo.UpsertBatch(candles); err != nil {
+			return written, err
+		}
+		written += len(candles)
+
+		s.logger.Info("Backfilled %d candles from %d trades for window %s",
+			len(candles), tradeCount, time.Unix(windowStart, 0).UTC().Format(time.RFC3339))
+	}
+
+	return written, nil
+}
@@ -0,0 +1,105 @@
+// internal/service/candle_service_test.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleServiceRebuildsPartialCandlesAfterRestart(t *testing.T) {
+	store := memory.NewStore()
+	candleRepo := memory.NewCandleRepository(store)
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+	tokenID, err := tokenRepo.Save(&models.Token{MintAddress: "mint", CreatorAddress: "creator"})
+	require.NoError(t, err)
+
+	trade := func(id, timestamp int64, sol float64, isBuy bool) *models.Trade {
+		trade := &models.Trade{ID: id, TokenID: tokenID, Signature: fmt.Sprintf("sig-%d", id), Timestamp: timestamp, SolAmount: sol, TokenAmount: 100, IsBuy: isBuy}
+		_, err := tradeRepo.Save(trade)
+		require.NoError(t, err)
+		return trade
+	}
+
+	// The first run shuts down halfway through the minute starting at 120 and saves it partial
+	before := NewCandleService(candleRepo, tokenRepo, tradeRepo, nil, logger.New("test"))
+	before.OnTrade(trade(1, 121, 2, true))  // 0.02
+	before.OnTrade(trade(2, 125, 1, false)) // 0.01
+	before.flushOpen()
+
+	// The collector keeps storing trades while the service is down
+	trade(3, 150, 4, true) // 0.04
+	trade(4, 170, 3, true) // 0.03
+
+	// The restarted run replays from the next five minutes and flushes its first minute
+	after := NewCandleService(candleRepo, tokenRepo, tradeRepo, nil, logger.New("test"))
+	after.Resume(context.Background(), 300)
+	after.OnTrade(trade(5, 310, 5, true))
+	after.flush(360)
+
+	candles, err := candleRepo.GetByToken(tokenID, 60, 0, 600, 10)
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+
+	minute := candles[0]
+	assert.Equal(t, int64(120), minute.BucketStart)
+	assert.InDelta(t, 0.02, minute.Open, 1e-9)
+	assert.InDelta(t, 0.04, minute.High, 1e-9)
+	assert.InDelta(t, 0.01, minute.Low, 1e-9)
+	assert.InDelta(t, 0.03, minute.Close, 1e-9)
+	assert.InDelta(t, 10, minute.VolumeSol, 1e-9)
+	assert.Equal(t, 4, minute.TradeCount)
+	assert.Equal(t, 1, minute.SellCount)
+
+	assert.Equal(t, int64(300), candles[1].BucketStart)
+	assert.Equal(t, 1, candles[1].TradeCount)
+}
+
+func TestCandleServiceRebuildsCandlesOfLateTrades(t *testing.T) {
+	store := memory.NewStore()
+	candleRepo := memory.NewCandleRepository(store)
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+	tokenID, err := tokenRepo.Save(&models.Token{MintAddress: "mint", CreatorAddress: "creator"})
+	require.NoError(t, err)
+
+	trade := func(id, timestamp int64, sol float64) *models.Trade {
+		trade := &models.Trade{ID: id, TokenID: tokenID, Signature: fmt.Sprintf("sig-%d", id), Timestamp: timestamp, SolAmount: sol, TokenAmount: 100, IsBuy: true}
+		_, err := tradeRepo.Save(trade)
+		require.NoError(t, err)
+		return trade
+	}
+
+	candleService := NewCandleService(candleRepo, tokenRepo, tradeRepo, nil, logger.New("test"))
+	candleService.OnTrade(trade(1, 121, 2))
+	candleService.flush(200)
+
+	// The trade is too late for the minute starting at 120, which is rebuilt only once the
+	// five minutes it falls in have been flushed
+	candleService.OnTrade(trade(2, 130, 4))
+	candleService.backfillLate(context.Background())
+	candles, err := candleRepo.GetByToken(tokenID, 60, 120, 120, 10)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, 1, candles[0].TradeCount)
+
+	candleService.flush(300)
+	candleService.backfillLate(context.Background())
+	candles, err = candleRepo.GetByToken(tokenID, 60, 120, 120, 10)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, 2, candles[0].TradeCount)
+	assert.InDelta(t, 0.04, candles[0].High, 1e-9)
+
+	candles, err = candleRepo.GetByToken(tokenID, 300, 0, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, 2, candles[0].TradeCount)
+}
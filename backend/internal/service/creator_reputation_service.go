@@ -0,0 +1,280 @@
+// internal/service/creator_reputation_service.go
+package service
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// creatorProfileMaxAge is how old a stored profile may get before a lookup rebuilds it
+	creatorProfileMaxAge = 10 * time.Minute
+
+	// creatorProfileCacheTTL bounds how often the same creator is read from the database
+	creatorProfileCacheTTL = 1 * time.Minute
+
+	// creatorRefreshInterval is how often creators with recent launches are re-profiled
+	creatorRefreshInterval = 5 * time.Minute
+
+	// creatorRefreshLookback re-profiles creators whose launches may still graduate or be dumped
+	creatorRefreshLookback = 1 * time.Hour
+
+	// creatorFastDumpSec is how soon after launch a creator sell counts as a dump
+	creatorFastDumpSec = 300
+
+	// creatorBackfillBatchSize is the number of creators profiled per page during backfill
+	creatorBackfillBatchSize = 500
+)
+
+// CreatorReputationProvider looks up the reputation profile of a creator wallet
+type CreatorReputationProvider interface {
+	GetProfile(creatorAddress string) (*models.CreatorProfile, error)
+}
+
+type cachedCreatorProfile struct {
+	profile   *models.CreatorProfile
+	fetchedAt time.Time
+}
+
+// CreatorReputationService builds reputation profiles for token creators from their launch history
+type CreatorReputationService struct {
+	profileRepo repository.CreatorProfileRepositoryInterface
+	logger      *logger.Logger
+	cacheMu     sync.Mutex
+	cache       map[string]cachedCreatorProfile
+}
+
+// NewCreatorReputationService creates a new creator reputation service
+func NewCreatorReputationService(profileRepo repository.CreatorProfileRepositoryInterface, logger *logger.Logger) *CreatorReputationService {
+	return &CreatorReputationService{
+		profileRepo: profileRepo,
+		logger:      logger,
+		cache:       make(map[string]cachedCreatorProfile),
+	}
+}
+
+// GetProfile implements CreatorReputationProvider. Missing or stale profiles are rebuilt
+// on demand so brand-new launches are judged on current history. Returns nil for
+// creators with no known launches.
+func (s *CreatorReputationService) GetProfile(creatorAddress string) (*models.CreatorProfile, error) {
+	s.cacheMu.Lock()
+	cached, ok := s.cache[creatorAddress]
+	s.cacheMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < creatorProfileCacheTTL {
+		return cached.profile, nil
+	}
+
+	profile, err := s.profileRepo.GetByAddress(creatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == nil || time.Since(profile.UpdatedAt) > creatorProfileMaxAge {
+		profile, err = s.Refresh(creatorAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.cacheMu.Lock()
+	s.cache[creatorAddress] = cachedCreatorProfile{profile: profile, fetchedAt: time.Now()}
+	s.cacheMu.Unlock()
+
+	return profile, nil
+}
+
+// GetTopCreators returns profiled creators ordered by reputation
+func (s *CreatorReputationService) GetTopCreators(minLaunches int, ascending bool, limit int) ([]*models.CreatorProfile, error) {
+	profiles, err := s.profileRepo.GetTop(minLaunches, ascending, limit)
+	if err != nil {
+		return nil, err
+	}
+	if profiles == nil {
+		profiles = []*models.CreatorProfile{}
+	}
+	return profiles, nil
+}
+
+// Refresh rebuilds and stores the profile of a creator
+func (s *CreatorReputationService) Refresh(creatorAddress string) (*models.CreatorProfile, error) {
+	launches, err := s.profileRepo.GetLaunches(creatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(launches) == 0 {
+		return nil, nil
+	}
+
+	profile := BuildCreatorProfile(creatorAddress, launches)
+	if err := s.profileRepo.Upsert(profile); err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache[creatorAddress] = cachedCreatorProfile{profile: profile, fetchedAt: time.Now()}
+	s.cacheMu.Unlock()
+
+	return profile, nil
+}
+
+// Start periodically re-profiles creators with recent launches
+func (s *CreatorReputationService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(creatorRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Creator reputation service stopped")
+				return
+			case now := <-ticker.C:
+				since := now.Add(-creatorRefreshLookback).UnixMilli()
+				count, err := s.refreshSince(ctx, since)
+				if err != nil {
+					s.logger.Error("Error refreshing creator profiles: %v", err)
+					continue
+				}
+				s.logger.Info("Refreshed %d creator profiles", count)
+			}
+		}
+	}()
+}
+
+// Backfill profiles every creator with a launch at or after sinceMs and returns the
+// number of profiles written
+func (s *CreatorReputationService) Backfill(ctx context.Context, sinceMs int64) (int, error) {
+	return s.refreshSince(ctx, sinceMs)
+}
+
+// refreshSince re-profiles all creators with a launch at or after sinceMs
+func (s *CreatorReputationService) refreshSince(ctx context.Context, sinceMs int64) (int, error) {
+	written := 0
+	after := ""
+	for {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		addresses, err := s.profileRepo.GetCreatorAddresses(sinceMs, after, creatorBackfillBatchSize)
+		if err != nil {
+			return written, err
+		}
+
+		for _, address := range addresses {
+			profile, err := s.Refresh(address)
+			if err != nil {
+				s.logger.Error("Error profiling creator %s: %v", address, err)
+				continue
+			}
+			if profile != nil {
+				written++
+			}
+		}
+
+		if len(addresses) < creatorBackfillBatchSize {
+			return written, nil
+		}
+		after = addresses[len(addresses)-1]
+	}
+}
+
+// BuildCreatorProfile aggregates a creator's launches into a profile and scores it
+func BuildCreatorProfile(creatorAddress string, launches []*models.CreatorLaunch) *models.CreatorProfile {
+	profile := &models.CreatorProfile{
+		CreatorAddress: creatorAddress,
+		TokensLaunched: len(launches),
+	}
+
+	var kothSecTotal, sellSecTotal, peakTotal float64
+	for _, launch := range launches {
+		if profile.FirstLaunchAt == 0 || launch.CreatedTimestamp < profile.FirstLaunchAt {
+			profile.FirstLaunchAt = launch.CreatedTimestamp
+		}
+		if launch.CreatedTimestamp > profile.LastLaunchAt {
+			profile.LastLaunchAt = launch.CreatedTimestamp
+		}
+
+		if launch.Completed {
+			profile.TokensGraduated++
+		}
+
+		if launch.KingOfTheHillTimeStamp > 0 && launch.KingOfTheHillTimeStamp >= launch.CreatedTimestamp {
+			profile.KothCount++
+			kothSecTotal += float64(launch.KingOfTheHillTimeStamp-launch.CreatedTimestamp) / 1000
+		}
+
+		if launch.FirstCreatorSellAt > 0 {
+			sellSec := float64(launch.FirstCreatorSellAt - launch.CreatedTimestamp/1000)
+			if sellSec < 0 {
+				sellSec = 0
+			}
+			profile.CreatorSellCount++
+			sellSecTotal += sellSec
+			if sellSec <= creatorFastDumpSec {
+				profile.FastDumpCount++
+			}
+		}
+
+		peak := launchPeakUsdMarketCap(launch)
+		peakTotal += peak
+		if peak > profile.MaxPeakUsdMarketCap {
+			profile.MaxPeakUsdMarketCap = peak
+		}
+	}
+
+	if profile.TokensLaunched > 0 {
+		profile.GraduationRate = float64(profile.TokensGraduated) / float64(profile.TokensLaunched)
+		profile.AvgPeakUsdMarketCap = peakTotal / float64(profile.TokensLaunched)
+	}
+	if profile.KothCount > 0 {
+		profile.AvgTimeToKothSec = kothSecTotal / float64(profile.KothCount)
+	}
+	if profile.CreatorSellCount > 0 {
+		profile.AvgTimeToSellSec = sellSecTotal / float64(profile.CreatorSellCount)
+	}
+
+	profile.ReputationScore = creatorReputationScore(profile)
+	return profile
+}
+
+// launchPeakUsdMarketCap estimates the peak USD market cap of a launch. Supply is fixed,
+// so market cap scales with price and the current value can be scaled by max/last price.
+func launchPeakUsdMarketCap(launch *models.CreatorLaunch) float64 {
+	if launch.LastPrice > 0 && launch.MaxPrice > launch.LastPrice {
+		return launch.UsdMarketCap * launch.MaxPrice / launch.LastPrice
+	}
+	return launch.UsdMarketCap
+}
+
+// creatorReputationScore maps a profile to 0-100. A creator with no history sits at 50;
+// graduations and king-of-the-hill runs raise the score, fast dumps and serial launches
+// without a graduation lower it.
+func creatorReputationScore(profile *models.CreatorProfile) float64 {
+	if profile.TokensLaunched == 0 {
+		return 50
+	}
+
+	launches := float64(profile.TokensLaunched)
+	score := 50.0
+	score += 35 * profile.GraduationRate
+	score += 15 * float64(profile.KothCount) / launches
+	score -= 30 * float64(profile.FastDumpCount) / launches
+
+	if profile.TokensLaunched > 3 && profile.TokensGraduated == 0 {
+		score -= math.Min(25, 5*float64(profile.TokensLaunched-3))
+	}
+
+	// A $10k average peak is neutral; each tenfold step moves the score by 5
+	if profile.AvgPeakUsdMarketCap > 0 {
+		score += math.Max(-10, math.Min(10, 5*math.Log10(profile.AvgPeakUsdMarketCap/10000)))
+	}
+
+	return math.Max(0, math.Min(100, score))
+}
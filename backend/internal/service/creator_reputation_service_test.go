@@ -0,0 +1,82 @@
+// internal/service/creator_reputation_service_test.go
+package service
+
+import (
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreatorProfile(t *testing.T) {
+	launchMs := int64(1700000000000)
+	launches := []*models.CreatorLaunch{
+		{
+			TokenID:                1,
+			CreatedTimestamp:       launchMs,
+			Completed:              true,
+			KingOfTheHillTimeStamp: launchMs + 600000,
+			UsdMarketCap:           60000,
+			MaxPrice:               2,
+			LastPrice:              1,
+		},
+		{
+			TokenID:            2,
+			CreatedTimestamp:   launchMs + 3600000,
+			UsdMarketCap:       4000,
+			MaxPrice:           1,
+			LastPrice:          1,
+			FirstCreatorSellAt: (launchMs+3600000)/1000 + 60,
+		},
+	}
+
+	profile := BuildCreatorProfile("creator1", launches)
+
+	assert.Equal(t, 2, profile.TokensLaunched)
+	assert.Equal(t, 1, profile.TokensGraduated)
+	assert.Equal(t, 0.5, profile.GraduationRate)
+	assert.Equal(t, 1, profile.KothCount)
+	assert.Equal(t, 600.0, profile.AvgTimeToKothSec)
+	assert.Equal(t, 1, profile.CreatorSellCount)
+	assert.Equal(t, 1, profile.FastDumpCount)
+	assert.Equal(t, 60.0, profile.AvgTimeToSellSec)
+	assert.Equal(t, 120000.0, profile.MaxPeakUsdMarketCap)
+	assert.Equal(t, 62000.0, profile.AvgPeakUsdMarketCap)
+	assert.Equal(t, launchMs, profile.FirstLaunchAt)
+	assert.Equal(t, launchMs+3600000, profile.LastLaunchAt)
+	assert.True(t, profile.ReputationScore > 50)
+}
+
+func TestCreatorReputationScoreSerialLauncher(t *testing.T) {
+	var launches []*models.CreatorLaunch
+	for i := 0; i < 6; i++ {
+		launches = append(launches, &models.CreatorLaunch{
+			TokenID:            int64(i + 1),
+			CreatedTimestamp:   int64(1700000000000 + i*60000),
+			UsdMarketCap:       3000,
+			FirstCreatorSellAt: int64(1700000000+i*60) + 30,
+		})
+	}
+
+	profile := BuildCreatorProfile("rugger", launches)
+
+	assert.Equal(t, 0, profile.TokensGraduated)
+	assert.Equal(t, 6, profile.FastDumpCount)
+	assert.True(t, profile.ReputationScore < 10)
+}
+
+func TestCreatorFilterReason(t *testing.T) {
+	serial := &models.CreatorProfile{TokensLaunched: 4, TokensGraduated: 0, ReputationScore: 40}
+	proven := &models.CreatorProfile{TokensLaunched: 4, TokensGraduated: 1, ReputationScore: 70}
+
+	config := models.StrategyConfig{MaxCreatorLaunchesWithoutGraduation: 3}
+	assert.NotEmpty(t, creatorFilterReason(config, serial))
+	assert.Empty(t, creatorFilterReason(config, proven))
+	assert.Empty(t, creatorFilterReason(config, nil))
+
+	config = models.StrategyConfig{MinCreatorReputation: 50}
+	assert.NotEmpty(t, creatorFilterReason(config, serial))
+	assert.Empty(t, creatorFilterReason(config, proven))
+
+	assert.Empty(t, creatorFilterReason(models.StrategyConfig{}, serial))
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/StratWarsAI/strategy-wars/internal/models"
 	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
@@ -16,6 +17,7 @@ type DataService struct {
 	tokenRepo repository.TokenRepositoryInterface
 	tradeRepo repository.TradeRepositoryInterface
 	logger    *logger.Logger
+	lifecycle *TokenLifecycleService
 }
 
 // NewDataService creates a new data service
@@ -27,6 +29,21 @@ func NewDataService(db *sql.DB, logger *logger.Logger) *DataService {
 	}
 }
 
+// SetLifecycleTracker sets the service that records token lifecycle transitions
+func (s *DataService) SetLifecycleTracker(lifecycle *TokenLifecycleService) {
+	s.lifecycle = lifecycle
+}
+
+// observeLifecycle records lifecycle transitions for a token, if lifecycle tracking is enabled
+func (s *DataService) observeLifecycle(token *models.Token, source string, traded bool, at int64) {
+	if s.lifecycle == nil {
+		return
+	}
+	if err := s.lifecycle.Observe(token, source, traded, at); err != nil {
+		s.logger.Error("Error recording lifecycle of %s: %v", token.MintAddress, err)
+	}
+}
+
 // ProcessTokenData processes token data from WebSocket
 func (s *DataService) ProcessTokenData(data map[string]interface{}) error {
 	s.logger.Debug("Processing token data")
@@ -128,9 +145,68 @@ func (s *DataService) ProcessTokenData(data map[string]interface{}) error {
 	}
 
 	s.logger.Info("Saved token: %s (ID: %d)", token.Name, id)
+
+	token.ID = id
+	s.observeLifecycle(token, "tokenCreated", false, time.Now().Unix())
 	return nil
 }
 
+// ProcessTokenUpdate processes any other Socket.IO event that refers to a known token, such
+// as a bonding curve completion or a new king of the hill, and records lifecycle transitions
+func (s *DataService) ProcessTokenUpdate(event string, data map[string]interface{}) error {
+	mintAddress, ok := data["mint"].(string)
+	if !ok || mintAddress == "" {
+		return fmt.Errorf("invalid mint address")
+	}
+
+	token, err := s.tokenRepo.GetByMintAddress(mintAddress)
+	if err != nil {
+		return fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		s.logger.Debug("Ignoring %s for unknown token %s", event, mintAddress)
+		return nil
+	}
+
+	if applyTokenUpdates(token, data) {
+		if _, err := s.tokenRepo.Save(token); err != nil {
+			return fmt.Errorf("error saving token: %v", err)
+		}
+	}
+
+	s.observeLifecycle(token, event, false, time.Now().Unix())
+	return nil
+}
+
+// applyTokenUpdates copies market cap, completion and king of the hill changes from event
+// data onto a token and reports whether anything changed. Completion and the king of the
+// hill timestamp are never cleared once set.
+func applyTokenUpdates(token *models.Token, data map[string]interface{}) bool {
+	changed := false
+
+	if mcVal, ok := data["market_cap"].(float64); ok && mcVal > 0 && token.MarketCap != mcVal {
+		token.MarketCap = mcVal
+		changed = true
+	}
+
+	if usdMcVal, ok := data["usd_market_cap"].(float64); ok && usdMcVal > 0 && token.UsdMarketCap != usdMcVal {
+		token.UsdMarketCap = usdMcVal
+		changed = true
+	}
+
+	if complete, ok := data["complete"].(bool); ok && complete && !token.Completed {
+		token.Completed = true
+		changed = true
+	}
+
+	if val, ok := data["king_of_the_hill_timestamp"].(float64); ok && val > 0 && token.KingOfTheHillTimeStamp == 0 {
+		token.KingOfTheHillTimeStamp = int64(val)
+		changed = true
+	}
+
+	return changed
+}
+
 // ProcessTradeData processes trade data from WebSocket
 func (s *DataService) ProcessTradeData(data map[string]interface{}) error {
 	s.logger.Debug("Processing trade data")
@@ -163,43 +239,14 @@ func (s *DataService) ProcessTradeData(data map[string]interface{}) error {
 		if err != nil || token == nil {
 			return fmt.Errorf("token still not found after processing")
 		}
-	} else {
-		// Check if we have market cap updates in the trade data
-		var marketCap float64
-		if mcVal, ok := data["market_cap"].(float64); ok {
-			marketCap = mcVal
-		}
-
-		var usdMarketCap float64
-		if usdMcVal, ok := data["usd_market_cap"].(float64); ok {
-			usdMarketCap = usdMcVal
-		}
-
-		// If we have updated market cap info, update the token
-		if marketCap > 0 || usdMarketCap > 0 {
-			needsUpdate := false
-
-			if marketCap > 0 && token.MarketCap != marketCap {
-				token.MarketCap = marketCap
-				needsUpdate = true
-			}
-
-			if usdMarketCap > 0 && token.UsdMarketCap != usdMarketCap {
-				token.UsdMarketCap = usdMarketCap
-				needsUpdate = true
-			}
-
-			if needsUpdate {
-				// Update token with new market cap info
-				_, err := s.tokenRepo.Save(token)
-				if err != nil {
-					s.logger.Error("Error updating token market cap: %v", err)
-					// Continue processing trade even if token update fails
-				} else {
-					s.logger.Info("Updated token market cap for %s, USD: %f, SOL: %f",
-						token.Symbol, token.UsdMarketCap, token.MarketCap)
-				}
-			}
+	} else if applyTokenUpdates(token, data) {
+		// Trades carry the token's latest market cap, completion and king of the hill state
+		if _, err := s.tokenRepo.Save(token); err != nil {
+			s.logger.Error("Error updating token market cap: %v", err)
+			// Continue processing trade even if token update fails
+		} else {
+			s.logger.Info("Updated token market cap for %s, USD: %f, SOL: %f",
+				token.Symbol, token.UsdMarketCap, token.MarketCap)
 		}
 	}
 
@@ -262,5 +309,11 @@ func (s *DataService) ProcessTradeData(data map[string]interface{}) error {
 		s.logger.Debug("Trade already exists: %s", signature)
 	}
 
+	at := timestamp
+	if at == 0 {
+		at = time.Now().Unix()
+	}
+	s.observeLifecycle(token, "tradeCreated", true, at)
+
 	return nil
 }
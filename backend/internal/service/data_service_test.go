@@ -85,6 +85,29 @@ func (m *MockTradeRepository) GetTradesBySignature(signature string) (*models.Tr
 	return args.Get(0).(*models.Trade), args.Error(1)
 }
 
+func (m *MockTradeRepository) GetTradesAfterID(afterID int64, limit int) ([]*models.Trade, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]*models.Trade), args.Error(1)
+}
+
+func (m *MockTradeRepository) GetTradesByTimeRange(from, to int64, afterID int64, limit int) ([]*models.Trade, error) {
+	args := m.Called(from, to, afterID, limit)
+	return args.Get(0).([]*models.Trade), args.Error(1)
+}
+
+func (m *MockTradeRepository) GetLastTradeIDBefore(timestamp int64) (int64, error) {
+	args := m.Called(timestamp)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTradeRepository) GetHolderBalances(tokenID int64) ([]*models.HolderBalance, int64, error) {
+	args := m.Called(tokenID)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*models.HolderBalance), args.Get(1).(int64), args.Error(2)
+}
+
 type DataServiceWithMocks struct {
 	tokenRepo repository.TokenRepositoryInterface
 	tradeRepo repository.TradeRepositoryInterface
@@ -0,0 +1,335 @@
+// internal/service/entry_model_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// entryModelCacheTTL is how long the latest version of a model is reused before
+	// checking for a newer one
+	entryModelCacheTTL = 1 * time.Minute
+
+	// entryModelEpochs is the number of gradient descent passes over the training rows
+	entryModelEpochs = 500
+
+	// entryModelLearningRate is the gradient descent step size on standardized features
+	entryModelLearningRate = 0.1
+
+	// entryModelL2 is the L2 regularization strength applied to the weights
+	entryModelL2 = 0.001
+
+	// entryModelValidationFraction is the share of the most recent rows held out for validation
+	entryModelValidationFraction = 0.2
+
+	// entryModelMinRows is the number of labeled entries needed to train a model
+	entryModelMinRows = 20
+)
+
+// EntryModelProvider returns a trained entry model; version 0 means the latest
+type EntryModelProvider interface {
+	GetEntryModel(name string, version int) (*models.EntryModel, error)
+}
+
+// EntryModelTrainingOptions selects the data and target an entry model is trained on
+type EntryModelTrainingOptions struct {
+	Name         string
+	HorizonMin   int     // Outcome horizon in minutes: 1, 5 or 30
+	MinReturnPct float64 // Max return within the horizon that counts as a hit, e.g. 100 for 2x
+	From         time.Time
+	To           time.Time
+}
+
+type cachedEntryModel struct {
+	model     *models.EntryModel
+	fetchedAt time.Time
+}
+
+// EntryModelService trains logistic regression entry models on labeled feature snapshots
+// and serves them to the simulator
+type EntryModelService struct {
+	modelRepo   repository.EntryModelRepositoryInterface
+	outcomeRepo repository.TokenOutcomeRepositoryInterface
+	logger      *logger.Logger
+	mu          sync.Mutex
+	cache       map[string]*cachedEntryModel
+}
+
+// NewEntryModelService creates a new entry model service
+func NewEntryModelService(
+	modelRepo repository.EntryModelRepositoryInterface,
+	outcomeRepo repository.TokenOutcomeRepositoryInterface,
+	logger *logger.Logger,
+) *EntryModelService {
+	return &EntryModelService{
+		modelRepo:   modelRepo,
+		outcomeRepo: outcomeRepo,
+		logger:      logger,
+		cache:       make(map[string]*cachedEntryModel),
+	}
+}
+
+// GetEntryModel implements EntryModelProvider. Pinned versions never change and are
+// cached for good; the latest version is refreshed every entryModelCacheTTL.
+func (s *EntryModelService) GetEntryModel(name string, version int) (*models.EntryModel, error) {
+	key := fmt.Sprintf("%s@v%d", name, version)
+
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && (version > 0 || time.Since(cached.fetchedAt) < entryModelCacheTTL) {
+		return cached.model, nil
+	}
+
+	var model *models.EntryModel
+	var err error
+	if version > 0 {
+		model, err = s.modelRepo.GetByVersion(name, version)
+	} else {
+		model, err = s.modelRepo.GetLatest(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if model == nil {
+		return nil, fmt.Errorf("entry model %s not found", key)
+	}
+
+	s.mu.Lock()
+	s.cache[key] = &cachedEntryModel{model: model, fetchedAt: time.Now()}
+	s.mu.Unlock()
+
+	return model, nil
+}
+
+// Train fits a model on the entry snapshots taken between opts.From and opts.To whose
+// tokens have outcome labels, and saves it as the next version of opts.Name
+func (s *EntryModelService) Train(ctx context.Context, opts EntryModelTrainingOptions) (*models.EntryModel, error) {
+	if opts.HorizonMin != 1 && opts.HorizonMin != 5 && opts.HorizonMin != 30 {
+		return nil, fmt.Errorf("horizon must be 1, 5 or 30 minutes")
+	}
+
+	names := EntryModelFeatureNames()
+	var samples [][]float64
+	var labels []bool
+	afterID := int64(0)
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		rows, err := s.outcomeRepo.GetLabeledSnapshots(opts.From, opts.To, afterID, outcomeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			if row.Snapshot.Decision != models.FeatureDecisionEntry || row.Snapshot.Features == nil {
+				continue
+			}
+			samples = append(samples, entryFeatureVector(row.Snapshot.Features, names))
+			labels = append(labels, outcomeReturn(row.Outcome, opts.HorizonMin) >= opts.MinReturnPct)
+		}
+
+		if len(rows) < outcomeBatchSize {
+			break
+		}
+		afterID = rows[len(rows)-1].Snapshot.ID
+	}
+
+	if len(samples) < entryModelMinRows {
+		return nil, fmt.Errorf("need at least %d labeled entries to train, found %d", entryModelMinRows, len(samples))
+	}
+
+	// Hold out the most recent rows to measure how the model generalizes
+	split := len(samples) - int(float64(len(samples))*entryModelValidationFraction)
+	model := TrainEntryModel(samples[:split], labels[:split], names)
+	model.Name = opts.Name
+	model.Target = fmt.Sprintf("max_return_%dm_pct>=%g", opts.HorizonMin, opts.MinReturnPct)
+	for key, value := range evaluateEntryModel(model, samples[split:], labels[split:], "validation_") {
+		model.Metrics[key] = value
+	}
+
+	if err := s.modelRepo.Save(model); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Trained entry model %s on %d rows (target %s)", model.Tag(), model.TrainedRows, model.Target)
+	return model, nil
+}
+
+// outcomeReturn returns a token's max return within the horizon in minutes
+func outcomeReturn(outcome *models.TokenOutcome, horizonMin int) float64 {
+	switch horizonMin {
+	case 1:
+		return outcome.MaxReturn1mPct
+	case 5:
+		return outcome.MaxReturn5mPct
+	default:
+		return outcome.MaxReturn30mPct
+	}
+}
+
+// EntryModelFeatureNames returns the features entry models are trained on: every
+// per-window feature of the feature store plus the time since the last trade
+func EntryModelFeatureNames() []string {
+	names := []string{"seconds_since_last_trade"}
+	for _, windowSec := range models.FeatureWindows {
+		for _, column := range windowFeatureColumns {
+			names = append(names, featureColumnName(column, windowSec))
+		}
+	}
+	return names
+}
+
+// entryFeatureVector reads the named features; unknown names and missing windows read as 0
+func entryFeatureVector(features *models.TokenFeatures, names []string) []float64 {
+	values := map[string]float64{
+		"seconds_since_last_trade": float64(features.SecondsSinceLastTrade),
+	}
+	for _, window := range features.Windows {
+		for _, column := range windowFeatureColumns {
+			values[featureColumnName(column, window.WindowSec)] = windowFeatureValues[column](window)
+		}
+	}
+
+	vector := make([]float64, len(names))
+	for i, name := range names {
+		vector[i] = values[name]
+	}
+	return vector
+}
+
+// ScoreEntryModel returns the model's probability that an entry with these features hits
+// the model's target
+func ScoreEntryModel(model *models.EntryModel, features *models.TokenFeatures) float64 {
+	return predictEntryModel(model, entryFeatureVector(features, model.Features))
+}
+
+// TrainEntryModel fits a logistic regression with batch gradient descent on standardized
+// features and reports its fit on the training rows
+func TrainEntryModel(samples [][]float64, labels []bool, names []string) *models.EntryModel {
+	n := len(names)
+	model := &models.EntryModel{
+		ModelType:   models.EntryModelLogistic,
+		Features:    names,
+		Weights:     make([]float64, n),
+		Means:       make([]float64, n),
+		Scales:      make([]float64, n),
+		TrainedRows: len(samples),
+	}
+
+	for j := 0; j < n; j++ {
+		var sum float64
+		for _, sample := range samples {
+			sum += sample[j]
+		}
+		mean := sum / float64(len(samples))
+
+		var variance float64
+		for _, sample := range samples {
+			variance += (sample[j] - mean) * (sample[j] - mean)
+		}
+		scale := math.Sqrt(variance / float64(len(samples)))
+		if scale == 0 {
+			scale = 1
+		}
+		model.Means[j] = mean
+		model.Scales[j] = scale
+	}
+
+	standardized := make([][]float64, len(samples))
+	for i, sample := range samples {
+		standardized[i] = make([]float64, n)
+		for j, value := range sample {
+			standardized[i][j] = (value - model.Means[j]) / model.Scales[j]
+		}
+	}
+
+	gradient := make([]float64, n)
+	for epoch := 0; epoch < entryModelEpochs; epoch++ {
+		for j := range gradient {
+			gradient[j] = 0
+		}
+		var biasGradient float64
+
+		for i, x := range standardized {
+			z := model.Bias
+			for j, value := range x {
+				z += model.Weights[j] * value
+			}
+			residual := sigmoid(z)
+			if labels[i] {
+				residual--
+			}
+			for j, value := range x {
+				gradient[j] += residual * value
+			}
+			biasGradient += residual
+		}
+
+		count := float64(len(standardized))
+		for j := range model.Weights {
+			model.Weights[j] -= entryModelLearningRate * (gradient[j]/count + entryModelL2*model.Weights[j])
+		}
+		model.Bias -= entryModelLearningRate * biasGradient / count
+	}
+
+	model.Metrics = evaluateEntryModel(model, samples, labels, "train_")
+	return model
+}
+
+// evaluateEntryModel returns the log loss, accuracy at 0.5 and positive rate of the model
+// on the rows, with keys prefixed by prefix
+func evaluateEntryModel(model *models.EntryModel, samples [][]float64, labels []bool, prefix string) map[string]float64 {
+	metrics := map[string]float64{prefix + "rows": float64(len(samples))}
+	if len(samples) == 0 {
+		return metrics
+	}
+
+	var logLoss float64
+	var correct, positives int
+	for i, sample := range samples {
+		p := math.Min(math.Max(predictEntryModel(model, sample), 1e-15), 1-1e-15)
+		if labels[i] {
+			positives++
+			logLoss -= math.Log(p)
+		} else {
+			logLoss -= math.Log(1 - p)
+		}
+		if (p >= 0.5) == labels[i] {
+			correct++
+		}
+	}
+
+	count := float64(len(samples))
+	metrics[prefix+"log_loss"] = logLoss / count
+	metrics[prefix+"accuracy"] = float64(correct) / count
+	metrics[prefix+"positive_rate"] = float64(positives) / count
+	return metrics
+}
+
+// predictEntryModel standardizes a raw feature vector and returns the model's probability
+func predictEntryModel(model *models.EntryModel, vector []float64) float64 {
+	z := model.Bias
+	for j, weight := range model.Weights {
+		if j >= len(vector) {
+			break
+		}
+		z += weight * (vector[j] - model.Means[j]) / model.Scales[j]
+	}
+	return sigmoid(z)
+}
+
+// sigmoid maps a log-odds value to a probability
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
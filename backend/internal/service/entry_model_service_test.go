@@ -0,0 +1,180 @@
+// internal/service/entry_model_service_test.go
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEntryModelRepository is a mock implementation of entry model repository
+type MockEntryModelRepository struct {
+	mock.Mock
+}
+
+// Ensure MockEntryModelRepository implements the EntryModelRepositoryInterface
+var _ repository.EntryModelRepositoryInterface = (*MockEntryModelRepository)(nil)
+
+func (m *MockEntryModelRepository) Save(model *models.EntryModel) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+func (m *MockEntryModelRepository) GetLatest(name string) (*models.EntryModel, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EntryModel), args.Error(1)
+}
+
+func (m *MockEntryModelRepository) GetByVersion(name string, version int) (*models.EntryModel, error) {
+	args := m.Called(name, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EntryModel), args.Error(1)
+}
+
+// flowFeatures returns features whose 60s window has the given net flow
+func flowFeatures(netFlowSol float64) *models.TokenFeatures {
+	return &models.TokenFeatures{
+		SecondsSinceLastTrade: 5,
+		Windows: []*models.TokenWindowFeatures{
+			{WindowSec: 60, NetFlowSol: netFlowSol, BuyCount: 3},
+		},
+	}
+}
+
+func TestEntryFeatureVector(t *testing.T) {
+	names := EntryModelFeatureNames()
+	assert.Equal(t, 1+len(models.FeatureWindows)*len(windowFeatureColumns), len(names))
+
+	vector := entryFeatureVector(flowFeatures(2.5), []string{"seconds_since_last_trade", "net_flow_sol_60s", "net_flow_sol_10s", "unknown"})
+	assert.Equal(t, []float64{5, 2.5, 0, 0}, vector)
+}
+
+func TestEntryModelServiceTrain(t *testing.T) {
+	modelRepo := new(MockEntryModelRepository)
+	outcomeRepo := new(MockTokenOutcomeRepository)
+	s := NewEntryModelService(modelRepo, outcomeRepo, logger.New("test"))
+
+	from := time.Unix(1700000000, 0)
+	to := time.Unix(1700086400, 0)
+
+	// Tokens with strong net flow went on to 2x within 5 minutes, the rest didn't
+	var rows []*models.LabeledFeatureSnapshot
+	for i := 0; i < 100; i++ {
+		flow := float64(i%10) - 4.5
+		ret := 10.0
+		if flow > 0 {
+			ret = 150
+		}
+		rows = append(rows, &models.LabeledFeatureSnapshot{
+			Snapshot: &models.TokenFeatureSnapshot{ID: int64(i + 1), Decision: models.FeatureDecisionEntry, Features: flowFeatures(flow)},
+			Outcome:  &models.TokenOutcome{MaxReturn5mPct: ret},
+		})
+	}
+	// Exit snapshots are not training rows
+	rows = append(rows, &models.LabeledFeatureSnapshot{
+		Snapshot: &models.TokenFeatureSnapshot{ID: 101, Decision: models.FeatureDecisionExit, Features: flowFeatures(9)},
+		Outcome:  &models.TokenOutcome{},
+	})
+
+	outcomeRepo.On("GetLabeledSnapshots", from, to, int64(0), outcomeBatchSize).Return(rows, nil).Once()
+	modelRepo.On("Save", mock.AnythingOfType("*models.EntryModel")).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.EntryModel).Version = 1
+	}).Return(nil).Once()
+
+	model, err := s.Train(context.Background(), EntryModelTrainingOptions{
+		Name:         "p2x_5m",
+		HorizonMin:   5,
+		MinReturnPct: 100,
+		From:         from,
+		To:           to,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "p2x_5m@v1", model.Tag())
+	assert.Equal(t, "max_return_5m_pct>=100", model.Target)
+	assert.Equal(t, 80, model.TrainedRows)
+	assert.Equal(t, float64(20), model.Metrics["validation_rows"])
+	assert.Equal(t, float64(1), model.Metrics["validation_accuracy"])
+
+	assert.Greater(t, ScoreEntryModel(model, flowFeatures(4)), 0.9)
+	assert.Less(t, ScoreEntryModel(model, flowFeatures(-4)), 0.1)
+
+	_, err = s.Train(context.Background(), EntryModelTrainingOptions{Name: "p2x_5m", HorizonMin: 10})
+	assert.Error(t, err)
+
+	outcomeRepo.AssertExpectations(t)
+	modelRepo.AssertExpectations(t)
+}
+
+func TestEntryModelServiceGetEntryModel(t *testing.T) {
+	modelRepo := new(MockEntryModelRepository)
+	s := NewEntryModelService(modelRepo, nil, logger.New("test"))
+
+	latest := &models.EntryModel{Name: "p2x_5m", Version: 3}
+	modelRepo.On("GetLatest", "p2x_5m").Return(latest, nil).Once()
+	modelRepo.On("GetByVersion", "p2x_5m", 2).Return(&models.EntryModel{Name: "p2x_5m", Version: 2}, nil).Once()
+	modelRepo.On("GetLatest", "missing").Return(nil, nil).Once()
+
+	// Cached after the first lookup
+	for i := 0; i < 2; i++ {
+		model, err := s.GetEntryModel("p2x_5m", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, model.Version)
+
+		model, err = s.GetEntryModel("p2x_5m", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, model.Version)
+	}
+
+	_, err := s.GetEntryModel("missing", 0)
+	assert.Error(t, err)
+
+	modelRepo.AssertExpectations(t)
+}
+
+func TestAnalyzeEntrySignalModelScore(t *testing.T) {
+	modelRepo := new(MockEntryModelRepository)
+	s := &SimulationService{logger: logger.New("test")}
+	s.SetEntryModelProvider(NewEntryModelService(modelRepo, nil, logger.New("test")))
+	ctx := &SimulationContext{Config: models.StrategyConfig{
+		EntryTimeWindowSec: 60,
+		EntrySignalType:    models.EntrySignalModelScore,
+		EntryModelName:     "p2x_5m",
+		MinModelScore:      0.6,
+	}}
+
+	// P = sigmoid(net_flow_sol_60s)
+	model := &models.EntryModel{
+		Name:     "p2x_5m",
+		Version:  4,
+		Features: []string{"net_flow_sol_60s"},
+		Weights:  []float64{1},
+		Means:    []float64{0},
+		Scales:   []float64{1},
+	}
+	modelRepo.On("GetLatest", "p2x_5m").Return(model, nil).Once()
+
+	entry, data := s.analyzeEntrySignal(ctx, &models.Token{}, nil, nil, nil, nil, flowFeatures(2))
+	assert.True(t, entry)
+	assert.Equal(t, "p2x_5m@v4", data["model_version"])
+	assert.InDelta(t, 0.8808, data["model_score"], 1e-4)
+
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, nil, nil, nil, nil, flowFeatures(0))
+	assert.False(t, entry)
+
+	// No features, no score
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, nil, nil, nil, nil, nil)
+	assert.False(t, entry)
+
+	modelRepo.AssertExpectations(t)
+}
@@ -0,0 +1,354 @@
+// internal/service/feature_store.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// featureMaxWindowSec is the longest rolling window; older trades are dropped
+	featureMaxWindowSec = 300
+
+	// featureDefaultWindowSec is the window feature filters use when a strategy doesn't pick one
+	featureDefaultWindowSec = 60
+
+	// featureSeedTrades is the number of stored trades loaded when a token is first queried
+	featureSeedTrades = 1000
+
+	// featureEvictInterval is how often idle tokens are dropped from the store
+	featureEvictInterval = 1 * time.Minute
+
+	// featureIdleTTL is how long a token is kept without new trades or queries
+	featureIdleTTL = 30 * time.Minute
+)
+
+// windowFeatureColumns names the per-window features in the order datasets and models use
+var windowFeatureColumns = []string{
+	"buy_count", "sell_count", "unique_buyers", "buy_volume_sol", "sell_volume_sol",
+	"volume_sol", "net_flow_sol", "price_velocity_pct", "volatility_pct",
+}
+
+// windowFeatureValues reads each per-window feature by column name
+var windowFeatureValues = map[string]func(*models.TokenWindowFeatures) float64{
+	"buy_count":          func(w *models.TokenWindowFeatures) float64 { return float64(w.BuyCount) },
+	"sell_count":         func(w *models.TokenWindowFeatures) float64 { return float64(w.SellCount) },
+	"unique_buyers":      func(w *models.TokenWindowFeatures) float64 { return float64(w.UniqueBuyers) },
+	"buy_volume_sol":     func(w *models.TokenWindowFeatures) float64 { return w.BuyVolumeSol },
+	"sell_volume_sol":    func(w *models.TokenWindowFeatures) float64 { return w.SellVolumeSol },
+	"volume_sol":         func(w *models.TokenWindowFeatures) float64 { return w.VolumeSol },
+	"net_flow_sol":       func(w *models.TokenWindowFeatures) float64 { return w.NetFlowSol },
+	"price_velocity_pct": func(w *models.TokenWindowFeatures) float64 { return w.PriceVelocityPct },
+	"volatility_pct":     func(w *models.TokenWindowFeatures) float64 { return w.VolatilityPct },
+}
+
+// featureColumnName returns the dataset and model name of a per-window feature
+func featureColumnName(column string, windowSec int) string {
+	return fmt.Sprintf("%s_%ds", column, windowSec)
+}
+
+// TokenFeatureProvider serves rolling-window token features and the trades behind them,
+// and persists feature snapshots taken at strategy decisions
+type TokenFeatureProvider interface {
+	GetTokenFeatures(token *models.Token) (*models.TokenFeatures, error)
+	GetRecentTrades(token *models.Token) ([]*models.Trade, error)
+	SaveSnapshot(snapshot *models.TokenFeatureSnapshot) error
+}
+
+// tokenFeatureBuffer holds the trades of one token inside the longest feature window
+type tokenFeatureBuffer struct {
+	trades      []*models.Trade // Oldest first
+	lastTradeID int64
+	seeded      bool // Whether stored trades have been loaded
+	lastAccess  time.Time
+}
+
+// add inserts a trade the buffer has not seen yet and drops trades that fell out of the
+// longest window
+func (b *tokenFeatureBuffer) add(trade *models.Trade) {
+	if trade.ID != 0 && trade.ID <= b.lastTradeID {
+		return
+	}
+	if trade.ID > b.lastTradeID {
+		b.lastTradeID = trade.ID
+	}
+	b.lastAccess = time.Now()
+
+	b.trades = append(b.trades, trade)
+	if n := len(b.trades); n > 1 && b.trades[n-2].Timestamp > trade.Timestamp {
+		sort.SliceStable(b.trades, func(i, j int) bool {
+			return b.trades[i].Timestamp < b.trades[j].Timestamp
+		})
+	}
+
+	cutoff := b.trades[len(b.trades)-1].Timestamp - featureMaxWindowSec
+	drop := 0
+	for drop < len(b.trades) && b.trades[drop].Timestamp < cutoff {
+		drop++
+	}
+	if drop > 0 {
+		b.trades = append([]*models.Trade(nil), b.trades[drop:]...)
+	}
+}
+
+// FeatureStore maintains rolling-window trading features per token from the trade feed.
+// The simulator reads features and trades from it instead of querying raw trades on every
+// tick, and snapshots features at entries and exits for training and analysis.
+type FeatureStore struct {
+	featureRepo repository.TokenFeatureRepositoryInterface
+	tradeRepo   repository.TradeRepositoryInterface
+	tokenRepo   repository.TokenRepositoryInterface
+	logger      *logger.Logger
+	mu          sync.Mutex
+	buffers     map[int64]*tokenFeatureBuffer
+}
+
+// NewFeatureStore creates a new feature store
+func NewFeatureStore(
+	featureRepo repository.TokenFeatureRepositoryInterface,
+	tradeRepo repository.TradeRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	logger *logger.Logger,
+) *FeatureStore {
+	return &FeatureStore{
+		featureRepo: featureRepo,
+		tradeRepo:   tradeRepo,
+		tokenRepo:   tokenRepo,
+		logger:      logger,
+		buffers:     make(map[int64]*tokenFeatureBuffer),
+	}
+}
+
+// OnTrade implements TradeObserver
+func (s *FeatureStore) OnTrade(trade *models.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffer, ok := s.buffers[trade.TokenID]
+	if !ok {
+		buffer = &tokenFeatureBuffer{}
+		s.buffers[trade.TokenID] = buffer
+	}
+	buffer.add(trade)
+}
+
+// tradesOf returns a copy of a token's buffered trades, oldest first. The first request for
+// a token loads its stored trades so the store also covers activity from before the feed
+// started.
+func (s *FeatureStore) tradesOf(tokenID int64) ([]*models.Trade, error) {
+	s.mu.Lock()
+	buffer, ok := s.buffers[tokenID]
+	if ok && buffer.seeded {
+		buffer.lastAccess = time.Now()
+		trades := append([]*models.Trade(nil), buffer.trades...)
+		s.mu.Unlock()
+		return trades, nil
+	}
+	s.mu.Unlock()
+
+	// Stored trades come newest first
+	stored, err := s.tradeRepo.GetTradesByTokenID(tokenID, featureSeedTrades)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffer, ok = s.buffers[tokenID]
+	if !ok || !buffer.seeded {
+		seeded := &tokenFeatureBuffer{seeded: true}
+		for i := len(stored) - 1; i >= 0; i-- {
+			seeded.add(stored[i])
+		}
+		// Keep live trades the stored query did not return yet
+		if ok {
+			for _, trade := range buffer.trades {
+				seeded.add(trade)
+			}
+		}
+		buffer = seeded
+		s.buffers[tokenID] = buffer
+	}
+	buffer.lastAccess = time.Now()
+
+	return append([]*models.Trade(nil), buffer.trades...), nil
+}
+
+// GetTokenFeatures implements TokenFeatureProvider
+func (s *FeatureStore) GetTokenFeatures(token *models.Token) (*models.TokenFeatures, error) {
+	trades, err := s.tradesOf(token.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	features := ComputeTokenFeatures(trades, time.Now().Unix())
+	features.TokenID = token.ID
+	features.MintAddress = token.MintAddress
+	return features, nil
+}
+
+// GetRecentTrades implements TokenFeatureProvider. Trades come newest first, like the
+// trade repository returns them.
+func (s *FeatureStore) GetRecentTrades(token *models.Token) ([]*models.Trade, error) {
+	trades, err := s.tradesOf(token.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(trades)-1; i < j; i, j = i+1, j-1 {
+		trades[i], trades[j] = trades[j], trades[i]
+	}
+	return trades, nil
+}
+
+// GetTokenFeaturesByMint returns the rolling-window features of a token by mint address
+func (s *FeatureStore) GetTokenFeaturesByMint(mint string) (*models.TokenFeatures, error) {
+	token, err := s.tokenRepo.GetByMintAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found")
+	}
+	return s.GetTokenFeatures(token)
+}
+
+// SaveSnapshot implements TokenFeatureProvider
+func (s *FeatureStore) SaveSnapshot(snapshot *models.TokenFeatureSnapshot) error {
+	id, err := s.featureRepo.SaveSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	snapshot.ID = id
+	return nil
+}
+
+// Start periodically drops tokens that went quiet
+func (s *FeatureStore) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(featureEvictInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Feature store stopped")
+				return
+			case now := <-ticker.C:
+				s.evictIdle(now)
+			}
+		}
+	}()
+}
+
+// evictIdle drops the buffers of tokens nobody has traded or queried recently
+func (s *FeatureStore) evictIdle(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for tokenID, buffer := range s.buffers {
+		if now.Sub(buffer.lastAccess) > featureIdleTTL {
+			delete(s.buffers, tokenID)
+		}
+	}
+}
+
+// ComputeTokenFeatures computes a token's features over each of models.FeatureWindows
+// ending at now (unix seconds)
+func ComputeTokenFeatures(trades []*models.Trade, now int64) *models.TokenFeatures {
+	sorted := make([]*models.Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Timestamp != sorted[j].Timestamp {
+			return sorted[i].Timestamp < sorted[j].Timestamp
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	features := &models.TokenFeatures{
+		AsOf:                  now,
+		SecondsSinceLastTrade: -1,
+		Windows:               make([]*models.TokenWindowFeatures, 0, len(models.FeatureWindows)),
+	}
+	if len(sorted) > 0 {
+		last := sorted[len(sorted)-1]
+		features.LastTradeAt = last.Timestamp
+		features.SecondsSinceLastTrade = now - last.Timestamp
+		if features.SecondsSinceLastTrade < 0 {
+			features.SecondsSinceLastTrade = 0
+		}
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if price := tradePrice(sorted[i]); price > 0 {
+				features.LastPrice = price
+				break
+			}
+		}
+	}
+
+	for _, windowSec := range models.FeatureWindows {
+		features.Windows = append(features.Windows, computeWindowFeatures(sorted, now, windowSec))
+	}
+
+	return features
+}
+
+// computeWindowFeatures computes the features of trades (oldest first) in the window
+// ending at now
+func computeWindowFeatures(trades []*models.Trade, now int64, windowSec int) *models.TokenWindowFeatures {
+	window := &models.TokenWindowFeatures{WindowSec: windowSec}
+	start := now - int64(windowSec)
+
+	buyers := make(map[string]bool)
+	var prices []float64
+	for _, trade := range trades {
+		if trade.Timestamp < start || trade.Timestamp > now {
+			continue
+		}
+
+		if trade.IsBuy {
+			window.BuyCount++
+			window.BuyVolumeSol += trade.SolAmount
+			buyers[trade.UserAddress] = true
+		} else {
+			window.SellCount++
+			window.SellVolumeSol += trade.SolAmount
+		}
+		if price := tradePrice(trade); price > 0 {
+			prices = append(prices, price)
+		}
+	}
+
+	window.UniqueBuyers = len(buyers)
+	window.VolumeSol = window.BuyVolumeSol + window.SellVolumeSol
+	window.NetFlowSol = window.BuyVolumeSol - window.SellVolumeSol
+
+	if len(prices) >= 2 {
+		first, last := prices[0], prices[len(prices)-1]
+		window.PriceVelocityPct = (last - first) / first * 100 / (float64(windowSec) / 60)
+
+		changes := make([]float64, 0, len(prices)-1)
+		var sum float64
+		for i := 1; i < len(prices); i++ {
+			change := (prices[i] - prices[i-1]) / prices[i-1] * 100
+			changes = append(changes, change)
+			sum += change
+		}
+		mean := sum / float64(len(changes))
+		var variance float64
+		for _, change := range changes {
+			variance += (change - mean) * (change - mean)
+		}
+		window.VolatilityPct = math.Sqrt(variance / float64(len(changes)))
+	}
+
+	return window
+}
@@ -0,0 +1,120 @@
+// internal/service/feature_store_test.go
+package service
+
+import (
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTokenFeatures(t *testing.T) {
+	now := int64(1700000300)
+	trades := []*models.Trade{
+		{ID: 1, UserAddress: "w1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 200},
+		{ID: 2, UserAddress: "w2", IsBuy: true, SolAmount: 2, TokenAmount: 1000, Timestamp: now - 50},
+		{ID: 3, UserAddress: "w1", IsBuy: false, SolAmount: 0.5, TokenAmount: 250, Timestamp: now - 20},
+		{ID: 4, UserAddress: "w3", IsBuy: true, SolAmount: 1, TokenAmount: 250, Timestamp: now - 5},
+		{ID: 5, UserAddress: "w3", IsBuy: true, SolAmount: 1, TokenAmount: 250, Timestamp: now - 4},
+	}
+
+	features := ComputeTokenFeatures(trades, now)
+	assert.Equal(t, now, features.AsOf)
+	assert.Equal(t, now-4, features.LastTradeAt)
+	assert.Equal(t, int64(4), features.SecondsSinceLastTrade)
+	assert.InDelta(t, 0.004, features.LastPrice, 1e-12)
+	assert.Len(t, features.Windows, len(models.FeatureWindows))
+
+	ten := features.Window(10)
+	assert.Equal(t, 2, ten.BuyCount)
+	assert.Equal(t, 0, ten.SellCount)
+	assert.Equal(t, 1, ten.UniqueBuyers)
+	assert.InDelta(t, 2.0, ten.NetFlowSol, 1e-9)
+	assert.Zero(t, ten.PriceVelocityPct)
+	assert.Zero(t, ten.VolatilityPct)
+
+	sixty := features.Window(60)
+	assert.Equal(t, 3, sixty.BuyCount)
+	assert.Equal(t, 1, sixty.SellCount)
+	assert.Equal(t, 2, sixty.UniqueBuyers)
+	assert.InDelta(t, 4.0, sixty.BuyVolumeSol, 1e-9)
+	assert.InDelta(t, 0.5, sixty.SellVolumeSol, 1e-9)
+	assert.InDelta(t, 4.5, sixty.VolumeSol, 1e-9)
+	assert.InDelta(t, 3.5, sixty.NetFlowSol, 1e-9)
+	// 0.002 -> 0.002 -> 0.004 -> 0.004 is +100% over one minute
+	assert.InDelta(t, 100.0, sixty.PriceVelocityPct, 1e-9)
+	// Changes of 0%, 100% and 0%
+	assert.InDelta(t, 47.14045, sixty.VolatilityPct, 1e-5)
+
+	five := features.Window(300)
+	assert.Equal(t, 4, five.BuyCount)
+	assert.Equal(t, 3, five.UniqueBuyers)
+	// 0.001 -> 0.004 is +300% over five minutes
+	assert.InDelta(t, 60.0, five.PriceVelocityPct, 1e-9)
+
+	assert.Nil(t, features.Window(15))
+
+	empty := ComputeTokenFeatures(nil, now)
+	assert.Equal(t, int64(-1), empty.SecondsSinceLastTrade)
+	assert.Zero(t, empty.Window(60).BuyCount)
+}
+
+func TestFeatureStoreRecentTrades(t *testing.T) {
+	tradeRepo := new(MockTradeRepository)
+	store := NewFeatureStore(nil, tradeRepo, nil, logger.New("test"))
+	token := &models.Token{ID: 1, MintAddress: "mint1"}
+
+	// Stored trades come newest first; the oldest is outside the longest window
+	tradeRepo.On("GetTradesByTokenID", int64(1), featureSeedTrades).Return([]*models.Trade{
+		{ID: 3, TokenID: 1, IsBuy: true, Timestamp: 1700000400},
+		{ID: 2, TokenID: 1, IsBuy: true, Timestamp: 1700000200},
+		{ID: 1, TokenID: 1, IsBuy: true, Timestamp: 1700000000},
+	}, nil).Once()
+
+	// A live trade that arrived before the first query is kept
+	store.OnTrade(&models.Trade{ID: 4, TokenID: 1, IsBuy: false, Timestamp: 1700000410})
+
+	trades, err := store.GetRecentTrades(token)
+	assert.NoError(t, err)
+	assert.Len(t, trades, 3)
+	assert.Equal(t, int64(4), trades[0].ID)
+	assert.Equal(t, int64(2), trades[2].ID)
+
+	// Later trades come from the feed without another query, and duplicates are ignored
+	store.OnTrade(&models.Trade{ID: 5, TokenID: 1, IsBuy: true, Timestamp: 1700000510})
+	store.OnTrade(&models.Trade{ID: 5, TokenID: 1, IsBuy: true, Timestamp: 1700000510})
+
+	trades, err = store.GetRecentTrades(token)
+	assert.NoError(t, err)
+	assert.Len(t, trades, 3)
+	assert.Equal(t, int64(5), trades[0].ID)
+	assert.Equal(t, int64(3), trades[2].ID)
+
+	tradeRepo.AssertExpectations(t)
+}
+
+func TestFeatureFilterReason(t *testing.T) {
+	features := &models.TokenFeatures{
+		SecondsSinceLastTrade: 20,
+		Windows: []*models.TokenWindowFeatures{
+			{WindowSec: 30, NetFlowSol: -1, PriceVelocityPct: 5, VolatilityPct: 10},
+			{WindowSec: 60, NetFlowSol: 2, PriceVelocityPct: 15, VolatilityPct: 30},
+		},
+	}
+
+	assert.Empty(t, featureFilterReason(models.StrategyConfig{}, features))
+	assert.Empty(t, featureFilterReason(models.StrategyConfig{MinNetFlowSol: 1, MinPriceVelocityPct: 10}, features))
+	assert.Contains(t, featureFilterReason(models.StrategyConfig{MaxVolatilityPct: 20}, features), "volatility")
+	assert.Contains(t, featureFilterReason(models.StrategyConfig{MaxSecondsSinceLastTrade: 10}, features), "no trade")
+
+	config := models.StrategyConfig{FeatureWindowSec: 30, MinNetFlowSol: 1}
+	assert.Contains(t, featureFilterReason(config, features), "net flow")
+	config = models.StrategyConfig{FeatureWindowSec: 30, MinPriceVelocityPct: 10}
+	assert.Contains(t, featureFilterReason(config, features), "price velocity")
+
+	config = models.StrategyConfig{InitialBalance: 10, FixedPositionSizeSol: 1, MaxHoldTimeSec: 60, FeatureWindowSec: 300}
+	assert.NoError(t, validateStrategyConfig(&config))
+	config.FeatureWindowSec = 45
+	assert.Error(t, validateStrategyConfig(&config))
+}
@@ -0,0 +1,201 @@
+// internal/service/feed_health_service.go
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// Feed health statuses
+const (
+	FeedStatusHealthy  = "healthy"
+	FeedStatusDegraded = "degraded"
+	FeedStatusUnknown  = "unknown"
+)
+
+// feedHealthCacheTTL bounds how often IsDegraded hits the database
+const feedHealthCacheTTL = 5 * time.Second
+
+// FeedHealthChecker reports whether market data is currently trustworthy
+type FeedHealthChecker interface {
+	IsDegraded() (bool, string)
+}
+
+// FeedHealth describes the state of the upstream feed as reported by the collector
+type FeedHealth struct {
+	Status                  string            `json:"status"`
+	Reason                  string            `json:"reason,omitempty"`
+	Source                  string            `json:"source"`
+	Connected               bool              `json:"connected"`
+	LastMessageAt           *time.Time        `json:"last_message_at,omitempty"`
+	SecondsSinceLastMessage float64           `json:"seconds_since_last_message"`
+	LastReportAt            *time.Time        `json:"last_report_at,omitempty"`
+	MessagesPerMinute       float64           `json:"messages_per_minute"`
+	TradesPerMinute         float64           `json:"trades_per_minute"`
+	TokensPerMinute         float64           `json:"tokens_per_minute"`
+	OpenGap                 *models.DataGap   `json:"open_gap,omitempty"`
+	RecentGaps              []*models.DataGap `json:"recent_gaps"`
+	CheckedAt               time.Time         `json:"checked_at"`
+}
+
+// FeedHealthService evaluates feed health from the metrics and gaps the collector persists
+type FeedHealthService struct {
+	metricRepo     repository.FeedMetricRepositoryInterface
+	gapRepo        repository.DataGapRepositoryInterface
+	logger         *logger.Logger
+	staleThreshold time.Duration
+	reportPeriod   time.Duration
+
+	cacheMu        sync.Mutex
+	cachedAt       time.Time
+	cachedDegraded bool
+	cachedReason   string
+}
+
+// NewFeedHealthService creates a new feed health service
+func NewFeedHealthService(
+	metricRepo repository.FeedMetricRepositoryInterface,
+	gapRepo repository.DataGapRepositoryInterface,
+	staleThreshold time.Duration,
+	reportPeriod time.Duration,
+	logger *logger.Logger,
+) *FeedHealthService {
+	return &FeedHealthService{
+		metricRepo:     metricRepo,
+		gapRepo:        gapRepo,
+		logger:         logger,
+		staleThreshold: staleThreshold,
+		reportPeriod:   reportPeriod,
+	}
+}
+
+// GetFeedHealth builds a full health report including rates and recent gaps
+func (s *FeedHealthService) GetFeedHealth() (*FeedHealth, error) {
+	now := time.Now()
+	health := &FeedHealth{
+		Source:     FeedSourcePumpFun,
+		CheckedAt:  now,
+		RecentGaps: []*models.DataGap{},
+	}
+
+	recentGaps, err := s.gapRepo.GetRecent(20)
+	if err != nil {
+		return nil, err
+	}
+	if recentGaps != nil {
+		health.RecentGaps = recentGaps
+	}
+
+	openGap, err := s.gapRepo.GetOpen(FeedSourcePumpFun)
+	if err != nil {
+		return nil, err
+	}
+	health.OpenGap = openGap
+
+	// Rates over the last five minutes of reports
+	metrics, err := s.metricRepo.GetSince(FeedSourcePumpFun, now.Add(-5*time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages, trades, tokens int64
+	var covered time.Duration
+	for _, metric := range metrics {
+		messages += metric.MessageCount
+		trades += metric.TradeCount
+		tokens += metric.TokenCount
+		covered += metric.PeriodEnd.Sub(metric.PeriodStart)
+	}
+	if covered > 0 {
+		minutes := covered.Minutes()
+		health.MessagesPerMinute = float64(messages) / minutes
+		health.TradesPerMinute = float64(trades) / minutes
+		health.TokensPerMinute = float64(tokens) / minutes
+	}
+
+	var latest *models.FeedMetric
+	if len(metrics) > 0 {
+		latest = metrics[len(metrics)-1]
+	} else {
+		latest, err = s.metricRepo.GetLatest(FeedSourcePumpFun)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if latest != nil {
+		reportAt := latest.PeriodEnd
+		health.LastReportAt = &reportAt
+		health.Connected = latest.Connected
+		if !latest.LastMessageAt.IsZero() {
+			lastMessageAt := latest.LastMessageAt
+			health.LastMessageAt = &lastMessageAt
+			health.SecondsSinceLastMessage = now.Sub(lastMessageAt).Seconds()
+		}
+	}
+
+	health.Status, health.Reason = s.evaluate(now, latest, openGap)
+	return health, nil
+}
+
+// IsDegraded implements FeedHealthChecker. Results are cached briefly since every
+// simulation iteration asks.
+func (s *FeedHealthService) IsDegraded() (bool, string) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.cachedAt) < feedHealthCacheTTL {
+		return s.cachedDegraded, s.cachedReason
+	}
+
+	openGap, err := s.gapRepo.GetOpen(FeedSourcePumpFun)
+	if err != nil {
+		s.logger.Error("Error checking open data gap: %v", err)
+		return s.cachedDegraded, s.cachedReason
+	}
+
+	latest, err := s.metricRepo.GetLatest(FeedSourcePumpFun)
+	if err != nil {
+		s.logger.Error("Error checking latest feed metric: %v", err)
+		return s.cachedDegraded, s.cachedReason
+	}
+
+	status, reason := s.evaluate(now, latest, openGap)
+	s.cachedAt = now
+	s.cachedDegraded = status == FeedStatusDegraded
+	s.cachedReason = reason
+
+	return s.cachedDegraded, s.cachedReason
+}
+
+// evaluate derives a status from the latest collector report and any open gap
+func (s *FeedHealthService) evaluate(now time.Time, latest *models.FeedMetric, openGap *models.DataGap) (string, string) {
+	if openGap != nil {
+		return FeedStatusDegraded, fmt.Sprintf("feed %s since %s", openGap.Reason, openGap.StartedAt.Format(time.RFC3339))
+	}
+
+	if latest == nil {
+		// No collector has reported yet, so there is nothing to judge the data by
+		return FeedStatusUnknown, "no feed metrics reported"
+	}
+
+	if now.Sub(latest.PeriodEnd) > s.staleThreshold+2*s.reportPeriod {
+		return FeedStatusDegraded, fmt.Sprintf("collector has not reported since %s", latest.PeriodEnd.Format(time.RFC3339))
+	}
+
+	if !latest.Connected {
+		return FeedStatusDegraded, "collector reports feed disconnected"
+	}
+
+	if !latest.LastMessageAt.IsZero() && now.Sub(latest.LastMessageAt) > s.staleThreshold {
+		return FeedStatusDegraded, fmt.Sprintf("no feed messages since %s", latest.LastMessageAt.Format(time.RFC3339))
+	}
+
+	return FeedStatusHealthy, ""
+}
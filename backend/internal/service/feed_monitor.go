@@ -0,0 +1,245 @@
+// internal/service/feed_monitor.go
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket"
+)
+
+// FeedSourcePumpFun identifies the pump.fun upstream in feed metrics and gaps
+const FeedSourcePumpFun = "pumpfun"
+
+// Gap reasons recorded in data_gaps
+const (
+	GapReasonDisconnected  = "disconnected"
+	GapReasonStalled       = "stalled"
+	GapReasonCollectorDown = "collector_down"
+)
+
+// FeedStatsProvider exposes the liveness counters of an upstream feed client
+type FeedStatsProvider interface {
+	Stats() websocket.FeedStats
+}
+
+// FeedMonitor runs in the collector. It records per-period message rates and
+// persists gaps in which the upstream feed was disconnected or silent.
+type FeedMonitor struct {
+	source         string
+	client         FeedStatsProvider
+	events         <-chan websocket.ConnectionEvent
+	metricRepo     repository.FeedMetricRepositoryInterface
+	gapRepo        repository.DataGapRepositoryInterface
+	logger         *logger.Logger
+	staleThreshold time.Duration
+	period         time.Duration
+	openGap        *models.DataGap
+	lastStats      websocket.FeedStats
+	periodStart    time.Time
+	mu             sync.Mutex
+}
+
+// NewFeedMonitor creates a new feed monitor
+func NewFeedMonitor(
+	source string,
+	client FeedStatsProvider,
+	events <-chan websocket.ConnectionEvent,
+	metricRepo repository.FeedMetricRepositoryInterface,
+	gapRepo repository.DataGapRepositoryInterface,
+	staleThreshold time.Duration,
+	period time.Duration,
+	logger *logger.Logger,
+) *FeedMonitor {
+	return &FeedMonitor{
+		source:         source,
+		client:         client,
+		events:         events,
+		metricRepo:     metricRepo,
+		gapRepo:        gapRepo,
+		logger:         logger,
+		staleThreshold: staleThreshold,
+		period:         period,
+	}
+}
+
+// Start restores gap state left by a previous run and monitors the feed until the
+// context is cancelled
+func (m *FeedMonitor) Start(ctx context.Context) {
+	m.recover(time.Now())
+
+	m.mu.Lock()
+	m.lastStats = m.client.Stats()
+	m.periodStart = time.Now()
+	m.mu.Unlock()
+
+	go func() {
+		livenessTicker := time.NewTicker(1 * time.Second)
+		periodTicker := time.NewTicker(m.period)
+		defer livenessTicker.Stop()
+		defer periodTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				m.recordPeriod(time.Now())
+				m.logger.Info("Feed monitor stopped")
+				return
+			case event := <-m.events:
+				m.logger.Warn("Feed %s at %s: %s", event.Type, event.Time.Format(time.RFC3339), event.Reason)
+				m.checkLiveness(time.Now())
+			case now := <-livenessTicker.C:
+				m.checkLiveness(now)
+			case now := <-periodTicker.C:
+				m.recordPeriod(now)
+			}
+		}
+	}()
+}
+
+// recover picks up a gap left open by a previous collector run, or records the
+// downtime since the last reported message as a gap
+func (m *FeedMonitor) recover(now time.Time) {
+	openGap, err := m.gapRepo.GetOpen(m.source)
+	if err != nil {
+		m.logger.Error("Error loading open data gap: %v", err)
+		return
+	}
+	if openGap != nil {
+		m.mu.Lock()
+		m.openGap = openGap
+		m.mu.Unlock()
+		m.logger.Warn("Resuming open data gap %d started at %s", openGap.ID, openGap.StartedAt.Format(time.RFC3339))
+		return
+	}
+
+	latest, err := m.metricRepo.GetLatest(m.source)
+	if err != nil {
+		m.logger.Error("Error loading latest feed metric: %v", err)
+		return
+	}
+	if latest == nil || latest.LastMessageAt.IsZero() {
+		return
+	}
+
+	if now.Sub(latest.LastMessageAt) > m.staleThreshold {
+		m.openGapAt(latest.LastMessageAt, GapReasonCollectorDown)
+	}
+}
+
+// checkLiveness opens a gap when the feed is disconnected or silent and closes it
+// once messages flow again
+func (m *FeedMonitor) checkLiveness(now time.Time) {
+	stats := m.client.Stats()
+
+	lastActivity := stats.LastMessageAt
+	if stats.ConnectedAt.After(lastActivity) {
+		lastActivity = stats.ConnectedAt
+	}
+
+	m.mu.Lock()
+	hasOpenGap := m.openGap != nil
+	var gapStart time.Time
+	if hasOpenGap {
+		gapStart = m.openGap.StartedAt
+	}
+	m.mu.Unlock()
+
+	switch {
+	case !stats.Connected:
+		if !hasOpenGap {
+			start := stats.DisconnectedAt
+			if start.IsZero() {
+				start = now
+			}
+			m.openGapAt(start, GapReasonDisconnected)
+		}
+
+	case now.Sub(lastActivity) > m.staleThreshold:
+		if !hasOpenGap {
+			start := lastActivity
+			if start.IsZero() {
+				start = now
+			}
+			m.openGapAt(start, GapReasonStalled)
+		}
+
+	case hasOpenGap && stats.LastMessageAt.After(gapStart):
+		m.closeGap(now)
+	}
+}
+
+// openGapAt persists a new open gap
+func (m *FeedMonitor) openGapAt(start time.Time, reason string) {
+	gap := &models.DataGap{
+		Source:    m.source,
+		StartedAt: start,
+		Reason:    reason,
+	}
+
+	id, err := m.gapRepo.Save(gap)
+	if err != nil {
+		m.logger.Error("Error saving data gap: %v", err)
+		return
+	}
+	gap.ID = id
+
+	m.mu.Lock()
+	m.openGap = gap
+	m.mu.Unlock()
+
+	m.logger.Warn("Data gap %d opened (%s) at %s", id, reason, start.Format(time.RFC3339))
+}
+
+// closeGap marks the open gap as ended
+func (m *FeedMonitor) closeGap(end time.Time) {
+	m.mu.Lock()
+	gap := m.openGap
+	m.mu.Unlock()
+
+	if gap == nil {
+		return
+	}
+
+	if err := m.gapRepo.Close(gap.ID, end); err != nil {
+		m.logger.Error("Error closing data gap %d: %v", gap.ID, err)
+		return
+	}
+
+	m.mu.Lock()
+	m.openGap = nil
+	m.mu.Unlock()
+
+	m.logger.Info("Data gap %d closed after %.1fs", gap.ID, end.Sub(gap.StartedAt).Seconds())
+}
+
+// recordPeriod persists the message counts received since the previous period
+func (m *FeedMonitor) recordPeriod(now time.Time) {
+	stats := m.client.Stats()
+
+	m.mu.Lock()
+	previous := m.lastStats
+	periodStart := m.periodStart
+	m.lastStats = stats
+	m.periodStart = now
+	m.mu.Unlock()
+
+	metric := &models.FeedMetric{
+		Source:        m.source,
+		PeriodStart:   periodStart,
+		PeriodEnd:     now,
+		MessageCount:  int64(stats.Messages - previous.Messages),
+		TradeCount:    int64(stats.Trades - previous.Trades),
+		TokenCount:    int64(stats.Tokens - previous.Tokens),
+		Connected:     stats.Connected,
+		LastMessageAt: stats.LastMessageAt,
+	}
+
+	if _, err := m.metricRepo.Save(metric); err != nil {
+		m.logger.Error("Error saving feed metric: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// internal/service/feed_monitor_test.go
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFeedMetricRepository is a mock implementation of feed metric repository
+type MockFeedMetricRepository struct {
+	mock.Mock
+}
+
+var _ repository.FeedMetricRepositoryInterface = (*MockFeedMetricRepository)(nil)
+
+func (m *MockFeedMetricRepository) Save(metric *models.FeedMetric) (int64, error) {
+	args := m.Called(metric)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFeedMetricRepository) GetLatest(source string) (*models.FeedMetric, error) {
+	args := m.Called(source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FeedMetric), args.Error(1)
+}
+
+func (m *MockFeedMetricRepository) GetSince(source string, since time.Time) ([]*models.FeedMetric, error) {
+	args := m.Called(source, since)
+	return args.Get(0).([]*models.FeedMetric), args.Error(1)
+}
+
+// MockDataGapRepository is a mock implementation of data gap repository
+type MockDataGapRepository struct {
+	mock.Mock
+}
+
+var _ repository.DataGapRepositoryInterface = (*MockDataGapRepository)(nil)
+
+func (m *MockDataGapRepository) Save(gap *models.DataGap) (int64, error) {
+	args := m.Called(gap)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDataGapRepository) Close(id int64, endedAt time.Time) error {
+	args := m.Called(id, endedAt)
+	return args.Error(0)
+}
+
+func (m *MockDataGapRepository) GetOpen(source string) (*models.DataGap, error) {
+	args := m.Called(source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DataGap), args.Error(1)
+}
+
+func (m *MockDataGapRepository) GetOverlapping(from, to time.Time) ([]*models.DataGap, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*models.DataGap), args.Error(1)
+}
+
+func (m *MockDataGapRepository) GetRecent(limit int) ([]*models.DataGap, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]*models.DataGap), args.Error(1)
+}
+
+// fakeFeedStats returns fixed feed stats
+type fakeFeedStats struct {
+	stats websocket.FeedStats
+}
+
+func (f *fakeFeedStats) Stats() websocket.FeedStats {
+	return f.stats
+}
+
+func TestFeedMonitorOpensAndClosesGap(t *testing.T) {
+	now := time.Now()
+	client := &fakeFeedStats{stats: websocket.FeedStats{
+		Connected:     true,
+		ConnectedAt:   now.Add(-2 * time.Minute),
+		LastMessageAt: now.Add(-45 * time.Second),
+	}}
+	metricRepo := new(MockFeedMetricRepository)
+	gapRepo := new(MockDataGapRepository)
+
+	monitor := NewFeedMonitor(FeedSourcePumpFun, client, nil, metricRepo, gapRepo, 30*time.Second, 10*time.Second, logger.New("test"))
+
+	// Silent for longer than the threshold: a stalled gap starts at the last message
+	gapRepo.On("Save", mock.MatchedBy(func(gap *models.DataGap) bool {
+		return gap.Reason == GapReasonStalled && gap.StartedAt.Equal(client.stats.LastMessageAt)
+	})).Return(int64(7), nil).Once()
+
+	monitor.checkLiveness(now)
+	monitor.checkLiveness(now.Add(time.Second))
+	gapRepo.AssertNumberOfCalls(t, "Save", 1)
+
+	// Messages flow again: the gap is closed
+	client.stats.LastMessageAt = now.Add(2 * time.Second)
+	closedAt := now.Add(3 * time.Second)
+	gapRepo.On("Close", int64(7), closedAt).Return(nil).Once()
+
+	monitor.checkLiveness(closedAt)
+
+	gapRepo.AssertExpectations(t)
+	assert.Nil(t, monitor.openGap)
+}
+
+func TestFeedMonitorDisconnectGap(t *testing.T) {
+	now := time.Now()
+	disconnectedAt := now.Add(-5 * time.Second)
+	client := &fakeFeedStats{stats: websocket.FeedStats{
+		Connected:      false,
+		LastMessageAt:  now.Add(-6 * time.Second),
+		DisconnectedAt: disconnectedAt,
+	}}
+	metricRepo := new(MockFeedMetricRepository)
+	gapRepo := new(MockDataGapRepository)
+
+	monitor := NewFeedMonitor(FeedSourcePumpFun, client, nil, metricRepo, gapRepo, 30*time.Second, 10*time.Second, logger.New("test"))
+
+	gapRepo.On("Save", mock.MatchedBy(func(gap *models.DataGap) bool {
+		return gap.Reason == GapReasonDisconnected && gap.StartedAt.Equal(disconnectedAt)
+	})).Return(int64(3), nil).Once()
+
+	monitor.checkLiveness(now)
+
+	gapRepo.AssertExpectations(t)
+	assert.NotNil(t, monitor.openGap)
+	assert.Equal(t, int64(3), monitor.openGap.ID)
+}
+
+func TestFeedMonitorRecoverCollectorDowntime(t *testing.T) {
+	now := time.Now()
+	lastMessageAt := now.Add(-10 * time.Minute)
+	client := &fakeFeedStats{}
+	metricRepo := new(MockFeedMetricRepository)
+	gapRepo := new(MockDataGapRepository)
+
+	monitor := NewFeedMonitor(FeedSourcePumpFun, client, nil, metricRepo, gapRepo, 30*time.Second, 10*time.Second, logger.New("test"))
+
+	gapRepo.On("GetOpen", FeedSourcePumpFun).Return(nil, nil)
+	metricRepo.On("GetLatest", FeedSourcePumpFun).Return(&models.FeedMetric{
+		Source:        FeedSourcePumpFun,
+		PeriodEnd:     lastMessageAt,
+		Connected:     true,
+		LastMessageAt: lastMessageAt,
+	}, nil)
+	gapRepo.On("Save", mock.MatchedBy(func(gap *models.DataGap) bool {
+		return gap.Reason == GapReasonCollectorDown && gap.StartedAt.Equal(lastMessageAt)
+	})).Return(int64(1), nil).Once()
+
+	monitor.recover(now)
+
+	gapRepo.AssertExpectations(t)
+	metricRepo.AssertExpectations(t)
+}
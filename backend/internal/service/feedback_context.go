@@ -0,0 +1,458 @@
+// internal/service/feedback_context.go
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// feedbackRecentRuns is how many completed simulation runs, besides the running one, the
+	// feedback summarizes
+	feedbackRecentRuns = 3
+
+	// feedbackMaxFindings is how many analyzer findings the feedback quotes
+	feedbackMaxFindings = 5
+
+	// feedbackMinStrategyTrades is how many closed trades a strategy needs to count towards
+	// the parameter correlations
+	feedbackMinStrategyTrades = 3
+
+	// feedbackMinCorrelationStrategies is how many strategies a parameter correlation needs
+	feedbackMinCorrelationStrategies = 4
+
+	// feedbackMinCorrelation is the weakest correlation the feedback reports
+	feedbackMinCorrelation = 0.3
+
+	// feedbackCharsPerToken estimates prompt tokens from text length
+	feedbackCharsPerToken = 4
+
+	// feedbackMinTruncatedChars is the shortest a line is cut to before it is left out
+	feedbackMinTruncatedChars = 80
+)
+
+// feedbackHoldBuckets group closed trades by how long they were held, in seconds
+var feedbackHoldBuckets = []struct {
+	label  string
+	maxSec int64
+}{
+	{"under 30s", 30},
+	{"30s to 2m", 120},
+	{"2m to 10m", 600},
+	{"over 10m", math.MaxInt64},
+}
+
+// feedbackCorrelationParams are the strategy parameters correlated with outcomes
+var feedbackCorrelationParams = []string{
+	"marketCapThreshold",
+	"minBuysForEntry",
+	"entryTimeWindowSec",
+	"takeProfitPct",
+	"stopLossPct",
+	"maxHoldTimeSec",
+	"fixedPositionSizeSol",
+}
+
+// FeedbackContextBuilder summarizes recent simulation runs for strategy prompts, so the model
+// learns why strategies lost money and not just which ones won. The summary covers the exit
+// reasons that lost the most, PnL by hold time, how strategy parameters correlated with
+// returns and the performance analyzer's findings on the weakest results. It is cut to a
+// token budget, dropping the least important parts first.
+type FeedbackContextBuilder struct {
+	strategyRepo         repository.StrategyRepositoryInterface
+	simulatedTradeRepo   repository.SimulatedTradeRepositoryInterface
+	simulationRunRepo    repository.SimulationRunRepositoryInterface
+	simulationResultRepo repository.SimulationResultRepositoryInterface
+	tokenBudget          int
+}
+
+// NewFeedbackContextBuilder creates a new feedback context builder whose summaries fit in
+// tokenBudget prompt tokens
+func NewFeedbackContextBuilder(
+	strategyRepo repository.StrategyRepositoryInterface,
+	simulatedTradeRepo repository.SimulatedTradeRepositoryInterface,
+	simulationRunRepo repository.SimulationRunRepositoryInterface,
+	simulationResultRepo repository.SimulationResultRepositoryInterface,
+	tokenBudget int,
+) *FeedbackContextBuilder {
+	return &FeedbackContextBuilder{
+		strategyRepo:         strategyRepo,
+		simulatedTradeRepo:   simulatedTradeRepo,
+		simulationRunRepo:    simulationRunRepo,
+		simulationResultRepo: simulationResultRepo,
+		tokenBudget:          tokenBudget,
+	}
+}
+
+// feedbackSection is a titled list of summary lines, most important first
+type feedbackSection struct {
+	title string
+	lines []string
+}
+
+// feedbackStrategyOutcome is how one strategy did across the recent runs
+type feedbackStrategyOutcome struct {
+	config    models.JSONB
+	trades    int
+	netPnL    float64
+	staked    float64
+	returnPct float64 // Net PnL per SOL staked, in percent
+}
+
+// Build summarizes the recent simulation runs. With strategyID 0 the exit reasons, hold
+// times and findings cover every strategy in the runs; otherwise they cover all of that
+// strategy's trades and analyses. The parameter correlations always compare the
+// strategies in the recent runs. An empty string means there is nothing to summarize yet.
+func (b *FeedbackContextBuilder) Build(strategyID int64) (string, error) {
+	runs, err := b.recentRuns()
+	if err != nil {
+		return "", err
+	}
+
+	var runTrades []*models.SimulatedTrade
+	for _, run := range runs {
+		trades, err := b.simulatedTradeRepo.GetBySimulationRun(run.ID)
+		if err != nil {
+			return "", fmt.Errorf("error getting trades of simulation run %d: %v", run.ID, err)
+		}
+		runTrades = append(runTrades, closedFeedbackTrades(trades)...)
+	}
+
+	trades := runTrades
+	var results []*models.SimulationResult
+	if strategyID != 0 {
+		strategyTrades, err := b.simulatedTradeRepo.GetByStrategyID(strategyID)
+		if err != nil {
+			return "", fmt.Errorf("error getting trades of strategy %d: %v", strategyID, err)
+		}
+		trades = closedFeedbackTrades(strategyTrades)
+
+		results, err = b.simulationResultRepo.GetByStrategy(strategyID, feedbackMaxFindings)
+		if err != nil {
+			return "", fmt.Errorf("error getting results of strategy %d: %v", strategyID, err)
+		}
+	} else {
+		for _, run := range runs {
+			runResults, err := b.simulationResultRepo.GetBySimulationRun(run.ID)
+			if err != nil {
+				return "", fmt.Errorf("error getting results of simulation run %d: %v", run.ID, err)
+			}
+			results = append(results, runResults...)
+		}
+	}
+
+	correlations, err := b.correlationLines(runTrades)
+	if err != nil {
+		return "", err
+	}
+	findings, err := b.findingLines(results)
+	if err != nil {
+		return "", err
+	}
+
+	sections := []feedbackSection{
+		{title: fmt.Sprintf("Exit reasons, biggest losses first (%d closed trades):", len(trades)), lines: exitReasonLines(trades)},
+		{title: "PnL by hold time:", lines: holdTimeLines(trades)},
+		{title: "Parameters versus return per SOL staked across recent strategies (correlation from -1 to 1):", lines: correlations},
+		{title: "Performance analyst findings, weakest results first:", lines: findings},
+	}
+
+	return renderFeedback(sections, b.tokenBudget), nil
+}
+
+// recentRuns returns the running simulation and the most recent completed ones
+func (b *FeedbackContextBuilder) recentRuns() ([]*models.SimulationRun, error) {
+	var runs []*models.SimulationRun
+	current, err := b.simulationRunRepo.GetCurrent()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current simulation run: %v", err)
+	}
+	if current != nil {
+		runs = append(runs, current)
+	}
+
+	completed, err := b.simulationRunRepo.GetByStatus("completed", feedbackRecentRuns)
+	if err != nil {
+		return nil, fmt.Errorf("error getting completed simulation runs: %v", err)
+	}
+	for _, run := range completed {
+		if current == nil || run.ID != current.ID {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+// closedFeedbackTrades returns the trades that were closed with a known PnL
+func closedFeedbackTrades(trades []*models.SimulatedTrade) []*models.SimulatedTrade {
+	var closed []*models.SimulatedTrade
+	for _, trade := range trades {
+		if (trade.Status == "completed" || trade.Status == "closed") && trade.ProfitLoss != nil {
+			closed = append(closed, trade)
+		}
+	}
+	return closed
+}
+
+// feedbackStats accumulates the PnL of a group of trades
+type feedbackStats struct {
+	trades int
+	wins   int
+	netPnL float64
+}
+
+func (s *feedbackStats) add(trade *models.SimulatedTrade) {
+	s.trades++
+	s.netPnL += *trade.ProfitLoss
+	if *trade.ProfitLoss > 0 {
+		s.wins++
+	}
+}
+
+func (s *feedbackStats) String() string {
+	return fmt.Sprintf("%d trades, %.0f%% win rate, %.4f SOL total, %.4f SOL average",
+		s.trades, float64(s.wins)/float64(s.trades)*100, s.netPnL, s.netPnL/float64(s.trades))
+}
+
+// exitReasonLines summarizes trades by exit reason, most net loss first
+func exitReasonLines(trades []*models.SimulatedTrade) []string {
+	stats := make(map[string]*feedbackStats)
+	for _, trade := range trades {
+		reason := "unknown"
+		if trade.ExitReason != nil && *trade.ExitReason != "" {
+			reason = *trade.ExitReason
+		}
+		if stats[reason] == nil {
+			stats[reason] = &feedbackStats{}
+		}
+		stats[reason].add(trade)
+	}
+
+	reasons := make([]string, 0, len(stats))
+	for reason := range stats {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if stats[reasons[i]].netPnL != stats[reasons[j]].netPnL {
+			return stats[reasons[i]].netPnL < stats[reasons[j]].netPnL
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	lines := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		lines = append(lines, fmt.Sprintf("%s: %s", reason, stats[reason]))
+	}
+	return lines
+}
+
+// holdTimeLines summarizes trades by how long they were held
+func holdTimeLines(trades []*models.SimulatedTrade) []string {
+	stats := make([]feedbackStats, len(feedbackHoldBuckets))
+	for _, trade := range trades {
+		if trade.ExitTimestamp == nil {
+			continue
+		}
+		held := *trade.ExitTimestamp - trade.EntryTimestamp
+		for i, bucket := range feedbackHoldBuckets {
+			if held < bucket.maxSec {
+				stats[i].add(trade)
+				break
+			}
+		}
+	}
+
+	var lines []string
+	for i, bucket := range feedbackHoldBuckets {
+		if stats[i].trades > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", bucket.label, &stats[i]))
+		}
+	}
+	return lines
+}
+
+// correlationLines correlates each strategy parameter with the return per SOL staked of the
+// strategies that traded in the recent runs, strongest correlation first
+func (b *FeedbackContextBuilder) correlationLines(trades []*models.SimulatedTrade) ([]string, error) {
+	outcomes := make(map[int64]*feedbackStrategyOutcome)
+	for _, trade := range trades {
+		outcome := outcomes[trade.StrategyID]
+		if outcome == nil {
+			outcome = &feedbackStrategyOutcome{}
+			outcomes[trade.StrategyID] = outcome
+		}
+		outcome.trades++
+		outcome.netPnL += *trade.ProfitLoss
+		outcome.staked += trade.PositionSize
+	}
+
+	var strategies []*feedbackStrategyOutcome
+	for strategyID, outcome := range outcomes {
+		if outcome.trades < feedbackMinStrategyTrades || outcome.staked <= 0 {
+			continue
+		}
+		strategy, err := b.strategyRepo.GetByID(strategyID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting strategy %d: %v", strategyID, err)
+		}
+		if strategy == nil {
+			continue
+		}
+		outcome.config = strategy.Config
+		outcome.returnPct = outcome.netPnL / outcome.staked * 100
+		strategies = append(strategies, outcome)
+	}
+	if len(strategies) < feedbackMinCorrelationStrategies {
+		return nil, nil
+	}
+
+	type correlation struct {
+		param       string
+		coefficient float64
+		strategies  int
+	}
+	var correlations []correlation
+	for _, param := range feedbackCorrelationParams {
+		var values, returns []float64
+		for _, outcome := range strategies {
+			if value, ok := outcome.config[param].(float64); ok {
+				values = append(values, value)
+				returns = append(returns, outcome.returnPct)
+			}
+		}
+		if len(values) < feedbackMinCorrelationStrategies {
+			continue
+		}
+		coefficient, ok := pearsonCorrelation(values, returns)
+		if !ok || math.Abs(coefficient) < feedbackMinCorrelation {
+			continue
+		}
+		correlations = append(correlations, correlation{param, coefficient, len(values)})
+	}
+
+	sort.SliceStable(correlations, func(i, j int) bool {
+		return math.Abs(correlations[i].coefficient) > math.Abs(correlations[j].coefficient)
+	})
+
+	lines := make([]string, 0, len(correlations))
+	for _, c := range correlations {
+		direction := "higher values did better"
+		if c.coefficient < 0 {
+			direction = "higher values did worse"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %.2f over %d strategies, %s", c.param, c.coefficient, c.strategies, direction))
+	}
+	return lines, nil
+}
+
+// pearsonCorrelation returns the correlation coefficient of xs and ys, and false when
+// either doesn't vary
+func pearsonCorrelation(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
+
+// findingLines quotes the analyses of the weakest results, one per strategy
+func (b *FeedbackContextBuilder) findingLines(results []*models.SimulationResult) ([]string, error) {
+	var analyzed []*models.SimulationResult
+	for _, result := range results {
+		if strings.TrimSpace(result.Analysis) != "" {
+			analyzed = append(analyzed, result)
+		}
+	}
+	sort.SliceStable(analyzed, func(i, j int) bool { return analyzed[i].ROI < analyzed[j].ROI })
+
+	names := make(map[int64]string)
+	var lines []string
+	for _, result := range analyzed {
+		if len(lines) >= feedbackMaxFindings {
+			break
+		}
+		if _, seen := names[result.StrategyID]; seen {
+			continue
+		}
+
+		name := fmt.Sprintf("Strategy #%d", result.StrategyID)
+		strategy, err := b.strategyRepo.GetByID(result.StrategyID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting strategy %d: %v", result.StrategyID, err)
+		}
+		if strategy != nil {
+			name = strategy.Name
+		}
+		names[result.StrategyID] = name
+
+		analysis := strings.Join(strings.Fields(result.Analysis), " ")
+		lines = append(lines, fmt.Sprintf("%s (%s, ROI %.2f%%, %d trades): %s",
+			name, result.PerformanceRating, result.ROI, result.TradeCount, analysis))
+	}
+	return lines, nil
+}
+
+// renderFeedback writes the sections in order until tokenBudget is used up. A line that
+// doesn't fit is cut at a word if enough of it fits, and everything after it is left out.
+func renderFeedback(sections []feedbackSection, tokenBudget int) string {
+	remaining := tokenBudget * feedbackCharsPerToken
+	var b strings.Builder
+
+	for _, section := range sections {
+		header := section.title + "\n"
+		if b.Len() > 0 {
+			header = "\n" + header
+		}
+
+		for i, line := range section.lines {
+			prefix := "- "
+			if i == 0 {
+				prefix = header + prefix
+			}
+			text := prefix + line + "\n"
+
+			if len(text) > remaining {
+				room := remaining - len(prefix) - len("...\n")
+				if room < feedbackMinTruncatedChars {
+					return strings.TrimRight(b.String(), "\n")
+				}
+				for !utf8.RuneStart(line[room]) {
+					room--
+				}
+				cut := line[:room]
+				if space := strings.LastIndex(cut, " "); space > 0 {
+					cut = cut[:space]
+				}
+				b.WriteString(prefix + cut + "...\n")
+				return strings.TrimRight(b.String(), "\n")
+			}
+
+			b.WriteString(text)
+			remaining -= len(text)
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,174 @@
+// internal/service/feedback_context_test.go
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type feedbackFixture struct {
+	strategyRepo         *memory.StrategyRepository
+	simulatedTradeRepo   *memory.SimulatedTradeRepository
+	simulationRunRepo    *memory.SimulationRunRepository
+	simulationResultRepo *memory.SimulationResultRepository
+	strategyIDs          []int64
+}
+
+// newFeedbackFixture stores a completed run of four strategies whose returns fall as their
+// stop loss widens. Winners take profit within 30 seconds and losers stop out after 5
+// minutes. The two losing strategies have analyses.
+func newFeedbackFixture(t *testing.T) *feedbackFixture {
+	store := memory.NewStore()
+	f := &feedbackFixture{
+		strategyRepo:         memory.NewStrategyRepository(store),
+		simulatedTradeRepo:   memory.NewSimulatedTradeRepository(store),
+		simulationRunRepo:    memory.NewSimulationRunRepository(store),
+		simulationResultRepo: memory.NewSimulationResultRepository(store),
+	}
+
+	runID, err := f.simulationRunRepo.Save(&models.SimulationRun{StartTime: time.Now(), EndTime: time.Now(), Status: "completed"})
+	require.NoError(t, err)
+
+	for i, pnl := range []float64{0.2, 0.1, -0.1, -0.2} {
+		strategyID, err := f.strategyRepo.Save(&models.Strategy{
+			Name:   string(rune('A' + i)),
+			Config: models.JSONB{"marketCapThreshold": 8000.0, "stopLossPct": float64(10 * (i + 1))},
+		})
+		require.NoError(t, err)
+		f.strategyIDs = append(f.strategyIDs, strategyID)
+
+		reason, held := "take_profit", int64(20)
+		if pnl < 0 {
+			reason, held = "stop_loss", 300
+		}
+		for j := 0; j < 3; j++ {
+			profitLoss, exitTime := pnl, int64(1000+held)
+			_, err := f.simulatedTradeRepo.Save(&models.SimulatedTrade{
+				StrategyID:      strategyID,
+				TokenID:         int64(j + 1),
+				SimulationRunID: &runID,
+				EntryPrice:      0.001,
+				EntryTimestamp:  1000,
+				ExitTimestamp:   &exitTime,
+				PositionSize:    1,
+				ProfitLoss:      &profitLoss,
+				Status:          "completed",
+				ExitReason:      &reason,
+			})
+			require.NoError(t, err)
+		}
+
+		if pnl < 0 {
+			_, err := f.simulationResultRepo.Save(&models.SimulationResult{
+				SimulationRunID:   runID,
+				StrategyID:        strategyID,
+				ROI:               pnl * 100,
+				TradeCount:        3,
+				PerformanceRating: "poor",
+				Analysis:          "Stops out\n  before the move.",
+				CreatedAt:         time.Now(),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	return f
+}
+
+func (f *feedbackFixture) builder(tokenBudget int) *FeedbackContextBuilder {
+	return NewFeedbackContextBuilder(f.strategyRepo, f.simulatedTradeRepo, f.simulationRunRepo, f.simulationResultRepo, tokenBudget)
+}
+
+func TestFeedbackContextBuild(t *testing.T) {
+	f := newFeedbackFixture(t)
+
+	feedback, err := f.builder(600).Build(0)
+	require.NoError(t, err)
+
+	assert.Equal(t, `Exit reasons, biggest losses first (12 closed trades):
+- stop_loss: 6 trades, 0% win rate, -0.9000 SOL total, -0.1500 SOL average
+- take_profit: 6 trades, 100% win rate, 0.9000 SOL total, 0.1500 SOL average
+
+PnL by hold time:
+- under 30s: 6 trades, 100% win rate, 0.9000 SOL total, 0.1500 SOL average
+- 2m to 10m: 6 trades, 0% win rate, -0.9000 SOL total, -0.1500 SOL average
+
+Parameters versus return per SOL staked across recent strategies (correlation from -1 to 1):
+- stopLossPct: -0.99 over 4 strategies, higher values did worse
+
+Performance analyst findings, weakest results first:
+- D (poor, ROI -20.00%, 3 trades): Stops out before the move.
+- C (poor, ROI -10.00%, 3 trades): Stops out before the move.`, feedback)
+}
+
+func TestFeedbackContextBuildForStrategy(t *testing.T) {
+	f := newFeedbackFixture(t)
+
+	feedback, err := f.builder(600).Build(f.strategyIDs[3])
+	require.NoError(t, err)
+
+	assert.Contains(t, feedback, "(3 closed trades):\n- stop_loss: 3 trades")
+	assert.NotContains(t, feedback, "take_profit")
+	assert.Contains(t, feedback, "- stopLossPct: -0.99 over 4 strategies", "correlations compare every recent strategy")
+	assert.Contains(t, feedback, "- D (poor")
+	assert.NotContains(t, feedback, "- C (poor")
+}
+
+func TestFeedbackContextTokenBudget(t *testing.T) {
+	f := newFeedbackFixture(t)
+
+	feedback, err := f.builder(40).Build(0)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(feedback), 40*feedbackCharsPerToken)
+	assert.Equal(t, "Exit reasons, biggest losses first (12 closed trades):\n- stop_loss: 6 trades, 0% win rate, -0.9000 SOL total, -0.1500 SOL average", feedback)
+
+	empty, err := NewFeedbackContextBuilder(
+		memory.NewStrategyRepository(memory.NewStore()),
+		memory.NewSimulatedTradeRepository(memory.NewStore()),
+		memory.NewSimulationRunRepository(memory.NewStore()),
+		memory.NewSimulationResultRepository(memory.NewStore()),
+		600,
+	).Build(0)
+	require.NoError(t, err)
+	assert.Empty(t, empty, "no runs, no feedback")
+}
+
+func TestRenderFeedbackTruncatesLongLines(t *testing.T) {
+	sections := []feedbackSection{
+		{title: "Short:", lines: []string{"first"}},
+		{title: "Findings:", lines: []string{strings.Repeat("word ", 100), "never shown"}},
+	}
+
+	feedback := renderFeedback(sections, 50)
+	assert.LessOrEqual(t, len(feedback), 50*feedbackCharsPerToken)
+	assert.True(t, strings.HasPrefix(feedback, "Short:\n- first\n\nFindings:\n- word word"))
+	assert.True(t, strings.HasSuffix(feedback, "word..."))
+	assert.NotContains(t, feedback, "never shown")
+}
+
+func TestGenerateOptimizedStrategyIncludesFeedback(t *testing.T) {
+	f := newAIServiceFixture(validStrategyJSON)
+	feedback := newFeedbackFixture(t)
+	f.service.SetFeedbackBuilder(feedback.builder(600))
+
+	baseID, err := f.service.SaveStrategy(&models.Strategy{Name: "Base", Config: models.JSONB{"stopLossPct": 10.0}})
+	require.NoError(t, err)
+	require.Equal(t, feedback.strategyIDs[0], baseID)
+
+	strategy, err := f.service.GenerateOptimizedStrategy(baseID)
+	require.NoError(t, err)
+	assert.Equal(t, OptimizationStrategyPrompt, strategy.PromptTemplate)
+	assert.Equal(t, 3, strategy.PromptVersion)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 1)
+	user := requests[0].Messages[1].Content
+	assert.Contains(t, user, "Optimize the trading strategy named 'Base'.")
+	assert.Contains(t, user, "Tune the parameters to fix these weaknesses:\n\nExit reasons, biggest losses first (3 closed trades):\n- take_profit: 3 trades")
+}
@@ -0,0 +1,291 @@
+// internal/service/holder_ledger.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// PumpFunTotalSupply is the fixed supply of every pump.fun token in raw units
+	// (1 billion tokens with 6 decimals), matching trades.token_amount
+	PumpFunTotalSupply = 1e15
+
+	// holderLedgerIdleTTL is how long a ledger is kept without being queried
+	holderLedgerIdleTTL = 1 * time.Hour
+
+	// holderTopCount is the number of largest holders used for concentration
+	holderTopCount = 10
+)
+
+// HolderSnapshotProvider returns the current holder distribution of a token
+type HolderSnapshotProvider interface {
+	GetHolderSnapshot(tokenID int64) (*models.HolderSnapshot, error)
+	GetWalletBalances(tokenID int64, wallets []string) (map[string]float64, error)
+}
+
+// tokenLedger tracks net balances per wallet for one token
+type tokenLedger struct {
+	tokenID     int64
+	mint        string
+	creator     string
+	balances    map[string]float64
+	lastTradeID int64
+	lastAccess  time.Time
+}
+
+// apply updates balances with a trade the ledger has not seen yet
+func (l *tokenLedger) apply(trade *models.Trade) {
+	if trade.ID != 0 && trade.ID <= l.lastTradeID {
+		return
+	}
+
+	if trade.IsBuy {
+		l.balances[trade.UserAddress] += trade.TokenAmount
+	} else {
+		l.balances[trade.UserAddress] -= trade.TokenAmount
+	}
+
+	if trade.ID > l.lastTradeID {
+		l.lastTradeID = trade.ID
+	}
+}
+
+// snapshot summarizes the ledger. Wallets that sold more than we saw them buy (bought
+// before collection started) are counted as holding nothing.
+func (l *tokenLedger) snapshot() *models.HolderSnapshot {
+	holders := make([]*models.HolderBalance, 0, len(l.balances))
+	for wallet, balance := range l.balances {
+		if balance <= 0 {
+			continue
+		}
+		holders = append(holders, &models.HolderBalance{
+			WalletAddress: wallet,
+			Balance:       balance,
+			SupplyPct:     balance / PumpFunTotalSupply * 100,
+		})
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].Balance != holders[j].Balance {
+			return holders[i].Balance > holders[j].Balance
+		}
+		return holders[i].WalletAddress < holders[j].WalletAddress
+	})
+
+	top := holders
+	if len(top) > holderTopCount {
+		top = top[:holderTopCount]
+	}
+
+	snapshot := &models.HolderSnapshot{
+		TokenID:        l.tokenID,
+		MintAddress:    l.mint,
+		CreatorAddress: l.creator,
+		UniqueHolders:  len(holders),
+		TopHolders:     top,
+		LastTradeID:    l.lastTradeID,
+		UpdatedAt:      time.Now(),
+	}
+	for _, holder := range top {
+		snapshot.Top10Pct += holder.SupplyPct
+	}
+	if balance := l.balances[l.creator]; balance > 0 {
+		snapshot.CreatorBalance = balance
+		snapshot.CreatorPct = balance / PumpFunTotalSupply * 100
+	}
+
+	return snapshot
+}
+
+// HolderLedgerService maintains running holder balances per token. Ledgers are seeded
+// from the trades table on first request and then kept current from the trade feed.
+type HolderLedgerService struct {
+	tradeRepo repository.TradeRepositoryInterface
+	tokenRepo repository.TokenRepositoryInterface
+	logger    *logger.Logger
+	mu        sync.Mutex
+	ledgers   map[int64]*tokenLedger
+	loading   map[int64][]*models.Trade // Trades received while a ledger is being seeded
+}
+
+// NewHolderLedgerService creates a new holder ledger service
+func NewHolderLedgerService(
+	tradeRepo repository.TradeRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	logger *logger.Logger,
+) *HolderLedgerService {
+	return &HolderLedgerService{
+		tradeRepo: tradeRepo,
+		tokenRepo: tokenRepo,
+		logger:    logger,
+		ledgers:   make(map[int64]*tokenLedger),
+		loading:   make(map[int64][]*models.Trade),
+	}
+}
+
+// OnTrade implements TradeObserver. Only tokens with a loaded ledger are tracked.
+func (s *HolderLedgerService) OnTrade(trade *models.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ledger, ok := s.ledgers[trade.TokenID]; ok {
+		ledger.apply(trade)
+		return
+	}
+	if pending, ok := s.loading[trade.TokenID]; ok {
+		s.loading[trade.TokenID] = append(pending, trade)
+	}
+}
+
+// GetHolderSnapshot implements HolderSnapshotProvider
+func (s *HolderLedgerService) GetHolderSnapshot(tokenID int64) (*models.HolderSnapshot, error) {
+	s.mu.Lock()
+	ledger, ok := s.ledgers[tokenID]
+	if ok {
+		ledger.lastAccess = time.Now()
+		snapshot := ledger.snapshot()
+		s.mu.Unlock()
+		return snapshot, nil
+	}
+	s.mu.Unlock()
+
+	ledger, err := s.load(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ledger.snapshot(), nil
+}
+
+// GetWalletBalances implements HolderSnapshotProvider. Negative balances are reported as 0.
+func (s *HolderLedgerService) GetWalletBalances(tokenID int64, wallets []string) (map[string]float64, error) {
+	s.mu.Lock()
+	ledger, ok := s.ledgers[tokenID]
+	s.mu.Unlock()
+
+	if !ok {
+		var err error
+		if ledger, err = s.load(tokenID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger.lastAccess = time.Now()
+	balances := make(map[string]float64, len(wallets))
+	for _, wallet := range wallets {
+		balances[wallet] = math.Max(0, ledger.balances[wallet])
+	}
+	return balances, nil
+}
+
+// GetHolderSnapshotByMint returns the holder distribution of a token by mint address
+func (s *HolderLedgerService) GetHolderSnapshotByMint(mint string) (*models.HolderSnapshot, error) {
+	token, err := s.tokenRepo.GetByMintAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found")
+	}
+	return s.GetHolderSnapshot(token.ID)
+}
+
+// load seeds a ledger from stored trades and registers it for live updates
+func (s *HolderLedgerService) load(tokenID int64) (*tokenLedger, error) {
+	s.mu.Lock()
+	if _, ok := s.loading[tokenID]; !ok {
+		s.loading[tokenID] = nil
+	}
+	s.mu.Unlock()
+
+	ledger, err := s.seed(tokenID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := s.loading[tokenID]
+	delete(s.loading, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Another caller may have finished loading first
+	if existing, ok := s.ledgers[tokenID]; ok {
+		existing.lastAccess = time.Now()
+		return existing, nil
+	}
+
+	for _, trade := range pending {
+		ledger.apply(trade)
+	}
+	s.ledgers[tokenID] = ledger
+
+	return ledger, nil
+}
+
+// seed builds a ledger from the trades table
+func (s *HolderLedgerService) seed(tokenID int64) (*tokenLedger, error) {
+	token, err := s.tokenRepo.GetByID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found: %d", tokenID)
+	}
+
+	balances, lastTradeID, err := s.tradeRepo.GetHolderBalances(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := &tokenLedger{
+		tokenID:     tokenID,
+		mint:        token.MintAddress,
+		creator:     token.CreatorAddress,
+		balances:    make(map[string]float64, len(balances)),
+		lastTradeID: lastTradeID,
+		lastAccess:  time.Now(),
+	}
+	for _, balance := range balances {
+		ledger.balances[balance.WalletAddress] = balance.Balance
+	}
+
+	return ledger, nil
+}
+
+// Start evicts ledgers nobody has asked about recently
+func (s *HolderLedgerService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Holder ledger service stopped")
+				return
+			case now := <-ticker.C:
+				s.mu.Lock()
+				for tokenID, ledger := range s.ledgers {
+					if now.Sub(ledger.lastAccess) > holderLedgerIdleTTL {
+						delete(s.ledgers, tokenID)
+					}
+				}
+				s.mu.Unlock()
+			}
+		}
+	}()
+}
@@ -0,0 +1,73 @@
+// internal/service/holder_ledger_test.go
+package service
+
+import (
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolderLedgerSeedAndLiveTrades(t *testing.T) {
+	tokenRepo := new(MockTokenRepository)
+	tradeRepo := new(MockTradeRepository)
+	ledger := NewHolderLedgerService(tradeRepo, tokenRepo, logger.New("test"))
+
+	tokenRepo.On("GetByID", int64(1)).Return(&models.Token{ID: 1, MintAddress: "mint1", CreatorAddress: "dev"}, nil)
+	tradeRepo.On("GetHolderBalances", int64(1)).Return([]*models.HolderBalance{
+		{WalletAddress: "dev", Balance: 100e12},  // 10% of supply
+		{WalletAddress: "whale", Balance: 50e12}, // 5%
+		{WalletAddress: "early", Balance: -1e12}, // Sold more than we saw bought
+	}, int64(10), nil)
+
+	snapshot, err := ledger.GetHolderSnapshot(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, snapshot.UniqueHolders)
+	assert.InDelta(t, 15.0, snapshot.Top10Pct, 1e-9)
+	assert.InDelta(t, 10.0, snapshot.CreatorPct, 1e-9)
+	assert.Equal(t, "dev", snapshot.TopHolders[0].WalletAddress)
+
+	// Trades already covered by the seed are ignored, newer ones applied
+	ledger.OnTrade(&models.Trade{ID: 9, TokenID: 1, UserAddress: "dev", TokenAmount: 100e12, IsBuy: false})
+	ledger.OnTrade(&models.Trade{ID: 11, TokenID: 1, UserAddress: "dev", TokenAmount: 40e12, IsBuy: false})
+	ledger.OnTrade(&models.Trade{ID: 12, TokenID: 1, UserAddress: "new", TokenAmount: 20e12, IsBuy: true})
+	// Trades for tokens nobody asked about are not tracked
+	ledger.OnTrade(&models.Trade{ID: 13, TokenID: 2, UserAddress: "new", TokenAmount: 20e12, IsBuy: true})
+
+	snapshot, err = ledger.GetHolderSnapshot(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, snapshot.UniqueHolders)
+	assert.InDelta(t, 6.0, snapshot.CreatorPct, 1e-9)
+	assert.InDelta(t, 13.0, snapshot.Top10Pct, 1e-9)
+
+	balances, err := ledger.GetWalletBalances(1, []string{"dev", "early", "nobody"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"dev": 60e12, "early": 0, "nobody": 0}, balances)
+
+	tradeRepo.AssertNumberOfCalls(t, "GetHolderBalances", 1)
+}
+
+func TestHolderExitReason(t *testing.T) {
+	entry := &models.HolderSnapshot{
+		CreatorAddress: "dev",
+		CreatorBalance: 100,
+		TopHolders: []*models.HolderBalance{
+			{WalletAddress: "dev", Balance: 100},
+			{WalletAddress: "whale", Balance: 80},
+		},
+	}
+
+	creatorExit := models.StrategyConfig{ExitOnCreatorSell: true}
+	assert.Equal(t, []string{"dev"}, holderWatchList(creatorExit, entry))
+	assert.Equal(t, "", holderExitReason(creatorExit, entry, map[string]float64{"dev": 100}))
+	assert.Equal(t, "creator_sell", holderExitReason(creatorExit, entry, map[string]float64{"dev": 99}))
+
+	dumpExit := models.StrategyConfig{ExitOnTopHolderDumpPct: 50}
+	assert.Equal(t, []string{"dev", "whale"}, holderWatchList(dumpExit, entry))
+	assert.Equal(t, "", holderExitReason(dumpExit, entry, map[string]float64{"dev": 100, "whale": 60}))
+	assert.Equal(t, "top_holder_dump", holderExitReason(dumpExit, entry, map[string]float64{"dev": 100, "whale": 40}))
+
+	// Buying more never triggers an exit
+	assert.Equal(t, "", holderExitReason(dumpExit, entry, map[string]float64{"dev": 150, "whale": 200}))
+}
@@ -0,0 +1,353 @@
+// internal/service/launch_analysis_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// launchWindowSec is how long after creation buys count as launch buys
+	launchWindowSec = 30
+
+	// launchSniperSec is how soon after creation a buy counts as a snipe
+	launchSniperSec = 3
+
+	// launchClusterMinWallets is the number of distinct wallets buying in the same second
+	// that marks the second as a bundle
+	launchClusterMinWallets = 3
+
+	// launchSameSizeMinWallets is the number of wallets buying the exact same SOL amount
+	// that marks them as scripted
+	launchSameSizeMinWallets = 3
+
+	// launchTradeLimit caps the trades loaded for one launch
+	launchTradeLimit = 1000
+
+	// launchProvisionalTTL is how long an analysis of a still-open launch window is reused
+	launchProvisionalTTL = 5 * time.Second
+
+	// launchCacheTTL is how long final analyses are kept in memory
+	launchCacheTTL = 10 * time.Minute
+
+	// launchAnalysisInterval is how often launches whose window has closed are analyzed
+	launchAnalysisInterval = 30 * time.Second
+
+	// launchAnalysisLookback bounds how far back the periodic run looks for unanalyzed launches
+	launchAnalysisLookback = 10 * time.Minute
+
+	// launchBackfillBatchSize is the number of tokens analyzed per page during backfill
+	launchBackfillBatchSize = 500
+)
+
+// LaunchAnalysisProvider returns the launch analysis of a token
+type LaunchAnalysisProvider interface {
+	GetLaunchAnalysis(token *models.Token) (*models.LaunchAnalysis, error)
+}
+
+type cachedLaunchAnalysis struct {
+	analysis  *models.LaunchAnalysis
+	fetchedAt time.Time
+}
+
+// LaunchAnalysisService labels the early buyers of each launch as creator, bundle, sniper
+// or fresh wallets and stores how much of the supply went to bundled wallets
+type LaunchAnalysisService struct {
+	launchRepo repository.LaunchAnalysisRepositoryInterface
+	tokenRepo  repository.TokenRepositoryInterface
+	logger     *logger.Logger
+	cacheMu    sync.Mutex
+	cache      map[int64]cachedLaunchAnalysis
+}
+
+// NewLaunchAnalysisService creates a new launch analysis service
+func NewLaunchAnalysisService(
+	launchRepo repository.LaunchAnalysisRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	logger *logger.Logger,
+) *LaunchAnalysisService {
+	return &LaunchAnalysisService{
+		launchRepo: launchRepo,
+		tokenRepo:  tokenRepo,
+		logger:     logger,
+		cache:      make(map[int64]cachedLaunchAnalysis),
+	}
+}
+
+// GetLaunchAnalysis implements LaunchAnalysisProvider. Launches whose window is still
+// open are analyzed on the fly and not stored.
+func (s *LaunchAnalysisService) GetLaunchAnalysis(token *models.Token) (*models.LaunchAnalysis, error) {
+	s.cacheMu.Lock()
+	cached, ok := s.cache[token.ID]
+	s.cacheMu.Unlock()
+	if ok && (cached.analysis.Final || time.Since(cached.fetchedAt) < launchProvisionalTTL) {
+		return cached.analysis, nil
+	}
+
+	analysis, err := s.launchRepo.GetByTokenID(token.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if analysis == nil {
+		analysis, err = s.Analyze(token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.cacheMu.Lock()
+	s.cache[token.ID] = cachedLaunchAnalysis{analysis: analysis, fetchedAt: time.Now()}
+	s.cacheMu.Unlock()
+
+	return analysis, nil
+}
+
+// GetLaunchAnalysisByMint returns the launch analysis of a token by mint address
+func (s *LaunchAnalysisService) GetLaunchAnalysisByMint(mint string) (*models.LaunchAnalysis, error) {
+	token, err := s.tokenRepo.GetByMintAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found")
+	}
+	return s.GetLaunchAnalysis(token)
+}
+
+// Analyze labels the launch buyers of a token and stores the result once the launch
+// window has closed
+func (s *LaunchAnalysisService) Analyze(token *models.Token) (*models.LaunchAnalysis, error) {
+	trades, err := s.launchRepo.GetLaunchTrades(token.ID, launchStartSec(token)+launchWindowSec, launchTradeLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []string
+	seen := make(map[string]bool)
+	for _, trade := range trades {
+		if trade.IsBuy && !seen[trade.UserAddress] {
+			seen[trade.UserAddress] = true
+			wallets = append(wallets, trade.UserAddress)
+		}
+	}
+
+	firstTradeAt := map[string]int64{}
+	if len(wallets) > 0 {
+		if firstTradeAt, err = s.launchRepo.GetFirstTradeTimes(wallets); err != nil {
+			return nil, err
+		}
+	}
+
+	analysis := AnalyzeLaunch(token, trades, firstTradeAt, time.Now().Unix())
+	if analysis.Final {
+		if err := s.launchRepo.Upsert(analysis); err != nil {
+			return nil, err
+		}
+	}
+
+	return analysis, nil
+}
+
+// Start periodically analyzes recent launches whose window has closed and drops old
+// entries from the cache
+func (s *LaunchAnalysisService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(launchAnalysisInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Launch analysis service stopped")
+				return
+			case now := <-ticker.C:
+				from := now.Add(-launchAnalysisLookback).UnixMilli()
+				to := now.Add(-launchWindowSec * time.Second).UnixMilli()
+				count, err := s.analyzeRange(ctx, from, to)
+				if err != nil {
+					s.logger.Error("Error analyzing launches: %v", err)
+				} else if count > 0 {
+					s.logger.Debug("Analyzed %d launches", count)
+				}
+
+				s.cacheMu.Lock()
+				for tokenID, cached := range s.cache {
+					if now.Sub(cached.fetchedAt) > launchCacheTTL {
+						delete(s.cache, tokenID)
+					}
+				}
+				s.cacheMu.Unlock()
+			}
+		}
+	}()
+}
+
+// Backfill analyzes every launch created at or after sinceMs whose window has closed and
+// returns the number of analyses written
+func (s *LaunchAnalysisService) Backfill(ctx context.Context, sinceMs int64) (int, error) {
+	return s.analyzeRange(ctx, sinceMs, time.Now().Add(-launchWindowSec*time.Second).UnixMilli())
+}
+
+// analyzeRange analyzes launches created between fromMs and toMs that have no stored analysis
+func (s *LaunchAnalysisService) analyzeRange(ctx context.Context, fromMs, toMs int64) (int, error) {
+	written := 0
+	for {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		tokens, err := s.launchRepo.GetUnanalyzedTokens(fromMs, toMs, launchBackfillBatchSize)
+		if err != nil {
+			return written, err
+		}
+
+		for _, token := range tokens {
+			if _, err := s.Analyze(token); err != nil {
+				s.logger.Error("Error analyzing launch of %s: %v", token.MintAddress, err)
+				continue
+			}
+			written++
+		}
+
+		if len(tokens) < launchBackfillBatchSize {
+			return written, nil
+		}
+		fromMs = tokens[len(tokens)-1].CreatedTimestamp + 1
+	}
+}
+
+// launchStartSec returns the launch time of a token in unix seconds
+func launchStartSec(token *models.Token) int64 {
+	return token.CreatedTimestamp / 1000
+}
+
+// AnalyzeLaunch labels the wallets that bought within the launch window. The trade
+// stream carries no SOL transfers, so funding links between wallets are approximated by
+// same-second clustering, identical buy sizes and wallets never seen trading before.
+// Creator buys, bundle clusters, identical-size buyers and fresh snipers all count
+// towards the bundled supply.
+func AnalyzeLaunch(token *models.Token, trades []*models.Trade, firstTradeAt map[string]int64, nowSec int64) *models.LaunchAnalysis {
+	start := launchStartSec(token)
+	windowEnd := start + launchWindowSec
+
+	analysis := &models.LaunchAnalysis{
+		TokenID:        token.ID,
+		MintAddress:    token.MintAddress,
+		CreatorAddress: token.CreatorAddress,
+		WindowSec:      launchWindowSec,
+		Final:          nowSec >= windowEnd,
+		AnalyzedAt:     time.Now(),
+	}
+
+	buyers := make(map[string]*models.LaunchBuyer)
+	secondWallets := make(map[int64]map[string]bool)
+	sizeWallets := make(map[float64]map[string]bool)
+	for _, trade := range trades {
+		if !trade.IsBuy || trade.Timestamp > windowEnd {
+			continue
+		}
+		analysis.EarlyBuyCount++
+
+		buyer, ok := buyers[trade.UserAddress]
+		if !ok {
+			buyer = &models.LaunchBuyer{WalletAddress: trade.UserAddress, FirstBuyAt: trade.Timestamp}
+			buyers[trade.UserAddress] = buyer
+		}
+		buyer.BuyCount++
+		buyer.SolAmount += trade.SolAmount
+		buyer.TokenAmount += trade.TokenAmount
+		if trade.Timestamp < buyer.FirstBuyAt {
+			buyer.FirstBuyAt = trade.Timestamp
+		}
+
+		if trade.UserAddress == token.CreatorAddress {
+			continue
+		}
+		if secondWallets[trade.Timestamp] == nil {
+			secondWallets[trade.Timestamp] = make(map[string]bool)
+		}
+		secondWallets[trade.Timestamp][trade.UserAddress] = true
+		if trade.SolAmount > 0 {
+			if sizeWallets[trade.SolAmount] == nil {
+				sizeWallets[trade.SolAmount] = make(map[string]bool)
+			}
+			sizeWallets[trade.SolAmount][trade.UserAddress] = true
+		}
+	}
+
+	bundled := make(map[string]bool)
+	for _, wallets := range secondWallets {
+		if len(wallets) >= launchClusterMinWallets {
+			for wallet := range wallets {
+				bundled[wallet] = true
+			}
+		}
+	}
+	sameSize := make(map[string]bool)
+	for _, wallets := range sizeWallets {
+		if len(wallets) >= launchSameSizeMinWallets {
+			for wallet := range wallets {
+				sameSize[wallet] = true
+			}
+		}
+	}
+
+	analysis.Buyers = make([]*models.LaunchBuyer, 0, len(buyers))
+	for wallet, buyer := range buyers {
+		buyer.SupplyPct = buyer.TokenAmount / PumpFunTotalSupply * 100
+		buyer.Labels = []string{}
+
+		isSniper := buyer.FirstBuyAt-start <= launchSniperSec
+		first, seen := firstTradeAt[wallet]
+		isFresh := !seen || first >= start
+
+		if wallet == token.CreatorAddress {
+			buyer.Labels = append(buyer.Labels, models.LaunchLabelCreator)
+			buyer.Bundled = true
+		}
+		if bundled[wallet] {
+			buyer.Labels = append(buyer.Labels, models.LaunchLabelBundle)
+			buyer.Bundled = true
+		}
+		if sameSize[wallet] {
+			buyer.Labels = append(buyer.Labels, models.LaunchLabelSameSize)
+			buyer.Bundled = true
+		}
+		if isSniper && wallet != token.CreatorAddress {
+			buyer.Labels = append(buyer.Labels, models.LaunchLabelSniper)
+			analysis.SniperCount++
+			analysis.SniperSupplyPct += buyer.SupplyPct
+		}
+		if isFresh {
+			buyer.Labels = append(buyer.Labels, models.LaunchLabelFresh)
+			analysis.FreshWalletCount++
+			if isSniper {
+				buyer.Bundled = true
+			}
+		}
+
+		if buyer.Bundled {
+			analysis.BundledWalletCount++
+			analysis.BundledSupplyPct += buyer.SupplyPct
+		}
+		analysis.Buyers = append(analysis.Buyers, buyer)
+	}
+	analysis.EarlyBuyerCount = len(analysis.Buyers)
+
+	sort.Slice(analysis.Buyers, func(i, j int) bool {
+		if analysis.Buyers[i].TokenAmount != analysis.Buyers[j].TokenAmount {
+			return analysis.Buyers[i].TokenAmount > analysis.Buyers[j].TokenAmount
+		}
+		return analysis.Buyers[i].WalletAddress < analysis.Buyers[j].WalletAddress
+	})
+
+	return analysis
+}
@@ -0,0 +1,114 @@
+// internal/service/launch_analysis_service_test.go
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func launchBuyerByWallet(analysis *models.LaunchAnalysis, wallet string) *models.LaunchBuyer {
+	for _, buyer := range analysis.Buyers {
+		if buyer.WalletAddress == wallet {
+			return buyer
+		}
+	}
+	return nil
+}
+
+func TestAnalyzeLaunch(t *testing.T) {
+	start := int64(1700000000)
+	token := &models.Token{ID: 1, MintAddress: "mint1", CreatorAddress: "dev", CreatedTimestamp: start * 1000}
+
+	trades := []*models.Trade{
+		// Creator buys its own launch
+		{UserAddress: "dev", IsBuy: true, SolAmount: 2e9, TokenAmount: 50e12, Timestamp: start},
+		// Three wallets land in the same second
+		{UserAddress: "b1", IsBuy: true, SolAmount: 1.1e9, TokenAmount: 30e12, Timestamp: start + 1},
+		{UserAddress: "b2", IsBuy: true, SolAmount: 1.2e9, TokenAmount: 30e12, Timestamp: start + 1},
+		{UserAddress: "b3", IsBuy: true, SolAmount: 1.3e9, TokenAmount: 30e12, Timestamp: start + 1},
+		// A known wallet snipes on its own
+		{UserAddress: "sniper", IsBuy: true, SolAmount: 0.5e9, TokenAmount: 10e12, Timestamp: start + 2},
+		// Three wallets spread out but buying the exact same amount
+		{UserAddress: "s1", IsBuy: true, SolAmount: 0.25e9, TokenAmount: 5e12, Timestamp: start + 10},
+		{UserAddress: "s2", IsBuy: true, SolAmount: 0.25e9, TokenAmount: 5e12, Timestamp: start + 14},
+		{UserAddress: "s3", IsBuy: true, SolAmount: 0.25e9, TokenAmount: 5e12, Timestamp: start + 20},
+		// An ordinary buyer and a sell that is ignored
+		{UserAddress: "retail", IsBuy: true, SolAmount: 0.3e9, TokenAmount: 4e12, Timestamp: start + 15},
+		{UserAddress: "b1", IsBuy: false, SolAmount: 1e9, TokenAmount: 30e12, Timestamp: start + 16},
+		// After the window
+		{UserAddress: "late", IsBuy: true, SolAmount: 0.3e9, TokenAmount: 4e12, Timestamp: start + launchWindowSec + 1},
+	}
+	firstTradeAt := map[string]int64{
+		"dev":    start,
+		"b1":     start + 1,
+		"b2":     start + 1,
+		"b3":     start + 1,
+		"sniper": start - 86400,
+		"s1":     start - 86400,
+		"s2":     start - 86400,
+		"s3":     start - 86400,
+		"retail": start - 86400,
+	}
+
+	analysis := AnalyzeLaunch(token, trades, firstTradeAt, start+launchWindowSec)
+	assert.True(t, analysis.Final)
+	assert.Equal(t, 9, analysis.EarlyBuyCount)
+	assert.Equal(t, 9, analysis.EarlyBuyerCount)
+	assert.Equal(t, 7, analysis.BundledWalletCount)
+	assert.Equal(t, 4, analysis.SniperCount)
+	assert.Equal(t, 4, analysis.FreshWalletCount)
+	// dev 5% + bundle 9% + same size 1.5%
+	assert.InDelta(t, 15.5, analysis.BundledSupplyPct, 1e-9)
+	// bundle 9% + sniper 1%
+	assert.InDelta(t, 10.0, analysis.SniperSupplyPct, 1e-9)
+
+	assert.Equal(t, []string{models.LaunchLabelCreator, models.LaunchLabelFresh}, launchBuyerByWallet(analysis, "dev").Labels)
+	assert.Equal(t, []string{models.LaunchLabelBundle, models.LaunchLabelSniper, models.LaunchLabelFresh},
+		launchBuyerByWallet(analysis, "b2").Labels)
+	assert.False(t, launchBuyerByWallet(analysis, "sniper").Bundled)
+	assert.Equal(t, []string{models.LaunchLabelSameSize}, launchBuyerByWallet(analysis, "s3").Labels)
+	assert.Empty(t, launchBuyerByWallet(analysis, "retail").Labels)
+	assert.Nil(t, launchBuyerByWallet(analysis, "late"))
+	assert.Equal(t, "dev", analysis.Buyers[0].WalletAddress)
+
+	// While the window is open the analysis is provisional
+	assert.False(t, AnalyzeLaunch(token, trades[:5], firstTradeAt, start+5).Final)
+}
+
+func TestAnalyzeEntrySignalExcludesLaunchBuyers(t *testing.T) {
+	s := &SimulationService{logger: logger.New("test")}
+	ctx := &SimulationContext{Config: models.StrategyConfig{
+		EntryTimeWindowSec: 60,
+		MinBuysForEntry:    3,
+	}}
+
+	now := time.Now().Unix()
+	trades := []*models.Trade{
+		{UserAddress: "b1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 5},
+		{UserAddress: "b2", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 4},
+		{UserAddress: "sniper", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 3},
+		{UserAddress: "retail", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 2},
+	}
+	launch := &models.LaunchAnalysis{
+		BundledSupplyPct: 12,
+		Buyers: []*models.LaunchBuyer{
+			{WalletAddress: "b1", Labels: []string{models.LaunchLabelBundle}, Bundled: true},
+			{WalletAddress: "b2", Labels: []string{models.LaunchLabelBundle}, Bundled: true},
+			{WalletAddress: "sniper", Labels: []string{models.LaunchLabelSniper}},
+			{WalletAddress: "retail", Labels: []string{}},
+		},
+	}
+
+	entry, data := s.analyzeEntrySignal(ctx, &models.Token{}, trades, launch, nil, nil, nil)
+	assert.True(t, entry)
+	assert.Equal(t, 1, data["organic_buy_count"])
+	assert.Equal(t, 12.0, data["bundled_supply_pct"])
+
+	ctx.Config.ExcludeLaunchBuyers = true
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, trades, launch, nil, nil, nil)
+	assert.False(t, entry)
+}
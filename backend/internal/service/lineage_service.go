@@ -0,0 +1,228 @@
+// internal/service/lineage_service.go
+package service
+
+import (
+	"fmt"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// LineageRecorder records that a strategy was derived from one or more parents
+type LineageRecorder interface {
+	RecordDerivation(childID int64, parentIDs []int64, reason string) error
+}
+
+// LineageService records the parent/child links between strategies and walks them into
+// ancestry and descendant trees
+type LineageService struct {
+	generationRepo repository.StrategyGenerationRepositoryInterface
+	strategyRepo   repository.StrategyRepositoryInterface
+	metricRepo     repository.StrategyMetricRepositoryInterface
+	logger         *logger.Logger
+}
+
+// NewLineageService creates a new lineage service
+func NewLineageService(
+	generationRepo repository.StrategyGenerationRepositoryInterface,
+	strategyRepo repository.StrategyRepositoryInterface,
+	metricRepo repository.StrategyMetricRepositoryInterface,
+	logger *logger.Logger,
+) *LineageService {
+	return &LineageService{
+		generationRepo: generationRepo,
+		strategyRepo:   strategyRepo,
+		metricRepo:     metricRepo,
+		logger:         logger,
+	}
+}
+
+// RecordDerivation links a derived strategy to each of its parents. The child's generation
+// is one more than its most derived parent's.
+func (s *LineageService) RecordDerivation(childID int64, parentIDs []int64, reason string) error {
+	generation := 0
+	var parents []int64
+	seen := make(map[int64]bool, len(parentIDs))
+	for _, parentID := range parentIDs {
+		if parentID == childID || seen[parentID] {
+			continue
+		}
+		seen[parentID] = true
+		parents = append(parents, parentID)
+
+		parentGeneration, err := s.generationOf(parentID)
+		if err != nil {
+			return err
+		}
+		generation = max(generation, parentGeneration)
+	}
+
+	for _, parentID := range parents {
+		if _, err := s.generationRepo.Save(&models.StrategyGeneration{
+			GenerationNumber:  generation + 1,
+			ParentStrategyID:  parentID,
+			ChildStrategyID:   childID,
+			ImprovementReason: reason,
+		}); err != nil {
+			return fmt.Errorf("error recording lineage of strategy %d: %v", childID, err)
+		}
+	}
+
+	if len(parents) > 0 {
+		s.logger.Info("Recorded strategy %d as generation %d derived from %v", childID, generation+1, parents)
+	}
+	return nil
+}
+
+// GetLineage returns the lineage tree of a strategy up to depth derivations in each
+// direction, or nil if the strategy doesn't exist
+func (s *LineageService) GetLineage(strategyID int64, depth int) (*models.LineageNode, error) {
+	root, parentLinks, err := s.loadNode(strategyID)
+	if err != nil || root == nil {
+		return nil, err
+	}
+
+	if err := s.addParents(root, parentLinks, depth, map[int64]bool{strategyID: true}); err != nil {
+		return nil, err
+	}
+	if err := s.addChildren(root, depth, map[int64]bool{strategyID: true}); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// addParents attaches the ancestors of node, following parentLinks up to depth
+// derivations. path holds the strategies already on the path to guard against cycles.
+func (s *LineageService) addParents(node *models.LineageNode, parentLinks []*models.StrategyGeneration, depth int, path map[int64]bool) error {
+	if depth <= 0 {
+		return nil
+	}
+
+	for _, link := range parentLinks {
+		if path[link.ParentStrategyID] {
+			continue
+		}
+		parent, grandparentLinks, err := s.loadNode(link.ParentStrategyID)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			continue
+		}
+		parent.Delta = performanceDelta(node.Performance, parent.Performance)
+
+		path[parent.StrategyID] = true
+		err = s.addParents(parent, grandparentLinks, depth-1, path)
+		delete(path, parent.StrategyID)
+		if err != nil {
+			return err
+		}
+		node.Parents = append(node.Parents, parent)
+	}
+	return nil
+}
+
+// addChildren attaches the descendants of node up to depth derivations
+func (s *LineageService) addChildren(node *models.LineageNode, depth int, path map[int64]bool) error {
+	if depth <= 0 {
+		return nil
+	}
+
+	childLinks, err := s.generationRepo.GetByParentStrategy(node.StrategyID)
+	if err != nil {
+		return fmt.Errorf("error getting children of strategy %d: %v", node.StrategyID, err)
+	}
+
+	for _, link := range childLinks {
+		if path[link.ChildStrategyID] {
+			continue
+		}
+		child, _, err := s.loadNode(link.ChildStrategyID)
+		if err != nil {
+			return err
+		}
+		if child == nil {
+			continue
+		}
+		child.ImprovementReason = link.ImprovementReason
+		child.Delta = performanceDelta(child.Performance, node.Performance)
+
+		path[child.StrategyID] = true
+		err = s.addChildren(child, depth-1, path)
+		delete(path, child.StrategyID)
+		if err != nil {
+			return err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return nil
+}
+
+// loadNode builds the lineage node of a strategy along with the links to its parents, or
+// returns nil if the strategy doesn't exist
+func (s *LineageService) loadNode(strategyID int64) (*models.LineageNode, []*models.StrategyGeneration, error) {
+	strategy, err := s.strategyRepo.GetByID(strategyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting strategy %d: %v", strategyID, err)
+	}
+	if strategy == nil {
+		return nil, nil, nil
+	}
+
+	parentLinks, err := s.generationRepo.GetByChildStrategy(strategyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting parents of strategy %d: %v", strategyID, err)
+	}
+
+	node := &models.LineageNode{
+		StrategyID: strategy.ID,
+		Name:       strategy.Name,
+	}
+	for _, link := range parentLinks {
+		node.Generation = max(node.Generation, link.GenerationNumber)
+		if node.ImprovementReason == "" {
+			node.ImprovementReason = link.ImprovementReason
+		}
+	}
+
+	metric, err := s.metricRepo.GetLatestByStrategy(strategyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting metrics of strategy %d: %v", strategyID, err)
+	}
+	if metric != nil {
+		node.Performance = &models.LineagePerformance{
+			ROI:         metric.ROI,
+			WinRate:     metric.WinRate,
+			TotalTrades: metric.TotalTrades,
+		}
+	}
+
+	return node, parentLinks, nil
+}
+
+// generationOf returns a strategy's generation, 0 if it wasn't derived from another
+func (s *LineageService) generationOf(strategyID int64) (int, error) {
+	links, err := s.generationRepo.GetByChildStrategy(strategyID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting parents of strategy %d: %v", strategyID, err)
+	}
+
+	generation := 0
+	for _, link := range links {
+		generation = max(generation, link.GenerationNumber)
+	}
+	return generation, nil
+}
+
+// performanceDelta returns the derived strategy's performance minus its parent's, or nil
+// if either has no metrics yet
+func performanceDelta(derived, parent *models.LineagePerformance) *models.LineageDelta {
+	if derived == nil || parent == nil {
+		return nil
+	}
+	return &models.LineageDelta{
+		ROI:     derived.ROI - parent.ROI,
+		WinRate: derived.WinRate - parent.WinRate,
+	}
+}
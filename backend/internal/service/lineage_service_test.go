@@ -0,0 +1,138 @@
+// internal/service/lineage_service_test.go
+package service
+
+import (
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lineageFixture struct {
+	service        *LineageService
+	strategyRepo   *memory.StrategyRepository
+	metricRepo     *memory.StrategyMetricRepository
+	generationRepo *memory.StrategyGenerationRepository
+}
+
+func newLineageFixture() *lineageFixture {
+	store := memory.NewStore()
+	f := &lineageFixture{
+		strategyRepo:   memory.NewStrategyRepository(store),
+		metricRepo:     memory.NewStrategyMetricRepository(store),
+		generationRepo: memory.NewStrategyGenerationRepository(store),
+	}
+	f.service = NewLineageService(f.generationRepo, f.strategyRepo, f.metricRepo, logger.New("test"))
+	return f
+}
+
+func (f *lineageFixture) strategy(t *testing.T, name string, roi, winRate float64) int64 {
+	t.Helper()
+	id, err := f.strategyRepo.Save(&models.Strategy{Name: name, Config: models.JSONB{}})
+	require.NoError(t, err)
+	_, err = f.metricRepo.Save(&models.StrategyMetric{StrategyID: id, ROI: roi, WinRate: winRate, TotalTrades: 10})
+	require.NoError(t, err)
+	return id
+}
+
+func TestLineageRecordDerivationGenerations(t *testing.T) {
+	f := newLineageFixture()
+	rootA := f.strategy(t, "Root A", 5, 40)
+	rootB := f.strategy(t, "Root B", 8, 50)
+	child := f.strategy(t, "Child", 12, 55)
+	grandchild := f.strategy(t, "Grandchild", 10, 60)
+
+	require.NoError(t, f.service.RecordDerivation(child, []int64{rootA, rootB, rootA, child}, "Evolved"))
+	require.NoError(t, f.service.RecordDerivation(grandchild, []int64{child, rootB}, "Optimized"))
+
+	links, err := f.generationRepo.GetByChildStrategy(child)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, 1, links[0].GenerationNumber)
+	assert.Equal(t, rootA, links[0].ParentStrategyID)
+	assert.Equal(t, rootB, links[1].ParentStrategyID)
+
+	links, err = f.generationRepo.GetByChildStrategy(grandchild)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, 2, links[0].GenerationNumber)
+	assert.Equal(t, 2, links[1].GenerationNumber)
+}
+
+func TestLineageGetLineage(t *testing.T) {
+	f := newLineageFixture()
+	root := f.strategy(t, "Root", 5, 40)
+	child := f.strategy(t, "Child", 12, 55)
+	grandchild := f.strategy(t, "Grandchild", 10, 60)
+	unmeasured, err := f.strategyRepo.Save(&models.Strategy{Name: "Unmeasured", Config: models.JSONB{}})
+	require.NoError(t, err)
+
+	require.NoError(t, f.service.RecordDerivation(child, []int64{root}, "Tighter stop loss"))
+	require.NoError(t, f.service.RecordDerivation(grandchild, []int64{child}, "Higher take profit"))
+	require.NoError(t, f.service.RecordDerivation(unmeasured, []int64{child}, "Smart money entry"))
+
+	lineage, err := f.service.GetLineage(child, 5)
+	require.NoError(t, err)
+	require.NotNil(t, lineage)
+	assert.Equal(t, child, lineage.StrategyID)
+	assert.Equal(t, 1, lineage.Generation)
+	assert.Equal(t, "Tighter stop loss", lineage.ImprovementReason)
+	assert.Nil(t, lineage.Delta)
+
+	require.Len(t, lineage.Parents, 1)
+	parent := lineage.Parents[0]
+	assert.Equal(t, root, parent.StrategyID)
+	assert.Equal(t, 0, parent.Generation)
+	require.NotNil(t, parent.Delta)
+	assert.InDelta(t, 7, parent.Delta.ROI, 1e-9)
+	assert.InDelta(t, 15, parent.Delta.WinRate, 1e-9)
+
+	require.Len(t, lineage.Children, 2)
+	assert.Equal(t, grandchild, lineage.Children[0].StrategyID)
+	assert.Equal(t, 2, lineage.Children[0].Generation)
+	assert.Equal(t, "Higher take profit", lineage.Children[0].ImprovementReason)
+	require.NotNil(t, lineage.Children[0].Delta)
+	assert.InDelta(t, -2, lineage.Children[0].Delta.ROI, 1e-9)
+	assert.InDelta(t, 5, lineage.Children[0].Delta.WinRate, 1e-9)
+	assert.Equal(t, unmeasured, lineage.Children[1].StrategyID)
+	assert.Nil(t, lineage.Children[1].Performance)
+	assert.Nil(t, lineage.Children[1].Delta)
+
+	// Depth limits how far the tree is followed
+	shallow, err := f.service.GetLineage(root, 1)
+	require.NoError(t, err)
+	require.Len(t, shallow.Children, 1)
+	assert.Empty(t, shallow.Children[0].Children)
+
+	missing, err := f.service.GetLineage(grandchild+100, 5)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestLineageGetLineageCycle(t *testing.T) {
+	f := newLineageFixture()
+	a := f.strategy(t, "A", 1, 10)
+	b := f.strategy(t, "B", 2, 20)
+
+	require.NoError(t, f.service.RecordDerivation(b, []int64{a}, "First"))
+	require.NoError(t, f.service.RecordDerivation(a, []int64{b}, "Back again"))
+
+	lineage, err := f.service.GetLineage(a, 10)
+	require.NoError(t, err)
+	require.Len(t, lineage.Children, 1)
+	assert.Empty(t, lineage.Children[0].Children)
+	require.Len(t, lineage.Parents, 1)
+	assert.Empty(t, lineage.Parents[0].Parents)
+}
+
+func TestTopStrategyIDs(t *testing.T) {
+	ids := TopStrategyIDs([]map[string]interface{}{
+		{"id": int64(3), "name": "A"},
+		{"name": "No ID"},
+		{"id": int64(7)},
+	})
+	assert.Equal(t, []int64{3, 7}, ids)
+}
@@ -0,0 +1,109 @@
+// internal/service/llm_anthropic.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultAnthropicEndpoint  = "https://api.anthropic.com/v1/messages"
+	defaultAnthropicModel     = "claude-3-5-sonnet-latest"
+	defaultAnthropicMaxTokens = 1024
+	anthropicAPIVersion       = "2023-06-01"
+)
+
+// AnthropicProvider talks to Anthropic Messages-style endpoints
+type AnthropicProvider struct {
+	client httpLLMClient
+}
+
+type anthropicMessagesRequest struct {
+	Model       string       `json:"model"`
+	System      string       `json:"system,omitempty"`
+	Messages    []LLMMessage `json:"messages"`
+	Temperature float64      `json:"temperature"`
+	MaxTokens   int          `json:"max_tokens"`
+}
+
+type anthropicMessagesResponse struct {
+	Model   string `json:"model"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// NewAnthropicProvider creates a provider for a Messages-style endpoint
+func NewAnthropicProvider(cfg LLMProviderConfig) *AnthropicProvider {
+	return &AnthropicProvider{client: newHTTPLLMClient(cfg, defaultAnthropicEndpoint, defaultAnthropicModel)}
+}
+
+// Name returns the provider name
+func (p *AnthropicProvider) Name() string {
+	return LLMProviderAnthropic
+}
+
+// Complete sends a messages request. System messages are moved to the top-level system
+// prompt since the API only accepts user and assistant turns. The API has no JSON mode, so
+// a response schema is added to the system prompt instead.
+func (p *AnthropicProvider) Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	body := anthropicMessagesRequest{
+		Model:       p.client.modelFor(req),
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}
+	if body.MaxTokens <= 0 {
+		body.MaxTokens = defaultAnthropicMaxTokens
+	}
+
+	var system []string
+	for _, message := range req.Messages {
+		if message.Role == "system" {
+			system = append(system, message.Content)
+			continue
+		}
+		body.Messages = append(body.Messages, message)
+	}
+	if req.ResponseSchema != nil {
+		schema, err := json.Marshal(req.ResponseSchema.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding response schema: %v", err)
+		}
+		system = append(system, "Respond with a single JSON object and nothing else. It must match this JSON Schema:\n"+string(schema))
+	}
+	body.System = strings.Join(system, "\n\n")
+
+	headers := map[string]string{"anthropic-version": anthropicAPIVersion}
+	if p.client.apiKey != "" {
+		headers["x-api-key"] = p.client.apiKey
+	}
+
+	var resp anthropicMessagesResponse
+	if err := p.client.post(ctx, req, headers, body, &resp); err != nil {
+		return nil, fmt.Errorf("error from Anthropic API: %v", err)
+	}
+
+	var content strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+	if content.Len() == 0 {
+		return nil, fmt.Errorf("no text content in Anthropic response")
+	}
+
+	return &LLMResponse{
+		Content:      content.String(),
+		Model:        resp.Model,
+		InputTokens:  resp.Usage.InputTokens,
+		OutputTokens: resp.Usage.OutputTokens,
+	}, nil
+}
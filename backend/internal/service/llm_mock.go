@@ -0,0 +1,197 @@
+// internal/service/llm_mock.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// MockLLMProvider is a deterministic provider for tests and offline development. Queued
+// responses are returned first, in order; after that the same conversation always gets the
+// same reply: a strategy draft for the "strategy_draft" response schema, a diagnosis for the
+// "trade_post_mortem" schema, a strategy JSON object when the conversation otherwise asks
+// for JSON and a short performance analysis otherwise.
+type MockLLMProvider struct {
+	mu        sync.Mutex
+	responses []string
+	requests  []LLMRequest
+	err       error
+}
+
+// NewMockLLMProvider creates a mock provider that returns the given responses in order
+// before falling back to its generated replies
+func NewMockLLMProvider(responses ...string) *MockLLMProvider {
+	return &MockLLMProvider{responses: responses}
+}
+
+// Name returns the provider name
+func (p *MockLLMProvider) Name() string {
+	return LLMProviderMock
+}
+
+// Enqueue adds responses to return before the generated replies
+func (p *MockLLMProvider) Enqueue(responses ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.responses = append(p.responses, responses...)
+}
+
+// SetError makes every following completion fail with err; nil clears it
+func (p *MockLLMProvider) SetError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// Requests returns the requests completed so far
+func (p *MockLLMProvider) Requests() []LLMRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]LLMRequest(nil), p.requests...)
+}
+
+// Complete records the request and returns the next queued or generated reply
+func (p *MockLLMProvider) Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, req)
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	var content string
+	if len(p.responses) > 0 {
+		content = p.responses[0]
+		p.responses = p.responses[1:]
+	} else {
+		content = mockReply(req)
+	}
+
+	var input int
+	for _, message := range req.Messages {
+		input += len(strings.Fields(message.Content))
+	}
+
+	model := req.Model
+	if model == "" {
+		model = LLMProviderMock
+	}
+
+	return &LLMResponse{
+		Content:      content,
+		Model:        model,
+		InputTokens:  input,
+		OutputTokens: len(strings.Fields(content)),
+	}, nil
+}
+
+// mockReply generates the reply to a conversation from a hash of its messages
+func mockReply(req LLMRequest) string {
+	hash := fnv.New64a()
+	asksForJSON := req.ResponseSchema != nil
+	for _, message := range req.Messages {
+		hash.Write([]byte(message.Role))
+		hash.Write([]byte(message.Content))
+		if strings.Contains(message.Content, "JSON") {
+			asksForJSON = true
+		}
+	}
+	seed := hash.Sum64()
+
+	if !asksForJSON {
+		return fmt.Sprintf("The strategy shows a mixed record with moderate risk (analysis %04d). "+
+			"Its win rate and drawdown suggest average performance. "+
+			"Tightening the stop loss could reduce losses on failed entries.", seed%10000)
+	}
+
+	if req.ResponseSchema != nil && req.ResponseSchema.Name == "trade_post_mortem" {
+		return mockPostMortem(seed)
+	}
+	strategy := mockStrategy(seed)
+	if req.ResponseSchema != nil && req.ResponseSchema.Name == "strategy_draft" {
+		return mockDraft(strategy)
+	}
+	content, _ := json.Marshal(strategy)
+	return string(content)
+}
+
+// mockStrategy generates strategy parameters from a seed
+func mockStrategy(seed uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 fmt.Sprintf("Mock Strategy %04d", seed%10000),
+		"description":          "Deterministic strategy from the mock LLM provider",
+		"marketCapThreshold":   float64(5000 + (seed>>8)%10*1000),
+		"minBuysForEntry":      3 + (seed>>16)%5,
+		"entryTimeWindowSec":   60 + (seed>>24)%5*60,
+		"takeProfitPct":        float64(30 + (seed>>32)%8*10),
+		"stopLossPct":          float64(15 + (seed>>40)%4*5),
+		"maxHoldTimeSec":       600 + (seed>>48)%6*300,
+		"fixedPositionSizeSol": 0.5,
+		"initialBalance":       10.0,
+	}
+}
+
+// mockDraft wraps strategy parameters in a draft with rules, an explanation of every
+// parameter and one ambiguity
+func mockDraft(strategy map[string]interface{}) string {
+	var explanations []map[string]interface{}
+	for key := range strategy {
+		if key != "name" && key != "description" {
+			explanations = append(explanations, map[string]interface{}{
+				"parameter":   key,
+				"source":      "",
+				"explanation": "Default chosen by the mock LLM provider",
+			})
+		}
+	}
+	sort.Slice(explanations, func(i, j int) bool {
+		return explanations[i]["parameter"].(string) < explanations[j]["parameter"].(string)
+	})
+	explanations = append(explanations, map[string]interface{}{
+		"parameter":   "rules",
+		"source":      "",
+		"explanation": "Restates the entry and exit parameters",
+	})
+
+	draft := map[string]interface{}{
+		"strategy": strategy,
+		"rules": []map[string]interface{}{
+			{"condition": fmt.Sprintf("at least %v buys within %v seconds", strategy["minBuysForEntry"], strategy["entryTimeWindowSec"]), "action": "buy"},
+			{"condition": "take profit, stop loss or maximum hold time reached", "action": "sell"},
+		},
+		"explanations": explanations,
+		"ambiguities": []map[string]interface{}{
+			{"parameter": "fixedPositionSizeSol", "issue": "The description does not size positions", "assumption": "0.5 SOL per trade"},
+		},
+	}
+	content, _ := json.Marshal(draft)
+	return string(content)
+}
+
+// mockPostMortem generates a post-mortem with one diagnosis picked from a seed
+func mockPostMortem(seed uint64) string {
+	postMortem := map[string]interface{}{
+		"diagnoses": []map[string]interface{}{
+			{
+				"code":       models.DiagnosisCodes[seed%uint64(len(models.DiagnosisCodes))],
+				"detail":     fmt.Sprintf("Deterministic diagnosis %04d from the mock LLM provider", seed%10000),
+				"confidence": 0.5,
+			},
+		},
+		"summary": "The mock LLM provider does not look at the trade.",
+	}
+	content, _ := json.Marshal(postMortem)
+	return string(content)
+}
@@ -0,0 +1,82 @@
+// internal/service/llm_ollama.go
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	defaultOllamaEndpoint = "http://localhost:11434/api/chat"
+	defaultOllamaModel    = "llama3"
+)
+
+// OllamaProvider talks to a local Ollama-style chat endpoint
+type OllamaProvider struct {
+	client httpLLMClient
+}
+
+type ollamaChatRequest struct {
+	Model    string        `json:"model"`
+	Messages []LLMMessage  `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Format   interface{}   `json:"format,omitempty"` // JSON Schema for structured output
+	Options  ollamaOptions `json:"options"`
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+type ollamaChatResponse struct {
+	Model           string     `json:"model"`
+	Message         LLMMessage `json:"message"`
+	PromptEvalCount int        `json:"prompt_eval_count"`
+	EvalCount       int        `json:"eval_count"`
+}
+
+// NewOllamaProvider creates a provider for an Ollama-style endpoint
+func NewOllamaProvider(cfg LLMProviderConfig) *OllamaProvider {
+	return &OllamaProvider{client: newHTTPLLMClient(cfg, defaultOllamaEndpoint, defaultOllamaModel)}
+}
+
+// Name returns the provider name
+func (p *OllamaProvider) Name() string {
+	return LLMProviderOllama
+}
+
+// Complete sends a non-streaming chat request
+func (p *OllamaProvider) Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	body := ollamaChatRequest{
+		Model:    p.client.modelFor(req),
+		Messages: req.Messages,
+		Options: ollamaOptions{
+			Temperature: req.Temperature,
+			NumPredict:  req.MaxTokens,
+		},
+	}
+	if req.ResponseSchema != nil {
+		body.Format = req.ResponseSchema.Schema
+	}
+
+	headers := map[string]string{}
+	if p.client.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.client.apiKey
+	}
+
+	var resp ollamaChatResponse
+	if err := p.client.post(ctx, req, headers, body, &resp); err != nil {
+		return nil, fmt.Errorf("error from Ollama API: %v", err)
+	}
+	if resp.Message.Content == "" {
+		return nil, fmt.Errorf("no message content in Ollama response")
+	}
+
+	return &LLMResponse{
+		Content:      resp.Message.Content,
+		Model:        resp.Model,
+		InputTokens:  resp.PromptEvalCount,
+		OutputTokens: resp.EvalCount,
+	}, nil
+}
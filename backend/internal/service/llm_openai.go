@@ -0,0 +1,85 @@
+// internal/service/llm_openai.go
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	defaultOpenAIEndpoint = "https://api.openai.com/v1/chat/completions"
+	defaultOpenAIModel    = "gpt-4"
+)
+
+// OpenAIProvider talks to OpenAI-compatible chat completions endpoints
+type OpenAIProvider struct {
+	client httpLLMClient
+}
+
+type openAIChatRequest struct {
+	Model       string       `json:"model"`
+	Messages    []LLMMessage `json:"messages"`
+	Temperature float64      `json:"temperature"`
+	MaxTokens   int          `json:"max_tokens,omitempty"`
+
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string         `json:"type"`
+	JSONSchema *LLMJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message LLMMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// NewOpenAIProvider creates a provider for an OpenAI-compatible endpoint
+func NewOpenAIProvider(cfg LLMProviderConfig) *OpenAIProvider {
+	return &OpenAIProvider{client: newHTTPLLMClient(cfg, defaultOpenAIEndpoint, defaultOpenAIModel)}
+}
+
+// Name returns the provider name
+func (p *OpenAIProvider) Name() string {
+	return LLMProviderOpenAI
+}
+
+// Complete sends a chat completion request
+func (p *OpenAIProvider) Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	body := openAIChatRequest{
+		Model:       p.client.modelFor(req),
+		Messages:    req.Messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}
+	if req.ResponseSchema != nil {
+		body.ResponseFormat = &openAIResponseFormat{Type: "json_schema", JSONSchema: req.ResponseSchema}
+	}
+
+	headers := map[string]string{}
+	if p.client.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.client.apiKey
+	}
+
+	var resp openAIChatResponse
+	if err := p.client.post(ctx, req, headers, body, &resp); err != nil {
+		return nil, fmt.Errorf("error from OpenAI API: %v", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in OpenAI response")
+	}
+
+	return &LLMResponse{
+		Content:      resp.Choices[0].Message.Content,
+		Model:        resp.Model,
+		InputTokens:  resp.Usage.PromptTokens,
+		OutputTokens: resp.Usage.CompletionTokens,
+	}, nil
+}
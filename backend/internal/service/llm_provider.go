@@ -0,0 +1,164 @@
+// internal/service/llm_provider.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// LLM provider names accepted in configuration
+const (
+	LLMProviderOpenAI    = "openai"
+	LLMProviderAnthropic = "anthropic"
+	LLMProviderOllama    = "ollama"
+	LLMProviderMock      = "mock"
+)
+
+// defaultLLMTimeout bounds a completion when neither the call nor the provider sets a timeout
+const defaultLLMTimeout = 60 * time.Second
+
+// LLMMessage is one message of a chat conversation
+type LLMMessage struct {
+	Role    string `json:"role"` // "system", "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// LLMJSONSchema asks a provider for a single JSON object matching Schema
+type LLMJSONSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// LLMRequest is a provider-neutral chat completion request. Zero values fall back to the
+// provider's defaults.
+type LLMRequest struct {
+	Model          string
+	Messages       []LLMMessage
+	Temperature    float64
+	MaxTokens      int
+	Timeout        time.Duration
+	ResponseSchema *LLMJSONSchema // Requests structured JSON output when set
+	Purpose        string         // One of the models.AIPurpose* values, for usage accounting
+}
+
+// LLMResponse is the text a provider completed along with its token usage
+type LLMResponse struct {
+	Content      string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+}
+
+// LLMProvider completes chat conversations against a language model backend
+type LLMProvider interface {
+	Name() string
+	Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error)
+}
+
+// LLMProviderConfig configures an LLM provider; empty fields use the provider's defaults
+type LLMProviderConfig struct {
+	Provider string
+	APIKey   string
+	Endpoint string
+	Model    string
+	Timeout  time.Duration
+}
+
+// NewLLMProvider creates the provider named in cfg
+func NewLLMProvider(cfg LLMProviderConfig) (LLMProvider, error) {
+	switch cfg.Provider {
+	case LLMProviderOpenAI, "":
+		return NewOpenAIProvider(cfg), nil
+	case LLMProviderAnthropic:
+		return NewAnthropicProvider(cfg), nil
+	case LLMProviderOllama:
+		return NewOllamaProvider(cfg), nil
+	case LLMProviderMock:
+		return NewMockLLMProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider: %s", cfg.Provider)
+	}
+}
+
+// httpLLMClient holds the settings shared by the HTTP-backed providers
+type httpLLMClient struct {
+	apiKey     string
+	endpoint   string
+	model      string
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+func newHTTPLLMClient(cfg LLMProviderConfig, defaultEndpoint, defaultModel string) httpLLMClient {
+	client := httpLLMClient{
+		apiKey:     cfg.APIKey,
+		endpoint:   cfg.Endpoint,
+		model:      cfg.Model,
+		timeout:    cfg.Timeout,
+		httpClient: &http.Client{},
+	}
+	if client.endpoint == "" {
+		client.endpoint = defaultEndpoint
+	}
+	if client.model == "" {
+		client.model = defaultModel
+	}
+	if client.timeout <= 0 {
+		client.timeout = defaultLLMTimeout
+	}
+	return client
+}
+
+// modelFor returns the model a request should use
+func (c *httpLLMClient) modelFor(req LLMRequest) string {
+	if req.Model != "" {
+		return req.Model
+	}
+	return c.model
+}
+
+// post sends a JSON body to the provider endpoint and decodes the JSON reply into out. The
+// request's timeout, or the provider's, bounds the whole exchange.
+func (c *httpLLMClient) post(ctx context.Context, req LLMRequest, headers map[string]string, body, out interface{}) error {
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = c.timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(httpResp.Body, 4096))
+		return fmt.Errorf("status %d: %s", httpResp.StatusCode, bytes.TrimSpace(errBody))
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// internal/service/llm_provider_test.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// llmTestServer records the last request body and headers and replies with reply
+func llmTestServer(t *testing.T, status int, reply string) (*httptest.Server, *map[string]interface{}, *http.Header) {
+	t.Helper()
+	body := map[string]interface{}{}
+	headers := http.Header{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(server.Close)
+	return server, &body, &headers
+}
+
+func testConversation() []LLMMessage {
+	return []LLMMessage{
+		{Role: "system", Content: "You are a trader."},
+		{Role: "user", Content: "Describe a strategy."},
+	}
+}
+
+func TestNewLLMProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{"", LLMProviderOpenAI},
+		{LLMProviderOpenAI, LLMProviderOpenAI},
+		{LLMProviderAnthropic, LLMProviderAnthropic},
+		{LLMProviderOllama, LLMProviderOllama},
+		{LLMProviderMock, LLMProviderMock},
+	}
+	for _, tt := range tests {
+		provider, err := NewLLMProvider(LLMProviderConfig{Provider: tt.provider})
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, provider.Name())
+	}
+
+	_, err := NewLLMProvider(LLMProviderConfig{Provider: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestOpenAIProviderComplete(t *testing.T) {
+	server, body, headers := llmTestServer(t, http.StatusOK,
+		`{"model":"gpt-4o","choices":[{"message":{"role":"assistant","content":"hello"}}],"usage":{"prompt_tokens":12,"completion_tokens":3}}`)
+
+	provider := NewOpenAIProvider(LLMProviderConfig{APIKey: "secret", Endpoint: server.URL, Model: "gpt-4"})
+	resp, err := provider.Complete(context.Background(), LLMRequest{
+		Model:       "gpt-4o",
+		Messages:    testConversation(),
+		Temperature: 0.2,
+		MaxTokens:   100,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "gpt-4o", (*body)["model"], "per-call model overrides the configured one")
+	assert.Equal(t, 0.2, (*body)["temperature"])
+	assert.Equal(t, float64(100), (*body)["max_tokens"])
+	assert.Len(t, (*body)["messages"], 2)
+
+	assert.Equal(t, "hello", resp.Content)
+	assert.Equal(t, "gpt-4o", resp.Model)
+	assert.Equal(t, 12, resp.InputTokens)
+	assert.Equal(t, 3, resp.OutputTokens)
+}
+
+func TestOpenAIProviderDefaultModel(t *testing.T) {
+	server, body, _ := llmTestServer(t, http.StatusOK, `{"choices":[{"message":{"content":"ok"}}]}`)
+
+	provider := NewOpenAIProvider(LLMProviderConfig{Endpoint: server.URL, Model: "local-model"})
+	_, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation()})
+	require.NoError(t, err)
+	assert.Equal(t, "local-model", (*body)["model"])
+}
+
+func TestOpenAIProviderErrors(t *testing.T) {
+	server, _, _ := llmTestServer(t, http.StatusTooManyRequests, `{"error":"rate limited"}`)
+	provider := NewOpenAIProvider(LLMProviderConfig{Endpoint: server.URL})
+	_, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "429")
+	assert.Contains(t, err.Error(), "rate limited")
+
+	empty, _, _ := llmTestServer(t, http.StatusOK, `{"choices":[]}`)
+	provider = NewOpenAIProvider(LLMProviderConfig{Endpoint: empty.URL})
+	_, err = provider.Complete(context.Background(), LLMRequest{Messages: testConversation()})
+	assert.Error(t, err)
+}
+
+func TestAnthropicProviderComplete(t *testing.T) {
+	server, body, headers := llmTestServer(t, http.StatusOK,
+		`{"model":"claude-test","content":[{"type":"text","text":"hel"},{"type":"text","text":"lo"}],"usage":{"input_tokens":9,"output_tokens":2}}`)
+
+	provider := NewAnthropicProvider(LLMProviderConfig{APIKey: "secret", Endpoint: server.URL, Model: "claude-test"})
+	resp, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation(), Temperature: 0.5})
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", headers.Get("x-api-key"))
+	assert.Equal(t, anthropicAPIVersion, headers.Get("anthropic-version"))
+	assert.Equal(t, "claude-test", (*body)["model"])
+	assert.Equal(t, "You are a trader.", (*body)["system"])
+	assert.Equal(t, float64(defaultAnthropicMaxTokens), (*body)["max_tokens"])
+
+	messages := (*body)["messages"].([]interface{})
+	require.Len(t, messages, 1, "system messages move out of the conversation")
+	assert.Equal(t, "user", messages[0].(map[string]interface{})["role"])
+
+	assert.Equal(t, "hello", resp.Content)
+	assert.Equal(t, 9, resp.InputTokens)
+	assert.Equal(t, 2, resp.OutputTokens)
+}
+
+func TestOllamaProviderComplete(t *testing.T) {
+	server, body, headers := llmTestServer(t, http.StatusOK,
+		`{"model":"llama3","message":{"role":"assistant","content":"hi"},"prompt_eval_count":7,"eval_count":1}`)
+
+	provider := NewOllamaProvider(LLMProviderConfig{Endpoint: server.URL})
+	resp, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation(), Temperature: 0.3, MaxTokens: 50})
+	require.NoError(t, err)
+
+	assert.Empty(t, headers.Get("Authorization"))
+	assert.Equal(t, defaultOllamaModel, (*body)["model"])
+	assert.Equal(t, false, (*body)["stream"])
+	options := (*body)["options"].(map[string]interface{})
+	assert.Equal(t, 0.3, options["temperature"])
+	assert.Equal(t, float64(50), options["num_predict"])
+
+	assert.Equal(t, "hi", resp.Content)
+	assert.Equal(t, 7, resp.InputTokens)
+	assert.Equal(t, 1, resp.OutputTokens)
+}
+
+func TestLLMProviderResponseSchema(t *testing.T) {
+	schema := &LLMJSONSchema{Name: "strategy", Schema: map[string]interface{}{"type": "object"}}
+	req := LLMRequest{Messages: testConversation(), ResponseSchema: schema}
+
+	openAI, openAIBody, _ := llmTestServer(t, http.StatusOK, `{"choices":[{"message":{"content":"{}"}}]}`)
+	_, err := NewOpenAIProvider(LLMProviderConfig{Endpoint: openAI.URL}).Complete(context.Background(), req)
+	require.NoError(t, err)
+	format := (*openAIBody)["response_format"].(map[string]interface{})
+	assert.Equal(t, "json_schema", format["type"])
+	assert.Equal(t, "strategy", format["json_schema"].(map[string]interface{})["name"])
+
+	ollama, ollamaBody, _ := llmTestServer(t, http.StatusOK, `{"message":{"content":"{}"}}`)
+	_, err = NewOllamaProvider(LLMProviderConfig{Endpoint: ollama.URL}).Complete(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"type": "object"}, (*ollamaBody)["format"])
+
+	anthropic, anthropicBody, _ := llmTestServer(t, http.StatusOK, `{"content":[{"type":"text","text":"{}"}]}`)
+	_, err = NewAnthropicProvider(LLMProviderConfig{Endpoint: anthropic.URL}).Complete(context.Background(), req)
+	require.NoError(t, err)
+	assert.Contains(t, (*anthropicBody)["system"], `{"type":"object"}`)
+	assert.Contains(t, (*anthropicBody)["system"], "You are a trader.")
+}
+
+func TestLLMProviderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(LLMProviderConfig{Endpoint: server.URL, Timeout: time.Minute})
+
+	start := time.Now()
+	_, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation(), Timeout: 50 * time.Millisecond})
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second, "per-call timeout overrides the provider timeout")
+}
+
+func TestMockLLMProviderDeterministic(t *testing.T) {
+	req := LLMRequest{Messages: []LLMMessage{{Role: "user", Content: "Return ONLY the JSON object"}}}
+
+	first, err := NewMockLLMProvider().Complete(context.Background(), req)
+	require.NoError(t, err)
+	second, err := NewMockLLMProvider().Complete(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, first.Content, second.Content)
+
+	var strategy map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(first.Content), &strategy))
+	assert.Contains(t, strategy, "takeProfitPct")
+
+	analysis, err := NewMockLLMProvider().Complete(context.Background(), LLMRequest{Messages: testConversation()})
+	require.NoError(t, err)
+	assert.NotContains(t, analysis.Content, "{")
+	assert.Greater(t, analysis.OutputTokens, 0)
+}
+
+func TestMockLLMProviderQueueAndError(t *testing.T) {
+	provider := NewMockLLMProvider("first")
+	provider.Enqueue("second")
+
+	for _, want := range []string{"first", "second"} {
+		resp, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation()})
+		require.NoError(t, err)
+		assert.Equal(t, want, resp.Content)
+	}
+
+	provider.SetError(errors.New("offline"))
+	_, err := provider.Complete(context.Background(), LLMRequest{Messages: testConversation()})
+	assert.EqualError(t, err, "offline")
+	assert.Len(t, provider.Requests(), 3)
+}
+
+func TestAIServiceGenerateStrategyWithMock(t *testing.T) {
+	store := memory.NewStore()
+	provider := NewMockLLMProvider()
+	aiService := NewAIService(provider, memory.NewStrategyRepository(store), logger.New("test"))
+
+	strategy, err := aiService.GenerateStrategy("Create a strategy", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, strategy.Name)
+	assert.Contains(t, strategy.Config, "stopLossPct")
+
+	requests := provider.Requests()
+	require.Len(t, requests, 1)
+	assert.Empty(t, requests[0].Model, "the provider's configured model is used")
+	assert.Equal(t, "system", requests[0].Messages[0].Role)
+}
@@ -0,0 +1,625 @@
+// internal/service/prompt_library.go
+package service
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// Prompt template kinds; each kind renders its own typed input
+const (
+	PromptKindStrategy   = "strategy"    // Renders a StrategyPromptInput
+	PromptKindAnalysis   = "analysis"    // Renders an AnalysisPromptInput
+	PromptKindDraft      = "draft"       // Renders a DraftPromptInput
+	PromptKindPostMortem = "post_mortem" // Renders a PostMortemPromptInput
+)
+
+// Built-in templates used by the AI service and automation jobs
+const (
+	DefaultStrategyPrompt      = "generation"
+	DefaultAnalysisPrompt      = "performance"
+	EvolutionStrategyPrompt    = "evolution"
+	OptimizationStrategyPrompt = "optimization"
+	DefaultDraftPrompt         = "authoring"
+	DefaultPostMortemPrompt    = "trade"
+)
+
+// maxPromptTopStrategies is how many top performing strategies a prompt shows
+const maxPromptTopStrategies = 3
+
+// maxPromptRejections is how many recently rejected candidates a prompt shows
+const maxPromptRejections = 3
+
+//go:embed prompts
+var builtinPrompts embed.FS
+
+var (
+	promptFilePattern    = regexp.MustCompile(`^v([1-9][0-9]*)\.tmpl$`)
+	promptIDPattern      = regexp.MustCompile(`^[a-z0-9_]+$`)
+	promptExtendsPattern = regexp.MustCompile(`^\{\{/\*\s*extends\s+([a-z0-9_]+@v[1-9][0-9]*)\s*\*/\}\}`)
+)
+
+// StrategyPromptInput is the data strategy templates render
+type StrategyPromptInput struct {
+	Instruction    string                  // What the caller asked for; templates may set their own instead
+	OptionalParams []StrategyPromptParam   // Optional strategy parameters and what they do
+	TopStrategies  []StrategyPromptExample // Best performing strategies to learn from
+	BaseStrategy   *StrategyPromptExample  // Strategy being optimized, if any
+
+	// Recently rejected candidates, so the model avoids repeating them
+	RecentRejections []StrategyPromptRejection
+
+	// Summary of recent simulation runs from the feedback context builder, so the model can
+	// address why strategies lost money
+	Feedback string
+}
+
+// StrategyPromptParam describes a strategy parameter to the model
+type StrategyPromptParam struct {
+	Key         string
+	Description string
+}
+
+// StrategyPromptExample summarizes an existing strategy; parameters it doesn't set are zero
+type StrategyPromptExample struct {
+	ID                 int64
+	Name               string
+	WinCount           int
+	WinRate            float64
+	MarketCapThreshold float64
+	TakeProfitPct      float64
+	StopLossPct        float64
+}
+
+// StrategyPromptRejection is a generated strategy that failed admission and why
+type StrategyPromptRejection struct {
+	Name       string
+	Parameters string // The candidate's config as JSON
+	Reasons    []string
+}
+
+// AnalysisPromptInput is the data analysis templates render. Metrics missing from the
+// performance summary are zero.
+type AnalysisPromptInput struct {
+	StrategyName       string
+	TotalTrades        int // Completed trades
+	ActiveTrades       int
+	WinRate            float64
+	ROI                float64
+	MaxDrawdown        float64
+	NetPnL             float64
+	AvgProfit          float64
+	AvgLoss            float64
+	HasActiveTrades    bool
+	IsActiveSimulation bool
+}
+
+// DraftPromptInput is the data draft templates render. A new draft has no CurrentDraft or
+// Instruction.
+type DraftPromptInput struct {
+	Description          string             // The user's description of the strategy
+	Params               []DraftPromptParam // Every strategy parameter
+	CurrentDraft         string             // The draft being revised as JSON
+	Instruction          string             // The change asked for
+	PreviousInstructions []string           // Changes applied to the current draft, oldest first
+}
+
+// DraftPromptParam describes a strategy parameter and its bounds to the model
+type DraftPromptParam struct {
+	Key         string
+	Type        string
+	Required    bool
+	Bounds      string // e.g. "1-99" or "one of [10 30 60 300]"
+	Description string
+}
+
+// PostMortemPromptInput is the data post-mortem templates render: one closed trade and
+// what the market did around it. Price changes are percentages from the entry price.
+type PostMortemPromptInput struct {
+	StrategyName  string
+	Parameters    string // The strategy's config as JSON
+	Trade         PostMortemTrade
+	EntrySignal   string                 // Entry signal data and token features as JSON, empty when not recorded
+	Launch        *models.LaunchAnalysis // nil when the launch wasn't analyzed
+	Anomalies     *models.TokenAnomalies // nil when the token's trades weren't analyzed
+	PricePath     []PostMortemPricePoint // During the hold
+	AfterExit     []PostMortemPricePoint // After the exit
+	SimilarTrades []PostMortemSimilarTrade
+	Diagnoses     []PostMortemDiagnosisCode // Codes the model chooses from
+}
+
+// PostMortemTrade describes the trade being diagnosed
+type PostMortemTrade struct {
+	TokenSymbol    string
+	EntryPrice     float64
+	ExitPrice      float64
+	EntryMarketCap float64
+	ExitMarketCap  float64
+	HoldSec        int64
+	ReturnPct      float64
+	ProfitLoss     float64 // SOL
+	ExitReason     string
+	MaxRunUpPct    float64 // Highest price during the hold
+	MaxDrawdownPct float64 // Lowest price during the hold
+	HoldTrades     int     // Token trades during the hold
+}
+
+// PostMortemPricePoint is the last price in a slice of time after entry or exit
+type PostMortemPricePoint struct {
+	OffsetSec int64
+	ChangePct float64
+}
+
+// PostMortemSimilarTrade is another closed trade of the strategy entered at a similar
+// market cap
+type PostMortemSimilarTrade struct {
+	EntryMarketCap float64
+	HoldSec        int64
+	ReturnPct      float64
+	ExitReason     string
+	Diagnoses      []string // Codes of its post-mortem, if it has one
+}
+
+// PostMortemDiagnosisCode is a diagnosis the model may make
+type PostMortemDiagnosisCode struct {
+	Code        string
+	Description string
+}
+
+// postMortemDiagnoses describes every diagnosis code to the model
+var postMortemDiagnoses = []PostMortemDiagnosisCode{
+	{models.DiagnosisBundledLaunch, "entered a launch where bundled or sniper wallets held much of the supply"},
+	{models.DiagnosisWashTrading, "the entry signal came from wash trading, dust buys or other manufactured activity"},
+	{models.DiagnosisLateEntry, "entered after most of the move had already happened"},
+	{models.DiagnosisStopTooTight, "the stop loss was hit by normal volatility and the price recovered afterwards"},
+	{models.DiagnosisStopTooLoose, "the stop loss let a failed entry lose far more than it needed to"},
+	{models.DiagnosisTakeProfitTooLow, "sold well below the run-up that followed"},
+	{models.DiagnosisHeldTooLong, "gave back a run-up waiting for an exit that never came"},
+	{models.DiagnosisHolderDump, "the creator or large holders sold into the position"},
+	{models.DiagnosisThinLiquidity, "too few trades for the entry signal or exit price to mean much"},
+	{models.DiagnosisSoundTrade, "the trade played out as the strategy intended; nothing to change"},
+	{models.DiagnosisOther, "a cause none of the other codes describe"},
+}
+
+// draftPromptParams describes every strategy parameter except the name and description
+var draftPromptParams = newDraftPromptParams()
+
+// newDraftPromptParams builds the parameter list of draft prompts from strategyFields
+func newDraftPromptParams() []DraftPromptParam {
+	descriptions := make(map[string]string, len(optionalStrategyParams))
+	for _, param := range optionalStrategyParams {
+		descriptions[param.Key] = param.Description
+	}
+
+	var params []DraftPromptParam
+	for _, field := range strategyFields {
+		if field.Key == "name" || field.Key == "description" {
+			continue
+		}
+
+		param := DraftPromptParam{
+			Key:         field.Key,
+			Type:        field.Type,
+			Required:    field.Required,
+			Description: field.Description,
+		}
+		if param.Description == "" {
+			param.Description = descriptions[field.Key]
+		}
+		switch {
+		case len(field.Enum) > 0:
+			param.Bounds = fmt.Sprintf("one of %v", field.Enum)
+		case field.Type == "boolean":
+			param.Bounds = "true or false"
+		default:
+			param.Bounds = fmt.Sprintf("%g-%g", field.Min, field.Max)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// promptSampleInputs are rendered through every template when it is loaded, so a template
+// using a field its kind's input doesn't have fails at startup instead of in a job
+var promptSampleInputs = map[string]interface{}{
+	PromptKindStrategy: StrategyPromptInput{
+		Instruction:    "Generate a trading strategy",
+		OptionalParams: optionalStrategyParams,
+		TopStrategies: []StrategyPromptExample{
+			{ID: 1, Name: "Sample", WinCount: 3, WinRate: 60, MarketCapThreshold: 7000, TakeProfitPct: 50, StopLossPct: 20},
+		},
+		BaseStrategy: &StrategyPromptExample{ID: 1, Name: "Sample", WinCount: 3},
+		RecentRejections: []StrategyPromptRejection{
+			{Name: "Rejected", Parameters: `{"takeProfitPct":500}`, Reasons: []string{"made 0 backtest trades, at least 3 required"}},
+		},
+		Feedback: "Exit reasons, biggest losses first (4 closed trades):\n- stop_loss: 3 trades, 0% win rate, -0.6000 SOL total, -0.2000 SOL average",
+	},
+	PromptKindAnalysis: AnalysisPromptInput{
+		StrategyName:       "Sample",
+		TotalTrades:        10,
+		ActiveTrades:       1,
+		WinRate:            60,
+		ROI:                12.5,
+		MaxDrawdown:        8,
+		NetPnL:             1.25,
+		AvgProfit:          0.4,
+		AvgLoss:            -0.2,
+		HasActiveTrades:    true,
+		IsActiveSimulation: true,
+	},
+	PromptKindDraft: DraftPromptInput{
+		Description:          "buy tokens with 5+ unique buyers in 30s under $15k, exit at 2x or 90s",
+		Params:               draftPromptParams,
+		CurrentDraft:         `{"strategy":{"name":"Sample"},"rules":[],"explanations":[],"ambiguities":[]}`,
+		Instruction:          "use a 20% stop loss",
+		PreviousInstructions: []string{"exit at 3x instead"},
+	},
+	PromptKindPostMortem: PostMortemPromptInput{
+		StrategyName: "Sample",
+		Parameters:   `{"takeProfitPct":50,"stopLossPct":10}`,
+		Trade: PostMortemTrade{
+			TokenSymbol:    "SAMPLE",
+			EntryPrice:     0.00003,
+			ExitPrice:      0.000027,
+			EntryMarketCap: 8000,
+			ExitMarketCap:  7200,
+			HoldSec:        40,
+			ReturnPct:      -10,
+			ProfitLoss:     -0.05,
+			ExitReason:     "stop_loss",
+			MaxRunUpPct:    4,
+			MaxDrawdownPct: -10,
+			HoldTrades:     12,
+		},
+		EntrySignal:   `{"signal":{"buy_count":6},"features":{"windows":[{"window_sec":30,"volatility_pct":22}]}}`,
+		Launch:        &models.LaunchAnalysis{BundledSupplyPct: 35, SniperSupplyPct: 12, BundledWalletCount: 4, SniperCount: 2, EarlyBuyerCount: 9, Final: true},
+		Anomalies:     &models.TokenAnomalies{AnomalyScore: 40, FlaggedBuyPct: 25, FlaggedVolumePct: 30},
+		PricePath:     []PostMortemPricePoint{{OffsetSec: 10, ChangePct: 4}, {OffsetSec: 40, ChangePct: -10}},
+		AfterExit:     []PostMortemPricePoint{{OffsetSec: 60, ChangePct: 35}},
+		SimilarTrades: []PostMortemSimilarTrade{{EntryMarketCap: 7500, HoldSec: 30, ReturnPct: -10, ExitReason: "stop_loss", Diagnoses: []string{models.DiagnosisStopTooTight}}},
+		Diagnoses:     postMortemDiagnoses,
+	},
+}
+
+// PromptTemplate is one version of a prompt, stored as <kind>/<id>/v<version>.tmpl. It
+// defines a "system" and a "user" template. A file starting with
+// {{/* extends <id>@v<version> */}} is parsed on top of that template of the same kind and
+// only redefines the parts it changes. Parents are pinned to a version, so changing a
+// parent means adding a new version rather than silently changing what its children send.
+type PromptTemplate struct {
+	Kind    string `json:"kind"`
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	Extends string `json:"extends,omitempty"` // Parent as id@vN
+	tmpl    *template.Template
+}
+
+// Tag identifies the template and version, e.g. momentum@v2
+func (t *PromptTemplate) Tag() string {
+	return fmt.Sprintf("%s@v%d", t.ID, t.Version)
+}
+
+// RenderedPrompt is a template executed with its input
+type RenderedPrompt struct {
+	System   string
+	User     string
+	Template *PromptTemplate
+}
+
+// Render executes the template's system and user parts with input
+func (t *PromptTemplate) Render(input interface{}) (*RenderedPrompt, error) {
+	var system, user bytes.Buffer
+	if err := t.tmpl.ExecuteTemplate(&system, "system", input); err != nil {
+		return nil, fmt.Errorf("error rendering %s prompt %s: %v", t.Kind, t.Tag(), err)
+	}
+	if err := t.tmpl.ExecuteTemplate(&user, "user", input); err != nil {
+		return nil, fmt.Errorf("error rendering %s prompt %s: %v", t.Kind, t.Tag(), err)
+	}
+	return &RenderedPrompt{
+		System:   strings.TrimSpace(system.String()),
+		User:     strings.TrimSpace(user.String()),
+		Template: t,
+	}, nil
+}
+
+// PromptLibrary holds every version of every prompt template
+type PromptLibrary struct {
+	templates map[string][]*PromptTemplate // By kind/id, oldest version first
+}
+
+// promptFile is a template file read from a source but not yet parsed
+type promptFile struct {
+	kind    string
+	id      string
+	version int
+	extends string // Parent key, kind/id@vN
+	text    string
+}
+
+// LoadPromptLibrary loads the built-in templates plus those in dir, which uses the same
+// <kind>/<id>/v<version>.tmpl layout. Templates in dir may add versions and extend
+// built-in templates, but not replace a version that already exists.
+func LoadPromptLibrary(dir string) (*PromptLibrary, error) {
+	builtin, err := fs.Sub(builtinPrompts, "prompts")
+	if err != nil {
+		return nil, fmt.Errorf("error opening built-in prompts: %v", err)
+	}
+
+	sources := []fs.FS{builtin}
+	if dir != "" {
+		sources = append(sources, os.DirFS(dir))
+	}
+	return loadPromptLibrary(sources...)
+}
+
+// DefaultPromptLibrary returns the built-in templates. They are covered by tests, so a
+// failure to load them is a programming error.
+func DefaultPromptLibrary() *PromptLibrary {
+	library, err := LoadPromptLibrary("")
+	if err != nil {
+		panic(err)
+	}
+	return library
+}
+
+func loadPromptLibrary(sources ...fs.FS) (*PromptLibrary, error) {
+	files := make(map[string]*promptFile)
+	for _, source := range sources {
+		if err := readPromptFiles(source, files); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parsed := make(map[string]*PromptTemplate)
+	var parse func(key string, seen []string) (*PromptTemplate, error)
+	parse = func(key string, seen []string) (*PromptTemplate, error) {
+		if t, ok := parsed[key]; ok {
+			return t, nil
+		}
+		for _, k := range seen {
+			if k == key {
+				return nil, fmt.Errorf("prompt template %s extends itself", key)
+			}
+		}
+
+		file := files[key]
+		var base *template.Template
+		if file.extends != "" {
+			if _, ok := files[file.extends]; !ok {
+				return nil, fmt.Errorf("prompt template %s extends unknown template %s", key, file.extends)
+			}
+			parent, err := parse(file.extends, append(seen, key))
+			if err != nil {
+				return nil, err
+			}
+			if base, err = parent.tmpl.Clone(); err != nil {
+				return nil, fmt.Errorf("error cloning prompt template %s: %v", file.extends, err)
+			}
+		} else {
+			base = template.New(key)
+		}
+
+		tmpl, err := base.Parse(file.text)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing prompt template %s: %v", key, err)
+		}
+		for _, name := range []string{"system", "user"} {
+			if tmpl.Lookup(name) == nil {
+				return nil, fmt.Errorf("prompt template %s does not define %q", key, name)
+			}
+		}
+
+		t := &PromptTemplate{
+			Kind:    file.kind,
+			ID:      file.id,
+			Version: file.version,
+			tmpl:    tmpl,
+		}
+		if file.extends != "" {
+			t.Extends = strings.TrimPrefix(file.extends, file.kind+"/")
+		}
+		parsed[key] = t
+		return t, nil
+	}
+
+	library := &PromptLibrary{templates: make(map[string][]*PromptTemplate)}
+	for _, key := range keys {
+		t, err := parse(key, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := t.Render(promptSampleInputs[t.Kind]); err != nil {
+			return nil, err
+		}
+		library.templates[t.Kind+"/"+t.ID] = append(library.templates[t.Kind+"/"+t.ID], t)
+	}
+
+	for _, versions := range library.templates {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].Version < versions[j].Version
+		})
+	}
+
+	return library, nil
+}
+
+// readPromptFiles adds the template files in source to files, keyed by kind/id@vN
+func readPromptFiles(source fs.FS, files map[string]*promptFile) error {
+	return fs.WalkDir(source, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || path.Ext(name) != ".tmpl" {
+			return nil
+		}
+
+		parts := strings.Split(name, "/")
+		if len(parts) != 3 {
+			return fmt.Errorf("prompt template %s is not at <kind>/<id>/v<version>.tmpl", name)
+		}
+		kind, id := parts[0], parts[1]
+		if _, ok := promptSampleInputs[kind]; !ok {
+			return fmt.Errorf("prompt template %s has unknown kind %s", name, kind)
+		}
+		if !promptIDPattern.MatchString(id) {
+			return fmt.Errorf("prompt template %s: id must be lowercase letters, digits and underscores", name)
+		}
+		match := promptFilePattern.FindStringSubmatch(parts[2])
+		if match == nil {
+			return fmt.Errorf("prompt template %s: file name must be v<version>.tmpl", name)
+		}
+		version, _ := strconv.Atoi(match[1])
+
+		data, err := fs.ReadFile(source, name)
+		if err != nil {
+			return fmt.Errorf("error reading prompt template %s: %v", name, err)
+		}
+
+		file := &promptFile{kind: kind, id: id, version: version, text: string(data)}
+		if match := promptExtendsPattern.FindStringSubmatch(file.text); match != nil {
+			file.extends = kind + "/" + match[1]
+		}
+
+		key := fmt.Sprintf("%s/%s@v%d", kind, id, version)
+		if _, ok := files[key]; ok {
+			return fmt.Errorf("prompt template %s is defined more than once", key)
+		}
+		files[key] = file
+		return nil
+	})
+}
+
+// Get returns a template of the given kind by reference, either id for its latest version
+// or id@vN for a specific one
+func (l *PromptLibrary) Get(kind, ref string) (*PromptTemplate, error) {
+	id, version, err := ParsePromptRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := l.templates[kind+"/"+id]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("unknown %s prompt template %s", kind, id)
+	}
+	if version == 0 {
+		return versions[len(versions)-1], nil
+	}
+	for _, t := range versions {
+		if t.Version == version {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown %s prompt template %s", kind, ref)
+}
+
+// List returns every template of a kind, ordered by ID and version
+func (l *PromptLibrary) List(kind string) []*PromptTemplate {
+	var templates []*PromptTemplate
+	for key, versions := range l.templates {
+		if strings.HasPrefix(key, kind+"/") {
+			templates = append(templates, versions...)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].ID != templates[j].ID {
+			return templates[i].ID < templates[j].ID
+		}
+		return templates[i].Version < templates[j].Version
+	})
+	return templates
+}
+
+// ParsePromptRef splits a template reference into its ID and version; version is 0 when
+// the reference doesn't pin one
+func ParsePromptRef(ref string) (string, int, error) {
+	id, versionStr, pinned := strings.Cut(strings.TrimSpace(ref), "@v")
+	if !promptIDPattern.MatchString(id) {
+		return "", 0, fmt.Errorf("invalid prompt template reference %q", ref)
+	}
+	if !pinned {
+		return id, 0, nil
+	}
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version < 1 {
+		return "", 0, fmt.Errorf("invalid prompt template reference %q", ref)
+	}
+	return id, version, nil
+}
+
+// NewStrategyPromptInput builds the input for strategy templates from an instruction and
+// the strategies returned by GetTopPerformingStrategies
+func NewStrategyPromptInput(instruction string, topStrategies []map[string]interface{}) StrategyPromptInput {
+	input := StrategyPromptInput{
+		Instruction:    instruction,
+		OptionalParams: optionalStrategyParams,
+	}
+
+	for _, strategy := range topStrategies {
+		if len(input.TopStrategies) >= maxPromptTopStrategies {
+			break
+		}
+
+		example := StrategyPromptExample{}
+		example.ID, _ = strategy["id"].(int64)
+		example.Name, _ = strategy["name"].(string)
+		example.WinCount, _ = strategy["win_count"].(int)
+		example.WinRate, _ = strategy["win_rate"].(float64)
+		if params, ok := strategy["parameters"].(map[string]interface{}); ok {
+			example.MarketCapThreshold, _ = params["marketCapThreshold"].(float64)
+			example.TakeProfitPct, _ = params["takeProfitPct"].(float64)
+			example.StopLossPct, _ = params["stopLossPct"].(float64)
+		}
+		input.TopStrategies = append(input.TopStrategies, example)
+	}
+
+	return input
+}
+
+// newStrategyPromptExample summarizes a saved strategy for a prompt
+func newStrategyPromptExample(strategy *models.Strategy) *StrategyPromptExample {
+	example := &StrategyPromptExample{
+		ID:       strategy.ID,
+		Name:     strategy.Name,
+		WinCount: strategy.WinCount,
+		WinRate:  float64(strategy.WinCount) / float64(max(strategy.WinCount+strategy.VoteCount, 1)) * 100,
+	}
+	example.MarketCapThreshold, _ = strategy.Config["marketCapThreshold"].(float64)
+	example.TakeProfitPct, _ = strategy.Config["takeProfitPct"].(float64)
+	example.StopLossPct, _ = strategy.Config["stopLossPct"].(float64)
+	return example
+}
+
+// NewAnalysisPromptInput builds the input for analysis templates from a performance summary
+func NewAnalysisPromptInput(strategyName string, metrics map[string]interface{}, hasActiveTrades, isActiveSimulation bool) AnalysisPromptInput {
+	input := AnalysisPromptInput{
+		StrategyName:       strategyName,
+		HasActiveTrades:    hasActiveTrades,
+		IsActiveSimulation: isActiveSimulation,
+	}
+	input.TotalTrades, _ = metrics["total_trades"].(int)
+	input.ActiveTrades, _ = metrics["active_trades"].(int)
+	input.WinRate, _ = metrics["win_rate"].(float64)
+	input.ROI, _ = metrics["roi"].(float64)
+	input.MaxDrawdown, _ = metrics["max_drawdown"].(float64)
+	input.NetPnL, _ = metrics["net_pnl"].(float64)
+	input.AvgProfit, _ = metrics["avg_profit"].(float64)
+	input.AvgLoss, _ = metrics["avg_loss"].(float64)
+	return input
+}
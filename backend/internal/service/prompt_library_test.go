@@ -0,0 +1,227 @@
+// internal/service/prompt_library_test.go
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBasePrompt = `{{define "system"}}System {{.Instruction}}{{end}}
+{{define "instruction"}}{{.Instruction}}{{end}}
+{{define "user"}}Do this: {{template "instruction" .}}{{end}}`
+
+func TestBuiltinPromptLibrary(t *testing.T) {
+	library, err := LoadPromptLibrary("")
+	require.NoError(t, err)
+
+	for _, ref := range []string{DefaultStrategyPrompt, EvolutionStrategyPrompt, OptimizationStrategyPrompt, "momentum", "defensive", "early_entry", "diversified"} {
+		tmpl, err := library.Get(PromptKindStrategy, ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, PromptKindStrategy, tmpl.Kind)
+	}
+	_, err = library.Get(PromptKindAnalysis, DefaultAnalysisPrompt)
+	require.NoError(t, err)
+
+	_, err = library.Get(PromptKindAnalysis, "momentum")
+	assert.Error(t, err, "templates are looked up within their kind")
+	_, err = library.Get(PromptKindStrategy, "momentum@v99")
+	assert.Error(t, err)
+
+	momentum, err := library.Get(PromptKindStrategy, "momentum@v1")
+	require.NoError(t, err)
+	assert.Equal(t, "generation@v1", momentum.Extends)
+	assert.Equal(t, "momentum@v1", momentum.Tag())
+
+	momentum, err = library.Get(PromptKindStrategy, "momentum")
+	require.NoError(t, err)
+	assert.Equal(t, "generation@v2", momentum.Extends, "the latest versions show recent rejections")
+}
+
+func TestRenderStrategyPrompt(t *testing.T) {
+	library := DefaultPromptLibrary()
+	topStrategies := []map[string]interface{}{
+		{"id": int64(4), "name": "Sniper", "win_rate": 75.0, "parameters": map[string]interface{}{"takeProfitPct": 40.0}},
+	}
+
+	momentum, err := library.Get(PromptKindStrategy, "momentum")
+	require.NoError(t, err)
+	prompt, err := momentum.Render(NewStrategyPromptInput("ignored", topStrategies))
+	require.NoError(t, err)
+	assert.Contains(t, prompt.System, "professional algorithmic trader")
+	assert.True(t, strings.HasPrefix(prompt.User, "Generate a profitable trading strategy for cryptocurrency tokens focused on momentum"))
+	assert.NotContains(t, prompt.User, "ignored", "the template sets its own instruction")
+	assert.Contains(t, prompt.User, "Strategy: Sniper\nWin Rate: 75.00%\nParameters:\n- Take Profit: 40.00%\n")
+	assert.NotContains(t, prompt.User, "Guidelines")
+	assert.Contains(t, prompt.User, "- exitOnCreatorSell: Exit immediately when the token creator sells (boolean)\n")
+
+	generation, err := library.Get(PromptKindStrategy, DefaultStrategyPrompt)
+	require.NoError(t, err)
+	prompt, err = generation.Render(NewStrategyPromptInput("Create a scalping strategy", nil))
+	require.NoError(t, err)
+	assert.Contains(t, prompt.User, "Create a scalping strategy\n\nYou are tasked")
+	assert.Contains(t, prompt.User, "Guidelines for creating an effective strategy")
+	assert.Contains(t, prompt.User, "Return ONLY the JSON object for your strategy.")
+	assert.NotContains(t, prompt.User, "rejected after backtesting")
+
+	input := NewStrategyPromptInput("Create a scalping strategy", nil)
+	input.RecentRejections = []StrategyPromptRejection{
+		{Name: "Overtrader", Parameters: `{"minBuysForEntry":1}`, Reasons: []string{"backtest max drawdown 45.00% is above the 30.00% limit"}},
+	}
+	prompt, err = generation.Render(input)
+	require.NoError(t, err)
+	assert.Contains(t, prompt.User, "Strategy: Overtrader\nParameters: {\"minBuysForEntry\":1}\nRejected because:\n- backtest max drawdown 45.00% is above the 30.00% limit\n\nGenerate a complete strategy")
+
+	v1, err := library.Get(PromptKindStrategy, "generation@v1")
+	require.NoError(t, err)
+	prompt, err = v1.Render(input)
+	require.NoError(t, err)
+	assert.NotContains(t, prompt.User, "Overtrader", "pinned versions render as they always did")
+}
+
+func TestNewStrategyPromptInputLimitsTopStrategies(t *testing.T) {
+	var topStrategies []map[string]interface{}
+	for i := 0; i < 5; i++ {
+		topStrategies = append(topStrategies, map[string]interface{}{"id": int64(i + 1), "name": "s"})
+	}
+	input := NewStrategyPromptInput("", topStrategies)
+	assert.Len(t, input.TopStrategies, maxPromptTopStrategies)
+	assert.Equal(t, int64(1), input.TopStrategies[0].ID)
+}
+
+func TestRenderAnalysisPrompt(t *testing.T) {
+	tmpl, err := DefaultPromptLibrary().Get(PromptKindAnalysis, DefaultAnalysisPrompt)
+	require.NoError(t, err)
+
+	metrics := map[string]interface{}{"total_trades": 12, "active_trades": 2, "win_rate": 58.333, "roi": -4.2, "net_pnl": -0.42}
+	prompt, err := tmpl.Render(NewAnalysisPromptInput("Momentum", metrics, true, false))
+	require.NoError(t, err)
+	assert.Contains(t, prompt.User, "Strategy Name: Momentum\nTotal Completed Trades: 12\nActive Positions: 2\nWin Rate: 58.33%\nReturn on Investment (ROI): -4.20%\nNet Profit/Loss: -0.4200\n")
+	assert.NotContains(t, prompt.User, "Maximum Drawdown", "metrics missing from the summary are left out")
+	assert.Contains(t, prompt.User, "active open positions")
+	assert.NotContains(t, prompt.User, "currently being simulated")
+}
+
+func TestRenderDraftPrompt(t *testing.T) {
+	tmpl, err := DefaultPromptLibrary().Get(PromptKindDraft, DefaultDraftPrompt)
+	require.NoError(t, err)
+
+	prompt, err := tmpl.Render(DraftPromptInput{Description: "exit at 2x", Params: draftPromptParams})
+	require.NoError(t, err)
+	assert.Contains(t, prompt.User, "A user described the trading strategy they want:\n\"exit at 2x\"")
+	assert.Contains(t, prompt.User, "- stopLossPct (number, required, 1-99): Percentage loss that triggers stop loss")
+	assert.Contains(t, prompt.User, "- featureWindowSec (integer, one of [10 30 60 300]):")
+	assert.NotContains(t, prompt.User, "- name (", "the name is not a parameter")
+	assert.NotContains(t, prompt.User, "current draft")
+
+	prompt, err = tmpl.Render(DraftPromptInput{
+		Description:          "exit at 2x",
+		Params:               draftPromptParams,
+		CurrentDraft:         `{"strategy":{"takeProfitPct":100}}`,
+		Instruction:          "use a 20% stop loss",
+		PreviousInstructions: []string{"exit at 3x instead"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, prompt.User, "Changes they already asked for, oldest first:\n- exit at 3x instead\n")
+	assert.Contains(t, prompt.User, "The current draft:\n{\"strategy\":{\"takeProfitPct\":100}}")
+	assert.Contains(t, prompt.User, "keeping everything the change doesn't affect:\n\"use a 20% stop loss\"")
+}
+
+func TestPromptLibraryExtendsPinnedVersion(t *testing.T) {
+	library, err := loadPromptLibrary(fstest.MapFS{
+		"strategy/base/v1.tmpl":  {Data: []byte(testBasePrompt)},
+		"strategy/base/v2.tmpl":  {Data: []byte(`{{define "system"}}New system{{end}}{{define "user"}}New user{{end}}`)},
+		"strategy/child/v1.tmpl": {Data: []byte("{{/* extends base@v1 */}}\n{{define \"instruction\"}}Buy dips{{end}}")},
+	})
+	require.NoError(t, err)
+
+	latest, err := library.Get(PromptKindStrategy, "base")
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest.Version)
+
+	child, err := library.Get(PromptKindStrategy, "child")
+	require.NoError(t, err)
+	prompt, err := child.Render(StrategyPromptInput{Instruction: "Sell rips"})
+	require.NoError(t, err)
+	assert.Equal(t, "System Sell rips", prompt.System)
+	assert.Equal(t, "Do this: Buy dips", prompt.User)
+
+	base, err := library.Get(PromptKindStrategy, "base@v1")
+	require.NoError(t, err)
+	prompt, err = base.Render(StrategyPromptInput{Instruction: "Sell rips"})
+	require.NoError(t, err)
+	assert.Equal(t, "Do this: Sell rips", prompt.User, "children don't change their parent")
+
+	assert.Len(t, library.List(PromptKindStrategy), 3)
+	assert.Empty(t, library.List(PromptKindAnalysis))
+}
+
+func TestPromptLibraryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		err   string
+	}{
+		{"bad layout", fstest.MapFS{"strategy/v1.tmpl": {Data: []byte(testBasePrompt)}}, "is not at <kind>/<id>/v<version>.tmpl"},
+		{"unknown kind", fstest.MapFS{"email/base/v1.tmpl": {Data: []byte(testBasePrompt)}}, "unknown kind email"},
+		{"bad file name", fstest.MapFS{"strategy/base/latest.tmpl": {Data: []byte(testBasePrompt)}}, "file name must be v<version>.tmpl"},
+		{"missing user", fstest.MapFS{"strategy/base/v1.tmpl": {Data: []byte(`{{define "system"}}x{{end}}`)}}, `does not define "user"`},
+		{"unknown parent", fstest.MapFS{"strategy/child/v1.tmpl": {Data: []byte(`{{/* extends base@v3 */}}`)}}, "extends unknown template strategy/base@v3"},
+		{"cycle", fstest.MapFS{
+			"strategy/a/v1.tmpl": {Data: []byte(`{{/* extends b@v1 */}}`)},
+			"strategy/b/v1.tmpl": {Data: []byte(`{{/* extends a@v1 */}}`)},
+		}, "extends itself"},
+		{"unknown field", fstest.MapFS{
+			"analysis/base/v1.tmpl": {Data: []byte(`{{define "system"}}x{{end}}{{define "user"}}{{.Instruction}}{{end}}`)},
+		}, "can't evaluate field Instruction"},
+		{"syntax", fstest.MapFS{"strategy/base/v1.tmpl": {Data: []byte(`{{define "system"}}{{end}`)}}, "error parsing prompt template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadPromptLibrary(tt.files)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestLoadPromptLibraryDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "strategy", "momentum"), 0o755))
+	v3 := "{{/* extends generation@v2 */}}\n{{define \"instruction\"}}Chase breakouts{{end}}"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "strategy", "momentum", "v3.tmpl"), []byte(v3), 0o644))
+
+	library, err := LoadPromptLibrary(dir)
+	require.NoError(t, err)
+	momentum, err := library.Get(PromptKindStrategy, "momentum")
+	require.NoError(t, err)
+	assert.Equal(t, 3, momentum.Version, "directory templates add versions to the built-in ones")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "strategy", "momentum", "v1.tmpl"), []byte(v3), 0o644))
+	_, err = LoadPromptLibrary(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "defined more than once")
+}
+
+func TestParsePromptRef(t *testing.T) {
+	id, version, err := ParsePromptRef("momentum")
+	require.NoError(t, err)
+	assert.Equal(t, "momentum", id)
+	assert.Zero(t, version)
+
+	id, version, err = ParsePromptRef(" early_entry@v12 ")
+	require.NoError(t, err)
+	assert.Equal(t, "early_entry", id)
+	assert.Equal(t, 12, version)
+
+	for _, ref := range []string{"", "Momentum", "momentum@v0", "momentum@vx", "../momentum"} {
+		_, _, err := ParsePromptRef(ref)
+		assert.Error(t, err, ref)
+	}
+}
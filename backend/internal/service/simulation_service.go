@@ -37,6 +37,15 @@ type SimulationService struct {
 	simulationDone       chan int64
 	workerPool           chan struct{} // Limit concurrent token evaluations
 	shutdownCh           chan struct{} // Channel for graceful shutdown
+	feedHealth           FeedHealthChecker
+	creatorReputation    CreatorReputationProvider
+	smartWallets         SmartWalletProvider
+	holderLedger         HolderSnapshotProvider
+	launchAnalysis       LaunchAnalysisProvider
+	tradeAnomalies       TradeAnomalyProvider
+	tokenLifecycle       TokenLifecycleProvider
+	tokenFeatures        TokenFeatureProvider
+	entryModels          EntryModelProvider
 }
 
 // SimulationContext holds the context for an active simulation
@@ -51,6 +60,8 @@ type SimulationContext struct {
 	CurrentBalance  float64
 	InitialBalance  float64
 	SimulationRunID int64              // ID of the database record for this simulation run
+	EntriesPaused   bool               // New entries are paused while market data is degraded
+	DataDegraded    bool               // The run saw degraded market data at least once
 	mu              sync.RWMutex       // For thread-safe access to context data
 	tokensMu        sync.RWMutex       // For thread-safe access to trades slice
 	wg              sync.WaitGroup     // To wait for all goroutines to finish
@@ -122,6 +133,51 @@ func NewSimulationService(
 	return service
 }
 
+// SetFeedHealthChecker sets the checker used to pause entries during data gaps
+func (s *SimulationService) SetFeedHealthChecker(checker FeedHealthChecker) {
+	s.feedHealth = checker
+}
+
+// SetCreatorReputationProvider sets the provider used by creator entry filters
+func (s *SimulationService) SetCreatorReputationProvider(provider CreatorReputationProvider) {
+	s.creatorReputation = provider
+}
+
+// SetSmartWalletProvider sets the provider used by smart_money entry signals
+func (s *SimulationService) SetSmartWalletProvider(provider SmartWalletProvider) {
+	s.smartWallets = provider
+}
+
+// SetHolderSnapshotProvider sets the provider used by holder filters and dump exits
+func (s *SimulationService) SetHolderSnapshotProvider(provider HolderSnapshotProvider) {
+	s.holderLedger = provider
+}
+
+// SetLaunchAnalysisProvider sets the provider used by bundled-supply filters and launch-aware entry signals
+func (s *SimulationService) SetLaunchAnalysisProvider(provider LaunchAnalysisProvider) {
+	s.launchAnalysis = provider
+}
+
+// SetTradeAnomalyProvider sets the provider used by anomaly score filters and organic entry signals
+func (s *SimulationService) SetTradeAnomalyProvider(provider TradeAnomalyProvider) {
+	s.tradeAnomalies = provider
+}
+
+// SetTokenLifecycleProvider sets the provider used by king of the hill entries and graduation exits
+func (s *SimulationService) SetTokenLifecycleProvider(provider TokenLifecycleProvider) {
+	s.tokenLifecycle = provider
+}
+
+// SetTokenFeatureProvider sets the feature store entry logic reads features and recent trades from
+func (s *SimulationService) SetTokenFeatureProvider(provider TokenFeatureProvider) {
+	s.tokenFeatures = provider
+}
+
+// SetEntryModelProvider sets the provider of trained models used by model_score entries
+func (s *SimulationService) SetEntryModelProvider(provider EntryModelProvider) {
+	s.entryModels = provider
+}
+
 // Shutdown gracefully shuts down the service
 func (s *SimulationService) Shutdown() {
 	s.logger.Info("Shutting down simulation service...")
@@ -354,6 +410,38 @@ func validateStrategyConfig(config *models.StrategyConfig) error {
 	if config.MaxHoldTimeSec <= 0 {
 		return fmt.Errorf("max hold time must be positive")
 	}
+	switch config.EntrySignalType {
+	case "", models.EntrySignalBuyCount:
+	case models.EntrySignalSmartMoney:
+		if config.MinSmartWalletBuys <= 0 {
+			config.MinSmartWalletBuys = 2
+		}
+	case models.EntrySignalUniqueBuyers:
+		if config.MinUniqueBuyers <= 0 {
+			config.MinUniqueBuyers = 3
+		}
+	case models.EntrySignalOrganicVolume:
+		if config.MinOrganicBuyVolumeSol <= 0 {
+			return fmt.Errorf("minOrganicBuyVolumeSol must be positive for organic_volume entries")
+		}
+	case models.EntrySignalKingOfTheHill:
+	case models.EntrySignalModelScore:
+		if config.EntryModelName == "" {
+			return fmt.Errorf("entryModelName is required for model_score entries")
+		}
+		if config.EntryModelVersion < 0 {
+			return fmt.Errorf("entryModelVersion must not be negative")
+		}
+		if config.MinModelScore <= 0 || config.MinModelScore >= 1 {
+			return fmt.Errorf("minModelScore must be between 0 and 1 for model_score entries")
+		}
+	default:
+		return fmt.Errorf("unsupported entry signal type: %s", config.EntrySignalType)
+	}
+
+	if config.FeatureWindowSec != 0 && !isFeatureWindow(config.FeatureWindowSec) {
+		return fmt.Errorf("featureWindowSec must be one of %v", models.FeatureWindows)
+	}
 	return nil
 }
 
@@ -528,6 +616,12 @@ func (s *SimulationService) runSimulation(ctx *SimulationContext) {
 
 // runSimulationIteration runs a single iteration of the simulation
 func (s *SimulationService) runSimulationIteration(ctx *SimulationContext) error {
+	// Don't open positions on stale data; open trades keep being monitored
+	if s.checkDataHealth(ctx) {
+		s.sendSimulationStatusUpdate(ctx)
+		return nil
+	}
+
 	// Fetch tokens to evaluate - using a shorter age window to focus on fresh tokens
 	// Changed back from 24 hours to 5 minutes to focus on newest tokens
 	maxAgeSec := int64(300)
@@ -607,6 +701,43 @@ func (s *SimulationService) runSimulationIteration(ctx *SimulationContext) error
 	return nil
 }
 
+// checkDataHealth pauses or resumes entries based on feed health and returns
+// true while entries are paused
+func (s *SimulationService) checkDataHealth(ctx *SimulationContext) bool {
+	if s.feedHealth == nil {
+		return false
+	}
+
+	degraded, reason := s.feedHealth.IsDegraded()
+
+	ctx.mu.Lock()
+	wasPaused := ctx.EntriesPaused
+	firstDegradation := degraded && !ctx.DataDegraded
+	ctx.EntriesPaused = degraded
+	if degraded {
+		ctx.DataDegraded = true
+	}
+	ctx.mu.Unlock()
+
+	if firstDegradation {
+		if err := s.simulationRunRepo.MarkDataDegraded(ctx.SimulationRunID, reason); err != nil {
+			s.logger.Error("Error marking simulation run %d as degraded: %v", ctx.SimulationRunID, err)
+		}
+	}
+
+	if degraded && !wasPaused {
+		s.logger.Warn("Pausing entries for strategy %d: %s", ctx.StrategyID, reason)
+		s.sendSimulationEvent(ctx, "entries_paused", map[string]interface{}{
+			"reason": reason,
+		})
+	} else if !degraded && wasPaused {
+		s.logger.Info("Resuming entries for strategy %d, market data is healthy again", ctx.StrategyID)
+		s.sendSimulationEvent(ctx, "entries_resumed", nil)
+	}
+
+	return degraded
+}
+
 // hasExistingTrade checks if we already have any trade (active or completed) for this token
 func (s *SimulationService) hasExistingTrade(ctx *SimulationContext, tokenID int64) bool {
 	// First check in-memory trades
@@ -642,6 +773,23 @@ func (s *SimulationService) hasActiveTradeForToken(ctx *SimulationContext, token
 	return false
 }
 
+const (
+	// maxEntryTokenAgeSec is the age above which tokens are no longer entered
+	maxEntryTokenAgeSec = 180
+
+	// Tolerances around a strategy's market cap threshold within which tokens are entered
+	entryMarketCapLowerTolerancePct = -10.0
+	entryMarketCapUpperTolerancePct = 40.0
+)
+
+// inEntryMarketCapRange reports whether a USD market cap is close enough to the strategy's
+// market cap threshold to enter
+func inEntryMarketCapRange(config models.StrategyConfig, marketCap float64) bool {
+	lowerLimit := config.MarketCapThreshold * (1.0 + entryMarketCapLowerTolerancePct/100.0)
+	upperLimit := config.MarketCapThreshold * (1.0 + entryMarketCapUpperTolerancePct/100.0)
+	return marketCap >= lowerLimit && marketCap <= upperLimit
+}
+
 // evaluateToken evaluates a token against a strategy
 func (s *SimulationService) evaluateToken(ctx *SimulationContext, token *models.Token) error {
 	// Check if context is cancelled
@@ -684,28 +832,54 @@ func (s *SimulationService) evaluateToken(ctx *SimulationContext, token *models.
 	// We're adding back the token age restriction to focus on fresh tokens
 	// This ensures we're looking at the newest tokens on the market
 	now := time.Now().Unix()
-	if now-token.CreatedTimestamp/1000 > 180 { // 3 min = 180 seconds
+	if now-token.CreatedTimestamp/1000 > maxEntryTokenAgeSec {
 		return nil // Skip older tokens
 	}
 
 	// Check if token meets basic criteria like market cap threshold
-	const marketCapLowerTolerance = -10.0
-	const marketCapUpperTolerance = 40.0
-	marketCapLowerLimit := ctx.Config.MarketCapThreshold * (1.0 + marketCapLowerTolerance/100.0)
-	marketCapUpperLimit := ctx.Config.MarketCapThreshold * (1.0 + marketCapUpperTolerance/100.0)
+	if !inEntryMarketCapRange(ctx.Config, token.UsdMarketCap) {
+		s.logger.Debug("Token %s (%s) is outside the market cap range around $%.2f: $%.2f",
+			token.Symbol, token.Name, ctx.Config.MarketCapThreshold, token.UsdMarketCap)
+		return nil // Skip tokens outside the tolerance range
+	}
 
-	if token.UsdMarketCap < marketCapLowerLimit {
-		return nil // Skip tokens below the lower limit
+	// Apply creator reputation filters before looking at trades
+	creatorProfile, skipReason := s.checkCreatorFilters(ctx, token)
+	if skipReason != "" {
+		s.logger.Debug("Skipping token %s: %s", token.Symbol, skipReason)
+		return nil
 	}
 
-	if token.UsdMarketCap > marketCapUpperLimit {
-		s.logger.Debug("Token %s (%s) exceeds market cap upper limit: $%.2f > $%.2f",
-			token.Symbol, token.Name, token.UsdMarketCap, marketCapUpperLimit)
-		return nil // Skip tokens above the upper tolerance limit
+	// Refuse tokens whose supply is concentrated in a few wallets
+	holderSnapshot, skipReason := s.checkHolderFilters(ctx, token)
+	if skipReason != "" {
+		s.logger.Debug("Skipping token %s: %s", token.Symbol, skipReason)
+		return nil
+	}
+
+	// Refuse launches where bundled wallets took too much of the supply
+	launchAnalysis, skipReason := s.checkLaunchFilters(ctx, token)
+	if skipReason != "" {
+		s.logger.Debug("Skipping token %s: %s", token.Symbol, skipReason)
+		return nil
+	}
+
+	// Refuse tokens whose activity is mostly wash trading or bots
+	anomalies, skipReason := s.checkAnomalyFilters(ctx, token)
+	if skipReason != "" {
+		s.logger.Debug("Skipping token %s: %s", token.Symbol, skipReason)
+		return nil
+	}
+
+	// Refuse tokens whose rolling-window features fail the strategy's feature filters
+	features, skipReason := s.checkFeatureFilters(ctx, token)
+	if skipReason != "" {
+		s.logger.Debug("Skipping token %s: %s", token.Symbol, skipReason)
+		return nil
 	}
 
 	// Get recent trades for this token
-	trades, err := s.tradeRepo.GetTradesByTokenID(token.ID, 50)
+	trades, err := s.getRecentTrades(token)
 	if err != nil {
 		return fmt.Errorf("error fetching trades: %v", err)
 	}
@@ -715,10 +889,29 @@ func (s *SimulationService) evaluateToken(ctx *SimulationContext, token *models.
 	}
 
 	// Analyze trades based on strategy
-	entrySignal, entrySignalData := s.analyzeEntrySignal(ctx, token, trades)
+	lifecycle := s.getTokenLifecycle(token)
+	entrySignal, entrySignalData := s.analyzeEntrySignal(ctx, token, trades, launchAnalysis, anomalies, lifecycle, features)
 	if !entrySignal {
 		return nil // No entry signal detected
 	}
+	if creatorProfile != nil {
+		entrySignalData["creator_reputation"] = creatorProfile.ReputationScore
+		entrySignalData["creator_launches"] = creatorProfile.TokensLaunched
+		entrySignalData["creator_graduations"] = creatorProfile.TokensGraduated
+	}
+	if holderSnapshot != nil {
+		entrySignalData["top10_pct"] = holderSnapshot.Top10Pct
+		entrySignalData["unique_holders"] = holderSnapshot.UniqueHolders
+		entrySignalData["creator_pct"] = holderSnapshot.CreatorPct
+	}
+	if features != nil {
+		if window := features.Window(featureWindowSec(ctx.Config)); window != nil {
+			entrySignalData["feature_window_sec"] = window.WindowSec
+			entrySignalData["net_flow_sol"] = window.NetFlowSol
+			entrySignalData["price_velocity_pct"] = window.PriceVelocityPct
+			entrySignalData["volatility_pct"] = window.VolatilityPct
+		}
+	}
 
 	// We've removed the random skip to ensure we evaluate all qualifying tokens
 	// This ensures maximum trading opportunities are captured
@@ -740,6 +933,9 @@ func (s *SimulationService) evaluateToken(ctx *SimulationContext, token *models.
 		Status:            "active",
 		SimulationRunID:   &ctx.SimulationRunID,
 	}
+	if modelVersion, ok := entrySignalData["model_version"].(string); ok {
+		simTrade.ModelVersion = &modelVersion
+	}
 
 	// Update balance (with mutex protection)
 	ctx.mu.Lock()
@@ -762,6 +958,7 @@ func (s *SimulationService) evaluateToken(ctx *SimulationContext, token *models.
 		return fmt.Errorf("error saving simulated trade to database: %v", err)
 	}
 	simTrade.ID = tradeID
+	s.saveFeatureSnapshot(ctx, token, simTrade, models.FeatureDecisionEntry, features)
 
 	// Add to in-memory list of trades (with mutex protection)
 	ctx.tokensMu.Lock()
@@ -799,6 +996,275 @@ func (s *SimulationService) evaluateToken(ctx *SimulationContext, token *models.
 	return nil
 }
 
+// checkCreatorFilters applies the strategy's creator filters and returns the creator's
+// profile along with a reason when the token should be skipped
+func (s *SimulationService) checkCreatorFilters(ctx *SimulationContext, token *models.Token) (*models.CreatorProfile, string) {
+	if s.creatorReputation == nil {
+		return nil, ""
+	}
+	if ctx.Config.MinCreatorReputation <= 0 && ctx.Config.MaxCreatorLaunchesWithoutGraduation <= 0 {
+		return nil, ""
+	}
+
+	profile, err := s.creatorReputation.GetProfile(token.CreatorAddress)
+	if err != nil {
+		// Don't block entries on a lookup failure
+		s.logger.Error("Error getting creator profile for %s: %v", token.CreatorAddress, err)
+		return nil, ""
+	}
+
+	return profile, creatorFilterReason(ctx.Config, profile)
+}
+
+// creatorFilterReason returns why a creator fails the strategy's filters, or "" if it
+// passes. Creators without a profile have no history and pass.
+func creatorFilterReason(config models.StrategyConfig, profile *models.CreatorProfile) string {
+	if profile == nil {
+		return ""
+	}
+
+	if config.MinCreatorReputation > 0 && profile.ReputationScore < config.MinCreatorReputation {
+		return fmt.Sprintf("creator reputation %.1f below %.1f", profile.ReputationScore, config.MinCreatorReputation)
+	}
+
+	if config.MaxCreatorLaunchesWithoutGraduation > 0 && profile.TokensGraduated == 0 &&
+		profile.TokensLaunched > config.MaxCreatorLaunchesWithoutGraduation {
+		return fmt.Sprintf("creator has %d launches and no graduation", profile.TokensLaunched)
+	}
+
+	return ""
+}
+
+// checkHolderFilters applies the strategy's holder concentration filter and returns the
+// token's holder snapshot along with a reason when the token should be skipped
+func (s *SimulationService) checkHolderFilters(ctx *SimulationContext, token *models.Token) (*models.HolderSnapshot, string) {
+	if s.holderLedger == nil || ctx.Config.MaxTop10ConcentrationPct <= 0 {
+		return nil, ""
+	}
+
+	snapshot, err := s.holderLedger.GetHolderSnapshot(token.ID)
+	if err != nil {
+		s.logger.Error("Error getting holder snapshot for %s: %v", token.MintAddress, err)
+		return nil, ""
+	}
+
+	if snapshot.Top10Pct > ctx.Config.MaxTop10ConcentrationPct {
+		return snapshot, fmt.Sprintf("top 10 holders own %.1f%% of supply, above %.1f%%",
+			snapshot.Top10Pct, ctx.Config.MaxTop10ConcentrationPct)
+	}
+
+	return snapshot, ""
+}
+
+// checkLaunchFilters applies the strategy's bundled supply filter and returns the token's
+// launch analysis along with a reason when the token should be skipped. The analysis is
+// also fetched without a filter so entry signals can discount launch buyers.
+func (s *SimulationService) checkLaunchFilters(ctx *SimulationContext, token *models.Token) (*models.LaunchAnalysis, string) {
+	if s.launchAnalysis == nil {
+		return nil, ""
+	}
+
+	analysis, err := s.launchAnalysis.GetLaunchAnalysis(token)
+	if err != nil {
+		s.logger.Error("Error getting launch analysis for %s: %v", token.MintAddress, err)
+		return nil, ""
+	}
+
+	if ctx.Config.MaxBundledSupplyPct > 0 && analysis.BundledSupplyPct > ctx.Config.MaxBundledSupplyPct {
+		return analysis, fmt.Sprintf("bundled launch wallets bought %.1f%% of supply, above %.1f%%",
+			analysis.BundledSupplyPct, ctx.Config.MaxBundledSupplyPct)
+	}
+
+	return analysis, ""
+}
+
+// checkAnomalyFilters applies the strategy's anomaly score filter and returns the token's
+// trade anomalies along with a reason when the token should be skipped
+func (s *SimulationService) checkAnomalyFilters(ctx *SimulationContext, token *models.Token) (*models.TokenAnomalies, string) {
+	if s.tradeAnomalies == nil {
+		return nil, ""
+	}
+
+	anomalies, err := s.tradeAnomalies.GetTokenAnomalies(token)
+	if err != nil {
+		s.logger.Error("Error getting trade anomalies for %s: %v", token.MintAddress, err)
+		return nil, ""
+	}
+
+	if ctx.Config.MaxAnomalyScore > 0 && anomalies.AnomalyScore > ctx.Config.MaxAnomalyScore {
+		return anomalies, fmt.Sprintf("trade anomaly score %.1f is above %.1f",
+			anomalies.AnomalyScore, ctx.Config.MaxAnomalyScore)
+	}
+
+	return anomalies, ""
+}
+
+// checkFeatureFilters applies the strategy's feature filters and returns the token's
+// rolling-window features along with a reason when the token should be skipped
+func (s *SimulationService) checkFeatureFilters(ctx *SimulationContext, token *models.Token) (*models.TokenFeatures, string) {
+	if s.tokenFeatures == nil {
+		return nil, ""
+	}
+
+	features, err := s.tokenFeatures.GetTokenFeatures(token)
+	if err != nil {
+		s.logger.Error("Error getting features for %s: %v", token.MintAddress, err)
+		return nil, ""
+	}
+
+	return features, featureFilterReason(ctx.Config, features)
+}
+
+// featureFilterReason returns why a token's features fail the strategy's feature filters,
+// or "" if they pass
+func featureFilterReason(config models.StrategyConfig, features *models.TokenFeatures) string {
+	windowSec := featureWindowSec(config)
+	window := features.Window(windowSec)
+	if window == nil {
+		return ""
+	}
+
+	if config.MaxSecondsSinceLastTrade > 0 &&
+		(features.SecondsSinceLastTrade < 0 || features.SecondsSinceLastTrade > config.MaxSecondsSinceLastTrade) {
+		return fmt.Sprintf("no trade in the last %ds", config.MaxSecondsSinceLastTrade)
+	}
+	if config.MinNetFlowSol != 0 && window.NetFlowSol < config.MinNetFlowSol {
+		return fmt.Sprintf("net flow %.3f SOL over %ds below %.3f", window.NetFlowSol, windowSec, config.MinNetFlowSol)
+	}
+	if config.MinPriceVelocityPct != 0 && window.PriceVelocityPct < config.MinPriceVelocityPct {
+		return fmt.Sprintf("price velocity %.2f%%/min over %ds below %.2f", window.PriceVelocityPct, windowSec, config.MinPriceVelocityPct)
+	}
+	if config.MaxVolatilityPct > 0 && window.VolatilityPct > config.MaxVolatilityPct {
+		return fmt.Sprintf("volatility %.2f%% over %ds above %.2f", window.VolatilityPct, windowSec, config.MaxVolatilityPct)
+	}
+
+	return ""
+}
+
+// featureWindowSec returns the feature window a strategy's filters use
+func featureWindowSec(config models.StrategyConfig) int {
+	if config.FeatureWindowSec > 0 {
+		return config.FeatureWindowSec
+	}
+	return featureDefaultWindowSec
+}
+
+// isFeatureWindow reports whether the feature store maintains a window of this length
+func isFeatureWindow(windowSec int) bool {
+	for _, window := range models.FeatureWindows {
+		if window == windowSec {
+			return true
+		}
+	}
+	return false
+}
+
+// getRecentTrades returns a token's recent trades, newest first, from the feature store
+// when available and from the database otherwise
+func (s *SimulationService) getRecentTrades(token *models.Token) ([]*models.Trade, error) {
+	if s.tokenFeatures != nil {
+		trades, err := s.tokenFeatures.GetRecentTrades(token)
+		if err == nil {
+			return trades, nil
+		}
+		s.logger.Error("Error getting recent trades for %s from feature store: %v", token.MintAddress, err)
+	}
+
+	return s.tradeRepo.GetTradesByTokenID(token.ID, 50)
+}
+
+// saveFeatureSnapshot persists a token's features at an entry or exit decision. Features
+// are read from the store when the caller has none.
+func (s *SimulationService) saveFeatureSnapshot(
+	ctx *SimulationContext,
+	token *models.Token,
+	trade *models.SimulatedTrade,
+	decision string,
+	features *models.TokenFeatures,
+) {
+	if s.tokenFeatures == nil {
+		return
+	}
+
+	if features == nil {
+		var err error
+		features, err = s.tokenFeatures.GetTokenFeatures(token)
+		if err != nil {
+			s.logger.Error("Error getting features for %s: %v", token.MintAddress, err)
+			return
+		}
+	}
+
+	strategyID := ctx.StrategyID
+	runID := ctx.SimulationRunID
+	tradeID := trade.ID
+	snapshot := &models.TokenFeatureSnapshot{
+		TokenID:          token.ID,
+		StrategyID:       &strategyID,
+		SimulationRunID:  &runID,
+		SimulatedTradeID: &tradeID,
+		Decision:         decision,
+		Features:         features,
+	}
+	if err := s.tokenFeatures.SaveSnapshot(snapshot); err != nil {
+		s.logger.Error("Error saving %s feature snapshot for %s: %v", decision, token.MintAddress, err)
+	}
+}
+
+// getTokenLifecycle returns the lifecycle of a token, or nil when it isn't available
+func (s *SimulationService) getTokenLifecycle(token *models.Token) *models.TokenLifecycle {
+	if s.tokenLifecycle == nil {
+		return nil
+	}
+
+	lifecycle, err := s.tokenLifecycle.GetLifecycle(token.ID)
+	if err != nil {
+		s.logger.Error("Error getting lifecycle for %s: %v", token.MintAddress, err)
+		return nil
+	}
+
+	return lifecycle
+}
+
+// holderWatchList returns the wallets whose balances decide holder dump exits
+func holderWatchList(config models.StrategyConfig, entry *models.HolderSnapshot) []string {
+	var wallets []string
+	if config.ExitOnCreatorSell && entry.CreatorAddress != "" {
+		wallets = append(wallets, entry.CreatorAddress)
+	}
+	if config.ExitOnTopHolderDumpPct > 0 {
+		for _, holder := range entry.TopHolders {
+			wallets = append(wallets, holder.WalletAddress)
+		}
+	}
+	return wallets
+}
+
+// holderExitReason compares current balances with those at entry and returns
+// "creator_sell" or "top_holder_dump" when the strategy says to get out
+func holderExitReason(config models.StrategyConfig, entry *models.HolderSnapshot, balances map[string]float64) string {
+	if config.ExitOnCreatorSell && entry.CreatorBalance > 0 {
+		if current, ok := balances[entry.CreatorAddress]; ok && current < entry.CreatorBalance {
+			return "creator_sell"
+		}
+	}
+
+	if config.ExitOnTopHolderDumpPct > 0 {
+		for _, holder := range entry.TopHolders {
+			current, ok := balances[holder.WalletAddress]
+			if !ok || holder.Balance <= 0 {
+				continue
+			}
+			soldPct := (holder.Balance - current) / holder.Balance * 100
+			if soldPct >= config.ExitOnTopHolderDumpPct {
+				return "top_holder_dump"
+			}
+		}
+	}
+
+	return ""
+}
+
 // monitorTrade monitors an active trade for exit conditions
 func (s *SimulationService) monitorTrade(ctx *SimulationContext, trade *models.SimulatedTrade, token *models.Token) {
 	defer ctx.wg.Done()
@@ -822,6 +1288,19 @@ func (s *SimulationService) monitorTrade(ctx *SimulationContext, trade *models.S
 	entryTime := time.Unix(trade.EntryTimestamp, 0)
 	deadline := entryTime.Add(maxHoldTime)
 
+	// Remember who held what at entry so creator and top holder dumps can be spotted
+	var entryHolders *models.HolderSnapshot
+	var watchedWallets []string
+	if s.holderLedger != nil && (ctx.Config.ExitOnCreatorSell || ctx.Config.ExitOnTopHolderDumpPct > 0) {
+		snapshot, err := s.holderLedger.GetHolderSnapshot(token.ID)
+		if err != nil {
+			s.logger.Error("Error getting holder snapshot for %s, holder exits disabled: %v", token.Symbol, err)
+		} else {
+			entryHolders = snapshot
+			watchedWallets = holderWatchList(ctx.Config, snapshot)
+		}
+	}
+
 	// Track last checked price
 	var lastCheckedPrice float64
 
@@ -879,11 +1358,30 @@ func (s *SimulationService) monitorTrade(ctx *SimulationContext, trade *models.S
 			// Check exit conditions
 			exitReason := ""
 
-			// Take profit condition
-			if currentPrice >= takeProfitLevel {
-				exitReason = "take_profit"
-			} else if currentPrice <= stopLossLevel { // Stop loss condition
-				exitReason = "stop_loss"
+			// Graduation moves trading off the bonding curve
+			if ctx.Config.ExitOnGraduation {
+				if lifecycle := s.getTokenLifecycle(token); lifecycle != nil && lifecycle.State == models.TokenStateGraduated {
+					exitReason = "graduated"
+				}
+			}
+
+			// Holder dumps come first, the price usually hasn't caught up yet
+			if exitReason == "" && len(watchedWallets) > 0 {
+				balances, err := s.holderLedger.GetWalletBalances(token.ID, watchedWallets)
+				if err != nil {
+					s.logger.Error("Error getting holder balances for %s: %v", token.Symbol, err)
+				} else {
+					exitReason = holderExitReason(ctx.Config, entryHolders, balances)
+				}
+			}
+
+			if exitReason == "" {
+				// Take profit condition
+				if currentPrice >= takeProfitLevel {
+					exitReason = "take_profit"
+				} else if currentPrice <= stopLossLevel { // Stop loss condition
+					exitReason = "stop_loss"
+				}
 			}
 
 			// If we have an exit reason, close the trade
@@ -958,6 +1456,7 @@ func (s *SimulationService) closeTradeWithReason(trade *models.SimulatedTrade, t
 	if err := s.simulatedTradeRepo.Update(trade); err != nil {
 		s.logger.Error("Error updating simulated trade: %v", err)
 	}
+	s.saveFeatureSnapshot(ctx, token, trade, models.FeatureDecisionExit, nil)
 
 	// Send trade exit event
 	s.sendSimulationEvent(ctx, "trade_closed", map[string]interface{}{
@@ -985,9 +1484,19 @@ func (s *SimulationService) closeTradeWithReason(trade *models.SimulatedTrade, t
 }
 
 // analyzeEntrySignal determines if a token should be bought based on strategy rules
-func (s *SimulationService) analyzeEntrySignal(ctx *SimulationContext, token *models.Token, trades []*models.Trade) (bool, map[string]interface{}) {
+func (s *SimulationService) analyzeEntrySignal(
+	ctx *SimulationContext,
+	token *models.Token,
+	trades []*models.Trade,
+	launch *models.LaunchAnalysis,
+	anomalies *models.TokenAnomalies,
+	lifecycle *models.TokenLifecycle,
+	features *models.TokenFeatures,
+) (bool, map[string]interface{}) {
 	// Count buy transactions in the time window
 	buyCount := 0
+	organicBuyCount := 0
+	var organicBuyVolume float64
 	var latestPrice float64
 
 	// Track additional signal data for logging/debugging
@@ -1003,6 +1512,22 @@ func (s *SimulationService) analyzeEntrySignal(ctx *SimulationContext, token *mo
 	s.logger.Info("Current time: %d, Lookback window: %d (%d seconds ago)",
 		now, lookbackTime, ctx.Config.EntryTimeWindowSec)
 
+	// Distinct smart wallets buying within the window
+	smartBuyers := make(map[string]bool)
+
+	// Distinct wallets whose buys are neither dust nor from flagged wallets
+	uniqueBuyers := make(map[string]bool)
+
+	// Bundled and sniper wallets from the launch don't count as organic buyers
+	launchBuyers := make(map[string]bool)
+	if launch != nil {
+		for _, buyer := range launch.Buyers {
+			if buyer.Bundled || hasLaunchLabel(buyer, models.LaunchLabelSniper) {
+				launchBuyers[buyer.WalletAddress] = true
+			}
+		}
+	}
+
 	// Analyze trades
 	for _, trade := range trades {
 		// Only look at recent trades within our time window
@@ -1013,6 +1538,17 @@ func (s *SimulationService) analyzeEntrySignal(ctx *SimulationContext, token *mo
 		// Count buys
 		if trade.IsBuy {
 			buyCount++
+			if !launchBuyers[trade.UserAddress] {
+				organicBuyCount++
+			}
+			if s.smartWallets != nil && s.smartWallets.IsSmartWallet(trade.UserAddress) {
+				smartBuyers[trade.UserAddress] = true
+			}
+			excluded := ctx.Config.ExcludeLaunchBuyers && launchBuyers[trade.UserAddress]
+			if !excluded && (anomalies == nil || anomalies.IsOrganicBuy(trade)) {
+				uniqueBuyers[trade.UserAddress] = true
+				organicBuyVolume += trade.SolAmount
+			}
 		}
 
 		// Track latest price (assuming last trade price is representative)
@@ -1023,12 +1559,96 @@ func (s *SimulationService) analyzeEntrySignal(ctx *SimulationContext, token *mo
 
 	// Save signal analysis data
 	signalData["buy_count"] = buyCount
-	signalData["min_buys_required"] = ctx.Config.MinBuysForEntry
 	signalData["lookback_window_sec"] = ctx.Config.EntryTimeWindowSec
 	signalData["latest_price"] = latestPrice
+	if launch != nil {
+		signalData["organic_buy_count"] = organicBuyCount
+		signalData["bundled_supply_pct"] = launch.BundledSupplyPct
+		signalData["sniper_supply_pct"] = launch.SniperSupplyPct
+		signalData["launch_sniper_count"] = launch.SniperCount
+	}
+	signalData["unique_buyers"] = len(uniqueBuyers)
+	signalData["organic_buy_volume_sol"] = organicBuyVolume
+	if anomalies != nil {
+		signalData["anomaly_score"] = anomalies.AnomalyScore
+		signalData["flagged_buy_pct"] = anomalies.FlaggedBuyPct
+	}
+	if lifecycle != nil {
+		signalData["lifecycle_state"] = lifecycle.State
+	}
+
+	switch ctx.Config.EntrySignalType {
+	case models.EntrySignalSmartMoney:
+		wallets := make([]string, 0, len(smartBuyers))
+		for wallet := range smartBuyers {
+			wallets = append(wallets, wallet)
+		}
+		sort.Strings(wallets)
 
-	// Check if we meet the minimum buys threshold
-	return buyCount >= ctx.Config.MinBuysForEntry, signalData
+		signalData["signal_type"] = models.EntrySignalSmartMoney
+		signalData["smart_wallet_buys"] = len(smartBuyers)
+		signalData["min_smart_wallet_buys"] = ctx.Config.MinSmartWalletBuys
+		signalData["smart_wallets"] = wallets
+
+		return len(smartBuyers) >= ctx.Config.MinSmartWalletBuys, signalData
+
+	case models.EntrySignalUniqueBuyers:
+		signalData["signal_type"] = models.EntrySignalUniqueBuyers
+		signalData["min_unique_buyers"] = ctx.Config.MinUniqueBuyers
+		return len(uniqueBuyers) >= ctx.Config.MinUniqueBuyers, signalData
+
+	case models.EntrySignalOrganicVolume:
+		signalData["signal_type"] = models.EntrySignalOrganicVolume
+		signalData["min_organic_buy_volume_sol"] = ctx.Config.MinOrganicBuyVolumeSol
+		return organicBuyVolume >= ctx.Config.MinOrganicBuyVolumeSol, signalData
+
+	case models.EntrySignalKingOfTheHill:
+		signalData["signal_type"] = models.EntrySignalKingOfTheHill
+		if lifecycle == nil || lifecycle.KingOfTheHillAt == 0 {
+			return false, signalData
+		}
+		signalData["king_of_the_hill_at"] = lifecycle.KingOfTheHillAt
+		return lifecycle.KingOfTheHillAt >= lookbackTime, signalData
+
+	case models.EntrySignalModelScore:
+		signalData["signal_type"] = models.EntrySignalModelScore
+		signalData["min_model_score"] = ctx.Config.MinModelScore
+		if s.entryModels == nil || features == nil {
+			return false, signalData
+		}
+		model, err := s.entryModels.GetEntryModel(ctx.Config.EntryModelName, ctx.Config.EntryModelVersion)
+		if err != nil {
+			s.logger.Error("Error getting entry model %s: %v", ctx.Config.EntryModelName, err)
+			return false, signalData
+		}
+		score := ScoreEntryModel(model, features)
+		signalData["model_score"] = score
+		signalData["model_version"] = model.Tag()
+		return score > ctx.Config.MinModelScore, signalData
+
+	default:
+		signalData["signal_type"] = models.EntrySignalBuyCount
+		signalData["min_buys_required"] = ctx.Config.MinBuysForEntry
+
+		count := buyCount
+		if ctx.Config.ExcludeLaunchBuyers {
+			count = organicBuyCount
+			signalData["excluded_launch_buyers"] = true
+		}
+
+		// Check if we meet the minimum buys threshold
+		return count >= ctx.Config.MinBuysForEntry, signalData
+	}
+}
+
+// hasLaunchLabel reports whether a launch buyer carries the given label
+func hasLaunchLabel(buyer *models.LaunchBuyer, label string) bool {
+	for _, l := range buyer.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
 }
 
 // sendSimulationEvent sends a simulation event via WebSocket
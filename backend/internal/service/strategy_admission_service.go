@@ -0,0 +1,221 @@
+// internal/service/strategy_admission_service.go
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// AdmissionConfig is the bar a generated strategy's backtest must clear before the strategy
+// is made public and queued for simulation
+type AdmissionConfig struct {
+	LookbackHours  int     // Hours of stored trades the backtest replays
+	MinTrades      int     // Trades the backtest must make
+	MaxDrawdownPct float64 // Largest backtest drawdown allowed, in percent
+	MinExpectancy  float64 // Average backtest profit per trade, in SOL, that must be exceeded
+}
+
+// StrategyRejectedError is returned when a generated strategy is not admitted. The
+// strategy is kept only as a candidate, with Status saying whether it was rejected, merged
+// into a near-duplicate or held unverified because it couldn't be backtested.
+type StrategyRejectedError struct {
+	CandidateID int64
+	Status      string // One of the Admission* values other than AdmissionAdmitted
+	Reasons     []string
+}
+
+func (e *StrategyRejectedError) Error() string {
+	if e.Status == models.AdmissionPending {
+		return "strategy held unverified: " + strings.Join(e.Reasons, "; ")
+	}
+	return "strategy rejected: " + strings.Join(e.Reasons, "; ")
+}
+
+// StrategyAdmissionService decides whether AI-generated strategies go live. A strategy's
+// config must pass validation, then a backtest over recent stored trades must clear the
+// configured thresholds. Strategies the backtest can't judge are never admitted; they are
+// held as pending candidates. Every candidate is kept with its backtest, so rejection
+// reasons can be fed back into the next generation prompt.
+type StrategyAdmissionService struct {
+	backtester    *Backtester
+	strategyRepo  repository.StrategyRepositoryInterface
+	candidateRepo repository.StrategyCandidateRepositoryInterface
+	diversity     *StrategyDiversityService
+	config        AdmissionConfig
+	logger        *logger.Logger
+}
+
+// NewStrategyAdmissionService creates a new strategy admission service
+func NewStrategyAdmissionService(
+	backtester *Backtester,
+	strategyRepo repository.StrategyRepositoryInterface,
+	candidateRepo repository.StrategyCandidateRepositoryInterface,
+	config AdmissionConfig,
+	logger *logger.Logger,
+) *StrategyAdmissionService {
+	return &StrategyAdmissionService{
+		backtester:    backtester,
+		strategyRepo:  strategyRepo,
+		candidateRepo: candidateRepo,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// SetDiversity sets what near-duplicates of saved strategies are checked against. A
+// near-duplicate is never admitted; when merging, its candidate points at the saved strategy.
+func (s *StrategyAdmissionService) SetDiversity(diversity *StrategyDiversityService) {
+	s.diversity = diversity
+}
+
+// Admit saves a generated strategy and returns its ID if it passes admission, and returns a
+// *StrategyRejectedError otherwise. Either way the candidate is recorded.
+func (s *StrategyAdmissionService) Admit(strategy *models.Strategy, purpose string) (int64, error) {
+	candidate := &models.StrategyCandidate{
+		Name:           strategy.Name,
+		Description:    strategy.Description,
+		Config:         strategy.Config,
+		Purpose:        purpose,
+		PromptTemplate: strategy.PromptTemplate,
+		PromptVersion:  strategy.PromptVersion,
+		Status:         models.AdmissionRejected,
+	}
+
+	if s.diversity != nil {
+		duplicate, err := s.diversity.Resolve(strategy)
+		if err != nil {
+			return 0, fmt.Errorf("error checking for near-duplicates: %v", err)
+		}
+		if duplicate != nil {
+			if duplicate.Merged {
+				candidate.Status = models.AdmissionMerged
+				candidate.StrategyID = &duplicate.ExistingID
+			}
+			candidate.Reasons = []string{duplicate.Error()}
+			s.saveCandidate(candidate)
+			return 0, &StrategyRejectedError{CandidateID: candidate.ID, Status: candidate.Status, Reasons: candidate.Reasons}
+		}
+	}
+
+	status, reasons, err := s.evaluate(strategy, candidate)
+	if err != nil {
+		return 0, err
+	}
+
+	if status != models.AdmissionAdmitted {
+		candidate.Status = status
+		candidate.Reasons = reasons
+		s.saveCandidate(candidate)
+		s.logger.Info("Did not admit generated strategy %s (%s): %s", strategy.Name, status, strings.Join(reasons, "; "))
+		return 0, &StrategyRejectedError{CandidateID: candidate.ID, Status: status, Reasons: reasons}
+	}
+
+	id, err := s.strategyRepo.Save(strategy)
+	if err != nil {
+		return 0, fmt.Errorf("error saving admitted strategy: %v", err)
+	}
+	strategy.ID = id
+
+	candidate.Status = models.AdmissionAdmitted
+	candidate.StrategyID = &id
+	s.saveCandidate(candidate)
+
+	return id, nil
+}
+
+// evaluate validates and backtests a strategy, recording the backtest on the candidate. It
+// returns the admission status the strategy earned and the reasons it was not admitted.
+// Strategies the backtest can't judge are pending rather than admitted, since they never
+// had to clear the thresholds.
+func (s *StrategyAdmissionService) evaluate(strategy *models.Strategy, candidate *models.StrategyCandidate) (string, []string, error) {
+	var config models.StrategyConfig
+	configData, err := json.Marshal(strategy.Config)
+	if err != nil {
+		return "", nil, fmt.Errorf("error encoding strategy config: %v", err)
+	}
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return models.AdmissionRejected, []string{fmt.Sprintf("invalid config: %v", err)}, nil
+	}
+	if err := validateStrategyConfig(&config); err != nil {
+		return models.AdmissionRejected, []string{fmt.Sprintf("invalid config: %v", err)}, nil
+	}
+
+	if !BacktestSupported(config) {
+		return models.AdmissionPending, []string{fmt.Sprintf("unverified: entry signal %s can't be backtested", config.EntrySignalType)}, nil
+	}
+
+	to := time.Now().Unix()
+	from := to - int64(s.config.LookbackHours)*3600
+	backtest, err := s.backtester.Run(config, from, to)
+	if err != nil {
+		return "", nil, fmt.Errorf("error backtesting strategy: %v", err)
+	}
+	candidate.Backtest = backtest
+
+	if backtest.MarketTrades == 0 {
+		return models.AdmissionPending, []string{fmt.Sprintf("unverified: no stored trades in the last %d hours", s.config.LookbackHours)}, nil
+	}
+	if backtest.Degraded {
+		return models.AdmissionPending, []string{fmt.Sprintf("unverified: %ds of the backtest window fell inside feed gaps", backtest.GapSec)}, nil
+	}
+
+	var rejections []string
+	if backtest.TradeCount < s.config.MinTrades {
+		rejections = append(rejections, fmt.Sprintf("made %d backtest trades, at least %d required", backtest.TradeCount, s.config.MinTrades))
+	}
+	if backtest.MaxDrawdown > s.config.MaxDrawdownPct {
+		rejections = append(rejections, fmt.Sprintf("backtest max drawdown %.2f%% is above the %.2f%% limit", backtest.MaxDrawdown, s.config.MaxDrawdownPct))
+	}
+	if backtest.TradeCount > 0 && backtest.Expectancy <= s.config.MinExpectancy {
+		rejections = append(rejections, fmt.Sprintf("backtest expectancy %.4f SOL per trade is not above %.4f", backtest.Expectancy, s.config.MinExpectancy))
+	}
+	if len(rejections) > 0 {
+		return models.AdmissionRejected, rejections, nil
+	}
+
+	return models.AdmissionAdmitted, nil, nil
+}
+
+// saveCandidate records a candidate. Failures are logged rather than returned so they never
+// undo an admission.
+func (s *StrategyAdmissionService) saveCandidate(candidate *models.StrategyCandidate) {
+	if _, err := s.candidateRepo.Save(candidate); err != nil {
+		s.logger.Error("Error saving strategy candidate %s: %v", candidate.Name, err)
+	}
+}
+
+// GetCandidates returns the most recent candidates with the given status, newest first. An
+// empty status returns candidates of every status.
+func (s *StrategyAdmissionService) GetCandidates(status string, limit int) ([]*models.StrategyCandidate, error) {
+	return s.candidateRepo.GetRecent(status, limit)
+}
+
+// RecentRejections returns the most recently rejected candidates in the form strategy
+// prompts show them
+func (s *StrategyAdmissionService) RecentRejections(limit int) ([]StrategyPromptRejection, error) {
+	candidates, err := s.candidateRepo.GetRecent(models.AdmissionRejected, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rejections := make([]StrategyPromptRejection, 0, len(candidates))
+	for _, candidate := range candidates {
+		parameters, err := json.Marshal(candidate.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding candidate config: %v", err)
+		}
+		rejections = append(rejections, StrategyPromptRejection{
+			Name:       candidate.Name,
+			Parameters: string(parameters),
+			Reasons:    candidate.Reasons,
+		})
+	}
+
+	return rejections, nil
+}
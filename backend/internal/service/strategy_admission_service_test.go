@@ -0,0 +1,269 @@
+// internal/service/strategy_admission_service_test.go
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type admissionFixture struct {
+	service       *StrategyAdmissionService
+	strategyRepo  *memory.StrategyRepository
+	candidateRepo *memory.StrategyCandidateRepository
+	tokenRepo     *memory.TokenRepository
+	tradeRepo     *memory.TradeRepository
+	gapRepo       *memory.DataGapRepository
+}
+
+func newAdmissionFixture(config AdmissionConfig) *admissionFixture {
+	store := memory.NewStore()
+	f := &admissionFixture{
+		strategyRepo:  memory.NewStrategyRepository(store),
+		candidateRepo: memory.NewStrategyCandidateRepository(store),
+		tokenRepo:     memory.NewTokenRepository(store),
+		tradeRepo:     memory.NewTradeRepository(store),
+		gapRepo:       memory.NewDataGapRepository(store),
+	}
+	backtester := NewBacktester(f.tradeRepo, f.tokenRepo)
+	backtester.SetDataGapRepository(f.gapRepo)
+	f.service = NewStrategyAdmissionService(
+		backtester,
+		f.strategyRepo,
+		f.candidateRepo,
+		config,
+		logger.New("test"),
+	)
+	return f
+}
+
+// seedWinningToken stores a token that backtestConfig enters and exits at take profit
+func (f *admissionFixture) seedWinningToken(t *testing.T) {
+	now := time.Now().Unix()
+	tokenID, err := f.tokenRepo.Save(&models.Token{MintAddress: "mint", CreatedTimestamp: (now - 60) * 1000, UsdMarketCap: 16000})
+	require.NoError(t, err)
+	for i, price := range []float64{0.001, 0.001, 0.002} {
+		_, err := f.tradeRepo.Save(&models.Trade{
+			TokenID:     tokenID,
+			Signature:   string(rune('a' + i)),
+			SolAmount:   1,
+			TokenAmount: 1 / price,
+			IsBuy:       true,
+			UserAddress: string(rune('a' + i)),
+			Timestamp:   now - 50 + int64(i),
+		})
+		require.NoError(t, err)
+	}
+}
+
+func admissionStrategy(t *testing.T, config models.StrategyConfig) *models.Strategy {
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	var strategyConfig models.JSONB
+	require.NoError(t, json.Unmarshal(data, &strategyConfig))
+	return &models.Strategy{
+		Name:           "Candidate",
+		Config:         strategyConfig,
+		IsPublic:       true,
+		AIEnhanced:     true,
+		Tags:           []string{"ai-generated"},
+		PromptTemplate: "momentum",
+		PromptVersion:  2,
+	}
+}
+
+var defaultAdmissionConfig = AdmissionConfig{LookbackHours: 6, MinTrades: 1, MaxDrawdownPct: 30}
+
+func TestAdmitStrategy(t *testing.T) {
+	f := newAdmissionFixture(defaultAdmissionConfig)
+	f.seedWinningToken(t)
+
+	strategy := admissionStrategy(t, backtestConfig())
+	id, err := f.service.Admit(strategy, models.AIPurposeStrategyGeneration)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+	assert.Equal(t, id, strategy.ID)
+
+	saved, err := f.strategyRepo.GetByID(id)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.True(t, saved.IsPublic)
+
+	candidates, err := f.candidateRepo.GetRecent("", 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	candidate := candidates[0]
+	assert.Equal(t, models.AdmissionAdmitted, candidate.Status)
+	assert.Equal(t, models.AIPurposeStrategyGeneration, candidate.Purpose)
+	assert.Equal(t, "momentum", candidate.PromptTemplate)
+	require.NotNil(t, candidate.StrategyID)
+	assert.Equal(t, id, *candidate.StrategyID)
+	require.NotNil(t, candidate.Backtest)
+	assert.Equal(t, 1, candidate.Backtest.TradeCount)
+	assert.Empty(t, candidate.Reasons)
+}
+
+func TestAdmitStrategyRejects(t *testing.T) {
+	f := newAdmissionFixture(AdmissionConfig{LookbackHours: 6, MinTrades: 3, MaxDrawdownPct: 30, MinExpectancy: 5})
+	f.seedWinningToken(t)
+
+	_, err := f.service.Admit(admissionStrategy(t, backtestConfig()), models.AIPurposeManualGeneration)
+	require.Error(t, err)
+	rejected, ok := err.(*StrategyRejectedError)
+	require.True(t, ok)
+	assert.NotZero(t, rejected.CandidateID)
+	assert.Equal(t, []string{
+		"made 1 backtest trades, at least 3 required",
+		"backtest expectancy 1.0000 SOL per trade is not above 5.0000",
+	}, rejected.Reasons)
+
+	strategies, err := f.strategyRepo.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, strategies, "rejected strategies never become public")
+
+	candidates, err := f.candidateRepo.GetRecent(models.AdmissionRejected, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, rejected.Reasons, candidates[0].Reasons)
+	assert.Nil(t, candidates[0].StrategyID)
+	require.NotNil(t, candidates[0].Backtest, "rejected candidates keep their backtest")
+	assert.Equal(t, 1, candidates[0].Backtest.TradeCount)
+}
+
+func TestAdmitStrategyInvalidConfig(t *testing.T) {
+	f := newAdmissionFixture(defaultAdmissionConfig)
+	config := backtestConfig()
+	config.MaxHoldTimeSec = 0
+
+	_, err := f.service.Admit(admissionStrategy(t, config), models.AIPurposeStrategyGeneration)
+	rejected, ok := err.(*StrategyRejectedError)
+	require.True(t, ok)
+	assert.Equal(t, []string{"invalid config: max hold time must be positive"}, rejected.Reasons)
+
+	candidates, err := f.candidateRepo.GetRecent(models.AdmissionRejected, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Nil(t, candidates[0].Backtest)
+}
+
+func TestAdmitStrategyHoldsUnverified(t *testing.T) {
+	f := newAdmissionFixture(defaultAdmissionConfig)
+
+	_, err := f.service.Admit(admissionStrategy(t, backtestConfig()), models.AIPurposeStrategyGeneration)
+	rejected, ok := err.(*StrategyRejectedError)
+	require.True(t, ok, "nothing to backtest against")
+	assert.Equal(t, models.AdmissionPending, rejected.Status)
+	assert.Contains(t, err.Error(), "strategy held unverified")
+
+	f.seedWinningToken(t)
+	config := backtestConfig()
+	config.EntrySignalType = models.EntrySignalKingOfTheHill
+	_, err = f.service.Admit(admissionStrategy(t, config), models.AIPurposeStrategyGeneration)
+	rejected, ok = err.(*StrategyRejectedError)
+	require.True(t, ok, "the backtest can't replay the entry signal")
+	assert.Equal(t, models.AdmissionPending, rejected.Status)
+
+	strategies, err := f.strategyRepo.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, strategies, "unverified strategies never become public")
+
+	candidates, err := f.candidateRepo.GetRecent(models.AdmissionPending, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, []string{"unverified: entry signal king_of_the_hill can't be backtested"}, candidates[0].Reasons)
+	assert.Nil(t, candidates[0].Backtest)
+	assert.Nil(t, candidates[0].StrategyID)
+	assert.Equal(t, []string{"unverified: no stored trades in the last 6 hours"}, candidates[1].Reasons)
+	require.NotNil(t, candidates[1].Backtest)
+
+	rejections, err := f.service.RecentRejections(10)
+	require.NoError(t, err)
+	assert.Empty(t, rejections, "pending candidates are not shown as rejections")
+}
+
+func TestAdmitStrategyHoldsDegradedBacktests(t *testing.T) {
+	f := newAdmissionFixture(defaultAdmissionConfig)
+	f.seedWinningToken(t)
+	gapStart := time.Now().Add(-2 * time.Hour)
+	gapID, err := f.gapRepo.Save(&models.DataGap{Source: "pumpfun", StartedAt: gapStart, Reason: "collector_down"})
+	require.NoError(t, err)
+	require.NoError(t, f.gapRepo.Close(gapID, gapStart.Add(10*time.Minute)))
+
+	_, err = f.service.Admit(admissionStrategy(t, backtestConfig()), models.AIPurposeStrategyGeneration)
+	rejected, ok := err.(*StrategyRejectedError)
+	require.True(t, ok, "a backtest missing trades can't admit a strategy")
+	assert.Equal(t, models.AdmissionPending, rejected.Status)
+	assert.Equal(t, []string{"unverified: 600s of the backtest window fell inside feed gaps"}, rejected.Reasons)
+
+	candidates, err := f.candidateRepo.GetRecent(models.AdmissionPending, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	require.NotNil(t, candidates[0].Backtest)
+	assert.True(t, candidates[0].Backtest.Degraded)
+	assert.Equal(t, 1, candidates[0].Backtest.TradeCount)
+}
+
+func TestAdmitStrategyNearDuplicates(t *testing.T) {
+	f := newAdmissionFixture(defaultAdmissionConfig)
+	f.seedWinningToken(t)
+	f.service.SetDiversity(NewStrategyDiversityService(f.strategyRepo, defaultDiversityConfig, logger.New("test")))
+
+	id, err := f.service.Admit(admissionStrategy(t, backtestConfig()), models.AIPurposeStrategyGeneration)
+	require.NoError(t, err)
+
+	clone := backtestConfig()
+	clone.TakeProfitPct = 52
+	_, err = f.service.Admit(admissionStrategy(t, clone), models.AIPurposeStrategyGeneration)
+	rejected, ok := err.(*StrategyRejectedError)
+	require.True(t, ok)
+	require.Len(t, rejected.Reasons, 1)
+	assert.Contains(t, rejected.Reasons[0], "near-duplicate of strategy")
+
+	candidates, err := f.candidateRepo.GetRecent(models.AdmissionRejected, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Nil(t, candidates[0].Backtest, "near-duplicates are not backtested")
+
+	merging := defaultDiversityConfig
+	merging.DuplicateAction = DuplicateActionMerge
+	f.service.SetDiversity(NewStrategyDiversityService(f.strategyRepo, merging, logger.New("test")))
+	_, err = f.service.Admit(admissionStrategy(t, clone), models.AIPurposeStrategyGeneration)
+	require.Error(t, err)
+
+	candidates, err = f.candidateRepo.GetRecent(models.AdmissionMerged, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	require.NotNil(t, candidates[0].StrategyID)
+	assert.Equal(t, id, *candidates[0].StrategyID)
+
+	strategies, err := f.strategyRepo.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, strategies, 1)
+}
+
+func TestGenerateStrategyShowsRecentRejections(t *testing.T) {
+	f := newAIServiceFixture(validStrategyJSON)
+	admission := newAdmissionFixture(AdmissionConfig{LookbackHours: 6, MinTrades: 3, MaxDrawdownPct: 30})
+	admission.seedWinningToken(t)
+	f.service.SetAdmission(admission.service)
+
+	_, err := f.service.AdmitStrategy(admissionStrategy(t, backtestConfig()), models.AIPurposeStrategyGeneration)
+	require.Error(t, err)
+
+	strategy, err := f.service.GenerateStrategyFromTemplate(models.AIPurposeStrategyGeneration, "momentum", NewStrategyPromptInput("", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 2, strategy.PromptVersion)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 1)
+	user := requests[0].Messages[1].Content
+	assert.Contains(t, user, "These recently generated strategies were rejected after backtesting")
+	assert.Contains(t, user, "Strategy: Candidate\nParameters: {")
+	assert.Contains(t, user, "Rejected because:\n- made 1 backtest trades, at least 3 required\n")
+}
@@ -0,0 +1,338 @@
+// internal/service/strategy_diversity.go
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// diversityCompareStrategies is how many saved strategies new ones are compared against
+const diversityCompareStrategies = 1000
+
+// ruleSimilarityWeight is the share of the similarity given to rules when either strategy
+// has any
+const ruleSimilarityWeight = 0.25
+
+// What happens to a strategy that is a near-duplicate of a saved one
+const (
+	DuplicateActionReject = "reject" // The strategy is not saved
+	DuplicateActionMerge  = "merge"  // The saved strategy takes the new one's tags and stands in for it
+)
+
+// DiversityConfig sets when strategies count as clones
+type DiversityConfig struct {
+	DuplicateThreshold float64 // Similarity (0-1) at or above which a new strategy is a near-duplicate, disabled at 0
+	DuplicateAction    string  // One of the DuplicateAction* values
+	ClusterSimilarity  float64 // Similarity (0-1) to a cluster's first strategy needed to join the cluster
+	MaxPerCluster      int     // Strategies of one cluster that may be queued or simulating at once, unlimited at 0
+}
+
+// DuplicateStrategyError is returned when a strategy is not saved because it is a
+// near-duplicate of a saved one. Merged is set when its tags were merged into the saved
+// strategy instead of it being rejected.
+type DuplicateStrategyError struct {
+	ExistingID   int64
+	ExistingName string
+	Similarity   float64
+	Merged       bool
+}
+
+func (e *DuplicateStrategyError) Error() string {
+	if e.Merged {
+		return fmt.Sprintf("merged into near-duplicate strategy #%d (%s), %.0f%% similar", e.ExistingID, e.ExistingName, e.Similarity*100)
+	}
+	return fmt.Sprintf("near-duplicate of strategy #%d (%s), %.0f%% similar", e.ExistingID, e.ExistingName, e.Similarity*100)
+}
+
+// StrategyDiversityService keeps strategies from being clones of each other. Strategies are
+// compared by their normalized config vectors and rules. New strategies too similar to a
+// saved one are rejected or merged into it, and strategies are grouped into
+// configuration-space clusters so automation can cap how many of one cluster simulate at once.
+type StrategyDiversityService struct {
+	strategyRepo repository.StrategyRepositoryInterface
+	config       DiversityConfig
+	logger       *logger.Logger
+}
+
+// NewStrategyDiversityService creates a new strategy diversity service
+func NewStrategyDiversityService(
+	strategyRepo repository.StrategyRepositoryInterface,
+	config DiversityConfig,
+	logger *logger.Logger,
+) *StrategyDiversityService {
+	return &StrategyDiversityService{
+		strategyRepo: strategyRepo,
+		config:       config,
+		logger:       logger,
+	}
+}
+
+// FindNearDuplicate returns the saved public strategy most similar to config and the
+// similarity, or nil if none reaches the duplicate threshold
+func (s *StrategyDiversityService) FindNearDuplicate(config models.JSONB) (*models.Strategy, float64, error) {
+	if s.config.DuplicateThreshold <= 0 {
+		return nil, 0, nil
+	}
+
+	strategies, err := s.strategyRepo.ListPublic(diversityCompareStrategies, 0)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing strategies: %v", err)
+	}
+
+	var closest *models.Strategy
+	var closestSimilarity float64
+	for _, strategy := range strategies {
+		if similarity := StrategySimilarity(config, strategy.Config); similarity > closestSimilarity {
+			closest, closestSimilarity = strategy, similarity
+		}
+	}
+	if closest == nil || closestSimilarity < s.config.DuplicateThreshold {
+		return nil, 0, nil
+	}
+	return closest, closestSimilarity, nil
+}
+
+// Resolve checks a strategy about to be saved against the saved ones and returns the
+// near-duplicate it matches, or nil if it should be saved. With merging on, the strategy has
+// been merged into the near-duplicate, which stands in for it, and the duplicate's Merged is
+// set.
+func (s *StrategyDiversityService) Resolve(strategy *models.Strategy) (*DuplicateStrategyError, error) {
+	existing, similarity, err := s.FindNearDuplicate(strategy.Config)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	duplicate := &DuplicateStrategyError{ExistingID: existing.ID, ExistingName: existing.Name, Similarity: similarity}
+
+	if s.config.DuplicateAction != DuplicateActionMerge {
+		s.logger.Info("Rejected strategy %s as a near-duplicate of strategy %d (%.2f similar)", strategy.Name, existing.ID, similarity)
+		return duplicate, nil
+	}
+
+	if err := s.merge(existing, strategy); err != nil {
+		return nil, err
+	}
+	duplicate.Merged = true
+	s.logger.Info("Merged strategy %s into near-duplicate strategy %d (%.2f similar)", strategy.Name, existing.ID, similarity)
+	return duplicate, nil
+}
+
+// merge adds the tags of a near-duplicate to the saved strategy that stands in for it
+func (s *StrategyDiversityService) merge(existing, duplicate *models.Strategy) error {
+	tags := append([]string(nil), existing.Tags...)
+	for _, tag := range duplicate.Tags {
+		if !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == len(existing.Tags) {
+		return nil
+	}
+
+	existing.Tags = tags
+	if err := s.strategyRepo.Update(existing); err != nil {
+		return fmt.Errorf("error merging into strategy %d: %v", existing.ID, err)
+	}
+	return nil
+}
+
+// ClusterStrategies groups strategies into configuration-space clusters, in ID order. Each
+// strategy joins the first cluster whose first strategy it is at least ClusterSimilarity
+// similar to, or starts a new one.
+func (s *StrategyDiversityService) ClusterStrategies(strategies []*models.Strategy) [][]*models.Strategy {
+	ordered := append([]*models.Strategy(nil), strategies...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	var clusters [][]*models.Strategy
+	for _, strategy := range ordered {
+		joined := false
+		for i, cluster := range clusters {
+			if StrategySimilarity(cluster[0].Config, strategy.Config) >= s.config.ClusterSimilarity {
+				clusters[i] = append(cluster, strategy)
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			clusters = append(clusters, []*models.Strategy{strategy})
+		}
+	}
+	return clusters
+}
+
+// WithinQuota reports whether candidate may be simulated alongside the occupying strategies
+// without its cluster exceeding MaxPerCluster
+func (s *StrategyDiversityService) WithinQuota(candidate *models.Strategy, occupying []*models.Strategy) bool {
+	if s.config.MaxPerCluster <= 0 {
+		return true
+	}
+
+	for _, cluster := range s.ClusterStrategies(append([]*models.Strategy{candidate}, occupying...)) {
+		for _, member := range cluster {
+			if member == candidate {
+				return len(cluster)-1 < s.config.MaxPerCluster
+			}
+		}
+	}
+	return true
+}
+
+// StrategySimilarity returns how alike two strategy configs are, from 0 to 1. Parameters are
+// normalized to the strategy schema bounds, on a log scale for those spanning orders of
+// magnitude, and compared by mean absolute difference; a filter only one config sets counts
+// as fully different. Rules are compared by the overlap of their condition and action pairs.
+// Position sizing is ignored as it doesn't change which trades a strategy takes.
+func StrategySimilarity(a, b models.JSONB) float64 {
+	va, vb := strategyVector(a), strategyVector(b)
+
+	var distance float64
+	dimensions := 0
+	for key, x := range va {
+		dimensions++
+		if y, ok := vb[key]; ok {
+			distance += math.Abs(x - y)
+		} else {
+			distance++
+		}
+	}
+	for key := range vb {
+		if _, ok := va[key]; !ok {
+			dimensions++
+			distance++
+		}
+	}
+
+	similarity := 1.0
+	if dimensions > 0 {
+		similarity = 1 - distance/float64(dimensions)
+	}
+
+	ra, rb := strategyRuleSet(a), strategyRuleSet(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return similarity
+	}
+	shared := 0
+	for rule := range ra {
+		if rb[rule] {
+			shared++
+		}
+	}
+	ruleSimilarity := float64(shared) / float64(len(ra)+len(rb)-shared)
+	return similarity*(1-ruleSimilarityWeight) + ruleSimilarity*ruleSimilarityWeight
+}
+
+// strategyVector maps each set strategy parameter to a value between 0 and 1. Unset flags
+// count as false and an unset entry signal as buy_count.
+func strategyVector(config models.JSONB) map[string]float64 {
+	vector := make(map[string]float64, len(strategyFields))
+	for _, field := range strategyFields {
+		switch field.Key {
+		case "name", "description", "fixedPositionSizeSol", "initialBalance":
+			continue
+		}
+		raw, set := config[field.Key]
+
+		switch {
+		case field.Type == "boolean":
+			if value, _ := raw.(bool); value {
+				vector[field.Key] = 1
+			} else {
+				vector[field.Key] = 0
+			}
+
+		case field.Type == "string":
+			// Entry signals are categories: each gets its own dimension
+			signal, _ := raw.(string)
+			if signal == "" {
+				signal = models.EntrySignalBuyCount
+			}
+			vector[field.Key+"="+signal] = 1
+
+		case !set || raw == nil:
+			continue
+
+		case len(field.Enum) > 0:
+			value, ok := jsonbFloat(raw)
+			if !ok {
+				continue
+			}
+			for i, allowed := range field.Enum {
+				if value == float64(allowed.(int)) {
+					vector[field.Key] = float64(i) / float64(len(field.Enum)-1)
+				}
+			}
+
+		default:
+			value, ok := jsonbFloat(raw)
+			if !ok {
+				continue
+			}
+			value = math.Max(field.Min, math.Min(field.Max, value))
+			if field.Min > 0 && field.Max/field.Min >= 100 {
+				vector[field.Key] = math.Log(value/field.Min) / math.Log(field.Max/field.Min)
+			} else {
+				vector[field.Key] = (value - field.Min) / (field.Max - field.Min)
+			}
+		}
+	}
+	return vector
+}
+
+// strategyRuleSet returns a strategy's rules as normalized "condition -> action" strings
+func strategyRuleSet(config models.JSONB) map[string]bool {
+	var rules []interface{}
+	switch raw := config["rules"].(type) {
+	case []interface{}:
+		rules = raw
+	case []map[string]interface{}:
+		for _, rule := range raw {
+			rules = append(rules, rule)
+		}
+	}
+
+	set := make(map[string]bool, len(rules))
+	for _, raw := range rules {
+		rule, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition, _ := rule["condition"].(string)
+		action, _ := rule["action"].(string)
+		normalized := strings.Join(strings.Fields(strings.ToLower(condition)), " ") + " -> " +
+			strings.Join(strings.Fields(strings.ToLower(action)), " ")
+		set[normalized] = true
+	}
+	return set
+}
+
+// jsonbFloat returns a numeric config value as float64, however it was decoded
+func jsonbFloat(raw interface{}) (float64, bool) {
+	switch value := raw.(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
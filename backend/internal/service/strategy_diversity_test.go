@@ -0,0 +1,200 @@
+// internal/service/strategy_diversity_test.go
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var defaultDiversityConfig = DiversityConfig{
+	DuplicateThreshold: 0.95,
+	DuplicateAction:    DuplicateActionReject,
+	ClusterSimilarity:  0.85,
+	MaxPerCluster:      1,
+}
+
+// diversityConfig returns a strategy config as stored, with numbers decoded as float64
+func diversityConfig(t *testing.T, config models.StrategyConfig) models.JSONB {
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	var decoded models.JSONB
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	return decoded
+}
+
+func TestStrategySimilarity(t *testing.T) {
+	base := backtestConfig()
+	a := diversityConfig(t, base)
+
+	assert.InDelta(t, 1, StrategySimilarity(a, diversityConfig(t, base)), 1e-9, "identical configs")
+
+	resized := base
+	resized.FixedPositionSizeSol = 0.1
+	resized.InitialBalance = 100
+	assert.InDelta(t, 1, StrategySimilarity(a, diversityConfig(t, resized)), 1e-9, "position sizing is ignored")
+
+	tweaked := base
+	tweaked.TakeProfitPct = 55
+	tweaked.StopLossPct = 21
+	assert.GreaterOrEqual(t, StrategySimilarity(a, diversityConfig(t, tweaked)), 0.95, "small tweaks are near-duplicates")
+
+	filtered := base
+	filtered.MaxAnomalyScore = 30
+	assert.Less(t, StrategySimilarity(a, diversityConfig(t, filtered)), 0.95, "an extra filter is not a duplicate")
+
+	signal := base
+	signal.EntrySignalType = models.EntrySignalSmartMoney
+	assert.Less(t, StrategySimilarity(a, diversityConfig(t, signal)), 0.95, "a different entry signal is not a duplicate")
+
+	different := base
+	different.MarketCapThreshold = 500000
+	different.TakeProfitPct = 500
+	different.MaxHoldTimeSec = 40000
+	different.StopLossPct = 80
+	assert.Less(t, StrategySimilarity(a, diversityConfig(t, different)), 0.85)
+
+	// Comparison is symmetric and independent of how numbers were decoded
+	b := diversityConfig(t, tweaked)
+	b["minBuysForEntry"] = 2
+	assert.InDelta(t, StrategySimilarity(a, b), StrategySimilarity(b, a), 1e-9)
+}
+
+func TestStrategySimilarityRules(t *testing.T) {
+	rules := func(conditions ...string) models.JSONB {
+		var list []interface{}
+		for _, condition := range conditions {
+			list = append(list, map[string]interface{}{"condition": condition, "action": "buy"})
+		}
+		return models.JSONB{"rules": list, "takeProfitPct": 50.0}
+	}
+
+	same := StrategySimilarity(rules("price > 1"), rules("Price  >  1"))
+	assert.InDelta(t, 1, same, 1e-9, "rules are compared case and whitespace insensitively")
+
+	half := StrategySimilarity(rules("price > 1", "volume > 5"), rules("price > 1", "holders > 10"))
+	assert.InDelta(t, 1-ruleSimilarityWeight*2/3, half, 1e-9, "one of three distinct rules shared")
+}
+
+func TestDiversityResolve(t *testing.T) {
+	store := memory.NewStore()
+	strategyRepo := memory.NewStrategyRepository(store)
+	existing := &models.Strategy{Name: "Momentum", Config: diversityConfig(t, backtestConfig()), IsPublic: true, Tags: []string{"ai-generated"}}
+	existingID, err := strategyRepo.Save(existing)
+	require.NoError(t, err)
+
+	clone := backtestConfig()
+	clone.TakeProfitPct = 52
+	unique := backtestConfig()
+	unique.EntrySignalType = models.EntrySignalUniqueBuyers
+	unique.MinUniqueBuyers = 5
+	unique.MaxTop10ConcentrationPct = 30
+
+	t.Run("reject", func(t *testing.T) {
+		diversity := NewStrategyDiversityService(strategyRepo, defaultDiversityConfig, logger.New("test"))
+
+		duplicate, err := diversity.Resolve(&models.Strategy{Name: "Momentum (20260101-1200)", Config: diversityConfig(t, clone)})
+		require.NoError(t, err)
+		require.NotNil(t, duplicate)
+		assert.False(t, duplicate.Merged)
+		assert.Equal(t, existingID, duplicate.ExistingID)
+		assert.Contains(t, duplicate.Error(), "near-duplicate of strategy")
+
+		duplicate, err = diversity.Resolve(&models.Strategy{Name: "Unique", Config: diversityConfig(t, unique)})
+		require.NoError(t, err)
+		assert.Nil(t, duplicate)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		config := defaultDiversityConfig
+		config.DuplicateAction = DuplicateActionMerge
+		diversity := NewStrategyDiversityService(strategyRepo, config, logger.New("test"))
+
+		duplicate, err := diversity.Resolve(&models.Strategy{Name: "Clone", Config: diversityConfig(t, clone), Tags: []string{"ai-generated", "optimized"}})
+		require.NoError(t, err)
+		require.NotNil(t, duplicate)
+		assert.True(t, duplicate.Merged)
+		assert.Contains(t, duplicate.Error(), "merged into near-duplicate strategy")
+
+		saved, err := strategyRepo.GetByID(existingID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"ai-generated", "optimized"}, saved.Tags)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		diversity := NewStrategyDiversityService(strategyRepo, DiversityConfig{}, logger.New("test"))
+		duplicate, err := diversity.Resolve(&models.Strategy{Name: "Clone", Config: diversityConfig(t, clone)})
+		require.NoError(t, err)
+		assert.Nil(t, duplicate)
+	})
+}
+
+func TestCreateStrategyRejectsNearDuplicates(t *testing.T) {
+	store := memory.NewStore()
+	strategyRepo := memory.NewStrategyRepository(store)
+	strategyService := NewStrategyService(strategyRepo, memory.NewStrategyMetricRepository(store), logger.New("test")).(*StrategyService)
+	strategyService.SetDiversity(NewStrategyDiversityService(strategyRepo, defaultDiversityConfig, logger.New("test")))
+
+	newStrategy := func(name string) *models.Strategy {
+		config := diversityConfig(t, backtestConfig())
+		config["rules"] = []interface{}{map[string]interface{}{"condition": "buys >= 2", "action": "buy"}}
+		return &models.Strategy{Name: name, Config: config}
+	}
+
+	id, err := strategyService.CreateStrategy(newStrategy("Original"))
+	require.NoError(t, err)
+
+	_, err = strategyService.CreateStrategy(newStrategy("Original (Optimized v2)"))
+	duplicate, ok := err.(*DuplicateStrategyError)
+	require.True(t, ok, "expected a DuplicateStrategyError, got %v", err)
+	assert.Equal(t, id, duplicate.ExistingID)
+
+	strategies, err := strategyRepo.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, strategies, 1)
+
+	merging := defaultDiversityConfig
+	merging.DuplicateAction = DuplicateActionMerge
+	strategyService.SetDiversity(NewStrategyDiversityService(strategyRepo, merging, logger.New("test")))
+	mergedID, err := strategyService.CreateStrategy(newStrategy("Original (Optimized v3)"))
+	duplicate, ok = err.(*DuplicateStrategyError)
+	require.True(t, ok, "a merge is reported, not passed off as a create")
+	assert.True(t, duplicate.Merged)
+	assert.Equal(t, id, duplicate.ExistingID)
+	assert.Zero(t, mergedID)
+
+	strategies, err = strategyRepo.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, strategies, 1)
+}
+
+func TestDiversityQuota(t *testing.T) {
+	diversity := NewStrategyDiversityService(nil, defaultDiversityConfig, logger.New("test"))
+
+	momentum := &models.Strategy{ID: 1, Config: diversityConfig(t, backtestConfig())}
+	cloneConfig := backtestConfig()
+	cloneConfig.StopLossPct = 25
+	clone := &models.Strategy{ID: 2, Config: diversityConfig(t, cloneConfig)}
+	otherConfig := backtestConfig()
+	otherConfig.EntrySignalType = models.EntrySignalKingOfTheHill
+	otherConfig.ExitOnGraduation = true
+	otherConfig.MaxBundledSupplyPct = 20
+	other := &models.Strategy{ID: 3, Config: diversityConfig(t, otherConfig)}
+
+	clusters := diversity.ClusterStrategies([]*models.Strategy{other, clone, momentum})
+	require.Len(t, clusters, 2)
+	assert.Equal(t, []*models.Strategy{momentum, clone}, clusters[0])
+	assert.Equal(t, []*models.Strategy{other}, clusters[1])
+
+	assert.True(t, diversity.WithinQuota(clone, nil))
+	assert.True(t, diversity.WithinQuota(clone, []*models.Strategy{other}))
+	assert.False(t, diversity.WithinQuota(clone, []*models.Strategy{momentum, other}))
+
+	unlimited := NewStrategyDiversityService(nil, DiversityConfig{ClusterSimilarity: 0.85}, logger.New("test"))
+	assert.True(t, unlimited.WithinQuota(clone, []*models.Strategy{momentum}))
+}
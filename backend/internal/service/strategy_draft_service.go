@@ -0,0 +1,417 @@
+// internal/service/strategy_draft_service.go
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var (
+	// ErrDraftNotFound is returned for strategy drafts that don't exist
+	ErrDraftNotFound = errors.New("strategy draft not found")
+	// ErrDraftSaved is returned when revising or saving a draft that has already been saved
+	ErrDraftSaved = errors.New("strategy draft has already been saved")
+)
+
+// draftRulesKey is the explanation parameter that covers a draft's rules
+const draftRulesKey = "rules"
+
+// StrategyDraftService turns a user's description of a strategy into a validated draft,
+// revises it with their follow-up instructions and saves it as a strategy once they are
+// happy with it. Every draft explains which words of the description each parameter came
+// from and flags what the description left open.
+type StrategyDraftService struct {
+	aiService       *AIService
+	draftRepo       repository.StrategyDraftRepositoryInterface
+	strategyService StrategyServiceInterface
+	logger          *logger.Logger
+}
+
+// NewStrategyDraftService creates a new strategy draft service
+func NewStrategyDraftService(
+	aiService *AIService,
+	draftRepo repository.StrategyDraftRepositoryInterface,
+	strategyService StrategyServiceInterface,
+	logger *logger.Logger,
+) *StrategyDraftService {
+	return &StrategyDraftService{
+		aiService:       aiService,
+		draftRepo:       draftRepo,
+		strategyService: strategyService,
+		logger:          logger,
+	}
+}
+
+// CreateDraft drafts a strategy from a description and stores it as revision 1
+func (s *StrategyDraftService) CreateDraft(description string) (*models.StrategyDraft, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, fmt.Errorf("strategy description is required")
+	}
+
+	draft, err := s.aiService.GenerateDraft(DefaultDraftPrompt, DraftPromptInput{Description: description})
+	if err != nil {
+		return nil, err
+	}
+	draft.Instructions = []string{description}
+	draft.Revision = 1
+	draft.Status = models.DraftOpen
+
+	if _, err := s.draftRepo.Save(draft); err != nil {
+		return nil, fmt.Errorf("error saving strategy draft: %v", err)
+	}
+
+	s.logger.Info("Drafted strategy %s as draft %d", draft.Name, draft.ID)
+	return draft, nil
+}
+
+// GetDraft retrieves a strategy draft, returning ErrDraftNotFound if it doesn't exist
+func (s *StrategyDraftService) GetDraft(id int64) (*models.StrategyDraft, error) {
+	draft, err := s.draftRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy draft: %v", err)
+	}
+	if draft == nil {
+		return nil, ErrDraftNotFound
+	}
+	return draft, nil
+}
+
+// ReviseDraft applies an instruction such as "use a 20% stop loss instead" to an open
+// draft and stores the result as its next revision
+func (s *StrategyDraftService) ReviseDraft(id int64, instruction string) (*models.StrategyDraft, error) {
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return nil, fmt.Errorf("revision instruction is required")
+	}
+
+	draft, err := s.GetDraft(id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status == models.DraftSaved {
+		return nil, ErrDraftSaved
+	}
+
+	current, err := draftPayloadJSON(draft)
+	if err != nil {
+		return nil, err
+	}
+	input := DraftPromptInput{
+		CurrentDraft: current,
+		Instruction:  instruction,
+	}
+	if len(draft.Instructions) > 0 {
+		input.Description = draft.Instructions[0]
+		input.PreviousInstructions = draft.Instructions[1:]
+	}
+
+	revised, err := s.aiService.GenerateDraft(DefaultDraftPrompt, input)
+	if err != nil {
+		return nil, err
+	}
+	draft.Name = revised.Name
+	draft.Description = revised.Description
+	draft.Config = revised.Config
+	draft.Explanations = revised.Explanations
+	draft.Ambiguities = revised.Ambiguities
+	draft.PromptTemplate = revised.PromptTemplate
+	draft.PromptVersion = revised.PromptVersion
+	draft.Instructions = append(draft.Instructions, instruction)
+	draft.Revision++
+
+	if err := s.draftRepo.Update(draft); err != nil {
+		return nil, fmt.Errorf("error updating strategy draft: %v", err)
+	}
+
+	s.logger.Info("Revised strategy draft %d to revision %d", draft.ID, draft.Revision)
+	return draft, nil
+}
+
+// SaveDraft saves an open draft with CreateStrategy and returns the strategy ID. A
+// near-duplicate of a saved strategy yields the *DuplicateStrategyError of CreateStrategy
+// and leaves the draft open for revision.
+func (s *StrategyDraftService) SaveDraft(id int64) (int64, error) {
+	draft, err := s.GetDraft(id)
+	if err != nil {
+		return 0, err
+	}
+	if draft.Status == models.DraftSaved {
+		return 0, ErrDraftSaved
+	}
+
+	strategy := &models.Strategy{
+		Name:           draft.Name,
+		Description:    draft.Description,
+		Config:         storedStrategyConfig(draft.Config),
+		IsPublic:       true,
+		AIEnhanced:     true,
+		Source:         models.StrategySourceAI,
+		Tags:           []string{"ai-drafted"},
+		PromptTemplate: draft.PromptTemplate,
+		PromptVersion:  draft.PromptVersion,
+	}
+	strategyID, err := s.strategyService.CreateStrategy(strategy)
+	if err != nil {
+		return 0, err
+	}
+
+	draft.Status = models.DraftSaved
+	draft.StrategyID = &strategyID
+	if err := s.draftRepo.Update(draft); err != nil {
+		return 0, fmt.Errorf("error updating strategy draft: %v", err)
+	}
+
+	s.logger.Info("Saved strategy draft %d as strategy %d", draft.ID, strategyID)
+	return strategyID, nil
+}
+
+// draftPayload is the JSON object draft templates ask the model for
+type draftPayload struct {
+	Strategy     map[string]interface{}    `json:"strategy"`
+	Rules        interface{}               `json:"rules"`
+	Explanations []models.DraftExplanation `json:"explanations"`
+	Ambiguities  []models.DraftAmbiguity   `json:"ambiguities"`
+}
+
+// draftPayloadJSON renders a stored draft the way the model returned it, so a revision
+// prompt can show the current draft
+func draftPayloadJSON(draft *models.StrategyDraft) (string, error) {
+	payload := draftPayload{
+		Strategy:     map[string]interface{}{"name": draft.Name, "description": draft.Description},
+		Rules:        draft.Config[draftRulesKey],
+		Explanations: draft.Explanations,
+		Ambiguities:  draft.Ambiguities,
+	}
+	for key, value := range draft.Config {
+		if key != draftRulesKey {
+			payload.Strategy[key] = value
+		}
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding strategy draft: %v", err)
+	}
+	return string(data), nil
+}
+
+// DraftJSONSchema returns the JSON Schema of the draft payload the AI must return: the
+// strategy, its rules, an explanation of each parameter and the ambiguities found
+func DraftJSONSchema() map[string]interface{} {
+	text := map[string]interface{}{"type": "string"}
+	object := func(required []string, keys ...string) map[string]interface{} {
+		properties := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			properties[key] = text
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"strategy": StrategyJSONSchema(),
+			"rules": map[string]interface{}{
+				"type":     "array",
+				"minItems": 1,
+				"items":    object([]string{"condition", "action"}, "condition", "action"),
+			},
+			"explanations": map[string]interface{}{
+				"type":  "array",
+				"items": object([]string{"parameter", "explanation"}, "parameter", "source", "explanation"),
+			},
+			"ambiguities": map[string]interface{}{
+				"type":  "array",
+				"items": object([]string{"issue"}, "parameter", "issue", "assumption"),
+			},
+		},
+		"required":             []string{"strategy", "rules", "explanations", "ambiguities"},
+		"additionalProperties": false,
+	}
+}
+
+// validateDraftPayload checks a decoded draft payload: the strategy must pass
+// validateStrategyPayload, there must be at least one rule, and every parameter set and
+// the rules must be explained. It returns the draft, with rules in its config and numbers
+// stored as float64 like saved strategy configs, or every problem found.
+func validateDraftPayload(payload map[string]interface{}) (*models.StrategyDraft, []string) {
+	var problems []string
+
+	var unknown []string
+	for key := range payload {
+		switch key {
+		case "strategy", "rules", "explanations", "ambiguities":
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		problems = append(problems, fmt.Sprintf("%s: is not a supported field", key))
+	}
+
+	values := map[string]interface{}{}
+	if strategy, ok := payload["strategy"].(map[string]interface{}); ok {
+		var strategyProblems []string
+		values, strategyProblems = validateStrategyPayload(strategy)
+		for _, problem := range strategyProblems {
+			problems = append(problems, "strategy."+problem)
+		}
+	} else {
+		problems = append(problems, "strategy: must be an object")
+	}
+
+	rules, ruleProblems := validateDraftRules(payload["rules"])
+	problems = append(problems, ruleProblems...)
+
+	explanations, explanationProblems := validateDraftExplanations(payload["explanations"], values)
+	problems = append(problems, explanationProblems...)
+
+	ambiguities, ambiguityProblems := validateDraftAmbiguities(payload["ambiguities"])
+	problems = append(problems, ambiguityProblems...)
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	draft := &models.StrategyDraft{
+		Name:         values["name"].(string),
+		Description:  values["description"].(string),
+		Explanations: explanations,
+		Ambiguities:  ambiguities,
+	}
+	delete(values, "name")
+	delete(values, "description")
+	values[draftRulesKey] = rules
+	draft.Config = storedStrategyConfig(values)
+	return draft, nil
+}
+
+// DraftValidationError lists every way an AI response failed the draft schema
+type DraftValidationError struct {
+	Problems []string
+}
+
+func (e *DraftValidationError) Error() string {
+	return "invalid strategy draft: " + strings.Join(e.Problems, "; ")
+}
+
+// validateDraftRules checks that a draft has at least one rule and that each has a
+// condition and an action
+func validateDraftRules(raw interface{}) ([]interface{}, []string) {
+	objects, problems := payloadObjects("rules", raw)
+	if len(objects) == 0 && len(problems) == 0 {
+		return nil, []string{"rules: must contain at least one rule"}
+	}
+
+	var rules []interface{}
+	for i, object := range objects {
+		condition, action := payloadString(object, "condition"), payloadString(object, "action")
+		if condition == "" {
+			problems = append(problems, fmt.Sprintf("rules[%d].condition: is required", i))
+		}
+		if action == "" {
+			problems = append(problems, fmt.Sprintf("rules[%d].action: is required", i))
+		}
+		rules = append(rules, map[string]interface{}{"condition": condition, "action": action})
+	}
+	return rules, problems
+}
+
+// validateDraftExplanations checks that each explanation names a strategy parameter or the
+// rules, and that every parameter in values and the rules are explained
+func validateDraftExplanations(raw interface{}, values map[string]interface{}) ([]models.DraftExplanation, []string) {
+	objects, problems := payloadObjects("explanations", raw)
+
+	known := map[string]bool{draftRulesKey: true}
+	for _, field := range strategyFields {
+		known[field.Key] = true
+	}
+
+	explained := make(map[string]bool, len(objects))
+	explanations := make([]models.DraftExplanation, 0, len(objects))
+	for i, object := range objects {
+		explanation := models.DraftExplanation{
+			Parameter:   payloadString(object, "parameter"),
+			Source:      payloadString(object, "source"),
+			Explanation: payloadString(object, "explanation"),
+		}
+		if !known[explanation.Parameter] {
+			problems = append(problems, fmt.Sprintf("explanations[%d].parameter: %q is not a strategy parameter", i, explanation.Parameter))
+			continue
+		}
+		if explanation.Explanation == "" {
+			problems = append(problems, fmt.Sprintf("explanations[%d].explanation: is required", i))
+			continue
+		}
+		explained[explanation.Parameter] = true
+		explanations = append(explanations, explanation)
+	}
+
+	var missing []string
+	for key := range values {
+		if key != "name" && key != "description" && !explained[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	if !explained[draftRulesKey] {
+		missing = append(missing, draftRulesKey)
+	}
+	for _, key := range missing {
+		problems = append(problems, fmt.Sprintf("explanations: %s is not explained", key))
+	}
+
+	return explanations, problems
+}
+
+// validateDraftAmbiguities checks that each ambiguity describes its issue; a missing list
+// means the description was unambiguous
+func validateDraftAmbiguities(raw interface{}) ([]models.DraftAmbiguity, []string) {
+	ambiguities := []models.DraftAmbiguity{}
+	if raw == nil {
+		return ambiguities, nil
+	}
+
+	objects, problems := payloadObjects("ambiguities", raw)
+	for i, object := range objects {
+		ambiguity := models.DraftAmbiguity{
+			Parameter:  payloadString(object, "parameter"),
+			Issue:      payloadString(object, "issue"),
+			Assumption: payloadString(object, "assumption"),
+		}
+		if ambiguity.Issue == "" {
+			problems = append(problems, fmt.Sprintf("ambiguities[%d].issue: is required", i))
+			continue
+		}
+		ambiguities = append(ambiguities, ambiguity)
+	}
+	return ambiguities, problems
+}
+
+// storedStrategyConfig copies a config the way a JSONB column stores it, so integers
+// become float64 like in configs read back from the database
+func storedStrategyConfig(config map[string]interface{}) models.JSONB {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return models.JSONB(config)
+	}
+	var stored models.JSONB
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return models.JSONB(config)
+	}
+	return stored
+}
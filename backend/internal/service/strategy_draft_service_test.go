@@ -0,0 +1,211 @@
+// internal/service/strategy_draft_service_test.go
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validDraftJSON is the draft of "buy tokens with 5+ unique buyers in 30s under $15k, exit
+// at 2x or 90s"
+const validDraftJSON = `{
+	"strategy": {
+		"name": "Unique Buyer Flip",
+		"description": "Buys broad early demand and exits at 2x or after 90 seconds",
+		"marketCapThreshold": 5000,
+		"minBuysForEntry": 5,
+		"entryTimeWindowSec": 30,
+		"takeProfitPct": 100,
+		"stopLossPct": 25,
+		"maxHoldTimeSec": 90,
+		"fixedPositionSizeSol": 0.5,
+		"initialBalance": 10,
+		"entrySignalType": "unique_buyers",
+		"minUniqueBuyers": 5
+	},
+	"rules": [
+		{"condition": "5 or more unique buyers within 30 seconds", "action": "buy"},
+		{"condition": "price doubles or 90 seconds pass", "action": "sell"}
+	],
+	"explanations": [
+		{"parameter": "marketCapThreshold", "source": "", "explanation": "Default minimum market cap"},
+		{"parameter": "minBuysForEntry", "source": "5+", "explanation": "Matches the unique buyer count"},
+		{"parameter": "entryTimeWindowSec", "source": "in 30s", "explanation": "Counting window"},
+		{"parameter": "takeProfitPct", "source": "exit at 2x", "explanation": "2x is a 100% gain"},
+		{"parameter": "stopLossPct", "source": "", "explanation": "Default stop loss"},
+		{"parameter": "maxHoldTimeSec", "source": "or 90s", "explanation": "Time exit"},
+		{"parameter": "fixedPositionSizeSol", "source": "", "explanation": "Default size"},
+		{"parameter": "initialBalance", "source": "", "explanation": "Default balance"},
+		{"parameter": "entrySignalType", "source": "unique buyers", "explanation": "Counts distinct organic wallets"},
+		{"parameter": "minUniqueBuyers", "source": "5+ unique buyers", "explanation": "Five distinct wallets"},
+		{"parameter": "rules", "source": "", "explanation": "Restates the entry and exits"}
+	],
+	"ambiguities": [
+		{"parameter": "marketCapThreshold", "issue": "under $15k asks for a maximum market cap, which no parameter supports", "assumption": "kept the default minimum of $5k"},
+		{"parameter": "stopLossPct", "issue": "no stop loss was given", "assumption": "25%"}
+	]
+}`
+
+type draftFixture struct {
+	service      *StrategyDraftService
+	provider     *MockLLMProvider
+	strategyRepo *memory.StrategyRepository
+	failureRepo  *memory.AIGenerationFailureRepository
+}
+
+func newDraftFixture(responses ...string) *draftFixture {
+	store := memory.NewStore()
+	f := &draftFixture{
+		provider:     NewMockLLMProvider(responses...),
+		strategyRepo: memory.NewStrategyRepository(store),
+		failureRepo:  memory.NewAIGenerationFailureRepository(store),
+	}
+	aiService := NewAIService(f.provider, f.strategyRepo, logger.New("test"))
+	aiService.SetFailureRepository(f.failureRepo)
+	strategyService := NewStrategyService(f.strategyRepo, memory.NewStrategyMetricRepository(store), logger.New("test")).(*StrategyService)
+	strategyService.SetDiversity(NewStrategyDiversityService(f.strategyRepo, defaultDiversityConfig, logger.New("test")))
+	f.service = NewStrategyDraftService(aiService, memory.NewStrategyDraftRepository(store), strategyService, logger.New("test"))
+	return f
+}
+
+func TestCreateDraft(t *testing.T) {
+	f := newDraftFixture(validDraftJSON)
+
+	draft, err := f.service.CreateDraft("buy tokens with 5+ unique buyers in 30s under $15k, exit at 2x or 90s")
+	require.NoError(t, err)
+	assert.NotZero(t, draft.ID)
+	assert.Equal(t, 1, draft.Revision)
+	assert.Equal(t, models.DraftOpen, draft.Status)
+	assert.Equal(t, "Unique Buyer Flip", draft.Name)
+	assert.Equal(t, DefaultDraftPrompt, draft.PromptTemplate)
+	assert.Equal(t, []string{"buy tokens with 5+ unique buyers in 30s under $15k, exit at 2x or 90s"}, draft.Instructions)
+
+	assert.Equal(t, models.EntrySignalUniqueBuyers, draft.Config["entrySignalType"])
+	assert.Equal(t, 5.0, draft.Config["minUniqueBuyers"], "numbers are stored as float64 like saved configs")
+	assert.Equal(t, 90.0, draft.Config["maxHoldTimeSec"])
+	assert.NotContains(t, draft.Config, "name")
+	assert.Len(t, draft.Config["rules"], 2)
+	assert.Len(t, draft.Explanations, 11)
+	require.Len(t, draft.Ambiguities, 2)
+	assert.Contains(t, draft.Ambiguities[0].Issue, "maximum market cap")
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, models.AIPurposeStrategyDrafting, requests[0].Purpose)
+	require.NotNil(t, requests[0].ResponseSchema)
+	assert.Equal(t, "strategy_draft", requests[0].ResponseSchema.Name)
+	assert.Contains(t, requests[0].Messages[1].Content, `"buy tokens with 5+ unique buyers in 30s under $15k, exit at 2x or 90s"`)
+	assert.Contains(t, requests[0].Messages[1].Content, "- minUniqueBuyers (integer, 1-100):")
+
+	stored, err := f.service.GetDraft(draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, draft.Config, stored.Config)
+
+	_, err = f.service.GetDraft(draft.ID + 1)
+	assert.ErrorIs(t, err, ErrDraftNotFound)
+}
+
+func TestCreateDraftRepairsUnexplainedParameters(t *testing.T) {
+	unexplained := `{
+		"strategy": {"name": "Quick Flip", "description": "Flips", "marketCapThreshold": 5000, "minBuysForEntry": 3,
+			"entryTimeWindowSec": 60, "takeProfitPct": 50, "stopLossPct": 20, "maxHoldTimeSec": 300,
+			"fixedPositionSizeSol": 0.5, "initialBalance": 10},
+		"rules": [],
+		"explanations": [{"parameter": "maxMarketCap", "explanation": "Under $15k"}]
+	}`
+	f := newDraftFixture(unexplained, validDraftJSON)
+
+	draft, err := f.service.CreateDraft("flip tokens under $15k")
+	require.NoError(t, err)
+	assert.Equal(t, "Unique Buyer Flip", draft.Name)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 2)
+	repair := requests[1].Messages[3].Content
+	assert.Contains(t, repair, "rules: must contain at least one rule")
+	assert.Contains(t, repair, `explanations[0].parameter: "maxMarketCap" is not a strategy parameter`)
+	assert.Contains(t, repair, "explanations: takeProfitPct is not explained")
+	assert.Contains(t, repair, "explanations: rules is not explained")
+	assert.NotContains(t, repair, "ambiguities", "a missing ambiguity list means none were found")
+}
+
+func TestCreateDraftRejectsAfterRepairAttempts(t *testing.T) {
+	f := newDraftFixture("not json", `{"strategy": {}}`, `{"rules": []}`)
+
+	_, err := f.service.CreateDraft("buy everything")
+	var validationErr *DraftValidationError
+	require.True(t, errors.As(err, &validationErr), "expected a DraftValidationError, got %v", err)
+	assert.Contains(t, validationErr.Problems, "strategy: must be an object")
+
+	failures, err := f.failureRepo.GetRecent(10)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, models.AIPurposeStrategyDrafting, failures[0].Purpose)
+}
+
+func TestReviseAndSaveDraft(t *testing.T) {
+	f := newDraftFixture(validDraftJSON)
+
+	draft, err := f.service.CreateDraft("buy tokens with 5+ unique buyers in 30s under $15k, exit at 2x or 90s")
+	require.NoError(t, err)
+
+	// The mock provider's generated draft stands in for the revision
+	revised, err := f.service.ReviseDraft(draft.ID, "use a tighter 10% stop loss")
+	require.NoError(t, err)
+	assert.Equal(t, draft.ID, revised.ID)
+	assert.Equal(t, 2, revised.Revision)
+	assert.Equal(t, []string{draft.Instructions[0], "use a tighter 10% stop loss"}, revised.Instructions)
+	assert.Len(t, revised.Config["rules"], 2)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 2)
+	prompt := requests[1].Messages[1].Content
+	assert.Contains(t, prompt, `"use a tighter 10% stop loss"`)
+	assert.Contains(t, prompt, `"name": "Unique Buyer Flip"`, "the current draft is shown")
+	assert.Contains(t, prompt, "5 or more unique buyers within 30 seconds")
+
+	strategyID, err := f.service.SaveDraft(draft.ID)
+	require.NoError(t, err)
+	strategy, err := f.strategyRepo.GetByID(strategyID)
+	require.NoError(t, err)
+	require.NotNil(t, strategy)
+	assert.Equal(t, revised.Name, strategy.Name)
+	assert.Equal(t, revised.Config["takeProfitPct"], strategy.Config["takeProfitPct"])
+	assert.Contains(t, strategy.Tags, "ai-drafted")
+
+	saved, err := f.service.GetDraft(draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DraftSaved, saved.Status)
+	require.NotNil(t, saved.StrategyID)
+	assert.Equal(t, strategyID, *saved.StrategyID)
+
+	_, err = f.service.ReviseDraft(draft.ID, "exit at 3x")
+	assert.ErrorIs(t, err, ErrDraftSaved)
+	_, err = f.service.SaveDraft(draft.ID)
+	assert.ErrorIs(t, err, ErrDraftSaved)
+}
+
+func TestSaveDraftRejectsNearDuplicates(t *testing.T) {
+	f := newDraftFixture(validDraftJSON, validDraftJSON)
+
+	first, err := f.service.CreateDraft("buy tokens with 5+ unique buyers in 30s, exit at 2x or 90s")
+	require.NoError(t, err)
+	_, err = f.service.SaveDraft(first.ID)
+	require.NoError(t, err)
+
+	second, err := f.service.CreateDraft("buy tokens with 5+ unique buyers in 30s, exit at 2x or 90s")
+	require.NoError(t, err)
+	_, err = f.service.SaveDraft(second.ID)
+	_, ok := err.(*DuplicateStrategyError)
+	require.True(t, ok, "expected a DuplicateStrategyError, got %v", err)
+
+	draft, err := f.service.GetDraft(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DraftOpen, draft.Status, "a rejected draft can still be revised")
+}
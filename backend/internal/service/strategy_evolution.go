@@ -0,0 +1,503 @@
+// internal/service/strategy_evolution.go
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// evolutionSeedStrategies is how many saved strategies are considered as the first generation
+const evolutionSeedStrategies = 1000
+
+// EvolutionConfig tunes the genetic algorithm
+type EvolutionConfig struct {
+	PopulationSize int     // Configs in each generation
+	Generations    int     // Generations evolved per run
+	TournamentSize int     // Configs competing in each parent selection
+	CrossoverRate  float64 // Chance a child mixes two parents rather than copying one
+	MutationRate   float64 // Chance each parameter of a child is mutated
+	MutationScale  float64 // Standard deviation of a mutation, as a fraction of the parameter's value
+	Elites         int     // Best configs carried unchanged into the next generation
+	Offspring      int     // Best new configs saved as strategies per run
+	MinTrades      int     // Backtest trades a config must make to be saved
+	LookbackHours  int     // Hours of stored trades the backtests replay
+	Seed           int64   // Random seed; 0 seeds each run from the clock
+}
+
+// DefaultEvolutionConfig returns the genetic algorithm settings used unless configured
+func DefaultEvolutionConfig() EvolutionConfig {
+	return EvolutionConfig{
+		PopulationSize: 20,
+		Generations:    15,
+		TournamentSize: 3,
+		CrossoverRate:  0.8,
+		MutationRate:   0.3,
+		MutationScale:  0.2,
+		Elites:         2,
+		Offspring:      2,
+		MinTrades:      3,
+		LookbackHours:  24,
+	}
+}
+
+// evolutionGene is a numeric StrategyConfig parameter the genetic algorithm evolves. Its
+// bounds and whether it is an integer come from the strategyFields entry of the same key.
+type evolutionGene struct {
+	key string
+	get func(config *models.StrategyConfig) float64
+	set func(config *models.StrategyConfig, value float64)
+}
+
+// evolutionGenes are the parameters the backtest can judge. Filters backed by live data,
+// the entry signal type and position sizing are inherited unchanged.
+var evolutionGenes = []evolutionGene{
+	{
+		key: "marketCapThreshold",
+		get: func(c *models.StrategyConfig) float64 { return c.MarketCapThreshold },
+		set: func(c *models.StrategyConfig, v float64) { c.MarketCapThreshold = v },
+	},
+	{
+		key: "minBuysForEntry",
+		get: func(c *models.StrategyConfig) float64 { return float64(c.MinBuysForEntry) },
+		set: func(c *models.StrategyConfig, v float64) { c.MinBuysForEntry = int(v) },
+	},
+	{
+		key: "entryTimeWindowSec",
+		get: func(c *models.StrategyConfig) float64 { return float64(c.EntryTimeWindowSec) },
+		set: func(c *models.StrategyConfig, v float64) { c.EntryTimeWindowSec = int(v) },
+	},
+	{
+		key: "takeProfitPct",
+		get: func(c *models.StrategyConfig) float64 { return c.TakeProfitPct },
+		set: func(c *models.StrategyConfig, v float64) { c.TakeProfitPct = v },
+	},
+	{
+		key: "stopLossPct",
+		get: func(c *models.StrategyConfig) float64 { return c.StopLossPct },
+		set: func(c *models.StrategyConfig, v float64) { c.StopLossPct = v },
+	},
+	{
+		key: "maxHoldTimeSec",
+		get: func(c *models.StrategyConfig) float64 { return float64(c.MaxHoldTimeSec) },
+		set: func(c *models.StrategyConfig, v float64) { c.MaxHoldTimeSec = int(v) },
+	},
+	{
+		key: "minUniqueBuyers",
+		get: func(c *models.StrategyConfig) float64 { return float64(c.MinUniqueBuyers) },
+		set: func(c *models.StrategyConfig, v float64) { c.MinUniqueBuyers = int(v) },
+	},
+	{
+		key: "minOrganicBuyVolumeSol",
+		get: func(c *models.StrategyConfig) float64 { return c.MinOrganicBuyVolumeSol },
+		set: func(c *models.StrategyConfig, v float64) { c.MinOrganicBuyVolumeSol = v },
+	},
+}
+
+// evolutionIndividual is a config in the population and how its backtest scored
+type evolutionIndividual struct {
+	config    models.StrategyConfig
+	base      models.JSONB // Saved config of the seed the individual was bred from first
+	parentIDs []int64      // Saved strategies the config descends from, ascending
+	seed      bool         // A saved strategy's config, unchanged
+	backtest  *models.BacktestResult
+	eligible  bool    // Made at least MinTrades backtest trades
+	fitness   float64 // Backtest ROI less half its max drawdown
+}
+
+// fitter reports whether a ranks above b. Configs that trade enough rank above those that
+// don't, then the higher fitness wins.
+func (a *evolutionIndividual) fitter(b *evolutionIndividual) bool {
+	if a.eligible != b.eligible {
+		return a.eligible
+	}
+	return a.fitness > b.fitness
+}
+
+// EvolutionResult summarizes one run of the genetic algorithm
+type EvolutionResult struct {
+	Seed        int64              `json:"seed"`
+	Generations int                `json:"generations"`
+	Seeds       int                `json:"seeds"`        // Saved strategies the first generation came from
+	SeedFitness float64            `json:"seed_fitness"` // Fitness of the best saved strategy
+	BestFitness float64            `json:"best_fitness"` // Fitness of the best config found
+	Strategies  []*models.Strategy `json:"strategies"`   // Strategies saved from the final generation
+	Rejected    int                `json:"rejected"`     // Offspring that didn't pass admission
+}
+
+// EvolutionEngine evolves strategy configs with a genetic algorithm instead of an LLM. The
+// first generation is made of the saved strategies whose backtests score best. Each
+// generation keeps its elites and fills the rest with children of tournament-selected
+// parents, made by uniform crossover and Gaussian mutation within the bounds the strategy
+// schema validates. Fitness comes from backtests over recent stored trades. The same seed
+// and trades always evolve the same strategies. Offspring go through the same admission as
+// AI-generated strategies before they are saved.
+type EvolutionEngine struct {
+	backtester   *Backtester
+	strategyRepo repository.StrategyRepositoryInterface
+	lineage      LineageRecorder
+	admission    *StrategyAdmissionService
+	diversity    *StrategyDiversityService
+	config       EvolutionConfig
+	logger       *logger.Logger
+}
+
+// NewEvolutionEngine creates a new evolution engine
+func NewEvolutionEngine(
+	backtester *Backtester,
+	strategyRepo repository.StrategyRepositoryInterface,
+	lineage LineageRecorder,
+	config EvolutionConfig,
+	logger *logger.Logger,
+) *EvolutionEngine {
+	return &EvolutionEngine{
+		backtester:   backtester,
+		strategyRepo: strategyRepo,
+		lineage:      lineage,
+		config:       config,
+		logger:       logger,
+	}
+}
+
+// SetAdmission sets the admission pipeline offspring must pass before they are saved
+func (e *EvolutionEngine) SetAdmission(admission *StrategyAdmissionService) {
+	e.admission = admission
+}
+
+// SetDiversity sets what evolved configs are checked against when no admission is set;
+// near-duplicates of saved strategies are not saved. Admission does its own check.
+func (e *EvolutionEngine) SetDiversity(diversity *StrategyDiversityService) {
+	e.diversity = diversity
+}
+
+// Run evolves strategies with the configured seed, or one from the clock if it is 0
+func (e *EvolutionEngine) Run() (*EvolutionResult, error) {
+	seed := e.config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return e.RunWithSeed(seed)
+}
+
+// RunWithSeed evolves the saved strategies and saves the best new configs that beat all of
+// them and pass admission, recording the strategies each descends from as its parents
+func (e *EvolutionEngine) RunWithSeed(seed int64) (*EvolutionResult, error) {
+	to := time.Now().Unix()
+	from := to - int64(e.config.LookbackHours)*3600
+	market, err := e.backtester.Load(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error loading backtest market: %v", err)
+	}
+	if len(market.Trades) == 0 {
+		return nil, fmt.Errorf("no stored trades in the last %d hours", e.config.LookbackHours)
+	}
+
+	seeds, err := e.seedPopulation(market)
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) < 2 {
+		return nil, fmt.Errorf("need at least 2 backtestable strategies to evolve, found %d", len(seeds))
+	}
+
+	e.logger.Info("Evolving %d strategies for %d generations with seed %d", len(seeds), e.config.Generations, seed)
+	population := evolvePopulation(e.config, seeds, market.Replay, rand.New(rand.NewSource(seed)))
+
+	result := &EvolutionResult{
+		Seed:        seed,
+		Generations: e.config.Generations,
+		Seeds:       len(seeds),
+		SeedFitness: seeds[0].fitness,
+		BestFitness: population[0].fitness,
+	}
+
+	for i, individual := range selectOffspring(population, seeds[0], e.config.Offspring) {
+		if e.admission == nil && e.diversity != nil {
+			existing, similarity, err := e.diversity.FindNearDuplicate(evolvedStrategyConfig(individual))
+			if err != nil {
+				return result, err
+			}
+			if existing != nil {
+				e.logger.Info("Skipping evolved config %.2f similar to strategy %d", similarity, existing.ID)
+				continue
+			}
+		}
+		strategy, err := e.saveOffspring(individual, seed, i+1)
+		if rejected, ok := err.(*StrategyRejectedError); ok {
+			e.logger.Info("Evolved strategy %s was not admitted: %v", strategy.Name, rejected)
+			result.Rejected++
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Strategies = append(result.Strategies, strategy)
+	}
+
+	e.logger.Info("Evolution with seed %d saved %d strategies (%d not admitted), best fitness %.2f against %.2f for the best saved strategy",
+		seed, len(result.Strategies), result.Rejected, result.BestFitness, result.SeedFitness)
+	return result, nil
+}
+
+// seedPopulation backtests the saved strategies the backtest supports and returns the best
+// PopulationSize of them, fittest first
+func (e *EvolutionEngine) seedPopulation(market *BacktestMarket) ([]*evolutionIndividual, error) {
+	strategies, err := e.strategyRepo.ListPublic(evolutionSeedStrategies, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error listing strategies: %v", err)
+	}
+
+	var seeds []*evolutionIndividual
+	for _, strategy := range strategies {
+		config, err := strategyConfigFromJSONB(strategy.Config)
+		if err != nil || validateStrategyConfig(&config) != nil || !BacktestSupported(config) {
+			continue
+		}
+		individual := &evolutionIndividual{config: config, base: strategy.Config, parentIDs: []int64{strategy.ID}, seed: true}
+		evaluateIndividual(individual, e.config, market.Replay)
+		seeds = append(seeds, individual)
+	}
+
+	sortPopulation(seeds)
+	if len(seeds) > e.config.PopulationSize {
+		seeds = seeds[:e.config.PopulationSize]
+	}
+	return seeds, nil
+}
+
+// saveOffspring saves an evolved config as a public strategy if it passes admission, and
+// records its lineage. A *StrategyRejectedError is returned with the unsaved strategy when
+// it doesn't.
+func (e *EvolutionEngine) saveOffspring(individual *evolutionIndividual, seed int64, rank int) (*models.Strategy, error) {
+	backtest := individual.backtest
+	strategy := &models.Strategy{
+		Name: fmt.Sprintf("Evolved Strategy (%s-%d)", time.Now().Format("20060102-1504"), rank),
+		Description: fmt.Sprintf("Evolved by the genetic algorithm from strategies %v over %d generations (seed %d). Backtest: %d trades, %.2f%% ROI, %.2f%% max drawdown.",
+			individual.parentIDs, e.config.Generations, seed, backtest.TradeCount, backtest.ROI, backtest.MaxDrawdown),
+		Config:   evolvedStrategyConfig(individual),
+		IsPublic: true,
+		Source:   models.StrategySourceGenetic,
+		Tags:     []string{"evolved", "genetic"},
+	}
+
+	var id int64
+	var err error
+	if e.admission != nil {
+		id, err = e.admission.Admit(strategy, models.PurposeGeneticEvolution)
+		if _, rejected := err.(*StrategyRejectedError); rejected {
+			return strategy, err
+		}
+	} else {
+		id, err = e.strategyRepo.Save(strategy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error saving evolved strategy: %v", err)
+	}
+	strategy.ID = id
+
+	if e.lineage != nil {
+		reason := fmt.Sprintf("Evolved by genetic algorithm over %d generations (seed %d)", e.config.Generations, seed)
+		if err := e.lineage.RecordDerivation(id, individual.parentIDs, reason); err != nil {
+			e.logger.Error("Error recording lineage of evolved strategy %d: %v", id, err)
+		}
+	}
+
+	return strategy, nil
+}
+
+// evolvePopulation runs the genetic algorithm from seeds, which must be sorted fittest
+// first, and returns the final generation sorted the same way
+func evolvePopulation(config EvolutionConfig, seeds []*evolutionIndividual, replay func(models.StrategyConfig) *models.BacktestResult, rng *rand.Rand) []*evolutionIndividual {
+	population := append([]*evolutionIndividual(nil), seeds...)
+
+	// Fill the first generation with mutants of the seeds
+	for i := 0; len(population) < config.PopulationSize; i++ {
+		child := copyIndividual(seeds[i%len(seeds)])
+		mutateConfig(&child.config, 1, config.MutationScale, rng)
+		evaluateIndividual(child, config, replay)
+		population = append(population, child)
+	}
+	sortPopulation(population)
+
+	for generation := 0; generation < config.Generations; generation++ {
+		elites := config.Elites
+		if elites > len(population) {
+			elites = len(population)
+		}
+		next := append([]*evolutionIndividual(nil), population[:elites]...)
+
+		for len(next) < config.PopulationSize {
+			first := tournamentSelect(population, config.TournamentSize, rng)
+			child := copyIndividual(first)
+			if rng.Float64() < config.CrossoverRate {
+				second := tournamentSelect(population, config.TournamentSize, rng)
+				crossoverConfigs(&child.config, &second.config, rng)
+				child.parentIDs = mergeParentIDs(first.parentIDs, second.parentIDs)
+			}
+			mutateConfig(&child.config, config.MutationRate, config.MutationScale, rng)
+			evaluateIndividual(child, config, replay)
+			next = append(next, child)
+		}
+
+		population = next
+		sortPopulation(population)
+	}
+
+	return population
+}
+
+// selectOffspring returns up to count of the fittest configs in population that are new,
+// distinct, trade enough and beat the best seed
+func selectOffspring(population []*evolutionIndividual, bestSeed *evolutionIndividual, count int) []*evolutionIndividual {
+	var offspring []*evolutionIndividual
+	for _, individual := range population {
+		if len(offspring) >= count {
+			break
+		}
+		if individual.seed || !individual.eligible || !individual.fitter(bestSeed) {
+			continue
+		}
+		duplicate := false
+		for _, chosen := range offspring {
+			if reflect.DeepEqual(chosen.config, individual.config) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			offspring = append(offspring, individual)
+		}
+	}
+	return offspring
+}
+
+// evaluateIndividual backtests an individual's config and scores it
+func evaluateIndividual(individual *evolutionIndividual, config EvolutionConfig, replay func(models.StrategyConfig) *models.BacktestResult) {
+	individual.backtest = replay(individual.config)
+	individual.eligible = individual.backtest.TradeCount >= config.MinTrades
+	individual.fitness = individual.backtest.ROI - individual.backtest.MaxDrawdown/2
+}
+
+// sortPopulation sorts a population fittest first, keeping the order of equals
+func sortPopulation(population []*evolutionIndividual) {
+	sort.SliceStable(population, func(i, j int) bool { return population[i].fitter(population[j]) })
+}
+
+// tournamentSelect returns the fittest of size individuals drawn at random
+func tournamentSelect(population []*evolutionIndividual, size int, rng *rand.Rand) *evolutionIndividual {
+	best := population[rng.Intn(len(population))]
+	for i := 1; i < size; i++ {
+		if contender := population[rng.Intn(len(population))]; contender.fitter(best) {
+			best = contender
+		}
+	}
+	return best
+}
+
+// copyIndividual returns an unevaluated copy of an individual to breed from
+func copyIndividual(individual *evolutionIndividual) *evolutionIndividual {
+	return &evolutionIndividual{
+		config:    individual.config,
+		base:      individual.base,
+		parentIDs: append([]int64(nil), individual.parentIDs...),
+	}
+}
+
+// crossoverConfigs gives child each gene of other with even odds. Genes the child's entry
+// signal doesn't use stay unset.
+func crossoverConfigs(child, other *models.StrategyConfig, rng *rand.Rand) {
+	for _, gene := range evolutionGenes {
+		if gene.get(child) == 0 || gene.get(other) == 0 {
+			continue
+		}
+		if rng.Intn(2) == 0 {
+			gene.set(child, gene.get(other))
+		}
+	}
+}
+
+// mutateConfig adds Gaussian noise to each set gene with probability rate. The noise has a
+// standard deviation of scale times the gene's value, and the result is rounded for
+// integer parameters and kept within their schema bounds.
+func mutateConfig(config *models.StrategyConfig, rate, scale float64, rng *rand.Rand) {
+	for _, gene := range evolutionGenes {
+		value := gene.get(config)
+		if value == 0 || rng.Float64() >= rate {
+			continue
+		}
+
+		field := strategyFieldByKey(gene.key)
+		value += rng.NormFloat64() * scale * math.Abs(value)
+		if field.Type == "integer" {
+			value = math.Round(value)
+		}
+		gene.set(config, math.Max(field.Min, math.Min(field.Max, value)))
+	}
+}
+
+// strategyFieldByKey returns the strategyFields entry for key
+func strategyFieldByKey(key string) strategyField {
+	for _, field := range strategyFields {
+		if field.Key == key {
+			return field
+		}
+	}
+	panic("unknown strategy field " + key)
+}
+
+// mergeParentIDs returns the sorted union of two sorted ID lists
+func mergeParentIDs(a, b []int64) []int64 {
+	merged := make([]int64, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			merged = append(merged, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			merged = append(merged, b[j])
+			j++
+		default:
+			merged = append(merged, a[i])
+			i++
+			j++
+		}
+	}
+	return merged
+}
+
+// strategyConfigFromJSONB decodes a saved strategy config
+func strategyConfigFromJSONB(data models.JSONB) (models.StrategyConfig, error) {
+	var config models.StrategyConfig
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return config, fmt.Errorf("error encoding strategy config: %v", err)
+	}
+	if err := json.Unmarshal(encoded, &config); err != nil {
+		return config, fmt.Errorf("invalid strategy config: %v", err)
+	}
+	return config, nil
+}
+
+// evolvedStrategyConfig returns the saved config of an individual: its base config with the
+// evolved genes set
+func evolvedStrategyConfig(individual *evolutionIndividual) models.JSONB {
+	config := make(models.JSONB, len(individual.base))
+	for key, value := range individual.base {
+		config[key] = value
+	}
+	for _, gene := range evolutionGenes {
+		if value := gene.get(&individual.config); value != 0 {
+			config[gene.key] = value
+		}
+	}
+	return config
+}
@@ -0,0 +1,262 @@
+// internal/service/strategy_evolution_test.go
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutateConfigStaysInBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		config := backtestConfig()
+		config.StopLossPct = 95
+		mutateConfig(&config, 1, 5, rng)
+
+		for _, gene := range evolutionGenes {
+			field := strategyFieldByKey(gene.key)
+			value := gene.get(&config)
+			if gene.key == "minUniqueBuyers" || gene.key == "minOrganicBuyVolumeSol" {
+				assert.Zero(t, value, "unset genes stay unset")
+				continue
+			}
+			require.GreaterOrEqual(t, value, field.Min, gene.key)
+			require.LessOrEqual(t, value, field.Max, gene.key)
+		}
+	}
+}
+
+func TestCrossoverConfigs(t *testing.T) {
+	child := backtestConfig()
+	other := backtestConfig()
+	other.TakeProfitPct = 80
+	other.StopLossPct = 40
+	other.MinUniqueBuyers = 5
+
+	taken := map[string]int{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		mixed := child
+		crossoverConfigs(&mixed, &other, rng)
+		assert.Zero(t, mixed.MinUniqueBuyers, "genes the child doesn't use are not inherited")
+		if mixed.TakeProfitPct == 80 {
+			taken["takeProfitPct"]++
+		}
+		if mixed.StopLossPct == 40 {
+			taken["stopLossPct"]++
+		}
+	}
+	assert.InDelta(t, 50, taken["takeProfitPct"], 15)
+	assert.InDelta(t, 50, taken["stopLossPct"], 15)
+}
+
+func TestMergeParentIDs(t *testing.T) {
+	assert.Equal(t, []int64{1, 2, 3, 5}, mergeParentIDs([]int64{1, 3}, []int64{2, 3, 5}))
+	assert.Equal(t, []int64{4}, mergeParentIDs(nil, []int64{4}))
+}
+
+// targetReplay scores configs by how close their take profit and stop loss are to 80 and 15
+func targetReplay(config models.StrategyConfig) *models.BacktestResult {
+	return &models.BacktestResult{
+		TradeCount: 5,
+		ROI:        -math.Abs(config.TakeProfitPct-80) - math.Abs(config.StopLossPct-15),
+	}
+}
+
+func TestEvolvePopulation(t *testing.T) {
+	config := DefaultEvolutionConfig()
+	newSeeds := func() []*evolutionIndividual {
+		first, second := backtestConfig(), backtestConfig()
+		first.TakeProfitPct, first.StopLossPct = 30, 40
+		second.TakeProfitPct, second.StopLossPct = 150, 5
+		seeds := []*evolutionIndividual{
+			{config: first, parentIDs: []int64{1}, seed: true},
+			{config: second, parentIDs: []int64{2}, seed: true},
+		}
+		for _, seed := range seeds {
+			evaluateIndividual(seed, config, targetReplay)
+		}
+		sortPopulation(seeds)
+		return seeds
+	}
+
+	seeds := newSeeds()
+	population := evolvePopulation(config, seeds, targetReplay, rand.New(rand.NewSource(7)))
+	require.Len(t, population, config.PopulationSize)
+	for i := 1; i < len(population); i++ {
+		assert.False(t, population[i].fitter(population[i-1]), "sorted fittest first")
+	}
+	assert.Greater(t, population[0].fitness, seeds[0].fitness)
+	assert.Greater(t, population[0].fitness, -20.0, "converges towards the target")
+
+	again := evolvePopulation(config, newSeeds(), targetReplay, rand.New(rand.NewSource(7)))
+	for i := range population {
+		assert.Equal(t, population[i].config, again[i].config, "the same seed evolves the same population")
+		assert.Equal(t, population[i].parentIDs, again[i].parentIDs)
+	}
+
+	offspring := selectOffspring(population, seeds[0], 2)
+	require.NotEmpty(t, offspring)
+	for _, individual := range offspring {
+		assert.False(t, individual.seed)
+		assert.True(t, individual.fitter(seeds[0]))
+	}
+	if len(offspring) == 2 {
+		assert.NotEqual(t, offspring[0].config, offspring[1].config)
+	}
+}
+
+// seedEvolutionMarket stores three tokens that dip 20% after entry and then double, and two
+// saved strategies that stop out in the dip, and returns the strategies' IDs
+func seedEvolutionMarket(t *testing.T, store *memory.Store) []int64 {
+	strategyRepo := memory.NewStrategyRepository(store)
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+
+	now := time.Now().Unix()
+	for i := 0; i < 3; i++ {
+		tokenID, err := tokenRepo.Save(&models.Token{
+			MintAddress:      fmt.Sprintf("mint%d", i),
+			CreatedTimestamp: (now - 100) * 1000,
+			UsdMarketCap:     16000,
+		})
+		require.NoError(t, err)
+		for j, price := range []float64{0.001, 0.001, 0.0008, 0.002} {
+			_, err := tradeRepo.Save(&models.Trade{
+				TokenID:     tokenID,
+				Signature:   fmt.Sprintf("sig%d-%d", i, j),
+				SolAmount:   1,
+				TokenAmount: 1 / price,
+				IsBuy:       j != 2,
+				UserAddress: fmt.Sprintf("wallet%d", j),
+				Timestamp:   now - 90 + int64(j),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	var seedIDs []int64
+	for _, stopLoss := range []float64{10, 12} {
+		id, err := strategyRepo.Save(&models.Strategy{
+			Name: fmt.Sprintf("Tight Stop %.0f", stopLoss),
+			Config: models.JSONB{
+				"marketCapThreshold":   8000.0,
+				"minBuysForEntry":      2.0,
+				"entryTimeWindowSec":   60.0,
+				"takeProfitPct":        50.0,
+				"stopLossPct":          stopLoss,
+				"maxHoldTimeSec":       300.0,
+				"fixedPositionSizeSol": 1.0,
+				"initialBalance":       10.0,
+				"exitOnGraduation":     true,
+			},
+			IsPublic: true,
+		})
+		require.NoError(t, err)
+		seedIDs = append(seedIDs, id)
+	}
+	return seedIDs
+}
+
+func TestEvolutionEngineRun(t *testing.T) {
+	store := memory.NewStore()
+	strategyRepo := memory.NewStrategyRepository(store)
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+	generationRepo := memory.NewStrategyGenerationRepository(store)
+	lineage := NewLineageService(generationRepo, strategyRepo, memory.NewStrategyMetricRepository(store), logger.New("test"))
+	seedIDs := seedEvolutionMarket(t, store)
+
+	config := DefaultEvolutionConfig()
+	config.MutationScale = 0.5
+	config.MinTrades = 1
+	engine := NewEvolutionEngine(NewBacktester(tradeRepo, tokenRepo), strategyRepo, lineage, config, logger.New("test"))
+
+	result, err := engine.RunWithSeed(42)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), result.Seed)
+	assert.Equal(t, 2, result.Seeds)
+	assert.InDelta(t, -6-3, result.SeedFitness, 1e-9, "three 0.2 SOL stop losses on 10 SOL, less half the 6% drawdown")
+	assert.Greater(t, result.BestFitness, result.SeedFitness)
+	require.NotEmpty(t, result.Strategies)
+
+	for _, strategy := range result.Strategies {
+		saved, err := strategyRepo.GetByID(strategy.ID)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.True(t, saved.IsPublic)
+		assert.Equal(t, models.StrategySourceGenetic, saved.Source)
+		assert.False(t, saved.AIEnhanced, "no LLM was involved")
+		assert.Equal(t, []string{"evolved", "genetic"}, saved.Tags)
+		assert.Greater(t, saved.Config["stopLossPct"], 20.0, "survives the dip")
+		assert.Equal(t, true, saved.Config["exitOnGraduation"], "parameters the algorithm doesn't evolve are inherited")
+
+		generations, err := generationRepo.GetByChildStrategy(strategy.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, generations)
+		for _, generation := range generations {
+			assert.Equal(t, 1, generation.GenerationNumber)
+			assert.Contains(t, seedIDs, generation.ParentStrategyID)
+			assert.Contains(t, generation.ImprovementReason, "genetic algorithm")
+		}
+	}
+
+	_, err = NewEvolutionEngine(NewBacktester(tradeRepo, tokenRepo), memory.NewStrategyRepository(memory.NewStore()), lineage, config, logger.New("test")).RunWithSeed(42)
+	assert.EqualError(t, err, "need at least 2 backtestable strategies to evolve, found 0")
+}
+
+func TestEvolutionEngineRunAdmitsOffspring(t *testing.T) {
+	store := memory.NewStore()
+	strategyRepo := memory.NewStrategyRepository(store)
+	tokenRepo := memory.NewTokenRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+	candidateRepo := memory.NewStrategyCandidateRepository(store)
+	lineage := NewLineageService(memory.NewStrategyGenerationRepository(store), strategyRepo, memory.NewStrategyMetricRepository(store), logger.New("test"))
+	seedEvolutionMarket(t, store)
+
+	config := DefaultEvolutionConfig()
+	config.MutationScale = 0.5
+	config.MinTrades = 1
+	backtester := NewBacktester(tradeRepo, tokenRepo)
+	engine := NewEvolutionEngine(backtester, strategyRepo, lineage, config, logger.New("test"))
+
+	// The offspring make three backtest trades, short of what admission asks for
+	strict := AdmissionConfig{LookbackHours: 6, MinTrades: 10, MaxDrawdownPct: 30}
+	engine.SetAdmission(NewStrategyAdmissionService(backtester, strategyRepo, candidateRepo, strict, logger.New("test")))
+	result, err := engine.RunWithSeed(42)
+	require.NoError(t, err)
+	assert.Empty(t, result.Strategies)
+	assert.Positive(t, result.Rejected)
+
+	strategies, err := strategyRepo.ListPublic(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, strategies, 2, "only the seeds are saved")
+
+	candidates, err := candidateRepo.GetRecent(models.AdmissionRejected, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, result.Rejected)
+	for _, candidate := range candidates {
+		assert.Equal(t, models.PurposeGeneticEvolution, candidate.Purpose)
+		assert.Equal(t, []string{"made 3 backtest trades, at least 10 required"}, candidate.Reasons)
+	}
+
+	lenient := AdmissionConfig{LookbackHours: 6, MinTrades: 1, MaxDrawdownPct: 30}
+	engine.SetAdmission(NewStrategyAdmissionService(backtester, strategyRepo, candidateRepo, lenient, logger.New("test")))
+	result, err = engine.RunWithSeed(42)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Strategies)
+
+	candidates, err = candidateRepo.GetRecent(models.AdmissionAdmitted, 10)
+	require.NoError(t, err)
+	require.Len(t, candidates, len(result.Strategies))
+	assert.Equal(t, result.Strategies[0].ID, *candidates[len(candidates)-1].StrategyID)
+}
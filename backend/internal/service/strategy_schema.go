@@ -0,0 +1,297 @@
+// internal/service/strategy_schema.go
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+)
+
+// strategyField describes one property of the strategy payload the AI returns
+type strategyField struct {
+	Key         string
+	Type        string // "string", "number", "integer" or "boolean"
+	Required    bool
+	Min         float64 // Inclusive bounds for numbers and integers, length bounds for strings
+	Max         float64
+	Enum        []interface{}
+	Description string // Optional fields take their description from optionalStrategyParams
+}
+
+// strategyFields are the properties of the strategy payload. Bounds are hard limits: a
+// value outside them is rejected rather than clamped or replaced with a default.
+var strategyFields = []strategyField{
+	{Key: "name", Type: "string", Required: true, Min: 1, Max: 100, Description: "A catchy name for the strategy"},
+	{Key: "description", Type: "string", Required: true, Min: 1, Max: 1000, Description: "A brief description of how the strategy works"},
+	{Key: "marketCapThreshold", Type: "number", Required: true, Min: 1000, Max: 1000000, Description: "Minimum market cap in USD for tokens to consider"},
+	{Key: "minBuysForEntry", Type: "integer", Required: true, Min: 1, Max: 100, Description: "Minimum buy transactions within the entry window to trigger entry"},
+	{Key: "entryTimeWindowSec", Type: "integer", Required: true, Min: 5, Max: 3600, Description: "Window in seconds for counting transactions towards the entry signal"},
+	{Key: "takeProfitPct", Type: "number", Required: true, Min: 1, Max: 1000, Description: "Percentage gain that triggers take profit"},
+	{Key: "stopLossPct", Type: "number", Required: true, Min: 1, Max: 99, Description: "Percentage loss that triggers stop loss"},
+	{Key: "maxHoldTimeSec", Type: "integer", Required: true, Min: 10, Max: 86400, Description: "Maximum time to hold a position in seconds"},
+	{Key: "fixedPositionSizeSol", Type: "number", Required: true, Min: 0.01, Max: 100, Description: "Fixed position size in SOL for each trade"},
+	{Key: "initialBalance", Type: "number", Required: true, Min: 0.1, Max: 10000, Description: "Starting balance in SOL"},
+
+	{Key: "entrySignalType", Type: "string", Enum: []interface{}{
+		models.EntrySignalBuyCount,
+		models.EntrySignalSmartMoney,
+		models.EntrySignalUniqueBuyers,
+		models.EntrySignalOrganicVolume,
+		models.EntrySignalKingOfTheHill,
+	}},
+	{Key: "minSmartWalletBuys", Type: "integer", Min: 1, Max: 20},
+	{Key: "minUniqueBuyers", Type: "integer", Min: 1, Max: 100},
+	{Key: "minOrganicBuyVolumeSol", Type: "number", Min: 0.01, Max: 1000},
+	{Key: "minCreatorReputation", Type: "number", Min: 0, Max: 100},
+	{Key: "maxCreatorLaunchesWithoutGraduation", Type: "integer", Min: 0, Max: 1000},
+	{Key: "maxTop10ConcentrationPct", Type: "number", Min: 1, Max: 100},
+	{Key: "maxBundledSupplyPct", Type: "number", Min: 0, Max: 100},
+	{Key: "excludeLaunchBuyers", Type: "boolean"},
+	{Key: "maxAnomalyScore", Type: "number", Min: 0, Max: 100},
+	{Key: "featureWindowSec", Type: "integer", Enum: []interface{}{10, 30, 60, 300}},
+	{Key: "minNetFlowSol", Type: "number", Min: -1000, Max: 1000},
+	{Key: "minPriceVelocityPct", Type: "number", Min: -1000, Max: 1000},
+	{Key: "maxVolatilityPct", Type: "number", Min: 0, Max: 1000},
+	{Key: "maxSecondsSinceLastTrade", Type: "integer", Min: 1, Max: 86400},
+	{Key: "exitOnCreatorSell", Type: "boolean"},
+	{Key: "exitOnGraduation", Type: "boolean"},
+	{Key: "exitOnTopHolderDumpPct", Type: "number", Min: 1, Max: 100},
+}
+
+// StrategyJSONSchema returns the JSON Schema of the strategy payload the AI must return
+func StrategyJSONSchema() map[string]interface{} {
+	descriptions := make(map[string]string, len(optionalStrategyParams))
+	for _, param := range optionalStrategyParams {
+		descriptions[param.Key] = param.Description
+	}
+
+	properties := make(map[string]interface{}, len(strategyFields))
+	var required []string
+	for _, field := range strategyFields {
+		property := map[string]interface{}{"type": field.Type}
+
+		description := field.Description
+		if description == "" {
+			description = descriptions[field.Key]
+		}
+		if description != "" {
+			property["description"] = description
+		}
+
+		switch {
+		case len(field.Enum) > 0:
+			property["enum"] = field.Enum
+		case field.Type == "string":
+			property["minLength"] = int(field.Min)
+			property["maxLength"] = int(field.Max)
+		case field.Type == "number" || field.Type == "integer":
+			property["minimum"] = field.Min
+			property["maximum"] = field.Max
+		}
+
+		properties[field.Key] = property
+		if field.Required {
+			required = append(required, field.Key)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// StrategyValidationError lists every way an AI response failed the strategy schema
+type StrategyValidationError struct {
+	Problems []string
+}
+
+func (e *StrategyValidationError) Error() string {
+	return "invalid strategy: " + strings.Join(e.Problems, "; ")
+}
+
+// decodeStrategyPayload decodes a response that must consist of a single JSON object,
+// optionally wrapped in a markdown code fence
+func decodeStrategyPayload(content string) (map[string]interface{}, error) {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, "```") {
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.TrimPrefix(content, "```")
+		content = strings.TrimSuffix(content, "```")
+		content = strings.TrimSpace(content)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(content)))
+	decoder.UseNumber()
+
+	var payload map[string]interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("response is not a JSON object: %v", err)
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("response is not a JSON object")
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("response contains more than one JSON value")
+	}
+	return payload, nil
+}
+
+// payloadObjects checks that raw is an array of objects, naming problems after field
+func payloadObjects(field string, raw interface{}) ([]map[string]interface{}, []string) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, []string{fmt.Sprintf("%s: must be an array", field)}
+	}
+
+	var objects []map[string]interface{}
+	var problems []string
+	for i, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s[%d]: must be an object", field, i))
+			continue
+		}
+		objects = append(objects, object)
+	}
+	return objects, problems
+}
+
+// payloadString returns a string property of an object, trimmed, or "" if it isn't a string
+func payloadString(object map[string]interface{}, key string) string {
+	value, _ := object[key].(string)
+	return strings.TrimSpace(value)
+}
+
+// validateStrategyPayload checks a decoded payload against strategyFields and the rules
+// that span fields. It returns the normalized config, with integers as int and numbers as
+// float64, and every problem found.
+func validateStrategyPayload(payload map[string]interface{}) (map[string]interface{}, []string) {
+	var problems []string
+	known := make(map[string]bool, len(strategyFields))
+	values := make(map[string]interface{}, len(payload))
+
+	for _, field := range strategyFields {
+		known[field.Key] = true
+		raw, ok := payload[field.Key]
+		if !ok || raw == nil {
+			if field.Required {
+				problems = append(problems, fmt.Sprintf("%s: is required", field.Key))
+			}
+			continue
+		}
+
+		value, problem := validateStrategyField(field, raw)
+		if problem != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", field.Key, problem))
+			continue
+		}
+		values[field.Key] = value
+	}
+
+	var unknown []string
+	for key := range payload {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		problems = append(problems, fmt.Sprintf("%s: is not a supported parameter", key))
+	}
+
+	if size, ok := values["fixedPositionSizeSol"].(float64); ok {
+		if balance, ok := values["initialBalance"].(float64); ok && size > balance {
+			problems = append(problems, "fixedPositionSizeSol: must not exceed initialBalance")
+		}
+	}
+	if values["entrySignalType"] == models.EntrySignalOrganicVolume {
+		if _, ok := values["minOrganicBuyVolumeSol"]; !ok {
+			problems = append(problems, "minOrganicBuyVolumeSol: is required for organic_volume entries")
+		}
+	}
+
+	return values, problems
+}
+
+// validateStrategyField converts one raw value to its field type and checks its bounds,
+// returning a description of the problem if there is one
+func validateStrategyField(field strategyField, raw interface{}) (interface{}, string) {
+	switch field.Type {
+	case "string":
+		value, ok := raw.(string)
+		if !ok {
+			return nil, "must be a string"
+		}
+		value = strings.TrimSpace(value)
+		if len(field.Enum) > 0 {
+			for _, allowed := range field.Enum {
+				if value == allowed {
+					return value, ""
+				}
+			}
+			return nil, fmt.Sprintf("must be one of %v", field.Enum)
+		}
+		if length := len(value); float64(length) < field.Min || float64(length) > field.Max {
+			return nil, fmt.Sprintf("must be between %d and %d characters", int(field.Min), int(field.Max))
+		}
+		return value, ""
+
+	case "boolean":
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, "must be a boolean"
+		}
+		return value, ""
+
+	case "number", "integer":
+		number, ok := raw.(json.Number)
+		if !ok {
+			return nil, fmt.Sprintf("must be a %s", field.Type)
+		}
+		value, err := number.Float64()
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Sprintf("must be a %s", field.Type)
+		}
+		if field.Type == "integer" && value != math.Trunc(value) {
+			return nil, "must be a whole number"
+		}
+		if len(field.Enum) > 0 {
+			for _, allowed := range field.Enum {
+				if value == float64(allowed.(int)) {
+					return int(value), ""
+				}
+			}
+			return nil, fmt.Sprintf("must be one of %v", field.Enum)
+		}
+		if value < field.Min || value > field.Max {
+			return nil, fmt.Sprintf("must be between %g and %g, got %g", field.Min, field.Max, value)
+		}
+		if field.Type == "integer" {
+			return int(value), ""
+		}
+		return value, ""
+	}
+
+	return nil, fmt.Sprintf("has unsupported type %s", field.Type)
+}
+
+// strategyRepairPrompt asks the model to fix the problems found in its last response
+func strategyRepairPrompt(problems []string) string {
+	var prompt strings.Builder
+	prompt.WriteString("Your previous response did not match the required strategy schema:\n\n")
+	for _, problem := range problems {
+		prompt.WriteString("- ")
+		prompt.WriteString(problem)
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString("\nReturn ONLY the corrected JSON object. Keep the parameters that were valid and fix the ones listed above.")
+	return prompt.String()
+}
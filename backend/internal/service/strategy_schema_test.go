@@ -0,0 +1,120 @@
+// internal/service/strategy_schema_test.go
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validStrategyJSON = `{
+	"name": "Momentum Chaser",
+	"description": "Buys tokens with rapid buy activity",
+	"marketCapThreshold": 7000,
+	"minBuysForEntry": 3,
+	"entryTimeWindowSec": 300,
+	"takeProfitPct": 50,
+	"stopLossPct": 30,
+	"maxHoldTimeSec": 600,
+	"fixedPositionSizeSol": 0.5,
+	"initialBalance": 10,
+	"entrySignalType": "unique_buyers",
+	"minUniqueBuyers": 5,
+	"exitOnCreatorSell": true
+}`
+
+func TestDecodeStrategyPayload(t *testing.T) {
+	payload, err := decodeStrategyPayload("```json\n" + validStrategyJSON + "\n```")
+	require.NoError(t, err)
+	assert.Equal(t, "Momentum Chaser", payload["name"])
+
+	_, err = decodeStrategyPayload("Here is my strategy: " + validStrategyJSON)
+	assert.Error(t, err, "prose around the object is rejected")
+
+	_, err = decodeStrategyPayload(`{"name": "a"} {"name": "b"}`)
+	assert.Error(t, err, "only one object is accepted")
+
+	_, err = decodeStrategyPayload(`[1, 2]`)
+	assert.Error(t, err)
+
+	_, err = decodeStrategyPayload(`null`)
+	assert.Error(t, err)
+}
+
+func TestValidateStrategyPayloadValid(t *testing.T) {
+	payload, err := decodeStrategyPayload(validStrategyJSON)
+	require.NoError(t, err)
+
+	config, problems := validateStrategyPayload(payload)
+	require.Empty(t, problems)
+	assert.Equal(t, 7000.0, config["marketCapThreshold"])
+	assert.Equal(t, 3, config["minBuysForEntry"])
+	assert.Equal(t, 5, config["minUniqueBuyers"])
+	assert.Equal(t, "unique_buyers", config["entrySignalType"])
+	assert.Equal(t, true, config["exitOnCreatorSell"])
+	assert.NotContains(t, config, "maxAnomalyScore", "absent optional fields stay unset")
+}
+
+func TestValidateStrategyPayloadProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		problem string
+	}{
+		{"missing required", `{"name": "x"}`, "marketCapThreshold: is required"},
+		{"out of range", `{"stopLossPct": 150}`, "stopLossPct: must be between 1 and 99, got 150"},
+		{"fractional integer", `{"minBuysForEntry": 2.5}`, "minBuysForEntry: must be a whole number"},
+		{"wrong type", `{"takeProfitPct": "50"}`, "takeProfitPct: must be a number"},
+		{"unknown enum", `{"entrySignalType": "vibes"}`, "entrySignalType: must be one of"},
+		{"feature window", `{"featureWindowSec": 45}`, "featureWindowSec: must be one of"},
+		{"unknown field", `{"leverage": 10}`, "leverage: is not a supported parameter"},
+		{"empty name", `{"name": " "}`, "name: must be between 1 and 100 characters"},
+		{"position above balance", `{"fixedPositionSizeSol": 5, "initialBalance": 1}`, "fixedPositionSizeSol: must not exceed initialBalance"},
+		{"organic volume", `{"entrySignalType": "organic_volume"}`, "minOrganicBuyVolumeSol: is required for organic_volume entries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := decodeStrategyPayload(tt.json)
+			require.NoError(t, err)
+
+			_, problems := validateStrategyPayload(payload)
+			found := false
+			for _, problem := range problems {
+				if strings.HasPrefix(problem, tt.problem) {
+					found = true
+				}
+			}
+			assert.True(t, found, "expected %q in %v", tt.problem, problems)
+		})
+	}
+}
+
+func TestStrategyJSONSchema(t *testing.T) {
+	schema := StrategyJSONSchema()
+	assert.Equal(t, false, schema["additionalProperties"])
+
+	required := schema["required"].([]string)
+	assert.Contains(t, required, "stopLossPct")
+	assert.NotContains(t, required, "entrySignalType")
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Len(t, properties, len(strategyFields))
+
+	stopLoss := properties["stopLossPct"].(map[string]interface{})
+	assert.Equal(t, 99.0, stopLoss["maximum"])
+
+	for _, param := range optionalStrategyParams {
+		property, ok := properties[param.Key].(map[string]interface{})
+		require.True(t, ok, "optional parameter %s is in the schema", param.Key)
+		assert.Equal(t, param.Description, property["description"])
+	}
+}
+
+func TestStrategyRepairPrompt(t *testing.T) {
+	prompt := strategyRepairPrompt([]string{"stopLossPct: must be between 1 and 99, got 150"})
+	assert.Contains(t, prompt, "- stopLossPct: must be between 1 and 99, got 150")
+	assert.Contains(t, prompt, "JSON object")
+}
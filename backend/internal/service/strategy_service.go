@@ -16,6 +16,7 @@ var _ StrategyServiceInterface = (*StrategyService)(nil)
 type StrategyService struct {
 	strategyRepo       repository.StrategyRepositoryInterface
 	strategyMetricRepo repository.StrategyMetricRepositoryInterface
+	diversity          *StrategyDiversityService
 	logger             *logger.Logger
 }
 
@@ -32,13 +33,31 @@ func NewStrategyService(
 	}
 }
 
-// CreateStrategy creates a new strategy
+// SetDiversity sets what near-duplicates of saved strategies are checked against
+func (s *StrategyService) SetDiversity(diversity *StrategyDiversityService) {
+	s.diversity = diversity
+}
+
+// CreateStrategy creates a new strategy. With diversity set, a near-duplicate of a saved
+// strategy is not created and a *DuplicateStrategyError is returned, with Merged set if it
+// was merged into the saved strategy rather than rejected.
 func (s *StrategyService) CreateStrategy(strategy *models.Strategy) (int64, error) {
 	// Validate the strategy
 	if err := s.validateStrategy(strategy); err != nil {
 		return 0, err
 	}
 
+	// Check for near-duplicates of saved strategies
+	if s.diversity != nil {
+		duplicate, err := s.diversity.Resolve(strategy)
+		if err != nil {
+			return 0, fmt.Errorf("error checking for near-duplicates: %v", err)
+		}
+		if duplicate != nil {
+			return 0, duplicate
+		}
+	}
+
 	// Set initial values
 	strategy.VoteCount = 0
 	strategy.WinCount = 0
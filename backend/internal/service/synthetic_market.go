@@ -0,0 +1,484 @@
+// internal/service/synthetic_market.go
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Bonding curve parameters of a new pump.fun token, in lamports and raw token units
+	syntheticVirtualSolReserves   = 30e9
+	syntheticVirtualTokenReserves = 1.073e15
+	syntheticRealTokenReserves    = 793.1e12
+
+	// syntheticLamportsPerSol converts SOL amounts to the lamports the feed reports
+	syntheticLamportsPerSol = 1e9
+
+	// syntheticKingOfTheHillProgress is the share of the curve's tokens that must be sold
+	// before a token is crowned king of the hill (about 50 SOL raised)
+	syntheticKingOfTheHillProgress = 0.85
+
+	// syntheticMaxTokenAgeSec is how long a token that never graduates keeps trading
+	syntheticMaxTokenAgeSec = 30 * 60
+
+	// syntheticMinRetailRate is the retail trade rate per second below which a token is dead
+	syntheticMinRetailRate = 0.002
+
+	// Sizes of the wallet pools agents are drawn from, so wallets recur across tokens
+	syntheticRetailWallets  = 2000
+	syntheticSniperWallets  = 40
+	syntheticWhaleWallets   = 15
+	syntheticCreatorWallets = 300
+)
+
+// Synthetic market event names, matching the pump.fun Socket.IO feed
+const (
+	SyntheticEventTokenCreated = "tokenCreated"
+	SyntheticEventTradeCreated = "tradeCreated"
+)
+
+// SyntheticRegime sets how busy and how hostile the synthetic market is
+type SyntheticRegime struct {
+	Name               string
+	LaunchesPerMin     float64 // New tokens per minute
+	RetailTradesPerMin float64 // Retail trades per minute on a fresh token of average quality
+	HypeHalfLifeSec    float64 // Time for a token's retail interest to halve
+	RetailBuyProb      float64 // Probability a retail trade on an average token is a buy
+	MaxSnipers         int     // Upper bound of sniper buys in a launch's first seconds
+	WhaleProb          float64 // Share of tokens a whale buys into
+	RuggerShare        float64 // Share of creators who dump their holding on every launch
+	WashProb           float64 // Share of tokens a wash trader churns
+	SolUsd             float64 // SOL price used for USD market caps
+}
+
+// SyntheticRegimes are the built-in market regimes, selected by name
+var SyntheticRegimes = map[string]SyntheticRegime{
+	"normal": {
+		Name:               "normal",
+		LaunchesPerMin:     6,
+		RetailTradesPerMin: 20,
+		HypeHalfLifeSec:    120,
+		RetailBuyProb:      0.55,
+		MaxSnipers:         4,
+		WhaleProb:          0.1,
+		RuggerShare:        0.2,
+		WashProb:           0.15,
+		SolUsd:             150,
+	},
+	"hot": {
+		Name:               "hot",
+		LaunchesPerMin:     12,
+		RetailTradesPerMin: 30,
+		HypeHalfLifeSec:    240,
+		RetailBuyProb:      0.62,
+		MaxSnipers:         8,
+		WhaleProb:          0.25,
+		RuggerShare:        0.15,
+		WashProb:           0.2,
+		SolUsd:             200,
+	},
+	"cold": {
+		Name:               "cold",
+		LaunchesPerMin:     3,
+		RetailTradesPerMin: 8,
+		HypeHalfLifeSec:    60,
+		RetailBuyProb:      0.45,
+		MaxSnipers:         2,
+		WhaleProb:          0.03,
+		RuggerShare:        0.35,
+		WashProb:           0.1,
+		SolUsd:             100,
+	},
+}
+
+// SyntheticEvent is one Socket.IO event produced by the synthetic market
+type SyntheticEvent struct {
+	Event string
+	Data  map[string]interface{}
+}
+
+// Frame encodes the event as the Socket.IO text frame the pump.fun feed sends
+func (e SyntheticEvent) Frame() ([]byte, error) {
+	payload, err := json.Marshal([]interface{}{e.Event, e.Data})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s frame: %v", e.Event, err)
+	}
+	return append([]byte("42"), payload...), nil
+}
+
+// syntheticAction is a trade an agent has scheduled on a token
+type syntheticAction struct {
+	at     int64
+	wallet string
+	isBuy  bool
+	sol    float64 // SOL to spend on a buy
+	share  float64 // Share of the wallet's balance to sell
+}
+
+// syntheticToken is a live token on its bonding curve
+type syntheticToken struct {
+	mint          string
+	name          string
+	symbol        string
+	creator       string
+	createdAt     int64
+	quality       float64 // Multiplier on retail interest and buy pressure
+	virtualSol    float64
+	virtualTokens float64
+	realTokens    float64
+	kingOfTheHill int64 // Unix milliseconds, 0 until crowned
+	complete      bool
+	rugged        bool
+	balances      map[string]float64
+	retailHolders []string
+	actions       []syntheticAction
+}
+
+// SyntheticMarket generates pump.fun launches and trades on bonding curves from a seeded
+// random source, one simulated second at a time. Launches attract snipers, retail,
+// occasional whales and wash traders; some creators dump their holding. The same seed,
+// regime and start time always produce the same events.
+type SyntheticMarket struct {
+	rng      *rand.Rand
+	regime   SyntheticRegime
+	now      int64
+	tokens   []*syntheticToken
+	retail   []string
+	snipers  []string
+	whales   []string
+	creators []string
+	ruggers  map[string]bool
+}
+
+// NewSyntheticMarket creates a synthetic market whose clock starts at start
+func NewSyntheticMarket(seed int64, regime SyntheticRegime, start time.Time) *SyntheticMarket {
+	rng := rand.New(rand.NewSource(seed))
+	m := &SyntheticMarket{
+		rng:     rng,
+		regime:  regime,
+		now:     start.Unix(),
+		ruggers: make(map[string]bool),
+	}
+
+	m.retail = m.wallets(syntheticRetailWallets)
+	m.snipers = m.wallets(syntheticSniperWallets)
+	m.whales = m.wallets(syntheticWhaleWallets)
+	m.creators = m.wallets(syntheticCreatorWallets)
+	for _, creator := range m.creators {
+		if rng.Float64() < regime.RuggerShare {
+			m.ruggers[creator] = true
+		}
+	}
+
+	return m
+}
+
+// Now returns the market's simulated time
+func (m *SyntheticMarket) Now() time.Time {
+	return time.Unix(m.now, 0)
+}
+
+// LiveTokens returns the number of tokens still trading
+func (m *SyntheticMarket) LiveTokens() int {
+	return len(m.tokens)
+}
+
+// Tick advances the market by one second and returns the events of that second in order
+func (m *SyntheticMarket) Tick() []SyntheticEvent {
+	m.now++
+	var events []SyntheticEvent
+
+	for i := m.poisson(m.regime.LaunchesPerMin / 60); i > 0; i-- {
+		events = append(events, m.launch()...)
+	}
+
+	live := m.tokens[:0]
+	for _, token := range m.tokens {
+		if token.createdAt == m.now {
+			// Launched this second; its first trades are already in events
+			live = append(live, token)
+			continue
+		}
+
+		events = append(events, m.trade(token)...)
+		if !token.complete && m.alive(token) {
+			live = append(live, token)
+		}
+	}
+	m.tokens = live
+
+	return events
+}
+
+// launch creates a token, its creator's buy and the snipers that follow it
+func (m *SyntheticMarket) launch() []SyntheticEvent {
+	name, symbol := m.tokenName()
+	token := &syntheticToken{
+		mint:          m.address(40) + "pump",
+		name:          name,
+		symbol:        symbol,
+		creator:       m.pick(m.creators),
+		createdAt:     m.now,
+		quality:       math.Exp(m.rng.NormFloat64()),
+		virtualSol:    syntheticVirtualSolReserves,
+		virtualTokens: syntheticVirtualTokenReserves,
+		realTokens:    syntheticRealTokenReserves,
+		balances:      make(map[string]float64),
+	}
+	m.tokens = append(m.tokens, token)
+
+	events := []SyntheticEvent{{Event: SyntheticEventTokenCreated, Data: m.tokenData(token)}}
+	events = append(events, m.execute(token, syntheticAction{wallet: token.creator, isBuy: true, sol: 0.2 + m.rng.Float64()*2.8})...)
+
+	if m.ruggers[token.creator] {
+		token.actions = append(token.actions, syntheticAction{at: m.now + 30 + m.rng.Int63n(570), wallet: token.creator, share: 1})
+	}
+
+	// Snipers buy within two seconds of launch and take profit within two minutes
+	for i := m.rng.Intn(m.regime.MaxSnipers + 1); i > 0; i-- {
+		sniper := m.pick(m.snipers)
+		buyAt := m.now + m.rng.Int63n(3)
+		token.actions = append(token.actions,
+			syntheticAction{at: buyAt, wallet: sniper, isBuy: true, sol: 0.5 + m.rng.Float64()*2.5},
+			syntheticAction{at: buyAt + 20 + m.rng.Int63n(100), wallet: sniper, share: 1},
+		)
+	}
+
+	if m.rng.Float64() < m.regime.WhaleProb {
+		whale := m.pick(m.whales)
+		buyAt := m.now + 10 + m.rng.Int63n(290)
+		token.actions = append(token.actions,
+			syntheticAction{at: buyAt, wallet: whale, isBuy: true, sol: 5 + m.rng.Float64()*15},
+			syntheticAction{at: buyAt + 60 + m.rng.Int63n(840), wallet: whale, share: 1},
+		)
+	}
+
+	// Wash traders churn the same size back and forth on a fixed period
+	if m.rng.Float64() < m.regime.WashProb {
+		washer := m.address(44)
+		size := 0.1 + m.rng.Float64()*0.9
+		period := 5 + m.rng.Int63n(11)
+		at := m.now + 5 + m.rng.Int63n(55)
+		for round := 10 + m.rng.Intn(30); round > 0; round-- {
+			token.actions = append(token.actions,
+				syntheticAction{at: at, wallet: washer, isBuy: true, sol: size},
+				syntheticAction{at: at + period/2, wallet: washer, share: 1},
+			)
+			at += period
+		}
+	}
+
+	// Keep actions due at launch in the launch's events
+	for _, action := range m.dueActions(token) {
+		events = append(events, m.execute(token, action)...)
+	}
+
+	return events
+}
+
+// trade runs a token's scheduled actions and retail trades for the current second
+func (m *SyntheticMarket) trade(token *syntheticToken) []SyntheticEvent {
+	var events []SyntheticEvent
+	for _, action := range m.dueActions(token) {
+		events = append(events, m.execute(token, action)...)
+	}
+
+	for i := m.poisson(m.retailRate(token)); i > 0 && !token.complete; i-- {
+		// Better tokens draw more buyers; holders take profit as the curve fills
+		buyProb := m.regime.RetailBuyProb + 0.08*math.Log(token.quality) - 0.25*curveProgress(token)
+		buyProb = math.Min(math.Max(buyProb, 0.2), 0.85)
+		if len(token.retailHolders) == 0 || m.rng.Float64() < buyProb {
+			wallet := m.pick(m.retail)
+			if token.balances[wallet] == 0 {
+				token.retailHolders = append(token.retailHolders, wallet)
+			}
+			sol := math.Min(math.Exp(m.rng.NormFloat64()*0.9-1.5), 5)
+			events = append(events, m.execute(token, syntheticAction{wallet: wallet, isBuy: true, sol: sol})...)
+			continue
+		}
+
+		wallet := token.retailHolders[m.rng.Intn(len(token.retailHolders))]
+		share := 0.3 + m.rng.Float64()*0.7
+		events = append(events, m.execute(token, syntheticAction{wallet: wallet, share: share})...)
+	}
+
+	return events
+}
+
+// dueActions removes and returns a token's actions scheduled at or before now, in order
+func (m *SyntheticMarket) dueActions(token *syntheticToken) []syntheticAction {
+	sort.SliceStable(token.actions, func(i, j int) bool {
+		return token.actions[i].at < token.actions[j].at
+	})
+
+	due := 0
+	for due < len(token.actions) && token.actions[due].at <= m.now {
+		due++
+	}
+	actions := token.actions[:due:due]
+	token.actions = token.actions[due:]
+	return actions
+}
+
+// execute applies a buy or sell to the token's bonding curve and returns its trade event
+func (m *SyntheticMarket) execute(token *syntheticToken, action syntheticAction) []SyntheticEvent {
+	if token.complete {
+		return nil
+	}
+
+	k := token.virtualSol * token.virtualTokens
+	var solAmount, tokenAmount float64
+	if action.isBuy {
+		solAmount = math.Round(action.sol * syntheticLamportsPerSol)
+		tokenAmount = math.Floor(token.virtualTokens - k/(token.virtualSol+solAmount))
+		if tokenAmount >= token.realTokens {
+			// The buy fills the rest of the curve and completes the token
+			tokenAmount = token.realTokens
+			solAmount = math.Round(k/(token.virtualTokens-tokenAmount) - token.virtualSol)
+		}
+		token.virtualSol += solAmount
+		token.virtualTokens -= tokenAmount
+		token.realTokens -= tokenAmount
+		token.balances[action.wallet] += tokenAmount
+	} else {
+		tokenAmount = math.Floor(token.balances[action.wallet] * action.share)
+		if tokenAmount <= 0 {
+			return nil
+		}
+		solAmount = math.Floor(token.virtualSol - k/(token.virtualTokens+tokenAmount))
+		token.virtualSol -= solAmount
+		token.virtualTokens += tokenAmount
+		token.realTokens += tokenAmount
+		token.balances[action.wallet] -= tokenAmount
+		if action.wallet == token.creator && m.ruggers[token.creator] {
+			token.rugged = true
+		}
+	}
+	if tokenAmount <= 0 || solAmount <= 0 {
+		return nil
+	}
+
+	if token.kingOfTheHill == 0 && curveProgress(token) >= syntheticKingOfTheHillProgress {
+		token.kingOfTheHill = m.now * 1000
+	}
+	if token.realTokens <= 0 {
+		token.complete = true
+	}
+
+	data := m.tokenData(token)
+	data["signature"] = m.address(88)
+	data["sol_amount"] = solAmount
+	data["token_amount"] = tokenAmount
+	data["is_buy"] = action.isBuy
+	data["user"] = action.wallet
+	data["timestamp"] = float64(m.now)
+
+	return []SyntheticEvent{{Event: SyntheticEventTradeCreated, Data: data}}
+}
+
+// tokenData returns the token fields the feed carries on token and trade events
+func (m *SyntheticMarket) tokenData(token *syntheticToken) map[string]interface{} {
+	marketCap := token.virtualSol / token.virtualTokens * PumpFunTotalSupply / syntheticLamportsPerSol
+	data := map[string]interface{}{
+		"mint":                   token.mint,
+		"name":                   token.name,
+		"symbol":                 token.symbol,
+		"creator":                token.creator,
+		"image_uri":              "https://synthetic.invalid/" + token.mint + ".png",
+		"metadata_uri":           "https://synthetic.invalid/" + token.mint + ".json",
+		"created_timestamp":      float64(token.createdAt * 1000),
+		"complete":               token.complete,
+		"total_supply":           float64(PumpFunTotalSupply),
+		"virtual_sol_reserves":   token.virtualSol,
+		"virtual_token_reserves": token.virtualTokens,
+		"market_cap":             marketCap,
+		"usd_market_cap":         marketCap * m.regime.SolUsd,
+	}
+	if token.kingOfTheHill > 0 {
+		data["king_of_the_hill_timestamp"] = float64(token.kingOfTheHill)
+	}
+	return data
+}
+
+// curveProgress returns the share of the curve's tokens that have been sold
+func curveProgress(token *syntheticToken) float64 {
+	return 1 - token.realTokens/syntheticRealTokenReserves
+}
+
+// retailRate returns the expected retail trades per second on a token
+func (m *SyntheticMarket) retailRate(token *syntheticToken) float64 {
+	age := float64(m.now - token.createdAt)
+	hype := token.quality * math.Pow(0.5, age/m.regime.HypeHalfLifeSec)
+	if token.kingOfTheHill > 0 {
+		// Being crowned puts the token on the front page
+		hype *= 2
+	}
+	if token.rugged {
+		hype *= 0.1
+	}
+	return m.regime.RetailTradesPerMin / 60 * hype
+}
+
+// alive reports whether a token keeps trading after this second
+func (m *SyntheticMarket) alive(token *syntheticToken) bool {
+	if len(token.actions) > 0 {
+		return true
+	}
+	return m.now-token.createdAt < syntheticMaxTokenAgeSec && m.retailRate(token) >= syntheticMinRetailRate
+}
+
+// poisson draws the number of events in one second at the given rate per second
+func (m *SyntheticMarket) poisson(rate float64) int {
+	if rate <= 0 {
+		return 0
+	}
+	limit := math.Exp(-rate)
+	count := 0
+	for p := m.rng.Float64(); p > limit; p *= m.rng.Float64() {
+		count++
+	}
+	return count
+}
+
+var (
+	syntheticBase58     = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	syntheticAdjectives = []string{"Based", "Tiny", "Giga", "Sleepy", "Angry", "Golden", "Turbo", "Cosmic", "Frozen", "Lucky", "Degen", "Wild"}
+	syntheticNouns      = []string{"Cat", "Frog", "Dog", "Moon", "Pepe", "Whale", "Rocket", "Banana", "Wizard", "Goblin", "Hamster", "Penguin"}
+)
+
+// address returns a random base58 string of the given length
+func (m *SyntheticMarket) address(length int) string {
+	var b strings.Builder
+	b.Grow(length)
+	for i := 0; i < length; i++ {
+		b.WriteByte(syntheticBase58[m.rng.Intn(len(syntheticBase58))])
+	}
+	return b.String()
+}
+
+// wallets returns count random wallet addresses
+func (m *SyntheticMarket) wallets(count int) []string {
+	wallets := make([]string, count)
+	for i := range wallets {
+		wallets[i] = m.address(44)
+	}
+	return wallets
+}
+
+// pick returns a random element of a wallet pool
+func (m *SyntheticMarket) pick(pool []string) string {
+	return pool[m.rng.Intn(len(pool))]
+}
+
+// tokenName returns a random meme token name and its symbol
+func (m *SyntheticMarket) tokenName() (string, string) {
+	adjective := syntheticAdjectives[m.rng.Intn(len(syntheticAdjectives))]
+	noun := syntheticNouns[m.rng.Intn(len(syntheticNouns))]
+	return adjective + " " + noun, strings.ToUpper(adjective[:1] + noun)
+}
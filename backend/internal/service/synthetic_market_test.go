@@ -0,0 +1,94 @@
+// internal/service/synthetic_market_test.go
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runSyntheticMarket collects the events of the first seconds of a seeded market
+func runSyntheticMarket(seed int64, regime string, seconds int) []SyntheticEvent {
+	m := NewSyntheticMarket(seed, SyntheticRegimes[regime], time.Unix(1700000000, 0))
+	var events []SyntheticEvent
+	for i := 0; i < seconds; i++ {
+		events = append(events, m.Tick()...)
+	}
+	return events
+}
+
+func TestSyntheticMarketDeterministic(t *testing.T) {
+	first := runSyntheticMarket(7, "normal", 600)
+	second := runSyntheticMarket(7, "normal", 600)
+	other := runSyntheticMarket(8, "normal", 600)
+
+	assert.NotEmpty(t, first)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
+
+func TestSyntheticMarketEvents(t *testing.T) {
+	events := runSyntheticMarket(1, "hot", 1800)
+
+	created := make(map[string]bool)
+	lastPrice := make(map[string]float64)
+	trades, buys := 0, 0
+	for _, event := range events {
+		mint := event.Data["mint"].(string)
+		assert.True(t, strings.HasSuffix(mint, "pump"))
+
+		switch event.Event {
+		case SyntheticEventTokenCreated:
+			assert.False(t, created[mint], "token created twice")
+			created[mint] = true
+			createdSec := int64(event.Data["created_timestamp"].(float64)) / 1000
+			assert.True(t, createdSec > 1700000000 && createdSec <= 1700001800)
+
+		case SyntheticEventTradeCreated:
+			assert.True(t, created[mint], "trade before token creation")
+			trades++
+
+			sol := event.Data["sol_amount"].(float64)
+			tokens := event.Data["token_amount"].(float64)
+			assert.Greater(t, sol, float64(0))
+			assert.Greater(t, tokens, float64(0))
+			assert.NotEmpty(t, event.Data["signature"])
+			assert.NotEmpty(t, event.Data["user"])
+
+			// Buys move the price up the curve and sells move it down
+			price := event.Data["virtual_sol_reserves"].(float64) / event.Data["virtual_token_reserves"].(float64)
+			if previous, ok := lastPrice[mint]; ok {
+				if event.Data["is_buy"].(bool) {
+					buys++
+					assert.Greater(t, price, previous)
+				} else {
+					assert.Less(t, price, previous)
+				}
+			}
+			lastPrice[mint] = price
+		}
+	}
+
+	assert.Greater(t, len(created), 100)
+	assert.Greater(t, trades, len(created))
+	assert.Greater(t, buys, 0)
+}
+
+func TestSyntheticEventFrame(t *testing.T) {
+	event := SyntheticEvent{
+		Event: SyntheticEventTradeCreated,
+		Data:  map[string]interface{}{"mint": "abcpump", "sol_amount": float64(1e9), "is_buy": true},
+	}
+
+	frame, err := event.Frame()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(frame), "42["))
+
+	var decoded []interface{}
+	assert.NoError(t, json.Unmarshal(frame[2:], &decoded))
+	assert.Equal(t, SyntheticEventTradeCreated, decoded[0])
+	assert.Equal(t, event.Data, decoded[1])
+}
@@ -0,0 +1,417 @@
+// internal/service/token_lifecycle_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/api/dto"
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket"
+)
+
+const (
+	// tokenAbandonAfter is how long a live token may go without trades before it is abandoned
+	tokenAbandonAfter = 1 * time.Hour
+
+	// tokenSweepInterval is how often the collector looks for abandoned tokens
+	tokenSweepInterval = 1 * time.Minute
+
+	// tokenSweepBatchSize is the number of stale tokens abandoned per query
+	tokenSweepBatchSize = 500
+
+	// tokenLifecycleIdleTTL is how long a lifecycle is cached without being touched
+	tokenLifecycleIdleTTL = 2 * time.Hour
+
+	// tokenTransitionPollInterval is how often the API reads new transitions
+	tokenTransitionPollInterval = 1 * time.Second
+
+	// tokenTransitionBatchSize is the number of transitions read per poll
+	tokenTransitionBatchSize = 500
+
+	// TokenTransitionSourceSweep is the source of transitions made by the abandon sweep
+	// rather than a Socket.IO event
+	TokenTransitionSourceSweep = "sweep"
+
+	// TokenLifecycleTopic is the WebSocket topic carrying every lifecycle transition
+	TokenLifecycleTopic = "lifecycle"
+)
+
+// allowedTokenTransitions lists the states each lifecycle state may move to
+var allowedTokenTransitions = map[string][]string{
+	"":                             {models.TokenStateCreated},
+	models.TokenStateCreated:       {models.TokenStateTrading, models.TokenStateKingOfTheHill, models.TokenStateGraduated, models.TokenStateAbandoned},
+	models.TokenStateTrading:       {models.TokenStateKingOfTheHill, models.TokenStateGraduated, models.TokenStateAbandoned},
+	models.TokenStateKingOfTheHill: {models.TokenStateGraduated, models.TokenStateAbandoned},
+	models.TokenStateAbandoned:     {models.TokenStateTrading, models.TokenStateKingOfTheHill, models.TokenStateGraduated},
+}
+
+// TokenLifecycleProvider returns the lifecycle of a token
+type TokenLifecycleProvider interface {
+	GetLifecycle(tokenID int64) (*models.TokenLifecycle, error)
+}
+
+// CanTransition reports whether a token may move from one lifecycle state to another
+func CanTransition(from, to string) bool {
+	for _, state := range allowedTokenTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextTokenStates returns the states a token moves through, in order, after an observation
+// of its current data. traded is true when the observation is a trade.
+func NextTokenStates(lifecycle *models.TokenLifecycle, token *models.Token, traded bool) []string {
+	state := lifecycle.State
+	var next []string
+	move := func(to string) {
+		if CanTransition(state, to) {
+			next = append(next, to)
+			state = to
+		}
+	}
+
+	if state == "" {
+		move(models.TokenStateCreated)
+	}
+	if traded && (state == models.TokenStateCreated || state == models.TokenStateAbandoned) {
+		move(models.TokenStateTrading)
+	}
+	if token.KingOfTheHillTimeStamp > 0 && lifecycle.KingOfTheHillAt == 0 {
+		move(models.TokenStateKingOfTheHill)
+	}
+	if token.Completed {
+		move(models.TokenStateGraduated)
+	}
+
+	return next
+}
+
+// buildTokenLifecycle replays stored transitions into a lifecycle
+func buildTokenLifecycle(tokenID int64, mint string, transitions []*models.TokenTransition) *models.TokenLifecycle {
+	lifecycle := &models.TokenLifecycle{
+		TokenID:     tokenID,
+		MintAddress: mint,
+		Transitions: make([]*models.TokenTransition, 0, len(transitions)),
+	}
+	for _, transition := range transitions {
+		lifecycle.Apply(transition)
+	}
+	return lifecycle
+}
+
+// copyTokenLifecycle returns a copy of a lifecycle that later transitions won't modify
+func copyTokenLifecycle(lifecycle *models.TokenLifecycle) *models.TokenLifecycle {
+	clone := *lifecycle
+	clone.Transitions = append([]*models.TokenTransition(nil), lifecycle.Transitions...)
+	return &clone
+}
+
+type cachedTokenLifecycle struct {
+	lifecycle  *models.TokenLifecycle
+	lastAccess time.Time
+}
+
+// TokenLifecycleService models each token as a lifecycle (created, trading, king of the
+// hill, graduated or abandoned). The collector records transitions with Observe and
+// StartSweeper; the API tails them with Start, broadcasts them over WebSocket and serves
+// lifecycles to strategies.
+type TokenLifecycleService struct {
+	transitionRepo repository.TokenTransitionRepositoryInterface
+	tokenRepo      repository.TokenRepositoryInterface
+	wsHub          *websocket.WSHub
+	logger         *logger.Logger
+	mu             sync.Mutex
+	lifecycles     map[int64]*cachedTokenLifecycle
+	lastID         int64
+}
+
+// NewTokenLifecycleService creates a new token lifecycle service
+func NewTokenLifecycleService(
+	transitionRepo repository.TokenTransitionRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	wsHub *websocket.WSHub,
+	logger *logger.Logger,
+) *TokenLifecycleService {
+	return &TokenLifecycleService{
+		transitionRepo: transitionRepo,
+		tokenRepo:      tokenRepo,
+		wsHub:          wsHub,
+		logger:         logger,
+		lifecycles:     make(map[int64]*cachedTokenLifecycle),
+	}
+}
+
+// GetLifecycle implements TokenLifecycleProvider
+func (s *TokenLifecycleService) GetLifecycle(tokenID int64) (*models.TokenLifecycle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lifecycle, err := s.lifecycleLocked(tokenID, "")
+	if err != nil {
+		return nil, err
+	}
+	return copyTokenLifecycle(lifecycle), nil
+}
+
+// GetLifecycleByMint returns the lifecycle of a token by mint address
+func (s *TokenLifecycleService) GetLifecycleByMint(mint string) (*models.TokenLifecycle, error) {
+	token, err := s.tokenRepo.GetByMintAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lifecycle, err := s.lifecycleLocked(token.ID, token.MintAddress)
+	if err != nil {
+		return nil, err
+	}
+	return copyTokenLifecycle(lifecycle), nil
+}
+
+// lifecycleLocked returns the cached lifecycle of a token, loading it from the database on
+// a miss; the caller must hold s.mu
+func (s *TokenLifecycleService) lifecycleLocked(tokenID int64, mint string) (*models.TokenLifecycle, error) {
+	if cached, ok := s.lifecycles[tokenID]; ok {
+		cached.lastAccess = time.Now()
+		return cached.lifecycle, nil
+	}
+
+	transitions, err := s.transitionRepo.GetByTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transitions) > 0 {
+		mint = transitions[0].MintAddress
+	}
+
+	lifecycle := buildTokenLifecycle(tokenID, mint, transitions)
+	s.lifecycles[tokenID] = &cachedTokenLifecycle{lifecycle: lifecycle, lastAccess: time.Now()}
+	return lifecycle, nil
+}
+
+// Observe records the lifecycle transitions implied by the latest data of a token. source
+// names the Socket.IO event the data came from and at is when it happened in unix seconds.
+func (s *TokenLifecycleService) Observe(token *models.Token, source string, traded bool, at int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lifecycle, err := s.lifecycleLocked(token.ID, token.MintAddress)
+	if err != nil {
+		return err
+	}
+
+	for _, state := range NextTokenStates(lifecycle, token, traded) {
+		timestamp := at
+		switch state {
+		case models.TokenStateCreated:
+			if token.CreatedTimestamp > 0 {
+				timestamp = token.CreatedTimestamp / 1000
+			}
+		case models.TokenStateKingOfTheHill:
+			timestamp = token.KingOfTheHillTimeStamp / 1000
+		}
+
+		if err := s.recordLocked(lifecycle, state, timestamp, source); err != nil {
+			return err
+		}
+		s.logger.Info("Token %s moved to %s (%s)", token.MintAddress, state, source)
+	}
+
+	return nil
+}
+
+// recordLocked stores a transition and applies it to the lifecycle; the caller must hold s.mu
+func (s *TokenLifecycleService) recordLocked(lifecycle *models.TokenLifecycle, state string, timestamp int64, source string) error {
+	transition := &models.TokenTransition{
+		TokenID:     lifecycle.TokenID,
+		MintAddress: lifecycle.MintAddress,
+		FromState:   lifecycle.State,
+		ToState:     state,
+		Timestamp:   timestamp,
+		Source:      source,
+	}
+
+	id, err := s.transitionRepo.Save(transition)
+	if err != nil {
+		return err
+	}
+	transition.ID = id
+	lifecycle.Apply(transition)
+
+	return nil
+}
+
+// StartSweeper periodically abandons live tokens that have stopped trading and drops
+// lifecycles nobody has touched recently
+func (s *TokenLifecycleService) StartSweeper(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(tokenSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Token lifecycle sweeper stopped")
+				return
+			case now := <-ticker.C:
+				count, err := s.sweep(ctx, now)
+				if err != nil {
+					s.logger.Error("Error abandoning stale tokens: %v", err)
+				} else if count > 0 {
+					s.logger.Info("Abandoned %d tokens without trades for %v", count, tokenAbandonAfter)
+				}
+				s.evictIdle(now)
+			}
+		}
+	}()
+}
+
+// sweep moves live tokens without trades since tokenAbandonAfter to abandoned
+func (s *TokenLifecycleService) sweep(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-tokenAbandonAfter).Unix()
+	abandoned := 0
+	for {
+		if ctx.Err() != nil {
+			return abandoned, ctx.Err()
+		}
+
+		stale, err := s.transitionRepo.GetStale(cutoff, tokenSweepBatchSize)
+		if err != nil {
+			return abandoned, err
+		}
+
+		s.mu.Lock()
+		for _, latest := range stale {
+			lifecycle, err := s.lifecycleLocked(latest.TokenID, latest.MintAddress)
+			if err != nil {
+				s.mu.Unlock()
+				return abandoned, err
+			}
+			if !CanTransition(lifecycle.State, models.TokenStateAbandoned) {
+				continue
+			}
+			if err := s.recordLocked(lifecycle, models.TokenStateAbandoned, now.Unix(), TokenTransitionSourceSweep); err != nil {
+				s.mu.Unlock()
+				return abandoned, err
+			}
+			abandoned++
+		}
+		s.mu.Unlock()
+
+		if len(stale) < tokenSweepBatchSize {
+			return abandoned, nil
+		}
+	}
+}
+
+// Start tails transitions recorded by the collector, keeps cached lifecycles current and
+// publishes each transition on the lifecycle topics
+func (s *TokenLifecycleService) Start(ctx context.Context) error {
+	lastID, err := s.transitionRepo.GetLastID()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.lastID = lastID
+	s.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(tokenTransitionPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Token lifecycle feed stopped")
+				return
+			case now := <-ticker.C:
+				s.poll(ctx)
+				s.evictIdle(now)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// poll applies and publishes all transitions recorded since the last poll
+func (s *TokenLifecycleService) poll(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.mu.Lock()
+		lastID := s.lastID
+		s.mu.Unlock()
+
+		transitions, err := s.transitionRepo.GetAfterID(lastID, tokenTransitionBatchSize)
+		if err != nil {
+			s.logger.Error("Error polling token transitions: %v", err)
+			return
+		}
+
+		for _, transition := range transitions {
+			s.mu.Lock()
+			if cached, ok := s.lifecycles[transition.TokenID]; ok {
+				// A lifecycle loaded after the transition was written already contains it
+				applied := cached.lifecycle.Transitions
+				if len(applied) == 0 || applied[len(applied)-1].ID < transition.ID {
+					cached.lifecycle.Apply(transition)
+				}
+			}
+			s.lastID = transition.ID
+			s.mu.Unlock()
+
+			s.publish(transition)
+		}
+
+		if len(transitions) < tokenTransitionBatchSize {
+			return
+		}
+	}
+}
+
+// publish sends a transition to clients subscribed to all transitions or to the token
+func (s *TokenLifecycleService) publish(transition *models.TokenTransition) {
+	if s.wsHub == nil {
+		return
+	}
+
+	for _, topic := range []string{TokenLifecycleTopic, TokenLifecycleMintTopic(transition.MintAddress)} {
+		s.wsHub.BroadcastTopic(topic, dto.TokenTransitionEvent{
+			Type:       "token_transition",
+			Topic:      topic,
+			Transition: transition,
+		})
+	}
+}
+
+// TokenLifecycleMintTopic returns the WebSocket topic carrying one token's transitions
+func TokenLifecycleMintTopic(mint string) string {
+	return fmt.Sprintf("%s:%s", TokenLifecycleTopic, mint)
+}
+
+// evictIdle drops cached lifecycles nobody has touched recently
+func (s *TokenLifecycleService) evictIdle(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for tokenID, cached := range s.lifecycles {
+		if now.Sub(cached.lastAccess) > tokenLifecycleIdleTTL {
+			delete(s.lifecycles, tokenID)
+		}
+	}
+}
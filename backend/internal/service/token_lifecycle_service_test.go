@@ -0,0 +1,173 @@
+// internal/service/token_lifecycle_service_test.go
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTokenTransitionRepository is a mock implementation of token transition repository
+type MockTokenTransitionRepository struct {
+	mock.Mock
+}
+
+// Ensure MockTokenTransitionRepository implements the TokenTransitionRepositoryInterface
+var _ repository.TokenTransitionRepositoryInterface = (*MockTokenTransitionRepository)(nil)
+
+func (m *MockTokenTransitionRepository) Save(transition *models.TokenTransition) (int64, error) {
+	args := m.Called(transition)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTokenTransitionRepository) GetByTokenID(tokenID int64) ([]*models.TokenTransition, error) {
+	args := m.Called(tokenID)
+	return args.Get(0).([]*models.TokenTransition), args.Error(1)
+}
+
+func (m *MockTokenTransitionRepository) GetAfterID(afterID int64, limit int) ([]*models.TokenTransition, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]*models.TokenTransition), args.Error(1)
+}
+
+func (m *MockTokenTransitionRepository) GetLastID() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTokenTransitionRepository) GetStale(cutoff int64, limit int) ([]*models.TokenTransition, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).([]*models.TokenTransition), args.Error(1)
+}
+
+func TestNextTokenStates(t *testing.T) {
+	tests := []struct {
+		name      string
+		lifecycle *models.TokenLifecycle
+		token     *models.Token
+		traded    bool
+		expected  []string
+	}{
+		{
+			name:      "New token",
+			lifecycle: &models.TokenLifecycle{},
+			token:     &models.Token{},
+			expected:  []string{models.TokenStateCreated},
+		},
+		{
+			name:      "First trade",
+			lifecycle: &models.TokenLifecycle{State: models.TokenStateCreated},
+			token:     &models.Token{},
+			traded:    true,
+			expected:  []string{models.TokenStateTrading},
+		},
+		{
+			name:      "Unseen token graduating on its first trade",
+			lifecycle: &models.TokenLifecycle{},
+			token:     &models.Token{KingOfTheHillTimeStamp: 1700000000000, Completed: true},
+			traded:    true,
+			expected: []string{
+				models.TokenStateCreated,
+				models.TokenStateTrading,
+				models.TokenStateKingOfTheHill,
+				models.TokenStateGraduated,
+			},
+		},
+		{
+			name:      "King of the hill is only entered once",
+			lifecycle: &models.TokenLifecycle{State: models.TokenStateTrading, KingOfTheHillAt: 1700000000},
+			token:     &models.Token{KingOfTheHillTimeStamp: 1700000000000},
+			traded:    true,
+			expected:  nil,
+		},
+		{
+			name:      "Abandoned token trades again",
+			lifecycle: &models.TokenLifecycle{State: models.TokenStateAbandoned},
+			token:     &models.Token{},
+			traded:    true,
+			expected:  []string{models.TokenStateTrading},
+		},
+		{
+			name:      "Graduated is final",
+			lifecycle: &models.TokenLifecycle{State: models.TokenStateGraduated},
+			token:     &models.Token{Completed: true},
+			traded:    true,
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NextTokenStates(tt.lifecycle, tt.token, tt.traded))
+		})
+	}
+
+	assert.False(t, CanTransition(models.TokenStateKingOfTheHill, models.TokenStateTrading))
+	assert.False(t, CanTransition(models.TokenStateGraduated, models.TokenStateAbandoned))
+}
+
+func TestTokenLifecycleServiceObserve(t *testing.T) {
+	transitionRepo := new(MockTokenTransitionRepository)
+	s := NewTokenLifecycleService(transitionRepo, nil, nil, logger.New("test"))
+
+	token := &models.Token{ID: 7, MintAddress: "mint1", CreatedTimestamp: 1700000000000}
+	transitionRepo.On("GetByTokenID", int64(7)).Return([]*models.TokenTransition{}, nil).Once()
+	transitionRepo.On("Save", mock.AnythingOfType("*models.TokenTransition")).Return(int64(1), nil).Once()
+	transitionRepo.On("Save", mock.AnythingOfType("*models.TokenTransition")).Return(int64(2), nil).Once()
+
+	assert.NoError(t, s.Observe(token, "tradeCreated", true, 1700000030))
+
+	lifecycle, err := s.GetLifecycle(7)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TokenStateTrading, lifecycle.State)
+	assert.Equal(t, int64(1700000030), lifecycle.StateSince)
+	assert.Len(t, lifecycle.Transitions, 2)
+	assert.Equal(t, int64(1700000000), lifecycle.Transitions[0].Timestamp)
+	assert.Equal(t, models.TokenStateTrading, lifecycle.Transitions[1].ToState)
+	assert.Equal(t, models.TokenStateCreated, lifecycle.Transitions[1].FromState)
+
+	// Repeated trades don't record anything new
+	assert.NoError(t, s.Observe(token, "tradeCreated", true, 1700000040))
+
+	token.KingOfTheHillTimeStamp = 1700000050000
+	token.Completed = true
+	transitionRepo.On("Save", mock.AnythingOfType("*models.TokenTransition")).Return(int64(3), nil).Once()
+	transitionRepo.On("Save", mock.AnythingOfType("*models.TokenTransition")).Return(int64(4), nil).Once()
+	assert.NoError(t, s.Observe(token, "tokenUpdate", false, 1700000060))
+
+	lifecycle, err = s.GetLifecycle(7)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TokenStateGraduated, lifecycle.State)
+	assert.Equal(t, int64(1700000050), lifecycle.KingOfTheHillAt)
+	assert.Equal(t, int64(1700000060), lifecycle.GraduatedAt)
+	assert.Len(t, lifecycle.Transitions, 4)
+
+	transitionRepo.AssertExpectations(t)
+}
+
+func TestAnalyzeEntrySignalKingOfTheHill(t *testing.T) {
+	s := &SimulationService{logger: logger.New("test")}
+	ctx := &SimulationContext{Config: models.StrategyConfig{
+		EntryTimeWindowSec: 60,
+		EntrySignalType:    models.EntrySignalKingOfTheHill,
+	}}
+	now := time.Now().Unix()
+
+	entry, _ := s.analyzeEntrySignal(ctx, &models.Token{}, nil, nil, nil, nil, nil)
+	assert.False(t, entry)
+
+	lifecycle := &models.TokenLifecycle{State: models.TokenStateKingOfTheHill, KingOfTheHillAt: now - 10}
+	entry, data := s.analyzeEntrySignal(ctx, &models.Token{}, nil, nil, nil, lifecycle, nil)
+	assert.True(t, entry)
+	assert.Equal(t, models.TokenStateKingOfTheHill, data["lifecycle_state"])
+
+	// Crowned too long ago to still be a fresh signal
+	lifecycle.KingOfTheHillAt = now - 600
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, nil, nil, nil, lifecycle, nil)
+	assert.False(t, entry)
+}
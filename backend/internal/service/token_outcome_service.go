@@ -0,0 +1,297 @@
+// internal/service/token_outcome_service.go
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// outcomeHorizonSec is how long after launch a token's outcome is measured
+	outcomeHorizonSec = 30 * 60
+
+	// outcomeTradeLimit caps the trades loaded for one token
+	outcomeTradeLimit = 10000
+
+	// outcomeLabelInterval is how often tokens whose horizon has passed are labeled
+	outcomeLabelInterval = 1 * time.Minute
+
+	// outcomeLabelLookback bounds how far back the periodic run looks for unlabeled tokens
+	outcomeLabelLookback = 2 * time.Hour
+
+	// outcomeBatchSize is the number of tokens labeled, or dataset rows exported, per page
+	outcomeBatchSize = 500
+)
+
+// TokenOutcomeService labels what happened to each token after launch, so strategy
+// decisions and their feature snapshots can be joined with ground truth for research
+type TokenOutcomeService struct {
+	outcomeRepo repository.TokenOutcomeRepositoryInterface
+	logger      *logger.Logger
+}
+
+// NewTokenOutcomeService creates a new token outcome service
+func NewTokenOutcomeService(outcomeRepo repository.TokenOutcomeRepositoryInterface, logger *logger.Logger) *TokenOutcomeService {
+	return &TokenOutcomeService{
+		outcomeRepo: outcomeRepo,
+		logger:      logger,
+	}
+}
+
+// Label computes and stores the outcome of a token whose horizon has passed
+func (s *TokenOutcomeService) Label(token *models.Token) (*models.TokenOutcome, error) {
+	launch := launchStartSec(token)
+	trades, err := s.outcomeRepo.GetOutcomeTrades(token.ID, launch, launch+outcomeHorizonSec, outcomeTradeLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	graduatedAt, err := s.outcomeRepo.GetGraduatedAt(token.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := ComputeTokenOutcome(token, trades, graduatedAt)
+	if err := s.outcomeRepo.Upsert(outcome); err != nil {
+		return nil, err
+	}
+
+	return outcome, nil
+}
+
+// Start periodically labels recent tokens whose outcome horizon has passed
+func (s *TokenOutcomeService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(outcomeLabelInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Token outcome service stopped")
+				return
+			case now := <-ticker.C:
+				from := now.Add(-outcomeLabelLookback).UnixMilli()
+				to := now.Add(-outcomeHorizonSec * time.Second).UnixMilli()
+				count, err := s.labelRange(ctx, from, to)
+				if err != nil {
+					s.logger.Error("Error labeling token outcomes: %v", err)
+				} else if count > 0 {
+					s.logger.Debug("Labeled %d token outcomes", count)
+				}
+			}
+		}
+	}()
+}
+
+// Backfill labels every token created at or after sinceMs whose horizon has passed and
+// returns the number of outcomes written
+func (s *TokenOutcomeService) Backfill(ctx context.Context, sinceMs int64) (int, error) {
+	return s.labelRange(ctx, sinceMs, time.Now().Add(-outcomeHorizonSec*time.Second).UnixMilli())
+}
+
+// labelRange labels tokens created between fromMs and toMs that have no stored outcome
+func (s *TokenOutcomeService) labelRange(ctx context.Context, fromMs, toMs int64) (int, error) {
+	written := 0
+	for {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		tokens, err := s.outcomeRepo.GetUnlabeledTokens(fromMs, toMs, outcomeBatchSize)
+		if err != nil {
+			return written, err
+		}
+
+		for _, token := range tokens {
+			if _, err := s.Label(token); err != nil {
+				s.logger.Error("Error labeling outcome of %s: %v", token.MintAddress, err)
+				continue
+			}
+			written++
+		}
+
+		if len(tokens) < outcomeBatchSize {
+			return written, nil
+		}
+		fromMs = tokens[len(tokens)-1].CreatedTimestamp + 1
+	}
+}
+
+// ComputeTokenOutcome measures the maximum return within 1, 5 and 30 minutes of launch and
+// the maximum drawdown within 30 minutes, relative to the first trade's price. trades must
+// be in execution order; graduatedAt is the recorded graduation time in unix seconds or 0.
+func ComputeTokenOutcome(token *models.Token, trades []*models.Trade, graduatedAt int64) *models.TokenOutcome {
+	launch := launchStartSec(token)
+	outcome := &models.TokenOutcome{
+		TokenID:         token.ID,
+		MintAddress:     token.MintAddress,
+		LaunchTimestamp: launch,
+		Graduated:       token.Completed || graduatedAt > 0,
+	}
+	if graduatedAt > 0 {
+		timeToGraduate := graduatedAt - launch
+		if timeToGraduate < 0 {
+			timeToGraduate = 0
+		}
+		outcome.TimeToGraduateSec = &timeToGraduate
+	}
+
+	var peak float64
+	for _, trade := range trades {
+		elapsed := trade.Timestamp - launch
+		if elapsed < 0 || elapsed > outcomeHorizonSec {
+			continue
+		}
+		outcome.TradeCount++
+
+		price := tradePrice(trade)
+		if price <= 0 {
+			continue
+		}
+		if outcome.BasePrice == 0 {
+			outcome.BasePrice = price
+			peak = price
+		}
+
+		ret := (price/outcome.BasePrice - 1) * 100
+		if elapsed <= 60 && ret > outcome.MaxReturn1mPct {
+			outcome.MaxReturn1mPct = ret
+		}
+		if elapsed <= 5*60 && ret > outcome.MaxReturn5mPct {
+			outcome.MaxReturn5mPct = ret
+		}
+		if ret > outcome.MaxReturn30mPct {
+			outcome.MaxReturn30mPct = ret
+		}
+
+		if price > peak {
+			peak = price
+		}
+		if drawdown := (peak - price) / peak * 100; drawdown > outcome.MaxDrawdownPct {
+			outcome.MaxDrawdownPct = drawdown
+		}
+	}
+
+	return outcome
+}
+
+// ExportDataset writes feature snapshots taken between from and to, joined with their
+// tokens' outcome labels, to w as CSV and returns the number of rows written
+func (s *TokenOutcomeService) ExportDataset(ctx context.Context, w io.Writer, from, to time.Time) (int, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(datasetHeader()); err != nil {
+		return 0, fmt.Errorf("error writing dataset header: %v", err)
+	}
+
+	written := 0
+	afterID := int64(0)
+	for {
+		if ctx.Err() != nil {
+			return written, ctx.Err()
+		}
+
+		rows, err := s.outcomeRepo.GetLabeledSnapshots(from, to, afterID, outcomeBatchSize)
+		if err != nil {
+			return written, err
+		}
+
+		for _, row := range rows {
+			if err := writer.Write(datasetRecord(row)); err != nil {
+				return written, fmt.Errorf("error writing dataset row: %v", err)
+			}
+			written++
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return written, fmt.Errorf("error writing dataset: %v", err)
+		}
+
+		if len(rows) < outcomeBatchSize {
+			return written, nil
+		}
+		afterID = rows[len(rows)-1].Snapshot.ID
+	}
+}
+
+// datasetHeader returns the column names of the dataset
+func datasetHeader() []string {
+	header := []string{
+		"snapshot_id", "token_id", "mint", "strategy_id", "simulation_run_id", "simulated_trade_id",
+		"decision", "snapshot_time", "seconds_since_launch", "last_price", "seconds_since_last_trade",
+	}
+	for _, windowSec := range models.FeatureWindows {
+		for _, column := range windowFeatureColumns {
+			header = append(header, featureColumnName(column, windowSec))
+		}
+	}
+	return append(header,
+		"max_return_1m_pct", "max_return_5m_pct", "max_return_30m_pct", "max_drawdown_pct",
+		"graduated", "time_to_graduate_sec",
+	)
+}
+
+// datasetRecord returns the CSV fields of one labeled snapshot; missing values are empty
+func datasetRecord(row *models.LabeledFeatureSnapshot) []string {
+	snapshot, outcome := row.Snapshot, row.Outcome
+	features := snapshot.Features
+	if features == nil {
+		features = &models.TokenFeatures{}
+	}
+	snapshotTime := snapshot.CreatedAt.Unix()
+
+	record := []string{
+		formatInt(snapshot.ID),
+		formatInt(snapshot.TokenID),
+		outcome.MintAddress,
+		formatOptionalInt(snapshot.StrategyID),
+		formatOptionalInt(snapshot.SimulationRunID),
+		formatOptionalInt(snapshot.SimulatedTradeID),
+		snapshot.Decision,
+		formatInt(snapshotTime),
+		formatInt(snapshotTime - outcome.LaunchTimestamp),
+		formatFloat(features.LastPrice),
+		formatInt(features.SecondsSinceLastTrade),
+	}
+	for _, windowSec := range models.FeatureWindows {
+		window := features.Window(windowSec)
+		for _, column := range windowFeatureColumns {
+			if window == nil {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, formatFloat(windowFeatureValues[column](window)))
+		}
+	}
+	return append(record,
+		formatFloat(outcome.MaxReturn1mPct),
+		formatFloat(outcome.MaxReturn5mPct),
+		formatFloat(outcome.MaxReturn30mPct),
+		formatFloat(outcome.MaxDrawdownPct),
+		strconv.FormatBool(outcome.Graduated),
+		formatOptionalInt(outcome.TimeToGraduateSec),
+	)
+}
+
+func formatInt(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func formatOptionalInt(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,145 @@
+// internal/service/token_outcome_service_test.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTokenOutcomeRepository is a mock implementation of token outcome repository
+type MockTokenOutcomeRepository struct {
+	mock.Mock
+}
+
+// Ensure MockTokenOutcomeRepository implements the TokenOutcomeRepositoryInterface
+var _ repository.TokenOutcomeRepositoryInterface = (*MockTokenOutcomeRepository)(nil)
+
+func (m *MockTokenOutcomeRepository) Upsert(outcome *models.TokenOutcome) error {
+	args := m.Called(outcome)
+	return args.Error(0)
+}
+
+func (m *MockTokenOutcomeRepository) GetUnlabeledTokens(fromMs, toMs int64, limit int) ([]*models.Token, error) {
+	args := m.Called(fromMs, toMs, limit)
+	return args.Get(0).([]*models.Token), args.Error(1)
+}
+
+func (m *MockTokenOutcomeRepository) GetOutcomeTrades(tokenID int64, fromSec, untilSec int64, limit int) ([]*models.Trade, error) {
+	args := m.Called(tokenID, fromSec, untilSec, limit)
+	return args.Get(0).([]*models.Trade), args.Error(1)
+}
+
+func (m *MockTokenOutcomeRepository) GetGraduatedAt(tokenID int64) (int64, error) {
+	args := m.Called(tokenID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTokenOutcomeRepository) GetLabeledSnapshots(from, to time.Time, afterID int64, limit int) ([]*models.LabeledFeatureSnapshot, error) {
+	args := m.Called(from, to, afterID, limit)
+	return args.Get(0).([]*models.LabeledFeatureSnapshot), args.Error(1)
+}
+
+func TestComputeTokenOutcome(t *testing.T) {
+	launch := int64(1700000000)
+	token := &models.Token{ID: 1, MintAddress: "mint1", CreatedTimestamp: launch * 1000}
+	trades := []*models.Trade{
+		{SolAmount: 1, TokenAmount: 1000, Timestamp: launch + 2},    // 0.001 base price
+		{SolAmount: 1.5, TokenAmount: 1000, Timestamp: launch + 30}, // +50%
+		{SolAmount: 0.9, TokenAmount: 1000, Timestamp: launch + 90}, // -40% from peak
+		{SolAmount: 3, TokenAmount: 1000, Timestamp: launch + 240},  // +200%
+		{SolAmount: 5, TokenAmount: 1000, Timestamp: launch + 1200}, // +400%
+		{SolAmount: 9, TokenAmount: 1000, Timestamp: launch + 2000}, // Past the horizon
+	}
+
+	outcome := ComputeTokenOutcome(token, trades, launch+1500)
+	assert.Equal(t, launch, outcome.LaunchTimestamp)
+	assert.Equal(t, 5, outcome.TradeCount)
+	assert.InDelta(t, 0.001, outcome.BasePrice, 1e-12)
+	assert.InDelta(t, 50.0, outcome.MaxReturn1mPct, 1e-9)
+	assert.InDelta(t, 200.0, outcome.MaxReturn5mPct, 1e-9)
+	assert.InDelta(t, 400.0, outcome.MaxReturn30mPct, 1e-9)
+	assert.InDelta(t, 40.0, outcome.MaxDrawdownPct, 1e-9)
+	assert.True(t, outcome.Graduated)
+	assert.Equal(t, int64(1500), *outcome.TimeToGraduateSec)
+
+	// Completed without a recorded transition still counts as graduated
+	token.Completed = true
+	outcome = ComputeTokenOutcome(token, nil, 0)
+	assert.True(t, outcome.Graduated)
+	assert.Nil(t, outcome.TimeToGraduateSec)
+	assert.Zero(t, outcome.TradeCount)
+	assert.Zero(t, outcome.MaxReturn30mPct)
+}
+
+func TestExportDataset(t *testing.T) {
+	outcomeRepo := new(MockTokenOutcomeRepository)
+	s := NewTokenOutcomeService(outcomeRepo, logger.New("test"))
+
+	from := time.Unix(1700000000, 0)
+	to := time.Unix(1700086400, 0)
+	strategyID := int64(3)
+	timeToGraduate := int64(600)
+	features := ComputeTokenFeatures([]*models.Trade{
+		{ID: 1, UserAddress: "w1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: 1700000110},
+	}, 1700000120)
+
+	outcomeRepo.On("GetLabeledSnapshots", from, to, int64(0), outcomeBatchSize).Return([]*models.LabeledFeatureSnapshot{
+		{
+			Snapshot: &models.TokenFeatureSnapshot{
+				ID:         7,
+				TokenID:    1,
+				StrategyID: &strategyID,
+				Decision:   models.FeatureDecisionEntry,
+				Features:   features,
+				CreatedAt:  time.Unix(1700000120, 0),
+			},
+			Outcome: &models.TokenOutcome{
+				TokenID:           1,
+				MintAddress:       "mint1",
+				LaunchTimestamp:   1700000000,
+				MaxReturn1mPct:    12.5,
+				Graduated:         true,
+				TimeToGraduateSec: &timeToGraduate,
+			},
+		},
+	}, nil).Once()
+
+	var buf bytes.Buffer
+	written, err := s.ExportDataset(context.Background(), &buf, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, written)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	header, row := records[0], records[1]
+	assert.Len(t, row, len(header))
+	values := make(map[string]string)
+	for i, column := range header {
+		values[column] = row[i]
+	}
+	assert.Equal(t, "7", values["snapshot_id"])
+	assert.Equal(t, "mint1", values["mint"])
+	assert.Equal(t, "3", values["strategy_id"])
+	assert.Equal(t, "", values["simulation_run_id"])
+	assert.Equal(t, "entry", values["decision"])
+	assert.Equal(t, "120", values["seconds_since_launch"])
+	assert.Equal(t, "10", values["seconds_since_last_trade"])
+	assert.Equal(t, "1", values["buy_count_10s"])
+	assert.Equal(t, "1", values["buy_volume_sol_300s"])
+	assert.Equal(t, "12.5", values["max_return_1m_pct"])
+	assert.Equal(t, "true", values["graduated"])
+	assert.Equal(t, "600", values["time_to_graduate_sec"])
+
+	outcomeRepo.AssertExpectations(t)
+}
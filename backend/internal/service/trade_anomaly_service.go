@@ -0,0 +1,414 @@
+// internal/service/trade_anomaly_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// anomalyWindowTrades is the number of most recent trades per token the detector looks at
+	anomalyWindowTrades = 500
+
+	// anomalySelfTradeSec is how soon a sell must follow a buy of the same size to count
+	// as a self-trade
+	anomalySelfTradeSec = 10
+
+	// anomalySelfTradeTolerance is how far apart the token amounts of a self-trade pair may be
+	anomalySelfTradeTolerance = 0.01
+
+	// anomalyRoundTripFraction is the share of a wallet's peak balance it must sell for the
+	// position to count as a completed round trip
+	anomalyRoundTripFraction = 0.9
+
+	// anomalyRoundTripMin is the number of round trips that flags a wallet as churning
+	anomalyRoundTripMin = 2
+
+	// anomalyDustFraction is the share of the median buy size below which a buy is dust
+	anomalyDustFraction = 0.05
+
+	// anomalyDustMinBuys is the number of buys a wallet needs before it can be flagged for dust
+	anomalyDustMinBuys = 3
+
+	// anomalyPeriodicMinTrades is the number of trades a wallet needs before its timing is judged
+	anomalyPeriodicMinTrades = 5
+
+	// anomalyPeriodicMaxCV is the highest coefficient of variation of a wallet's trade
+	// intervals that still counts as bot-like
+	anomalyPeriodicMaxCV = 0.1
+
+	// anomalyFlushInterval is how often updated anomaly scores are persisted
+	anomalyFlushInterval = 30 * time.Second
+
+	// anomalyIdleTTL is how long a token's trade window is kept without new trades or queries
+	anomalyIdleTTL = 30 * time.Minute
+)
+
+// TradeAnomalyProvider returns the wash-trading and bot activity detected in a token's trades
+type TradeAnomalyProvider interface {
+	GetTokenAnomalies(token *models.Token) (*models.TokenAnomalies, error)
+}
+
+// tokenTradeWindow holds the most recent trades of one token
+type tokenTradeWindow struct {
+	tokenID     int64
+	mint        string
+	trades      []*models.Trade
+	lastTradeID int64
+	seeded      bool                   // Whether stored trades have been loaded
+	dirty       bool                   // Whether trades arrived since the scores were last persisted
+	anomalies   *models.TokenAnomalies // Detection over the current trades, nil when stale
+	lastAccess  time.Time
+}
+
+// add appends a trade the window has not seen yet and drops the oldest beyond the limit
+func (w *tokenTradeWindow) add(trade *models.Trade) {
+	if trade.ID != 0 && trade.ID <= w.lastTradeID {
+		return
+	}
+
+	w.trades = append(w.trades, trade)
+	if len(w.trades) > anomalyWindowTrades {
+		w.trades = w.trades[len(w.trades)-anomalyWindowTrades:]
+	}
+	if trade.ID > w.lastTradeID {
+		w.lastTradeID = trade.ID
+	}
+	w.dirty = true
+	w.anomalies = nil
+	w.lastAccess = time.Now()
+}
+
+// detect returns the anomalies of the current trades, reusing the last result when
+// no trades arrived since
+func (w *tokenTradeWindow) detect() *models.TokenAnomalies {
+	if w.anomalies == nil {
+		w.anomalies = DetectTradeAnomalies(w.trades)
+		w.anomalies.TokenID = w.tokenID
+		w.anomalies.MintAddress = w.mint
+	}
+	return w.anomalies
+}
+
+// TradeAnomalyService watches the trade feed for self-trading, round-trip churning, dust
+// buys and bot-like timing, and persists per-token anomaly scores
+type TradeAnomalyService struct {
+	anomalyRepo repository.TokenAnomalyRepositoryInterface
+	tradeRepo   repository.TradeRepositoryInterface
+	tokenRepo   repository.TokenRepositoryInterface
+	logger      *logger.Logger
+	mu          sync.Mutex
+	windows     map[int64]*tokenTradeWindow
+}
+
+// NewTradeAnomalyService creates a new trade anomaly service
+func NewTradeAnomalyService(
+	anomalyRepo repository.TokenAnomalyRepositoryInterface,
+	tradeRepo repository.TradeRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	logger *logger.Logger,
+) *TradeAnomalyService {
+	return &TradeAnomalyService{
+		anomalyRepo: anomalyRepo,
+		tradeRepo:   tradeRepo,
+		tokenRepo:   tokenRepo,
+		logger:      logger,
+		windows:     make(map[int64]*tokenTradeWindow),
+	}
+}
+
+// OnTrade implements TradeObserver
+func (s *TradeAnomalyService) OnTrade(trade *models.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, ok := s.windows[trade.TokenID]
+	if !ok {
+		window = &tokenTradeWindow{tokenID: trade.TokenID, mint: trade.MintAddress}
+		s.windows[trade.TokenID] = window
+	}
+	window.add(trade)
+}
+
+// GetTokenAnomalies implements TradeAnomalyProvider. The first request for a token loads
+// its stored trades so the detector also sees activity from before the feed started.
+func (s *TradeAnomalyService) GetTokenAnomalies(token *models.Token) (*models.TokenAnomalies, error) {
+	s.mu.Lock()
+	window, ok := s.windows[token.ID]
+	if ok && window.seeded {
+		window.lastAccess = time.Now()
+		anomalies := window.detect()
+		s.mu.Unlock()
+		return anomalies, nil
+	}
+	s.mu.Unlock()
+
+	// Stored trades come newest first
+	stored, err := s.tradeRepo.GetTradesByTokenID(token.ID, anomalyWindowTrades)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, ok = s.windows[token.ID]
+	if ok && window.seeded {
+		window.lastAccess = time.Now()
+		return window.detect(), nil
+	}
+
+	seeded := &tokenTradeWindow{tokenID: token.ID, mint: token.MintAddress, seeded: true}
+	for i := len(stored) - 1; i >= 0; i-- {
+		seeded.add(stored[i])
+	}
+	// Keep live trades the stored query did not return yet
+	if ok {
+		for _, trade := range window.trades {
+			seeded.add(trade)
+		}
+	}
+	seeded.lastAccess = time.Now()
+	s.windows[token.ID] = seeded
+
+	return seeded.detect(), nil
+}
+
+// GetTokenAnomaliesByMint returns the anomalies detected in a token's trades by mint address
+func (s *TradeAnomalyService) GetTokenAnomaliesByMint(mint string) (*models.TokenAnomalies, error) {
+	token, err := s.tokenRepo.GetByMintAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token: %v", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token not found")
+	}
+	return s.GetTokenAnomalies(token)
+}
+
+// Start periodically persists the scores of tokens that received trades and evicts
+// windows of tokens that went quiet
+func (s *TradeAnomalyService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(anomalyFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.flush(time.Now())
+				s.logger.Info("Trade anomaly service stopped")
+				return
+			case now := <-ticker.C:
+				s.flush(now)
+			}
+		}
+	}()
+}
+
+// flush persists the scores of updated tokens and drops idle windows
+func (s *TradeAnomalyService) flush(now time.Time) {
+	s.mu.Lock()
+	var updated []*models.TokenAnomalies
+	for tokenID, window := range s.windows {
+		if window.dirty {
+			updated = append(updated, window.detect())
+			window.dirty = false
+		}
+		if now.Sub(window.lastAccess) > anomalyIdleTTL {
+			delete(s.windows, tokenID)
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.anomalyRepo.UpsertBatch(updated); err != nil {
+		s.logger.Error("Error saving anomalies of %d tokens: %v", len(updated), err)
+	}
+}
+
+// walletActivity collects one wallet's trades for anomaly detection
+type walletActivity struct {
+	trades    []*models.Trade
+	buyCount  int
+	dustCount int
+}
+
+// DetectTradeAnomalies flags wallets whose trading looks manufactured:
+//   - self_trade: a sell of the same size follows a buy within seconds
+//   - round_trip: the wallet repeatedly buys and then sells out again
+//   - dust: most of the wallet's buys are far below the token's median buy size
+//   - periodic: the wallet trades at near-constant intervals
+//
+// The anomaly score is the average of the share of buys and the share of buy volume
+// coming from flagged wallets or dust buys.
+func DetectTradeAnomalies(trades []*models.Trade) *models.TokenAnomalies {
+	sorted := make([]*models.Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Timestamp != sorted[j].Timestamp {
+			return sorted[i].Timestamp < sorted[j].Timestamp
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	anomalies := &models.TokenAnomalies{
+		TradeCount:     len(sorted),
+		FlaggedWallets: make(map[string][]string),
+		UpdatedAt:      time.Now(),
+	}
+
+	var buySizes []float64
+	for _, trade := range sorted {
+		if trade.IsBuy && trade.SolAmount > 0 {
+			buySizes = append(buySizes, trade.SolAmount)
+		}
+	}
+	if len(buySizes) > 0 {
+		sort.Float64s(buySizes)
+		anomalies.DustThresholdSol = buySizes[len(buySizes)/2] * anomalyDustFraction
+	}
+
+	wallets := make(map[string]*walletActivity)
+	for _, trade := range sorted {
+		activity, ok := wallets[trade.UserAddress]
+		if !ok {
+			activity = &walletActivity{}
+			wallets[trade.UserAddress] = activity
+		}
+		activity.trades = append(activity.trades, trade)
+		if trade.IsBuy {
+			anomalies.BuyCount++
+			activity.buyCount++
+			if trade.SolAmount < anomalies.DustThresholdSol {
+				anomalies.DustBuyCount++
+				activity.dustCount++
+			}
+		}
+	}
+
+	for wallet, activity := range wallets {
+		var flags []string
+
+		if pairs := countSelfTrades(activity.trades); pairs > 0 {
+			anomalies.SelfTradeCount += pairs
+			flags = append(flags, models.AnomalySelfTrade)
+		}
+		if countRoundTrips(activity.trades) >= anomalyRoundTripMin {
+			anomalies.RoundTripWallets++
+			flags = append(flags, models.AnomalyRoundTrip)
+		}
+		if activity.buyCount >= anomalyDustMinBuys && activity.dustCount*2 > activity.buyCount {
+			flags = append(flags, models.AnomalyDust)
+		}
+		if isPeriodic(activity.trades) {
+			anomalies.PeriodicWallets++
+			flags = append(flags, models.AnomalyPeriodic)
+		}
+
+		if len(flags) > 0 {
+			anomalies.FlaggedWallets[wallet] = flags
+		}
+	}
+	anomalies.FlaggedWalletCount = len(anomalies.FlaggedWallets)
+
+	var flaggedBuys int
+	var buyVolume, flaggedVolume float64
+	for _, trade := range sorted {
+		if !trade.IsBuy {
+			continue
+		}
+		buyVolume += trade.SolAmount
+		if !anomalies.IsOrganicBuy(trade) {
+			flaggedBuys++
+			flaggedVolume += trade.SolAmount
+		}
+	}
+	if anomalies.BuyCount > 0 {
+		anomalies.FlaggedBuyPct = float64(flaggedBuys) / float64(anomalies.BuyCount) * 100
+	}
+	if buyVolume > 0 {
+		anomalies.FlaggedVolumePct = flaggedVolume / buyVolume * 100
+	}
+	anomalies.AnomalyScore = (anomalies.FlaggedBuyPct + anomalies.FlaggedVolumePct) / 2
+
+	return anomalies
+}
+
+// countSelfTrades matches sells to earlier buys of the same size from the same wallet
+// within anomalySelfTradeSec and returns the number of matched pairs
+func countSelfTrades(trades []*models.Trade) int {
+	pairs := 0
+	var open []*models.Trade
+	for _, trade := range trades {
+		if trade.IsBuy {
+			open = append(open, trade)
+			continue
+		}
+		for i, buy := range open {
+			if trade.Timestamp-buy.Timestamp > anomalySelfTradeSec {
+				continue
+			}
+			if math.Abs(buy.TokenAmount-trade.TokenAmount) <= buy.TokenAmount*anomalySelfTradeTolerance {
+				pairs++
+				open = append(open[:i], open[i+1:]...)
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// countRoundTrips returns how many times a wallet built a position and sold nearly all of it
+func countRoundTrips(trades []*models.Trade) int {
+	trips := 0
+	var balance, peak float64
+	for _, trade := range trades {
+		if trade.IsBuy {
+			balance += trade.TokenAmount
+			peak = math.Max(peak, balance)
+			continue
+		}
+		balance -= trade.TokenAmount
+		if peak > 0 && balance <= peak*(1-anomalyRoundTripFraction) {
+			trips++
+			balance = math.Max(balance, 0)
+			peak = 0
+		}
+	}
+	return trips
+}
+
+// isPeriodic reports whether a wallet's trades are spaced at near-constant intervals
+func isPeriodic(trades []*models.Trade) bool {
+	if len(trades) < anomalyPeriodicMinTrades {
+		return false
+	}
+
+	intervals := make([]float64, 0, len(trades)-1)
+	var sum float64
+	for i := 1; i < len(trades); i++ {
+		interval := float64(trades[i].Timestamp - trades[i-1].Timestamp)
+		intervals = append(intervals, interval)
+		sum += interval
+	}
+	mean := sum / float64(len(intervals))
+	if mean <= 0 {
+		return false
+	}
+
+	var variance float64
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	variance /= float64(len(intervals))
+
+	return math.Sqrt(variance)/mean <= anomalyPeriodicMaxCV
+}
@@ -0,0 +1,117 @@
+// internal/service/trade_anomaly_service_test.go
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectTradeAnomalies(t *testing.T) {
+	start := int64(1700000000)
+
+	var trades []*models.Trade
+	id := int64(0)
+	add := func(wallet string, isBuy bool, sol, tokens float64, ts int64) {
+		id++
+		trades = append(trades, &models.Trade{
+			ID: id, UserAddress: wallet, IsBuy: isBuy, SolAmount: sol, TokenAmount: tokens, Timestamp: ts,
+		})
+	}
+
+	// Ordinary buyers at irregular times set the median buy size
+	add("retail1", true, 1, 1000, start)
+	add("retail2", true, 2, 2000, start+7)
+	add("retail3", true, 1.5, 1500, start+31)
+	add("retail4", true, 1, 1000, start+44)
+	// Sells the exact amount back within seconds
+	add("washer", true, 1, 1000, start+50)
+	add("washer", false, 1, 1000, start+53)
+	// Buys and sells out twice, spaced too far apart for a self-trade
+	add("churner", true, 1, 1000, start+60)
+	add("churner", false, 1, 1000, start+90)
+	add("churner", true, 1, 800, start+140)
+	add("churner", false, 1, 800, start+200)
+	// Tiny buys that only inflate the buy count
+	add("duster", true, 0.01, 10, start+3)
+	add("duster", true, 0.01, 10, start+19)
+	add("duster", true, 0.01, 10, start+27)
+	// Trades every 5 seconds like clockwork
+	for i := int64(0); i < 5; i++ {
+		add("bot", true, 1, 1000, start+100+i*5)
+	}
+
+	anomalies := DetectTradeAnomalies(trades)
+	assert.Equal(t, len(trades), anomalies.TradeCount)
+	assert.Equal(t, 15, anomalies.BuyCount)
+	assert.InDelta(t, 0.05, anomalies.DustThresholdSol, 1e-9)
+	assert.Equal(t, 1, anomalies.SelfTradeCount)
+	assert.Equal(t, 1, anomalies.RoundTripWallets)
+	assert.Equal(t, 3, anomalies.DustBuyCount)
+	assert.Equal(t, 1, anomalies.PeriodicWallets)
+	assert.Equal(t, 4, anomalies.FlaggedWalletCount)
+
+	assert.Equal(t, []string{models.AnomalySelfTrade}, anomalies.FlaggedWallets["washer"])
+	assert.Equal(t, []string{models.AnomalyRoundTrip}, anomalies.FlaggedWallets["churner"])
+	assert.Equal(t, []string{models.AnomalyDust}, anomalies.FlaggedWallets["duster"])
+	assert.Equal(t, []string{models.AnomalyPeriodic}, anomalies.FlaggedWallets["bot"])
+	assert.False(t, anomalies.IsFlagged("retail1"))
+
+	// washer 1 + churner 2 + duster 3 + bot 5 of 15 buys
+	assert.InDelta(t, 11.0/15*100, anomalies.FlaggedBuyPct, 1e-9)
+	// washer 1 + churner 2 + duster 0.03 + bot 5 of 13.53 SOL bought
+	assert.InDelta(t, 8.03/13.53*100, anomalies.FlaggedVolumePct, 1e-9)
+	assert.InDelta(t, (anomalies.FlaggedBuyPct+anomalies.FlaggedVolumePct)/2, anomalies.AnomalyScore, 1e-9)
+
+	// Organic trading alone scores zero
+	clean := DetectTradeAnomalies(trades[:4])
+	assert.Zero(t, clean.AnomalyScore)
+	assert.Empty(t, clean.FlaggedWallets)
+}
+
+func TestAnalyzeEntrySignalOrganicBuys(t *testing.T) {
+	s := &SimulationService{logger: logger.New("test")}
+	ctx := &SimulationContext{Config: models.StrategyConfig{
+		EntryTimeWindowSec: 60,
+		EntrySignalType:    models.EntrySignalUniqueBuyers,
+		MinUniqueBuyers:    3,
+	}}
+
+	now := time.Now().Unix()
+	trades := []*models.Trade{
+		{UserAddress: "bot", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 6},
+		{UserAddress: "bot", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 5},
+		{UserAddress: "duster", IsBuy: true, SolAmount: 0.01, TokenAmount: 10, Timestamp: now - 4},
+		{UserAddress: "retail1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 3},
+		{UserAddress: "retail1", IsBuy: true, SolAmount: 0.5, TokenAmount: 500, Timestamp: now - 2},
+		{UserAddress: "retail2", IsBuy: true, SolAmount: 2, TokenAmount: 2000, Timestamp: now - 1},
+	}
+	anomalies := &models.TokenAnomalies{
+		AnomalyScore:     40,
+		DustThresholdSol: 0.05,
+		FlaggedWallets:   map[string][]string{"bot": {models.AnomalyPeriodic}},
+	}
+
+	// Without anomaly data every buyer counts
+	entry, data := s.analyzeEntrySignal(ctx, &models.Token{}, trades, nil, nil, nil, nil)
+	assert.True(t, entry)
+	assert.Equal(t, 4, data["unique_buyers"])
+
+	entry, data = s.analyzeEntrySignal(ctx, &models.Token{}, trades, nil, anomalies, nil, nil)
+	assert.False(t, entry)
+	assert.Equal(t, 2, data["unique_buyers"])
+	assert.Equal(t, 3.5, data["organic_buy_volume_sol"])
+	assert.Equal(t, 40.0, data["anomaly_score"])
+
+	ctx.Config.EntrySignalType = models.EntrySignalOrganicVolume
+	ctx.Config.MinOrganicBuyVolumeSol = 3
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, trades, nil, anomalies, nil, nil)
+	assert.True(t, entry)
+
+	ctx.Config.MinOrganicBuyVolumeSol = 4
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, trades, nil, anomalies, nil, nil)
+	assert.False(t, entry)
+}
@@ -0,0 +1,124 @@
+// internal/service/trade_feed.go
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+// TradeObserver receives trades as they are read from the trade feed
+type TradeObserver interface {
+	OnTrade(trade *models.Trade)
+}
+
+// TradeFeed tails the trades table written by the collector and fans new trades
+// out to registered observers in insertion order
+type TradeFeed struct {
+	tradeRepo    repository.TradeRepositoryInterface
+	logger       *logger.Logger
+	observers    []TradeObserver
+	pollInterval time.Duration
+	batchSize    int
+	lastID       int64
+	mu           sync.RWMutex
+}
+
+// NewTradeFeed creates a new trade feed
+func NewTradeFeed(tradeRepo repository.TradeRepositoryInterface, logger *logger.Logger) *TradeFeed {
+	return &TradeFeed{
+		tradeRepo:    tradeRepo,
+		logger:       logger,
+		pollInterval: 1 * time.Second,
+		batchSize:    1000,
+	}
+}
+
+// Subscribe registers an observer for new trades
+func (f *TradeFeed) Subscribe(observer TradeObserver) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.observers = append(f.observers, observer)
+}
+
+// LastID returns the ID of the last trade delivered to observers
+func (f *TradeFeed) LastID() int64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.lastID
+}
+
+// Start replays trades newer than the given unix timestamp and then polls for new
+// trades until the context is cancelled
+func (f *TradeFeed) Start(ctx context.Context, since int64) error {
+	lastID, err := f.tradeRepo.GetLastTradeIDBefore(since)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.lastID = lastID
+	f.mu.Unlock()
+
+	f.logger.Info("Trade feed starting after trade ID %d", lastID)
+
+	go f.run(ctx)
+	return nil
+}
+
+// run polls the trades table until the context is cancelled
+func (f *TradeFeed) run(ctx context.Context) {
+	ticker := time.NewTicker(f.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			f.logger.Info("Trade feed stopped")
+			return
+		case <-ticker.C:
+			f.poll(ctx)
+		}
+	}
+}
+
+// poll delivers all trades inserted since the last poll
+func (f *TradeFeed) poll(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		trades, err := f.tradeRepo.GetTradesAfterID(f.LastID(), f.batchSize)
+		if err != nil {
+			f.logger.Error("Error polling trade feed: %v", err)
+			return
+		}
+
+		if len(trades) == 0 {
+			return
+		}
+
+		f.mu.RLock()
+		observers := f.observers
+		f.mu.RUnlock()
+
+		for _, trade := range trades {
+			for _, observer := range observers {
+				observer.OnTrade(trade)
+			}
+		}
+
+		f.mu.Lock()
+		f.lastID = trades[len(trades)-1].ID
+		f.mu.Unlock()
+
+		if len(trades) < f.batchSize {
+			return
+		}
+	}
+}
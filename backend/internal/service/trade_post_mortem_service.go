@@ -0,0 +1,642 @@
+// internal/service/trade_post_mortem_service.go
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+var (
+	// ErrTradeNotFound is returned for post-mortems of trades that don't exist
+	ErrTradeNotFound = errors.New("simulated trade not found")
+	// ErrTradeNotClosed is returned for post-mortems of trades that haven't exited yet
+	ErrTradeNotClosed = errors.New("simulated trade is not closed")
+	// ErrStrategyNotFound is returned for post-mortems of strategies that don't exist
+	ErrStrategyNotFound = errors.New("strategy not found")
+)
+
+const (
+	// DefaultPostMortemBatch is how many trades a batch diagnoses when no limit is given
+	DefaultPostMortemBatch = 20
+
+	postMortemPathPoints      = 20   // Price points shown for the hold
+	postMortemAfterExitSec    = 300  // How long after the exit the price is shown
+	postMortemAfterExitPoints = 5    // Price points shown after the exit
+	postMortemPathTrades      = 5000 // Most token trades read for a price path
+	postMortemSimilarTrades   = 5
+	postMortemEventScan       = 1000 // Simulation events searched for a trade's entry signal
+)
+
+// TradePostMortemService asks the AI why individual closed simulated trades won or lost.
+// Each post-mortem sees the entry signal and features recorded when the trade was entered,
+// the launch and anomaly analyses of the token, the price path during the hold and after
+// the exit, and the strategy's trades at a similar market cap. The diagnosis is stored on
+// the trade and aggregated per strategy.
+type TradePostMortemService struct {
+	aiService      *AIService
+	tradeRepo      repository.SimulatedTradeRepositoryInterface
+	strategyRepo   repository.StrategyRepositoryInterface
+	tokenRepo      repository.TokenRepositoryInterface
+	outcomeRepo    repository.TokenOutcomeRepositoryInterface
+	featureRepo    repository.TokenFeatureRepositoryInterface
+	eventRepo      repository.SimulationEventRepositoryInterface
+	launchAnalysis LaunchAnalysisProvider
+	tradeAnomalies TradeAnomalyProvider
+	logger         *logger.Logger
+}
+
+// PostMortemBatch is the outcome of diagnosing a strategy's closed trades
+type PostMortemBatch struct {
+	StrategyID int64                            `json:"strategy_id"`
+	Analyzed   int                              `json:"analyzed"`
+	Failed     int                              `json:"failed"`
+	Pending    int                              `json:"pending"`              // Closed trades still without a post-mortem
+	StoppedBy  string                           `json:"stopped_by,omitempty"` // Why the batch stopped early
+	Summary    *models.StrategyDiagnosisSummary `json:"summary"`
+}
+
+// postMortemSources caches what trades of the same simulation run share, so a batch reads
+// each run's feature snapshots and events once
+type postMortemSources struct {
+	snapshots map[int64][]*models.TokenFeatureSnapshot
+	events    map[int64][]*models.SimulationEvent
+}
+
+// NewTradePostMortemService creates a new trade post-mortem service
+func NewTradePostMortemService(
+	aiService *AIService,
+	tradeRepo repository.SimulatedTradeRepositoryInterface,
+	strategyRepo repository.StrategyRepositoryInterface,
+	tokenRepo repository.TokenRepositoryInterface,
+	outcomeRepo repository.TokenOutcomeRepositoryInterface,
+	logger *logger.Logger,
+) *TradePostMortemService {
+	return &TradePostMortemService{
+		aiService:    aiService,
+		tradeRepo:    tradeRepo,
+		strategyRepo: strategyRepo,
+		tokenRepo:    tokenRepo,
+		outcomeRepo:  outcomeRepo,
+		logger:       logger,
+	}
+}
+
+// SetFeatureRepository sets where the token features recorded at entry are read from
+func (s *TradePostMortemService) SetFeatureRepository(featureRepo repository.TokenFeatureRepositoryInterface) {
+	s.featureRepo = featureRepo
+}
+
+// SetSimulationEventRepository sets where the entry signal data of trades is read from
+func (s *TradePostMortemService) SetSimulationEventRepository(eventRepo repository.SimulationEventRepositoryInterface) {
+	s.eventRepo = eventRepo
+}
+
+// SetLaunchAnalysisProvider sets the provider of the launch analysis shown for each token
+func (s *TradePostMortemService) SetLaunchAnalysisProvider(provider LaunchAnalysisProvider) {
+	s.launchAnalysis = provider
+}
+
+// SetTradeAnomalyProvider sets the provider of the trade anomalies shown for each token
+func (s *TradePostMortemService) SetTradeAnomalyProvider(provider TradeAnomalyProvider) {
+	s.tradeAnomalies = provider
+}
+
+// AnalyzeTrade returns a closed trade with its post-mortem, asking the AI for one if the
+// trade has none yet or refresh is set
+func (s *TradePostMortemService) AnalyzeTrade(tradeID int64, refresh bool) (*models.SimulatedTrade, error) {
+	trade, err := s.tradeRepo.GetByID(tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting simulated trade: %v", err)
+	}
+	if trade == nil {
+		return nil, ErrTradeNotFound
+	}
+	if !isClosedTrade(trade) {
+		return nil, ErrTradeNotClosed
+	}
+	if trade.PostMortem != nil && !refresh {
+		return trade, nil
+	}
+
+	strategyTrades, err := s.tradeRepo.GetByStrategyID(trade.StrategyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy trades: %v", err)
+	}
+	if err := s.analyze(trade, strategyTrades, newPostMortemSources()); err != nil {
+		return nil, err
+	}
+	return trade, nil
+}
+
+// AnalyzeStrategy diagnoses up to limit closed trades of a strategy that have no
+// post-mortem yet, largest losses first. Trades that fail are skipped; running out of AI
+// budget or an open circuit breaker stops the batch.
+func (s *TradePostMortemService) AnalyzeStrategy(strategyID int64, limit int) (*PostMortemBatch, error) {
+	if limit <= 0 {
+		limit = DefaultPostMortemBatch
+	}
+	trades, err := s.strategyTrades(strategyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*models.SimulatedTrade
+	for _, trade := range trades {
+		if isClosedTrade(trade) && trade.PostMortem == nil {
+			pending = append(pending, trade)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return *pending[i].ProfitLoss < *pending[j].ProfitLoss })
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	batch := &PostMortemBatch{StrategyID: strategyID}
+	sources := newPostMortemSources()
+	for _, trade := range pending {
+		err := s.analyze(trade, trades, sources)
+		if errors.Is(err, ErrAIBudgetExceeded) || errors.Is(err, ErrAICircuitOpen) {
+			batch.StoppedBy = err.Error()
+			s.logger.Warn("Stopped post-mortems of strategy %d: %v", strategyID, err)
+			break
+		}
+		if err != nil {
+			batch.Failed++
+			s.logger.Warn("Error diagnosing trade %d of strategy %d: %v", trade.ID, strategyID, err)
+			continue
+		}
+		batch.Analyzed++
+	}
+
+	batch.Summary = summarizeDiagnoses(strategyID, trades)
+	batch.Pending = batch.Summary.ClosedTrades - batch.Summary.TradesAnalyzed
+	s.logger.Info("Diagnosed %d trades of strategy %d (%d failed, %d pending)",
+		batch.Analyzed, strategyID, batch.Failed, batch.Pending)
+	return batch, nil
+}
+
+// Summarize aggregates the post-mortems of a strategy's closed trades by diagnosis
+func (s *TradePostMortemService) Summarize(strategyID int64) (*models.StrategyDiagnosisSummary, error) {
+	trades, err := s.strategyTrades(strategyID)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeDiagnoses(strategyID, trades), nil
+}
+
+// strategyTrades returns the trades of a strategy, or ErrStrategyNotFound
+func (s *TradePostMortemService) strategyTrades(strategyID int64) ([]*models.SimulatedTrade, error) {
+	strategy, err := s.strategyRepo.GetByID(strategyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy: %v", err)
+	}
+	if strategy == nil {
+		return nil, ErrStrategyNotFound
+	}
+
+	trades, err := s.tradeRepo.GetByStrategyID(strategyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting strategy trades: %v", err)
+	}
+	return trades, nil
+}
+
+// analyze asks the AI for a post-mortem of a closed trade and stores it on the trade.
+// strategyTrades are the trades of its strategy, searched for similar trades.
+func (s *TradePostMortemService) analyze(trade *models.SimulatedTrade, strategyTrades []*models.SimulatedTrade, sources *postMortemSources) error {
+	input, err := s.buildPromptInput(trade, strategyTrades, sources)
+	if err != nil {
+		return err
+	}
+
+	postMortem, err := s.aiService.GeneratePostMortem(DefaultPostMortemPrompt, input)
+	if err != nil {
+		return fmt.Errorf("error generating post-mortem: %w", err)
+	}
+	if err := s.tradeRepo.SavePostMortem(trade.ID, postMortem); err != nil {
+		return fmt.Errorf("error saving post-mortem: %v", err)
+	}
+
+	trade.PostMortem = postMortem
+	s.logger.Info("Diagnosed trade %d of strategy %d: %s", trade.ID, trade.StrategyID, postMortemCodes(postMortem))
+	return nil
+}
+
+// buildPromptInput gathers what the model is shown about a closed trade. Missing context,
+// such as a token without a launch analysis, is left out rather than failing the trade.
+func (s *TradePostMortemService) buildPromptInput(trade *models.SimulatedTrade, strategyTrades []*models.SimulatedTrade, sources *postMortemSources) (PostMortemPromptInput, error) {
+	input := PostMortemPromptInput{
+		StrategyName: fmt.Sprintf("Strategy %d", trade.StrategyID),
+		Parameters:   "{}",
+		Trade: PostMortemTrade{
+			EntryPrice:     trade.EntryPrice,
+			ExitPrice:      *trade.ExitPrice,
+			EntryMarketCap: trade.EntryUsdMarketCap,
+			HoldSec:        *trade.ExitTimestamp - trade.EntryTimestamp,
+			ReturnPct:      tradeReturnPct(trade),
+		},
+		Diagnoses: postMortemDiagnoses,
+	}
+	if trade.ExitUsdMarketCap != nil {
+		input.Trade.ExitMarketCap = *trade.ExitUsdMarketCap
+	}
+	if trade.ProfitLoss != nil {
+		input.Trade.ProfitLoss = *trade.ProfitLoss
+	}
+	if trade.ExitReason != nil {
+		input.Trade.ExitReason = *trade.ExitReason
+	}
+
+	strategy, err := s.strategyRepo.GetByID(trade.StrategyID)
+	if err != nil {
+		return input, fmt.Errorf("error getting strategy: %v", err)
+	}
+	if strategy != nil {
+		input.StrategyName = strategy.Name
+		if parameters, err := json.Marshal(strategy.Config); err == nil {
+			input.Parameters = string(parameters)
+		}
+	}
+
+	token, err := s.tokenRepo.GetByID(trade.TokenID)
+	if err != nil {
+		return input, fmt.Errorf("error getting token: %v", err)
+	}
+	if token != nil {
+		input.Trade.TokenSymbol = token.Symbol
+		if s.launchAnalysis != nil {
+			if input.Launch, err = s.launchAnalysis.GetLaunchAnalysis(token); err != nil {
+				s.logger.Warn("Error getting launch analysis of %s: %v", token.MintAddress, err)
+			}
+		}
+		if s.tradeAnomalies != nil {
+			if input.Anomalies, err = s.tradeAnomalies.GetTokenAnomalies(token); err != nil {
+				s.logger.Warn("Error getting trade anomalies of %s: %v", token.MintAddress, err)
+			}
+		}
+	}
+
+	input.EntrySignal = s.entrySignal(trade, sources)
+
+	exitAt := *trade.ExitTimestamp
+	marketTrades, err := s.outcomeRepo.GetOutcomeTrades(trade.TokenID, trade.EntryTimestamp, exitAt+postMortemAfterExitSec, postMortemPathTrades)
+	if err != nil {
+		return input, fmt.Errorf("error getting token trades: %v", err)
+	}
+	var hold, afterExit []*models.Trade
+	for _, marketTrade := range marketTrades {
+		if marketTrade.Timestamp <= exitAt {
+			hold = append(hold, marketTrade)
+		} else {
+			afterExit = append(afterExit, marketTrade)
+		}
+	}
+	input.Trade.HoldTrades = len(hold)
+	input.Trade.MaxRunUpPct, input.Trade.MaxDrawdownPct = priceRange(hold, trade.EntryPrice)
+	input.PricePath = pricePath(hold, trade.EntryTimestamp, input.Trade.HoldSec, postMortemPathPoints, trade.EntryPrice)
+	input.AfterExit = pricePath(afterExit, exitAt, postMortemAfterExitSec, postMortemAfterExitPoints, trade.EntryPrice)
+
+	input.SimilarTrades = similarTrades(trade, strategyTrades, postMortemSimilarTrades)
+	return input, nil
+}
+
+// entrySignal returns the signal data and token features recorded when a trade was entered
+// as JSON, or "" if neither was recorded
+func (s *TradePostMortemService) entrySignal(trade *models.SimulatedTrade, sources *postMortemSources) string {
+	if trade.SimulationRunID == nil {
+		return ""
+	}
+	runID := *trade.SimulationRunID
+	signal := make(map[string]interface{})
+
+	if s.eventRepo != nil {
+		events, ok := sources.events[runID]
+		if !ok {
+			var err error
+			if events, err = s.eventRepo.GetBySimulationRunID(runID, postMortemEventScan, 0); err != nil {
+				s.logger.Warn("Error getting events of simulation run %d: %v", runID, err)
+			}
+			sources.events[runID] = events
+		}
+		for _, event := range events {
+			tokenID, _ := jsonbFloat(event.EventData["tokenId"])
+			if event.EventType == "trade_executed" && event.StrategyID == trade.StrategyID && int64(tokenID) == trade.TokenID {
+				if data, ok := event.EventData["signalData"]; ok {
+					signal["signal"] = data
+				}
+				break
+			}
+		}
+	}
+
+	if s.featureRepo != nil {
+		snapshots, ok := sources.snapshots[runID]
+		if !ok {
+			var err error
+			if snapshots, err = s.featureRepo.GetSnapshotsBySimulationRun(runID); err != nil {
+				s.logger.Warn("Error getting feature snapshots of simulation run %d: %v", runID, err)
+			}
+			sources.snapshots[runID] = snapshots
+		}
+		for _, snapshot := range snapshots {
+			if snapshot.Decision == "entry" && snapshot.SimulatedTradeID != nil && *snapshot.SimulatedTradeID == trade.ID {
+				signal["features"] = snapshot.Features
+				break
+			}
+		}
+	}
+
+	if len(signal) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(signal)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func newPostMortemSources() *postMortemSources {
+	return &postMortemSources{
+		snapshots: make(map[int64][]*models.TokenFeatureSnapshot),
+		events:    make(map[int64][]*models.SimulationEvent),
+	}
+}
+
+// isClosedTrade reports whether a simulated trade has exited
+func isClosedTrade(trade *models.SimulatedTrade) bool {
+	return trade.Status != "active" && trade.ExitPrice != nil && trade.ExitTimestamp != nil && trade.ProfitLoss != nil
+}
+
+// tradeReturnPct returns the percentage return of a closed trade
+func tradeReturnPct(trade *models.SimulatedTrade) float64 {
+	if trade.EntryPrice <= 0 || trade.ExitPrice == nil {
+		return 0
+	}
+	return (*trade.ExitPrice/trade.EntryPrice - 1) * 100
+}
+
+// priceRange returns the highest and lowest price of trades as percentage changes from
+// entryPrice
+func priceRange(trades []*models.Trade, entryPrice float64) (float64, float64) {
+	var high, low float64
+	for _, trade := range trades {
+		price := tradePrice(trade)
+		if price <= 0 || entryPrice <= 0 {
+			continue
+		}
+		change := (price/entryPrice - 1) * 100
+		high = math.Max(high, change)
+		low = math.Min(low, change)
+	}
+	return high, low
+}
+
+// pricePath splits spanSec seconds from startSec into at most points slices and returns the
+// last price traded in each, as a percentage change from entryPrice. Slices without trades
+// are left out.
+func pricePath(trades []*models.Trade, startSec, spanSec int64, points int, entryPrice float64) []PostMortemPricePoint {
+	if entryPrice <= 0 || points <= 0 {
+		return nil
+	}
+	sliceSec := (spanSec + int64(points) - 1) / int64(points)
+	if sliceSec < 1 {
+		sliceSec = 1
+	}
+
+	var path []PostMortemPricePoint
+	lastSlice := int64(-1)
+	for _, trade := range trades {
+		price := tradePrice(trade)
+		if price <= 0 {
+			continue
+		}
+		offset := trade.Timestamp - startSec
+		point := PostMortemPricePoint{OffsetSec: offset, ChangePct: (price/entryPrice - 1) * 100}
+		slice := offset / sliceSec
+		if slice >= int64(points) {
+			slice = int64(points) - 1
+		}
+		if slice == lastSlice {
+			path[len(path)-1] = point
+		} else {
+			path = append(path, point)
+			lastSlice = slice
+		}
+	}
+	return path
+}
+
+// similarTrades returns up to limit other closed trades of the strategy with the closest
+// entry market cap
+func similarTrades(trade *models.SimulatedTrade, strategyTrades []*models.SimulatedTrade, limit int) []PostMortemSimilarTrade {
+	distance := func(other *models.SimulatedTrade) float64 {
+		if trade.EntryUsdMarketCap <= 0 || other.EntryUsdMarketCap <= 0 {
+			return math.Abs(other.EntryUsdMarketCap - trade.EntryUsdMarketCap)
+		}
+		return math.Abs(math.Log(other.EntryUsdMarketCap / trade.EntryUsdMarketCap))
+	}
+
+	var candidates []*models.SimulatedTrade
+	for _, other := range strategyTrades {
+		if other.ID != trade.ID && isClosedTrade(other) {
+			candidates = append(candidates, other)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return distance(candidates[i]) < distance(candidates[j]) })
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	similar := make([]PostMortemSimilarTrade, 0, len(candidates))
+	for _, other := range candidates {
+		entry := PostMortemSimilarTrade{
+			EntryMarketCap: other.EntryUsdMarketCap,
+			HoldSec:        *other.ExitTimestamp - other.EntryTimestamp,
+			ReturnPct:      tradeReturnPct(other),
+		}
+		if other.ExitReason != nil {
+			entry.ExitReason = *other.ExitReason
+		}
+		if other.PostMortem != nil {
+			for _, diagnosis := range other.PostMortem.Diagnoses {
+				entry.Diagnoses = append(entry.Diagnoses, diagnosis.Code)
+			}
+		}
+		similar = append(similar, entry)
+	}
+	return similar
+}
+
+// summarizeDiagnoses counts how many of a strategy's closed trades got each diagnosis. A
+// trade counts once per code, however many of its diagnoses share it.
+func summarizeDiagnoses(strategyID int64, trades []*models.SimulatedTrade) *models.StrategyDiagnosisSummary {
+	summary := &models.StrategyDiagnosisSummary{
+		StrategyID: strategyID,
+		Diagnoses:  []models.DiagnosisCount{},
+	}
+
+	counts := make(map[string]*models.DiagnosisCount)
+	for _, trade := range trades {
+		if !isClosedTrade(trade) {
+			continue
+		}
+		summary.ClosedTrades++
+		if trade.PostMortem == nil {
+			continue
+		}
+		summary.TradesAnalyzed++
+
+		seen := make(map[string]bool)
+		for _, diagnosis := range trade.PostMortem.Diagnoses {
+			count, ok := counts[diagnosis.Code]
+			if !ok {
+				count = &models.DiagnosisCount{Code: diagnosis.Code, Parameters: []string{}}
+				counts[diagnosis.Code] = count
+			}
+			if diagnosis.Parameter != "" && !containsString(count.Parameters, diagnosis.Parameter) {
+				count.Parameters = append(count.Parameters, diagnosis.Parameter)
+			}
+			if seen[diagnosis.Code] {
+				continue
+			}
+			seen[diagnosis.Code] = true
+			count.Trades++
+			count.NetPnL += *trade.ProfitLoss
+		}
+	}
+
+	for _, count := range counts {
+		count.SharePct = float64(count.Trades) / float64(summary.TradesAnalyzed) * 100
+		sort.Strings(count.Parameters)
+		summary.Diagnoses = append(summary.Diagnoses, *count)
+	}
+	sort.Slice(summary.Diagnoses, func(i, j int) bool {
+		if summary.Diagnoses[i].Trades != summary.Diagnoses[j].Trades {
+			return summary.Diagnoses[i].Trades > summary.Diagnoses[j].Trades
+		}
+		return summary.Diagnoses[i].Code < summary.Diagnoses[j].Code
+	})
+	return summary
+}
+
+// postMortemCodes lists the diagnosis codes of a post-mortem for logging
+func postMortemCodes(postMortem *models.TradePostMortem) string {
+	codes := make([]string, 0, len(postMortem.Diagnoses))
+	for _, diagnosis := range postMortem.Diagnoses {
+		codes = append(codes, diagnosis.Code)
+	}
+	return strings.Join(codes, ", ")
+}
+
+// PostMortemJSONSchema returns the JSON Schema of the post-mortem the AI must return: one
+// to three diagnoses and a summary
+func PostMortemJSONSchema() map[string]interface{} {
+	text := map[string]interface{}{"type": "string"}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"diagnoses": map[string]interface{}{
+				"type":     "array",
+				"minItems": 1,
+				"maxItems": 3,
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"code":       map[string]interface{}{"type": "string", "enum": models.DiagnosisCodes},
+						"detail":     text,
+						"parameter":  text,
+						"suggestion": text,
+						"confidence": map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+					},
+					"required":             []string{"code", "detail", "confidence"},
+					"additionalProperties": false,
+				},
+			},
+			"summary": text,
+		},
+		"required":             []string{"diagnoses", "summary"},
+		"additionalProperties": false,
+	}
+}
+
+// PostMortemValidationError lists every way an AI response failed the post-mortem schema
+type PostMortemValidationError struct {
+	Problems []string
+}
+
+func (e *PostMortemValidationError) Error() string {
+	return "invalid post-mortem: " + strings.Join(e.Problems, "; ")
+}
+
+// validatePostMortemPayload checks a decoded post-mortem: one to three diagnoses with a
+// known code, evidence and a confidence between 0 and 1, naming only strategy parameters,
+// and a summary. It returns the post-mortem or every problem found.
+func validatePostMortemPayload(payload map[string]interface{}) (*models.TradePostMortem, []string) {
+	postMortem := &models.TradePostMortem{Summary: payloadString(payload, "summary")}
+	var problems []string
+	if postMortem.Summary == "" {
+		problems = append(problems, "summary: is required")
+	}
+
+	objects, objectProblems := payloadObjects("diagnoses", payload["diagnoses"])
+	problems = append(problems, objectProblems...)
+	if objectProblems == nil && (len(objects) == 0 || len(objects) > 3) {
+		problems = append(problems, "diagnoses: must contain one to three diagnoses")
+	}
+
+	known := make(map[string]bool, len(strategyFields))
+	for _, field := range strategyFields {
+		known[field.Key] = true
+	}
+
+	for i, object := range objects {
+		diagnosis := models.TradeDiagnosis{
+			Code:       payloadString(object, "code"),
+			Detail:     payloadString(object, "detail"),
+			Parameter:  payloadString(object, "parameter"),
+			Suggestion: payloadString(object, "suggestion"),
+		}
+		if !containsString(models.DiagnosisCodes, diagnosis.Code) {
+			problems = append(problems, fmt.Sprintf("diagnoses[%d].code: %q is not a diagnosis code", i, diagnosis.Code))
+		}
+		if diagnosis.Detail == "" {
+			problems = append(problems, fmt.Sprintf("diagnoses[%d].detail: is required", i))
+		}
+		if diagnosis.Parameter != "" && !known[diagnosis.Parameter] {
+			problems = append(problems, fmt.Sprintf("diagnoses[%d].parameter: %q is not a strategy parameter", i, diagnosis.Parameter))
+		}
+		confidence, ok := jsonbFloat(object["confidence"])
+		if !ok || confidence < 0 || confidence > 1 {
+			problems = append(problems, fmt.Sprintf("diagnoses[%d].confidence: must be a number between 0 and 1", i))
+		}
+		diagnosis.Confidence = confidence
+		postMortem.Diagnoses = append(postMortem.Diagnoses, diagnosis)
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return postMortem, nil
+}
+
+// postMortemRepairPrompt asks the model to fix a post-mortem that failed validation
+func postMortemRepairPrompt(problems []string) string {
+	var prompt strings.Builder
+	prompt.WriteString("Your previous response did not match the required post-mortem schema:\n\n")
+	for _, problem := range problems {
+		prompt.WriteString("- ")
+		prompt.WriteString(problem)
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString("\nReturn ONLY the corrected JSON object.")
+	return prompt.String()
+}
@@ -0,0 +1,315 @@
+// internal/service/trade_post_mortem_service_test.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stopTooTightJSON = `{
+	"diagnoses": [
+		{"code": "stop_too_tight", "detail": "Stopped at -10% and the price was +35% a minute later", "parameter": "stopLossPct", "suggestion": "Use a 20% stop", "confidence": 0.8},
+		{"code": "bundled_launch", "detail": "Entry signal came from bundled buys", "confidence": 0.4}
+	],
+	"summary": "A normal dip hit the stop before the token ran."
+}`
+
+type postMortemFixture struct {
+	service    *TradePostMortemService
+	provider   *MockLLMProvider
+	tradeRepo  *memory.SimulatedTradeRepository
+	strategyID int64
+	tokenID    int64
+	runID      int64
+	entryAt    int64
+}
+
+func newPostMortemFixture(t *testing.T, responses ...string) *postMortemFixture {
+	store := memory.NewStore()
+	strategyRepo := memory.NewStrategyRepository(store)
+	tokenRepo := memory.NewTokenRepository(store)
+	f := &postMortemFixture{
+		provider:  NewMockLLMProvider(responses...),
+		tradeRepo: memory.NewSimulatedTradeRepository(store),
+		entryAt:   time.Now().Add(-time.Hour).Unix(),
+	}
+
+	var err error
+	f.strategyID, err = strategyRepo.Save(&models.Strategy{
+		Name:   "Tight Stop",
+		Config: models.JSONB{"takeProfitPct": 50.0, "stopLossPct": 10.0},
+		Tags:   []string{},
+	})
+	require.NoError(t, err)
+	f.tokenID, err = tokenRepo.Save(&models.Token{MintAddress: "mint-pm", Symbol: "PM", Name: "Post Mortem"})
+	require.NoError(t, err)
+	f.runID, err = memory.NewSimulationRunRepository(store).Save(&models.SimulationRun{Status: "completed"})
+	require.NoError(t, err)
+
+	// The price dips 10% during the 40s hold and runs to +35% after the exit
+	tradeRepo := memory.NewTradeRepository(store)
+	for i, point := range []struct {
+		offset int64
+		price  float64
+	}{{5, 1.04}, {15, 1.02}, {30, 0.95}, {40, 0.9}, {100, 1.35}} {
+		_, err := tradeRepo.Save(&models.Trade{
+			TokenID:     f.tokenID,
+			Signature:   fmt.Sprintf("sig-%d", i),
+			SolAmount:   point.price,
+			TokenAmount: 1_000_000,
+			IsBuy:       true,
+			Timestamp:   f.entryAt + point.offset,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = memory.NewSimulationEventRepository(store).Save(&models.SimulationEvent{
+		StrategyID:      f.strategyID,
+		SimulationRunID: f.runID,
+		EventType:       "trade_executed",
+		EventData:       models.JSONB{"tokenId": float64(f.tokenID), "signalData": map[string]interface{}{"buy_count": 7.0}},
+		Timestamp:       time.Unix(f.entryAt, 0),
+	})
+	require.NoError(t, err)
+
+	aiService := NewAIService(f.provider, strategyRepo, logger.New("test"))
+	f.service = NewTradePostMortemService(aiService, f.tradeRepo, strategyRepo, tokenRepo, memory.NewTokenOutcomeRepository(store), logger.New("test"))
+	f.service.SetSimulationEventRepository(memory.NewSimulationEventRepository(store))
+	f.service.SetFeatureRepository(memory.NewTokenFeatureRepository(store))
+	return f
+}
+
+// closedTrade stores a trade entered at entryAt at a price of 1e-6 that exited after
+// holdSec with the given return
+func (f *postMortemFixture) closedTrade(t *testing.T, marketCap float64, holdSec int64, returnPct float64, exitReason string) int64 {
+	entryPrice := 0.000001
+	exitPrice := entryPrice * (1 + returnPct/100)
+	exitAt := f.entryAt + holdSec
+	profitLoss := returnPct / 100 * 0.5
+	id, err := f.tradeRepo.Save(&models.SimulatedTrade{
+		StrategyID:        f.strategyID,
+		TokenID:           f.tokenID,
+		SimulationRunID:   &f.runID,
+		EntryPrice:        entryPrice,
+		EntryTimestamp:    f.entryAt,
+		PositionSize:      0.5,
+		Status:            "active",
+		EntryUsdMarketCap: marketCap,
+	})
+	require.NoError(t, err)
+	require.NoError(t, f.tradeRepo.Update(&models.SimulatedTrade{
+		ID:            id,
+		ExitPrice:     &exitPrice,
+		ExitTimestamp: &exitAt,
+		ProfitLoss:    &profitLoss,
+		Status:        "completed",
+		ExitReason:    &exitReason,
+	}))
+	return id
+}
+
+func TestAnalyzeTrade(t *testing.T) {
+	f := newPostMortemFixture(t, stopTooTightJSON)
+	tradeID := f.closedTrade(t, 8000, 40, -10, "stop_loss")
+	similarID := f.closedTrade(t, 7500, 30, -10, "stop_loss")
+	require.NoError(t, f.tradeRepo.SavePostMortem(similarID, &models.TradePostMortem{
+		Diagnoses: []models.TradeDiagnosis{{Code: models.DiagnosisStopTooTight, Detail: "Stopped out early"}},
+	}))
+	f.closedTrade(t, 50000, 60, 50, "take_profit")
+
+	trade, err := f.service.AnalyzeTrade(tradeID, false)
+	require.NoError(t, err)
+	require.NotNil(t, trade.PostMortem)
+	require.Len(t, trade.PostMortem.Diagnoses, 2)
+	assert.Equal(t, models.DiagnosisStopTooTight, trade.PostMortem.Diagnoses[0].Code)
+	assert.Equal(t, "stopLossPct", trade.PostMortem.Diagnoses[0].Parameter)
+	assert.InDelta(t, 0.8, trade.PostMortem.Diagnoses[0].Confidence, 1e-9)
+	assert.Equal(t, DefaultPostMortemPrompt, trade.PostMortem.PromptTemplate)
+
+	stored, err := f.tradeRepo.GetByID(tradeID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.PostMortem)
+	assert.Equal(t, trade.PostMortem.Summary, stored.PostMortem.Summary)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, models.AIPurposeTradePostMortem, requests[0].Purpose)
+	require.NotNil(t, requests[0].ResponseSchema)
+	assert.Equal(t, "trade_post_mortem", requests[0].ResponseSchema.Name)
+	prompt := requests[0].Messages[1].Content
+	assert.Contains(t, prompt, `"stopLossPct":10`)
+	assert.Contains(t, prompt, "- Exit reason: stop_loss")
+	assert.Contains(t, prompt, "Highest price during the hold: +4.00%, lowest: -10.00%")
+	assert.Contains(t, prompt, `{"signal":{"buy_count":7}}`, "the entry signal comes from the trade_executed event")
+	assert.Contains(t, prompt, "- +5s: +4.00%\n")
+	assert.Contains(t, prompt, "- exit +60s: +35.00%\n")
+	assert.Contains(t, prompt, "- entry $7500, held 30s, -10.00%, exit stop_loss, diagnosed stop_too_tight\n")
+	assert.NotContains(t, prompt, "Launch:", "no launch analysis provider is set")
+
+	// A stored post-mortem is returned until a refresh is asked for
+	_, err = f.service.AnalyzeTrade(tradeID, false)
+	require.NoError(t, err)
+	assert.Len(t, f.provider.Requests(), 1)
+	_, err = f.service.AnalyzeTrade(tradeID, true)
+	require.NoError(t, err)
+	assert.Len(t, f.provider.Requests(), 2)
+}
+
+func TestAnalyzeTradeRejectsMissingAndOpenTrades(t *testing.T) {
+	f := newPostMortemFixture(t)
+
+	_, err := f.service.AnalyzeTrade(1000, false)
+	assert.ErrorIs(t, err, ErrTradeNotFound)
+
+	openID, err := f.tradeRepo.Save(&models.SimulatedTrade{
+		StrategyID:     f.strategyID,
+		TokenID:        f.tokenID,
+		EntryPrice:     0.000001,
+		EntryTimestamp: f.entryAt,
+		Status:         "active",
+	})
+	require.NoError(t, err)
+	_, err = f.service.AnalyzeTrade(openID, false)
+	assert.ErrorIs(t, err, ErrTradeNotClosed)
+	assert.Empty(t, f.provider.Requests())
+}
+
+func TestAnalyzeTradeRepairsInvalidDiagnoses(t *testing.T) {
+	invalid := `{
+		"diagnoses": [{"code": "bad_luck", "detail": "", "parameter": "stopLoss", "confidence": 2}],
+		"summary": ""
+	}`
+	f := newPostMortemFixture(t, invalid, stopTooTightJSON)
+	tradeID := f.closedTrade(t, 8000, 40, -10, "stop_loss")
+
+	trade, err := f.service.AnalyzeTrade(tradeID, false)
+	require.NoError(t, err)
+	assert.Equal(t, models.DiagnosisStopTooTight, trade.PostMortem.Diagnoses[0].Code)
+
+	requests := f.provider.Requests()
+	require.Len(t, requests, 2)
+	repair := requests[1].Messages[3].Content
+	assert.Contains(t, repair, "summary: is required")
+	assert.Contains(t, repair, `diagnoses[0].code: "bad_luck" is not a diagnosis code`)
+	assert.Contains(t, repair, "diagnoses[0].detail: is required")
+	assert.Contains(t, repair, `diagnoses[0].parameter: "stopLoss" is not a strategy parameter`)
+	assert.Contains(t, repair, "diagnoses[0].confidence: must be a number between 0 and 1")
+}
+
+func TestAnalyzeTradeRejectsAfterRepairAttempts(t *testing.T) {
+	f := newPostMortemFixture(t, "not json", `{"diagnoses": []}`, `{"summary": "none"}`)
+	tradeID := f.closedTrade(t, 8000, 40, -10, "stop_loss")
+
+	_, err := f.service.AnalyzeTrade(tradeID, false)
+	var validationErr *PostMortemValidationError
+	require.True(t, errors.As(err, &validationErr), "expected a PostMortemValidationError, got %v", err)
+	assert.Contains(t, validationErr.Problems, "diagnoses: must be an array")
+
+	stored, err := f.tradeRepo.GetByID(tradeID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.PostMortem)
+}
+
+func TestAnalyzeStrategy(t *testing.T) {
+	// The first trade diagnosed, the largest loss, never gets a valid response
+	f := newPostMortemFixture(t, "not json", "not json", "not json")
+	worstID := f.closedTrade(t, 8000, 40, -30, "stop_loss")
+	secondID := f.closedTrade(t, 9000, 40, -20, "stop_loss")
+	thirdID := f.closedTrade(t, 10000, 40, -10, "stop_loss")
+	bestID := f.closedTrade(t, 11000, 60, 50, "take_profit")
+
+	batch, err := f.service.AnalyzeStrategy(f.strategyID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, batch.Analyzed)
+	assert.Equal(t, 1, batch.Failed)
+	assert.Equal(t, 2, batch.Pending)
+	assert.Empty(t, batch.StoppedBy)
+	require.NotNil(t, batch.Summary)
+	assert.Equal(t, 4, batch.Summary.ClosedTrades)
+	assert.Equal(t, 2, batch.Summary.TradesAnalyzed)
+
+	for id, analyzed := range map[int64]bool{worstID: false, secondID: true, thirdID: true, bestID: false} {
+		trade, err := f.tradeRepo.GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, analyzed, trade.PostMortem != nil, "trade %d", id)
+	}
+
+	_, err = f.service.AnalyzeStrategy(f.strategyID+1000, 3)
+	assert.ErrorIs(t, err, ErrStrategyNotFound)
+}
+
+func TestSummarizeDiagnoses(t *testing.T) {
+	closed := func(profitLoss float64, diagnoses ...models.TradeDiagnosis) *models.SimulatedTrade {
+		exitPrice, exitAt := 1.0, int64(100)
+		trade := &models.SimulatedTrade{
+			EntryPrice:    1,
+			ExitPrice:     &exitPrice,
+			ExitTimestamp: &exitAt,
+			ProfitLoss:    &profitLoss,
+			Status:        "completed",
+		}
+		if diagnoses != nil {
+			trade.PostMortem = &models.TradePostMortem{Diagnoses: diagnoses}
+		}
+		return trade
+	}
+	tight := models.TradeDiagnosis{Code: models.DiagnosisStopTooTight, Parameter: "stopLossPct"}
+	bundled := models.TradeDiagnosis{Code: models.DiagnosisBundledLaunch, Parameter: "maxBundledSupplyPct"}
+
+	summary := summarizeDiagnoses(7, []*models.SimulatedTrade{
+		closed(-0.1, tight, bundled),
+		closed(-0.2, tight, models.TradeDiagnosis{Code: models.DiagnosisStopTooTight, Parameter: "featureWindowSec"}),
+		closed(-0.3, bundled),
+		closed(0.4, tight),
+		closed(0.5),
+		{Status: "active"},
+	})
+	assert.Equal(t, int64(7), summary.StrategyID)
+	assert.Equal(t, 5, summary.ClosedTrades)
+	assert.Equal(t, 4, summary.TradesAnalyzed)
+	require.Len(t, summary.Diagnoses, 2)
+
+	assert.Equal(t, models.DiagnosisStopTooTight, summary.Diagnoses[0].Code)
+	assert.Equal(t, 3, summary.Diagnoses[0].Trades, "a trade counts once per code")
+	assert.InDelta(t, 75, summary.Diagnoses[0].SharePct, 1e-9)
+	assert.InDelta(t, 0.1, summary.Diagnoses[0].NetPnL, 1e-9)
+	assert.Equal(t, []string{"featureWindowSec", "stopLossPct"}, summary.Diagnoses[0].Parameters)
+
+	assert.Equal(t, models.DiagnosisBundledLaunch, summary.Diagnoses[1].Code)
+	assert.Equal(t, 2, summary.Diagnoses[1].Trades)
+	assert.InDelta(t, -0.4, summary.Diagnoses[1].NetPnL, 1e-9)
+
+	empty := summarizeDiagnoses(7, nil)
+	assert.NotNil(t, empty.Diagnoses)
+	assert.Zero(t, empty.TradesAnalyzed)
+}
+
+func TestPricePath(t *testing.T) {
+	trade := func(offset int64, price float64) *models.Trade {
+		return &models.Trade{SolAmount: price, TokenAmount: 1, Timestamp: 1000 + offset}
+	}
+
+	path := pricePath([]*models.Trade{
+		trade(1, 1.1), trade(4, 1.2), // first 5s slice, the last price is kept
+		trade(12, 0.8),
+		trade(13, 0),   // no price
+		trade(20, 0.9), // the end of the span joins the last slice
+	}, 1000, 20, 4, 1)
+	require.Len(t, path, 3)
+	assert.Equal(t, int64(4), path[0].OffsetSec)
+	assert.InDelta(t, 20, path[0].ChangePct, 1e-9)
+	assert.Equal(t, int64(12), path[1].OffsetSec)
+	assert.InDelta(t, -20, path[1].ChangePct, 1e-9)
+	assert.Equal(t, int64(20), path[2].OffsetSec)
+
+	assert.Empty(t, pricePath(nil, 1000, 20, 4, 1))
+	assert.Empty(t, pricePath([]*models.Trade{trade(1, 1)}, 1000, 20, 4, 0), "no entry price to compare against")
+}
@@ -0,0 +1,245 @@
+// internal/service/wallet_analytics_service.go
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/repository"
+)
+
+const (
+	// smartWalletListSize is how many top-ranked wallets count as smart money
+	smartWalletListSize = 200
+
+	// smartWalletMinPositions is the number of closed positions needed before a wallet is ranked
+	smartWalletMinPositions = 5
+
+	// walletClosedFraction is the share of bought tokens that must be sold for a position
+	// to count as closed
+	walletClosedFraction = 0.9
+
+	// walletRefreshInterval is how often recently active wallets are re-scored
+	walletRefreshInterval = 10 * time.Minute
+
+	// walletBatchSize is the number of wallets whose positions are loaded per query
+	walletBatchSize = 200
+)
+
+// SmartWalletProvider reports whether a wallet is on the smart-money list
+type SmartWalletProvider interface {
+	IsSmartWallet(address string) bool
+}
+
+// WalletAnalyticsService reconstructs realized PnL and hit rate per wallet from the trades
+// table and maintains the ranked smart-wallet list
+type WalletAnalyticsService struct {
+	statsRepo    repository.WalletStatsRepositoryInterface
+	logger       *logger.Logger
+	mu           sync.RWMutex
+	smartWallets map[string]bool
+}
+
+// NewWalletAnalyticsService creates a new wallet analytics service
+func NewWalletAnalyticsService(statsRepo repository.WalletStatsRepositoryInterface, logger *logger.Logger) *WalletAnalyticsService {
+	return &WalletAnalyticsService{
+		statsRepo:    statsRepo,
+		logger:       logger,
+		smartWallets: make(map[string]bool),
+	}
+}
+
+// IsSmartWallet implements SmartWalletProvider
+func (s *WalletAnalyticsService) IsSmartWallet(address string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.smartWallets[address]
+}
+
+// GetSmartWallets returns the ranked smart-wallet list
+func (s *WalletAnalyticsService) GetSmartWallets(limit int) ([]*models.WalletStats, error) {
+	wallets, err := s.statsRepo.GetSmartWallets(limit)
+	if err != nil {
+		return nil, err
+	}
+	if wallets == nil {
+		wallets = []*models.WalletStats{}
+	}
+	return wallets, nil
+}
+
+// GetWalletStats returns the stats of a wallet, or nil if it has never been scored
+func (s *WalletAnalyticsService) GetWalletStats(address string) (*models.WalletStats, error) {
+	return s.statsRepo.GetByAddress(address)
+}
+
+// Start loads the current smart-wallet list and periodically re-scores wallets that traded
+// since the previous run
+func (s *WalletAnalyticsService) Start(ctx context.Context) {
+	if err := s.reloadSmartWallets(); err != nil {
+		s.logger.Error("Error loading smart wallets: %v", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(walletRefreshInterval)
+		defer ticker.Stop()
+
+		since := time.Now().Add(-walletRefreshInterval).Unix()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("Wallet analytics service stopped")
+				return
+			case now := <-ticker.C:
+				count, err := s.Refresh(ctx, since)
+				if err != nil {
+					s.logger.Error("Error refreshing wallet stats: %v", err)
+					continue
+				}
+				since = now.Unix()
+				s.logger.Info("Re-scored %d wallets, %d smart wallets tracked", count, s.smartWalletCount())
+			}
+		}
+	}()
+}
+
+// Refresh re-scores every wallet that traded at or after sinceSec, re-ranks all wallets
+// and reloads the smart-wallet list. Returns the number of wallets scored.
+func (s *WalletAnalyticsService) Refresh(ctx context.Context, sinceSec int64) (int, error) {
+	scored := 0
+	after := ""
+	for {
+		if ctx.Err() != nil {
+			return scored, ctx.Err()
+		}
+
+		addresses, err := s.statsRepo.GetActiveWallets(sinceSec, after, walletBatchSize)
+		if err != nil {
+			return scored, err
+		}
+		if len(addresses) == 0 {
+			break
+		}
+
+		positions, err := s.statsRepo.GetPositions(addresses)
+		if err != nil {
+			return scored, err
+		}
+
+		byWallet := make(map[string][]*models.WalletPosition, len(addresses))
+		for _, position := range positions {
+			byWallet[position.WalletAddress] = append(byWallet[position.WalletAddress], position)
+		}
+
+		stats := make([]*models.WalletStats, 0, len(byWallet))
+		for address, walletPositions := range byWallet {
+			stats = append(stats, BuildWalletStats(address, walletPositions))
+		}
+		if err := s.statsRepo.UpsertBatch(stats); err != nil {
+			return scored, err
+		}
+		scored += len(stats)
+
+		if len(addresses) < walletBatchSize {
+			break
+		}
+		after = addresses[len(addresses)-1]
+	}
+
+	if err := s.statsRepo.UpdateRanks(smartWalletListSize); err != nil {
+		return scored, err
+	}
+
+	return scored, s.reloadSmartWallets()
+}
+
+// reloadSmartWallets replaces the in-memory smart-wallet set from the database
+func (s *WalletAnalyticsService) reloadSmartWallets() error {
+	wallets, err := s.statsRepo.GetSmartWallets(smartWalletListSize)
+	if err != nil {
+		return err
+	}
+
+	smart := make(map[string]bool, len(wallets))
+	for _, wallet := range wallets {
+		smart[wallet.WalletAddress] = true
+	}
+
+	s.mu.Lock()
+	s.smartWallets = smart
+	s.mu.Unlock()
+
+	return nil
+}
+
+// smartWalletCount returns the size of the in-memory smart-wallet set
+func (s *WalletAnalyticsService) smartWalletCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.smartWallets)
+}
+
+// BuildWalletStats computes realized performance for a wallet from its per-token positions.
+// Realized PnL uses the average buy cost of the tokens sold. Tokens the wallet created are
+// left out so creators dumping their own launches don't rank as smart money.
+func BuildWalletStats(address string, positions []*models.WalletPosition) *models.WalletStats {
+	stats := &models.WalletStats{WalletAddress: address}
+
+	for _, position := range positions {
+		stats.BuyCount += position.BuyCount
+		stats.SellCount += position.SellCount
+		stats.VolumeSol += position.BuySol + position.SellSol
+		if position.LastTradeAt > stats.LastTradeAt {
+			stats.LastTradeAt = position.LastTradeAt
+		}
+
+		if position.IsCreator {
+			continue
+		}
+		stats.TokensTraded++
+
+		// Sells without a recorded buy (bought before collection started) can't be costed
+		if position.BuyTokens <= 0 || position.SellTokens <= 0 {
+			continue
+		}
+
+		soldTokens := position.SellTokens
+		if soldTokens > position.BuyTokens {
+			soldTokens = position.BuyTokens
+		}
+		avgCost := position.BuySol / position.BuyTokens
+		proceeds := position.SellSol * soldTokens / position.SellTokens
+		pnl := proceeds - soldTokens*avgCost
+		stats.RealizedPnlSol += pnl
+
+		if position.SellTokens >= position.BuyTokens*walletClosedFraction {
+			stats.ClosedPositions++
+			if pnl > 0 {
+				stats.WinningPositions++
+			}
+		}
+	}
+	stats.TradeCount = stats.BuyCount + stats.SellCount
+
+	if stats.ClosedPositions > 0 {
+		stats.HitRate = float64(stats.WinningPositions) / float64(stats.ClosedPositions)
+	}
+	stats.SmartScore = walletSmartScore(stats)
+
+	return stats
+}
+
+// walletSmartScore ranks profitable wallets by hit rate, shrunk towards zero for small
+// samples. Unprofitable or thinly traded wallets score 0 and are never ranked.
+func walletSmartScore(stats *models.WalletStats) float64 {
+	if stats.ClosedPositions < smartWalletMinPositions || stats.RealizedPnlSol <= 0 {
+		return 0
+	}
+
+	closed := float64(stats.ClosedPositions)
+	confidence := closed / (closed + 10)
+	return 100 * stats.HitRate * confidence
+}
@@ -0,0 +1,101 @@
+// internal/service/wallet_analytics_service_test.go
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StratWarsAI/strategy-wars/internal/models"
+	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSmartWallets is a fixed smart-wallet set
+type fakeSmartWallets map[string]bool
+
+func (f fakeSmartWallets) IsSmartWallet(address string) bool {
+	return f[address]
+}
+
+func TestBuildWalletStats(t *testing.T) {
+	positions := []*models.WalletPosition{
+		// Bought 1000 for 1, sold all for 2: +1 win
+		{WalletAddress: "w1", TokenID: 1, BuySol: 1, BuyTokens: 1000, SellSol: 2, SellTokens: 1000, BuyCount: 1, SellCount: 1, LastTradeAt: 100},
+		// Bought 1000 for 2, sold all for 1: -1 loss
+		{WalletAddress: "w1", TokenID: 2, BuySol: 2, BuyTokens: 1000, SellSol: 1, SellTokens: 1000, BuyCount: 2, SellCount: 1, LastTradeAt: 200},
+		// Sold half: realized +0.5 on half the bag, still open
+		{WalletAddress: "w1", TokenID: 3, BuySol: 1, BuyTokens: 1000, SellSol: 1, SellTokens: 500, BuyCount: 1, SellCount: 1, LastTradeAt: 150},
+		// Own launch: ignored for PnL
+		{WalletAddress: "w1", TokenID: 4, BuySol: 1, BuyTokens: 1000, SellSol: 10, SellTokens: 1000, BuyCount: 1, SellCount: 1, LastTradeAt: 50, IsCreator: true},
+	}
+
+	stats := BuildWalletStats("w1", positions)
+
+	assert.Equal(t, 9, stats.TradeCount)
+	assert.Equal(t, 3, stats.TokensTraded)
+	assert.Equal(t, 2, stats.ClosedPositions)
+	assert.Equal(t, 1, stats.WinningPositions)
+	assert.Equal(t, 0.5, stats.HitRate)
+	assert.InDelta(t, 0.5, stats.RealizedPnlSol, 1e-9)
+	assert.Equal(t, int64(200), stats.LastTradeAt)
+	// Too few closed positions to be ranked
+	assert.Equal(t, 0.0, stats.SmartScore)
+}
+
+func TestWalletSmartScore(t *testing.T) {
+	var positions []*models.WalletPosition
+	for i := 0; i < 10; i++ {
+		sellSol := 2.0
+		if i >= 8 {
+			sellSol = 0.5
+		}
+		positions = append(positions, &models.WalletPosition{
+			WalletAddress: "w2", TokenID: int64(i + 1),
+			BuySol: 1, BuyTokens: 1000, SellSol: sellSol, SellTokens: 1000,
+			BuyCount: 1, SellCount: 1,
+		})
+	}
+
+	stats := BuildWalletStats("w2", positions)
+
+	assert.Equal(t, 0.8, stats.HitRate)
+	assert.InDelta(t, 40.0, stats.SmartScore, 1e-9) // 100 * 0.8 * 10/20
+}
+
+func TestAnalyzeEntrySignalSmartMoney(t *testing.T) {
+	s := &SimulationService{
+		logger:       logger.New("test"),
+		smartWallets: fakeSmartWallets{"smart1": true, "smart2": true},
+	}
+	ctx := &SimulationContext{Config: models.StrategyConfig{
+		EntryTimeWindowSec: 60,
+		EntrySignalType:    models.EntrySignalSmartMoney,
+		MinSmartWalletBuys: 2,
+	}}
+
+	now := time.Now().Unix()
+	trades := []*models.Trade{
+		{UserAddress: "smart1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 5},
+		{UserAddress: "smart1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 4},
+		{UserAddress: "retail", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 3},
+		{UserAddress: "smart2", IsBuy: false, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 2},
+	}
+
+	entry, data := s.analyzeEntrySignal(ctx, &models.Token{}, trades, nil, nil, nil, nil)
+	assert.False(t, entry)
+	assert.Equal(t, 1, data["smart_wallet_buys"])
+
+	// A second smart wallet buying inside the window triggers the entry
+	trades = append(trades, &models.Trade{UserAddress: "smart2", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 1})
+	entry, data = s.analyzeEntrySignal(ctx, &models.Token{}, trades, nil, nil, nil, nil)
+	assert.True(t, entry)
+	assert.Equal(t, []string{"smart1", "smart2"}, data["smart_wallets"])
+
+	// Smart buys outside the window don't count
+	old := []*models.Trade{
+		{UserAddress: "smart1", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 120},
+		{UserAddress: "smart2", IsBuy: true, SolAmount: 1, TokenAmount: 1000, Timestamp: now - 1},
+	}
+	entry, _ = s.analyzeEntrySignal(ctx, &models.Token{}, old, nil, nil, nil, nil)
+	assert.False(t, entry)
+}
@@ -12,32 +12,81 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// EventMessage is a Socket.IO event other than trade and token creation that refers to a token
+type EventMessage struct {
+	Event string
+	Data  map[string]interface{}
+}
+
+const (
+	// defaultPingInterval is how often the client pings the upstream
+	defaultPingInterval = 30 * time.Second
+
+	// defaultReadTimeout is how long the upstream may stay silent, pongs included,
+	// before the connection is considered dead
+	defaultReadTimeout = 90 * time.Second
+
+	// defaultReconnectDelay is the first delay before reconnecting after a lost connection
+	defaultReconnectDelay = 5 * time.Second
+)
+
 // Client represents a WebSocket client for Pump.fun
 type Client struct {
-	URL            string
-	Conn           *websocket.Conn
-	Logger         *logger.Logger
-	TokenChannel   chan map[string]interface{}
-	TradeChannel   chan map[string]interface{}
-	done           chan struct{}
-	reconnectDelay time.Duration
-	mu             sync.Mutex
-	isConnected    bool
+	URL                string
+	Conn               *websocket.Conn
+	Logger             *logger.Logger
+	TokenChannel       chan map[string]interface{}
+	TradeChannel       chan map[string]interface{}
+	UpdateChannel      chan EventMessage
+	StatusChannel      chan ConnectionEvent
+	done               chan struct{}
+	reconnectDelay     time.Duration
+	baseReconnectDelay time.Duration
+	pingInterval       time.Duration
+	readTimeout        time.Duration
+	mu                 sync.Mutex
+	isConnected        bool
+	lastMessageAt      time.Time
+	connectedAt        time.Time
+	disconnectedAt     time.Time
+	counters           feedCounters
 }
 
 // NewClient creates a new WebSocket client
 func NewClient(url string, logger *logger.Logger) *Client {
 	return &Client{
-		URL:            url,
-		Logger:         logger,
-		TokenChannel:   make(chan map[string]interface{}, 100),
-		TradeChannel:   make(chan map[string]interface{}, 100),
-		done:           make(chan struct{}),
-		reconnectDelay: 5 * time.Second,
-		isConnected:    false,
+		URL:                url,
+		Logger:             logger,
+		TokenChannel:       make(chan map[string]interface{}, 100),
+		TradeChannel:       make(chan map[string]interface{}, 100),
+		UpdateChannel:      make(chan EventMessage, 100),
+		StatusChannel:      make(chan ConnectionEvent, 10),
+		done:               make(chan struct{}),
+		reconnectDelay:     defaultReconnectDelay,
+		baseReconnectDelay: defaultReconnectDelay,
+		pingInterval:       defaultPingInterval,
+		readTimeout:        defaultReadTimeout,
+		isConnected:        false,
 	}
 }
 
+// SetHeartbeat sets how often the upstream is pinged and how long it may stay silent
+// before the client reconnects; call it before Listen
+func (c *Client) SetHeartbeat(pingInterval, readTimeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pingInterval = pingInterval
+	c.readTimeout = readTimeout
+}
+
+// SetReconnectDelay sets the first delay before reconnecting; it doubles on every failed attempt
+func (c *Client) SetReconnectDelay(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reconnectDelay = delay
+	c.baseReconnectDelay = delay
+}
+
 // Connect establishes a WebSocket connection
 func (c *Client) Connect() error {
 	c.mu.Lock()
@@ -56,6 +105,15 @@ func (c *Client) Connect() error {
 	}
 	c.Conn = conn
 
+	// Any frame or pong within the read timeout keeps the connection alive
+	readTimeout := c.readTimeout
+	if err := conn.SetReadDeadline(time.Now().Add(readTimeout)); err != nil {
+		c.Logger.Error("SetReadDeadline error: %v", err)
+	}
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
 	// Send the Socket.IO handshake
 	err = conn.WriteMessage(websocket.TextMessage, []byte("40"))
 	if err != nil {
@@ -67,6 +125,14 @@ func (c *Client) Connect() error {
 	}
 
 	c.isConnected = true
+	c.connectedAt = time.Now()
+	if !c.disconnectedAt.IsZero() {
+		c.counters.reconnects.Add(1)
+		c.emitConnectionEvent(ConnectionEvent{
+			Type: ConnectionEventReconnected,
+			Time: c.connectedAt,
+		})
+	}
 	c.Logger.Info("Connected to WebSocket successfully")
 	return nil
 }
@@ -76,8 +142,11 @@ func (c *Client) Listen() {
 	c.Logger.Info("Starting WebSocket listener")
 
 	// Ping loop
+	c.mu.Lock()
+	pingInterval, readTimeout := c.pingInterval, c.readTimeout
+	c.mu.Unlock()
 	go func() {
-		ticker := time.NewTicker(30 * time.Second)
+		ticker := time.NewTicker(pingInterval)
 		defer ticker.Stop()
 
 		for {
@@ -87,17 +156,16 @@ func (c *Client) Listen() {
 				if c.isConnected && c.Conn != nil {
 					if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 						c.Logger.Error("WebSocket ping error: %v", err)
-						c.isConnected = false
+						c.markDisconnected(fmt.Sprintf("ping error: %v", err))
 
 						// Properly handle connection closure
 						if closeErr := c.Conn.Close(); closeErr != nil {
 							c.Logger.Error("Error closing connection: %v", closeErr)
 						}
+						c.Conn = nil
 
-						c.mu.Unlock()
-						// Try to reconnect
+						// Try to reconnect; the ping loop keeps running for the new connection
 						go c.reconnect()
-						return
 					}
 				}
 				c.mu.Unlock()
@@ -126,23 +194,30 @@ func (c *Client) Listen() {
 			if err != nil {
 				c.Logger.Error("WebSocket read error: %v", err)
 				c.mu.Lock()
-				c.isConnected = false
-				if c.Conn != nil {
-					// Properly handle connection closure
-					if closeErr := c.Conn.Close(); closeErr != nil {
-						c.Logger.Error("Error closing connection: %v", closeErr)
-					}
-					c.Conn = nil
+				if c.Conn != conn {
+					// The ping loop already dropped this connection and is reconnecting
+					c.mu.Unlock()
+					continue
 				}
+				if c.isConnected {
+					c.markDisconnected(fmt.Sprintf("read error: %v", err))
+				}
+				// Properly handle connection closure
+				if closeErr := c.Conn.Close(); closeErr != nil {
+					c.Logger.Error("Error closing connection: %v", closeErr)
+				}
+				c.Conn = nil
 				c.mu.Unlock()
 				// Try to reconnect
 				go c.reconnect()
-				// Wait a bit before trying to read again
-				time.Sleep(time.Second)
 				continue
 			}
+			if err := conn.SetReadDeadline(time.Now().Add(readTimeout)); err != nil {
+				c.Logger.Error("SetReadDeadline error: %v", err)
+			}
 
 			// Process the message
+			c.recordMessage()
 			c.processMessage(message)
 		}
 	}
@@ -204,7 +279,7 @@ func (c *Client) reconnect() {
 	}
 
 	c.mu.Lock()
-	c.reconnectDelay = 5 * time.Second // Reset the delay
+	c.reconnectDelay = c.baseReconnectDelay // Reset the delay
 	c.mu.Unlock()
 }
 
@@ -212,6 +287,12 @@ func (c *Client) reconnect() {
 func (c *Client) processMessage(message []byte) {
 	messageStr := string(message)
 
+	// Answer Engine.IO pings so the upstream doesn't time the connection out
+	if messageStr == "2" {
+		c.sendPong()
+		return
+	}
+
 	// Skip non-data messages
 	if len(messageStr) < 2 || !strings.HasPrefix(messageStr, "42") {
 		return
@@ -246,6 +327,7 @@ func (c *Client) processMessage(message []byte) {
 	switch eventType {
 	case "tradeCreated":
 		c.Logger.Debug("Received trade event: %s", eventType)
+		c.counters.trades.Add(1)
 		select {
 		case c.TradeChannel <- dataMap:
 			// Successfully sent to channel
@@ -255,6 +337,7 @@ func (c *Client) processMessage(message []byte) {
 
 	case "tokenCreated":
 		c.Logger.Debug("Received token event: %s", eventType)
+		c.counters.tokens.Add(1)
 		select {
 		case c.TokenChannel <- dataMap:
 			// Successfully sent to channel
@@ -263,6 +346,31 @@ func (c *Client) processMessage(message []byte) {
 		}
 
 	default:
-		c.Logger.Debug("Received other event: %s", eventType)
+		// Lifecycle updates such as completion or king of the hill arrive as other events
+		if _, ok := dataMap["mint"].(string); !ok {
+			c.Logger.Debug("Received other event: %s", eventType)
+			return
+		}
+		c.Logger.Debug("Received token update event: %s", eventType)
+		c.counters.updates.Add(1)
+		select {
+		case c.UpdateChannel <- EventMessage{Event: eventType, Data: dataMap}:
+			// Successfully sent to channel
+		default:
+			c.Logger.Warn("Update channel full, dropping %s message", eventType)
+		}
+	}
+}
+
+// sendPong answers an Engine.IO ping
+func (c *Client) sendPong() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Conn == nil {
+		return
+	}
+	if err := c.Conn.WriteMessage(websocket.TextMessage, []byte("3")); err != nil {
+		c.Logger.Error("Engine.IO pong error: %v", err)
 	}
 }
@@ -42,9 +42,10 @@ func NewClientWSHandler(hub *WSHub, logger *logger.Logger) *ClientWSHandler {
 func (h *ClientWSHandler) ServeWS() fiber.Handler {
 	return websocket.New(func(c *websocket.Conn) {
 		client := &WSClient{
-			hub:  h.hub,
-			conn: c,
-			send: make(chan []byte, 256),
+			hub:    h.hub,
+			conn:   c,
+			send:   make(chan []byte, 256),
+			topics: make(map[string]bool),
 		}
 
 		client.hub.register <- client
@@ -196,10 +197,8 @@ func (h *ClientWSHandler) handleClientMessage(client *WSClient, message []byte)
 
 // handleSubscribe handles subscription requests
 func (h *ClientWSHandler) handleSubscribe(client *WSClient, data map[string]interface{}) {
-	// In a real implementation, you'd store subscription info for each client
-	// and only send them relevant updates
-
-	// This is a simple acknowledgment
+	// Strategy subscriptions are only acknowledged; topic subscriptions are stored
+	// on the client so the hub can route topic broadcasts
 	response := map[string]interface{}{
 		"type":    "subscribe_ack",
 		"success": true,
@@ -210,6 +209,13 @@ func (h *ClientWSHandler) handleSubscribe(client *WSClient, data map[string]inte
 		h.logger.Info("Client subscribed to strategy %d", int64(strategyID))
 	}
 
+	// Topic subscriptions, e.g. "candles:<mint>:<interval>"
+	if topic, ok := data["topic"].(string); ok && topic != "" {
+		client.subscribe(topic)
+		response["topic"] = topic
+		h.logger.Info("Client subscribed to topic %s", topic)
+	}
+
 	// Send acknowledgment
 	jsonResponse, _ := json.Marshal(response)
 	client.send <- jsonResponse
@@ -217,9 +223,6 @@ func (h *ClientWSHandler) handleSubscribe(client *WSClient, data map[string]inte
 
 // handleUnsubscribe handles un subscription requests
 func (h *ClientWSHandler) handleUnsubscribe(client *WSClient, data map[string]interface{}) {
-	// In a real implementation, you'd remove the subscription
-
-	// This is a simple acknowledgment
 	response := map[string]interface{}{
 		"type":    "unsubscribe_ack",
 		"success": true,
@@ -230,6 +233,12 @@ func (h *ClientWSHandler) handleUnsubscribe(client *WSClient, data map[string]in
 		h.logger.Info("Client unsubscribed from strategy %d", int64(strategyID))
 	}
 
+	if topic, ok := data["topic"].(string); ok && topic != "" {
+		client.unsubscribe(topic)
+		response["topic"] = topic
+		h.logger.Info("Client unsubscribed from topic %s", topic)
+	}
+
 	// Send acknowledgment
 	jsonResponse, _ := json.Marshal(response)
 	client.send <- jsonResponse
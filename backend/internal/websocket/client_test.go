@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/StratWarsAI/strategy-wars/internal/pkg/logger"
+	"github.com/StratWarsAI/strategy-wars/internal/websocket/wstest"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
@@ -289,3 +290,137 @@ func TestClientListen(t *testing.T) {
 	assert.GreaterOrEqual(t, receivedTradeCount, 1, "Should receive at least one trade message")
 	mu.Unlock()
 }
+
+// startTestClient connects a client with short heartbeat and reconnect settings to a fake upstream
+func startTestClient(t *testing.T, upstream *wstest.Server) *Client {
+	client := NewClient(upstream.URL, logger.New("test"))
+	client.SetHeartbeat(50*time.Millisecond, 300*time.Millisecond)
+	client.SetReconnectDelay(20 * time.Millisecond)
+
+	assert.NoError(t, client.Connect())
+	go client.Listen()
+	assert.True(t, upstream.WaitForHandshakes(1, 2*time.Second), "client should complete the handshake")
+	return client
+}
+
+// receive waits for a message on a channel
+func receive[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+	var zero T
+	return zero
+}
+
+func TestClientRoutesUpstreamEvents(t *testing.T) {
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+	client := startTestClient(t, upstream)
+	defer client.Close()
+
+	_, err := upstream.Emit("tokenCreated", map[string]interface{}{"mint": "mint1", "creator": "creator1"})
+	assert.NoError(t, err)
+	_, err = upstream.Emit("tradeCreated", map[string]interface{}{"mint": "mint1", "signature": "sig1"})
+	assert.NoError(t, err)
+	_, err = upstream.Emit("tokenCompleted", map[string]interface{}{"mint": "mint1", "complete": true})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "creator1", receive(t, client.TokenChannel)["creator"])
+	assert.Equal(t, "sig1", receive(t, client.TradeChannel)["signature"])
+	update := receive(t, client.UpdateChannel)
+	assert.Equal(t, "tokenCompleted", update.Event)
+	assert.Equal(t, true, update.Data["complete"])
+
+	stats := client.Stats()
+	assert.True(t, stats.Connected)
+	assert.Equal(t, uint64(1), stats.Tokens)
+	assert.Equal(t, uint64(1), stats.Trades)
+	assert.Equal(t, uint64(1), stats.Updates)
+}
+
+func TestClientSkipsMalformedFrames(t *testing.T) {
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+	client := startTestClient(t, upstream)
+	defer client.Close()
+
+	for _, frame := range []string{`42["tradeCreated",{broken`, `42"tradeCreated"`, `42["tradeCreated","not an object"]`, `42[]`, `garbage`} {
+		_, err := upstream.SendRaw(frame)
+		assert.NoError(t, err)
+	}
+	_, err := upstream.Emit("tradeCreated", map[string]interface{}{"mint": "mint1", "signature": "sig1"})
+	assert.NoError(t, err)
+
+	// The valid frame after the malformed ones still arrives on the same connection
+	assert.Equal(t, "sig1", receive(t, client.TradeChannel)["signature"])
+	assert.Equal(t, 1, upstream.Handshakes())
+	assert.Empty(t, client.TradeChannel)
+}
+
+func TestClientReconnectsAfterDroppedConnection(t *testing.T) {
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+	client := startTestClient(t, upstream)
+	defer client.Close()
+
+	upstream.DropConnections()
+	assert.Equal(t, ConnectionEventDisconnected, receive(t, client.StatusChannel).Type)
+	assert.Equal(t, ConnectionEventReconnected, receive(t, client.StatusChannel).Type)
+	assert.True(t, upstream.WaitForHandshakes(2, 2*time.Second))
+	assert.True(t, upstream.WaitForConnections(1, 2*time.Second))
+
+	// Events flow again on the new connection
+	_, err := upstream.Emit("tradeCreated", map[string]interface{}{"mint": "mint1", "signature": "sig2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "sig2", receive(t, client.TradeChannel)["signature"])
+	assert.Equal(t, uint64(1), client.Stats().Reconnects)
+}
+
+func TestClientReconnectsAfterHeartbeatTimeout(t *testing.T) {
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+	client := startTestClient(t, upstream)
+	defer client.Close()
+
+	// A silent upstream that no longer answers pings is dropped after the read timeout
+	upstream.Stall()
+	event := receive(t, client.StatusChannel)
+	assert.Equal(t, ConnectionEventDisconnected, event.Type)
+	assert.Contains(t, event.Reason, "read error")
+
+	upstream.Resume()
+	assert.True(t, upstream.WaitForHandshakes(2, 2*time.Second))
+}
+
+func TestClientAnswersEngineIOPings(t *testing.T) {
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+	upstream.StartHeartbeat(30*time.Millisecond, 60*time.Millisecond)
+	client := startTestClient(t, upstream)
+	defer client.Close()
+
+	// Several heartbeat timeouts pass without the upstream dropping the client
+	time.Sleep(400 * time.Millisecond)
+	assert.Equal(t, 1, upstream.Handshakes())
+	assert.Equal(t, 1, upstream.Connections())
+	assert.True(t, client.Stats().Connected)
+}
+
+func TestClientSlowUpstream(t *testing.T) {
+	upstream := wstest.NewServer()
+	defer upstream.Close()
+	client := startTestClient(t, upstream)
+	defer client.Close()
+
+	// Frames arriving slower than the ping interval but within the read timeout are fine
+	upstream.SetWriteDelay(120 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err := upstream.Emit("tradeCreated", map[string]interface{}{"mint": "mint1", "signature": "slow"})
+		assert.NoError(t, err)
+		assert.Equal(t, "slow", receive(t, client.TradeChannel)["signature"])
+	}
+	assert.Equal(t, 1, upstream.Handshakes())
+}
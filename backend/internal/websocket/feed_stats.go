@@ -0,0 +1,88 @@
+// internal/websocket/feed_stats.go
+package websocket
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Connection event types sent on Client.StatusChannel
+const (
+	ConnectionEventDisconnected = "disconnected"
+	ConnectionEventReconnected  = "reconnected"
+)
+
+// ConnectionEvent describes a change in the upstream connection state
+type ConnectionEvent struct {
+	Type   string
+	Time   time.Time
+	Reason string
+}
+
+// FeedStats is a snapshot of the client's liveness counters
+type FeedStats struct {
+	Connected      bool
+	LastMessageAt  time.Time
+	ConnectedAt    time.Time
+	DisconnectedAt time.Time
+	Messages       uint64 // All frames received, including Socket.IO control frames
+	Trades         uint64
+	Tokens         uint64
+	Updates        uint64 // Other token events, such as completion or king of the hill
+	Reconnects     uint64
+}
+
+// feedCounters holds the counters updated on the read path
+type feedCounters struct {
+	messages   atomic.Uint64
+	trades     atomic.Uint64
+	tokens     atomic.Uint64
+	updates    atomic.Uint64
+	reconnects atomic.Uint64
+}
+
+// Stats returns a snapshot of the client's liveness counters
+func (c *Client) Stats() FeedStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return FeedStats{
+		Connected:      c.isConnected,
+		LastMessageAt:  c.lastMessageAt,
+		ConnectedAt:    c.connectedAt,
+		DisconnectedAt: c.disconnectedAt,
+		Messages:       c.counters.messages.Load(),
+		Trades:         c.counters.trades.Load(),
+		Tokens:         c.counters.tokens.Load(),
+		Updates:        c.counters.updates.Load(),
+		Reconnects:     c.counters.reconnects.Load(),
+	}
+}
+
+// recordMessage updates liveness tracking for a received frame
+func (c *Client) recordMessage() {
+	c.counters.messages.Add(1)
+	c.mu.Lock()
+	c.lastMessageAt = time.Now()
+	c.mu.Unlock()
+}
+
+// markDisconnected records a lost connection; the caller must hold c.mu
+func (c *Client) markDisconnected(reason string) {
+	c.isConnected = false
+	c.disconnectedAt = time.Now()
+	c.emitConnectionEvent(ConnectionEvent{
+		Type:   ConnectionEventDisconnected,
+		Time:   c.disconnectedAt,
+		Reason: reason,
+	})
+}
+
+// emitConnectionEvent publishes a connection event without blocking the client
+func (c *Client) emitConnectionEvent(event ConnectionEvent) {
+	select {
+	case c.StatusChannel <- event:
+	default:
+		c.Logger.Warn("Status channel full, dropping %s event", event.Type)
+	}
+}
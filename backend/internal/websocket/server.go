@@ -12,9 +12,35 @@ import (
 
 // WSClient represents a WebSocket client connected to our server
 type WSClient struct {
-	hub  *WSHub
-	conn *websocket.Conn
-	send chan []byte
+	hub    *WSHub
+	conn   *websocket.Conn
+	send   chan []byte
+	topics map[string]bool
+	mu     sync.RWMutex
+}
+
+// subscribe adds a topic to the client's subscriptions
+func (c *WSClient) subscribe(topic string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.topics == nil {
+		c.topics = make(map[string]bool)
+	}
+	c.topics[topic] = true
+}
+
+// unsubscribe removes a topic from the client's subscriptions
+func (c *WSClient) unsubscribe(topic string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.topics, topic)
+}
+
+// isSubscribed reports whether the client is subscribed to a topic
+func (c *WSClient) isSubscribed(topic string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.topics[topic]
 }
 
 // WSHub maintains the set of active WebSocket clients
@@ -92,6 +118,29 @@ func (h *WSHub) BroadcastJSON(v interface{}) {
 	h.broadcast <- jsonData
 }
 
+// BroadcastTopic sends a JSON message only to clients subscribed to the topic.
+// Slow clients miss topic updates rather than being disconnected.
+func (h *WSHub) BroadcastTopic(topic string, v interface{}) {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		h.logger.Error("Error marshaling JSON for topic %s: %v", topic, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.clients {
+		if !client.isSubscribed(topic) {
+			continue
+		}
+		select {
+		case client.send <- jsonData:
+		default:
+			h.logger.Warn("Dropping %s update for slow client", topic)
+		}
+	}
+}
+
 // writePump pumps messages from the hub to the websocket connection
 func (c *WSClient) writePump() {
 	ticker := time.NewTicker(30 * time.Second)
@@ -0,0 +1,299 @@
+// internal/websocket/wstest/server.go
+
+// Package wstest provides a fake pump.fun Socket.IO upstream for tests. It performs the
+// Engine.IO open and Socket.IO connect handshake, emits events as 42["event",{...}] frames
+// and can inject faults: dropped connections, malformed frames, slow writes, stalls that
+// trip the client's heartbeat and Engine.IO ping timeouts.
+package wstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// serverConn is one connected client
+type serverConn struct {
+	ws       *websocket.Conn
+	writeMu  sync.Mutex
+	lastPong time.Time
+}
+
+// write sends a text frame, serialized with other writers of the connection
+func (c *serverConn) write(frame string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ws.WriteMessage(websocket.TextMessage, []byte(frame))
+}
+
+// Server is a fake pump.fun Socket.IO upstream
+type Server struct {
+	URL string // ws:// URL to pass to websocket.NewClient
+
+	server     *httptest.Server
+	mu         sync.Mutex
+	conns      map[*serverConn]bool
+	handshakes int
+	changed    chan struct{} // Closed and replaced whenever handshakes or conns change
+	writeDelay time.Duration
+	stalled    bool
+	closed     bool
+	stop       chan struct{}
+}
+
+// NewServer starts a fake upstream; call Close when done
+func NewServer() *Server {
+	s := &Server{
+		conns:   make(map[*serverConn]bool),
+		changed: make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = "ws" + strings.TrimPrefix(s.server.URL, "http")
+	return s
+}
+
+// Close disconnects every client and stops the server
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.stop)
+	s.mu.Unlock()
+
+	s.DropConnections()
+	s.server.Close()
+}
+
+// Emit sends a Socket.IO event to every connected client and returns how many received it
+func (s *Server) Emit(event string, data map[string]interface{}) (int, error) {
+	payload, err := json.Marshal([]interface{}{event, data})
+	if err != nil {
+		return 0, fmt.Errorf("error encoding %s event: %v", event, err)
+	}
+	return s.SendRaw("42" + string(payload))
+}
+
+// SendRaw sends a frame as is, e.g. a malformed one, to every connected client and returns
+// how many received it. Frames are delayed by the write delay, if any.
+func (s *Server) SendRaw(frame string) (int, error) {
+	s.mu.Lock()
+	delay := s.writeDelay
+	conns := s.connList()
+	s.mu.Unlock()
+
+	if len(conns) == 0 {
+		return 0, fmt.Errorf("no connected clients")
+	}
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+
+	sent := 0
+	for _, conn := range conns {
+		if err := conn.write(frame); err == nil {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// DropConnections closes every client connection without a close frame, as a network
+// failure would
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	conns := s.connList()
+	s.mu.Unlock()
+
+	for _, conn := range conns {
+		_ = conn.ws.UnderlyingConn().Close()
+	}
+}
+
+// SetWriteDelay delays every frame sent by Emit and SendRaw, simulating a slow upstream
+func (s *Server) SetWriteDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeDelay = delay
+}
+
+// Stall makes the server stop answering pings and sending heartbeats while keeping
+// connections open, so clients only notice through their read timeout
+func (s *Server) Stall() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stalled = true
+}
+
+// Resume undoes Stall
+func (s *Server) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stalled = false
+	for conn := range s.conns {
+		conn.lastPong = time.Now()
+	}
+}
+
+// StartHeartbeat sends Engine.IO pings ("2") every interval and drops clients that don't
+// answer with a pong ("3") within timeout
+func (s *Server) StartHeartbeat(interval, timeout time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-ticker.C:
+				s.mu.Lock()
+				conns := s.connList()
+				stalled := s.stalled
+				s.mu.Unlock()
+				if stalled {
+					continue
+				}
+
+				for _, conn := range conns {
+					s.mu.Lock()
+					lastPong := conn.lastPong
+					s.mu.Unlock()
+					if now.Sub(lastPong) > timeout+interval {
+						_ = conn.ws.UnderlyingConn().Close()
+						continue
+					}
+					_ = conn.write("2")
+				}
+			}
+		}
+	}()
+}
+
+// Connections returns the number of connected clients
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// Handshakes returns the number of Socket.IO handshakes completed, reconnects included
+func (s *Server) Handshakes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.handshakes
+}
+
+// WaitForHandshakes waits until at least n handshakes have completed
+func (s *Server) WaitForHandshakes(n int, timeout time.Duration) bool {
+	return s.waitFor(func() bool { return s.handshakes >= n }, timeout)
+}
+
+// WaitForConnections waits until exactly n clients are connected
+func (s *Server) WaitForConnections(n int, timeout time.Duration) bool {
+	return s.waitFor(func() bool { return len(s.conns) == n }, timeout)
+}
+
+// waitFor waits until cond, evaluated with s.mu held, is true
+func (s *Server) waitFor(cond func() bool, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		if cond() {
+			s.mu.Unlock()
+			return true
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// handle upgrades a connection and runs the Engine.IO / Socket.IO handshake
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	conn := &serverConn{ws: ws, lastPong: time.Now()}
+
+	// Answer websocket pings unless stalled
+	ws.SetPingHandler(func(data string) error {
+		s.mu.Lock()
+		stalled := s.stalled
+		s.mu.Unlock()
+		if stalled {
+			return nil
+		}
+		conn.writeMu.Lock()
+		defer conn.writeMu.Unlock()
+		return ws.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.notifyLocked()
+		s.mu.Unlock()
+		_ = ws.Close()
+	}()
+
+	// Engine.IO open packet
+	if err := conn.write(`0{"sid":"wstest","upgrades":[],"pingInterval":25000,"pingTimeout":20000}`); err != nil {
+		return
+	}
+
+	for {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		switch string(message) {
+		case "40":
+			// Socket.IO connect; the client only receives events once connected
+			if err := conn.write(`40{"sid":"wstest"}`); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns[conn] = true
+			s.handshakes++
+			s.notifyLocked()
+			s.mu.Unlock()
+		case "3":
+			s.mu.Lock()
+			conn.lastPong = time.Now()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// connList returns the connected clients; the caller must hold s.mu
+func (s *Server) connList() []*serverConn {
+	conns := make([]*serverConn, 0, len(s.conns))
+	for conn := range s.conns {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// notifyLocked wakes up waiters; the caller must hold s.mu
+func (s *Server) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -1,5 +1,43 @@
 -- Migration Down Script
 
+-- Drop Strategy Candidates Table Indexes
+DROP INDEX IF EXISTS idx_strategy_candidates_status_created;
+
+-- Drop AI Usage Table Indexes
+DROP INDEX IF EXISTS idx_ai_usage_purpose_created;
+
+-- Drop AI Generation Failures Table Indexes
+DROP INDEX IF EXISTS idx_ai_generation_failures_created;
+
+-- Drop Token Feature Snapshots Table Indexes
+DROP INDEX IF EXISTS idx_token_feature_snapshots_created;
+DROP INDEX IF EXISTS idx_token_feature_snapshots_token;
+DROP INDEX IF EXISTS idx_token_feature_snapshots_run;
+
+-- Drop Token Transitions Table Indexes
+DROP INDEX IF EXISTS idx_token_transitions_token;
+
+-- Drop Token Anomalies Table Indexes
+DROP INDEX IF EXISTS idx_token_anomalies_score;
+
+-- Drop Launch Analyses Table Indexes
+DROP INDEX IF EXISTS idx_launch_analyses_bundled;
+DROP INDEX IF EXISTS idx_tokens_created_timestamp;
+
+-- Drop Wallet Stats Table Indexes
+DROP INDEX IF EXISTS idx_wallet_stats_rank;
+DROP INDEX IF EXISTS idx_trades_user_timestamp;
+
+-- Drop Creator Profiles Table Indexes
+DROP INDEX IF EXISTS idx_creator_profiles_score;
+DROP INDEX IF EXISTS idx_trades_token_user;
+
+-- Drop Data Gaps Table Indexes
+DROP INDEX IF EXISTS idx_data_gaps_source_time;
+
+-- Drop Feed Metrics Table Indexes
+DROP INDEX IF EXISTS idx_feed_metrics_source_period;
+
 -- Drop Strategy Generations Table Indexes
 DROP INDEX IF EXISTS idx_strategy_generations_parent;
 DROP INDEX IF EXISTS idx_strategy_generations_child;
@@ -51,8 +89,25 @@ DROP INDEX IF EXISTS idx_strategies_name;
 DROP INDEX IF EXISTS idx_strategies_ai_enhanced;
 DROP INDEX IF EXISTS idx_strategies_complexity;
 DROP INDEX IF EXISTS idx_strategies_risk;
+DROP INDEX IF EXISTS idx_strategies_prompt;
+DROP INDEX IF EXISTS idx_strategies_source;
 
 -- Drop tables (in reverse order of creation to handle dependencies)
+DROP TABLE IF EXISTS strategy_drafts;
+DROP TABLE IF EXISTS strategy_candidates;
+DROP TABLE IF EXISTS ai_usage;
+DROP TABLE IF EXISTS ai_generation_failures;
+DROP TABLE IF EXISTS entry_models;
+DROP TABLE IF EXISTS token_outcomes;
+DROP TABLE IF EXISTS token_feature_snapshots;
+DROP TABLE IF EXISTS token_transitions;
+DROP TABLE IF EXISTS token_anomalies;
+DROP TABLE IF EXISTS launch_analyses;
+DROP TABLE IF EXISTS wallet_stats;
+DROP TABLE IF EXISTS creator_profiles;
+DROP TABLE IF EXISTS data_gaps;
+DROP TABLE IF EXISTS feed_metrics;
+DROP TABLE IF EXISTS candles;
 DROP TABLE IF EXISTS strategy_generations;
 DROP TABLE IF EXISTS simulation_results;
 DROP TABLE IF EXISTS strategy_metrics;
@@ -15,6 +15,9 @@ CREATE TABLE IF NOT EXISTS strategies (
     complexity_score INTEGER DEFAULT 5,
     risk_score INTEGER DEFAULT 5,
     ai_enhanced BOOLEAN DEFAULT TRUE,
+    prompt_template VARCHAR(100) NOT NULL DEFAULT '', -- Prompt template an AI strategy was generated from
+    prompt_version INTEGER NOT NULL DEFAULT 0,
+    source VARCHAR(20) NOT NULL DEFAULT 'user', -- 'user', 'ai', 'genetic'
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -80,6 +83,8 @@ CREATE TABLE IF NOT EXISTS simulated_trades (
     exit_reason TEXT,
     entry_usd_market_cap DECIMAL(20, 9) DEFAULT 0,
     exit_usd_market_cap DECIMAL(20, 9) DEFAULT 0,
+    model_version VARCHAR(120), -- Entry model that scored the entry, as name@vN
+    post_mortem JSONB, -- AI diagnosis of the closed trade
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -113,6 +118,8 @@ CREATE TABLE IF NOT EXISTS simulation_results (
     max_drawdown DECIMAL(10, 2),
     performance_rating TEXT, -- 'excellent', 'good', 'average', 'poor', 'very_poor'
     analysis TEXT,
+    prompt_template VARCHAR(100) NOT NULL DEFAULT '', -- Prompt template the analysis was generated from
+    prompt_version INTEGER NOT NULL DEFAULT 0,
     rank INTEGER,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -138,11 +145,255 @@ CREATE TABLE IF NOT EXISTS strategy_generations (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Create candles table
+CREATE TABLE IF NOT EXISTS candles (
+    id SERIAL PRIMARY KEY,
+    token_id INTEGER NOT NULL REFERENCES tokens(id),
+    interval_sec INTEGER NOT NULL,
+    bucket_start BIGINT NOT NULL,
+    open DOUBLE PRECISION NOT NULL,
+    high DOUBLE PRECISION NOT NULL,
+    low DOUBLE PRECISION NOT NULL,
+    close DOUBLE PRECISION NOT NULL,
+    volume_sol DOUBLE PRECISION NOT NULL DEFAULT 0,
+    buy_volume_sol DOUBLE PRECISION NOT NULL DEFAULT 0,
+    sell_volume_sol DOUBLE PRECISION NOT NULL DEFAULT 0,
+    trade_count INTEGER NOT NULL DEFAULT 0,
+    buy_count INTEGER NOT NULL DEFAULT 0,
+    sell_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (token_id, interval_sec, bucket_start)
+);
+
+-- Create feed_metrics table
+CREATE TABLE IF NOT EXISTS feed_metrics (
+    id SERIAL PRIMARY KEY,
+    source TEXT NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    message_count BIGINT NOT NULL DEFAULT 0,
+    trade_count BIGINT NOT NULL DEFAULT 0,
+    token_count BIGINT NOT NULL DEFAULT 0,
+    connected BOOLEAN NOT NULL DEFAULT FALSE,
+    last_message_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create data_gaps table
+CREATE TABLE IF NOT EXISTS data_gaps (
+    id SERIAL PRIMARY KEY,
+    source TEXT NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    duration_sec DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create creator_profiles table
+CREATE TABLE IF NOT EXISTS creator_profiles (
+    creator_address TEXT PRIMARY KEY,
+    tokens_launched INTEGER NOT NULL DEFAULT 0,
+    tokens_graduated INTEGER NOT NULL DEFAULT 0,
+    graduation_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    koth_count INTEGER NOT NULL DEFAULT 0,
+    avg_time_to_koth_sec DOUBLE PRECISION NOT NULL DEFAULT 0,
+    creator_sell_count INTEGER NOT NULL DEFAULT 0,
+    avg_time_to_sell_sec DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fast_dump_count INTEGER NOT NULL DEFAULT 0,
+    avg_peak_usd_market_cap DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_peak_usd_market_cap DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reputation_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    first_launch_at BIGINT,
+    last_launch_at BIGINT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create wallet_stats table
+CREATE TABLE IF NOT EXISTS wallet_stats (
+    wallet_address TEXT PRIMARY KEY,
+    trade_count INTEGER NOT NULL DEFAULT 0,
+    buy_count INTEGER NOT NULL DEFAULT 0,
+    sell_count INTEGER NOT NULL DEFAULT 0,
+    tokens_traded INTEGER NOT NULL DEFAULT 0,
+    closed_positions INTEGER NOT NULL DEFAULT 0,
+    winning_positions INTEGER NOT NULL DEFAULT 0,
+    hit_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    realized_pnl_sol DOUBLE PRECISION NOT NULL DEFAULT 0,
+    volume_sol DOUBLE PRECISION NOT NULL DEFAULT 0,
+    smart_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rank INTEGER NOT NULL DEFAULT 0,
+    is_smart BOOLEAN NOT NULL DEFAULT FALSE,
+    last_trade_at BIGINT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create launch_analyses table
+CREATE TABLE IF NOT EXISTS launch_analyses (
+    token_id INTEGER PRIMARY KEY REFERENCES tokens(id),
+    window_sec INTEGER NOT NULL,
+    early_buy_count INTEGER NOT NULL DEFAULT 0,
+    early_buyer_count INTEGER NOT NULL DEFAULT 0,
+    bundled_wallet_count INTEGER NOT NULL DEFAULT 0,
+    sniper_count INTEGER NOT NULL DEFAULT 0,
+    fresh_wallet_count INTEGER NOT NULL DEFAULT 0,
+    bundled_supply_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    sniper_supply_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    buyers JSONB NOT NULL DEFAULT '[]',
+    analyzed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create token_anomalies table
+CREATE TABLE IF NOT EXISTS token_anomalies (
+    token_id INTEGER PRIMARY KEY REFERENCES tokens(id),
+    trade_count INTEGER NOT NULL DEFAULT 0,
+    buy_count INTEGER NOT NULL DEFAULT 0,
+    self_trade_count INTEGER NOT NULL DEFAULT 0,
+    round_trip_wallets INTEGER NOT NULL DEFAULT 0,
+    dust_buy_count INTEGER NOT NULL DEFAULT 0,
+    dust_threshold_sol DOUBLE PRECISION NOT NULL DEFAULT 0,
+    periodic_wallets INTEGER NOT NULL DEFAULT 0,
+    flagged_wallet_count INTEGER NOT NULL DEFAULT 0,
+    flagged_buy_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    flagged_volume_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    anomaly_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    flagged_wallets JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create token_transitions table
+CREATE TABLE IF NOT EXISTS token_transitions (
+    id SERIAL PRIMARY KEY,
+    token_id INTEGER NOT NULL REFERENCES tokens(id),
+    from_state VARCHAR(20) NOT NULL DEFAULT '',
+    to_state VARCHAR(20) NOT NULL,
+    timestamp BIGINT NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create token_feature_snapshots table
+CREATE TABLE IF NOT EXISTS token_feature_snapshots (
+    id SERIAL PRIMARY KEY,
+    token_id INTEGER NOT NULL REFERENCES tokens(id),
+    strategy_id INTEGER REFERENCES strategies(id),
+    simulation_run_id INTEGER REFERENCES simulation_runs(id),
+    simulated_trade_id INTEGER REFERENCES simulated_trades(id),
+    decision VARCHAR(20) NOT NULL,
+    features JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create token_outcomes table
+CREATE TABLE IF NOT EXISTS token_outcomes (
+    token_id INTEGER PRIMARY KEY REFERENCES tokens(id),
+    launch_timestamp BIGINT NOT NULL,
+    base_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    trade_count INTEGER NOT NULL DEFAULT 0,
+    max_return_1m_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_return_5m_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_return_30m_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_drawdown_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    graduated BOOLEAN NOT NULL DEFAULT FALSE,
+    time_to_graduate_sec BIGINT,
+    labeled_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create entry_models table
+CREATE TABLE IF NOT EXISTS entry_models (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    version INTEGER NOT NULL,
+    model_type VARCHAR(50) NOT NULL,
+    target VARCHAR(100) NOT NULL,
+    artifact JSONB NOT NULL,
+    metrics JSONB NOT NULL DEFAULT '{}',
+    trained_rows INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (name, version)
+);
+
+-- Create ai_generation_failures table
+CREATE TABLE IF NOT EXISTS ai_generation_failures (
+    id SERIAL PRIMARY KEY,
+    purpose VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100),
+    attempts INTEGER NOT NULL,
+    errors JSONB NOT NULL DEFAULT '[]',
+    last_response TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create ai_usage table
+CREATE TABLE IF NOT EXISTS ai_usage (
+    id SERIAL PRIMARY KEY,
+    purpose VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100),
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    outcome VARCHAR(20) NOT NULL,
+    error TEXT,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create strategy_candidates table
+CREATE TABLE IF NOT EXISTS strategy_candidates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    config JSONB NOT NULL,
+    purpose VARCHAR(50) NOT NULL,
+    prompt_template VARCHAR(100) NOT NULL DEFAULT '',
+    prompt_version INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    reasons JSONB NOT NULL DEFAULT '[]',
+    backtest JSONB,
+    strategy_id INTEGER REFERENCES strategies(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create strategy_drafts table
+CREATE TABLE IF NOT EXISTS strategy_drafts (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    config JSONB NOT NULL,
+    explanations JSONB NOT NULL DEFAULT '[]',
+    ambiguities JSONB NOT NULL DEFAULT '[]',
+    instructions JSONB NOT NULL DEFAULT '[]',
+    revision INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL,
+    strategy_id INTEGER REFERENCES strategies(id) ON DELETE SET NULL,
+    prompt_template VARCHAR(100) NOT NULL DEFAULT '',
+    prompt_version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Add columns to tables created before they existed
+ALTER TABLE simulated_trades ADD COLUMN IF NOT EXISTS model_version VARCHAR(120);
+ALTER TABLE strategies ADD COLUMN IF NOT EXISTS prompt_template VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE strategies ADD COLUMN IF NOT EXISTS prompt_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE simulation_results ADD COLUMN IF NOT EXISTS prompt_template VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE simulation_results ADD COLUMN IF NOT EXISTS prompt_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE simulated_trades ADD COLUMN IF NOT EXISTS post_mortem JSONB;
+ALTER TABLE strategies ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Strategies saved before they had a source are told apart by the tags they were saved with
+UPDATE strategies SET source = 'genetic' WHERE source = 'user' AND 'genetic' = ANY(tags);
+UPDATE strategies SET source = 'ai' WHERE source = 'user' AND tags && ARRAY['ai-generated', 'ai-drafted'];
+
 -- Strategies Table Indexes
 CREATE INDEX IF NOT EXISTS idx_strategies_name ON strategies(name);
 CREATE INDEX IF NOT EXISTS idx_strategies_ai_enhanced ON strategies(ai_enhanced);
 CREATE INDEX IF NOT EXISTS idx_strategies_complexity ON strategies(complexity_score);
 CREATE INDEX IF NOT EXISTS idx_strategies_risk ON strategies(risk_score);
+CREATE INDEX IF NOT EXISTS idx_strategies_prompt ON strategies(prompt_template, prompt_version);
+CREATE INDEX IF NOT EXISTS idx_strategies_source ON strategies(source);
 
 -- Simulation Runs Table Indexes
 CREATE INDEX IF NOT EXISTS idx_simulation_runs_status ON simulation_runs(status);
@@ -189,3 +440,47 @@ CREATE INDEX IF NOT EXISTS idx_simulation_events_timestamp ON simulation_events(
 CREATE INDEX IF NOT EXISTS idx_strategy_generations_parent ON strategy_generations(parent_strategy_id);
 CREATE INDEX IF NOT EXISTS idx_strategy_generations_child ON strategy_generations(child_strategy_id);
 CREATE INDEX IF NOT EXISTS idx_strategy_generations_number ON strategy_generations(generation_number);
+
+-- Candles Table Indexes
+-- Candles are read through the index of their UNIQUE (token_id, interval_sec, bucket_start)
+-- constraint; drop the duplicate earlier migrations created
+DROP INDEX IF EXISTS idx_candles_token_interval_bucket;
+
+-- Feed Metrics Table Indexes
+CREATE INDEX IF NOT EXISTS idx_feed_metrics_source_period ON feed_metrics(source, period_end);
+
+-- Data Gaps Table Indexes
+CREATE INDEX IF NOT EXISTS idx_data_gaps_source_time ON data_gaps(source, started_at, ended_at);
+
+-- Creator Profiles Table Indexes
+CREATE INDEX IF NOT EXISTS idx_creator_profiles_score ON creator_profiles(reputation_score);
+CREATE INDEX IF NOT EXISTS idx_trades_token_user ON trades(token_id, user_address);
+
+-- Wallet Stats Table Indexes
+CREATE INDEX IF NOT EXISTS idx_wallet_stats_rank ON wallet_stats(is_smart, rank);
+CREATE INDEX IF NOT EXISTS idx_trades_user_timestamp ON trades(user_address, timestamp);
+
+-- Launch Analyses Table Indexes
+CREATE INDEX IF NOT EXISTS idx_launch_analyses_bundled ON launch_analyses(bundled_supply_pct);
+CREATE INDEX IF NOT EXISTS idx_tokens_created_timestamp ON tokens(created_timestamp);
+
+-- Token Anomalies Table Indexes
+CREATE INDEX IF NOT EXISTS idx_token_anomalies_score ON token_anomalies(anomaly_score);
+
+-- Token Transitions Table Indexes
+CREATE INDEX IF NOT EXISTS idx_token_transitions_token ON token_transitions(token_id, id);
+
+-- Token Feature Snapshots Table Indexes
+CREATE INDEX IF NOT EXISTS idx_token_feature_snapshots_token ON token_feature_snapshots(token_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_token_feature_snapshots_run ON token_feature_snapshots(simulation_run_id);
+-- Labeled snapshots are read by creation time and joined to token_outcomes on its primary key
+CREATE INDEX IF NOT EXISTS idx_token_feature_snapshots_created ON token_feature_snapshots(created_at);
+
+-- AI Generation Failures Table Indexes
+CREATE INDEX IF NOT EXISTS idx_ai_generation_failures_created ON ai_generation_failures(created_at);
+
+-- AI Usage Table Indexes
+CREATE INDEX IF NOT EXISTS idx_ai_usage_purpose_created ON ai_usage(purpose, created_at);
+
+-- Strategy Candidates Table Indexes
+CREATE INDEX IF NOT EXISTS idx_strategy_candidates_status_created ON strategy_candidates(status, created_at);